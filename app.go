@@ -1,8 +1,15 @@
 package gogpu
 
 import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gogpu/gogpu/gpu"
 	"github.com/gogpu/gogpu/internal/platform"
 )
 
@@ -13,16 +20,115 @@ type App struct {
 	platform platform.Platform
 	renderer *Renderer
 
+	// How the backend was chosen, kept when starting fails
+	backendReport *BackendReport
+
 	// User callbacks
-	onDraw   func(*Context)
-	onUpdate func(float64) // delta time in seconds
-	onResize func(int, int)
+	onDraw          func(*Context)
+	onUpdate        func(float64) // delta time in seconds
+	onFixedUpdate   func(float64) // fixed time step in seconds
+	onResize        func(int, int)
+	onPen           func(PenEvent)
+	onTouch         func(TouchEvent)
+	onKey           func(KeyEvent)
+	onMouseButton   func(MouseEvent)
+	onMouseMove     func(MouseEvent)
+	onScroll        func(MouseEvent)
+	onChar          func(rune)
+	onGesture       func(GestureEvent)
+	onFileDrop      func(paths []string, x, y int)
+	onTextInput     func(TextInputEvent)
+	onWindowState   func(WindowState)
+	onFocusChanged  func(focused bool)
+	onMinimized     func()
+	onRestored      func()
+	onVisibility    func(visible bool)
+	onMonitors      func()
+	onTheme         func(dark bool)
+	onScale         func(scale float64)
+	onGamepad       func(slot int, connected bool)
+	onGPUError      func(err error)
+	onError         func(err error)
+	onMemoryWarning func(stats gpu.MemoryStats)
+	onLeaks         func(leaks []gpu.Leak)
+
+	onDeviceLost     func(reason DeviceLostReason, message string)
+	onDeviceRestored func(r *Renderer)
+
+	onFileDragEnter func(x, y int)
+	onFileDragMove  func(x, y int)
+	onFileDragLeave func()
+
+	// Window icon applied once the platform is initialized
+	icon *image.RGBA
+
+	// Cursor mode of the main window set with SetCursorMode
+	cursorMode CursorMode
+
+	// Custom menus added before Run, and callbacks keyed by menu item ID
+	menus       []Menu
+	menuActions map[int]func()
+	nextMenuID  int
+
+	// Keyboard, mouse and gamepad state returned by Input
+	input inputState
+
+	// Events collected for Events, in applications started with Start,
+	// and the slice returned by the last Events call
+	queueEvents bool
+	queued      []Event
+	events      []Event
+
+	// Additional windows opened with NewWindow
+	windows map[platform.WindowID]*Window
 
 	// State
 	running   bool
 	lastFrame time.Time
+
+	// First callback panic, which stopped the main loop
+	panicked *PanicError
+
+	// Frame rate cap and the FrameStats of the main window
+	limiter frameLimiter
+	timing  frameTiming
+
+	// Time not yet simulated by OnFixedUpdate
+	fixed fixedStep
+
+	// Main window state reported by the platform; rendering pauses
+	// while the window cannot be seen
+	minimized bool
+	occluded  bool
+
+	// The system is asleep; after waking, or after a display
+	// reconfiguration, the surface is reset before the next frame
+	asleep       bool
+	surfaceStale bool
+
+	// Size of the last resize applied to the renderer. Guarded by sizeMu:
+	// with Config.RenderThread it is written by the render goroutine.
+	sizeMu        sync.Mutex
+	width, height int
+
+	// Hand-off to the render goroutine while Config.RenderThread is in effect
+	renderThread *renderThread
+
+	// Frame requested in event-driven mode, by events or RequestRedraw
+	redraw atomic.Bool
 }
 
+// pausedFrameInterval is the main loop period while rendering is paused.
+const pausedFrameInterval = 16 * time.Millisecond
+
+// hiddenWaitInterval is the longest wait between event checks while the
+// main loop is paused with BackgroundPause.
+const hiddenWaitInterval = 100 * time.Millisecond
+
+// idleWaitInterval is the longest wait for events in event-driven mode
+// on platforms whose wait RequestRedraw can end.
+const idleWaitInterval = time.Second
+
 // NewApp creates a new application with the given configuration.
 func NewApp(config Config) *App {
 	return &App{
@@ -51,100 +157,419 @@ func (a *App) OnResize(fn func(width, height int)) *App {
 }
 
 // Run starts the application main loop.
-// This function blocks until the application quits.
-func (a *App) Run() error {
-	// Initialize platform (window)
-	a.platform = platform.New()
-	if err := a.platform.Init(platform.Config{
-		Title:      a.config.Title,
-		Width:      a.config.Width,
-		Height:     a.config.Height,
-		Resizable:  a.config.Resizable,
-		Fullscreen: a.config.Fullscreen,
-	}); err != nil {
+// This function blocks until the application quits. If a callback
+// panicked, it returns the *PanicError, else the error of writing
+// Config.TraceFile, if any; see OnError.
+func (a *App) Run() (err error) {
+	if err := a.start(); err != nil {
 		return err
 	}
-	defer a.platform.Destroy()
+	defer func() {
+		err = a.shutdown()
+	}()
 
-	// Initialize renderer with selected backend
-	var err error
-	a.renderer, err = newRenderer(a.platform, a.config.Backend)
-	if err != nil {
-		return err
+	// Main loop
+	pacer, _ := a.platform.(platform.FramePacer)
+
+	if waiter, ok := a.platform.(platform.EventWaiter); ok && a.config.RenderThread && !a.config.EventDriven {
+		a.runThreaded(waiter, pacer)
+		return nil
 	}
-	defer a.renderer.Destroy()
 
-	// Main loop
-	a.running = true
-	a.lastFrame = time.Now()
+	if resizer, ok := a.platform.(platform.LiveResizer); ok && a.config.RenderDuringResize {
+		resizer.SetLiveResizeHandler(a.liveResize)
+	}
 
 	for a.running && !a.platform.ShouldClose() {
+		// Wait for the display refresh if pacing is enabled
+		if pacer != nil {
+			pacer.WaitFrame()
+		}
+
+		// Release per-frame platform resources at the end of the frame
+		end := a.beginFrameScope()
+
+		// In event-driven mode, sleep until there is something to draw
+		if a.config.EventDriven && !a.redraw.Load() {
+			a.waitEvents(a.idleWaitTimeout())
+		}
+
 		// Process platform events
-		a.processEvents()
+		if a.processEvents() {
+			a.redraw.Store(true)
+		}
+
+		if a.config.EventDriven && !a.redraw.Swap(false) {
+			end()
+			continue
+		}
+
+		if a.config.Background == BackgroundPause && a.hidden() {
+			a.waitHidden()
+			end()
+			continue
+		}
+
+		// Wait until the next frame is due with Config.TargetFPS
+		a.limiter.wait()
 
 		// Calculate delta time
 		now := time.Now()
-		deltaTime := now.Sub(a.lastFrame).Seconds()
+		elapsed := now.Sub(a.lastFrame)
 		a.lastFrame = now
+		a.input.beginFrame()
 
-		// Call update callback
-		if a.onUpdate != nil {
-			a.onUpdate(deltaTime)
-		}
+		// Call update callbacks
+		a.update(elapsed)
 
 		// Render frame
-		a.renderFrame()
+		a.renderFrame(a.onDraw)
+		a.drawWindows()
+
+		end()
+	}
+
+	return nil
+}
+
+// start opens the main window and creates the renderer, before the main
+// loop of Run or an application loop started with Start. If it fails,
+// nothing is left open.
+func (a *App) start() error {
+	if a.config.Headless && (a.config.Width <= 0 || a.config.Height <= 0) {
+		return fmt.Errorf("gogpu: invalid headless size %dx%d", a.config.Width, a.config.Height)
+	}
+
+	// Initialize platform (window)
+	if a.config.Headless {
+		a.platform = platform.NewHeadless()
+	} else {
+		a.platform = platform.New()
+	}
+	if err := a.platform.Init(platform.Config{
+		Title:         a.config.Title,
+		Width:         a.config.Width,
+		Height:        a.config.Height,
+		Resizable:     a.config.Resizable,
+		Fullscreen:    a.config.Fullscreen,
+		Transparent:   a.config.Transparent,
+		Titlebar:      a.config.Titlebar,
+		PaceToDisplay: a.config.PaceToDisplay,
+		ContentType:   a.config.ContentType,
+	}); err != nil {
+		return err
+	}
+
+	if a.icon != nil {
+		_ = a.applyIcon() // Non-fatal: icons are cosmetic
+	}
+
+	for _, menu := range a.menus {
+		_ = a.applyMenu(menu) // Non-fatal: the app works without custom menus
 	}
+	a.menus = nil
 
+	// Initialize renderer with selected backend. Headless renderers have
+	// no surface.
+	surfacePlatform := a.platform
+	if a.config.Headless {
+		surfacePlatform = nil
+	}
+	var err error
+	a.renderer, err = newRenderer(surfacePlatform, a.config)
+	if err != nil {
+		a.backendReport = backendReportOf(err)
+		a.platform.Destroy()
+		return err
+	}
+	a.backendReport = a.renderer.BackendReport()
+	a.renderer.SetErrorHandler(a.handleGPUError)
+	a.renderer.SetMemoryWarningHandler(a.handleMemoryWarning)
+	a.renderer.SetLeakHandler(a.handleLeaks)
+	a.sizeMu.Lock()
+	a.width, a.height = a.platform.GetSize()
+	a.sizeMu.Unlock()
+
+	a.running = true
+	a.lastFrame = time.Now()
+	a.limiter = newFrameLimiter(a.config.TargetFPS)
 	return nil
 }
 
-// processEvents handles platform events.
-func (a *App) processEvents() {
+// stop closes the additional windows, the renderer and the main window,
+// and returns the error of writing the trace file, if any.
+func (a *App) stop() error {
+	a.closeWindows()
+	a.renderer.Destroy()
+	a.platform.Destroy()
+	return a.renderer.TraceErr()
+}
+
+// shutdown stops the app, then reports a callback panic and the error of
+// writing the trace file to OnError. It returns the panic, else the
+// trace error, or nil.
+func (a *App) shutdown() error {
+	traceErr := a.stop()
+	err := a.reportPanic()
+	if traceErr != nil {
+		a.reportError(traceErr)
+		if err == nil {
+			err = traceErr
+		}
+	}
+	return err
+}
+
+// beginFrameScope opens a frame scope if the platform needs one (macOS
+// autorelease pools) and returns the function that closes it.
+func (a *App) beginFrameScope() (end func()) {
+	if scoper, ok := a.platform.(platform.FrameScoper); ok {
+		return scoper.BeginFrameScope()
+	}
+	return func() {}
+}
+
+// processEvents handles platform events and reports whether there were any.
+func (a *App) processEvents() (handled bool) {
 	for {
 		event := a.platform.PollEvents()
 		if event.Type == platform.EventNone {
-			break
+			if reader, ok := a.platform.(platform.GamepadReader); ok {
+				a.input.sampleGamepads(reader)
+			}
+			return handled
+		}
+		handled = true
+
+		if a.queueEvents {
+			a.queueEvent(event)
+		}
+
+		// Resize and close of additional windows must not affect the main window
+		if event.Window != platform.MainWindow &&
+			(event.Type == platform.EventResize || event.Type == platform.EventClose) {
+			a.handleWindowEvent(event)
+			continue
 		}
 
 		switch event.Type {
 		case platform.EventResize:
-			a.renderer.Resize(event.Width, event.Height)
-			if a.onResize != nil {
-				a.onResize(event.Width, event.Height)
+			if a.renderThread != nil {
+				a.renderThread.resize(event.Width, event.Height)
+			} else {
+				a.resize(event.Width, event.Height)
 			}
 		case platform.EventClose:
 			a.running = false
+		case platform.EventPen:
+			if a.onPen != nil {
+				a.onPen(event.Pen)
+			}
+		case platform.EventKey, platform.EventMouseButton,
+			platform.EventMouseMove, platform.EventScroll:
+			a.handleInputEvent(event)
+		case platform.EventTouch:
+			if a.onTouch != nil {
+				a.onTouch(event.Touch)
+			}
+		case platform.EventGesture:
+			if a.onGesture != nil {
+				a.onGesture(event.Gesture)
+			}
+		case platform.EventDrop:
+			if event.Window == platform.MainWindow {
+				a.handleDrop(event.Drop)
+			}
+		case platform.EventMenu:
+			a.handleMenu(event.MenuID)
+		case platform.EventWindowState:
+			if event.Window == platform.MainWindow {
+				if event.State == platform.WindowUnfocused {
+					a.input.releaseAll()
+				}
+				a.handleWindowState(event.State)
+			}
+		case platform.EventText:
+			if event.Window == platform.MainWindow {
+				if a.onTextInput != nil {
+					a.onTextInput(event.Text)
+				}
+				a.handleChars(event.Text)
+			}
+		case platform.EventMonitors:
+			if a.onMonitors != nil {
+				a.onMonitors()
+			}
+		case platform.EventTheme:
+			if a.onTheme != nil {
+				a.onTheme(event.Dark)
+			}
+		case platform.EventScale:
+			if a.onScale != nil {
+				a.onScale(event.Scale)
+			}
+		case platform.EventGamepad:
+			if a.onGamepad != nil {
+				a.onGamepad(event.Gamepad.Slot, event.Gamepad.Connected)
+			}
+		case platform.EventSleep:
+			visible := !a.hidden()
+			a.asleep = true
+			a.visibilityChanged(visible)
+		case platform.EventWake:
+			visible := !a.hidden()
+			a.asleep = false
+			a.surfaceStale = true
+			a.visibilityChanged(visible)
+		case platform.EventDisplayReconfigured:
+			a.surfaceStale = true
 		}
 	}
 }
 
-// renderFrame renders a single frame.
-func (a *App) renderFrame() {
+// renderFrame renders a single frame with draw.
+func (a *App) renderFrame(draw func(*Context)) {
+	// Skip rendering while nobody can see the result. Without a frame
+	// to present nothing blocks the loop, so throttle it instead.
+	if a.hidden() {
+		time.Sleep(pausedFrameInterval)
+		return
+	}
+
+	if a.surfaceStale {
+		a.renderer.ResetSurface()
+		a.surfaceStale = false
+	}
+
 	// Skip rendering if window is minimized (zero dimensions)
 	width, height := a.platform.GetSize()
 	if width <= 0 || height <= 0 {
 		return // Window minimized, skip frame
 	}
 
+	a.drawFrame(draw)
+}
+
+// hidden reports whether nobody can see the main window.
+func (a *App) hidden() bool {
+	return a.minimized || a.occluded || a.asleep
+}
+
+// waitHidden blocks for up to hiddenWaitInterval while the main window is
+// hidden with BackgroundPause, returning early on platform events where
+// the platform can wait for them. The pause is left out of the next delta
+// time.
+func (a *App) waitHidden() {
+	a.waitEvents(hiddenWaitInterval)
+	a.lastFrame = time.Now()
+}
+
+// waitEvents blocks until platform events arrive or the timeout expires,
+// or sleeps for the timeout if the platform cannot wait for events.
+func (a *App) waitEvents(timeout time.Duration) {
+	if waiter, ok := a.platform.(platform.EventWaiter); ok {
+		waiter.WaitEvents(timeout)
+		return
+	}
+	time.Sleep(timeout)
+}
+
+// idleWaitTimeout returns how long the event-driven loop waits for events
+// at a time. RequestRedraw from another goroutine ends the wait early only
+// where the platform can be woken; elsewhere the wait is kept short.
+func (a *App) idleWaitTimeout() time.Duration {
+	if _, ok := a.platform.(platform.EventWaker); ok {
+		return idleWaitInterval
+	}
+	return eventWaitTimeout
+}
+
+// RequestRedraw asks for OnUpdate and OnDraw to run again in event-driven
+// mode (Config.EventDriven), e.g. while an animation plays or when a
+// background task has new results. Call it from OnDraw to keep drawing
+// continuously. Safe to call from any goroutine. It has no effect in the
+// default mode, which draws continuously anyway.
+func (a *App) RequestRedraw() {
+	a.redraw.Store(true)
+	if waker, ok := a.platform.(platform.EventWaker); ok {
+		waker.WakeEvents()
+	}
+}
+
+// drawFrame acquires a frame, calls draw and presents it.
+// It does not call platform methods, so it is safe from live resize handlers.
+func (a *App) drawFrame(draw func(*Context)) {
+	if !a.checkDevice() {
+		return // Device lost and not restored yet
+	}
+
 	// Acquire frame
+	start := time.Now()
 	if !a.renderer.BeginFrame() {
 		return // Frame not available
 	}
+	acquired := time.Now()
 
-	// Create context and call draw callback
-	if a.onDraw != nil {
+	a.timing.frame++
+	a.renderer.startFrameTimer(a.timing.frame)
+
+	// Create context and call draw callback. The frame is not presented
+	// after a panic.
+	if draw != nil {
 		ctx := newContext(a.renderer)
-		a.onDraw(ctx)
+		ctx.stats = a.timing.last
+		ctx.alpha = a.fixed.alpha
+		if !a.guard("OnDraw", func() { draw(ctx) }) {
+			return
+		}
 	}
 
+	a.renderer.stopFrameTimer()
+	drawn := time.Now()
+
 	// Present frame
 	a.renderer.EndFrame()
+	presented := time.Now()
+
+	// Run callbacks of completed GPU work, see OnSubmissionDone
+	a.renderer.Poll(false)
+
+	a.timing.end(a.renderer, start, acquired, drawn, presented)
+}
+
+// resize applies a new main window size to the renderer and calls
+// OnResize. Sizes already applied during a live resize are skipped.
+func (a *App) resize(width, height int) {
+	a.sizeMu.Lock()
+	unchanged := width == a.width && height == a.height
+	a.width, a.height = width, height
+	a.sizeMu.Unlock()
+	if unchanged {
+		return
+	}
+
+	a.renderer.Resize(width, height)
+	if a.onResize != nil {
+		a.guard("OnResize", func() { a.onResize(width, height) })
+	}
+}
+
+// liveResize renders a frame at the new size while the platform is
+// blocked in an interactive resize. See Config.RenderDuringResize.
+func (a *App) liveResize(width, height int) {
+	a.resize(width, height)
+	if width > 0 && height > 0 {
+		a.drawFrame(a.onDraw)
+	}
 }
 
 // Quit requests the application to quit.
 // The main loop will exit after completing the current frame.
 func (a *App) Quit() {
+	if a.renderThread != nil {
+		a.renderThread.requestQuit()
+		return
+	}
 	a.running = false
 }
 
@@ -156,7 +581,122 @@ func (a *App) Size() (width, height int) {
 	return a.config.Width, a.config.Height
 }
 
+// SetWindowIcon sets the window icon.
+// It may be called before Run; the icon is applied once the window exists.
+// Returns ErrPlatformNotSupported if the platform cannot change the icon.
+func (a *App) SetWindowIcon(img image.Image) error {
+	a.icon = packedRGBA(img)
+
+	if a.platform == nil {
+		return nil
+	}
+	return a.applyIcon()
+}
+
+// packedRGBA returns img as RGBA pixels without row padding, as
+// expected by the platform, converting or copying it if needed.
+func packedRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba, ok := img.(*image.RGBA)
+	if !ok || rgba.Stride != bounds.Dx()*4 || len(rgba.Pix) != bounds.Dx()*bounds.Dy()*4 {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+	return rgba
+}
+
+// applyIcon passes the stored icon to the platform.
+func (a *App) applyIcon() error {
+	bounds := a.icon.Bounds()
+	return platformError(a.platform.SetIcon(bounds.Dx(), bounds.Dy(), a.icon.Pix))
+}
+
+// ResizeEdge identifies the window edge or corner grabbed by BeginWindowResize.
+type ResizeEdge = platform.ResizeEdge
+
+// Resize edges for BeginWindowResize.
+const (
+	ResizeEdgeTop         = platform.ResizeEdgeTop
+	ResizeEdgeBottom      = platform.ResizeEdgeBottom
+	ResizeEdgeLeft        = platform.ResizeEdgeLeft
+	ResizeEdgeRight       = platform.ResizeEdgeRight
+	ResizeEdgeTopLeft     = platform.ResizeEdgeTopLeft
+	ResizeEdgeTopRight    = platform.ResizeEdgeTopRight
+	ResizeEdgeBottomLeft  = platform.ResizeEdgeBottomLeft
+	ResizeEdgeBottomRight = platform.ResizeEdgeBottomRight
+)
+
+// BeginWindowMove starts an interactive, system-driven window move.
+// Call it while the mouse button that started the drag is still held,
+// e.g. when the user presses on a custom-drawn title bar.
+func (a *App) BeginWindowMove() error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	return platformError(a.platform.BeginMove())
+}
+
+// BeginWindowResize starts an interactive, system-driven window resize
+// from the given edge. Same requirements as BeginWindowMove.
+func (a *App) BeginWindowResize(edge ResizeEdge) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	return platformError(a.platform.BeginResize(edge))
+}
+
+// ShowWindowMenu shows the system window menu at (x, y) in window coordinates.
+// Typically called on a right click on a custom title bar.
+func (a *App) ShowWindowMenu(x, y int) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	return platformError(a.platform.ShowWindowMenu(x, y))
+}
+
+// SetFullscreen switches the main window between windowed and fullscreen.
+// On macOS this is native fullscreen in its own Space; the transition is
+// animated and ends with a WindowEnteredFullscreen or WindowExitedFullscreen
+// state, after OnResize has reported the new size. On Windows it is
+// borderless fullscreen on the window's monitor, which switches at once.
+// Returns ErrPlatformNotSupported if the platform cannot toggle fullscreen
+// at runtime; Config.Fullscreen still applies at startup.
+func (a *App) SetFullscreen(fullscreen bool) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	f, ok := a.platform.(platform.Fullscreener)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	return platformError(f.SetFullscreen(fullscreen))
+}
+
+// IsFullscreen reports whether the main window is fullscreen.
+func (a *App) IsFullscreen() bool {
+	if f, ok := a.platform.(platform.Fullscreener); ok {
+		return f.IsFullscreen()
+	}
+	return a.config.Fullscreen
+}
+
+// platformError maps internal platform errors to public errors.
+func platformError(err error) error {
+	if errors.Is(err, platform.ErrUnsupported) {
+		return ErrPlatformNotSupported
+	}
+	return err
+}
+
 // Config returns the application configuration.
 func (a *App) Config() Config {
 	return a.config
 }
+
+// BackendReport describes how the GPU backend was chosen: the one in use
+// and why each backend tried before it was rejected. It is also set when
+// Run fails because no backend could start, and is nil before Run.
+// Include its String form in bug reports.
+func (a *App) BackendReport() *BackendReport {
+	return a.backendReport
+}
@@ -0,0 +1,81 @@
+package gogpu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gogpu/gpu"
+)
+
+// BackendReport describes how the renderer chose its backend: the one in
+// use and why each backend, or graphics API within a backend, tried
+// before it was rejected. Its String form is meant for bug reports and
+// support tickets. See App.BackendReport and Renderer.BackendReport.
+type BackendReport struct {
+	// Selected is the name of the backend in use, as returned by
+	// Renderer.Backend, or empty if none was usable.
+	Selected string
+
+	// Rejected lists the candidates tried and rejected, in order. Graphics
+	// APIs of the native backend are named e.g. "native/vulkan".
+	Rejected []gpu.Rejection
+}
+
+// String returns the report as text, one line per candidate.
+func (r *BackendReport) String() string {
+	var sb strings.Builder
+	if r.Selected != "" {
+		fmt.Fprintf(&sb, "selected: %s\n", r.Selected)
+	} else {
+		sb.WriteString("selected: none\n")
+	}
+	for _, rej := range r.Rejected {
+		fmt.Fprintf(&sb, "rejected %s: %v\n", rej.Name, rej.Reason)
+	}
+	return sb.String()
+}
+
+// reject records that the named candidate could not be used.
+func (r *BackendReport) reject(name string, reason error) {
+	r.Rejected = append(r.Rejected, gpu.Rejection{Name: name, Reason: reason})
+}
+
+// BackendError is returned when starting fails because no backend could
+// be used. Report lists why each candidate was rejected.
+type BackendError struct {
+	Report *BackendReport
+}
+
+// Error returns the reason of the only candidate tried, or all reasons
+// when several were tried.
+func (e *BackendError) Error() string {
+	rejected := e.Report.Rejected
+	if len(rejected) == 1 {
+		return rejected[0].Reason.Error()
+	}
+	reasons := make([]string, len(rejected))
+	for i, rej := range rejected {
+		reasons[i] = fmt.Sprintf("%s: %v", rej.Name, rej.Reason)
+	}
+	return "gogpu: no usable backend (" + strings.Join(reasons, "; ") + ")"
+}
+
+// Unwrap returns the reasons the candidates were rejected.
+func (e *BackendError) Unwrap() []error {
+	errs := make([]error, len(e.Report.Rejected))
+	for i, rej := range e.Report.Rejected {
+		errs[i] = rej.Reason
+	}
+	return errs
+}
+
+// backendReportOf returns the report of a BackendError in err's chain, or
+// nil.
+func backendReportOf(err error) *BackendReport {
+	var berr *BackendError
+	if errors.As(err, &berr) {
+		return berr.Report
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package gogpu
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// BindGroupLayout is a bind group layout that remembers its entries, so
+// bind groups built for it with NewBindGroupBuilder are checked before
+// they reach the GPU.
+type BindGroupLayout struct {
+	layout types.BindGroupLayout
+	desc   types.BindGroupLayoutDescriptor
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// NewBindGroupLayout creates a bind group layout.
+func (r *Renderer) NewBindGroupLayout(desc *types.BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+	layout, err := r.backend.CreateBindGroupLayout(r.device, desc)
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create bind group layout: %w", err)
+	}
+	return &BindGroupLayout{
+		layout: layout,
+		desc: types.BindGroupLayoutDescriptor{
+			Label:   desc.Label,
+			Entries: slices.Clone(desc.Entries),
+		},
+		renderer: r,
+	}, nil
+}
+
+// Handle returns the underlying bind group layout handle, e.g. for
+// PipelineLayoutDescriptor.BindGroupLayouts.
+func (l *BindGroupLayout) Handle() types.BindGroupLayout {
+	return l.layout
+}
+
+// Destroy releases the layout. Bind groups created with it stay valid.
+func (l *BindGroupLayout) Destroy() {
+	if l.renderer == nil || l.renderer.backend == nil {
+		return
+	}
+	if l.layout != 0 {
+		l.renderer.backend.ReleaseBindGroupLayout(l.layout)
+		l.layout = 0
+	}
+}
+
+// BindGroupBuilder collects the resources of a bind group and checks them
+// against the layout in Build:
+//
+//	group, err := r.NewBindGroupBuilder(layout).
+//		Buffer(0, uniforms).
+//		Texture(1, albedo).
+//		Sampler(2, albedo.Sampler()).
+//		Build()
+//
+// A bind group that does not match its layout would otherwise only
+// produce a validation error and frames that draw nothing.
+type BindGroupBuilder struct {
+	layout    *BindGroupLayout
+	label     string
+	resources []gpu.BindingResource
+}
+
+// NewBindGroupBuilder starts a bind group for layout.
+func (r *Renderer) NewBindGroupBuilder(layout *BindGroupLayout) *BindGroupBuilder {
+	return &BindGroupBuilder{layout: layout}
+}
+
+// Label sets the debug label of the bind group.
+func (b *BindGroupBuilder) Label(label string) *BindGroupBuilder {
+	b.label = label
+	return b
+}
+
+// Buffer binds the whole of buffer.
+func (b *BindGroupBuilder) Buffer(binding uint32, buffer *Buffer) *BindGroupBuilder {
+	return b.BufferRange(binding, buffer, 0, 0)
+}
+
+// BufferRange binds size bytes of buffer at offset, or the rest of the
+// buffer if size is 0.
+func (b *BindGroupBuilder) BufferRange(binding uint32, buffer *Buffer, offset, size uint64) *BindGroupBuilder {
+	b.resources = append(b.resources, gpu.BindingResource{
+		Entry:       types.BindGroupEntry{Binding: binding, Buffer: buffer.buffer, Offset: offset, Size: size},
+		BufferSize:  buffer.size,
+		BufferUsage: buffer.usage,
+	})
+	return b
+}
+
+// Texture binds the default view of texture.
+func (b *BindGroupBuilder) Texture(binding uint32, texture *Texture) *BindGroupBuilder {
+	b.resources = append(b.resources, gpu.BindingResource{
+		Entry:         types.BindGroupEntry{Binding: binding, TextureView: texture.view},
+		TextureFormat: texture.format,
+		ViewDimension: texture.viewDimension,
+	})
+	return b
+}
+
+// TextureView binds a view created with Texture.NewView, or by the
+// backend. Its format and dimension are not checked.
+func (b *BindGroupBuilder) TextureView(binding uint32, view types.TextureView) *BindGroupBuilder {
+	b.resources = append(b.resources, gpu.BindingResource{
+		Entry: types.BindGroupEntry{Binding: binding, TextureView: view},
+	})
+	return b
+}
+
+// Sampler binds a sampler.
+func (b *BindGroupBuilder) Sampler(binding uint32, sampler types.Sampler) *BindGroupBuilder {
+	b.resources = append(b.resources, gpu.BindingResource{
+		Entry: types.BindGroupEntry{Binding: binding, Sampler: sampler},
+	})
+	return b
+}
+
+// Build checks the resources against the layout and creates the bind
+// group. Errors name the first mismatching binding and wrap
+// gpu.ErrBindGroupMismatch.
+func (b *BindGroupBuilder) Build() (types.BindGroup, error) {
+	r := b.layout.renderer
+	limits := r.Limits()
+	if err := gpu.ValidateBindGroup(&b.layout.desc, b.resources, &limits); err != nil {
+		if b.label != "" {
+			return 0, fmt.Errorf("gogpu: bind group %q: %w", b.label, err)
+		}
+		return 0, fmt.Errorf("gogpu: %w", err)
+	}
+
+	entries := make([]types.BindGroupEntry, len(b.resources))
+	for i := range b.resources {
+		entries[i] = b.resources[i].Entry
+	}
+	group, err := r.backend.CreateBindGroup(r.device, &types.BindGroupDescriptor{
+		Label:   b.label,
+		Layout:  b.layout.layout,
+		Entries: entries,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gogpu: failed to create bind group: %w", err)
+	}
+	return group, nil
+}
@@ -0,0 +1,286 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// Buffer is a GPU buffer. Create one with Renderer.NewBuffer.
+type Buffer struct {
+	buffer types.Buffer
+	size   uint64
+	usage  types.BufferUsage
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// NewBuffer creates a buffer of size bytes. Buffers that are read back
+// with MapAsync need BufferUsageMapRead, which may only be combined with
+// BufferUsageCopyDst.
+func (r *Renderer) NewBuffer(size uint64, usage types.BufferUsage) (*Buffer, error) {
+	buffer, err := r.backend.CreateBuffer(r.device, &types.BufferDescriptor{
+		Size:  size,
+		Usage: usage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create buffer: %w", err)
+	}
+
+	return &Buffer{
+		buffer:   buffer,
+		size:     size,
+		usage:    usage,
+		renderer: r,
+	}, nil
+}
+
+// Handle returns the underlying GPU buffer handle.
+// For advanced use cases that need direct GPU access.
+func (b *Buffer) Handle() types.Buffer {
+	return b.buffer
+}
+
+// Size returns the buffer size in bytes.
+func (b *Buffer) Size() uint64 {
+	return b.size
+}
+
+// Usage returns the usages the buffer was created with.
+func (b *Buffer) Usage() types.BufferUsage {
+	return b.usage
+}
+
+// Write uploads data to the buffer at offset. The buffer needs
+// BufferUsageCopyDst.
+func (b *Buffer) Write(offset uint64, data []byte) {
+	b.renderer.backend.WriteBuffer(b.renderer.queue, b.buffer, offset, data)
+}
+
+// MapAsync maps size bytes at offset for CPU access. The callback runs
+// once the GPU work using the buffer is done, at the latest during a
+// later Renderer.Poll. After a successful mapping, MappedRange returns
+// the memory until Unmap.
+func (b *Buffer) MapAsync(mode types.MapMode, offset, size uint64, callback func(err error)) {
+	b.renderer.backend.MapBufferAsync(b.renderer.device, b.buffer, mode, offset, size, callback)
+}
+
+// MappedRange returns size bytes of mapped memory at offset. The slice
+// must not be used after Unmap.
+func (b *Buffer) MappedRange(offset, size uint64) []byte {
+	return b.renderer.backend.GetMappedRange(b.buffer, offset, size)
+}
+
+// Unmap ends CPU access to the buffer so the GPU can use it again.
+func (b *Buffer) Unmap() {
+	b.renderer.backend.UnmapBuffer(b.buffer)
+}
+
+// Destroy releases the buffer. After calling Destroy, the buffer should
+// not be used.
+func (b *Buffer) Destroy() {
+	if b.renderer == nil || b.renderer.backend == nil {
+		return
+	}
+
+	if b.buffer != 0 {
+		b.renderer.backend.ReleaseBuffer(b.buffer)
+		b.buffer = 0
+	}
+}
+
+// Poll processes completed GPU work and runs pending MapAsync callbacks.
+// With wait it blocks until all submitted work is done. Returns true if
+// no work is pending.
+func (r *Renderer) Poll(wait bool) bool {
+	return r.backend.Poll(r.device, wait)
+}
+
+// CopyBufferToBuffer copies size bytes from src at srcOffset to dst at
+// dstOffset. Offsets and size must be multiples of 4. src needs
+// BufferUsageCopySrc and dst BufferUsageCopyDst.
+func (r *Renderer) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst *Buffer, dstOffset, size uint64) error {
+	if err := gpu.ValidateBufferCopy(srcOffset, dstOffset, size); err != nil {
+		return err
+	}
+	return r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.CopyBufferToBuffer(encoder, src.buffer, srcOffset, dst.buffer, dstOffset, size)
+	})
+}
+
+// CopyBufferToTexture copies size texels laid out in src as described by
+// layout into dst at origin, e.g. to stream a region of a texture atlas.
+// layout.BytesPerRow must be a multiple of 256. src needs
+// BufferUsageCopySrc and dst TextureUsageCopyDst.
+func (r *Renderer) CopyBufferToTexture(src *Buffer, layout types.ImageDataLayout, dst *Texture, origin types.Origin3D, size types.Extent3D) error {
+	if err := gpu.ValidateImageCopyBuffer(&layout, &size); err != nil {
+		return err
+	}
+	return r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.CopyBufferToTexture(encoder,
+			&types.ImageCopyBuffer{Buffer: src.buffer, Layout: layout},
+			&types.ImageCopyTexture{Texture: dst.texture, Origin: origin, Aspect: types.TextureAspectAll},
+			&size,
+		)
+	})
+}
+
+// CopyTextureToTexture copies size texels of src at srcOrigin into dst at
+// dstOrigin. Both textures need the same format, src TextureUsageCopySrc
+// and dst TextureUsageCopyDst.
+func (r *Renderer) CopyTextureToTexture(src *Texture, srcOrigin types.Origin3D, dst *Texture, dstOrigin types.Origin3D, size types.Extent3D) error {
+	return r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.CopyTextureToTexture(encoder,
+			&types.ImageCopyTexture{Texture: src.texture, Origin: srcOrigin, Aspect: types.TextureAspectAll},
+			&types.ImageCopyTexture{Texture: dst.texture, Origin: dstOrigin, Aspect: types.TextureAspectAll},
+			&size,
+		)
+	})
+}
+
+// ClearBuffer fills size bytes of b at offset with zeros on the GPU.
+// offset and size must be multiples of 4, and b needs BufferUsageCopyDst.
+func (r *Renderer) ClearBuffer(b *Buffer, offset, size uint64) error {
+	if err := gpu.ValidateClearBuffer(offset, size); err != nil {
+		return err
+	}
+	return r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.ClearBuffer(encoder, b.buffer, offset, size)
+	})
+}
+
+// ReadBuffer copies size bytes at offset out of src, which needs
+// BufferUsageCopySrc, and waits until they are available.
+func (r *Renderer) ReadBuffer(src *Buffer, offset, size uint64) ([]byte, error) {
+	staging, err := r.NewBuffer(size, types.BufferUsageMapRead|types.BufferUsageCopyDst)
+	if err != nil {
+		return nil, err
+	}
+	defer staging.Destroy()
+
+	if err := r.CopyBufferToBuffer(src, offset, staging, 0, size); err != nil {
+		return nil, err
+	}
+	return r.readStaging(staging, size)
+}
+
+// ReadTexture copies the pixels of t, which must be an RGBA8 texture
+// like those created by NewTextureFromRGBA, and waits until they are
+// available. Rows are tightly packed, 4 bytes per pixel.
+func (r *Renderer) ReadTexture(t *Texture) ([]byte, error) {
+	width, height := uint32(t.width), uint32(t.height) //nolint:gosec // G115: texture size is positive
+	bytesPerRow := alignBytesPerRow(width * 4)
+	size := uint64(bytesPerRow) * uint64(height)
+
+	staging, err := r.NewBuffer(size, types.BufferUsageMapRead|types.BufferUsageCopyDst)
+	if err != nil {
+		return nil, err
+	}
+	defer staging.Destroy()
+
+	err = r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.CopyTextureToBuffer(encoder,
+			&types.ImageCopyTexture{
+				Texture: t.texture,
+				Aspect:  types.TextureAspectAll,
+			},
+			&types.ImageCopyBuffer{
+				Buffer: staging.buffer,
+				Layout: types.ImageDataLayout{
+					BytesPerRow:  bytesPerRow,
+					RowsPerImage: height,
+				},
+			},
+			&types.Extent3D{
+				Width:              width,
+				Height:             height,
+				DepthOrArrayLayers: 1,
+			},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	padded, err := r.readStaging(staging, size)
+	if err != nil {
+		return nil, err
+	}
+	return unpadRows(padded, width*4, bytesPerRow, height), nil
+}
+
+// submitCommands records commands with fn and submits them.
+func (r *Renderer) submitCommands(fn func(encoder types.CommandEncoder)) error {
+	encoder := r.backend.CreateCommandEncoder(r.device)
+	if encoder == 0 {
+		return fmt.Errorf("gogpu: failed to create command encoder")
+	}
+
+	fn(encoder)
+
+	commands := r.backend.FinishEncoder(encoder)
+	r.backend.ReleaseCommandEncoder(encoder)
+
+	r.submit(commands)
+	r.backend.ReleaseCommandBuffer(commands)
+
+	return nil
+}
+
+// readStaging maps a staging buffer, waiting for the GPU, and returns a
+// copy of its first size bytes.
+func (r *Renderer) readStaging(staging *Buffer, size uint64) ([]byte, error) {
+	var mapErr error
+	mapped := false
+	staging.MapAsync(types.MapModeRead, 0, size, func(err error) {
+		mapErr = err
+		mapped = true
+	})
+	if !mapped {
+		r.Poll(true)
+	}
+	if !mapped {
+		return nil, fmt.Errorf("gogpu: buffer mapping did not complete")
+	}
+	if mapErr != nil {
+		return nil, fmt.Errorf("gogpu: failed to map buffer: %w", mapErr)
+	}
+	defer staging.Unmap()
+
+	data := staging.MappedRange(0, size)
+	if uint64(len(data)) < size {
+		return nil, fmt.Errorf("gogpu: failed to map buffer")
+	}
+	return append([]byte(nil), data[:size]...), nil
+}
+
+// alignBytesPerRow rounds a row size up to the alignment required for
+// copies between textures and buffers.
+func alignBytesPerRow(bytesPerRow uint32) uint32 {
+	return alignUp(bytesPerRow, types.CopyBytesPerRowAlignment)
+}
+
+// alignUp rounds n up to a multiple of align, if align is not 0.
+func alignUp(n, align uint32) uint32 {
+	if align == 0 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// unpadRows removes the padding after each of height rows of rowSize
+// bytes stored every stride bytes.
+func unpadRows(data []byte, rowSize, stride, height uint32) []byte {
+	if rowSize == stride {
+		return data[:rowSize*height]
+	}
+
+	out := make([]byte, 0, rowSize*height)
+	for y := uint32(0); y < height; y++ {
+		start := y * stride
+		out = append(out, data[start:start+rowSize]...)
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package gogpu
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlignBytesPerRow(t *testing.T) {
+	tests := []struct {
+		in, want uint32
+	}{
+		{0, 0},
+		{4, 256},
+		{256, 256},
+		{257, 512},
+		{1920 * 4, 7680},
+	}
+
+	for _, tt := range tests {
+		if got := alignBytesPerRow(tt.in); got != tt.want {
+			t.Errorf("alignBytesPerRow(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUnpadRows(t *testing.T) {
+	padded := []byte{
+		1, 2, 3, 0, 0,
+		4, 5, 6, 0, 0,
+	}
+
+	got := unpadRows(padded, 3, 5, 2)
+	want := []byte{1, 2, 3, 4, 5, 6}
+	if !bytes.Equal(got, want) {
+		t.Errorf("unpadRows() = %v, want %v", got, want)
+	}
+
+	tight := []byte{1, 2, 3, 4}
+	if got := unpadRows(tight, 2, 2, 2); !bytes.Equal(got, tight) {
+		t.Errorf("unpadRows() without padding = %v, want %v", got, tight)
+	}
+}
@@ -0,0 +1,169 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// RenderBundle is a prerecorded list of draw commands that can be
+// replayed every frame with ExecuteBundles, at a fraction of the CPU cost
+// of recording the draws again. Create one with Renderer.NewRenderBundle.
+type RenderBundle struct {
+	bundle types.RenderBundle
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// Handle returns the underlying GPU render bundle handle.
+// For advanced use cases that need direct GPU access.
+func (b *RenderBundle) Handle() types.RenderBundle {
+	return b.bundle
+}
+
+// Destroy releases the bundle. After calling Destroy, the bundle should
+// not be used.
+func (b *RenderBundle) Destroy() {
+	if b.renderer == nil || b.renderer.backend == nil {
+		return
+	}
+
+	if b.bundle != 0 {
+		b.renderer.backend.ReleaseRenderBundle(b.bundle)
+		b.bundle = 0
+	}
+}
+
+// RenderBundleEncoder records the draws of a render bundle. It is only
+// valid inside the function passed to NewRenderBundle and should not be
+// stored.
+type RenderBundleEncoder struct {
+	renderer *Renderer
+	encoder  types.RenderBundleEncoder
+}
+
+// SetPipeline sets the pipeline used by following draws. It must target
+// the frame's format and sample count.
+func (e *RenderBundleEncoder) SetPipeline(pipeline types.RenderPipeline) {
+	e.renderer.backend.SetBundlePipeline(e.encoder, pipeline)
+}
+
+// SetBindGroup binds resources to the group index of the pipeline.
+func (e *RenderBundleEncoder) SetBindGroup(index uint32, group types.BindGroup, dynamicOffsets ...uint32) {
+	e.renderer.backend.SetBundleBindGroup(e.encoder, index, group, dynamicOffsets)
+}
+
+// SetVertexBuffer binds all of buffer to the vertex buffer slot.
+func (e *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer *Buffer) {
+	e.renderer.backend.SetBundleVertexBuffer(e.encoder, slot, buffer.buffer, 0, buffer.size)
+}
+
+// SetIndexBuffer binds all of buffer as the index buffer.
+func (e *RenderBundleEncoder) SetIndexBuffer(buffer *Buffer, format types.IndexFormat) {
+	e.renderer.backend.SetBundleIndexBuffer(e.encoder, buffer.buffer, format, 0, buffer.size)
+}
+
+// Draw records a draw of vertexCount vertices and instanceCount instances.
+func (e *RenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	e.renderer.backend.BundleDraw(e.encoder, vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+// DrawIndexed records an indexed draw of indexCount indices and
+// instanceCount instances.
+func (e *RenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	e.renderer.backend.BundleDrawIndexed(e.encoder, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+// DrawIndirect records a draw with types.DrawIndirectArgs read at offset
+// in buffer, which needs BufferUsageIndirect. The GPU reads the
+// arguments each time the bundle executes, so compute shaders can change
+// them without re-recording.
+func (e *RenderBundleEncoder) DrawIndirect(buffer *Buffer, offset uint64) {
+	e.renderer.backend.BundleDrawIndirect(e.encoder, buffer.buffer, offset)
+}
+
+// DrawIndexedIndirect records an indexed draw with
+// types.DrawIndexedIndirectArgs read at offset in buffer, like DrawIndirect.
+func (e *RenderBundleEncoder) DrawIndexedIndirect(buffer *Buffer, offset uint64) {
+	e.renderer.backend.BundleDrawIndexedIndirect(e.encoder, buffer.buffer, offset)
+}
+
+// MultiDrawIndirect records count draws with tightly packed
+// types.DrawIndirectArgs starting at offset in buffer.
+func (e *RenderBundleEncoder) MultiDrawIndirect(buffer *Buffer, offset uint64, count uint32) {
+	for i := uint64(0); i < uint64(count); i++ {
+		e.DrawIndirect(buffer, offset+i*types.DrawIndirectArgsSize)
+	}
+}
+
+// MultiDrawIndexedIndirect records count indexed draws with tightly
+// packed types.DrawIndexedIndirectArgs starting at offset in buffer.
+func (e *RenderBundleEncoder) MultiDrawIndexedIndirect(buffer *Buffer, offset uint64, count uint32) {
+	for i := uint64(0); i < uint64(count); i++ {
+		e.DrawIndexedIndirect(buffer, offset+i*types.DrawIndexedIndirectArgsSize)
+	}
+}
+
+// NewRenderBundle records the draws made by fn into a render bundle for
+// the frame's format and sample count. Pipelines, bind groups and buffers
+// used by the bundle must outlive it.
+func (r *Renderer) NewRenderBundle(fn func(enc *RenderBundleEncoder)) (*RenderBundle, error) {
+	encoder, err := r.backend.CreateRenderBundleEncoder(r.device, &types.RenderBundleEncoderDescriptor{
+		ColorFormats: []types.TextureFormat{r.format},
+		SampleCount:  r.sampleCount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create render bundle encoder: %w", err)
+	}
+	defer r.backend.ReleaseRenderBundleEncoder(encoder)
+
+	fn(&RenderBundleEncoder{renderer: r, encoder: encoder})
+
+	bundle := r.backend.FinishRenderBundle(encoder)
+	if bundle == 0 {
+		return nil, fmt.Errorf("gogpu: failed to finish render bundle")
+	}
+
+	return &RenderBundle{
+		bundle:   bundle,
+		renderer: r,
+	}, nil
+}
+
+// ExecuteBundles draws the bundles in order over the current frame,
+// keeping what was drawn before.
+func (r *Renderer) ExecuteBundles(bundles ...*RenderBundle) error {
+	if r.frame.currentView == 0 || len(bundles) == 0 {
+		return nil
+	}
+
+	handles := make([]types.RenderBundle, len(bundles))
+	for i, bundle := range bundles {
+		handles[i] = bundle.bundle
+	}
+
+	encoder := r.backend.CreateCommandEncoder(r.device)
+	if encoder == 0 {
+		return fmt.Errorf("gogpu: failed to create command encoder")
+	}
+
+	renderPass := r.backend.BeginRenderPass(encoder, &types.RenderPassDescriptor{
+		ColorAttachments: []types.ColorAttachment{
+			r.colorAttachment(types.LoadOpLoad, types.Color{}),
+		},
+	})
+
+	r.backend.ExecuteBundles(renderPass, handles)
+
+	r.backend.EndRenderPass(renderPass)
+	r.backend.ReleaseRenderPass(renderPass)
+
+	commands := r.backend.FinishEncoder(encoder)
+	r.backend.ReleaseCommandEncoder(encoder)
+
+	r.submit(commands)
+	r.backend.ReleaseCommandBuffer(commands)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package gogpu
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// Clipboard gives access to the system clipboard, see App.Clipboard.
+type Clipboard struct {
+	app *App
+}
+
+// Clipboard returns the system clipboard. It is implemented with
+// NSPasteboard on macOS, the Win32 clipboard on Windows, the CLIPBOARD
+// selection on X11 and wl_data_device on Wayland. Its methods return
+// ErrNotInitialized before the app has started.
+func (a *App) Clipboard() *Clipboard {
+	return &Clipboard{app: a}
+}
+
+// ReadText returns the text on the clipboard, or "" if it holds no text.
+// On Wayland, the clipboard is only shared with the focused window.
+func (c *Clipboard) ReadText() (string, error) {
+	return c.app.ClipboardText()
+}
+
+// WriteText replaces the clipboard contents with text. On X11 and
+// Wayland the app serves the text to other applications until it exits
+// or something else is copied.
+func (c *Clipboard) WriteText(text string) error {
+	return c.app.SetClipboardText(text)
+}
+
+// ReadImage returns the image on the clipboard, or nil if it holds no
+// image. Currently implemented on Windows and macOS, where only PNG
+// images are read.
+func (c *Clipboard) ReadImage() (image.Image, error) {
+	return c.app.ClipboardImage()
+}
+
+// WriteImage replaces the clipboard contents with img. Currently
+// implemented on Windows and macOS.
+func (c *Clipboard) WriteImage(img image.Image) error {
+	return c.app.SetClipboardImage(img)
+}
+
+// ClipboardText returns the text on the system clipboard, or "" if the
+// clipboard holds no text. Returns ErrPlatformNotSupported if the platform
+// has no clipboard access (currently implemented on macOS, Windows and
+// Linux). See also Clipboard.
+func (a *App) ClipboardText() (string, error) {
+	c, err := a.clipboard()
+	if err != nil {
+		return "", err
+	}
+	text, err := c.ReadClipboardText()
+	return text, platformError(err)
+}
+
+// SetClipboardText replaces the clipboard contents with text.
+func (a *App) SetClipboardText(text string) error {
+	c, err := a.clipboard()
+	if err != nil {
+		return err
+	}
+	return platformError(c.WriteClipboardText(text))
+}
+
+// ClipboardImage returns the image on the system clipboard, or nil if the
+// clipboard holds no image. Returns ErrPlatformNotSupported if the platform
+// cannot read clipboard images (currently implemented on Windows and
+// macOS).
+func (a *App) ClipboardImage() (image.Image, error) {
+	c, err := a.imageClipboard()
+	if err != nil {
+		return nil, err
+	}
+	img, err := c.ReadClipboardImage()
+	if img == nil || err != nil {
+		return nil, platformError(err)
+	}
+	return img, nil
+}
+
+// SetClipboardImage replaces the clipboard contents with img.
+func (a *App) SetClipboardImage(img image.Image) error {
+	c, err := a.imageClipboard()
+	if err != nil {
+		return err
+	}
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Rect.Min != (image.Point{}) {
+		bounds := img.Bounds()
+		nrgba = image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+	return platformError(c.WriteClipboardImage(nrgba))
+}
+
+// clipboard returns the platform Clipboard implementation.
+func (a *App) clipboard() (platform.Clipboard, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	c, ok := a.platform.(platform.Clipboard)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return c, nil
+}
+
+// imageClipboard returns the platform ImageClipboard implementation.
+func (a *App) imageClipboard() (platform.ImageClipboard, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	c, ok := a.platform.(platform.ImageClipboard)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return c, nil
+}
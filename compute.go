@@ -0,0 +1,131 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// ComputePipeline is a compiled compute shader entry point.
+// Create one with Renderer.NewComputePipeline and run it inside
+// Context.Compute.
+type ComputePipeline struct {
+	pipeline types.ComputePipeline
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// Handle returns the underlying GPU compute pipeline handle.
+// For advanced use cases that need direct GPU access.
+func (p *ComputePipeline) Handle() types.ComputePipeline {
+	return p.pipeline
+}
+
+// Destroy releases the pipeline. After calling Destroy, the pipeline
+// should not be used.
+func (p *ComputePipeline) Destroy() {
+	if p.renderer == nil || p.renderer.backend == nil {
+		return
+	}
+
+	if p.pipeline != 0 {
+		p.renderer.backend.ReleaseComputePipeline(p.pipeline)
+		p.pipeline = 0
+	}
+}
+
+// NewComputePipeline compiles WGSL source and creates a compute pipeline
+// for the entry point. The bind group layouts are derived from the shader.
+func (r *Renderer) NewComputePipeline(code, entryPoint string) (*ComputePipeline, error) {
+	shader, err := r.backend.CreateShaderModuleWGSL(r.device, code)
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create shader module: %w", err)
+	}
+
+	pipeline, err := r.backend.CreateComputePipeline(r.device, &types.ComputePipelineDescriptor{
+		Module:     shader,
+		EntryPoint: entryPoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create compute pipeline: %w", err)
+	}
+
+	return &ComputePipeline{
+		pipeline: pipeline,
+		renderer: r,
+	}, nil
+}
+
+// ComputePass records compute commands. It is only valid inside the
+// function passed to Context.Compute and should not be stored.
+type ComputePass struct {
+	renderer *Renderer
+	pass     types.ComputePass
+}
+
+// SetPipeline sets the pipeline used by following dispatches.
+func (p *ComputePass) SetPipeline(pipeline *ComputePipeline) {
+	p.renderer.backend.SetComputePipeline(p.pass, pipeline.pipeline)
+}
+
+// SetBindGroup binds resources to the group index of the pipeline.
+func (p *ComputePass) SetBindGroup(index uint32, group types.BindGroup, dynamicOffsets ...uint32) {
+	p.renderer.backend.SetComputeBindGroup(p.pass, index, group, dynamicOffsets)
+}
+
+// Dispatch runs x*y*z workgroups of the current pipeline.
+func (p *ComputePass) Dispatch(x, y, z uint32) {
+	p.renderer.backend.DispatchWorkgroups(p.pass, x, y, z)
+}
+
+// DispatchIndirect runs workgroups of the current pipeline with the
+// counts read from three uint32 values at offset in buffer, which must
+// have been created with BufferUsageIndirect.
+func (p *ComputePass) DispatchIndirect(buffer types.Buffer, offset uint64) {
+	p.renderer.backend.DispatchWorkgroupsIndirect(p.pass, buffer, offset)
+}
+
+// PushDebugGroup opens a labeled group of commands for graphics
+// debuggers. Each call must be matched by PopDebugGroup within the pass.
+func (p *ComputePass) PushDebugGroup(label string) {
+	p.renderer.backend.PushComputePassDebugGroup(p.pass, label)
+}
+
+// PopDebugGroup closes the group opened by the last PushDebugGroup.
+func (p *ComputePass) PopDebugGroup() {
+	p.renderer.backend.PopComputePassDebugGroup(p.pass)
+}
+
+// InsertDebugMarker inserts a single labeled marker for graphics debuggers.
+func (p *ComputePass) InsertDebugMarker(label string) {
+	p.renderer.backend.InsertComputePassDebugMarker(p.pass, label)
+}
+
+// Compute records a compute pass with fn and submits it to the queue.
+// The work runs on the GPU in submission order with the frame's draws.
+func (r *Renderer) Compute(fn func(pass *ComputePass)) error {
+	encoder := r.backend.CreateCommandEncoder(r.device)
+	if encoder == 0 {
+		return fmt.Errorf("gogpu: failed to create command encoder")
+	}
+
+	computePass := r.backend.BeginComputePass(encoder, nil)
+	if computePass == 0 {
+		r.backend.ReleaseCommandEncoder(encoder)
+		return fmt.Errorf("gogpu: failed to begin compute pass")
+	}
+
+	fn(&ComputePass{renderer: r, pass: computePass})
+
+	r.backend.EndComputePass(computePass)
+	r.backend.ReleaseComputePass(computePass)
+
+	commands := r.backend.FinishEncoder(encoder)
+	r.backend.ReleaseCommandEncoder(encoder)
+
+	r.submit(commands)
+	r.backend.ReleaseCommandBuffer(commands)
+
+	return nil
+}
@@ -1,30 +1,171 @@
 package gogpu
 
-import "github.com/gogpu/gogpu/gpu/types"
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/gogpu/internal/platform"
+)
 
 // Config configures the application.
 type Config struct {
 	// Title is the window title.
 	Title string
 
-	// Width is the initial window width in pixels.
+	// Width is the initial window width in pixels at a scale factor
+	// of 1. It is in points on macOS and scaled by the monitor DPI on
+	// Windows; see App.ScaleFactor.
 	Width int
 
-	// Height is the initial window height in pixels.
+	// Height is the initial window height, in the same units as Width.
 	Height int
 
 	// Resizable allows the window to be resized.
 	Resizable bool
 
-	// VSync enables vertical synchronization.
+	// VSync enables vertical synchronization. Without it frames are
+	// presented with the Mailbox present mode where the GPU supports it,
+	// which does not tear, else immediately; see Renderer.PresentMode.
+	// Without VSync, PaceToDisplay or TargetFPS nothing limits the frame
+	// rate.
 	VSync bool
 
+	// TargetFPS caps the frame rate at about TargetFPS frames per second
+	// by sleeping before OnUpdate when a frame comes early. It combines
+	// with VSync, e.g. to run at 30 Hz on a 60 Hz display, or without it
+	// to save power while keeping latency low. 0 does not cap the rate.
+	// Context.FrameStats reports how long frames take.
+	TargetFPS int
+
+	// FixedUpdateRate is how many times per second OnFixedUpdate runs.
+	// 0 means 60.
+	FixedUpdateRate int
+
+	// FramesInFlight is how many frames the CPU may record ahead of the
+	// GPU in backends that pace frames themselves, the native backend.
+	// 0 means gpu.DefaultFramesInFlight. More frames keep the GPU busier
+	// when frame times vary, at the cost of input latency.
+	FramesInFlight int
+
 	// Fullscreen starts in fullscreen mode.
 	Fullscreen bool
 
+	// Headless runs the app without a window: frames of Width by Height
+	// pixels are drawn into an offscreen texture, read back with
+	// App.Frame. There are no platform events, and the loop of Run only
+	// ends with Quit. See NewHeadlessApp.
+	Headless bool
+
+	// Transparent makes the window background transparent, so the desktop
+	// shows through wherever the frame is drawn with alpha below 1, e.g.
+	// after clearing to a transparent color. The surface uses premultiplied
+	// alpha. Currently implemented on macOS; ignored elsewhere.
+	Transparent bool
+
+	// SampleCount enables MSAA with the given number of samples per pixel.
+	// Frames are drawn into a multisampled target and resolved into the
+	// window. 0 or 1 disables MSAA; 4 is supported everywhere. The highest
+	// count the GPU supports up to SampleCount is used, see
+	// Context.SampleCount.
+	SampleCount int
+
+	// RequiredFeatures are optional GPU features to enable on the device,
+	// e.g. types.FeatureTimestampQuery. Starting fails if the GPU does
+	// not support all of them. Renderer.AdapterFeatures reports what the
+	// GPU supports.
+	RequiredFeatures types.Features
+
+	// Titlebar selects the title bar style. TitlebarTransparent and
+	// TitlebarHidden extend the drawable area under the title bar so apps
+	// can draw their own window chrome. Currently implemented on macOS;
+	// ignored elsewhere.
+	Titlebar TitlebarStyle
+
+	// PaceToDisplay runs OnUpdate/OnDraw in step with the refresh rate of
+	// the display the window is on, adapting when the window moves to a
+	// monitor with a different rate. Currently implemented on macOS
+	// (CVDisplayLink); ignored elsewhere.
+	PaceToDisplay bool
+
+	// ContentType hints the compositor at what the window shows, e.g.
+	// ContentTypeGame for interactive content, which it may show with
+	// lower latency. ContentTypeNone (default) gives no hint. Currently
+	// implemented on Wayland (content-type-v1); ignored elsewhere.
+	ContentType ContentType
+
+	// RenderDuringResize keeps drawing while the user drags a window edge
+	// on platforms where an interactive resize blocks the main loop
+	// (macOS). OnResize and OnDraw are called for every size step;
+	// OnUpdate is not. Without it the last frame stays on screen,
+	// pinned to the top-left corner, until the resize ends.
+	RenderDuringResize bool
+
+	// RenderThread runs OnUpdate, OnDraw and OnResize on a dedicated
+	// thread while the main thread only processes events, so slow frames
+	// do not delay input or window interaction, and drawing continues
+	// during an interactive resize. Other callbacks still run on the main
+	// thread: state they share with OnUpdate/OnDraw needs synchronization,
+	// and OnUpdate/OnDraw must not call App methods that reach the window
+	// system, such as SetFullscreen, dialogs or the clipboard.
+	// Currently implemented on macOS and Windows; ignored elsewhere.
+	RenderThread bool
+
+	// EventDriven draws only when something changed instead of
+	// continuously: the main loop sleeps until platform events arrive or
+	// App.RequestRedraw is called, then runs OnUpdate and OnDraw once.
+	// Idle tools and editors then use next to no CPU or GPU. The delta time
+	// passed to OnUpdate covers the whole idle period. Platforms that
+	// cannot wait for events are checked every 50 ms, and RequestRedraw
+	// from another goroutine may take as long except on Windows.
+	// Takes precedence over RenderThread.
+	EventDriven bool
+
+	// Background selects what the main loop does while the main window
+	// cannot be seen: minimized, occluded, or the system asleep. Drawing
+	// stops in either case. BackgroundThrottle (default) keeps calling
+	// OnUpdate at about 60 Hz; BackgroundPause stops calling it, so the app
+	// uses almost no CPU until the window is visible again.
+	Background BackgroundPolicy
+
+	// SrgbPolicy selects whether frames are drawn in a linear or an sRGB
+	// format. SrgbPolicyPassthrough (default) shows colors written by
+	// shaders as they are; SrgbPolicyEncode lets the GPU encode linear
+	// shader output, for gamma-correct blending and lighting. Either way
+	// colors given in sRGB, as gmath colors are, look the same on every
+	// backend when passed through Renderer.TargetColor.
+	SrgbPolicy types.SrgbPolicy
+
 	// Backend specifies which WebGPU implementation to use.
-	// BackendAuto (default) selects the best available.
+	// BackendAuto (default) tries the browser's WebGPU in js/wasm builds,
+	// then the Rust backend, then the native backend, using the first that
+	// starts; App.BackendReport tells why the others were rejected.
 	Backend types.BackendType
+
+	// BackendFallback lets an explicit Backend fall back to the other
+	// backends, in the BackendAuto order, when it cannot start. Without it
+	// starting fails if Backend is unusable.
+	BackendFallback bool
+
+	// Validation checks command recording in backends that do not
+	// validate on their own, the native backend: encoder and pass
+	// state, pipelines and bind groups of draws and dispatches, and
+	// resource usages. Invalid commands are skipped and reported as
+	// validation errors wrapping a *gpu.ValidationError. It costs some
+	// CPU time per command, so it is meant for development.
+	Validation bool
+
+	// LeakDetection records where textures, buffers and the other
+	// releasable resources are created, in backends that support it,
+	// the native backend, and reports those not released when the
+	// renderer is destroyed, with their creation stacks, to
+	// Renderer.SetLeakHandler and App.OnLeaks. Meant for development.
+	LeakDetection bool
+
+	// TraceFile, if set, records every backend call and writes the trace
+	// to this file when the renderer is destroyed. gpu.Replay plays it
+	// back without a window, to reproduce rendering bugs on another
+	// machine. Optional backend features such as indirect-count draws
+	// are unavailable while recording. Failures to write it are reported
+	// by Renderer.TraceErr, and by App.Run and OnError.
+	TraceFile string
 }
 
 // DefaultConfig returns sensible default configuration.
@@ -51,18 +192,137 @@ func (c Config) WithSize(width, height int) Config {
 	return c
 }
 
+// WithHeadless returns a copy that runs without a window, see
+// NewHeadlessApp.
+func (c Config) WithHeadless(headless bool) Config {
+	c.Headless = headless
+	return c
+}
+
 // WithBackend returns a copy with the backend set.
 // Use types.BackendRust for maximum performance (requires native library).
 // Use types.BackendGo for zero dependencies (pure Go, may be slower).
+// Use types.BackendWeb for the browser's WebGPU in js/wasm builds.
 // Use types.BackendAuto (default) to automatically select the best available.
 func (c Config) WithBackend(backend types.BackendType) Config {
 	c.Backend = backend
 	return c
 }
 
+// WithBackendFallback returns a copy that falls back to the other
+// backends when the one set with WithBackend cannot start.
+func (c Config) WithBackendFallback(fallback bool) Config {
+	c.BackendFallback = fallback
+	return c
+}
+
+// WithValidation returns a copy with command validation set.
+func (c Config) WithValidation(validation bool) Config {
+	c.Validation = validation
+	return c
+}
+
+// WithVSync returns a copy with vertical synchronization set.
+func (c Config) WithVSync(vsync bool) Config {
+	c.VSync = vsync
+	return c
+}
+
+// WithTargetFPS returns a copy that caps the frame rate at fps frames per
+// second, or does not cap it with 0.
+func (c Config) WithTargetFPS(fps int) Config {
+	c.TargetFPS = fps
+	return c
+}
+
+// WithFixedUpdateRate returns a copy with the OnFixedUpdate rate set to
+// rate updates per second.
+func (c Config) WithFixedUpdateRate(rate int) Config {
+	c.FixedUpdateRate = rate
+	return c
+}
+
+// WithBackground returns a copy with the policy for a hidden main window
+// set. BackgroundPause stops the loop until the window is visible again.
+func (c Config) WithBackground(policy BackgroundPolicy) Config {
+	c.Background = policy
+	return c
+}
+
+// WithFramesInFlight returns a copy with the frames in flight set.
+func (c Config) WithFramesInFlight(n int) Config {
+	c.FramesInFlight = n
+	return c
+}
+
+// WithLeakDetection returns a copy with leak detection set.
+func (c Config) WithLeakDetection(detect bool) Config {
+	c.LeakDetection = detect
+	return c
+}
+
+// WithTraceFile returns a copy that records a trace into path.
+func (c Config) WithTraceFile(path string) Config {
+	c.TraceFile = path
+	return c
+}
+
+// WithSrgbPolicy returns a copy with the sRGB policy set.
+func (c Config) WithSrgbPolicy(policy types.SrgbPolicy) Config {
+	c.SrgbPolicy = policy
+	return c
+}
+
+// WithContentType returns a copy with the content type hint set.
+func (c Config) WithContentType(contentType ContentType) Config {
+	c.ContentType = contentType
+	return c
+}
+
+// WithSampleCount returns a copy with MSAA set to count samples per pixel.
+func (c Config) WithSampleCount(count int) Config {
+	c.SampleCount = count
+	return c
+}
+
+// TitlebarStyle selects how the window title bar is drawn.
+type TitlebarStyle = platform.TitlebarStyle
+
+// Title bar styles.
+const (
+	TitlebarDefault     = platform.TitlebarDefault
+	TitlebarTransparent = platform.TitlebarTransparent
+	TitlebarHidden      = platform.TitlebarHidden
+)
+
+// ContentType describes the kind of content a window shows.
+type ContentType = platform.ContentType
+
+// Content types.
+const (
+	ContentTypeNone  = platform.ContentTypeNone
+	ContentTypePhoto = platform.ContentTypePhoto
+	ContentTypeVideo = platform.ContentTypeVideo
+	ContentTypeGame  = platform.ContentTypeGame
+)
+
+// BackgroundPolicy selects the main loop behavior while the window is hidden.
+type BackgroundPolicy uint8
+
+const (
+	// BackgroundThrottle skips drawing and calls OnUpdate at a reduced rate.
+	BackgroundThrottle BackgroundPolicy = iota
+
+	// BackgroundPause skips drawing and OnUpdate, waiting for events only.
+	// The first OnUpdate after the pause reports a short delta time, not
+	// the length of the pause.
+	BackgroundPause
+)
+
 // Re-export backend types for convenience.
 const (
 	BackendAuto = types.BackendAuto
 	BackendRust = types.BackendRust
 	BackendGo   = types.BackendGo
+	BackendWeb  = types.BackendWeb
 )
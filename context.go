@@ -10,6 +10,8 @@ import (
 type Context struct {
 	renderer *Renderer
 	cleared  bool
+	stats    FrameStats // Of the previous main window frame
+	alpha    float64    // See Interpolation
 }
 
 // newContext creates a new drawing context for a frame.
@@ -63,6 +65,13 @@ func (c *Context) Format() types.TextureFormat {
 	return c.renderer.Format()
 }
 
+// SampleCount returns the number of samples per pixel the frame is drawn
+// with, 1 without MSAA. Pipelines that draw into the frame must use it as
+// their multisample count.
+func (c *Context) SampleCount() uint32 {
+	return c.renderer.SampleCount()
+}
+
 // Backend returns the name of the active backend.
 // Returns "Rust (wgpu-native)" or "Pure Go (gogpu/wgpu)".
 func (c *Context) Backend() string {
@@ -81,3 +90,20 @@ func (c *Context) DrawTriangle(bgR, bgG, bgB, bgA float32) {
 func (c *Context) DrawTriangleColor(bg gmath.Color) {
 	c.DrawTriangle(bg.R, bg.G, bg.B, bg.A)
 }
+
+// Renderer returns the renderer, for creating textures and pipelines.
+func (c *Context) Renderer() *Renderer {
+	return c.renderer
+}
+
+// ExecuteBundles draws prerecorded render bundles over the frame, keeping
+// what was drawn before. See Renderer.NewRenderBundle.
+func (c *Context) ExecuteBundles(bundles ...*RenderBundle) error {
+	return c.renderer.ExecuteBundles(bundles...)
+}
+
+// Compute records a compute pass with fn and submits it. Compute work
+// submitted before drawing is visible to the frame's draws.
+func (c *Context) Compute(fn func(pass *ComputePass)) error {
+	return c.renderer.Compute(fn)
+}
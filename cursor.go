@@ -0,0 +1,196 @@
+package gogpu
+
+import (
+	"errors"
+	"image"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// CursorShape identifies a standard system cursor for SetCursor.
+type CursorShape = platform.CursorShape
+
+// Standard cursor shapes. Shapes a system lacks fall back to a similar
+// cursor or the arrow.
+const (
+	CursorDefault    = platform.CursorDefault
+	CursorText       = platform.CursorText
+	CursorCrosshair  = platform.CursorCrosshair
+	CursorPointer    = platform.CursorPointer
+	CursorGrab       = platform.CursorGrab
+	CursorGrabbing   = platform.CursorGrabbing
+	CursorResizeEW   = platform.CursorResizeEW
+	CursorResizeNS   = platform.CursorResizeNS
+	CursorResizeNWSE = platform.CursorResizeNWSE
+	CursorResizeNESW = platform.CursorResizeNESW
+	CursorResizeAll  = platform.CursorResizeAll
+	CursorNotAllowed = platform.CursorNotAllowed
+	CursorWait       = platform.CursorWait
+)
+
+// CursorMode controls the mouse cursor over the main window.
+type CursorMode uint8
+
+const (
+	// CursorModeNormal shows the cursor, which moves freely.
+	CursorModeNormal CursorMode = iota
+
+	// CursorModeHidden hides the cursor while it is over the window,
+	// e.g. for an application drawing its own.
+	CursorModeHidden
+
+	// CursorModeCaptured hides the cursor and locks it to the window for
+	// relative motion, see SetMouseCaptured.
+	CursorModeCaptured
+)
+
+// Cursor is a custom mouse cursor created from an image with NewCursor.
+type Cursor struct {
+	width, height int
+	hotX, hotY    int
+	pixels        []byte // Premultiplied RGBA
+}
+
+// NewCursor creates a cursor from img, clicking at (hotX, hotY) relative
+// to the top-left corner of the image. The image is copied, and shown at
+// one pixel per image pixel; 32x32 is supported everywhere.
+func NewCursor(img image.Image, hotX, hotY int) (*Cursor, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, errors.New("gogpu: empty cursor image")
+	}
+	if hotX < 0 || hotY < 0 || hotX >= bounds.Dx() || hotY >= bounds.Dy() {
+		return nil, errors.New("gogpu: cursor hot spot outside the image")
+	}
+
+	rgba := packedRGBA(img)
+	if rgba == img {
+		rgba = &image.RGBA{Pix: append([]byte(nil), rgba.Pix...), Stride: rgba.Stride, Rect: rgba.Rect}
+	}
+	return &Cursor{
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		hotX:   hotX,
+		hotY:   hotY,
+		pixels: rgba.Pix,
+	}, nil
+}
+
+// SetCursor shows a standard cursor over the main window.
+// Returns ErrPlatformNotSupported if the platform cannot change the cursor.
+func (a *App) SetCursor(shape CursorShape) error {
+	c, err := a.cursorSetter()
+	if err != nil {
+		return err
+	}
+	return platformError(c.SetCursor(platform.MainWindow, shape))
+}
+
+// SetCustomCursor shows cursor over the main window. SetCursor returns
+// to a standard cursor. Returns ErrPlatformNotSupported if the platform
+// cannot show custom cursors (currently X11).
+func (a *App) SetCustomCursor(cursor *Cursor) error {
+	c, err := a.cursorSetter()
+	if err != nil {
+		return err
+	}
+	return platformError(c.SetCustomCursor(platform.MainWindow,
+		cursor.width, cursor.height, cursor.hotX, cursor.hotY, cursor.pixels))
+}
+
+// SetCursorVisible hides the cursor while it is over the main window, or
+// shows it again. It is a shorthand for SetCursorMode with
+// CursorModeHidden or CursorModeNormal.
+func (a *App) SetCursorVisible(visible bool) error {
+	if visible {
+		return a.SetCursorMode(CursorModeNormal)
+	}
+	return a.SetCursorMode(CursorModeHidden)
+}
+
+// SetCursorMode shows, hides or captures the cursor of the main window.
+// Returns ErrPlatformNotSupported if the platform cannot hide the cursor,
+// or cannot capture it for CursorModeCaptured.
+func (a *App) SetCursorMode(mode CursorMode) error {
+	c, err := a.cursorSetter()
+	if err != nil {
+		return err
+	}
+
+	captured := mode == CursorModeCaptured
+	if captured || a.cursorMode == CursorModeCaptured {
+		if err := a.SetMouseCaptured(captured); err != nil {
+			return err
+		}
+	}
+	// The capture hides the cursor by itself
+	if err := c.SetCursorVisible(platform.MainWindow, mode == CursorModeNormal); err != nil && !captured {
+		return platformError(err)
+	}
+	a.cursorMode = mode
+	return nil
+}
+
+// CursorMode returns the cursor mode of the main window set with
+// SetCursorMode.
+func (a *App) CursorMode() CursorMode {
+	return a.cursorMode
+}
+
+// cursorSetter returns the platform CursorSetter implementation.
+func (a *App) cursorSetter() (platform.CursorSetter, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	c, ok := a.platform.(platform.CursorSetter)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return c, nil
+}
+
+// SetCursor shows a standard cursor over the window. It does nothing
+// once the window is closed.
+func (w *Window) SetCursor(shape CursorShape) error {
+	c, ok := w.cursorSetter()
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	if w.closed {
+		return nil
+	}
+	return platformError(c.SetCursor(w.id, shape))
+}
+
+// SetCustomCursor shows cursor over the window. It does nothing once the
+// window is closed.
+func (w *Window) SetCustomCursor(cursor *Cursor) error {
+	c, ok := w.cursorSetter()
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	if w.closed {
+		return nil
+	}
+	return platformError(c.SetCustomCursor(w.id,
+		cursor.width, cursor.height, cursor.hotX, cursor.hotY, cursor.pixels))
+}
+
+// SetCursorVisible hides the cursor while it is over the window, or shows
+// it again. It does nothing once the window is closed.
+func (w *Window) SetCursorVisible(visible bool) error {
+	c, ok := w.cursorSetter()
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	if w.closed {
+		return nil
+	}
+	return platformError(c.SetCursorVisible(w.id, visible))
+}
+
+// cursorSetter returns the platform CursorSetter implementation.
+func (w *Window) cursorSetter() (platform.CursorSetter, bool) {
+	c, ok := w.app.platform.(platform.CursorSetter)
+	return c, ok
+}
@@ -0,0 +1,57 @@
+package gogpu
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestNewCursor(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 10, 26, 26))
+	img.Set(10, 10, color.NRGBA{R: 255, A: 128})
+
+	cursor, err := NewCursor(img, 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor.width != 16 || cursor.height != 16 || cursor.hotX != 3 || cursor.hotY != 4 {
+		t.Errorf("cursor = %dx%d at (%d, %d), want 16x16 at (3, 4)",
+			cursor.width, cursor.height, cursor.hotX, cursor.hotY)
+	}
+	// Converted to premultiplied RGBA from the image's origin
+	if got := cursor.pixels[:4]; got[0] != 128 || got[3] != 128 {
+		t.Errorf("first pixel = %v, want premultiplied red at half alpha", got)
+	}
+
+	// RGBA images are copied
+	rgba := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	cursor, err = NewCursor(rgba, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rgba.Pix[0] = 1
+	if cursor.pixels[0] != 0 {
+		t.Error("cursor shares the pixels of the image")
+	}
+
+	if _, err := NewCursor(image.NewRGBA(image.Rectangle{}), 0, 0); err == nil {
+		t.Error("NewCursor accepted an empty image")
+	}
+	if _, err := NewCursor(rgba, 8, 0); err == nil {
+		t.Error("NewCursor accepted a hot spot outside the image")
+	}
+}
+
+func TestCursorBeforeRun(t *testing.T) {
+	app := NewApp(DefaultConfig())
+	if err := app.SetCursor(CursorPointer); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("SetCursor before Run = %v, want ErrNotInitialized", err)
+	}
+	if err := app.SetCursorMode(CursorModeHidden); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("SetCursorMode before Run = %v, want ErrNotInitialized", err)
+	}
+	if mode := app.CursorMode(); mode != CursorModeNormal {
+		t.Errorf("CursorMode = %v, want CursorModeNormal", mode)
+	}
+}
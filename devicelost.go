@@ -0,0 +1,129 @@
+package gogpu
+
+import (
+	"time"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// DeviceLostReason specifies why the GPU device was lost.
+type DeviceLostReason = types.DeviceLostReason
+
+// Device-lost reasons.
+const (
+	DeviceLostReasonUnknown   = types.DeviceLostReasonUnknown
+	DeviceLostReasonDestroyed = types.DeviceLostReasonDestroyed
+)
+
+// deviceRetryInterval is the wait between attempts to replace a lost
+// device, e.g. while an external GPU is being reconnected.
+const deviceRetryInterval = time.Second
+
+// deviceLoss describes a lost device.
+type deviceLoss struct {
+	reason  DeviceLostReason
+	message string
+	retry   bool // Replacing the device failed before
+}
+
+// OnDeviceLost sets the callback for the loss of the GPU device, after a
+// GPU reset, a driver update, or the removal of an external GPU.
+//
+// The app then replaces the device before the next frame: it requests a
+// new adapter and device, reconfigures the window surface, and calls
+// OnDeviceRestored. All resources created on the lost device, such as
+// textures, buffers and pipelines, are unusable and must be created again
+// in OnDeviceRestored. Built-in resources are recreated automatically.
+func (a *App) OnDeviceLost(fn func(reason DeviceLostReason, message string)) *App {
+	a.onDeviceLost = fn
+	return a
+}
+
+// OnDeviceRestored sets the callback run after a lost device was
+// replaced, to recreate the app's GPU resources with the renderer.
+// See OnDeviceLost.
+func (a *App) OnDeviceRestored(fn func(r *Renderer)) *App {
+	a.onDeviceRestored = fn
+	return a
+}
+
+// checkDevice replaces the device if it was lost and reports whether a
+// device is available for drawing.
+func (a *App) checkDevice() bool {
+	loss := a.renderer.takeDeviceLoss()
+	if loss == nil {
+		return true
+	}
+
+	if !loss.retry && a.onDeviceLost != nil {
+		a.onDeviceLost(loss.reason, loss.message)
+	}
+
+	if err := a.renderer.restore(a.config); err != nil {
+		// Keep the loss pending and try again later
+		loss.retry = true
+		a.renderer.setDeviceLoss(loss)
+		time.Sleep(deviceRetryInterval)
+		return false
+	}
+	a.renderer.SetErrorHandler(a.handleGPUError)
+	a.renderer.SetMemoryWarningHandler(a.handleMemoryWarning)
+	a.restoreWindows()
+
+	if a.onDeviceRestored != nil {
+		a.onDeviceRestored(a.renderer)
+	}
+	return true
+}
+
+// handleDeviceLost records the loss of the device for the next frame.
+// Devices destroyed on purpose, as by Destroy, are not replaced.
+func (r *Renderer) handleDeviceLost(reason DeviceLostReason, message string) {
+	if reason == DeviceLostReasonDestroyed {
+		return
+	}
+	r.setDeviceLoss(&deviceLoss{reason: reason, message: message})
+}
+
+// setDeviceLoss records a pending device loss.
+func (r *Renderer) setDeviceLoss(loss *deviceLoss) {
+	r.lostMu.Lock()
+	defer r.lostMu.Unlock()
+	r.lost = loss
+}
+
+// takeDeviceLoss returns and clears the pending device loss, if any.
+func (r *Renderer) takeDeviceLoss() *deviceLoss {
+	r.lostMu.Lock()
+	defer r.lostMu.Unlock()
+	loss := r.lost
+	r.lost = nil
+	return loss
+}
+
+// DeviceLost reports whether the device was lost and is not replaced yet.
+func (r *Renderer) DeviceLost() bool {
+	r.lostMu.Lock()
+	defer r.lostMu.Unlock()
+	return r.lost != nil
+}
+
+// restore replaces a lost device. The backend and instance are kept, so
+// handles of resources of the lost device never alias new ones.
+func (r *Renderer) restore(config Config) error {
+	r.releaseMSAATarget()
+	r.releaseFrame()
+	r.releaseTarget()
+
+	// Lost with the device; the backend frees them on Destroy and
+	// DrawTriangle builds new ones
+	r.trianglePipeline = 0
+	r.triangleShader = 0
+	r.staging = nil
+	r.transfers = nil
+	r.gpuTimer, r.gpuTimerFailed = nil, false
+
+	r.frame.surfaceConfigured = false
+	r.resetSubmissions()
+	return r.createDevice(config)
+}
@@ -0,0 +1,54 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// FileDialog configures a native open or save file dialog.
+//
+// Title, Directory (initial directory) and Filters apply to both dialogs.
+// Filename sets the initial name of a save dialog. Multiple and Directories
+// apply to open dialogs: select several items, or pick directories instead
+// of files.
+type FileDialog = platform.FileDialog
+
+// FileFilter restricts a file dialog to files with the given extensions,
+// written without the leading dot. Name describes the filter on platforms
+// that show it; macOS merges all filters into one set of allowed types.
+type FileFilter = platform.FileFilter
+
+// OpenFileDialog shows a native dialog for choosing existing files or
+// directories and blocks until it is closed. It returns the chosen paths,
+// or nil if the user cancelled.
+// Returns ErrPlatformNotSupported if the platform has no native file
+// dialogs (currently available on macOS).
+func (a *App) OpenFileDialog(dialog FileDialog) ([]string, error) {
+	d, err := a.dialogs()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := d.OpenFileDialog(dialog)
+	return paths, platformError(err)
+}
+
+// SaveFileDialog shows a native dialog for choosing where to save a file
+// and blocks until it is closed. It returns the chosen path, or "" if the
+// user cancelled. The dialog confirms replacing an existing file.
+func (a *App) SaveFileDialog(dialog FileDialog) (string, error) {
+	d, err := a.dialogs()
+	if err != nil {
+		return "", err
+	}
+	path, err := d.SaveFileDialog(dialog)
+	return path, platformError(err)
+}
+
+// dialogs returns the platform's file dialogs.
+func (a *App) dialogs() (platform.Dialogs, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	d, ok := a.platform.(platform.Dialogs)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return d, nil
+}
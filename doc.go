@@ -50,7 +50,66 @@
 //
 //   - OnDraw(func(*Context)): Called each frame for rendering
 //   - OnUpdate(func(float64)): Called each frame with delta time for logic
+//   - OnFixedUpdate(func(float64)): Called at a fixed rate for deterministic
+//     game logic, see Context.Interpolation
 //   - OnResize(func(int, int)): Called when window is resized
+//   - OnFocusChanged, OnMinimized, OnRestored and OnVisibilityChanged:
+//     Called as the main window changes state. Drawing stops while it is
+//     hidden, and WithBackground(BackgroundPause) stops OnUpdate as well
+//   - OnError(func(error)): Called with a *PanicError when a callback
+//     panicked, after the app was shut down; Run and Close return the
+//     same error
+//
+// # Input
+//
+// Keyboard and mouse input arrives through callbacks, using the Key and
+// MouseButton values of the input package:
+//
+//	app.OnKey(func(e gogpu.KeyEvent) {
+//	    if e.Down && e.Key == input.KeySpace {
+//	        jump()
+//	    }
+//	})
+//
+// OnMouseButton, OnMouseMove and OnScroll report the mouse, and OnChar
+// typed characters. For per-frame polling, Input returns the state at
+// the start of the frame:
+//
+//	app.OnUpdate(func(dt float64) {
+//	    if app.Input().IsKeyDown(input.KeyW) {
+//	        player.Forward(dt)
+//	    }
+//	})
+//
+// Up to four gamepads are supported, with buttons and axes in the Xbox
+// layout. Input().Gamepad(slot) polls them, Gamepads lists the connected
+// slots, OnGamepadConnected reports connections, and SetGamepadRumble
+// drives the rumble motors where the platform supports it.
+//
+// SetCursor picks a standard mouse cursor over the main window, and
+// SetCustomCursor one made from an image with NewCursor. SetCursorMode
+// hides the cursor, or captures it for relative motion read with
+// MouseDelta. Windows opened with NewWindow have their own cursor.
+//
+// OnFileDrop reports files dropped on the main window. OnFileDragEnter,
+// OnFileDragMove and OnFileDragLeave follow the drag before the drop, so
+// the window can highlight itself as a drop target.
+//
+// # Window Management
+//
+// While the app runs, SetTitle, SetSize and SetPosition change the main
+// window, and Minimize, Maximize, Restore and Focus its state. The same
+// methods exist on Window, along with RequestAttention. Window systems
+// differ in what they allow: Wayland applications cannot place their
+// windows, and systems that prevent focus stealing may only highlight a
+// window asked to take focus.
+//
+// # Headless Rendering
+//
+// NewHeadlessApp creates an app without a window, drawing into an
+// offscreen texture with the same OnDraw code. Tests and thumbnail
+// services start it with Start, draw with DrawFrame and read the pixels
+// back with Frame.
 //
 // # Advanced Usage
 //
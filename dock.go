@@ -0,0 +1,63 @@
+package gogpu
+
+import (
+	"image"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// SetDockIcon replaces the application icon in the Dock while the app
+// runs, e.g. to show progress. A nil image restores the default icon.
+// Returns ErrPlatformNotSupported on platforms without a dock
+// (currently implemented on macOS).
+func (a *App) SetDockIcon(img image.Image) error {
+	d, err := a.dock()
+	if err != nil {
+		return err
+	}
+	if img == nil {
+		return platformError(d.SetDockIcon(0, 0, nil))
+	}
+
+	rgba := packedRGBA(img)
+	bounds := rgba.Bounds()
+	return platformError(d.SetDockIcon(bounds.Dx(), bounds.Dy(), rgba.Pix))
+}
+
+// SetDockBadge shows a short label, such as an unread count, on the
+// application icon in the Dock. An empty label removes the badge.
+func (a *App) SetDockBadge(label string) error {
+	d, err := a.dock()
+	if err != nil {
+		return err
+	}
+	return platformError(d.SetDockBadge(label))
+}
+
+// RequestAttention asks for the user's attention while the application
+// is in the background, e.g. when a long task finishes. On macOS the Dock
+// icon bounces once, or until the app is activated if critical is set.
+// Elsewhere the main window is highlighted, e.g. by flashing its taskbar
+// button. It does nothing while the app is active.
+func (a *App) RequestAttention(critical bool) error {
+	if d, err := a.dock(); err == nil {
+		return platformError(d.RequestAttention(critical))
+	}
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.RequestWindowAttention(platform.MainWindow, critical))
+}
+
+// dock returns the platform Dock implementation.
+func (a *App) dock() (platform.Dock, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	d, ok := a.platform.(platform.Dock)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return d, nil
+}
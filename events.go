@@ -0,0 +1,205 @@
+package gogpu
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// EventType identifies the kind of an Event.
+type EventType uint8
+
+// Event types.
+const (
+	EventKey         EventType = iota + 1 // Key pressed or released
+	EventChar                             // Character typed
+	EventMouseButton                      // Mouse button pressed or released
+	EventMouseMove                        // Mouse moved
+	EventScroll                           // Mouse wheel or touchpad scrolled
+	EventResize                           // Window resized
+	EventClose                            // Window close requested
+	EventFocus                            // Window gained or lost keyboard focus
+	EventGamepad                          // Gamepad connected or disconnected
+)
+
+// Event is an event returned by App.Events, for applications that run
+// their own loop instead of Run. Type tells which fields are set.
+type Event struct {
+	Type EventType
+
+	// Window is the additional window a resize or close belongs to, or
+	// nil for the main window. Closing an additional window closes it
+	// like with Run; its Window.OnClose callback is still called.
+	Window *Window
+
+	Key     KeyEvent   // for EventKey
+	Char    rune       // for EventChar
+	Mouse   MouseEvent // for mouse button, move and scroll events
+	Width   int        // for EventResize: the new size in pixels
+	Height  int        // for EventResize
+	Focused bool       // for EventFocus: the window gained focus
+
+	Slot      int  // for EventGamepad: the gamepad slot
+	Connected bool // for EventGamepad: connected, or disconnected
+}
+
+// maxQueuedEvents limits the events held for App.Events. Beyond it the
+// oldest are dropped, so an application that stops reading does not
+// grow the queue without bound.
+const maxQueuedEvents = 1024
+
+// Start opens the window and creates the renderer for an application
+// that runs its own loop instead of calling Run:
+//
+//	if err := app.Start(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer app.Close()
+//	for app.Running() {
+//	    for _, e := range app.Events() {
+//	        // handle e
+//	    }
+//	    app.DrawFrame(draw)
+//	}
+//
+// Callbacks set with OnKey, OnResize and the like are still called, from
+// within Events; OnFixedUpdate and OnUpdate are called from DrawFrame.
+// Config.RenderThread and Config.EventDriven do not apply.
+// Like Run, Start must be called from the main goroutine.
+func (a *App) Start() error {
+	if err := a.start(); err != nil {
+		return err
+	}
+	a.queueEvents = true
+	return nil
+}
+
+// Running reports whether an application started with Start is still
+// running, i.e. the main window was not closed and Quit was not called.
+func (a *App) Running() bool {
+	return a.running && a.platform != nil && !a.platform.ShouldClose()
+}
+
+// Events processes pending platform events and returns them, oldest
+// first. Call it once per iteration of the loop: it also takes the
+// snapshot returned by Input, which starts a new frame of input state.
+// The slice is only valid until the next call.
+func (a *App) Events() []Event {
+	if a.platform == nil {
+		return nil
+	}
+
+	end := a.beginFrameScope()
+	defer end()
+
+	a.processEvents()
+	a.input.beginFrame()
+	a.events, a.queued = a.queued, a.events[:0]
+	return a.events
+}
+
+// DrawFrame calls OnFixedUpdate and OnUpdate for the time since the last
+// frame, draws one frame of the main window with draw, which may be nil,
+// and then draws the additional windows. With Config.PaceToDisplay
+// it first waits for the display refresh, and with Config.TargetFPS until
+// the next frame is due. While the main window is minimized or hidden no
+// frame is drawn and DrawFrame sleeps briefly, so the loop does not spin.
+func (a *App) DrawFrame(draw func(*Context)) {
+	if a.renderer == nil {
+		return
+	}
+
+	if pacer, ok := a.platform.(platform.FramePacer); ok {
+		pacer.WaitFrame()
+	}
+	a.limiter.wait()
+
+	end := a.beginFrameScope()
+	defer end()
+
+	// Events took the input snapshot; OnFixedUpdate and OnUpdate run as
+	// in Run, so Context.Interpolation is set for draw
+	now := time.Now()
+	elapsed := now.Sub(a.lastFrame)
+	a.lastFrame = now
+	a.update(elapsed)
+
+	a.renderFrame(draw)
+	a.drawWindows()
+}
+
+// Close closes the additional windows, the renderer and the main window
+// of an application started with Start, then reports a callback panic,
+// and the error of writing Config.TraceFile, to OnError. Like Run, it
+// returns the *PanicError, else the trace error.
+func (a *App) Close() error {
+	if a.platform == nil {
+		return nil
+	}
+	err := a.shutdown()
+	a.platform = nil
+	a.renderer = nil
+	a.queueEvents = false
+	return err
+}
+
+// queueEvent adds the Events counterpart of a platform event, if any, to
+// the queue returned by the next Events call.
+func (a *App) queueEvent(event platform.Event) {
+	var w *Window
+	if event.Window != platform.MainWindow {
+		var ok bool
+		if w, ok = a.windows[event.Window]; !ok {
+			return
+		}
+	}
+
+	add := func(e Event) {
+		if len(a.queued) == maxQueuedEvents {
+			a.queued = append(a.queued[:0], a.queued[1:]...)
+		}
+		e.Window = w
+		a.queued = append(a.queued, e)
+	}
+
+	switch event.Type {
+	case platform.EventResize:
+		add(Event{Type: EventResize, Width: event.Width, Height: event.Height})
+	case platform.EventClose:
+		add(Event{Type: EventClose})
+	case platform.EventGamepad:
+		add(Event{Type: EventGamepad, Slot: event.Gamepad.Slot, Connected: event.Gamepad.Connected})
+	}
+
+	// Input of additional windows is not reported, as with the callbacks
+	if w != nil {
+		return
+	}
+
+	switch event.Type {
+	case platform.EventKey:
+		add(Event{Type: EventKey, Key: event.Key})
+	case platform.EventMouseButton:
+		add(Event{Type: EventMouseButton, Mouse: event.Mouse})
+	case platform.EventMouseMove:
+		add(Event{Type: EventMouseMove, Mouse: event.Mouse})
+	case platform.EventScroll:
+		add(Event{Type: EventScroll, Mouse: event.Mouse})
+	case platform.EventText:
+		if !event.Text.Composing {
+			for _, r := range event.Text.Text {
+				if r != utf8.RuneError {
+					add(Event{Type: EventChar, Char: r})
+				}
+			}
+		}
+	case platform.EventWindowState:
+		switch event.State {
+		case platform.WindowFocused:
+			add(Event{Type: EventFocus, Focused: true})
+		case platform.WindowUnfocused:
+			add(Event{Type: EventFocus})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package gogpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+func TestQueueEvent(t *testing.T) {
+	w := &Window{}
+	a := &App{windows: map[platform.WindowID]*Window{2: w}}
+
+	a.queueEvent(platform.Event{Type: platform.EventKey, Key: KeyEvent{Key: input.KeyA, Down: true}})
+	a.queueEvent(platform.Event{Type: platform.EventText, Text: platform.TextEvent{Text: "ü", Composing: true}})
+	a.queueEvent(platform.Event{Type: platform.EventText, Text: platform.TextEvent{Text: "üb"}})
+	a.queueEvent(platform.Event{Type: platform.EventWindowState, State: platform.WindowUnfocused})
+	a.queueEvent(platform.Event{Type: platform.EventResize, Window: 2, Width: 64, Height: 48})
+	a.queueEvent(platform.Event{Type: platform.EventGamepad, Gamepad: platform.GamepadEvent{Slot: 1, Connected: true}})
+	// Input of additional windows and events of unknown windows are dropped
+	a.queueEvent(platform.Event{Type: platform.EventKey, Window: 2})
+	a.queueEvent(platform.Event{Type: platform.EventClose, Window: 3})
+
+	want := []Event{
+		{Type: EventKey, Key: KeyEvent{Key: input.KeyA, Down: true}},
+		{Type: EventChar, Char: 'ü'},
+		{Type: EventChar, Char: 'b'},
+		{Type: EventFocus},
+		{Type: EventResize, Window: w, Width: 64, Height: 48},
+		{Type: EventGamepad, Slot: 1, Connected: true},
+	}
+	if len(a.queued) != len(want) {
+		t.Fatalf("queued %d events, want %d: %+v", len(a.queued), len(want), a.queued)
+	}
+	for i, e := range a.queued {
+		if e != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+
+	// A full queue drops the oldest events
+	for range maxQueuedEvents {
+		a.queueEvent(platform.Event{Type: platform.EventMouseMove})
+	}
+	if len(a.queued) != maxQueuedEvents || a.queued[0].Type != EventMouseMove {
+		t.Errorf("full queue has %d events, first %v", len(a.queued), a.queued[0].Type)
+	}
+}
@@ -0,0 +1,56 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// OnFileDrop sets the callback for files dropped on the main window.
+// Coordinates are the drop location in window pixels.
+// Delivered on macOS, Windows and Wayland.
+func (a *App) OnFileDrop(fn func(paths []string, x, y int)) *App {
+	a.onFileDrop = fn
+	return a
+}
+
+// OnFileDragEnter sets the callback for files being dragged into the main
+// window, for highlighting it as a drop target. Every enter is followed
+// by a leave or a drop.
+func (a *App) OnFileDragEnter(fn func(x, y int)) *App {
+	a.onFileDragEnter = fn
+	return a
+}
+
+// OnFileDragMove sets the callback for files being dragged over the main
+// window, with the position in window pixels.
+func (a *App) OnFileDragMove(fn func(x, y int)) *App {
+	a.onFileDragMove = fn
+	return a
+}
+
+// OnFileDragLeave sets the callback for files dragged out of the main
+// window, or a drag that was cancelled.
+func (a *App) OnFileDragLeave(fn func()) *App {
+	a.onFileDragLeave = fn
+	return a
+}
+
+// handleDrop dispatches a platform drop event to the user callbacks.
+func (a *App) handleDrop(event platform.DropEvent) {
+	x, y := int(event.X), int(event.Y)
+	switch event.Phase {
+	case platform.DropPhaseEnter:
+		if a.onFileDragEnter != nil {
+			a.onFileDragEnter(x, y)
+		}
+	case platform.DropPhaseOver:
+		if a.onFileDragMove != nil {
+			a.onFileDragMove(x, y)
+		}
+	case platform.DropPhaseLeave:
+		if a.onFileDragLeave != nil {
+			a.onFileDragLeave()
+		}
+	case platform.DropPhaseDrop:
+		if a.onFileDrop != nil {
+			a.onFileDrop(event.Paths, x, y)
+		}
+	}
+}
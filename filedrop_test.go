@@ -0,0 +1,40 @@
+package gogpu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+func TestHandleDrop(t *testing.T) {
+	var got []string
+	a := &App{}
+	a.OnFileDragEnter(func(x, y int) { got = append(got, "enter") }).
+		OnFileDragMove(func(x, y int) { got = append(got, "move") }).
+		OnFileDragLeave(func() { got = append(got, "leave") }).
+		OnFileDrop(func(paths []string, x, y int) {
+			if x != 30 || y != 40 {
+				t.Errorf("drop at %d,%d, want 30,40", x, y)
+			}
+			got = append(got, paths...)
+		})
+
+	for _, event := range []platform.DropEvent{
+		{Phase: platform.DropPhaseEnter, X: 10, Y: 20},
+		{Phase: platform.DropPhaseOver, X: 15, Y: 25},
+		{Phase: platform.DropPhaseLeave},
+		{Phase: platform.DropPhaseEnter, X: 10, Y: 20},
+		{Phase: platform.DropPhaseDrop, Paths: []string{"a.png", "b.png"}, X: 30.5, Y: 40},
+	} {
+		a.handleDrop(event)
+	}
+
+	want := []string{"enter", "move", "leave", "enter", "a.png", "b.png"}
+	if !slices.Equal(got, want) {
+		t.Errorf("callbacks = %q, want %q", got, want)
+	}
+
+	// Phases without a callback are ignored
+	(&App{}).handleDrop(platform.DropEvent{Phase: platform.DropPhaseEnter})
+}
@@ -0,0 +1,78 @@
+package gogpu
+
+import "time"
+
+// defaultFixedUpdateRate is the OnFixedUpdate rate when
+// Config.FixedUpdateRate is 0.
+const defaultFixedUpdateRate = 60
+
+// maxFixedUpdateLag is the most time OnFixedUpdate catches up with in one
+// frame. After a longer stall the rest is dropped, so slow fixed updates
+// cannot make every following frame slower still.
+const maxFixedUpdateLag = 250 * time.Millisecond
+
+// OnFixedUpdate sets the callback for game logic and physics that must
+// not depend on the frame rate. It runs at the fixed rate of
+// Config.FixedUpdateRate, with dt always 1/rate seconds: each frame it is
+// called as often as the time passed since the last frame requires, zero
+// or more times, before OnUpdate. Draw the state between the last two
+// fixed updates with Context.Interpolation so motion stays smooth when
+// the frame rate and the update rate differ. After a stall of more than
+// a quarter second the missing time is skipped rather than simulated.
+func (a *App) OnFixedUpdate(fn func(dt float64)) *App {
+	a.onFixedUpdate = fn
+	return a
+}
+
+// Interpolation returns how far the frame is between the last fixed
+// update and the next one, from 0 to 1. Draw the previous state blended
+// with the current one, e.g. prev + (cur-prev)*alpha. It is 0 without
+// OnFixedUpdate.
+func (c *Context) Interpolation() float64 {
+	return c.alpha
+}
+
+// fixedStep accumulates the time OnFixedUpdate has not simulated yet.
+type fixedStep struct {
+	step        time.Duration
+	accumulated time.Duration
+	alpha       float64 // accumulated/step after the last frame's updates
+}
+
+// update calls OnFixedUpdate and OnUpdate for the time elapsed since the
+// last frame, timing them for FrameStats.
+func (a *App) update(elapsed time.Duration) {
+	start := time.Now()
+	a.fixedUpdate(elapsed)
+	if a.onUpdate != nil {
+		a.guard("OnUpdate", func() { a.onUpdate(elapsed.Seconds()) })
+	}
+	a.timing.update = time.Since(start)
+}
+
+// fixedUpdate calls OnFixedUpdate once for each whole step of the
+// accumulated time and keeps the rest for the next frame.
+func (a *App) fixedUpdate(elapsed time.Duration) {
+	if a.onFixedUpdate == nil {
+		return
+	}
+
+	f := &a.fixed
+	if f.step == 0 {
+		rate := a.config.FixedUpdateRate
+		if rate <= 0 {
+			rate = defaultFixedUpdateRate
+		}
+		f.step = time.Second / time.Duration(rate)
+	}
+
+	f.accumulated += min(elapsed, maxFixedUpdateLag)
+	dt := f.step.Seconds()
+	for f.accumulated >= f.step {
+		if !a.guard("OnFixedUpdate", func() { a.onFixedUpdate(dt) }) {
+			break
+		}
+		f.accumulated -= f.step
+	}
+	f.alpha = float64(f.accumulated) / float64(f.step)
+}
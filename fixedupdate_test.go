@@ -0,0 +1,32 @@
+package gogpu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedUpdate(t *testing.T) {
+	var steps []float64
+	a := &App{config: Config{FixedUpdateRate: 50}}
+	a.OnFixedUpdate(func(dt float64) { steps = append(steps, dt) })
+
+	a.fixedUpdate(30 * time.Millisecond)
+	if len(steps) != 1 || steps[0] != 0.02 {
+		t.Fatalf("steps after 30ms = %v, want one of 0.02", steps)
+	}
+	if a.fixed.alpha != 0.5 {
+		t.Errorf("alpha = %v, want 0.5", a.fixed.alpha)
+	}
+
+	// The 10ms left over count towards the next frame
+	a.fixedUpdate(30 * time.Millisecond)
+	if len(steps) != 3 || a.fixed.alpha != 0 {
+		t.Errorf("after 60ms: %d steps, alpha %v, want 3 steps, alpha 0", len(steps), a.fixed.alpha)
+	}
+
+	// A stall is only caught up with up to maxFixedUpdateLag
+	a.fixedUpdate(5 * time.Second)
+	if got, want := len(steps)-3, int(maxFixedUpdateLag/(20*time.Millisecond)); got != want {
+		t.Errorf("steps after a stall = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+package gogpu
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// FrameStats describes the timing of a frame of the main window,
+// returned by Context.FrameStats.
+type FrameStats struct {
+	// Frame is the number of the frame described, counting from 1.
+	Frame uint64
+
+	// FrameTime is the time from the start of the frame before it to the
+	// start of this one.
+	FrameTime time.Duration
+
+	// CPUTime is the time the frame spent in OnFixedUpdate, OnUpdate and
+	// OnDraw. Compare it with the frame budget, e.g. 16.7 ms at 60 Hz.
+	CPUTime time.Duration
+
+	// PresentLatency is the time spent acquiring the frame's surface
+	// texture and presenting it, which is where the frame waits for the
+	// display with VSync.
+	PresentLatency time.Duration
+
+	// GPUTime is the GPU time of frame GPUFrame, the most recent one whose
+	// timestamps were read back, usually two or three frames before Frame.
+	// It covers the GPU work submitted from the start of OnDraw until the
+	// frame is presented. Both are 0 unless the device has
+	// types.FeatureTimestampQuery, see Config.RequiredFeatures, and the
+	// backend writes timestamps.
+	GPUTime  time.Duration
+	GPUFrame uint64
+}
+
+// FrameStats returns the timing of the previous frame of the main window.
+// It is zero in the first frame and in the OnDraw of additional windows.
+func (c *Context) FrameStats() FrameStats {
+	return c.stats
+}
+
+// frameTiming collects the FrameStats of the main window on the goroutine
+// drawing it.
+type frameTiming struct {
+	frame   uint64
+	started time.Time     // Start of the last frame drawn
+	update  time.Duration // OnUpdate time of the next frame
+	last    FrameStats
+}
+
+// end records the stats of a frame drawn from start: its surface texture
+// was acquired by acquired, OnDraw returned at drawn, and the frame was
+// presented at presented.
+func (t *frameTiming) end(r *Renderer, start, acquired, drawn, presented time.Time) {
+	stats := FrameStats{
+		Frame:          t.frame,
+		CPUTime:        t.update + drawn.Sub(acquired),
+		PresentLatency: acquired.Sub(start) + presented.Sub(drawn),
+	}
+	if !t.started.IsZero() {
+		stats.FrameTime = start.Sub(t.started)
+	}
+	stats.GPUTime, stats.GPUFrame = r.gpuFrameTime()
+
+	t.started = start
+	t.update = 0
+	t.last = stats
+}
+
+// frameLimiter caps the frame rate, see Config.TargetFPS.
+type frameLimiter struct {
+	interval time.Duration
+	next     time.Time
+}
+
+// newFrameLimiter returns a limiter for fps frames per second, which does
+// not limit with fps 0.
+func newFrameLimiter(fps int) frameLimiter {
+	if fps <= 0 {
+		return frameLimiter{}
+	}
+	return frameLimiter{interval: time.Second / time.Duration(fps)}
+}
+
+// wait sleeps until the next frame is due. Frames are due at a steady
+// interval; a loop that fell behind by more than a frame starts over
+// from now instead of catching up with a burst of frames.
+func (l *frameLimiter) wait() {
+	if l.interval <= 0 {
+		return
+	}
+
+	now := time.Now()
+	if d := l.next.Sub(now); d > 0 {
+		time.Sleep(d)
+		now = l.next
+	}
+	l.next = l.next.Add(l.interval)
+	if l.next.Before(now) {
+		l.next = now.Add(l.interval)
+	}
+}
+
+// gpuTimerFrames is how many frames may wait for their timestamps to be
+// read back. Frames beyond that are not measured.
+const gpuTimerFrames = 4
+
+// gpuTimerStride is the distance of the frames' results in the resolve
+// buffer, as ResolveQuerySet offsets must be multiples of 256.
+const gpuTimerStride = 256
+
+// gpuFrameTimer measures the GPU time of frames with a pair of timestamps
+// each, read back without waiting for the GPU.
+type gpuFrameTimer struct {
+	queries *QuerySet
+	resolve *Buffer
+	slots   [gpuTimerFrames]gpuTimerSlot
+	current int // Slot of the frame being measured, or -1
+
+	time  time.Duration // Result of the most recent measured frame
+	frame uint64
+}
+
+// gpuTimerSlot is the readback of one frame's timestamps.
+type gpuTimerSlot struct {
+	readback *Buffer
+	frame    uint64
+	busy     bool // Measuring, or waiting for the readback
+}
+
+// newGPUFrameTimer creates the query set and buffers of a timer.
+func (r *Renderer) newGPUFrameTimer() (*gpuFrameTimer, error) {
+	t := &gpuFrameTimer{current: -1}
+	var err error
+	if t.queries, err = r.NewQuerySet(types.QueryTypeTimestamp, 2*gpuTimerFrames); err != nil {
+		return nil, err
+	}
+	t.resolve, err = r.NewBuffer(gpuTimerFrames*gpuTimerStride, types.BufferUsageQueryResolve|types.BufferUsageCopySrc)
+	if err != nil {
+		t.destroy()
+		return nil, err
+	}
+	for i := range t.slots {
+		t.slots[i].readback, err = r.NewBuffer(2*types.QueryResultSize, types.BufferUsageMapRead|types.BufferUsageCopyDst)
+		if err != nil {
+			t.destroy()
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// destroy releases the timer's GPU resources.
+func (t *gpuFrameTimer) destroy() {
+	if t.queries != nil {
+		t.queries.Destroy()
+	}
+	if t.resolve != nil {
+		t.resolve.Destroy()
+	}
+	for _, slot := range t.slots {
+		if slot.readback != nil {
+			slot.readback.Destroy()
+		}
+	}
+}
+
+// frameTimer returns the GPU frame timer, created on first use, or nil
+// without timestamp queries.
+func (r *Renderer) frameTimer() *gpuFrameTimer {
+	if r.gpuTimer == nil && !r.gpuTimerFailed {
+		if !r.Features().Has(types.FeatureTimestampQuery) {
+			r.gpuTimerFailed = true
+			return nil
+		}
+		timer, err := r.newGPUFrameTimer()
+		if err != nil {
+			r.gpuTimerFailed = true
+			return nil
+		}
+		r.gpuTimer = timer
+	}
+	return r.gpuTimer
+}
+
+// startFrameTimer writes the timestamp starting the GPU time of frame,
+// if a readback slot is free.
+func (r *Renderer) startFrameTimer(frame uint64) {
+	t := r.frameTimer()
+	if t == nil {
+		return
+	}
+	t.current = -1
+	for i := range t.slots {
+		if !t.slots[i].busy {
+			t.current = i
+			break
+		}
+	}
+	if t.current < 0 {
+		return
+	}
+
+	slot := &t.slots[t.current]
+	slot.busy = true
+	slot.frame = frame
+	_ = r.WriteTimestamp(t.queries, uint32(2*t.current)) //nolint:gosec // G115: below gpuTimerFrames
+}
+
+// stopFrameTimer writes the timestamp ending the frame's GPU time and
+// starts reading both back. The result is picked up during a later
+// Poll.
+func (r *Renderer) stopFrameTimer() {
+	t := r.gpuTimer
+	if t == nil || t.current < 0 {
+		return
+	}
+
+	i := t.current
+	t.current = -1
+	slot := &t.slots[i]
+	first := uint32(2 * i) //nolint:gosec // G115: below gpuTimerFrames
+	offset := uint64(i) * gpuTimerStride
+	_ = r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.WriteTimestamp(encoder, t.queries.querySet, first+1)
+		r.backend.ResolveQuerySet(encoder, t.queries.querySet, first, 2, t.resolve.buffer, offset)
+		r.backend.CopyBufferToBuffer(encoder, t.resolve.buffer, offset, slot.readback.buffer, 0, 2*types.QueryResultSize)
+	})
+
+	slot.readback.MapAsync(types.MapModeRead, 0, 2*types.QueryResultSize, func(err error) {
+		if err == nil {
+			data := slot.readback.MappedRange(0, 2*types.QueryResultSize)
+			start := binary.LittleEndian.Uint64(data)
+			end := binary.LittleEndian.Uint64(data[types.QueryResultSize:])
+			slot.readback.Unmap()
+
+			// Backends without timestamps leave the results 0
+			if end > start && slot.frame > t.frame {
+				t.time = time.Duration(end - start)
+				t.frame = slot.frame
+			}
+		}
+		slot.busy = false
+	})
+}
+
+// gpuFrameTime returns the GPU time of the most recent measured frame.
+func (r *Renderer) gpuFrameTime() (time.Duration, uint64) {
+	if r.gpuTimer == nil {
+		return 0, 0
+	}
+	return r.gpuTimer.time, r.gpuTimer.frame
+}
@@ -0,0 +1,56 @@
+package gogpu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrameLimiter(t *testing.T) {
+	if l := newFrameLimiter(0); l.interval != 0 {
+		t.Errorf("interval = %v without a target", l.interval)
+	}
+
+	l := newFrameLimiter(100)
+	start := time.Now()
+	for range 5 {
+		l.wait()
+	}
+	// The first frame is due at once, the others 10 ms apart
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 frames at 100 FPS took %v, want at least 40ms", elapsed)
+	}
+
+	// A loop that fell behind is not made to catch up
+	time.Sleep(50 * time.Millisecond)
+	l.wait()
+	if d := time.Until(l.next); d < 5*time.Millisecond {
+		t.Errorf("next frame due in %v after falling behind, want about 10ms", d)
+	}
+}
+
+func TestFrameTimingEnd(t *testing.T) {
+	var timing frameTiming
+	r := &Renderer{}
+	start := time.Now()
+	ms := func(n int) time.Time { return start.Add(time.Duration(n) * time.Millisecond) }
+
+	timing.frame = 1
+	timing.update = 2 * time.Millisecond
+	timing.end(r, ms(0), ms(1), ms(5), ms(8))
+	want := FrameStats{Frame: 1, CPUTime: 6 * time.Millisecond, PresentLatency: 4 * time.Millisecond}
+	if timing.last != want {
+		t.Errorf("first frame stats = %+v, want %+v", timing.last, want)
+	}
+
+	timing.frame = 2
+	timing.end(r, ms(16), ms(17), ms(20), ms(21))
+	want = FrameStats{
+		Frame:          2,
+		FrameTime:      16 * time.Millisecond,
+		CPUTime:        3 * time.Millisecond,
+		PresentLatency: 2 * time.Millisecond,
+	}
+	if timing.last != want {
+		t.Errorf("second frame stats = %+v, want %+v", timing.last, want)
+	}
+}
@@ -0,0 +1,68 @@
+package gogpu
+
+import (
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// Gamepad is a snapshot of a gamepad's name, buttons and axes, indexed by
+// input.GamepadButton and input.GamepadAxis. Buttons follow the Xbox
+// layout whatever the controller: GamepadA is the bottom face button and
+// GamepadX the left one. Dead zones are applied: sticks at rest read 0,
+// and a stick moved just past its dead zone reads close to 0 rather than
+// jumping to the dead zone's edge.
+//
+// Gamepads are read through XInput on Windows, evdev on Linux, which
+// needs read access to /dev/input (granted to the desktop user on most
+// distributions), and the GameController framework on macOS.
+type Gamepad = platform.Gamepad
+
+// Gamepads returns the slots of the connected gamepads in ascending
+// order, as of the last processed events.
+func (a *App) Gamepads() []int {
+	r, ok := a.platform.(platform.GamepadReader)
+	if !ok {
+		return nil
+	}
+	var slots []int
+	for slot := range input.MaxGamepads {
+		if _, connected := r.Gamepad(slot); connected {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// Gamepad returns the gamepad in slot, 0 to input.MaxGamepads-1, as of
+// the last processed events, or false if the slot is empty or the
+// platform has no gamepad support. App.Input holds the same state as of
+// the start of the frame.
+func (a *App) Gamepad(slot int) (Gamepad, bool) {
+	if r, ok := a.platform.(platform.GamepadReader); ok {
+		return r.Gamepad(slot)
+	}
+	return Gamepad{}, false
+}
+
+// SetGamepadRumble sets the speeds of a gamepad's low- and high-frequency
+// rumble motors, from 0 to 1. The motors run until changed; set both to 0
+// to stop them. Rumble is supported on Windows and on Linux for gamepads
+// with force feedback; otherwise ErrPlatformNotSupported is returned.
+func (a *App) SetGamepadRumble(slot int, low, high float64) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	r, ok := a.platform.(platform.GamepadReader)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	return platformError(r.SetGamepadRumble(slot, low, high))
+}
+
+// OnGamepadConnected sets the callback for gamepads being connected to or
+// disconnected from a slot. A newly connected gamepad may take up to a
+// second to be reported.
+func (a *App) OnGamepadConnected(fn func(slot int, connected bool)) *App {
+	a.onGamepad = fn
+	return a
+}
@@ -0,0 +1,38 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// GestureEvent describes a high-level touchpad gesture such as pinch or rotate.
+// Magnification and Rotation are deltas, so a zoom factor is accumulated as
+// scale *= 1 + event.Magnification.
+type GestureEvent = platform.GestureEvent
+
+// GestureKind identifies a touchpad gesture.
+type GestureKind = platform.GestureKind
+
+// GesturePhase describes the lifecycle stage of a GestureEvent.
+type GesturePhase = platform.GesturePhase
+
+// Gesture kinds.
+const (
+	GestureMagnify      = platform.GestureMagnify
+	GestureRotate       = platform.GestureRotate
+	GestureSwipe        = platform.GestureSwipe
+	GestureSmartMagnify = platform.GestureSmartMagnify
+)
+
+// Gesture phases.
+const (
+	GesturePhaseNone   = platform.GesturePhaseNone
+	GesturePhaseBegin  = platform.GesturePhaseBegin
+	GesturePhaseChange = platform.GesturePhaseChange
+	GesturePhaseEnd    = platform.GesturePhaseEnd
+	GesturePhaseCancel = platform.GesturePhaseCancel
+)
+
+// OnGesture sets the callback for touchpad gestures.
+// Currently delivered on macOS trackpads.
+func (a *App) OnGesture(fn func(GestureEvent)) *App {
+	a.onGesture = fn
+	return a
+}
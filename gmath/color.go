@@ -2,6 +2,7 @@ package gmath
 
 import (
 	"fmt"
+	"math"
 )
 
 // Color represents an RGBA color with float32 components.
@@ -68,6 +69,35 @@ func (c Color) Premultiply() Color {
 	return Color{c.R * c.A, c.G * c.A, c.B * c.A, c.A}
 }
 
+// ToLinear converts the color from sRGB, in which colors are usually
+// given and in which Color components are meant, to linear values, as
+// shaders write them into sRGB render targets. Alpha is unchanged.
+func (c Color) ToLinear() Color {
+	return Color{SrgbToLinear(c.R), SrgbToLinear(c.G), SrgbToLinear(c.B), c.A}
+}
+
+// ToSrgb converts the color from linear values to sRGB, the inverse of
+// ToLinear.
+func (c Color) ToSrgb() Color {
+	return Color{LinearToSrgb(c.R), LinearToSrgb(c.G), LinearToSrgb(c.B), c.A}
+}
+
+// SrgbToLinear decodes an sRGB color component in [0, 1] to a linear one.
+func SrgbToLinear(v float32) float32 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return float32(math.Pow((float64(v)+0.055)/1.055, 2.4))
+}
+
+// LinearToSrgb encodes a linear color component in [0, 1] as sRGB.
+func LinearToSrgb(v float32) float32 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return float32(1.055*math.Pow(float64(v), 1/2.4) - 0.055)
+}
+
 // String returns a string representation.
 func (c Color) String() string {
 	return fmt.Sprintf("Color(%f, %f, %f, %f)", c.R, c.G, c.B, c.A)
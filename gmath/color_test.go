@@ -1,6 +1,7 @@
 package gmath
 
 import (
+	"math"
 	"testing"
 )
 
@@ -179,3 +180,20 @@ func TestGopherBlue(t *testing.T) {
 		t.Errorf("GopherBlue.B = %f, want ~0.843", c.B)
 	}
 }
+
+func TestColorSrgb(t *testing.T) {
+	c := NewColor(0.5, 0, 1, 0.25)
+	l := c.ToLinear()
+	if math.Abs(float64(l.R)-0.214041) > 1e-5 || l.G != 0 || !almostEqual(l.B, 1) || l.A != 0.25 {
+		t.Errorf("ToLinear() = %v, want (0.214, 0, 1, 0.25)", l)
+	}
+
+	back := l.ToSrgb()
+	if !almostEqual(back.R, c.R) || !almostEqual(back.G, c.G) || !almostEqual(back.B, c.B) {
+		t.Errorf("ToLinear().ToSrgb() = %v, want %v", back, c)
+	}
+
+	if got := SrgbToLinear(0.02); !almostEqual(got, 0.02/12.92) {
+		t.Errorf("SrgbToLinear(0.02) = %f, want the linear segment", got)
+	}
+}
@@ -33,19 +33,47 @@ type Backend interface {
 	// Adapter operations
 	RequestAdapter(instance types.Instance, opts *types.AdapterOptions) (types.Adapter, error)
 
+	GetAdapterInfo(adapter types.Adapter) types.AdapterInfo
+	GetAdapterFeatures(adapter types.Adapter) types.Features
+	GetAdapterLimits(adapter types.Adapter) types.Limits
+
 	// Device operations
 	RequestDevice(adapter types.Adapter, opts *types.DeviceOptions) (types.Device, error)
 	GetQueue(device types.Device) types.Queue
+	GetDeviceFeatures(device types.Device) types.Features
+	GetDeviceLimits(device types.Device) types.Limits
+
+	// Error handling. Device errors are *types.Error values; the innermost
+	// error scope with a matching filter captures them, and errors no
+	// scope captures go to the uncaptured-error callback.
+	PushErrorScope(device types.Device, filter types.ErrorFilter)
+	PopErrorScope(device types.Device) error
+	SetUncapturedErrorCallback(device types.Device, callback func(err error))
+
+	// SetDeviceLostCallback sets the function called once when the device
+	// is lost. All resources of a lost device are unusable; a new device
+	// must be requested.
+	SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string))
 
 	// Surface operations
 	CreateSurface(instance types.Instance, handle types.SurfaceHandle) (types.Surface, error)
+	GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities
 	ConfigureSurface(surface types.Surface, device types.Device, config *types.SurfaceConfig)
 	GetCurrentTexture(surface types.Surface) (types.SurfaceTexture, error)
 	Present(surface types.Surface)
 
+	// ReleaseSurface waits for the frames in flight on a surface and
+	// destroys it, e.g. when its window closes.
+	ReleaseSurface(surface types.Surface)
+
 	// Shader operations
 	CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error)
 
+	// CreateShaderModuleSPIRV creates a shader module from SPIR-V words,
+	// see ParseSPIRV. Backends that cannot translate SPIR-V for their
+	// graphics API return an error.
+	CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error)
+
 	// Pipeline operations
 	CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error)
 
@@ -54,7 +82,43 @@ type Backend interface {
 	BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass
 	EndRenderPass(pass types.RenderPass)
 	FinishEncoder(encoder types.CommandEncoder) types.CommandBuffer
-	Submit(queue types.Queue, commands types.CommandBuffer)
+	// Submit returns the index of the submission, see OnSubmittedWorkDone.
+	Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex
+	CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64)
+	CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D)
+	CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D)
+	CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D)
+	ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64)
+
+	// Debug operations. Groups nest and must be popped within the same
+	// encoder or pass they were pushed on. Labels show up in graphics
+	// debuggers such as RenderDoc and Xcode.
+	PushDebugGroup(encoder types.CommandEncoder, label string)
+	PopDebugGroup(encoder types.CommandEncoder)
+	InsertDebugMarker(encoder types.CommandEncoder, label string)
+	PushRenderPassDebugGroup(pass types.RenderPass, label string)
+	PopRenderPassDebugGroup(pass types.RenderPass)
+	InsertRenderPassDebugMarker(pass types.RenderPass, label string)
+	PushComputePassDebugGroup(pass types.ComputePass, label string)
+	PopComputePassDebugGroup(pass types.ComputePass)
+	InsertComputePassDebugMarker(pass types.ComputePass, label string)
+
+	// Query operations
+	CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error)
+	WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32)
+	BeginOcclusionQuery(pass types.RenderPass, index uint32)
+	EndOcclusionQuery(pass types.RenderPass)
+	ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64)
+
+	// Poll processes completed GPU work and runs pending callbacks, such as
+	// those of MapBufferAsync. With wait it blocks until all submitted work
+	// is done. Returns true if the queue is empty.
+	Poll(device types.Device, wait bool) bool
+
+	// OnSubmittedWorkDone calls callback once all work submitted to the
+	// queue so far has completed. It runs during a later Poll of the
+	// queue's device.
+	OnSubmittedWorkDone(queue types.Queue, callback func())
 
 	// Render pass operations
 	SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline)
@@ -62,6 +126,7 @@ type Backend interface {
 
 	// Texture operations
 	CreateTexture(device types.Device, desc *types.TextureDescriptor) (types.Texture, error)
+	SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32
 	CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView
 	WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, data []byte, layout *types.ImageDataLayout, size *types.Extent3D)
 
@@ -72,6 +137,14 @@ type Backend interface {
 	CreateBuffer(device types.Device, desc *types.BufferDescriptor) (types.Buffer, error)
 	WriteBuffer(queue types.Queue, buffer types.Buffer, offset uint64, data []byte)
 
+	// MapBufferAsync maps a range of a buffer for CPU access. The callback
+	// runs once the mapping completes or fails, at the latest during a
+	// later Poll of the device. GetMappedRange then returns the memory
+	// until UnmapBuffer.
+	MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error))
+	GetMappedRange(buffer types.Buffer, offset, size uint64) []byte
+	UnmapBuffer(buffer types.Buffer)
+
 	// Bind group operations
 	CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error)
 	CreateBindGroup(device types.Device, desc *types.BindGroupDescriptor) (types.BindGroup, error)
@@ -83,6 +156,48 @@ type Backend interface {
 	SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, format types.IndexFormat, offset, size uint64)
 	DrawIndexed(pass types.RenderPass, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32)
 
+	// Dynamic render pass state. The viewport and scissor rect are in
+	// framebuffer pixels and reset to the full attachment for each pass.
+	SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32)
+	SetScissorRect(pass types.RenderPass, x, y, width, height uint32)
+	SetBlendConstant(pass types.RenderPass, color *types.Color)
+	SetStencilReference(pass types.RenderPass, reference uint32)
+
+	// SetPushConstants sets push constant bytes at offset for the shader
+	// stages. Requires types.FeaturePushConstants and a pipeline layout
+	// with a matching push constant range.
+	SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte)
+
+	// Indirect draws read their arguments (types.DrawIndirectArgs or
+	// types.DrawIndexedIndirectArgs) at offset in a buffer created with
+	// BufferUsageIndirect. See MultiDrawIndirect for several draws.
+	DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64)
+	DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64)
+
+	// Render bundle operations. Bundles record draws once; ExecuteBundles
+	// replays them in render passes with matching attachment formats.
+	CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error)
+	SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline)
+	SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32)
+	SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64)
+	SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64)
+	BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32)
+	BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32)
+	SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte)
+	BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64)
+	BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64)
+	FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle
+	ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle)
+
+	// Compute operations
+	CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error)
+	BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass
+	SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline)
+	SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32)
+	DispatchWorkgroups(pass types.ComputePass, x, y, z uint32)
+	DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64)
+	EndComputePass(pass types.ComputePass)
+
 	// Resource release
 	ReleaseTexture(texture types.Texture)
 	ReleaseTextureView(view types.TextureView)
@@ -91,9 +206,16 @@ type Backend interface {
 	ReleaseBindGroupLayout(layout types.BindGroupLayout)
 	ReleaseBindGroup(group types.BindGroup)
 	ReleasePipelineLayout(layout types.PipelineLayout)
+	ReleaseShaderModule(module types.ShaderModule)
+	ReleaseRenderPipeline(pipeline types.RenderPipeline)
 	ReleaseCommandBuffer(buffer types.CommandBuffer)
 	ReleaseCommandEncoder(encoder types.CommandEncoder)
 	ReleaseRenderPass(pass types.RenderPass)
+	ReleaseComputePipeline(pipeline types.ComputePipeline)
+	ReleaseComputePass(pass types.ComputePass)
+	ReleaseQuerySet(querySet types.QuerySet)
+	ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder)
+	ReleaseRenderBundle(bundle types.RenderBundle)
 }
 
 // activeBackend is the currently selected backend.
@@ -0,0 +1,266 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+	wgputypes "github.com/gogpu/wgpu/types"
+)
+
+// --- Adapter and device capabilities ---
+// Shared by the Vulkan and Metal backends.
+
+// adapterCaps holds what an adapter reported when it was enumerated.
+type adapterCaps struct {
+	info     types.AdapterInfo
+	features types.Features
+	limits   types.Limits
+}
+
+// deviceCaps holds the features and limits a device was opened with.
+type deviceCaps struct {
+	features types.Features
+	limits   types.Limits
+}
+
+// newAdapterCaps converts the capabilities of an enumerated adapter.
+func newAdapterCaps(exposed *hal.ExposedAdapter) adapterCaps {
+	return adapterCaps{
+		info: types.AdapterInfo{
+			Name:        exposed.Info.Name,
+			Vendor:      exposed.Info.Vendor,
+			Driver:      exposed.Info.Driver,
+			VendorID:    exposed.Info.VendorID,
+			DeviceID:    exposed.Info.DeviceID,
+			Backend:     convertGraphicsAPI(exposed.Info.Backend),
+			AdapterType: convertAdapterType(exposed.Info.DeviceType),
+		},
+		features: convertHALFeatures(exposed.Features),
+		limits:   convertHALLimits(&exposed.Capabilities.Limits),
+	}
+}
+
+// GetAdapterInfo returns information about an adapter.
+func (b *Backend) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	return b.adapterCaps[adapter].info
+}
+
+// GetAdapterFeatures returns the features an adapter supports.
+func (b *Backend) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	return b.adapterCaps[adapter].features
+}
+
+// GetAdapterLimits returns the limits of an adapter.
+func (b *Backend) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	return b.adapterCaps[adapter].limits
+}
+
+// GetDeviceFeatures returns the features enabled on a device.
+func (b *Backend) GetDeviceFeatures(device types.Device) types.Features {
+	return b.deviceCaps[device].features
+}
+
+// GetDeviceLimits returns the limits of a device.
+func (b *Backend) GetDeviceLimits(device types.Device) types.Limits {
+	return b.deviceCaps[device].limits
+}
+
+// GetSurfaceCapabilities returns the formats and modes a surface supports.
+func (b *Backend) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	halSurface, err := b.registry.GetSurface(surface)
+	if err != nil {
+		return types.SurfaceCapabilities{}
+	}
+
+	halAdapter, err := b.registry.GetAdapter(adapter)
+	if err != nil {
+		return types.SurfaceCapabilities{}
+	}
+
+	halCaps := halAdapter.SurfaceCapabilities(halSurface)
+	if halCaps == nil {
+		return types.SurfaceCapabilities{}
+	}
+
+	caps := types.SurfaceCapabilities{
+		Formats:      make([]types.TextureFormat, len(halCaps.Formats)),
+		PresentModes: make([]types.PresentMode, len(halCaps.PresentModes)),
+		AlphaModes:   make([]types.AlphaMode, len(halCaps.AlphaModes)),
+	}
+	for i, format := range halCaps.Formats {
+		caps.Formats[i] = types.TextureFormat(format)
+	}
+	for i, mode := range halCaps.PresentModes {
+		caps.PresentModes[i] = convertHALPresentMode(mode)
+	}
+	for i, mode := range halCaps.AlphaModes {
+		caps.AlphaModes[i] = types.AlphaMode(mode)
+	}
+	return caps
+}
+
+// convertHALPresentMode converts a HAL present mode to gogpu PresentMode.
+func convertHALPresentMode(mode hal.PresentMode) types.PresentMode {
+	switch mode {
+	case hal.PresentModeImmediate:
+		return types.PresentModeImmediate
+	case hal.PresentModeMailbox:
+		return types.PresentModeMailbox
+	default:
+		return types.PresentModeFifo
+	}
+}
+
+// deviceRequirements returns the HAL features and limits to open a device
+// with, and the capabilities the device has then.
+func deviceRequirements(opts *types.DeviceOptions) (wgputypes.Features, wgputypes.Limits, deviceCaps) {
+	caps := deviceCaps{limits: types.DefaultLimits()}
+	if opts != nil {
+		caps.features = opts.RequiredFeatures
+		if opts.RequiredLimits != nil {
+			caps.limits = *opts.RequiredLimits
+		}
+	}
+	return convertFeatures(caps.features), convertLimits(&caps.limits), caps
+}
+
+// featureNames maps gogpu features to HAL features.
+var featureNames = []struct {
+	feature types.Features
+	hal     wgputypes.Features
+}{
+	{types.FeatureDepthClipControl, wgputypes.Features(wgputypes.FeatureDepthClipControl)},
+	{types.FeatureDepth32FloatStencil8, wgputypes.Features(wgputypes.FeatureDepth32FloatStencil8)},
+	{types.FeatureTimestampQuery, wgputypes.Features(wgputypes.FeatureTimestampQuery)},
+	{types.FeatureTextureCompressionBC, wgputypes.Features(wgputypes.FeatureTextureCompressionBC)},
+	{types.FeatureTextureCompressionETC2, wgputypes.Features(wgputypes.FeatureTextureCompressionETC2)},
+	{types.FeatureTextureCompressionASTC, wgputypes.Features(wgputypes.FeatureTextureCompressionASTC)},
+	{types.FeatureIndirectFirstInstance, wgputypes.Features(wgputypes.FeatureIndirectFirstInstance)},
+	{types.FeatureShaderF16, wgputypes.Features(wgputypes.FeatureShaderF16)},
+	{types.FeatureRG11B10UfloatRenderable, wgputypes.Features(wgputypes.FeatureRG11B10UfloatRenderable)},
+	{types.FeatureBGRA8UnormStorage, wgputypes.Features(wgputypes.FeatureBGRA8UnormStorage)},
+	{types.FeatureFloat32Filterable, wgputypes.Features(wgputypes.FeatureFloat32Filterable)},
+}
+
+// convertHALFeatures converts HAL features to gogpu Features.
+func convertHALFeatures(features wgputypes.Features) types.Features {
+	var result types.Features
+	for _, f := range featureNames {
+		if features&f.hal != 0 {
+			result |= f.feature
+		}
+	}
+	return result
+}
+
+// convertFeatures converts gogpu Features to HAL features.
+func convertFeatures(features types.Features) wgputypes.Features {
+	var result wgputypes.Features
+	for _, f := range featureNames {
+		if features.Has(f.feature) {
+			result |= f.hal
+		}
+	}
+	return result
+}
+
+// convertGraphicsAPI converts a HAL backend to gogpu GraphicsAPI.
+func convertGraphicsAPI(backend wgputypes.Backend) types.GraphicsAPI {
+	switch backend {
+	case wgputypes.BackendVulkan:
+		return types.GraphicsAPIVulkan
+	case wgputypes.BackendMetal:
+		return types.GraphicsAPIMetal
+	case wgputypes.BackendDX12:
+		return types.GraphicsAPID3D12
+	case wgputypes.BackendGL:
+		return types.GraphicsAPIOpenGL
+	default:
+		return types.GraphicsAPIUndefined
+	}
+}
+
+// convertAdapterType converts a HAL device type to gogpu AdapterType.
+func convertAdapterType(deviceType wgputypes.DeviceType) types.AdapterType {
+	switch deviceType {
+	case wgputypes.DeviceTypeDiscreteGPU:
+		return types.AdapterTypeDiscreteGPU
+	case wgputypes.DeviceTypeIntegratedGPU:
+		return types.AdapterTypeIntegratedGPU
+	case wgputypes.DeviceTypeVirtualGPU:
+		return types.AdapterTypeVirtualGPU
+	case wgputypes.DeviceTypeCPU:
+		return types.AdapterTypeCPU
+	default:
+		return types.AdapterTypeUnknown
+	}
+}
+
+// convertHALLimits converts HAL limits to gogpu Limits.
+func convertHALLimits(l *wgputypes.Limits) types.Limits {
+	return types.Limits{
+		MaxTextureDimension1D:                     l.MaxTextureDimension1D,
+		MaxTextureDimension2D:                     l.MaxTextureDimension2D,
+		MaxTextureDimension3D:                     l.MaxTextureDimension3D,
+		MaxTextureArrayLayers:                     l.MaxTextureArrayLayers,
+		MaxBindGroups:                             l.MaxBindGroups,
+		MaxBindingsPerBindGroup:                   l.MaxBindingsPerBindGroup,
+		MaxDynamicUniformBuffersPerPipelineLayout: l.MaxDynamicUniformBuffersPerPipelineLayout,
+		MaxDynamicStorageBuffersPerPipelineLayout: l.MaxDynamicStorageBuffersPerPipelineLayout,
+		MaxSampledTexturesPerShaderStage:          l.MaxSampledTexturesPerShaderStage,
+		MaxSamplersPerShaderStage:                 l.MaxSamplersPerShaderStage,
+		MaxStorageBuffersPerShaderStage:           l.MaxStorageBuffersPerShaderStage,
+		MaxStorageTexturesPerShaderStage:          l.MaxStorageTexturesPerShaderStage,
+		MaxUniformBuffersPerShaderStage:           l.MaxUniformBuffersPerShaderStage,
+		MaxUniformBufferBindingSize:               l.MaxUniformBufferBindingSize,
+		MaxStorageBufferBindingSize:               l.MaxStorageBufferBindingSize,
+		MinUniformBufferOffsetAlignment:           l.MinUniformBufferOffsetAlignment,
+		MinStorageBufferOffsetAlignment:           l.MinStorageBufferOffsetAlignment,
+		MaxVertexBuffers:                          l.MaxVertexBuffers,
+		MaxBufferSize:                             l.MaxBufferSize,
+		MaxVertexAttributes:                       l.MaxVertexAttributes,
+		MaxVertexBufferArrayStride:                l.MaxVertexBufferArrayStride,
+		MaxColorAttachments:                       l.MaxColorAttachments,
+		MaxComputeWorkgroupStorageSize:            l.MaxComputeWorkgroupStorageSize,
+		MaxComputeInvocationsPerWorkgroup:         l.MaxComputeInvocationsPerWorkgroup,
+		MaxComputeWorkgroupSizeX:                  l.MaxComputeWorkgroupSizeX,
+		MaxComputeWorkgroupSizeY:                  l.MaxComputeWorkgroupSizeY,
+		MaxComputeWorkgroupSizeZ:                  l.MaxComputeWorkgroupSizeZ,
+		MaxComputeWorkgroupsPerDimension:          l.MaxComputeWorkgroupsPerDimension,
+	}
+}
+
+// convertLimits converts gogpu Limits to HAL limits.
+func convertLimits(l *types.Limits) wgputypes.Limits {
+	limits := wgputypes.DefaultLimits()
+	limits.MaxTextureDimension1D = l.MaxTextureDimension1D
+	limits.MaxTextureDimension2D = l.MaxTextureDimension2D
+	limits.MaxTextureDimension3D = l.MaxTextureDimension3D
+	limits.MaxTextureArrayLayers = l.MaxTextureArrayLayers
+	limits.MaxBindGroups = l.MaxBindGroups
+	limits.MaxBindingsPerBindGroup = l.MaxBindingsPerBindGroup
+	limits.MaxDynamicUniformBuffersPerPipelineLayout = l.MaxDynamicUniformBuffersPerPipelineLayout
+	limits.MaxDynamicStorageBuffersPerPipelineLayout = l.MaxDynamicStorageBuffersPerPipelineLayout
+	limits.MaxSampledTexturesPerShaderStage = l.MaxSampledTexturesPerShaderStage
+	limits.MaxSamplersPerShaderStage = l.MaxSamplersPerShaderStage
+	limits.MaxStorageBuffersPerShaderStage = l.MaxStorageBuffersPerShaderStage
+	limits.MaxStorageTexturesPerShaderStage = l.MaxStorageTexturesPerShaderStage
+	limits.MaxUniformBuffersPerShaderStage = l.MaxUniformBuffersPerShaderStage
+	limits.MaxUniformBufferBindingSize = l.MaxUniformBufferBindingSize
+	limits.MaxStorageBufferBindingSize = l.MaxStorageBufferBindingSize
+	limits.MinUniformBufferOffsetAlignment = l.MinUniformBufferOffsetAlignment
+	limits.MinStorageBufferOffsetAlignment = l.MinStorageBufferOffsetAlignment
+	limits.MaxVertexBuffers = l.MaxVertexBuffers
+	limits.MaxBufferSize = l.MaxBufferSize
+	limits.MaxVertexAttributes = l.MaxVertexAttributes
+	limits.MaxVertexBufferArrayStride = l.MaxVertexBufferArrayStride
+	limits.MaxColorAttachments = l.MaxColorAttachments
+	limits.MaxComputeWorkgroupStorageSize = l.MaxComputeWorkgroupStorageSize
+	limits.MaxComputeInvocationsPerWorkgroup = l.MaxComputeInvocationsPerWorkgroup
+	limits.MaxComputeWorkgroupSizeX = l.MaxComputeWorkgroupSizeX
+	limits.MaxComputeWorkgroupSizeY = l.MaxComputeWorkgroupSizeY
+	limits.MaxComputeWorkgroupSizeZ = l.MaxComputeWorkgroupSizeZ
+	limits.MaxComputeWorkgroupsPerDimension = l.MaxComputeWorkgroupsPerDimension
+	return limits
+}
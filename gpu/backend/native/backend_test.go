@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+	wgputypes "github.com/gogpu/wgpu/types"
 )
 
 // TestBackendNotStub verifies that the native backend is properly implemented
@@ -73,3 +75,98 @@ func TestBackendRegistry(t *testing.T) {
 		t.Fatal("Backend registry is nil")
 	}
 }
+
+// maxFrameAllocs bounds the allocations of a steady-state frame in
+// TestFrameAllocs. The backend reuses its encoders and call arguments,
+// see pool.go; what remains are the handle boxes of the registry and
+// the HAL's own per-call objects and FFI arguments.
+const maxFrameAllocs = 64
+
+// newFrame returns a function that records, submits and releases a frame
+// that clears a texture. Skipped without a GPU; run with -tags software
+// to use the software HAL.
+func newFrame(tb testing.TB) func() {
+	backend := New()
+	if err := backend.Init(); err != nil {
+		tb.Skip(err)
+	}
+	tb.Cleanup(backend.Destroy)
+
+	instance, err := backend.CreateInstance()
+	if err != nil {
+		tb.Skip(err)
+	}
+	adapter, err := backend.RequestAdapter(instance, nil)
+	if err != nil {
+		tb.Skip(err)
+	}
+	device, err := backend.RequestDevice(adapter, nil)
+	if err != nil {
+		tb.Skip(err)
+	}
+	if backend.backend.Variant() == wgputypes.BackendGL {
+		// GL contexts come with surfaces; headless GL devices cannot draw
+		tb.Skip("GL backend without a surface")
+	}
+	queue := backend.GetQueue(device)
+
+	texture, err := backend.CreateTexture(device, &types.TextureDescriptor{
+		Size:          types.Extent3D{Width: 64, Height: 64, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     types.TextureDimension2D,
+		Format:        types.TextureFormatRGBA8Unorm,
+		Usage:         types.TextureUsageRenderAttachment,
+	})
+	if err != nil {
+		tb.Skip(err)
+	}
+	view := backend.CreateTextureView(texture, nil)
+	tb.Cleanup(func() {
+		backend.Poll(device, true)
+		backend.ReleaseTextureView(view)
+		backend.ReleaseTexture(texture)
+	})
+
+	desc := &types.RenderPassDescriptor{
+		ColorAttachments: []types.ColorAttachment{{
+			View:    view,
+			LoadOp:  types.LoadOpClear,
+			StoreOp: types.StoreOpStore,
+		}},
+	}
+
+	return func() {
+		encoder := backend.CreateCommandEncoder(device)
+		pass := backend.BeginRenderPass(encoder, desc)
+		backend.EndRenderPass(pass)
+		backend.ReleaseRenderPass(pass)
+		commands := backend.FinishEncoder(encoder)
+		backend.ReleaseCommandEncoder(encoder)
+		backend.Submit(queue, commands)
+		backend.ReleaseCommandBuffer(commands)
+		backend.Poll(device, false)
+	}
+}
+
+// TestFrameAllocs checks that steady-state frames stay within
+// maxFrameAllocs allocations, with pooled encoders.
+func TestFrameAllocs(t *testing.T) {
+	frame := newFrame(t)
+	for range 8 {
+		frame() // Fill the pools
+	}
+	if allocs := testing.AllocsPerRun(100, frame); allocs > maxFrameAllocs {
+		t.Errorf("frame allocates %v times, want at most %d", allocs, maxFrameAllocs)
+	}
+}
+
+// BenchmarkFrame reports the allocations of the per-frame path.
+func BenchmarkFrame(b *testing.B) {
+	frame := newFrame(b)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		frame()
+	}
+}
@@ -0,0 +1,68 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// --- Render bundle operations ---
+// Shared by the Vulkan and Metal backends. Not implemented yet: the HAL
+// has no render bundles, so they need to be recorded and replayed here.
+
+func (b *Backend) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
+func (b *Backend) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+	// Not implemented yet
+}
+
+func (b *Backend) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	// Not implemented yet
+}
+
+func (b *Backend) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	// Not implemented yet
+}
+
+func (b *Backend) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	// Not implemented yet
+}
+
+func (b *Backend) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+	// Not implemented yet
+}
+
+func (b *Backend) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	return 0
+}
+
+func (b *Backend) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {
+	// Not implemented yet
+}
+
+func (b *Backend) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {
+	// Not implemented yet
+}
+
+func (b *Backend) ReleaseRenderBundle(bundle types.RenderBundle) {
+	// Not implemented yet
+}
@@ -0,0 +1,204 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+	wgputypes "github.com/gogpu/wgpu/types"
+)
+
+// --- Compute operations ---
+// Shared by the Vulkan and Metal backends. The OpenGL fallback has no
+// compute shaders. Compute pipeline states, compute encoders and
+// threadgroup sizes are the HAL's business (gogpu/wgpu); on Metal the
+// threadgroup size of a dispatch comes from the shader's workgroup size.
+
+// CreateComputePipeline creates a compute pipeline.
+func (b *Backend) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	if b.backend.Variant() == wgputypes.BackendGL {
+		return 0, fmt.Errorf("native: compute is not supported by the OpenGL backend")
+	}
+
+	halDevice, err := b.registry.GetDevice(device)
+	if err != nil {
+		return 0, err
+	}
+
+	module, err := b.registry.GetShaderModule(desc.Module)
+	if err != nil {
+		return 0, err
+	}
+
+	var layout hal.PipelineLayout // nil = auto layout
+	if desc.Layout != 0 {
+		layout, err = b.registry.GetPipelineLayout(desc.Layout)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	halDesc := &hal.ComputePipelineDescriptor{
+		Label:  desc.Label,
+		Layout: layout,
+		Compute: hal.ComputeState{
+			Module:     module,
+			EntryPoint: desc.EntryPoint,
+		},
+	}
+
+	pipeline, err := halDevice.CreateComputePipeline(halDesc)
+	if err != nil {
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create compute pipeline: %w", err))
+	}
+
+	handle := b.registry.RegisterComputePipeline(pipeline)
+	if b.validator != nil {
+		b.validator.CreateComputePipeline(handle, desc)
+	}
+	return handle, nil
+}
+
+// BeginComputePass begins a compute pass.
+func (b *Backend) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	if b.validator != nil && b.rejected(b.validator.BeginComputePass(encoder)) {
+		return 0
+	}
+
+	halEncoder, err := b.registry.GetCommandEncoder(encoder)
+	if err != nil {
+		return 0
+	}
+
+	halDesc := &hal.ComputePassDescriptor{}
+	if desc != nil {
+		halDesc.Label = desc.Label
+	}
+
+	pass := halEncoder.BeginComputePass(halDesc)
+
+	handle := b.registry.RegisterComputePass(pass)
+	if b.validator != nil {
+		b.validator.ComputePassBegun(encoder, handle)
+	}
+	return handle
+}
+
+// SetComputePipeline sets the compute pipeline.
+func (b *Backend) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {
+	if b.validator != nil && b.rejected(b.validator.SetComputePipeline(pass, pipeline)) {
+		return
+	}
+
+	halPass, err := b.registry.GetComputePass(pass)
+	if err != nil {
+		return
+	}
+
+	halPipeline, err := b.registry.GetComputePipeline(pipeline)
+	if err != nil {
+		return
+	}
+
+	halPass.SetPipeline(halPipeline)
+}
+
+// SetComputeBindGroup sets a bind group for a compute pass.
+func (b *Backend) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	if b.validator != nil && b.rejected(b.validator.SetComputeBindGroup(pass, index, bindGroup)) {
+		return
+	}
+
+	halPass, err := b.registry.GetComputePass(pass)
+	if err != nil {
+		return
+	}
+
+	halGroup, err := b.registry.GetBindGroup(bindGroup)
+	if err != nil {
+		return
+	}
+
+	if dropsComputeBindGroups(halPass) {
+		// Dispatches would read nothing; report it rather than compute
+		// garbage silently
+		b.rejected(fmt.Errorf("native: Metal compute passes cannot bind groups yet: %w", gpu.ErrNotImplemented))
+		return
+	}
+	halPass.SetBindGroup(index, halGroup, dynamicOffsets)
+}
+
+// DispatchWorkgroups dispatches compute workgroups.
+func (b *Backend) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {
+	if b.validator != nil && b.rejected(b.validator.Dispatch("DispatchWorkgroups", pass, 0)) {
+		return
+	}
+
+	if x == 0 || y == 0 || z == 0 {
+		// An empty dispatch does nothing in WebGPU, but Metal rejects
+		// empty threadgroup grids
+		return
+	}
+
+	halPass, err := b.registry.GetComputePass(pass)
+	if err != nil {
+		return
+	}
+
+	halPass.Dispatch(x, y, z)
+}
+
+// DispatchWorkgroupsIndirect dispatches compute workgroups with counts read from a buffer.
+func (b *Backend) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+	if b.validator != nil && b.rejected(b.validator.Dispatch("DispatchWorkgroupsIndirect", pass, buffer)) {
+		return
+	}
+
+	halPass, err := b.registry.GetComputePass(pass)
+	if err != nil {
+		return
+	}
+
+	halBuffer, err := b.registry.GetBuffer(buffer)
+	if err != nil {
+		return
+	}
+
+	halPass.DispatchIndirect(halBuffer, offset)
+}
+
+// EndComputePass ends a compute pass.
+func (b *Backend) EndComputePass(pass types.ComputePass) {
+	if b.validator != nil && b.rejected(b.validator.EndComputePass(pass)) {
+		return
+	}
+
+	halPass, err := b.registry.GetComputePass(pass)
+	if err != nil {
+		return
+	}
+
+	halPass.End()
+}
+
+func (b *Backend) ReleaseComputePipeline(pipeline types.ComputePipeline) {
+	if b.validator != nil {
+		b.validator.Release(pipeline)
+	}
+	halPipeline, err := b.registry.GetComputePipeline(pipeline)
+	if err == nil && halPipeline != nil {
+		b.destroyAfterGPU(halPipeline)
+	}
+	b.registry.UnregisterComputePipeline(pipeline)
+}
+
+func (b *Backend) ReleaseComputePass(pass types.ComputePass) {
+	if b.validator != nil {
+		b.validator.Release(pass)
+	}
+	// Compute passes are ended, not destroyed
+	b.registry.UnregisterComputePass(pass)
+}
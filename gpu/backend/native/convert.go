@@ -14,6 +14,18 @@ func convertTextureFormat(format gogputypes.TextureFormat) types.TextureFormat {
 	return types.TextureFormat(format)
 }
 
+// convertTextureFormats converts a list of gogpu TextureFormats.
+func convertTextureFormats(formats []gogputypes.TextureFormat) []types.TextureFormat {
+	if len(formats) == 0 {
+		return nil
+	}
+	result := make([]types.TextureFormat, len(formats))
+	for i, f := range formats {
+		result[i] = convertTextureFormat(f)
+	}
+	return result
+}
+
 // convertPresentMode converts gogpu PresentMode to wgpu hal.PresentMode.
 func convertPresentMode(mode gogputypes.PresentMode) hal.PresentMode {
 	switch mode {
@@ -172,8 +184,7 @@ func convertIndexFormat(format gogputypes.IndexFormat) types.IndexFormat { //nol
 }
 
 // convertAddressMode converts gogpu AddressMode to wgpu types.AddressMode.
-// Used by CreateSampler (not yet fully implemented).
-func convertAddressMode(mode gogputypes.AddressMode) types.AddressMode { //nolint:unused
+func convertAddressMode(mode gogputypes.AddressMode) types.AddressMode {
 	switch mode {
 	case gogputypes.AddressModeRepeat:
 		return types.AddressModeRepeat
@@ -187,8 +198,7 @@ func convertAddressMode(mode gogputypes.AddressMode) types.AddressMode { //nolin
 }
 
 // convertFilterMode converts gogpu FilterMode to wgpu types.FilterMode.
-// Used by CreateSampler (not yet fully implemented).
-func convertFilterMode(mode gogputypes.FilterMode) types.FilterMode { //nolint:unused
+func convertFilterMode(mode gogputypes.FilterMode) types.FilterMode {
 	switch mode {
 	case gogputypes.FilterModeNearest:
 		return types.FilterModeNearest
@@ -199,16 +209,40 @@ func convertFilterMode(mode gogputypes.FilterMode) types.FilterMode { //nolint:u
 	}
 }
 
-// convertMipmapFilterMode converts gogpu MipmapFilterMode to wgpu types.MipmapFilterMode.
-// Used by CreateSampler (not yet fully implemented).
-func convertMipmapFilterMode(mode gogputypes.MipmapFilterMode) types.MipmapFilterMode { //nolint:unused
+// convertMipmapFilterMode converts gogpu MipmapFilterMode to the
+// types.FilterMode the HAL sampler descriptor takes.
+func convertMipmapFilterMode(mode gogputypes.MipmapFilterMode) types.FilterMode {
 	switch mode {
 	case gogputypes.MipmapFilterModeNearest:
-		return types.MipmapFilterModeNearest
+		return types.FilterModeNearest
 	case gogputypes.MipmapFilterModeLinear:
-		return types.MipmapFilterModeLinear
+		return types.FilterModeLinear
+	default:
+		return types.FilterModeLinear
+	}
+}
+
+// convertCompareFunction converts gogpu CompareFunction to wgpu types.CompareFunction.
+func convertCompareFunction(fn gogputypes.CompareFunction) types.CompareFunction {
+	switch fn {
+	case gogputypes.CompareFunctionNever:
+		return types.CompareFunctionNever
+	case gogputypes.CompareFunctionLess:
+		return types.CompareFunctionLess
+	case gogputypes.CompareFunctionEqual:
+		return types.CompareFunctionEqual
+	case gogputypes.CompareFunctionLessEqual:
+		return types.CompareFunctionLessEqual
+	case gogputypes.CompareFunctionGreater:
+		return types.CompareFunctionGreater
+	case gogputypes.CompareFunctionNotEqual:
+		return types.CompareFunctionNotEqual
+	case gogputypes.CompareFunctionGreaterEqual:
+		return types.CompareFunctionGreaterEqual
+	case gogputypes.CompareFunctionAlways:
+		return types.CompareFunctionAlways
 	default:
-		return types.MipmapFilterModeLinear
+		return types.CompareFunctionUndefined
 	}
 }
 
@@ -231,8 +265,7 @@ func convertShaderStage(stage gogputypes.ShaderStage) types.ShaderStage { //noli
 }
 
 // convertTextureDimension converts gogpu TextureDimension to wgpu types.TextureDimension.
-// Used by CreateTexture (not yet fully implemented).
-func convertTextureDimension(dim gogputypes.TextureDimension) types.TextureDimension { //nolint:unused
+func convertTextureDimension(dim gogputypes.TextureDimension) types.TextureDimension {
 	switch dim {
 	case gogputypes.TextureDimension1D:
 		return types.TextureDimension1D
@@ -310,3 +343,149 @@ func convertImageDataLayout(layout gogputypes.ImageDataLayout) *hal.ImageDataLay
 		RowsPerImage: layout.RowsPerImage,
 	}
 }
+
+// convertVertexStepMode converts gogpu VertexStepMode to wgpu types.VertexStepMode.
+func convertVertexStepMode(mode gogputypes.VertexStepMode) types.VertexStepMode {
+	if mode == gogputypes.VertexStepModeInstance {
+		return types.VertexStepModeInstance
+	}
+	return types.VertexStepModeVertex
+}
+
+// convertVertexFormat converts gogpu VertexFormat to wgpu types.VertexFormat.
+func convertVertexFormat(format gogputypes.VertexFormat) types.VertexFormat {
+	switch format {
+	case gogputypes.VertexFormatUint8x2:
+		return types.VertexFormatUint8x2
+	case gogputypes.VertexFormatUint8x4:
+		return types.VertexFormatUint8x4
+	case gogputypes.VertexFormatSint8x2:
+		return types.VertexFormatSint8x2
+	case gogputypes.VertexFormatSint8x4:
+		return types.VertexFormatSint8x4
+	case gogputypes.VertexFormatUnorm8x2:
+		return types.VertexFormatUnorm8x2
+	case gogputypes.VertexFormatUnorm8x4:
+		return types.VertexFormatUnorm8x4
+	case gogputypes.VertexFormatSnorm8x2:
+		return types.VertexFormatSnorm8x2
+	case gogputypes.VertexFormatSnorm8x4:
+		return types.VertexFormatSnorm8x4
+	case gogputypes.VertexFormatUint16x2:
+		return types.VertexFormatUint16x2
+	case gogputypes.VertexFormatUint16x4:
+		return types.VertexFormatUint16x4
+	case gogputypes.VertexFormatSint16x2:
+		return types.VertexFormatSint16x2
+	case gogputypes.VertexFormatSint16x4:
+		return types.VertexFormatSint16x4
+	case gogputypes.VertexFormatUnorm16x2:
+		return types.VertexFormatUnorm16x2
+	case gogputypes.VertexFormatUnorm16x4:
+		return types.VertexFormatUnorm16x4
+	case gogputypes.VertexFormatSnorm16x2:
+		return types.VertexFormatSnorm16x2
+	case gogputypes.VertexFormatSnorm16x4:
+		return types.VertexFormatSnorm16x4
+	case gogputypes.VertexFormatFloat16x2:
+		return types.VertexFormatFloat16x2
+	case gogputypes.VertexFormatFloat16x4:
+		return types.VertexFormatFloat16x4
+	case gogputypes.VertexFormatFloat32:
+		return types.VertexFormatFloat32
+	case gogputypes.VertexFormatFloat32x2:
+		return types.VertexFormatFloat32x2
+	case gogputypes.VertexFormatFloat32x3:
+		return types.VertexFormatFloat32x3
+	case gogputypes.VertexFormatFloat32x4:
+		return types.VertexFormatFloat32x4
+	case gogputypes.VertexFormatUint32:
+		return types.VertexFormatUint32
+	case gogputypes.VertexFormatUint32x2:
+		return types.VertexFormatUint32x2
+	case gogputypes.VertexFormatUint32x3:
+		return types.VertexFormatUint32x3
+	case gogputypes.VertexFormatUint32x4:
+		return types.VertexFormatUint32x4
+	case gogputypes.VertexFormatSint32:
+		return types.VertexFormatSint32
+	case gogputypes.VertexFormatSint32x2:
+		return types.VertexFormatSint32x2
+	case gogputypes.VertexFormatSint32x3:
+		return types.VertexFormatSint32x3
+	case gogputypes.VertexFormatSint32x4:
+		return types.VertexFormatSint32x4
+	default:
+		return types.VertexFormatFloat32x4
+	}
+}
+
+// convertBlendState converts gogpu BlendState to wgpu types.BlendState.
+func convertBlendState(state *gogputypes.BlendState) *types.BlendState {
+	if state == nil {
+		return nil
+	}
+	return &types.BlendState{
+		Color: convertBlendComponent(state.Color),
+		Alpha: convertBlendComponent(state.Alpha),
+	}
+}
+
+// convertBlendComponent converts gogpu BlendComponent to wgpu types.BlendComponent.
+func convertBlendComponent(c gogputypes.BlendComponent) types.BlendComponent {
+	return types.BlendComponent{
+		SrcFactor: convertBlendFactor(c.SrcFactor),
+		DstFactor: convertBlendFactor(c.DstFactor),
+		Operation: convertBlendOperation(c.Operation),
+	}
+}
+
+// convertBlendFactor converts gogpu BlendFactor to wgpu types.BlendFactor.
+func convertBlendFactor(factor gogputypes.BlendFactor) types.BlendFactor {
+	switch factor {
+	case gogputypes.BlendFactorZero:
+		return types.BlendFactorZero
+	case gogputypes.BlendFactorOne:
+		return types.BlendFactorOne
+	case gogputypes.BlendFactorSrc:
+		return types.BlendFactorSrc
+	case gogputypes.BlendFactorOneMinusSrc:
+		return types.BlendFactorOneMinusSrc
+	case gogputypes.BlendFactorSrcAlpha:
+		return types.BlendFactorSrcAlpha
+	case gogputypes.BlendFactorOneMinusSrcAlpha:
+		return types.BlendFactorOneMinusSrcAlpha
+	case gogputypes.BlendFactorDst:
+		return types.BlendFactorDst
+	case gogputypes.BlendFactorOneMinusDst:
+		return types.BlendFactorOneMinusDst
+	case gogputypes.BlendFactorDstAlpha:
+		return types.BlendFactorDstAlpha
+	case gogputypes.BlendFactorOneMinusDstAlpha:
+		return types.BlendFactorOneMinusDstAlpha
+	case gogputypes.BlendFactorSrcAlphaSaturated:
+		return types.BlendFactorSrcAlphaSaturated
+	case gogputypes.BlendFactorConstant:
+		return types.BlendFactorConstant
+	case gogputypes.BlendFactorOneMinusConstant:
+		return types.BlendFactorOneMinusConstant
+	default:
+		return types.BlendFactorOne
+	}
+}
+
+// convertBlendOperation converts gogpu BlendOperation to wgpu types.BlendOperation.
+func convertBlendOperation(op gogputypes.BlendOperation) types.BlendOperation {
+	switch op {
+	case gogputypes.BlendOperationSubtract:
+		return types.BlendOperationSubtract
+	case gogputypes.BlendOperationReverseSubtract:
+		return types.BlendOperationReverseSubtract
+	case gogputypes.BlendOperationMin:
+		return types.BlendOperationMin
+	case gogputypes.BlendOperationMax:
+		return types.BlendOperationMax
+	default:
+		return types.BlendOperationAdd
+	}
+}
@@ -0,0 +1,38 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// --- Debug operations ---
+// Shared by the Vulkan and Metal backends. The HAL of gogpu/wgpu v0.8.6
+// has no debug groups or markers, so these do nothing.
+
+// PushDebugGroup opens a debug group on a command encoder.
+func (b *Backend) PushDebugGroup(encoder types.CommandEncoder, label string) {}
+
+// PopDebugGroup closes the innermost debug group on a command encoder.
+func (b *Backend) PopDebugGroup(encoder types.CommandEncoder) {}
+
+// InsertDebugMarker inserts a debug marker on a command encoder.
+func (b *Backend) InsertDebugMarker(encoder types.CommandEncoder, label string) {}
+
+// PushRenderPassDebugGroup opens a debug group on a render pass.
+func (b *Backend) PushRenderPassDebugGroup(pass types.RenderPass, label string) {}
+
+// PopRenderPassDebugGroup closes the innermost debug group on a render pass.
+func (b *Backend) PopRenderPassDebugGroup(pass types.RenderPass) {}
+
+// InsertRenderPassDebugMarker inserts a debug marker on a render pass.
+func (b *Backend) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {}
+
+// PushComputePassDebugGroup opens a debug group on a compute pass.
+func (b *Backend) PushComputePassDebugGroup(pass types.ComputePass, label string) {}
+
+// PopComputePassDebugGroup closes the innermost debug group on a compute pass.
+func (b *Backend) PopComputePassDebugGroup(pass types.ComputePass) {}
+
+// InsertComputePassDebugMarker inserts a debug marker on a compute pass.
+func (b *Backend) InsertComputePassDebugMarker(pass types.ComputePass, label string) {}
@@ -0,0 +1,76 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"errors"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// --- Error handling ---
+// Shared by the Vulkan and Metal backends. The HAL does not validate, so
+// error scopes are emulated with gpu.ErrorScopes and fed the errors of
+// failed HAL calls.
+
+// errorScopes returns the error scope stack of a device.
+func (b *Backend) errorScopes(device types.Device) *gpu.ErrorScopes {
+	scopes := b.scopes[device]
+	if scopes == nil {
+		scopes = &gpu.ErrorScopes{}
+		b.scopes[device] = scopes
+	}
+	return scopes
+}
+
+// reportError reports a failed HAL call on a device as a validation error,
+// or the loss of the device. It returns err, so failure paths can report
+// and return in one step.
+func (b *Backend) reportError(device types.Device, err error) error {
+	if errors.Is(err, hal.ErrDeviceLost) {
+		b.deviceLost(device, err)
+		return err
+	}
+
+	b.errorScopes(device).Report(&types.Error{
+		Type:    types.ErrorFilterValidation,
+		Message: err.Error(),
+		Err:     err,
+	})
+	return err
+}
+
+// deviceLost calls the device-lost callback of a device, once.
+func (b *Backend) deviceLost(device types.Device, err error) {
+	callback := b.lostCallbacks[device]
+	if callback == nil {
+		return
+	}
+	delete(b.lostCallbacks, device)
+	callback(types.DeviceLostReasonUnknown, err.Error())
+}
+
+// SetDeviceLostCallback sets the function called once when the device
+// is lost.
+func (b *Backend) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+	b.lostCallbacks[device] = callback
+}
+
+// PushErrorScope opens an error scope on a device.
+func (b *Backend) PushErrorScope(device types.Device, filter types.ErrorFilter) {
+	b.errorScopes(device).Push(filter)
+}
+
+// PopErrorScope closes the innermost error scope of a device and returns
+// the first error it captured.
+func (b *Backend) PopErrorScope(device types.Device) error {
+	return b.errorScopes(device).Pop()
+}
+
+// SetUncapturedErrorCallback sets the callback for device errors no
+// error scope captures.
+func (b *Backend) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {
+	b.errorScopes(device).SetUncapturedCallback(callback)
+}
@@ -0,0 +1,98 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"slices"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// --- Frames in flight ---
+// Shared by the Vulkan and Metal backends. Each surface has a ring of
+// frame slots holding the last submission of the frame presented in
+// them. GetCurrentTexture waits only for the frame that used the next
+// slot, so the CPU records a frame while the GPU still renders the ones
+// before, up to the number of slots. Present then recycles the objects
+// released by frames that have completed, without the application
+// polling.
+
+// frameRing paces the frames of one surface.
+type frameRing struct {
+	queue types.Queue
+	slots []types.SubmissionIndex // Last submission of the frame in each slot, 0 if none
+	next  int                     // Slot of the frame being recorded
+}
+
+// SetMaxFramesInFlight sets how many frames may be pending on the GPU,
+// see gpu.FramePacer. Surfaces already presenting keep their count.
+func (b *Backend) SetMaxFramesInFlight(n int) {
+	b.framesInFlight = max(n, 1)
+}
+
+// frameRing returns the frame ring of surface, creating it once the
+// surface is configured.
+func (b *Backend) frameRing(surface types.Surface) *frameRing {
+	if ring := b.frames[surface]; ring != nil {
+		return ring
+	}
+	device, err := b.registry.GetDeviceForSurface(surface)
+	if err != nil {
+		return nil
+	}
+	queue, err := b.registry.GetQueueForDevice(device)
+	if err != nil {
+		return nil
+	}
+	n := b.framesInFlight
+	if n == 0 {
+		n = gpu.DefaultFramesInFlight
+	}
+	ring := &frameRing{queue: queue, slots: make([]types.SubmissionIndex, n)}
+	b.frames[surface] = ring
+	return ring
+}
+
+// waitForFrameSlot waits until the frame that last used the next slot of
+// surface has completed. Called by GetCurrentTexture before acquiring.
+func (b *Backend) waitForFrameSlot(surface types.Surface) {
+	ring := b.frameRing(surface)
+	if ring == nil {
+		return
+	}
+	subs := b.submissions[ring.queue]
+	if index := ring.slots[ring.next]; subs != nil && index != 0 {
+		subs.reached(index, true)
+	}
+}
+
+// framePresented records the work submitted so far as the frame of the
+// current slot of surface and moves to the next. Called by Present.
+func (b *Backend) framePresented(surface types.Surface) {
+	ring := b.frameRing(surface)
+	if ring == nil {
+		return
+	}
+	if subs := b.submissions[ring.queue]; subs != nil {
+		ring.slots[ring.next] = subs.submitted
+		subs.runCallbacks()
+	}
+	ring.next = (ring.next + 1) % len(ring.slots)
+	b.runDeferredDestroys(false)
+}
+
+// surfaceReleased waits for the frames in flight on surface and forgets
+// its ring. Called by ReleaseSurface before the surface is destroyed.
+func (b *Backend) surfaceReleased(surface types.Surface) {
+	ring := b.frames[surface]
+	if ring == nil {
+		return
+	}
+	delete(b.frames, surface)
+	if subs := b.submissions[ring.queue]; subs != nil {
+		subs.reached(slices.Max(ring.slots), true)
+	}
+}
+
+var _ gpu.FramePacer = (*Backend)(nil)
@@ -0,0 +1,70 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/wgpu/hal"
+	wgputypes "github.com/gogpu/wgpu/types"
+)
+
+// --- HAL backend selection ---
+// CreateInstance tries the HAL backends of halBackends in order and uses
+// the first one exposing an adapter. The lists are in the
+// halbackends_<GOOS>.go files; halbackends_software.go appends the
+// software HAL in builds with -tags software.
+
+// createHALInstance creates an instance of the first HAL backend that
+// exposes at least one adapter, and makes it the backend in use.
+func (b *Backend) createHALInstance() (hal.Instance, error) {
+	b.rejections = nil
+	var errs []error
+	for _, backend := range halBackends {
+		desc := &hal.InstanceDescriptor{
+			Backends: wgputypes.Backends(1 << backend.Variant()),
+			Flags:    0, // No debug for now
+		}
+
+		instance, err := backend.CreateInstance(desc)
+		if err == nil && len(instance.EnumerateAdapters(nil)) == 0 {
+			instance.Destroy()
+			err = errors.New("no adapters found")
+		}
+		if err != nil {
+			b.rejections = append(b.rejections, gpu.Rejection{Name: "native/" + halBackendName(backend), Reason: err})
+			errs = append(errs, fmt.Errorf("%s: %w", halBackendName(backend), err))
+			continue
+		}
+
+		b.backend = backend
+		return instance, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// halBackendName returns the package name of a HAL backend.
+func halBackendName(backend hal.Backend) string {
+	switch backend.Variant() {
+	case wgputypes.BackendVulkan:
+		return "vulkan"
+	case wgputypes.BackendDX12:
+		return "dx12"
+	case wgputypes.BackendGL:
+		return "gles"
+	case wgputypes.BackendMetal:
+		return "metal"
+	default:
+		return "software"
+	}
+}
+
+// Rejections returns the HAL backends the last CreateInstance tried and
+// rejected before the one in use, in order.
+func (b *Backend) Rejections() []gpu.Rejection {
+	return b.rejections
+}
+
+var _ gpu.RejectionReporter = (*Backend)(nil)
@@ -0,0 +1,21 @@
+//go:build darwin
+
+package native
+
+import (
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/metal"
+)
+
+// halBackends lists the HAL backends CreateInstance tries, in order.
+// Metal is the only GPU backend on macOS.
+var halBackends = []hal.Backend{
+	metal.Backend{},
+}
+
+// dropsComputeBindGroups reports whether pass ignores SetBindGroup: the
+// Metal compute encoder of gogpu/wgpu v0.8.6 does not bind groups yet.
+func dropsComputeBindGroups(pass hal.ComputePassEncoder) bool {
+	_, ok := pass.(*metal.ComputePassEncoder)
+	return ok
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package native
+
+import (
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/gles"
+	"github.com/gogpu/wgpu/hal/vulkan"
+)
+
+// halBackends lists the HAL backends CreateInstance tries, in order:
+// Vulkan, then OpenGL 3.3 / OpenGL ES 3.0 through EGL or GLX for machines
+// and VMs without a Vulkan driver. The GL backend has no compute shaders.
+var halBackends = []hal.Backend{
+	vulkan.Backend{},
+	gles.Backend{},
+}
+
+// dropsComputeBindGroups reports whether pass ignores SetBindGroup; the
+// HAL backends here all bind groups in compute passes.
+func dropsComputeBindGroups(pass hal.ComputePassEncoder) bool {
+	return false
+}
@@ -0,0 +1,15 @@
+//go:build software && (windows || linux || darwin)
+
+package native
+
+import (
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+// With -tags software, the software HAL is the last resort for machines
+// without any usable GPU driver, such as CI runners. In gogpu/wgpu
+// v0.8.6 it clears, copies and reads back textures, but does not
+// rasterize draws.
+func init() {
+	halBackends = append(halBackends, software.API{})
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package native
+
+import (
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/dx12"
+	"github.com/gogpu/wgpu/hal/gles"
+	"github.com/gogpu/wgpu/hal/vulkan"
+)
+
+// halBackends lists the HAL backends CreateInstance tries, in order:
+// Vulkan, then Direct3D 12 through d3d12.dll and dxgi.dll, which every
+// Windows 10 and later machine has, for machines without a Vulkan
+// driver; then OpenGL 3.3 through WGL. The GL backend has no compute
+// shaders.
+var halBackends = []hal.Backend{
+	vulkan.Backend{},
+	dx12.Backend{},
+	gles.Backend{},
+}
+
+// dropsComputeBindGroups reports whether pass ignores SetBindGroup; the
+// HAL backends here all bind groups in compute passes.
+func dropsComputeBindGroups(pass hal.ComputePassEncoder) bool {
+	return false
+}
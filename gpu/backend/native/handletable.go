@@ -0,0 +1,177 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// --- Handle tables ---
+// Each resource type has its own handleTable, a slab of slots addressed
+// by handle. A handle packs the slot index and the generation of the
+// slot: releasing a resource bumps the generation, so stale handles are
+// detected even after the slot has been reused. Lookups take no lock.
+
+const (
+	// handleIndexBits is the number of low handle bits holding the slot
+	// index, which allows 16M live resources of each type. The
+	// generation takes the remaining bits.
+	handleIndexBits = 24
+	handleIndexMask = 1<<handleIndexBits - 1
+
+	// handleChunkSize is the number of slots allocated at a time. Chunks
+	// never move, so lookups may use a slot while the table grows.
+	handleChunkBits = 10
+	handleChunkSize = 1 << handleChunkBits
+)
+
+// handleGenerationMask keeps generations within the bits of a handle.
+const handleGenerationMask = 1<<(bits.UintSize-handleIndexBits) - 1
+
+var (
+	// errInvalidHandle is returned for handles that were never registered.
+	errInvalidHandle = errors.New("invalid handle")
+
+	// errReleasedHandle is returned for handles of released resources.
+	errReleasedHandle = errors.New("handle of a released resource")
+)
+
+// handleTable maps handles of type H to values of type T.
+type handleTable[H ~uintptr, T any] struct {
+	// Serializes register and unregister
+	mu   sync.Mutex
+	free []uint32 // Released slots
+	next uint32   // First slot never used
+
+	chunks atomic.Pointer[[]*handleChunk[T]]
+}
+
+// handleChunk is a block of slots.
+type handleChunk[T any] [handleChunkSize]handleSlot[T]
+
+// handleSlot holds one value. value is nil while the slot is free.
+type handleSlot[T any] struct {
+	generation atomic.Uintptr
+	value      atomic.Pointer[T]
+}
+
+// register stores value in a free slot and returns its handle, or 0 if
+// all slots are taken.
+func (t *handleTable[H, T]) register(value T) H {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var index uint32
+	if n := len(t.free); n > 0 {
+		index = t.free[n-1]
+		t.free = t.free[:n-1]
+	} else {
+		if t.next > handleIndexMask-1 {
+			return 0
+		}
+		index = t.next
+		t.next++
+		t.grow(index)
+	}
+
+	slot := t.slot(index)
+	slot.value.Store(&value)
+	return makeHandle[H](index, slot.generation.Load())
+}
+
+// grow adds a chunk if index lies beyond the last one. Called with t.mu
+// held.
+func (t *handleTable[H, T]) grow(index uint32) {
+	var chunks []*handleChunk[T]
+	if p := t.chunks.Load(); p != nil {
+		chunks = *p
+	}
+	if int(index>>handleChunkBits) < len(chunks) {
+		return
+	}
+	grown := append(chunks[:len(chunks):len(chunks)], new(handleChunk[T]))
+	t.chunks.Store(&grown)
+}
+
+// slot returns the slot of index, or nil if it was never allocated.
+func (t *handleTable[H, T]) slot(index uint32) *handleSlot[T] {
+	p := t.chunks.Load()
+	if p == nil {
+		return nil
+	}
+	chunk := int(index >> handleChunkBits)
+	if chunk >= len(*p) {
+		return nil
+	}
+	return &(*p)[chunk][index&(handleChunkSize-1)]
+}
+
+// get returns the value of handle.
+func (t *handleTable[H, T]) get(handle H) (T, error) {
+	var zero T
+	index, generation, ok := splitHandle(handle)
+	if !ok {
+		return zero, errInvalidHandle
+	}
+	slot := t.slot(index)
+	if slot == nil {
+		return zero, errInvalidHandle
+	}
+	// The generation is checked after loading the value: if the slot
+	// was released or reused in between, the generation has moved on
+	value := slot.value.Load()
+	if value == nil || slot.generation.Load() != generation {
+		return zero, errReleasedHandle
+	}
+	return *value, nil
+}
+
+// unregister frees the slot of handle. Stale handles are ignored.
+func (t *handleTable[H, T]) unregister(handle H) {
+	index, generation, ok := splitHandle(handle)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	slot := t.slot(index)
+	if slot == nil || slot.generation.Load() != generation || slot.value.Load() == nil {
+		return
+	}
+	slot.generation.Store((generation + 1) & handleGenerationMask)
+	slot.value.Store(nil)
+	t.free = append(t.free, index)
+}
+
+// clear frees all slots. Handles issued before stay invalid.
+func (t *handleTable[H, T]) clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for index := range t.next {
+		slot := t.slot(index)
+		if slot.value.Load() == nil {
+			continue
+		}
+		slot.generation.Store((slot.generation.Load() + 1) & handleGenerationMask)
+		slot.value.Store(nil)
+		t.free = append(t.free, index)
+	}
+}
+
+// makeHandle packs a slot index and generation into a handle. Indices
+// are stored plus one, so no handle is 0.
+func makeHandle[H ~uintptr](index uint32, generation uintptr) H {
+	return H(generation<<handleIndexBits | uintptr(index+1))
+}
+
+// splitHandle unpacks a handle made by makeHandle.
+func splitHandle[H ~uintptr](handle H) (index uint32, generation uintptr, ok bool) {
+	i := uintptr(handle) & handleIndexMask
+	if i == 0 {
+		return 0, 0, false
+	}
+	return uint32(i - 1), uintptr(handle) >> handleIndexBits, true
+}
@@ -0,0 +1,81 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type testHandle uintptr
+
+func TestHandleTable(t *testing.T) {
+	var table handleTable[testHandle, string]
+
+	a := table.register("a")
+	b := table.register("b")
+	if a == 0 || b == 0 || a == b {
+		t.Fatalf("handles %d and %d, want distinct non-zero handles", a, b)
+	}
+	if v, err := table.get(b); err != nil || v != "b" {
+		t.Errorf("get(b) = %q, %v", v, err)
+	}
+
+	table.unregister(a)
+	if _, err := table.get(a); !errors.Is(err, errReleasedHandle) {
+		t.Errorf("get of a released handle: err = %v, want errReleasedHandle", err)
+	}
+
+	// The slot of a is reused, but a stays invalid
+	c := table.register("c")
+	if c == a {
+		t.Fatal("reused slot got the released handle")
+	}
+	if _, err := table.get(a); !errors.Is(err, errReleasedHandle) {
+		t.Errorf("get of a reused handle: err = %v, want errReleasedHandle", err)
+	}
+	if v, _ := table.get(c); v != "c" {
+		t.Errorf("get(c) = %q", v)
+	}
+
+	if _, err := table.get(0); !errors.Is(err, errInvalidHandle) {
+		t.Errorf("get(0): err = %v, want errInvalidHandle", err)
+	}
+	if _, err := table.get(1 << 20); !errors.Is(err, errInvalidHandle) {
+		t.Errorf("get of an unallocated slot: err = %v, want errInvalidHandle", err)
+	}
+
+	table.clear()
+	if _, err := table.get(b); err == nil {
+		t.Error("handle valid after clear")
+	}
+}
+
+func TestHandleTableConcurrent(t *testing.T) {
+	var table handleTable[testHandle, int]
+	keep := table.register(-1)
+
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 3 * handleChunkSize {
+				h := table.register(g*1e6 + i)
+				if v, err := table.get(h); err != nil || v != g*1e6+i {
+					t.Errorf("get = %d, %v", v, err)
+					return
+				}
+				if v, _ := table.get(keep); v != -1 {
+					t.Errorf("get(keep) = %d", v)
+					return
+				}
+				if i%2 == 0 {
+					table.unregister(h)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
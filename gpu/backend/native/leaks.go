@@ -0,0 +1,113 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"cmp"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu"
+)
+
+// --- Leak detection ---
+// Shared by the Vulkan and Metal backends. With EnableLeakDetection the
+// registry records the creation stack of every resource the Backend
+// interface can release, and Destroy reports those still registered.
+
+// leakStackDepth is the number of frames recorded per resource.
+const leakStackDepth = 32
+
+// leakKey identifies a registered resource.
+type leakKey struct {
+	kind   string
+	handle uintptr
+}
+
+// leakTracker records the creation stacks of live resources.
+type leakTracker struct {
+	mu   sync.Mutex
+	live map[leakKey][]uintptr // Program counters of the creation stack
+}
+
+// created records that handle of kind was registered by the caller of
+// the caller of created.
+func (t *leakTracker) created(kind string, handle uintptr) {
+	if handle == 0 {
+		return
+	}
+	pcs := make([]uintptr, leakStackDepth)
+	// Skip runtime.Callers, created and the Register method
+	pcs = pcs[:runtime.Callers(3, pcs)]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.live[leakKey{kind, handle}] = pcs
+}
+
+// released forgets handle of kind.
+func (t *leakTracker) released(kind string, handle uintptr) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.live, leakKey{kind, handle})
+}
+
+// clear forgets all resources.
+func (t *leakTracker) clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	clear(t.live)
+}
+
+// leaks returns the live resources, ordered by kind and handle.
+func (t *leakTracker) leaks() []gpu.Leak {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	leaks := make([]gpu.Leak, 0, len(t.live))
+	for key, pcs := range t.live {
+		leaks = append(leaks, gpu.Leak{Kind: key.kind, Handle: key.handle, Stack: formatStack(pcs)})
+	}
+	slices.SortFunc(leaks, func(a, b gpu.Leak) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Handle, b.Handle))
+	})
+	return leaks
+}
+
+// formatStack formats program counters like a panic stack trace.
+func formatStack(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			return sb.String()
+		}
+	}
+}
+
+// EnableLeakDetection makes the backend record where resources are
+// created and report those not released to report on Destroy.
+func (b *Backend) EnableLeakDetection(report func(leaks []gpu.Leak)) {
+	b.leakReport = report
+	b.registry.leaks.Store(&leakTracker{live: make(map[leakKey][]uintptr)})
+}
+
+// reportLeaks passes the resources still registered to the leak report
+// function. Called by Destroy before the registry is cleared.
+func (b *Backend) reportLeaks() {
+	t := b.registry.leaks.Load()
+	if t == nil || b.leakReport == nil {
+		return
+	}
+	if leaks := t.leaks(); len(leaks) > 0 {
+		b.leakReport(leaks)
+	}
+}
+
+var _ gpu.LeakDetector = (*Backend)(nil)
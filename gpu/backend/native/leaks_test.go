@@ -0,0 +1,36 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLeakTracker(t *testing.T) {
+	tracker := &leakTracker{live: make(map[leakKey][]uintptr)}
+	register := func(kind string, handle uintptr) {
+		tracker.created(kind, handle) // Skipped like a Register method
+	}
+
+	register("texture", 2)
+	register("buffer", 1)
+	register("texture", 1)
+	tracker.released("texture", 2)
+
+	leaks := tracker.leaks()
+	if len(leaks) != 2 {
+		t.Fatalf("%d leaks, want 2: %v", len(leaks), leaks)
+	}
+	if leaks[0].Kind != "buffer" || leaks[1].Kind != "texture" || leaks[1].Handle != 1 {
+		t.Errorf("leaks = %v, want buffer 1 and texture 1", leaks)
+	}
+	if first, _, _ := strings.Cut(leaks[0].Stack, "\n"); !strings.HasSuffix(first, ".TestLeakTracker") {
+		t.Errorf("stack does not start at the creating function:\n%s", leaks[0].Stack)
+	}
+
+	tracker.clear()
+	if leaks := tracker.leaks(); len(leaks) != 0 {
+		t.Errorf("leaks after clear = %v", leaks)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// --- Memory accounting ---
+// The registry records the estimated size of every texture and buffer
+// created through the backend and sums them per device in a
+// gpu.MemoryTracker. Surface textures belong to the swapchain and are
+// not counted.
+
+// allocation is the memory of one resource.
+type allocation struct {
+	device types.Device
+	bytes  uint64
+}
+
+// memoryBudgeter is implemented by HAL adapters that report how much
+// memory the application may use, such as Vulkan adapters with
+// VK_EXT_memory_budget.
+type memoryBudgeter interface {
+	MemoryBudget() uint64
+}
+
+// adapterMemoryBudget returns the memory budget of adapter, or 0 if it
+// reports none.
+func adapterMemoryBudget(adapter hal.Adapter) uint64 {
+	if mb, ok := adapter.(memoryBudgeter); ok {
+		return mb.MemoryBudget()
+	}
+	return 0
+}
+
+// MemoryTracker returns the memory tracker of device, creating it on
+// first use.
+func (r *ResourceRegistry) MemoryTracker(device types.Device) *gpu.MemoryTracker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tracker(device)
+}
+
+// tracker returns the memory tracker of device. Called with r.mu held.
+func (r *ResourceRegistry) tracker(device types.Device) *gpu.MemoryTracker {
+	t := r.memory[device]
+	if t == nil {
+		t = &gpu.MemoryTracker{}
+		r.memory[device] = t
+	}
+	return t
+}
+
+// TrackTexture accounts for a texture of bytes created on device, until
+// it is unregistered.
+func (r *ResourceRegistry) TrackTexture(handle types.Texture, device types.Device, bytes uint64) {
+	r.mu.Lock()
+	r.textureMemory[handle] = allocation{device: device, bytes: bytes}
+	t := r.tracker(device)
+	r.mu.Unlock()
+	t.AddTexture(bytes)
+}
+
+// TrackBuffer accounts for a buffer of bytes created on device, until it
+// is unregistered.
+func (r *ResourceRegistry) TrackBuffer(handle types.Buffer, device types.Device, bytes uint64) {
+	r.mu.Lock()
+	r.bufferMemory[handle] = allocation{device: device, bytes: bytes}
+	t := r.tracker(device)
+	r.mu.Unlock()
+	t.AddBuffer(bytes)
+}
+
+func (r *ResourceRegistry) untrackTexture(handle types.Texture) {
+	r.mu.Lock()
+	a, ok := r.textureMemory[handle]
+	delete(r.textureMemory, handle)
+	t := r.memory[a.device]
+	r.mu.Unlock()
+	if ok && t != nil {
+		t.RemoveTexture(a.bytes)
+	}
+}
+
+func (r *ResourceRegistry) untrackBuffer(handle types.Buffer) {
+	r.mu.Lock()
+	a, ok := r.bufferMemory[handle]
+	delete(r.bufferMemory, handle)
+	t := r.memory[a.device]
+	r.mu.Unlock()
+	if ok && t != nil {
+		t.RemoveBuffer(a.bytes)
+	}
+}
+
+// MemoryStats returns the memory taken by the textures and buffers of
+// device.
+func (b *Backend) MemoryStats(device types.Device) gpu.MemoryStats {
+	return b.registry.MemoryTracker(device).Stats()
+}
+
+// SetMemoryWarningCallback sets the function called when device nears
+// its memory budget. Adapters that report no budget never warn.
+func (b *Backend) SetMemoryWarningCallback(device types.Device, callback func(stats gpu.MemoryStats)) {
+	b.registry.MemoryTracker(device).SetWarningCallback(callback)
+}
+
+var _ gpu.MemoryReporter = (*Backend)(nil)
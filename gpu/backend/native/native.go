@@ -54,6 +54,52 @@ func (b *Backend) GetQueue(device types.Device) types.Queue {
 	return 0
 }
 
+// GetAdapterInfo returns information about an adapter.
+func (b *Backend) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	return types.AdapterInfo{}
+}
+
+// GetAdapterFeatures returns the features an adapter supports.
+func (b *Backend) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	return 0
+}
+
+// GetAdapterLimits returns the limits of an adapter.
+func (b *Backend) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	return types.Limits{}
+}
+
+// GetDeviceFeatures returns the features enabled on a device.
+func (b *Backend) GetDeviceFeatures(device types.Device) types.Features {
+	return 0
+}
+
+// GetDeviceLimits returns the limits of a device.
+func (b *Backend) GetDeviceLimits(device types.Device) types.Limits {
+	return types.Limits{}
+}
+
+// PushErrorScope opens an error scope on a device.
+func (b *Backend) PushErrorScope(device types.Device, filter types.ErrorFilter) {
+	// Not implemented
+}
+
+// PopErrorScope closes the innermost error scope of a device.
+func (b *Backend) PopErrorScope(device types.Device) error {
+	return gpu.ErrNotImplemented
+}
+
+// SetUncapturedErrorCallback sets the callback for device errors no
+// error scope captures.
+func (b *Backend) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {
+	// Not implemented
+}
+
+// SetDeviceLostCallback sets the callback for a lost device.
+func (b *Backend) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+	// Not implemented
+}
+
 // CreateSurface creates a rendering surface.
 func (b *Backend) CreateSurface(instance types.Instance, handle types.SurfaceHandle) (types.Surface, error) {
 	return 0, gpu.ErrNotImplemented
@@ -74,11 +120,21 @@ func (b *Backend) Present(surface types.Surface) {
 	// Not implemented
 }
 
+// ReleaseSurface destroys a surface.
+func (b *Backend) ReleaseSurface(surface types.Surface) {
+	// Not implemented
+}
+
 // CreateShaderModuleWGSL creates a shader module from WGSL code.
 func (b *Backend) CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error) {
 	return 0, gpu.ErrNotImplemented
 }
 
+// CreateShaderModuleSPIRV creates a shader module from SPIR-V words.
+func (b *Backend) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
 // CreateRenderPipeline creates a render pipeline.
 func (b *Backend) CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
 	return 0, gpu.ErrNotImplemented
@@ -105,7 +161,42 @@ func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffe
 }
 
 // Submit submits commands to the queue.
-func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) {
+func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
+	return 0
+}
+
+// CopyBufferToBuffer records a copy between buffers.
+func (b *Backend) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+	// Not implemented
+}
+
+// CopyTextureToBuffer records a copy from a texture into a buffer.
+func (b *Backend) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+	// Not implemented
+}
+
+// CopyBufferToTexture records a copy from a buffer into a texture.
+func (b *Backend) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	// Not implemented
+}
+
+// CopyTextureToTexture records a copy between textures.
+func (b *Backend) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	// Not implemented
+}
+
+// ClearBuffer records filling a range of a buffer with zeros.
+func (b *Backend) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+	// Not implemented
+}
+
+// Poll processes completed GPU work.
+func (b *Backend) Poll(device types.Device, wait bool) bool {
+	return true
+}
+
+// OnSubmittedWorkDone calls callback once submitted work has completed.
+func (b *Backend) OnSubmittedWorkDone(queue types.Queue, callback func()) {
 	// Not implemented
 }
 
@@ -124,6 +215,16 @@ func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescript
 	return 0, gpu.ErrNotImplemented
 }
 
+// GetSurfaceCapabilities returns the formats and modes a surface supports.
+func (b *Backend) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	return types.SurfaceCapabilities{}
+}
+
+// SupportedSampleCounts returns the sample counts a texture format supports.
+func (b *Backend) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	return []uint32{1}
+}
+
 // CreateTextureView creates a texture view.
 func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
 	return 0
@@ -149,6 +250,21 @@ func (b *Backend) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uin
 	// Not implemented
 }
 
+// MapBufferAsync maps a buffer range for CPU access.
+func (b *Backend) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	callback(gpu.ErrNotImplemented)
+}
+
+// GetMappedRange returns the mapped memory of a buffer.
+func (b *Backend) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	return nil
+}
+
+// UnmapBuffer unmaps a buffer.
+func (b *Backend) UnmapBuffer(buffer types.Buffer) {
+	// Not implemented
+}
+
 // CreateBindGroupLayout creates a bind group layout.
 func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
 	return 0, gpu.ErrNotImplemented
@@ -184,6 +300,41 @@ func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount,
 	// Not implemented
 }
 
+// CreateComputePipeline creates a compute pipeline.
+func (b *Backend) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
+// BeginComputePass begins a compute pass.
+func (b *Backend) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	return 0
+}
+
+// SetComputePipeline sets the compute pipeline.
+func (b *Backend) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {
+	// Not implemented
+}
+
+// SetComputeBindGroup sets a bind group for a compute pass.
+func (b *Backend) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	// Not implemented
+}
+
+// DispatchWorkgroups dispatches compute workgroups.
+func (b *Backend) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {
+	// Not implemented
+}
+
+// DispatchWorkgroupsIndirect dispatches compute workgroups with counts read from a buffer.
+func (b *Backend) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+	// Not implemented
+}
+
+// EndComputePass ends a compute pass.
+func (b *Backend) EndComputePass(pass types.ComputePass) {
+	// Not implemented
+}
+
 // ReleaseTexture releases a texture.
 func (b *Backend) ReleaseTexture(texture types.Texture) {
 	// Not implemented
@@ -219,6 +370,16 @@ func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout) {
 	// Not implemented
 }
 
+// ReleaseShaderModule releases a shader module.
+func (b *Backend) ReleaseShaderModule(module types.ShaderModule) {
+	// Not implemented
+}
+
+// ReleaseRenderPipeline releases a render pipeline.
+func (b *Backend) ReleaseRenderPipeline(pipeline types.RenderPipeline) {
+	// Not implemented
+}
+
 // ReleaseCommandBuffer releases a command buffer.
 func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer) {
 	// Not implemented
@@ -234,5 +395,195 @@ func (b *Backend) ReleaseRenderPass(pass types.RenderPass) {
 	// Not implemented
 }
 
+// ReleaseComputePipeline releases a compute pipeline.
+func (b *Backend) ReleaseComputePipeline(pipeline types.ComputePipeline) {
+	// Not implemented
+}
+
+// ReleaseComputePass releases a compute pass.
+func (b *Backend) ReleaseComputePass(pass types.ComputePass) {
+	// Not implemented
+}
+
+// PushDebugGroup opens a debug group on a command encoder.
+func (b *Backend) PushDebugGroup(encoder types.CommandEncoder, label string) {
+	// Not implemented
+}
+
+// PopDebugGroup closes the innermost debug group on a command encoder.
+func (b *Backend) PopDebugGroup(encoder types.CommandEncoder) {
+	// Not implemented
+}
+
+// InsertDebugMarker inserts a debug marker on a command encoder.
+func (b *Backend) InsertDebugMarker(encoder types.CommandEncoder, label string) {
+	// Not implemented
+}
+
+// PushRenderPassDebugGroup opens a debug group on a render pass.
+func (b *Backend) PushRenderPassDebugGroup(pass types.RenderPass, label string) {
+	// Not implemented
+}
+
+// PopRenderPassDebugGroup closes the innermost debug group on a render pass.
+func (b *Backend) PopRenderPassDebugGroup(pass types.RenderPass) {
+	// Not implemented
+}
+
+// InsertRenderPassDebugMarker inserts a debug marker on a render pass.
+func (b *Backend) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {
+	// Not implemented
+}
+
+// PushComputePassDebugGroup opens a debug group on a compute pass.
+func (b *Backend) PushComputePassDebugGroup(pass types.ComputePass, label string) {
+	// Not implemented
+}
+
+// PopComputePassDebugGroup closes the innermost debug group on a compute pass.
+func (b *Backend) PopComputePassDebugGroup(pass types.ComputePass) {
+	// Not implemented
+}
+
+// InsertComputePassDebugMarker inserts a debug marker on a compute pass.
+func (b *Backend) InsertComputePassDebugMarker(pass types.ComputePass, label string) {
+	// Not implemented
+}
+
+// CreateQuerySet creates a query set.
+func (b *Backend) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
+// WriteTimestamp records a timestamp query.
+func (b *Backend) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+	// Not implemented
+}
+
+// BeginOcclusionQuery begins an occlusion query.
+func (b *Backend) BeginOcclusionQuery(pass types.RenderPass, index uint32) {
+	// Not implemented
+}
+
+// EndOcclusionQuery ends the current occlusion query.
+func (b *Backend) EndOcclusionQuery(pass types.RenderPass) {
+	// Not implemented
+}
+
+// ResolveQuerySet copies query results into a buffer.
+func (b *Backend) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+	// Not implemented
+}
+
+// ReleaseQuerySet releases a query set.
+func (b *Backend) ReleaseQuerySet(querySet types.QuerySet) {
+	// Not implemented
+}
+
+// CreateRenderBundleEncoder creates a render bundle encoder.
+func (b *Backend) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
+// SetBundlePipeline sets the render pipeline of a render bundle.
+func (b *Backend) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+	// Not implemented
+}
+
+// SetBundleBindGroup sets a bind group of a render bundle.
+func (b *Backend) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	// Not implemented
+}
+
+// SetBundleVertexBuffer sets a vertex buffer of a render bundle.
+func (b *Backend) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+	// Not implemented
+}
+
+// SetBundleIndexBuffer sets the index buffer of a render bundle.
+func (b *Backend) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	// Not implemented
+}
+
+// BundleDraw records a draw call into a render bundle.
+func (b *Backend) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	// Not implemented
+}
+
+// BundleDrawIndexed records an indexed draw call into a render bundle.
+func (b *Backend) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	// Not implemented
+}
+
+// DrawIndirect issues a draw call with arguments read from a buffer.
+func (b *Backend) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	// Not implemented
+}
+
+// DrawIndexedIndirect issues an indexed draw call with arguments read from a buffer.
+func (b *Backend) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	// Not implemented
+}
+
+// SetViewport sets the viewport transform of a render pass.
+func (b *Backend) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+	// Not implemented
+}
+
+// SetScissorRect restricts rendering to a rectangle of the attachments.
+func (b *Backend) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {
+	// Not implemented
+}
+
+// SetBlendConstant sets the color used by BlendFactorConstant.
+func (b *Backend) SetBlendConstant(pass types.RenderPass, color *types.Color) {
+	// Not implemented
+}
+
+// SetStencilReference sets the reference value used by stencil tests.
+func (b *Backend) SetStencilReference(pass types.RenderPass, reference uint32) {
+	// Not implemented
+}
+
+// BundleDrawIndirect records an indirect draw call into a render bundle.
+func (b *Backend) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	// Not implemented
+}
+
+// BundleDrawIndexedIndirect records an indirect indexed draw call into a render bundle.
+func (b *Backend) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	// Not implemented
+}
+
+// SetPushConstants sets push constants for a render pass.
+func (b *Backend) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+	// Not implemented
+}
+
+// SetBundlePushConstants sets push constants in a render bundle.
+func (b *Backend) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+	// Not implemented
+}
+
+// FinishRenderBundle finishes recording a render bundle.
+func (b *Backend) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	return 0
+}
+
+// ExecuteBundles executes render bundles in a render pass.
+func (b *Backend) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {
+	// Not implemented
+}
+
+// ReleaseRenderBundleEncoder releases a render bundle encoder.
+func (b *Backend) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {
+	// Not implemented
+}
+
+// ReleaseRenderBundle releases a render bundle.
+func (b *Backend) ReleaseRenderBundle(bundle types.RenderBundle) {
+	// Not implemented
+}
+
 // Ensure Backend implements gpu.Backend.
 var _ gpu.Backend = (*Backend)(nil)
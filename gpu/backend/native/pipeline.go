@@ -0,0 +1,90 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+	wgputypes "github.com/gogpu/wgpu/types"
+)
+
+// renderPipelineDescriptor builds the HAL descriptor of a render pipeline.
+// Shared by the Vulkan and Metal backends.
+func (b *Backend) renderPipelineDescriptor(desc *types.RenderPipelineDescriptor) (*hal.RenderPipelineDescriptor, error) {
+	vertexShader, err := b.registry.GetShaderModule(desc.VertexShader)
+	if err != nil {
+		return nil, err
+	}
+
+	var layout hal.PipelineLayout // nil = auto layout
+	if desc.Layout != 0 {
+		layout, err = b.registry.GetPipelineLayout(desc.Layout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buffers := make([]wgputypes.VertexBufferLayout, len(desc.VertexBuffers))
+	for i, vb := range desc.VertexBuffers {
+		attributes := make([]wgputypes.VertexAttribute, len(vb.Attributes))
+		for j, attr := range vb.Attributes {
+			attributes[j] = wgputypes.VertexAttribute{
+				Format:         convertVertexFormat(attr.Format),
+				Offset:         attr.Offset,
+				ShaderLocation: attr.ShaderLocation,
+			}
+		}
+		buffers[i] = wgputypes.VertexBufferLayout{
+			ArrayStride: vb.ArrayStride,
+			StepMode:    convertVertexStepMode(vb.StepMode),
+			Attributes:  attributes,
+		}
+	}
+
+	multisample := desc.Multisample.WithDefaults()
+	halDesc := &hal.RenderPipelineDescriptor{
+		Label:  desc.Label,
+		Layout: layout,
+		Vertex: hal.VertexState{
+			Module:     vertexShader,
+			EntryPoint: desc.VertexEntryPoint,
+			Buffers:    buffers,
+		},
+		Primitive: wgputypes.PrimitiveState{
+			Topology:  convertPrimitiveTopology(desc.Topology),
+			FrontFace: convertFrontFace(desc.FrontFace),
+			CullMode:  convertCullMode(desc.CullMode),
+		},
+		DepthStencil: nil,
+		Multisample: wgputypes.MultisampleState{
+			Count:                  multisample.Count,
+			Mask:                   uint64(multisample.Mask),
+			AlphaToCoverageEnabled: multisample.AlphaToCoverageEnabled,
+		},
+	}
+
+	if desc.FragmentShader != 0 {
+		fragmentShader, err := b.registry.GetShaderModule(desc.FragmentShader)
+		if err != nil {
+			return nil, err
+		}
+
+		colorTargets := desc.ColorTargets()
+		targets := make([]wgputypes.ColorTargetState, len(colorTargets))
+		for i, target := range colorTargets {
+			targets[i] = wgputypes.ColorTargetState{
+				Format:    convertTextureFormat(target.Format),
+				Blend:     convertBlendState(target.Blend),
+				WriteMask: wgputypes.ColorWriteMask(target.WriteMask), // Same bits as WebGPU
+			}
+		}
+
+		halDesc.Fragment = &hal.FragmentState{
+			Module:     fragmentShader,
+			EntryPoint: desc.FragmentEntry,
+			Targets:    targets,
+		}
+	}
+
+	return halDesc, nil
+}
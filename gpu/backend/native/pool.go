@@ -0,0 +1,113 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// --- Allocation reuse ---
+// Shared by the Vulkan and Metal backends. The descriptors and slices
+// passed to the HAL on every frame escape to the heap through its
+// interfaces, so they are kept in the backend and reset between calls
+// instead of allocated anew. The HAL does not retain them past a call.
+// Deferred destructions reuse their entries likewise, see
+// submission.go. HAL command encoders are pooled by device and begin
+// recording again once the GPU is done with their last command buffer.
+// The backend creates no bind groups yet, see CreateBindGroup, so there
+// are none to pool. BenchmarkFrame reports what a frame allocates and
+// TestFrameAllocs bounds it.
+
+// callScratch holds the reusable arguments of per-frame HAL calls.
+type callScratch struct {
+	encoderDesc      hal.CommandEncoderDescriptor
+	passDesc         hal.RenderPassDescriptor
+	colorAttachments []hal.RenderPassColorAttachment
+	commandBuffers   []hal.CommandBuffer
+}
+
+// reset drops the references the scratch arguments hold after a call,
+// keeping their capacity, so released HAL objects are not kept alive.
+func (s *callScratch) reset() {
+	s.passDesc = hal.RenderPassDescriptor{}
+	clear(s.colorAttachments)
+	s.colorAttachments = s.colorAttachments[:0]
+	clear(s.commandBuffers)
+	s.commandBuffers = s.commandBuffers[:0]
+}
+
+// encoderPool holds the free HAL command encoders of a device.
+type encoderPool struct {
+	free []*pooledEncoder
+}
+
+// pooledEncoder is a HAL command encoder reused across frames. It is
+// held by its encoder handle and, once finished, by the handle of its
+// command buffer; when both are released it goes back to its pool after
+// the GPU, see Destroy.
+type pooledEncoder struct {
+	pool    *encoderPool
+	encoder hal.CommandEncoder
+	buffer  hal.CommandBuffer // Of the last EndEncoding, until recycled
+	holds   int               // Live handles of the encoder and buffer
+}
+
+// Destroy destroys the command buffer of the encoder and returns the
+// encoder to its pool. destroyAfterGPU calls it once the submissions of
+// the buffer have completed, so the encoder may record again.
+func (e *pooledEncoder) Destroy() {
+	if e.buffer != nil {
+		e.buffer.Destroy()
+		e.buffer = nil
+	}
+	e.pool.free = append(e.pool.free, e)
+}
+
+// acquireEncoder returns a recording command encoder of device, taken
+// from its pool when one is free.
+func (b *Backend) acquireEncoder(device types.Device, halDevice hal.Device) (*pooledEncoder, error) {
+	pool := b.encoderPools[device]
+	if pool == nil {
+		pool = &encoderPool{}
+		b.encoderPools[device] = pool
+	}
+
+	var e *pooledEncoder
+	if n := len(pool.free); n > 0 {
+		e = pool.free[n-1]
+		pool.free[n-1] = nil
+		pool.free = pool.free[:n-1]
+	} else {
+		desc := &b.scratch.encoderDesc
+		desc.Label = "command_encoder"
+		encoder, err := halDevice.CreateCommandEncoder(desc)
+		if err != nil {
+			return nil, err
+		}
+		e = &pooledEncoder{pool: pool, encoder: encoder}
+	}
+
+	// New Metal encoders are recording already; others, and reused
+	// encoders, begin here
+	if r, ok := e.encoder.(interface{ IsRecording() bool }); !ok || !r.IsRecording() {
+		if err := e.encoder.BeginEncoding("command_encoder"); err != nil {
+			return nil, err // Dropped, not pooled
+		}
+	}
+	e.holds = 1
+	return e, nil
+}
+
+// releasePooled drops a handle's hold on a pooled encoder and recycles
+// the encoder after the GPU once neither handle holds it.
+func (b *Backend) releasePooled(e *pooledEncoder) {
+	e.holds--
+	if e.holds > 0 {
+		return
+	}
+	if e.buffer == nil {
+		e.encoder.DiscardEncoding() // Never finished
+	}
+	b.destroyAfterGPU(e)
+}
@@ -0,0 +1,35 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// --- Query operations ---
+// Shared by the Vulkan and Metal backends. Not implemented yet.
+
+func (b *Backend) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	return 0, gpu.ErrNotImplemented
+}
+
+func (b *Backend) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+	// Not implemented yet
+}
+
+func (b *Backend) BeginOcclusionQuery(pass types.RenderPass, index uint32) {
+	// Not implemented yet
+}
+
+func (b *Backend) EndOcclusionQuery(pass types.RenderPass) {
+	// Not implemented yet
+}
+
+func (b *Backend) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) ReleaseQuerySet(querySet types.QuerySet) {
+	// Not implemented yet
+}
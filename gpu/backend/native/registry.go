@@ -3,10 +3,12 @@
 package native
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
 
+	"github.com/gogpu/gogpu/gpu"
 	"github.com/gogpu/gogpu/gpu/types"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -14,32 +16,34 @@ import (
 // ResourceRegistry maps uintptr handles (gogpu) to interface objects (wgpu/hal).
 // This is the bridge between gogpu's handle-based API and wgpu's interface-based HAL.
 //
-// Thread-safe: All operations use sync.RWMutex for concurrent access.
+// Thread-safe: each resource type has its own handleTable, whose lookups
+// take no lock, so the many Get calls of a frame do not contend. Handles
+// carry a generation, so a handle used after its resource was released
+// fails instead of reaching a newer resource in the same slot. The
+// relations between handles, which change rarely, are guarded by mu.
 type ResourceRegistry struct {
-	mu sync.RWMutex
+	// Resource tables - uintptr handles → HAL objects
+	instances        handleTable[types.Instance, hal.Instance]
+	adapters         handleTable[types.Adapter, hal.Adapter]
+	devices          handleTable[types.Device, hal.Device]
+	queues           handleTable[types.Queue, hal.Queue]
+	surfaces         handleTable[types.Surface, hal.Surface]
+	textures         handleTable[types.Texture, hal.Texture]
+	textureViews     handleTable[types.TextureView, hal.TextureView]
+	shaderModules    handleTable[types.ShaderModule, hal.ShaderModule]
+	renderPipelines  handleTable[types.RenderPipeline, hal.RenderPipeline]
+	commandEncoders  handleTable[types.CommandEncoder, hal.CommandEncoder]
+	commandBuffers   handleTable[types.CommandBuffer, hal.CommandBuffer]
+	renderPasses     handleTable[types.RenderPass, hal.RenderPassEncoder]
+	buffers          handleTable[types.Buffer, hal.Buffer]
+	samplers         handleTable[types.Sampler, hal.Sampler]
+	bindGroupLayouts handleTable[types.BindGroupLayout, hal.BindGroupLayout]
+	bindGroups       handleTable[types.BindGroup, hal.BindGroup]
+	pipelineLayouts  handleTable[types.PipelineLayout, hal.PipelineLayout]
+	computePipelines handleTable[types.ComputePipeline, hal.ComputePipeline]
+	computePasses    handleTable[types.ComputePass, hal.ComputePassEncoder]
 
-	// nextHandle generates unique handle IDs.
-	// We start at 1 to avoid confusion with zero values.
-	nextHandle atomic.Uint64
-
-	// Resource maps - uintptr handles → HAL objects
-	instances        map[types.Instance]hal.Instance
-	adapters         map[types.Adapter]hal.Adapter
-	devices          map[types.Device]hal.Device
-	queues           map[types.Queue]hal.Queue
-	surfaces         map[types.Surface]hal.Surface
-	textures         map[types.Texture]hal.Texture
-	textureViews     map[types.TextureView]hal.TextureView
-	shaderModules    map[types.ShaderModule]hal.ShaderModule
-	renderPipelines  map[types.RenderPipeline]hal.RenderPipeline
-	commandEncoders  map[types.CommandEncoder]hal.CommandEncoder
-	commandBuffers   map[types.CommandBuffer]hal.CommandBuffer
-	renderPasses     map[types.RenderPass]hal.RenderPassEncoder
-	buffers          map[types.Buffer]hal.Buffer
-	samplers         map[types.Sampler]hal.Sampler
-	bindGroupLayouts map[types.BindGroupLayout]hal.BindGroupLayout
-	bindGroups       map[types.BindGroup]hal.BindGroup
-	pipelineLayouts  map[types.PipelineLayout]hal.PipelineLayout
+	mu sync.RWMutex
 
 	// Device → Queue mapping (one queue per device in WebGPU)
 	deviceQueues map[types.Device]types.Queue
@@ -50,190 +54,112 @@ type ResourceRegistry struct {
 	// Surface → current SurfaceTexture mapping (for Present)
 	currentSurfaceTextures map[types.Surface]hal.SurfaceTexture
 
-	// Reverse maps for cleanup - HAL objects → handles
-	instanceHandles        map[hal.Instance]types.Instance
-	adapterHandles         map[hal.Adapter]types.Adapter
-	deviceHandles          map[hal.Device]types.Device
-	queueHandles           map[hal.Queue]types.Queue
-	surfaceHandles         map[hal.Surface]types.Surface
-	textureHandles         map[hal.Texture]types.Texture
-	textureViewHandles     map[hal.TextureView]types.TextureView
-	shaderModuleHandles    map[hal.ShaderModule]types.ShaderModule
-	renderPipelineHandles  map[hal.RenderPipeline]types.RenderPipeline
-	commandEncoderHandles  map[hal.CommandEncoder]types.CommandEncoder
-	commandBufferHandles   map[hal.CommandBuffer]types.CommandBuffer
-	renderPassHandles      map[hal.RenderPassEncoder]types.RenderPass
-	bufferHandles          map[hal.Buffer]types.Buffer
-	samplerHandles         map[hal.Sampler]types.Sampler
-	bindGroupLayoutHandles map[hal.BindGroupLayout]types.BindGroupLayout
-	bindGroupHandles       map[hal.BindGroup]types.BindGroup
-	pipelineLayoutHandles  map[hal.PipelineLayout]types.PipelineLayout
+	// Memory accounting, see memory.go
+	memory        map[types.Device]*gpu.MemoryTracker
+	textureMemory map[types.Texture]allocation
+	bufferMemory  map[types.Buffer]allocation
+
+	// Creation stacks of live resources, see leaks.go; nil unless leak
+	// detection is enabled
+	leaks atomic.Pointer[leakTracker]
 }
 
 // NewResourceRegistry creates a new empty registry.
 func NewResourceRegistry() *ResourceRegistry {
-	r := &ResourceRegistry{
-		instances:        make(map[types.Instance]hal.Instance),
-		adapters:         make(map[types.Adapter]hal.Adapter),
-		devices:          make(map[types.Device]hal.Device),
-		queues:           make(map[types.Queue]hal.Queue),
-		surfaces:         make(map[types.Surface]hal.Surface),
-		textures:         make(map[types.Texture]hal.Texture),
-		textureViews:     make(map[types.TextureView]hal.TextureView),
-		shaderModules:    make(map[types.ShaderModule]hal.ShaderModule),
-		renderPipelines:  make(map[types.RenderPipeline]hal.RenderPipeline),
-		commandEncoders:  make(map[types.CommandEncoder]hal.CommandEncoder),
-		commandBuffers:   make(map[types.CommandBuffer]hal.CommandBuffer),
-		renderPasses:     make(map[types.RenderPass]hal.RenderPassEncoder),
-		buffers:          make(map[types.Buffer]hal.Buffer),
-		samplers:         make(map[types.Sampler]hal.Sampler),
-		bindGroupLayouts: make(map[types.BindGroupLayout]hal.BindGroupLayout),
-		bindGroups:       make(map[types.BindGroup]hal.BindGroup),
-		pipelineLayouts:  make(map[types.PipelineLayout]hal.PipelineLayout),
-
+	return &ResourceRegistry{
 		deviceQueues:           make(map[types.Device]types.Queue),
 		surfaceDevices:         make(map[types.Surface]types.Device),
 		currentSurfaceTextures: make(map[types.Surface]hal.SurfaceTexture),
+		memory:                 make(map[types.Device]*gpu.MemoryTracker),
+		textureMemory:          make(map[types.Texture]allocation),
+		bufferMemory:           make(map[types.Buffer]allocation),
+	}
+}
 
-		instanceHandles:        make(map[hal.Instance]types.Instance),
-		adapterHandles:         make(map[hal.Adapter]types.Adapter),
-		deviceHandles:          make(map[hal.Device]types.Device),
-		queueHandles:           make(map[hal.Queue]types.Queue),
-		surfaceHandles:         make(map[hal.Surface]types.Surface),
-		textureHandles:         make(map[hal.Texture]types.Texture),
-		textureViewHandles:     make(map[hal.TextureView]types.TextureView),
-		shaderModuleHandles:    make(map[hal.ShaderModule]types.ShaderModule),
-		renderPipelineHandles:  make(map[hal.RenderPipeline]types.RenderPipeline),
-		commandEncoderHandles:  make(map[hal.CommandEncoder]types.CommandEncoder),
-		commandBufferHandles:   make(map[hal.CommandBuffer]types.CommandBuffer),
-		renderPassHandles:      make(map[hal.RenderPassEncoder]types.RenderPass),
-		bufferHandles:          make(map[hal.Buffer]types.Buffer),
-		samplerHandles:         make(map[hal.Sampler]types.Sampler),
-		bindGroupLayoutHandles: make(map[hal.BindGroupLayout]types.BindGroupLayout),
-		bindGroupHandles:       make(map[hal.BindGroup]types.BindGroup),
-		pipelineLayoutHandles:  make(map[hal.PipelineLayout]types.PipelineLayout),
-	}
-	// Start handles at 1 to avoid zero confusion
-	r.nextHandle.Store(1)
-	return r
-}
-
-// newHandle generates a new unique handle.
-func (r *ResourceRegistry) newHandle() uintptr {
-	return uintptr(r.nextHandle.Add(1))
+// handleError describes a failed lookup of a kind handle.
+func handleError(kind string, handle uintptr, err error) error {
+	if errors.Is(err, errReleasedHandle) {
+		return fmt.Errorf("invalid %s handle: %d (resource was released)", kind, handle)
+	}
+	return fmt.Errorf("invalid %s handle: %d", kind, handle)
 }
 
 // --- Instance ---
 
 func (r *ResourceRegistry) RegisterInstance(instance hal.Instance) types.Instance {
-	handle := types.Instance(r.newHandle())
-	r.mu.Lock()
-	r.instances[handle] = instance
-	r.instanceHandles[instance] = handle
-	r.mu.Unlock()
-	return handle
+	return r.instances.register(instance)
 }
 
 func (r *ResourceRegistry) GetInstance(handle types.Instance) (hal.Instance, error) {
-	r.mu.RLock()
-	instance, ok := r.instances[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid instance handle: %d", handle)
+	instance, err := r.instances.get(handle)
+	if err != nil {
+		return nil, handleError("instance", uintptr(handle), err)
 	}
 	return instance, nil
 }
 
 func (r *ResourceRegistry) UnregisterInstance(handle types.Instance) {
-	r.mu.Lock()
-	if instance, ok := r.instances[handle]; ok {
-		delete(r.instances, handle)
-		delete(r.instanceHandles, instance)
-	}
-	r.mu.Unlock()
+	r.instances.unregister(handle)
 }
 
 // --- Adapter ---
 
 func (r *ResourceRegistry) RegisterAdapter(adapter hal.Adapter) types.Adapter {
-	handle := types.Adapter(r.newHandle())
-	r.mu.Lock()
-	r.adapters[handle] = adapter
-	r.adapterHandles[adapter] = handle
-	r.mu.Unlock()
-	return handle
+	return r.adapters.register(adapter)
 }
 
 func (r *ResourceRegistry) GetAdapter(handle types.Adapter) (hal.Adapter, error) {
-	r.mu.RLock()
-	adapter, ok := r.adapters[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid adapter handle: %d", handle)
+	adapter, err := r.adapters.get(handle)
+	if err != nil {
+		return nil, handleError("adapter", uintptr(handle), err)
 	}
 	return adapter, nil
 }
 
 func (r *ResourceRegistry) UnregisterAdapter(handle types.Adapter) {
-	r.mu.Lock()
-	if adapter, ok := r.adapters[handle]; ok {
-		delete(r.adapters, handle)
-		delete(r.adapterHandles, adapter)
-	}
-	r.mu.Unlock()
+	r.adapters.unregister(handle)
 }
 
 // --- Device ---
 
 func (r *ResourceRegistry) RegisterDevice(device hal.Device) types.Device {
-	handle := types.Device(r.newHandle())
-	r.mu.Lock()
-	r.devices[handle] = device
-	r.deviceHandles[device] = handle
-	r.mu.Unlock()
-	return handle
+	return r.devices.register(device)
 }
 
 func (r *ResourceRegistry) GetDevice(handle types.Device) (hal.Device, error) {
-	r.mu.RLock()
-	device, ok := r.devices[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid device handle: %d", handle)
+	device, err := r.devices.get(handle)
+	if err != nil {
+		return nil, handleError("device", uintptr(handle), err)
 	}
 	return device, nil
 }
 
 func (r *ResourceRegistry) UnregisterDevice(handle types.Device) {
+	r.devices.unregister(handle)
+
 	r.mu.Lock()
-	if device, ok := r.devices[handle]; ok {
-		delete(r.devices, handle)
-		delete(r.deviceHandles, device)
-	}
-	r.mu.Unlock()
+	defer r.mu.Unlock()
+	delete(r.memory, handle)
 }
 
 // --- Queue ---
 
 func (r *ResourceRegistry) RegisterQueue(queue hal.Queue) types.Queue {
-	handle := types.Queue(r.newHandle())
-	r.mu.Lock()
-	r.queues[handle] = queue
-	r.queueHandles[queue] = handle
-	r.mu.Unlock()
-	return handle
+	return r.queues.register(queue)
 }
 
 func (r *ResourceRegistry) GetQueue(handle types.Queue) (hal.Queue, error) {
-	r.mu.RLock()
-	queue, ok := r.queues[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid queue handle: %d", handle)
+	queue, err := r.queues.get(handle)
+	if err != nil {
+		return nil, handleError("queue", uintptr(handle), err)
 	}
 	return queue, nil
 }
 
+func (r *ResourceRegistry) UnregisterQueue(handle types.Queue) {
+	r.queues.unregister(handle)
+}
+
 // RegisterDeviceQueue stores the device→queue mapping.
 func (r *ResourceRegistry) RegisterDeviceQueue(device types.Device, queue types.Queue) {
 	r.mu.Lock()
@@ -252,6 +178,18 @@ func (r *ResourceRegistry) GetQueueForDevice(device types.Device) (types.Queue,
 	return queue, nil
 }
 
+// GetDeviceForQueue returns the device handle a queue belongs to.
+func (r *ResourceRegistry) GetDeviceForQueue(queue types.Queue) (types.Device, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for device, q := range r.deviceQueues {
+		if q == queue {
+			return device, nil
+		}
+	}
+	return 0, fmt.Errorf("no device found for queue handle: %d", queue)
+}
+
 // RegisterSurfaceDevice stores the surface→device mapping for Present.
 func (r *ResourceRegistry) RegisterSurfaceDevice(surface types.Surface, device types.Device) {
 	r.mu.Lock()
@@ -308,439 +246,397 @@ func (r *ResourceRegistry) GetAnySurfaceTexture() hal.SurfaceTexture {
 // --- Surface ---
 
 func (r *ResourceRegistry) RegisterSurface(surface hal.Surface) types.Surface {
-	handle := types.Surface(r.newHandle())
-	r.mu.Lock()
-	r.surfaces[handle] = surface
-	r.surfaceHandles[surface] = handle
-	r.mu.Unlock()
-	return handle
+	return r.surfaces.register(surface)
 }
 
 func (r *ResourceRegistry) GetSurface(handle types.Surface) (hal.Surface, error) {
-	r.mu.RLock()
-	surface, ok := r.surfaces[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid surface handle: %d", handle)
+	surface, err := r.surfaces.get(handle)
+	if err != nil {
+		return nil, handleError("surface", uintptr(handle), err)
 	}
 	return surface, nil
 }
 
 func (r *ResourceRegistry) UnregisterSurface(handle types.Surface) {
+	r.surfaces.unregister(handle)
 	r.mu.Lock()
-	if surface, ok := r.surfaces[handle]; ok {
-		delete(r.surfaces, handle)
-		delete(r.surfaceHandles, surface)
-	}
+	delete(r.surfaceDevices, handle)
+	delete(r.currentSurfaceTextures, handle)
 	r.mu.Unlock()
 }
 
 // --- Texture ---
 
 func (r *ResourceRegistry) RegisterTexture(texture hal.Texture) types.Texture {
-	handle := types.Texture(r.newHandle())
-	r.mu.Lock()
-	r.textures[handle] = texture
-	r.textureHandles[texture] = handle
-	r.mu.Unlock()
+	handle := r.textures.register(texture)
+	if t := r.leaks.Load(); t != nil {
+		t.created("texture", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetTexture(handle types.Texture) (hal.Texture, error) {
-	r.mu.RLock()
-	texture, ok := r.textures[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid texture handle: %d", handle)
+	texture, err := r.textures.get(handle)
+	if err != nil {
+		return nil, handleError("texture", uintptr(handle), err)
 	}
 	return texture, nil
 }
 
 func (r *ResourceRegistry) UnregisterTexture(handle types.Texture) {
-	r.mu.Lock()
-	if texture, ok := r.textures[handle]; ok {
-		delete(r.textures, handle)
-		delete(r.textureHandles, texture)
+	r.textures.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("texture", uintptr(handle))
 	}
-	r.mu.Unlock()
+	r.untrackTexture(handle)
 }
 
 // --- TextureView ---
 
 func (r *ResourceRegistry) RegisterTextureView(view hal.TextureView) types.TextureView {
-	handle := types.TextureView(r.newHandle())
-	r.mu.Lock()
-	r.textureViews[handle] = view
-	r.textureViewHandles[view] = handle
-	r.mu.Unlock()
+	handle := r.textureViews.register(view)
+	if t := r.leaks.Load(); t != nil {
+		t.created("texture view", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetTextureView(handle types.TextureView) (hal.TextureView, error) {
-	r.mu.RLock()
-	view, ok := r.textureViews[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid texture view handle: %d", handle)
+	view, err := r.textureViews.get(handle)
+	if err != nil {
+		return nil, handleError("texture view", uintptr(handle), err)
 	}
 	return view, nil
 }
 
 func (r *ResourceRegistry) UnregisterTextureView(handle types.TextureView) {
-	r.mu.Lock()
-	if view, ok := r.textureViews[handle]; ok {
-		delete(r.textureViews, handle)
-		delete(r.textureViewHandles, view)
+	r.textureViews.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("texture view", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- ShaderModule ---
 
 func (r *ResourceRegistry) RegisterShaderModule(module hal.ShaderModule) types.ShaderModule {
-	handle := types.ShaderModule(r.newHandle())
-	r.mu.Lock()
-	r.shaderModules[handle] = module
-	r.shaderModuleHandles[module] = handle
-	r.mu.Unlock()
-	return handle
+	return r.shaderModules.register(module)
 }
 
 func (r *ResourceRegistry) GetShaderModule(handle types.ShaderModule) (hal.ShaderModule, error) {
-	r.mu.RLock()
-	module, ok := r.shaderModules[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid shader module handle: %d", handle)
+	module, err := r.shaderModules.get(handle)
+	if err != nil {
+		return nil, handleError("shader module", uintptr(handle), err)
 	}
 	return module, nil
 }
 
 func (r *ResourceRegistry) UnregisterShaderModule(handle types.ShaderModule) {
-	r.mu.Lock()
-	if module, ok := r.shaderModules[handle]; ok {
-		delete(r.shaderModules, handle)
-		delete(r.shaderModuleHandles, module)
-	}
-	r.mu.Unlock()
+	r.shaderModules.unregister(handle)
 }
 
 // --- RenderPipeline ---
 
 func (r *ResourceRegistry) RegisterRenderPipeline(pipeline hal.RenderPipeline) types.RenderPipeline {
-	handle := types.RenderPipeline(r.newHandle())
-	r.mu.Lock()
-	r.renderPipelines[handle] = pipeline
-	r.renderPipelineHandles[pipeline] = handle
-	r.mu.Unlock()
-	return handle
+	return r.renderPipelines.register(pipeline)
 }
 
 func (r *ResourceRegistry) GetRenderPipeline(handle types.RenderPipeline) (hal.RenderPipeline, error) {
-	r.mu.RLock()
-	pipeline, ok := r.renderPipelines[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid render pipeline handle: %d", handle)
+	pipeline, err := r.renderPipelines.get(handle)
+	if err != nil {
+		return nil, handleError("render pipeline", uintptr(handle), err)
 	}
 	return pipeline, nil
 }
 
 func (r *ResourceRegistry) UnregisterRenderPipeline(handle types.RenderPipeline) {
-	r.mu.Lock()
-	if pipeline, ok := r.renderPipelines[handle]; ok {
-		delete(r.renderPipelines, handle)
-		delete(r.renderPipelineHandles, pipeline)
-	}
-	r.mu.Unlock()
+	r.renderPipelines.unregister(handle)
 }
 
 // --- CommandEncoder ---
 
 func (r *ResourceRegistry) RegisterCommandEncoder(encoder hal.CommandEncoder) types.CommandEncoder {
-	handle := types.CommandEncoder(r.newHandle())
-	r.mu.Lock()
-	r.commandEncoders[handle] = encoder
-	r.commandEncoderHandles[encoder] = handle
-	r.mu.Unlock()
+	handle := r.commandEncoders.register(encoder)
+	if t := r.leaks.Load(); t != nil {
+		t.created("command encoder", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetCommandEncoder(handle types.CommandEncoder) (hal.CommandEncoder, error) {
-	r.mu.RLock()
-	encoder, ok := r.commandEncoders[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid command encoder handle: %d", handle)
+	encoder, err := r.commandEncoders.get(handle)
+	if err != nil {
+		return nil, handleError("command encoder", uintptr(handle), err)
 	}
 	return encoder, nil
 }
 
 func (r *ResourceRegistry) UnregisterCommandEncoder(handle types.CommandEncoder) {
-	r.mu.Lock()
-	if encoder, ok := r.commandEncoders[handle]; ok {
-		delete(r.commandEncoders, handle)
-		delete(r.commandEncoderHandles, encoder)
+	r.commandEncoders.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("command encoder", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- CommandBuffer ---
 
 func (r *ResourceRegistry) RegisterCommandBuffer(buffer hal.CommandBuffer) types.CommandBuffer {
-	handle := types.CommandBuffer(r.newHandle())
-	r.mu.Lock()
-	r.commandBuffers[handle] = buffer
-	r.commandBufferHandles[buffer] = handle
-	r.mu.Unlock()
+	handle := r.commandBuffers.register(buffer)
+	if t := r.leaks.Load(); t != nil {
+		t.created("command buffer", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetCommandBuffer(handle types.CommandBuffer) (hal.CommandBuffer, error) {
-	r.mu.RLock()
-	buffer, ok := r.commandBuffers[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid command buffer handle: %d", handle)
+	buffer, err := r.commandBuffers.get(handle)
+	if err != nil {
+		return nil, handleError("command buffer", uintptr(handle), err)
 	}
 	return buffer, nil
 }
 
 func (r *ResourceRegistry) UnregisterCommandBuffer(handle types.CommandBuffer) {
-	r.mu.Lock()
-	if buffer, ok := r.commandBuffers[handle]; ok {
-		delete(r.commandBuffers, handle)
-		delete(r.commandBufferHandles, buffer)
+	r.commandBuffers.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("command buffer", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- RenderPass ---
 
 func (r *ResourceRegistry) RegisterRenderPass(pass hal.RenderPassEncoder) types.RenderPass {
-	handle := types.RenderPass(r.newHandle())
-	r.mu.Lock()
-	r.renderPasses[handle] = pass
-	r.renderPassHandles[pass] = handle
-	r.mu.Unlock()
+	handle := r.renderPasses.register(pass)
+	if t := r.leaks.Load(); t != nil {
+		t.created("render pass", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetRenderPass(handle types.RenderPass) (hal.RenderPassEncoder, error) {
-	r.mu.RLock()
-	pass, ok := r.renderPasses[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid render pass handle: %d", handle)
+	pass, err := r.renderPasses.get(handle)
+	if err != nil {
+		return nil, handleError("render pass", uintptr(handle), err)
 	}
 	return pass, nil
 }
 
 func (r *ResourceRegistry) UnregisterRenderPass(handle types.RenderPass) {
-	r.mu.Lock()
-	if pass, ok := r.renderPasses[handle]; ok {
-		delete(r.renderPasses, handle)
-		delete(r.renderPassHandles, pass)
+	r.renderPasses.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("render pass", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- Buffer ---
 
 func (r *ResourceRegistry) RegisterBuffer(buffer hal.Buffer) types.Buffer {
-	handle := types.Buffer(r.newHandle())
-	r.mu.Lock()
-	r.buffers[handle] = buffer
-	r.bufferHandles[buffer] = handle
-	r.mu.Unlock()
+	handle := r.buffers.register(buffer)
+	if t := r.leaks.Load(); t != nil {
+		t.created("buffer", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetBuffer(handle types.Buffer) (hal.Buffer, error) {
-	r.mu.RLock()
-	buffer, ok := r.buffers[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid buffer handle: %d", handle)
+	buffer, err := r.buffers.get(handle)
+	if err != nil {
+		return nil, handleError("buffer", uintptr(handle), err)
 	}
 	return buffer, nil
 }
 
 func (r *ResourceRegistry) UnregisterBuffer(handle types.Buffer) {
-	r.mu.Lock()
-	if buffer, ok := r.buffers[handle]; ok {
-		delete(r.buffers, handle)
-		delete(r.bufferHandles, buffer)
+	r.buffers.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("buffer", uintptr(handle))
 	}
-	r.mu.Unlock()
+	r.untrackBuffer(handle)
 }
 
 // --- Sampler ---
 
 func (r *ResourceRegistry) RegisterSampler(sampler hal.Sampler) types.Sampler {
-	handle := types.Sampler(r.newHandle())
-	r.mu.Lock()
-	r.samplers[handle] = sampler
-	r.samplerHandles[sampler] = handle
-	r.mu.Unlock()
+	handle := r.samplers.register(sampler)
+	if t := r.leaks.Load(); t != nil {
+		t.created("sampler", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetSampler(handle types.Sampler) (hal.Sampler, error) {
-	r.mu.RLock()
-	sampler, ok := r.samplers[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid sampler handle: %d", handle)
+	sampler, err := r.samplers.get(handle)
+	if err != nil {
+		return nil, handleError("sampler", uintptr(handle), err)
 	}
 	return sampler, nil
 }
 
 func (r *ResourceRegistry) UnregisterSampler(handle types.Sampler) {
-	r.mu.Lock()
-	if sampler, ok := r.samplers[handle]; ok {
-		delete(r.samplers, handle)
-		delete(r.samplerHandles, sampler)
+	r.samplers.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("sampler", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- BindGroupLayout ---
 
 func (r *ResourceRegistry) RegisterBindGroupLayout(layout hal.BindGroupLayout) types.BindGroupLayout {
-	handle := types.BindGroupLayout(r.newHandle())
-	r.mu.Lock()
-	r.bindGroupLayouts[handle] = layout
-	r.bindGroupLayoutHandles[layout] = handle
-	r.mu.Unlock()
+	handle := r.bindGroupLayouts.register(layout)
+	if t := r.leaks.Load(); t != nil {
+		t.created("bind group layout", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetBindGroupLayout(handle types.BindGroupLayout) (hal.BindGroupLayout, error) {
-	r.mu.RLock()
-	layout, ok := r.bindGroupLayouts[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid bind group layout handle: %d", handle)
+	layout, err := r.bindGroupLayouts.get(handle)
+	if err != nil {
+		return nil, handleError("bind group layout", uintptr(handle), err)
 	}
 	return layout, nil
 }
 
 func (r *ResourceRegistry) UnregisterBindGroupLayout(handle types.BindGroupLayout) {
-	r.mu.Lock()
-	if layout, ok := r.bindGroupLayouts[handle]; ok {
-		delete(r.bindGroupLayouts, handle)
-		delete(r.bindGroupLayoutHandles, layout)
+	r.bindGroupLayouts.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("bind group layout", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- BindGroup ---
 
 func (r *ResourceRegistry) RegisterBindGroup(group hal.BindGroup) types.BindGroup {
-	handle := types.BindGroup(r.newHandle())
-	r.mu.Lock()
-	r.bindGroups[handle] = group
-	r.bindGroupHandles[group] = handle
-	r.mu.Unlock()
+	handle := r.bindGroups.register(group)
+	if t := r.leaks.Load(); t != nil {
+		t.created("bind group", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetBindGroup(handle types.BindGroup) (hal.BindGroup, error) {
-	r.mu.RLock()
-	group, ok := r.bindGroups[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid bind group handle: %d", handle)
+	group, err := r.bindGroups.get(handle)
+	if err != nil {
+		return nil, handleError("bind group", uintptr(handle), err)
 	}
 	return group, nil
 }
 
 func (r *ResourceRegistry) UnregisterBindGroup(handle types.BindGroup) {
-	r.mu.Lock()
-	if group, ok := r.bindGroups[handle]; ok {
-		delete(r.bindGroups, handle)
-		delete(r.bindGroupHandles, group)
+	r.bindGroups.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("bind group", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // --- PipelineLayout ---
 
 func (r *ResourceRegistry) RegisterPipelineLayout(layout hal.PipelineLayout) types.PipelineLayout {
-	handle := types.PipelineLayout(r.newHandle())
-	r.mu.Lock()
-	r.pipelineLayouts[handle] = layout
-	r.pipelineLayoutHandles[layout] = handle
-	r.mu.Unlock()
+	handle := r.pipelineLayouts.register(layout)
+	if t := r.leaks.Load(); t != nil {
+		t.created("pipeline layout", uintptr(handle))
+	}
 	return handle
 }
 
 func (r *ResourceRegistry) GetPipelineLayout(handle types.PipelineLayout) (hal.PipelineLayout, error) {
-	r.mu.RLock()
-	layout, ok := r.pipelineLayouts[handle]
-	r.mu.RUnlock()
-	if !ok {
-		return nil, fmt.Errorf("invalid pipeline layout handle: %d", handle)
+	layout, err := r.pipelineLayouts.get(handle)
+	if err != nil {
+		return nil, handleError("pipeline layout", uintptr(handle), err)
 	}
 	return layout, nil
 }
 
 func (r *ResourceRegistry) UnregisterPipelineLayout(handle types.PipelineLayout) {
-	r.mu.Lock()
-	if layout, ok := r.pipelineLayouts[handle]; ok {
-		delete(r.pipelineLayouts, handle)
-		delete(r.pipelineLayoutHandles, layout)
+	r.pipelineLayouts.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("pipeline layout", uintptr(handle))
+	}
+}
+
+// --- ComputePipeline ---
+
+func (r *ResourceRegistry) RegisterComputePipeline(pipeline hal.ComputePipeline) types.ComputePipeline {
+	handle := r.computePipelines.register(pipeline)
+	if t := r.leaks.Load(); t != nil {
+		t.created("compute pipeline", uintptr(handle))
+	}
+	return handle
+}
+
+func (r *ResourceRegistry) GetComputePipeline(handle types.ComputePipeline) (hal.ComputePipeline, error) {
+	pipeline, err := r.computePipelines.get(handle)
+	if err != nil {
+		return nil, handleError("compute pipeline", uintptr(handle), err)
+	}
+	return pipeline, nil
+}
+
+func (r *ResourceRegistry) UnregisterComputePipeline(handle types.ComputePipeline) {
+	r.computePipelines.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("compute pipeline", uintptr(handle))
+	}
+}
+
+// --- ComputePass ---
+
+func (r *ResourceRegistry) RegisterComputePass(pass hal.ComputePassEncoder) types.ComputePass {
+	handle := r.computePasses.register(pass)
+	if t := r.leaks.Load(); t != nil {
+		t.created("compute pass", uintptr(handle))
+	}
+	return handle
+}
+
+func (r *ResourceRegistry) GetComputePass(handle types.ComputePass) (hal.ComputePassEncoder, error) {
+	pass, err := r.computePasses.get(handle)
+	if err != nil {
+		return nil, handleError("compute pass", uintptr(handle), err)
+	}
+	return pass, nil
+}
+
+func (r *ResourceRegistry) UnregisterComputePass(handle types.ComputePass) {
+	r.computePasses.unregister(handle)
+	if t := r.leaks.Load(); t != nil {
+		t.released("compute pass", uintptr(handle))
 	}
-	r.mu.Unlock()
 }
 
 // Clear releases all registered resources and clears all maps.
 // WARNING: Does NOT destroy HAL objects - caller must destroy them first!
 func (r *ResourceRegistry) Clear() {
+	r.instances.clear()
+	r.adapters.clear()
+	r.devices.clear()
+	r.queues.clear()
+	r.surfaces.clear()
+	r.textures.clear()
+	r.textureViews.clear()
+	r.shaderModules.clear()
+	r.renderPipelines.clear()
+	r.commandEncoders.clear()
+	r.commandBuffers.clear()
+	r.renderPasses.clear()
+	r.buffers.clear()
+	r.samplers.clear()
+	r.bindGroupLayouts.clear()
+	r.bindGroups.clear()
+	r.pipelineLayouts.clear()
+	r.computePipelines.clear()
+	r.computePasses.clear()
+	if t := r.leaks.Load(); t != nil {
+		t.clear()
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Clear forward maps
-	r.instances = make(map[types.Instance]hal.Instance)
-	r.adapters = make(map[types.Adapter]hal.Adapter)
-	r.devices = make(map[types.Device]hal.Device)
-	r.queues = make(map[types.Queue]hal.Queue)
-	r.surfaces = make(map[types.Surface]hal.Surface)
-	r.textures = make(map[types.Texture]hal.Texture)
-	r.textureViews = make(map[types.TextureView]hal.TextureView)
-	r.shaderModules = make(map[types.ShaderModule]hal.ShaderModule)
-	r.renderPipelines = make(map[types.RenderPipeline]hal.RenderPipeline)
-	r.commandEncoders = make(map[types.CommandEncoder]hal.CommandEncoder)
-	r.commandBuffers = make(map[types.CommandBuffer]hal.CommandBuffer)
-	r.renderPasses = make(map[types.RenderPass]hal.RenderPassEncoder)
-	r.buffers = make(map[types.Buffer]hal.Buffer)
-	r.samplers = make(map[types.Sampler]hal.Sampler)
-	r.bindGroupLayouts = make(map[types.BindGroupLayout]hal.BindGroupLayout)
-	r.bindGroups = make(map[types.BindGroup]hal.BindGroup)
-	r.pipelineLayouts = make(map[types.PipelineLayout]hal.PipelineLayout)
-
 	// Clear device→queue mapping
 	r.deviceQueues = make(map[types.Device]types.Queue)
 	r.surfaceDevices = make(map[types.Surface]types.Device)
 	r.currentSurfaceTextures = make(map[types.Surface]hal.SurfaceTexture)
-
-	// Clear reverse maps
-	r.instanceHandles = make(map[hal.Instance]types.Instance)
-	r.adapterHandles = make(map[hal.Adapter]types.Adapter)
-	r.deviceHandles = make(map[hal.Device]types.Device)
-	r.queueHandles = make(map[hal.Queue]types.Queue)
-	r.surfaceHandles = make(map[hal.Surface]types.Surface)
-	r.textureHandles = make(map[hal.Texture]types.Texture)
-	r.textureViewHandles = make(map[hal.TextureView]types.TextureView)
-	r.shaderModuleHandles = make(map[hal.ShaderModule]types.ShaderModule)
-	r.renderPipelineHandles = make(map[hal.RenderPipeline]types.RenderPipeline)
-	r.commandEncoderHandles = make(map[hal.CommandEncoder]types.CommandEncoder)
-	r.commandBufferHandles = make(map[hal.CommandBuffer]types.CommandBuffer)
-	r.renderPassHandles = make(map[hal.RenderPassEncoder]types.RenderPass)
-	r.bufferHandles = make(map[hal.Buffer]types.Buffer)
-	r.samplerHandles = make(map[hal.Sampler]types.Sampler)
-	r.bindGroupLayoutHandles = make(map[hal.BindGroupLayout]types.BindGroupLayout)
-	r.bindGroupHandles = make(map[hal.BindGroup]types.BindGroup)
-	r.pipelineLayoutHandles = make(map[hal.PipelineLayout]types.PipelineLayout)
+	r.memory = make(map[types.Device]*gpu.MemoryTracker)
+	r.textureMemory = make(map[types.Texture]allocation)
+	r.bufferMemory = make(map[types.Buffer]allocation)
 }
@@ -0,0 +1,50 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// --- Samplers ---
+// Shared by the Vulkan and Metal backends.
+
+// CreateSampler creates a texture sampler. The HAL has no border colors,
+// so devices never get FeatureAddressModeClampToBorder and
+// AddressModeClampToBorder is rejected.
+func (b *Backend) CreateSampler(device types.Device, desc *types.SamplerDescriptor) (types.Sampler, error) {
+	halDevice, err := b.registry.GetDevice(device)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := gpu.NormalizeSampler(desc, b.GetDeviceFeatures(device))
+	if err != nil {
+		return 0, err
+	}
+
+	halDesc := &hal.SamplerDescriptor{
+		Label:        d.Label,
+		AddressModeU: convertAddressMode(d.AddressModeU),
+		AddressModeV: convertAddressMode(d.AddressModeV),
+		AddressModeW: convertAddressMode(d.AddressModeW),
+		MagFilter:    convertFilterMode(d.MagFilter),
+		MinFilter:    convertFilterMode(d.MinFilter),
+		MipmapFilter: convertMipmapFilterMode(d.MipmapFilter),
+		LodMinClamp:  d.LodMinClamp,
+		LodMaxClamp:  d.LodMaxClamp,
+		Compare:      convertCompareFunction(d.Compare),
+		Anisotropy:   d.MaxAnisotropy,
+	}
+
+	sampler, err := halDevice.CreateSampler(halDesc)
+	if err != nil {
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create sampler: %w", err))
+	}
+
+	return b.registry.RegisterSampler(sampler), nil
+}
@@ -0,0 +1,37 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+	wgputypes "github.com/gogpu/wgpu/types"
+)
+
+// CreateShaderModuleSPIRV creates a shader module from SPIR-V words.
+// Only the Vulkan HAL consumes SPIR-V; the others compile shaders from
+// WGSL and have no SPIR-V translation.
+func (b *Backend) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	if b.backend.Variant() != wgputypes.BackendVulkan {
+		return 0, fmt.Errorf("native: SPIR-V shaders need the Vulkan backend, not %s; use WGSL or the Rust backend", halBackendName(b.backend))
+	}
+
+	halDevice, err := b.registry.GetDevice(device)
+	if err != nil {
+		return 0, err
+	}
+
+	desc := &hal.ShaderModuleDescriptor{
+		Label:  "shader",
+		Source: hal.ShaderSource{SPIRV: code},
+	}
+
+	module, err := halDevice.CreateShaderModule(desc)
+	if err != nil {
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create shader module from SPIR-V: %w", err))
+	}
+
+	return b.registry.RegisterShaderModule(module), nil
+}
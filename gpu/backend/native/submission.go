@@ -0,0 +1,218 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"time"
+
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// --- Submission tracking ---
+// Shared by the Vulkan and Metal backends. Every submission to a queue
+// signals the queue's fence with its index, so Poll can tell which
+// submissions completed, run OnSubmittedWorkDone callbacks and destroy
+// released resources the GPU was still using.
+
+// fenceWaitTimeout bounds how long Poll waits for submitted work.
+const fenceWaitTimeout = 10 * time.Second
+
+// queueSubmissions tracks the submissions to one queue.
+type queueSubmissions struct {
+	device    types.Device
+	halDevice hal.Device
+	fence     hal.Fence
+
+	submitted types.SubmissionIndex
+	completed types.SubmissionIndex
+
+	// OnSubmittedWorkDone callbacks in submission order
+	callbacks []workDoneCallback
+}
+
+// workDoneCallback runs once submission index has completed.
+type workDoneCallback struct {
+	index    types.SubmissionIndex
+	callback func()
+}
+
+// queueSubmissions returns the submission state of a queue, creating its
+// fence on first use.
+func (b *Backend) queueSubmissions(queue types.Queue) (*queueSubmissions, error) {
+	if subs := b.submissions[queue]; subs != nil {
+		return subs, nil
+	}
+
+	device, err := b.registry.GetDeviceForQueue(queue)
+	if err != nil {
+		return nil, err
+	}
+	halDevice, err := b.registry.GetDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	fence, err := halDevice.CreateFence()
+	if err != nil {
+		return nil, b.reportError(device, err)
+	}
+
+	subs := &queueSubmissions{device: device, halDevice: halDevice, fence: fence}
+	b.submissions[queue] = subs
+	return subs, nil
+}
+
+// OnSubmittedWorkDone calls callback during Poll once all work submitted
+// to the queue so far has completed.
+func (b *Backend) OnSubmittedWorkDone(queue types.Queue, callback func()) {
+	subs, err := b.queueSubmissions(queue)
+	if err != nil {
+		return
+	}
+	subs.callbacks = append(subs.callbacks, workDoneCallback{index: subs.submitted, callback: callback})
+}
+
+// Poll runs the OnSubmittedWorkDone callbacks of completed submissions.
+// With wait it first waits for all submitted work. Returns true if no
+// work is pending.
+func (b *Backend) Poll(device types.Device, wait bool) bool {
+	idle := true
+	for _, subs := range b.submissions {
+		if subs.device != device {
+			continue
+		}
+		if !subs.reached(subs.submitted, wait) {
+			idle = false
+		}
+		subs.runCallbacks()
+	}
+	b.runDeferredDestroys(false)
+	return idle
+}
+
+// reached reports whether submission index has completed, waiting for it
+// with wait.
+func (s *queueSubmissions) reached(index types.SubmissionIndex, wait bool) bool {
+	if index <= s.completed {
+		return true
+	}
+	var timeout time.Duration
+	if wait {
+		timeout = fenceWaitTimeout
+	}
+	done, err := s.halDevice.Wait(s.fence, uint64(index), timeout)
+	if err != nil || !done {
+		return false
+	}
+	s.completed = index
+	return true
+}
+
+// runCallbacks runs the callbacks of completed submissions. Callbacks
+// may register new ones.
+func (s *queueSubmissions) runCallbacks() {
+	n := 0
+	for n < len(s.callbacks) && s.reached(s.callbacks[n].index, false) {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	ready := s.callbacks[:n:n]
+	s.callbacks = s.callbacks[n:]
+	for _, cb := range ready {
+		cb.callback()
+	}
+}
+
+// destroyer is a HAL object destroyed by its Destroy method.
+type destroyer interface {
+	Destroy()
+}
+
+// deferredDestroy destroys a released HAL object once the submissions
+// that were pending when it was released have completed.
+type deferredDestroy struct {
+	waits  []submissionWait
+	object destroyer
+}
+
+// submissionWait is a submission a deferred destruction waits for.
+type submissionWait struct {
+	subs  *queueSubmissions
+	index types.SubmissionIndex
+}
+
+// destroyAfterGPU destroys object once all work submitted so far has
+// completed, right away if there is none. Release methods use it, as
+// command buffers in flight may still use the released object; their
+// handles become invalid immediately. Entries of b.deferred are reused
+// with their waits, so steady-state frames do not allocate here.
+func (b *Backend) destroyAfterGPU(object destroyer) {
+	pending := false
+	for _, subs := range b.submissions {
+		if subs.submitted > subs.completed {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		object.Destroy()
+		return
+	}
+
+	if len(b.deferred) < cap(b.deferred) {
+		b.deferred = b.deferred[:len(b.deferred)+1]
+	} else {
+		b.deferred = append(b.deferred, deferredDestroy{})
+	}
+	d := &b.deferred[len(b.deferred)-1]
+	d.object = object
+	d.waits = d.waits[:0]
+	for _, subs := range b.submissions {
+		if subs.submitted > subs.completed {
+			d.waits = append(d.waits, submissionWait{subs: subs, index: subs.submitted})
+		}
+	}
+}
+
+// runDeferredDestroys destroys the objects whose submissions have
+// completed, waiting for them with wait. Objects are destroyed in the
+// order they were released.
+func (b *Backend) runDeferredDestroys(wait bool) {
+	n := 0
+	for i := range b.deferred {
+		d := &b.deferred[i]
+		done := true
+		for _, w := range d.waits {
+			if !w.subs.reached(w.index, wait) {
+				done = false
+				break
+			}
+		}
+		if !done {
+			// Swap, so the entry at i keeps a waits slice to reuse
+			b.deferred[n], b.deferred[i] = b.deferred[i], b.deferred[n]
+			n++
+			continue
+		}
+		d.object.Destroy()
+		d.object = nil
+		clear(d.waits)
+	}
+	b.deferred = b.deferred[:n]
+}
+
+// destroySubmissions destroys the objects still waiting for the GPU and
+// the fences of all queues.
+func (b *Backend) destroySubmissions() {
+	b.runDeferredDestroys(true)
+	for _, d := range b.deferred {
+		d.object.Destroy() // Timed out; the device is going away anyway
+	}
+	b.deferred = nil
+	for _, subs := range b.submissions {
+		subs.halDevice.DestroyFence(subs.fence)
+	}
+	clear(b.submissions)
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package native
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/gogpu/internal/xlib"
+)
+
+// surfaceHandles returns the display and window handles the HAL creates
+// a surface for handle with: the shared Xlib display and the X11 window,
+// see package xlib. Handles of Wayland windows get no surface, see
+// xlib.ErrWayland.
+func surfaceHandles(handle types.SurfaceHandle) (display, window uintptr, err error) {
+	if handle.Wayland {
+		return 0, 0, xlib.ErrWayland
+	}
+	display, err = xlib.Display()
+	if err != nil {
+		return 0, 0, err
+	}
+	return display, handle.Window, nil
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package native
+
+import "github.com/gogpu/gogpu/gpu/types"
+
+// surfaceHandles returns the display and window handles the HAL creates
+// a surface for handle with: HINSTANCE and HWND, as they are.
+func surfaceHandles(handle types.SurfaceHandle) (display, window uintptr, err error) {
+	return handle.Instance, handle.Window, nil
+}
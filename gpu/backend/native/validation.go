@@ -0,0 +1,45 @@
+//go:build windows || linux || darwin
+
+package native
+
+import (
+	"errors"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// --- Validation ---
+// Shared by the Vulkan and Metal backends. The HAL does not validate, so
+// invalid command sequences crash or draw nothing. With EnableValidation
+// a gpu.CommandValidator checks commands as they are recorded; invalid
+// ones are skipped and reported to the device's error scopes.
+
+// EnableValidation makes the backend validate command recording.
+func (b *Backend) EnableValidation() {
+	b.validator = gpu.NewCommandValidator()
+}
+
+// rejected reports err of the validator, if any, as a validation error
+// and tells whether the command must be skipped.
+func (b *Backend) rejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	var verr *gpu.ValidationError
+	device := types.Device(0)
+	if errors.As(err, &verr) {
+		device = verr.Device
+	}
+	if device == 0 {
+		// Usage errors do not know their device; there is one in practice
+		for d := range b.deviceCaps {
+			device = d
+			break
+		}
+	}
+	_ = b.reportError(device, err)
+	return true
+}
+
+var _ gpu.CommandValidation = (*Backend)(nil)
@@ -3,7 +3,9 @@
 // Package native provides the WebGPU backend using pure Go (gogpu/wgpu).
 // This backend offers zero dependencies and simple cross-compilation.
 //
-// Implementation uses gogpu/wgpu HAL (Hardware Abstraction Layer) with Vulkan backend.
+// Implementation uses gogpu/wgpu HAL (Hardware Abstraction Layer): Vulkan, or
+// Direct3D 12 on Windows without it, falling back to OpenGL/GLES (see
+// halbackends.go).
 package native
 
 import (
@@ -12,7 +14,6 @@ import (
 	"github.com/gogpu/gogpu/gpu"
 	"github.com/gogpu/gogpu/gpu/types"
 	"github.com/gogpu/wgpu/hal"
-	"github.com/gogpu/wgpu/hal/vulkan"
 	wgputypes "github.com/gogpu/wgpu/types"
 )
 
@@ -20,19 +21,65 @@ import (
 type Backend struct {
 	registry *ResourceRegistry
 	backend  hal.Backend
+
+	// Capabilities by handle, see adapter.go
+	adapterCaps map[types.Adapter]adapterCaps
+	deviceCaps  map[types.Device]deviceCaps
+
+	// Error scopes and device-lost callbacks by device, see errors.go
+	scopes        map[types.Device]*gpu.ErrorScopes
+	lostCallbacks map[types.Device]func(reason types.DeviceLostReason, message string)
+
+	// HAL backends CreateInstance tried and rejected, see halbackends.go
+	rejections []gpu.Rejection
+
+	// Submissions by queue, and released objects waiting for them, see
+	// submission.go
+	submissions map[types.Queue]*queueSubmissions
+	deferred    []deferredDestroy
+
+	// Frame pacing by surface, see frames.go; 0 frames in flight means
+	// gpu.DefaultFramesInFlight
+	frames         map[types.Surface]*frameRing
+	framesInFlight int
+
+	// Descriptors and slices of HAL calls, reused, see pool.go
+	scratch callScratch
+
+	// Command encoders by device for reuse, and the pooled encoders of
+	// live encoder and command buffer handles, see pool.go
+	encoderPools   map[types.Device]*encoderPool
+	pooledEncoders map[types.CommandEncoder]*pooledEncoder
+	pooledBuffers  map[types.CommandBuffer]*pooledEncoder
+
+	// Command validation, nil unless enabled, see validation.go
+	validator *gpu.CommandValidator
+
+	// Receives unreleased resources on Destroy, nil unless leak
+	// detection is enabled, see leaks.go
+	leakReport func(leaks []gpu.Leak)
 }
 
 // New creates a new Pure Go backend.
 func New() *Backend {
 	return &Backend{
-		registry: NewResourceRegistry(),
-		backend:  vulkan.Backend{}, // Vulkan is the first HAL implementation
+		registry:       NewResourceRegistry(),
+		adapterCaps:    make(map[types.Adapter]adapterCaps),
+		deviceCaps:     make(map[types.Device]deviceCaps),
+		scopes:         make(map[types.Device]*gpu.ErrorScopes),
+		lostCallbacks:  make(map[types.Device]func(types.DeviceLostReason, string)),
+		submissions:    make(map[types.Queue]*queueSubmissions),
+		frames:         make(map[types.Surface]*frameRing),
+		encoderPools:   make(map[types.Device]*encoderPool),
+		pooledEncoders: make(map[types.CommandEncoder]*pooledEncoder),
+		pooledBuffers:  make(map[types.CommandBuffer]*pooledEncoder),
+		backend:        halBackends[0], // Replaced by the fallback CreateInstance picks
 	}
 }
 
 // Name returns the backend identifier.
 func (b *Backend) Name() string {
-	return "Pure Go (gogpu/wgpu/vulkan)"
+	return "Pure Go (gogpu/wgpu/" + halBackendName(b.backend) + ")"
 }
 
 // Init initializes the backend.
@@ -47,18 +94,23 @@ func (b *Backend) Destroy() {
 	// Note: This does NOT destroy HAL resources!
 	// Caller must explicitly release all handles before calling Destroy.
 	// This just clears the registry.
+	b.destroySubmissions()
+	clear(b.frames)
+	b.reportLeaks()
 	b.registry.Clear()
+	clear(b.adapterCaps)
+	clear(b.deviceCaps)
+	clear(b.scopes)
+	clear(b.lostCallbacks)
+	clear(b.encoderPools)
+	clear(b.pooledEncoders)
+	clear(b.pooledBuffers)
 }
 
-// CreateInstance creates a WebGPU instance.
+// CreateInstance creates a WebGPU instance on the first available HAL
+// backend of halBackends.
 func (b *Backend) CreateInstance() (types.Instance, error) {
-	// Create HAL instance with default config
-	desc := &hal.InstanceDescriptor{
-		Backends: wgputypes.Backends(1 << wgputypes.BackendVulkan), // Vulkan backend
-		Flags:    0,                                                // No debug for now
-	}
-
-	halInstance, err := b.backend.CreateInstance(desc)
+	halInstance, err := b.createHALInstance()
 	if err != nil {
 		return 0, fmt.Errorf("native: failed to create instance: %w", err)
 	}
@@ -87,6 +139,7 @@ func (b *Backend) RequestAdapter(instance types.Instance, opts *types.AdapterOpt
 
 	// Register and return handle
 	handle := b.registry.RegisterAdapter(exposed.Adapter)
+	b.adapterCaps[handle] = newAdapterCaps(&exposed)
 	return handle, nil
 }
 
@@ -97,14 +150,17 @@ func (b *Backend) RequestDevice(adapter types.Adapter, opts *types.DeviceOptions
 		return 0, err
 	}
 
-	// Open device with default features and limits
-	openDevice, err := halAdapter.Open(wgputypes.Features(0), wgputypes.DefaultLimits())
+	// Open device with the required features and limits
+	features, limits, caps := deviceRequirements(opts)
+	openDevice, err := halAdapter.Open(features, limits)
 	if err != nil {
 		return 0, fmt.Errorf("native: failed to open device: %w", err)
 	}
 
 	// Register device and queue
 	deviceHandle := b.registry.RegisterDevice(openDevice.Device)
+	b.deviceCaps[deviceHandle] = caps
+	b.registry.MemoryTracker(deviceHandle).SetBudget(adapterMemoryBudget(halAdapter))
 	queueHandle := b.registry.RegisterQueue(openDevice.Queue)
 
 	// Store device→queue mapping
@@ -129,7 +185,12 @@ func (b *Backend) CreateSurface(instance types.Instance, handle types.SurfaceHan
 		return 0, err
 	}
 
-	halSurface, err := halInstance.CreateSurface(handle.Instance, handle.Window)
+	display, window, err := surfaceHandles(handle)
+	if err != nil {
+		return 0, err
+	}
+
+	halSurface, err := halInstance.CreateSurface(display, window)
 	if err != nil {
 		return 0, fmt.Errorf("native: failed to create surface: %w", err)
 	}
@@ -150,6 +211,9 @@ func (b *Backend) ConfigureSurface(surface types.Surface, device types.Device, c
 		return
 	}
 
+	// Store surface → device mapping for frame pacing
+	b.registry.RegisterSurfaceDevice(surface, device)
+
 	// Convert config
 	halConfig := &hal.SurfaceConfiguration{
 		Format:      convertTextureFormat(config.Format),
@@ -171,6 +235,9 @@ func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture
 		return types.SurfaceTexture{Status: types.SurfaceStatusError}, err
 	}
 
+	// Wait for the frame that last used this frame's slot
+	b.waitForFrameSlot(surface)
+
 	// Acquire texture (fence=nil for now)
 	acquired, err := halSurface.AcquireTexture(nil)
 	if err != nil {
@@ -191,8 +258,26 @@ func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture
 func (b *Backend) Present(surface types.Surface) {
 	// Presentation happens via Queue.Present in HAL
 	// We need to get the queue and call Present on it
-	// For now, this is a no-op - presentation will happen in Submit
+	// For now, presentation will happen in Submit
 	// TODO: Proper presentation flow
+	b.framePresented(surface)
+}
+
+// ReleaseSurface waits for the frames in flight on the surface, then
+// unconfigures and destroys it.
+func (b *Backend) ReleaseSurface(surface types.Surface) {
+	halSurface, err := b.registry.GetSurface(surface)
+	if err != nil {
+		return
+	}
+	b.surfaceReleased(surface)
+	if device, err := b.registry.GetDeviceForSurface(surface); err == nil {
+		if halDevice, err := b.registry.GetDevice(device); err == nil {
+			halSurface.Unconfigure(halDevice)
+		}
+	}
+	halSurface.Destroy()
+	b.registry.UnregisterSurface(surface)
 }
 
 // CreateShaderModuleWGSL creates a shader module from WGSL code.
@@ -209,7 +294,7 @@ func (b *Backend) CreateShaderModuleWGSL(device types.Device, code string) (type
 
 	module, err := halDevice.CreateShaderModule(desc)
 	if err != nil {
-		return 0, fmt.Errorf("native: failed to create shader module: %w", err)
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create shader module: %w", err))
 	}
 
 	handle := b.registry.RegisterShaderModule(module)
@@ -223,52 +308,20 @@ func (b *Backend) CreateRenderPipeline(device types.Device, desc *types.RenderPi
 		return 0, err
 	}
 
-	// Get shader modules
-	vertexShader, err := b.registry.GetShaderModule(desc.VertexShader)
-	if err != nil {
-		return 0, err
-	}
-
-	fragmentShader, err := b.registry.GetShaderModule(desc.FragmentShader)
+	halDesc, err := b.renderPipelineDescriptor(desc)
 	if err != nil {
 		return 0, err
 	}
 
-	// Build HAL descriptor
-	halDesc := &hal.RenderPipelineDescriptor{
-		Label:  desc.Label,
-		Layout: nil, // Auto layout
-		Vertex: hal.VertexState{
-			Module:     vertexShader,
-			EntryPoint: desc.VertexEntryPoint,
-			Buffers:    nil, // No vertex buffers for triangle
-		},
-		Primitive: wgputypes.PrimitiveState{
-			Topology:  convertPrimitiveTopology(desc.Topology),
-			FrontFace: convertFrontFace(desc.FrontFace),
-			CullMode:  convertCullMode(desc.CullMode),
-		},
-		DepthStencil: nil, // No depth/stencil for triangle
-		Multisample:  wgputypes.MultisampleState{Count: 1, Mask: 0xFFFFFFFF},
-		Fragment: &hal.FragmentState{
-			Module:     fragmentShader,
-			EntryPoint: desc.FragmentEntry,
-			Targets: []wgputypes.ColorTargetState{
-				{
-					Format:    convertTextureFormat(desc.TargetFormat),
-					Blend:     nil, // No blending for now
-					WriteMask: wgputypes.ColorWriteMaskAll,
-				},
-			},
-		},
-	}
-
 	pipeline, err := halDevice.CreateRenderPipeline(halDesc)
 	if err != nil {
-		return 0, fmt.Errorf("native: failed to create render pipeline: %w", err)
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create render pipeline: %w", err))
 	}
 
 	handle := b.registry.RegisterRenderPipeline(pipeline)
+	if b.validator != nil {
+		b.validator.CreateRenderPipeline(handle, desc)
+	}
 	return handle, nil
 }
 
@@ -279,56 +332,81 @@ func (b *Backend) CreateCommandEncoder(device types.Device) types.CommandEncoder
 		return 0
 	}
 
-	desc := &hal.CommandEncoderDescriptor{
-		Label: "command_encoder",
-	}
-
-	encoder, err := halDevice.CreateCommandEncoder(desc)
+	pooled, err := b.acquireEncoder(device, halDevice)
 	if err != nil {
+		_ = b.reportError(device, fmt.Errorf("native: failed to create command encoder: %w", err))
 		return 0
 	}
 
-	handle := b.registry.RegisterCommandEncoder(encoder)
+	handle := b.registry.RegisterCommandEncoder(pooled.encoder)
+	b.pooledEncoders[handle] = pooled
+	if b.validator != nil {
+		b.validator.CreateCommandEncoder(handle, device)
+	}
 	return handle
 }
 
 // BeginRenderPass begins a render pass.
 func (b *Backend) BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass {
+	if b.validator != nil && b.rejected(b.validator.BeginRenderPass(encoder, desc)) {
+		return 0
+	}
+
 	halEncoder, err := b.registry.GetCommandEncoder(encoder)
 	if err != nil {
 		return 0
 	}
 
 	// Convert color attachments
-	colorAttachments := make([]hal.RenderPassColorAttachment, 0, len(desc.ColorAttachments))
+	colorAttachments := b.scratch.colorAttachments[:0]
 	for _, ca := range desc.ColorAttachments {
 		view, err := b.registry.GetTextureView(ca.View)
 		if err != nil {
 			continue
 		}
 
+		// Multisampled attachments resolve into the resolve target
+		var resolveTarget hal.TextureView
+		if ca.ResolveTarget != 0 {
+			resolveTarget, err = b.registry.GetTextureView(ca.ResolveTarget)
+			if err != nil {
+				continue
+			}
+		}
+
 		colorAttachments = append(colorAttachments, hal.RenderPassColorAttachment{
-			View:       view,
-			LoadOp:     convertLoadOp(ca.LoadOp),
-			StoreOp:    convertStoreOp(ca.StoreOp),
-			ClearValue: wgputypes.Color{R: ca.ClearValue.R, G: ca.ClearValue.G, B: ca.ClearValue.B, A: ca.ClearValue.A},
+			View:          view,
+			ResolveTarget: resolveTarget,
+			LoadOp:        convertLoadOp(ca.LoadOp),
+			StoreOp:       convertStoreOp(ca.StoreOp),
+			ClearValue:    wgputypes.Color{R: ca.ClearValue.R, G: ca.ClearValue.G, B: ca.ClearValue.B, A: ca.ClearValue.A},
 		})
 	}
 
-	halDesc := &hal.RenderPassDescriptor{
+	halDesc := &b.scratch.passDesc
+	*halDesc = hal.RenderPassDescriptor{
 		Label:            desc.Label,
 		ColorAttachments: colorAttachments,
 	}
 
 	// Begin render pass
 	pass := halEncoder.BeginRenderPass(halDesc)
+	b.scratch.colorAttachments = colorAttachments
+	b.scratch.reset()
 
 	handle := b.registry.RegisterRenderPass(pass)
+	if b.validator != nil {
+		b.validator.RenderPassBegun(encoder, handle)
+	}
 	return handle
 }
 
 // EndRenderPass ends a render pass.
 func (b *Backend) EndRenderPass(pass types.RenderPass) {
+	if b.validator != nil && b.rejected(b.validator.EndRenderPass(pass)) {
+		return
+	}
+
 	halPass, err := b.registry.GetRenderPass(pass)
 	if err != nil {
 		return
@@ -339,6 +417,10 @@ func (b *Backend) EndRenderPass(pass types.RenderPass) {
 
 // FinishEncoder finishes the command encoder.
 func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffer {
+	if b.validator != nil && b.rejected(b.validator.FinishEncoder(encoder)) {
+		return 0
+	}
+
 	halEncoder, err := b.registry.GetCommandEncoder(encoder)
 	if err != nil {
 		return 0
@@ -350,27 +432,51 @@ func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffe
 	}
 
 	handle := b.registry.RegisterCommandBuffer(cmdBuffer)
+	if pooled := b.pooledEncoders[encoder]; pooled != nil {
+		pooled.buffer = cmdBuffer
+		pooled.holds++
+		b.pooledBuffers[handle] = pooled
+	}
 	return handle
 }
 
 // Submit submits commands to the queue.
-func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) {
+func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
 	halQueue, err := b.registry.GetQueue(queue)
 	if err != nil {
-		return
+		return 0
 	}
 
 	halCmdBuffer, err := b.registry.GetCommandBuffer(commands)
 	if err != nil {
-		return
+		return 0
+	}
+
+	subs, err := b.queueSubmissions(queue)
+	if err != nil {
+		return 0
 	}
 
-	// Submit with no fence
-	_ = halQueue.Submit([]hal.CommandBuffer{halCmdBuffer}, nil, 0)
+	// The fence reaches the submission index once the work completes
+	index := subs.submitted + 1
+	buffers := append(b.scratch.commandBuffers[:0], halCmdBuffer)
+	err = halQueue.Submit(buffers, subs.fence, uint64(index))
+	b.scratch.commandBuffers = buffers
+	b.scratch.reset()
+	if err != nil {
+		_ = b.reportError(subs.device, fmt.Errorf("native: submit failed: %w", err))
+		return 0
+	}
+	subs.submitted = index
+	return index
 }
 
 // SetPipeline sets the render pipeline.
 func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline) {
+	if b.validator != nil && b.rejected(b.validator.SetRenderPipeline(pass, pipeline)) {
+		return
+	}
+
 	halPass, err := b.registry.GetRenderPass(pass)
 	if err != nil {
 		return
@@ -386,6 +492,10 @@ func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeli
 
 // Draw issues a draw call.
 func (b *Backend) Draw(pass types.RenderPass, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	if b.validator != nil && b.rejected(b.validator.Draw("Draw", pass, false, 0)) {
+		return
+	}
+
 	halPass, err := b.registry.GetRenderPass(pass)
 	if err != nil {
 		return
@@ -394,10 +504,125 @@ func (b *Backend) Draw(pass types.RenderPass, vertexCount, instanceCount, firstV
 	halPass.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
 }
 
+// DrawIndirect issues a draw call with arguments read from a buffer.
+func (b *Backend) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	if b.validator != nil && b.rejected(b.validator.Draw("DrawIndirect", pass, false, buffer)) {
+		return
+	}
+
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil {
+		return
+	}
+
+	halBuffer, err := b.registry.GetBuffer(buffer)
+	if err != nil {
+		return
+	}
+
+	halPass.DrawIndirect(halBuffer, offset)
+}
+
+// DrawIndexedIndirect issues an indexed draw call with arguments read from a buffer.
+func (b *Backend) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	if b.validator != nil && b.rejected(b.validator.Draw("DrawIndexedIndirect", pass, true, buffer)) {
+		return
+	}
+
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil {
+		return
+	}
+
+	halBuffer, err := b.registry.GetBuffer(buffer)
+	if err != nil {
+		return
+	}
+
+	halPass.DrawIndexedIndirect(halBuffer, offset)
+}
+
+// SetViewport sets the viewport transform of a render pass.
+func (b *Backend) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil {
+		return
+	}
+
+	halPass.SetViewport(x, y, width, height, minDepth, maxDepth)
+}
+
+// SetScissorRect restricts rendering to a rectangle of the attachments.
+func (b *Backend) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil {
+		return
+	}
+
+	halPass.SetScissorRect(x, y, width, height)
+}
+
+// SetBlendConstant sets the color used by BlendFactorConstant.
+func (b *Backend) SetBlendConstant(pass types.RenderPass, color *types.Color) {
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil || color == nil {
+		return
+	}
+
+	halPass.SetBlendConstant(&wgputypes.Color{R: color.R, G: color.G, B: color.B, A: color.A})
+}
+
+// SetStencilReference sets the reference value used by stencil tests.
+func (b *Backend) SetStencilReference(pass types.RenderPass, reference uint32) {
+	halPass, err := b.registry.GetRenderPass(pass)
+	if err != nil {
+		return
+	}
+
+	halPass.SetStencilReference(reference)
+}
+
 // --- Texture operations (stubs for now) ---
 
 func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescriptor) (types.Texture, error) {
-	return 0, gpu.ErrNotImplemented
+	halDevice, err := b.registry.GetDevice(device)
+	if err != nil {
+		return 0, err
+	}
+
+	halDesc := &hal.TextureDescriptor{
+		Label: desc.Label,
+		Size: hal.Extent3D{
+			Width:              desc.Size.Width,
+			Height:             desc.Size.Height,
+			DepthOrArrayLayers: desc.Size.DepthOrArrayLayers,
+		},
+		MipLevelCount: desc.MipLevelCount,
+		SampleCount:   desc.SampleCount,
+		Dimension:     convertTextureDimension(desc.Dimension),
+		Format:        convertTextureFormat(desc.Format),
+		Usage:         convertTextureUsage(desc.Usage),
+		ViewFormats:   convertTextureFormats(desc.ViewFormats),
+	}
+
+	texture, err := halDevice.CreateTexture(halDesc)
+	if err != nil {
+		return 0, b.reportError(device, fmt.Errorf("native: failed to create texture: %w", err))
+	}
+
+	handle := b.registry.RegisterTexture(texture)
+	b.registry.TrackTexture(handle, device, gpu.TextureMemorySize(desc))
+	if b.validator != nil {
+		b.validator.CreateTexture(handle, desc)
+	}
+	return handle, nil
+}
+
+// SupportedSampleCounts returns the sample counts a texture format supports
+// for multisampled rendering.
+func (b *Backend) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	// WebGPU guarantees 1 and 4 samples for all renderable color formats
+	return []uint32{1, 4}
 }
 
 func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
@@ -411,8 +636,13 @@ func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureVi
 		return 0
 	}
 
+	if desc == nil {
+		desc = &types.TextureViewDescriptor{} // Whole texture
+	}
+
 	// Convert descriptor
 	halDesc := &hal.TextureViewDescriptor{
+		Label:           desc.Label,
 		Format:          convertTextureFormat(desc.Format),
 		Dimension:       convertTextureViewDimension(desc.Dimension),
 		Aspect:          convertTextureAspect(desc.Aspect),
@@ -428,6 +658,9 @@ func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureVi
 	}
 
 	handle := b.registry.RegisterTextureView(view)
+	if b.validator != nil {
+		b.validator.CreateTextureView(handle, texture)
+	}
 	return handle
 }
 
@@ -435,10 +668,6 @@ func (b *Backend) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, d
 	// Not implemented yet
 }
 
-func (b *Backend) CreateSampler(device types.Device, desc *types.SamplerDescriptor) (types.Sampler, error) {
-	return 0, gpu.ErrNotImplemented
-}
-
 func (b *Backend) CreateBuffer(device types.Device, desc *types.BufferDescriptor) (types.Buffer, error) {
 	return 0, gpu.ErrNotImplemented
 }
@@ -447,6 +676,38 @@ func (b *Backend) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uin
 	// Not implemented yet
 }
 
+func (b *Backend) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	callback(gpu.ErrNotImplemented)
+}
+
+func (b *Backend) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	return nil
+}
+
+func (b *Backend) UnmapBuffer(buffer types.Buffer) {
+	// Not implemented yet
+}
+
+func (b *Backend) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+	// Not implemented yet
+}
+
+func (b *Backend) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+	// Not implemented yet
+}
+
+func (b *Backend) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	// Not implemented yet
+}
+
+func (b *Backend) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	// Not implemented yet
+}
+
+func (b *Backend) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+	// Not implemented yet
+}
+
 func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
 	return 0, gpu.ErrNotImplemented
 }
@@ -475,20 +736,30 @@ func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount,
 	// Not implemented yet
 }
 
+func (b *Backend) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+	// Not implemented yet; pipeline layouts have no push constants
+}
+
 // --- Resource release ---
 
 func (b *Backend) ReleaseTexture(texture types.Texture) {
+	if b.validator != nil {
+		b.validator.Release(texture)
+	}
 	halTexture, err := b.registry.GetTexture(texture)
 	if err == nil && halTexture != nil {
-		halTexture.Destroy()
+		b.destroyAfterGPU(halTexture)
 	}
 	b.registry.UnregisterTexture(texture)
 }
 
 func (b *Backend) ReleaseTextureView(view types.TextureView) {
+	if b.validator != nil {
+		b.validator.Release(view)
+	}
 	halView, err := b.registry.GetTextureView(view)
 	if err == nil && halView != nil {
-		halView.Destroy()
+		b.destroyAfterGPU(halView)
 	}
 	b.registry.UnregisterTextureView(view)
 }
@@ -496,7 +767,7 @@ func (b *Backend) ReleaseTextureView(view types.TextureView) {
 func (b *Backend) ReleaseSampler(sampler types.Sampler) {
 	halSampler, err := b.registry.GetSampler(sampler)
 	if err == nil && halSampler != nil {
-		halSampler.Destroy()
+		b.destroyAfterGPU(halSampler)
 	}
 	b.registry.UnregisterSampler(sampler)
 }
@@ -504,7 +775,7 @@ func (b *Backend) ReleaseSampler(sampler types.Sampler) {
 func (b *Backend) ReleaseBuffer(buffer types.Buffer) {
 	halBuffer, err := b.registry.GetBuffer(buffer)
 	if err == nil && halBuffer != nil {
-		halBuffer.Destroy()
+		b.destroyAfterGPU(halBuffer)
 	}
 	b.registry.UnregisterBuffer(buffer)
 }
@@ -512,7 +783,7 @@ func (b *Backend) ReleaseBuffer(buffer types.Buffer) {
 func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout) {
 	halLayout, err := b.registry.GetBindGroupLayout(layout)
 	if err == nil && halLayout != nil {
-		halLayout.Destroy()
+		b.destroyAfterGPU(halLayout)
 	}
 	b.registry.UnregisterBindGroupLayout(layout)
 }
@@ -520,7 +791,7 @@ func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout) {
 func (b *Backend) ReleaseBindGroup(group types.BindGroup) {
 	halGroup, err := b.registry.GetBindGroup(group)
 	if err == nil && halGroup != nil {
-		halGroup.Destroy()
+		b.destroyAfterGPU(halGroup)
 	}
 	b.registry.UnregisterBindGroup(group)
 }
@@ -528,26 +799,55 @@ func (b *Backend) ReleaseBindGroup(group types.BindGroup) {
 func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout) {
 	halLayout, err := b.registry.GetPipelineLayout(layout)
 	if err == nil && halLayout != nil {
-		halLayout.Destroy()
+		b.destroyAfterGPU(halLayout)
 	}
 	b.registry.UnregisterPipelineLayout(layout)
 }
 
+func (b *Backend) ReleaseShaderModule(module types.ShaderModule) {
+	halModule, err := b.registry.GetShaderModule(module)
+	if err == nil && halModule != nil {
+		b.destroyAfterGPU(halModule)
+	}
+	b.registry.UnregisterShaderModule(module)
+}
+
+func (b *Backend) ReleaseRenderPipeline(pipeline types.RenderPipeline) {
+	halPipeline, err := b.registry.GetRenderPipeline(pipeline)
+	if err == nil && halPipeline != nil {
+		b.destroyAfterGPU(halPipeline)
+	}
+	b.registry.UnregisterRenderPipeline(pipeline)
+}
+
 func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer) {
-	halBuffer, err := b.registry.GetCommandBuffer(buffer)
-	if err == nil && halBuffer != nil {
-		halBuffer.Destroy()
+	if pooled, ok := b.pooledBuffers[buffer]; ok {
+		// Destroyed with its encoder
+		delete(b.pooledBuffers, buffer)
+		b.releasePooled(pooled)
+	} else if halBuffer, err := b.registry.GetCommandBuffer(buffer); err == nil && halBuffer != nil {
+		b.destroyAfterGPU(halBuffer)
 	}
 	b.registry.UnregisterCommandBuffer(buffer)
 }
 
 func (b *Backend) ReleaseCommandEncoder(encoder types.CommandEncoder) {
-	// Command encoders don't have Destroy in HAL - they're consumed when EndEncoding() is called.
-	// We just unregister the handle from the registry.
+	if b.validator != nil {
+		b.validator.Release(encoder)
+	}
+	// Command encoders have no Destroy in the HAL; they go back to
+	// their pool, see pool.go
+	if pooled, ok := b.pooledEncoders[encoder]; ok {
+		delete(b.pooledEncoders, encoder)
+		b.releasePooled(pooled)
+	}
 	b.registry.UnregisterCommandEncoder(encoder)
 }
 
 func (b *Backend) ReleaseRenderPass(pass types.RenderPass) {
+	if b.validator != nil {
+		b.validator.Release(pass)
+	}
 	// Render passes are ended, not destroyed
 	b.registry.UnregisterRenderPass(pass)
 }
@@ -0,0 +1,317 @@
+//go:build windows || linux || darwin
+
+package rust
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// The gogpu enums below do not share their values with webgpu.h, so they
+// are converted by name.
+
+// convertPrimitiveTopology converts gogpu PrimitiveTopology to wgpu.PrimitiveTopology.
+func convertPrimitiveTopology(topology types.PrimitiveTopology) wgpu.PrimitiveTopology {
+	switch topology {
+	case types.PrimitiveTopologyPointList:
+		return wgpu.PrimitiveTopologyPointList
+	case types.PrimitiveTopologyLineList:
+		return wgpu.PrimitiveTopologyLineList
+	case types.PrimitiveTopologyLineStrip:
+		return wgpu.PrimitiveTopologyLineStrip
+	case types.PrimitiveTopologyTriangleStrip:
+		return wgpu.PrimitiveTopologyTriangleStrip
+	default:
+		return wgpu.PrimitiveTopologyTriangleList
+	}
+}
+
+// convertFrontFace converts gogpu FrontFace to wgpu.FrontFace.
+func convertFrontFace(face types.FrontFace) wgpu.FrontFace {
+	if face == types.FrontFaceCW {
+		return wgpu.FrontFaceCW
+	}
+	return wgpu.FrontFaceCCW
+}
+
+// convertCullMode converts gogpu CullMode to wgpu.CullMode.
+func convertCullMode(mode types.CullMode) wgpu.CullMode {
+	switch mode {
+	case types.CullModeFront:
+		return wgpu.CullModeFront
+	case types.CullModeBack:
+		return wgpu.CullModeBack
+	default:
+		return wgpu.CullModeNone
+	}
+}
+
+// convertVertexStepMode converts gogpu VertexStepMode to wgpu.VertexStepMode.
+func convertVertexStepMode(mode types.VertexStepMode) wgpu.VertexStepMode {
+	if mode == types.VertexStepModeInstance {
+		return wgpu.VertexStepModeInstance
+	}
+	return wgpu.VertexStepModeVertex
+}
+
+// convertVertexFormat converts gogpu VertexFormat to wgpu.VertexFormat.
+func convertVertexFormat(format types.VertexFormat) wgpu.VertexFormat {
+	switch format {
+	case types.VertexFormatUint8x2:
+		return wgpu.VertexFormatUint8x2
+	case types.VertexFormatUint8x4:
+		return wgpu.VertexFormatUint8x4
+	case types.VertexFormatSint8x2:
+		return wgpu.VertexFormatSint8x2
+	case types.VertexFormatSint8x4:
+		return wgpu.VertexFormatSint8x4
+	case types.VertexFormatUnorm8x2:
+		return wgpu.VertexFormatUnorm8x2
+	case types.VertexFormatUnorm8x4:
+		return wgpu.VertexFormatUnorm8x4
+	case types.VertexFormatSnorm8x2:
+		return wgpu.VertexFormatSnorm8x2
+	case types.VertexFormatSnorm8x4:
+		return wgpu.VertexFormatSnorm8x4
+	case types.VertexFormatUint16x2:
+		return wgpu.VertexFormatUint16x2
+	case types.VertexFormatUint16x4:
+		return wgpu.VertexFormatUint16x4
+	case types.VertexFormatSint16x2:
+		return wgpu.VertexFormatSint16x2
+	case types.VertexFormatSint16x4:
+		return wgpu.VertexFormatSint16x4
+	case types.VertexFormatUnorm16x2:
+		return wgpu.VertexFormatUnorm16x2
+	case types.VertexFormatUnorm16x4:
+		return wgpu.VertexFormatUnorm16x4
+	case types.VertexFormatSnorm16x2:
+		return wgpu.VertexFormatSnorm16x2
+	case types.VertexFormatSnorm16x4:
+		return wgpu.VertexFormatSnorm16x4
+	case types.VertexFormatFloat16x2:
+		return wgpu.VertexFormatFloat16x2
+	case types.VertexFormatFloat16x4:
+		return wgpu.VertexFormatFloat16x4
+	case types.VertexFormatFloat32:
+		return wgpu.VertexFormatFloat32
+	case types.VertexFormatFloat32x2:
+		return wgpu.VertexFormatFloat32x2
+	case types.VertexFormatFloat32x3:
+		return wgpu.VertexFormatFloat32x3
+	case types.VertexFormatFloat32x4:
+		return wgpu.VertexFormatFloat32x4
+	case types.VertexFormatUint32:
+		return wgpu.VertexFormatUint32
+	case types.VertexFormatUint32x2:
+		return wgpu.VertexFormatUint32x2
+	case types.VertexFormatUint32x3:
+		return wgpu.VertexFormatUint32x3
+	case types.VertexFormatUint32x4:
+		return wgpu.VertexFormatUint32x4
+	case types.VertexFormatSint32:
+		return wgpu.VertexFormatSint32
+	case types.VertexFormatSint32x2:
+		return wgpu.VertexFormatSint32x2
+	case types.VertexFormatSint32x3:
+		return wgpu.VertexFormatSint32x3
+	case types.VertexFormatSint32x4:
+		return wgpu.VertexFormatSint32x4
+	default:
+		return wgpu.VertexFormatFloat32x4
+	}
+}
+
+// convertBlendState converts a gogpu BlendState, whose enums match webgpu.h.
+func convertBlendState(state *types.BlendState) *wgpu.BlendState {
+	if state == nil {
+		return nil
+	}
+	return &wgpu.BlendState{
+		Color: convertBlendComponent(state.Color),
+		Alpha: convertBlendComponent(state.Alpha),
+	}
+}
+
+func convertBlendComponent(c types.BlendComponent) wgpu.BlendComponent {
+	return wgpu.BlendComponent{
+		Operation: wgpu.BlendOperation(c.Operation),
+		SrcFactor: wgpu.BlendFactor(c.SrcFactor),
+		DstFactor: wgpu.BlendFactor(c.DstFactor),
+	}
+}
+
+// convertTextureAspect converts a TextureAspect to its wgpu value.
+func convertTextureAspect(aspect types.TextureAspect) wgpu.TextureAspect {
+	switch aspect {
+	case types.TextureAspectStencilOnly:
+		return wgpu.TextureAspectStencilOnly
+	case types.TextureAspectDepthOnly:
+		return wgpu.TextureAspectDepthOnly
+	default:
+		return wgpu.TextureAspectAll
+	}
+}
+
+// toBool converts a Go bool to wgpu.Bool.
+func toBool(v bool) wgpu.Bool {
+	if v {
+		return wgpu.True
+	}
+	return wgpu.False
+}
+
+// toStringView returns a wgpu.StringView of a debug label. The view
+// points into label, which the caller keeps alive until wgpu-native has
+// copied it. Empty labels map to the null view so wgpu-native falls back
+// to its own naming.
+func toStringView(label string) wgpu.StringView {
+	if label == "" {
+		return wgpu.EmptyStringView()
+	}
+	return wgpu.StringView{
+		Data:   uintptr(unsafe.Pointer(unsafe.StringData(label))),
+		Length: uintptr(len(label)),
+	}
+}
+
+// countUndefined is WGPU_MIP_LEVEL_COUNT_UNDEFINED and
+// WGPU_ARRAY_LAYER_COUNT_UNDEFINED of webgpu.h, which go-webgpu v0.1.3
+// does not define.
+const countUndefined = 0xFFFFFFFF
+
+// countOrUndefined returns count, or countUndefined for a zero count,
+// which webgpu.h reads as "all remaining".
+func countOrUndefined(count uint32) uint32 {
+	if count == 0 {
+		return countUndefined
+	}
+	return count
+}
+
+// featureNames maps gogpu features to their webgpu.h names. go-webgpu
+// v0.1.3 only names timestamp queries.
+var featureNames = []struct {
+	feature types.Features
+	name    wgpu.FeatureName
+}{
+	{types.FeatureTimestampQuery, wgpu.FeatureNameTimestampQuery},
+}
+
+// supportedFeatures returns the features for which has reports true.
+func supportedFeatures(has func(wgpu.FeatureName) bool) types.Features {
+	var features types.Features
+	for _, f := range featureNames {
+		if has(f.name) {
+			features |= f.feature
+		}
+	}
+	return features
+}
+
+// convertGraphicsAPI converts wgpu.BackendType to gogpu GraphicsAPI.
+func convertGraphicsAPI(backend wgpu.BackendType) types.GraphicsAPI {
+	switch backend {
+	case wgpu.BackendTypeVulkan:
+		return types.GraphicsAPIVulkan
+	case wgpu.BackendTypeMetal:
+		return types.GraphicsAPIMetal
+	case wgpu.BackendTypeD3D12:
+		return types.GraphicsAPID3D12
+	case wgpu.BackendTypeOpenGL:
+		return types.GraphicsAPIOpenGL
+	case wgpu.BackendTypeOpenGLES:
+		return types.GraphicsAPIOpenGLES
+	case wgpu.BackendTypeWebGPU:
+		return types.GraphicsAPIWebGPU
+	default:
+		return types.GraphicsAPIUndefined
+	}
+}
+
+// convertAdapterType converts wgpu.AdapterType to gogpu AdapterType.
+func convertAdapterType(adapterType wgpu.AdapterType) types.AdapterType {
+	switch adapterType {
+	case wgpu.AdapterTypeDiscreteGPU:
+		return types.AdapterTypeDiscreteGPU
+	case wgpu.AdapterTypeIntegratedGPU:
+		return types.AdapterTypeIntegratedGPU
+	case wgpu.AdapterTypeCPU:
+		return types.AdapterTypeCPU
+	default:
+		return types.AdapterTypeUnknown
+	}
+}
+
+// convertLimits converts wgpu.Limits to gogpu Limits.
+func convertLimits(l *wgpu.Limits) types.Limits {
+	return types.Limits{
+		MaxTextureDimension1D:                     l.MaxTextureDimension1D,
+		MaxTextureDimension2D:                     l.MaxTextureDimension2D,
+		MaxTextureDimension3D:                     l.MaxTextureDimension3D,
+		MaxTextureArrayLayers:                     l.MaxTextureArrayLayers,
+		MaxBindGroups:                             l.MaxBindGroups,
+		MaxBindingsPerBindGroup:                   l.MaxBindingsPerBindGroup,
+		MaxDynamicUniformBuffersPerPipelineLayout: l.MaxDynamicUniformBuffersPerPipelineLayout,
+		MaxDynamicStorageBuffersPerPipelineLayout: l.MaxDynamicStorageBuffersPerPipelineLayout,
+		MaxSampledTexturesPerShaderStage:          l.MaxSampledTexturesPerShaderStage,
+		MaxSamplersPerShaderStage:                 l.MaxSamplersPerShaderStage,
+		MaxStorageBuffersPerShaderStage:           l.MaxStorageBuffersPerShaderStage,
+		MaxStorageTexturesPerShaderStage:          l.MaxStorageTexturesPerShaderStage,
+		MaxUniformBuffersPerShaderStage:           l.MaxUniformBuffersPerShaderStage,
+		MaxUniformBufferBindingSize:               l.MaxUniformBufferBindingSize,
+		MaxStorageBufferBindingSize:               l.MaxStorageBufferBindingSize,
+		MinUniformBufferOffsetAlignment:           l.MinUniformBufferOffsetAlignment,
+		MinStorageBufferOffsetAlignment:           l.MinStorageBufferOffsetAlignment,
+		MaxVertexBuffers:                          l.MaxVertexBuffers,
+		MaxBufferSize:                             l.MaxBufferSize,
+		MaxVertexAttributes:                       l.MaxVertexAttributes,
+		MaxVertexBufferArrayStride:                l.MaxVertexBufferArrayStride,
+		MaxColorAttachments:                       l.MaxColorAttachments,
+		MaxComputeWorkgroupStorageSize:            l.MaxComputeWorkgroupStorageSize,
+		MaxComputeInvocationsPerWorkgroup:         l.MaxComputeInvocationsPerWorkgroup,
+		MaxComputeWorkgroupSizeX:                  l.MaxComputeWorkgroupSizeX,
+		MaxComputeWorkgroupSizeY:                  l.MaxComputeWorkgroupSizeY,
+		MaxComputeWorkgroupSizeZ:                  l.MaxComputeWorkgroupSizeZ,
+		MaxComputeWorkgroupsPerDimension:          l.MaxComputeWorkgroupsPerDimension,
+	}
+}
+
+// convertErrorType converts wgpu.ErrorType to the gogpu ErrorFilter
+// whose scopes capture it.
+func convertErrorType(typ wgpu.ErrorType) types.ErrorFilter {
+	switch typ {
+	case wgpu.ErrorTypeValidation:
+		return types.ErrorFilterValidation
+	case wgpu.ErrorTypeOutOfMemory:
+		return types.ErrorFilterOutOfMemory
+	default:
+		return types.ErrorFilterInternal
+	}
+}
+
+// unmetLimit returns the name of the first of the required limits that
+// supported does not meet, or "" if it meets all of them. Max limits are
+// met by a value at least as high, Min alignments by one at most as high;
+// a Min alignment of 0 is not required.
+func unmetLimit(required, supported *types.Limits) string {
+	req := reflect.ValueOf(required).Elem()
+	sup := reflect.ValueOf(supported).Elem()
+	for i := range req.NumField() {
+		name := req.Type().Field(i).Name
+		r, s := req.Field(i).Uint(), sup.Field(i).Uint()
+		if strings.HasPrefix(name, "Min") {
+			if r != 0 && r < s {
+				return name
+			}
+		} else if r > s {
+			return name
+		}
+	}
+	return ""
+}
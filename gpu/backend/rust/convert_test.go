@@ -0,0 +1,43 @@
+//go:build windows || linux || darwin
+
+package rust
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestUnmetLimit(t *testing.T) {
+	defaults := types.DefaultLimits()
+
+	lower := defaults
+	lower.MaxBindGroups = 2
+	lower.MinUniformBufferOffsetAlignment = 512
+	lower.MinStorageBufferOffsetAlignment = 0
+
+	higher := defaults
+	higher.MaxBufferSize *= 2
+
+	finer := defaults
+	finer.MinUniformBufferOffsetAlignment = 64
+
+	pushConstants := defaults
+	pushConstants.MaxPushConstantSize = 128
+
+	for _, tt := range []struct {
+		name     string
+		required types.Limits
+		want     string
+	}{
+		{"defaults", defaults, ""},
+		{"lower", lower, ""},
+		{"higher", higher, "MaxBufferSize"},
+		{"finer alignment", finer, "MinUniformBufferOffsetAlignment"},
+		{"push constants", pushConstants, "MaxPushConstantSize"},
+	} {
+		if got := unmetLimit(&tt.required, &defaults); got != tt.want {
+			t.Errorf("%s: unmetLimit = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+//go:build windows || linux || darwin
+
+package rust
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestReportUnsupported(t *testing.T) {
+	b := New()
+	device := types.Device(b.newHandle())
+	scopes := &gpu.ErrorScopes{}
+	b.scopes.put(device, scopes)
+
+	encoder := types.CommandEncoder(b.newHandle())
+	pass := types.RenderPass(b.newHandle())
+	b.encoderDevices.put(encoder, device)
+	b.passDevices.put(pass, device)
+
+	scopes.Push(types.ErrorFilterValidation)
+	b.BeginOcclusionQuery(pass, 0)
+	if err := scopes.Pop(); !errors.Is(err, gpu.ErrNotImplemented) {
+		t.Errorf("BeginOcclusionQuery error = %v, want one wrapping ErrNotImplemented", err)
+	}
+
+	var uncaptured error
+	scopes.SetUncapturedCallback(func(err error) { uncaptured = err })
+	b.EndOcclusionQuery(pass)
+	if !errors.Is(uncaptured, gpu.ErrNotImplemented) {
+		t.Errorf("uncaptured EndOcclusionQuery error = %v, want one wrapping ErrNotImplemented", uncaptured)
+	}
+
+	bundle := types.RenderBundleEncoder(b.newHandle())
+	b.bundleEncoderDevices.put(bundle, device)
+	uncaptured = nil
+	b.SetBundlePushConstants(bundle, types.ShaderStageVertex, 0, make([]byte, 4))
+	if !errors.Is(uncaptured, gpu.ErrNotImplemented) {
+		t.Errorf("uncaptured SetBundlePushConstants error = %v, want one wrapping ErrNotImplemented", uncaptured)
+	}
+
+	// Released passes no longer know their device
+	b.ReleaseRenderPass(pass)
+	uncaptured = nil
+	b.EndOcclusionQuery(pass)
+	if uncaptured != nil {
+		t.Errorf("EndOcclusionQuery on a released pass reported %v", uncaptured)
+	}
+}
+
+func TestLoseDevice(t *testing.T) {
+	b := New()
+	device := types.Device(b.newHandle())
+	var losses []types.DeviceLostReason
+	b.SetDeviceLostCallback(device, func(reason types.DeviceLostReason, message string) {
+		losses = append(losses, reason)
+	})
+
+	b.loseDevice(device, "device lost")
+	b.loseDevice(device, "device lost")
+	if len(losses) != 1 || losses[0] != types.DeviceLostReasonUnknown {
+		t.Errorf("losses = %v, want one of DeviceLostReasonUnknown", losses)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build windows || linux || darwin
+
+package rust
+
+import "sync"
+
+// handleMap maps handles of type H to wgpu-native objects of type T.
+//
+// Thread-safe: like the tables of the native registry, each resource type
+// has its own handleMap, so goroutines creating or using resources of
+// different types do not contend. Handles come from one counter and are
+// never reused, so a handle used after its resource was released finds
+// nothing instead of a newer resource. The zero value is ready for use.
+type handleMap[H ~uintptr, T any] struct {
+	mu sync.RWMutex
+	m  map[H]T
+}
+
+// get returns the object of handle, or the zero value.
+func (m *handleMap[H, T]) get(handle H) T {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m[handle]
+}
+
+// put stores the object of handle.
+func (m *handleMap[H, T]) put(handle H, value T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m == nil {
+		m.m = make(map[H]T)
+	}
+	m.m[handle] = value
+}
+
+// take removes the object of handle and returns it. Of several
+// goroutines releasing the same handle, only one gets ok.
+func (m *handleMap[H, T]) take(handle H) (value T, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok = m.m[handle]
+	delete(m.m, handle)
+	return value, ok
+}
+
+// takeAll removes all objects and returns them.
+func (m *handleMap[H, T]) takeAll() []T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	values := make([]T, 0, len(m.m))
+	for _, v := range m.m {
+		values = append(values, v)
+	}
+	clear(m.m)
+	return values
+}
@@ -0,0 +1,54 @@
+//go:build windows || linux || darwin
+
+package rust
+
+import (
+	"sync"
+	"testing"
+)
+
+type testHandle uintptr
+
+func TestHandleMapConcurrent(t *testing.T) {
+	var b Backend
+	var m handleMap[testHandle, int]
+	keep := testHandle(b.newHandle())
+	m.put(keep, -1)
+
+	var wg sync.WaitGroup
+	released := make([]int, 4)
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				h := testHandle(b.newHandle())
+				m.put(h, i)
+				if v := m.get(h); v != i {
+					t.Errorf("get = %d, want %d", v, i)
+					return
+				}
+				if v := m.get(keep); v != -1 {
+					t.Errorf("get(keep) = %d", v)
+					return
+				}
+				// Two releases of the same handle, only one takes it
+				for range 2 {
+					if _, ok := m.take(h); ok {
+						released[g]++
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for g, n := range released {
+		if n != 1000 {
+			t.Errorf("goroutine %d took %d handles, want 1000", g, n)
+		}
+	}
+	if values := m.takeAll(); len(values) != 1 || values[0] != -1 {
+		t.Errorf("takeAll = %v, want [-1]", values)
+	}
+}
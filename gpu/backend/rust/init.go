@@ -1,10 +1,11 @@
-//go:build (windows && !purego) || rust
+//go:build ((windows || linux || darwin) && !purego) || rust
 
 // Package rust provides the WebGPU backend using wgpu-native (Rust) via go-webgpu/webgpu.
 //
 // Build tags:
-//   - Default (no tags): included on Windows
-//   - -tags rust: force include on any platform
+//   - Default (no tags): included on Windows, Linux and macOS
+//   - -tags rust: force include on any platform; elsewhere the stub of
+//     rust_stub.go reports itself unavailable, so nothing registers
 //   - -tags purego: exclude (use native backend only)
 package rust
 
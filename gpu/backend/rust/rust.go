@@ -1,12 +1,19 @@
-//go:build windows
+//go:build windows || linux || darwin
 
 // Package rust provides the WebGPU backend using wgpu-native (Rust) via go-webgpu/webgpu.
 // This backend offers maximum performance and is battle-tested in production.
-// Currently only available on Windows due to go-webgpu/goffi limitations.
+// It runs on Windows, Linux (X11, or XWayland) and macOS, where the
+// wgpu-native library is installed, on amd64 and arm64 except
+// windows/arm64, which goffi v0.3.6 does not support yet.
 package rust
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
 
 	"github.com/go-webgpu/webgpu/wgpu"
 
@@ -15,62 +22,81 @@ import (
 )
 
 // Backend implements gpu.Backend using wgpu-native.
+//
+// Resources may be created and released from several goroutines: the
+// handle maps are guarded, see handleMap.
 type Backend struct {
 	// Store native handles for cleanup
-	instances        map[types.Instance]*wgpu.Instance
-	adapters         map[types.Adapter]*wgpu.Adapter
-	devices          map[types.Device]*wgpu.Device
-	queues           map[types.Queue]*wgpu.Queue
-	surfaces         map[types.Surface]*wgpu.Surface
-	shaders          map[types.ShaderModule]*wgpu.ShaderModule
-	pipelines        map[types.RenderPipeline]*wgpu.RenderPipeline
-	encoders         map[types.CommandEncoder]*wgpu.CommandEncoder
-	cmdBuffers       map[types.CommandBuffer]*wgpu.CommandBuffer
-	passes           map[types.RenderPass]*wgpu.RenderPassEncoder
-	textures         map[types.Texture]*wgpu.Texture
-	views            map[types.TextureView]*wgpu.TextureView
-	samplers         map[types.Sampler]*wgpu.Sampler
-	gpuBuffers       map[types.Buffer]*wgpu.Buffer
-	bindGroupLayouts map[types.BindGroupLayout]*wgpu.BindGroupLayout
-	bindGroups       map[types.BindGroup]*wgpu.BindGroup
-	pipelineLayouts  map[types.PipelineLayout]*wgpu.PipelineLayout
-
-	nextHandle uintptr
-}
-
-// IsAvailable returns true on Windows where go-webgpu/goffi is supported.
+	instances        handleMap[types.Instance, *wgpu.Instance]
+	adapters         handleMap[types.Adapter, *wgpu.Adapter]
+	devices          handleMap[types.Device, *wgpu.Device]
+	queues           handleMap[types.Queue, *wgpu.Queue]
+	surfaces         handleMap[types.Surface, *wgpu.Surface]
+	shaders          handleMap[types.ShaderModule, *wgpu.ShaderModule]
+	pipelines        handleMap[types.RenderPipeline, *wgpu.RenderPipeline]
+	encoders         handleMap[types.CommandEncoder, *wgpu.CommandEncoder]
+	cmdBuffers       handleMap[types.CommandBuffer, *wgpu.CommandBuffer]
+	passes           handleMap[types.RenderPass, *wgpu.RenderPassEncoder]
+	textures         handleMap[types.Texture, *wgpu.Texture]
+	views            handleMap[types.TextureView, *wgpu.TextureView]
+	samplers         handleMap[types.Sampler, *wgpu.Sampler]
+	gpuBuffers       handleMap[types.Buffer, *wgpu.Buffer]
+	bindGroupLayouts handleMap[types.BindGroupLayout, *wgpu.BindGroupLayout]
+	bindGroups       handleMap[types.BindGroup, *wgpu.BindGroup]
+	pipelineLayouts  handleMap[types.PipelineLayout, *wgpu.PipelineLayout]
+	computePipelines handleMap[types.ComputePipeline, *wgpu.ComputePipeline]
+	computePasses    handleMap[types.ComputePass, *wgpu.ComputePassEncoder]
+	querySets        handleMap[types.QuerySet, *wgpu.QuerySet]
+	bundleEncoders   handleMap[types.RenderBundleEncoder, *wgpu.RenderBundleEncoder]
+	bundles          handleMap[types.RenderBundle, *wgpu.RenderBundle]
+
+	// Device of each command encoder, render pass and render bundle
+	// encoder, to report errors of the calls this backend cannot carry
+	// out
+	encoderDevices       handleMap[types.CommandEncoder, types.Device]
+	passDevices          handleMap[types.RenderPass, types.Device]
+	bundleEncoderDevices handleMap[types.RenderBundleEncoder, types.Device]
+
+	// Error scopes by device, mirrored on the wgpu-native device
+	scopes handleMap[types.Device, *gpu.ErrorScopes]
+
+	// Instance of each adapter and device, which wgpu-native needs to
+	// pop error scopes
+	adapterInstances handleMap[types.Adapter, *wgpu.Instance]
+	deviceInstances  handleMap[types.Device, *wgpu.Instance]
+
+	// Index of the last submission to each queue
+	submissions handleMap[types.Queue, *atomic.Uint64]
+
+	// Work-done callbacks by device, run by Poll, and the device of each
+	// queue; go-webgpu v0.1.3 lacks wgpuQueueOnSubmittedWorkDone
+	workDone     handleMap[types.Device, *workDoneCallbacks]
+	queueDevices handleMap[types.Queue, types.Device]
+
+	// Device-lost callbacks by device, and the device each surface is
+	// configured for; go-webgpu v0.1.3 cannot set the device-lost
+	// callback, so surfaces that report their device lost call it
+	deviceLost     handleMap[types.Device, func(reason types.DeviceLostReason, message string)]
+	surfaceDevices handleMap[types.Surface, types.Device]
+
+	nextHandle atomic.Uintptr
+}
+
+// IsAvailable returns true on the platforms go-webgpu/goffi supports.
+// Init fails if the wgpu-native library is missing.
 func IsAvailable() bool {
 	return true
 }
 
 // New creates a new Rust backend.
 func New() *Backend {
-	return &Backend{
-		instances:        make(map[types.Instance]*wgpu.Instance),
-		adapters:         make(map[types.Adapter]*wgpu.Adapter),
-		devices:          make(map[types.Device]*wgpu.Device),
-		queues:           make(map[types.Queue]*wgpu.Queue),
-		surfaces:         make(map[types.Surface]*wgpu.Surface),
-		shaders:          make(map[types.ShaderModule]*wgpu.ShaderModule),
-		pipelines:        make(map[types.RenderPipeline]*wgpu.RenderPipeline),
-		encoders:         make(map[types.CommandEncoder]*wgpu.CommandEncoder),
-		cmdBuffers:       make(map[types.CommandBuffer]*wgpu.CommandBuffer),
-		passes:           make(map[types.RenderPass]*wgpu.RenderPassEncoder),
-		textures:         make(map[types.Texture]*wgpu.Texture),
-		views:            make(map[types.TextureView]*wgpu.TextureView),
-		samplers:         make(map[types.Sampler]*wgpu.Sampler),
-		gpuBuffers:       make(map[types.Buffer]*wgpu.Buffer),
-		bindGroupLayouts: make(map[types.BindGroupLayout]*wgpu.BindGroupLayout),
-		bindGroups:       make(map[types.BindGroup]*wgpu.BindGroup),
-		pipelineLayouts:  make(map[types.PipelineLayout]*wgpu.PipelineLayout),
-		nextHandle:       1,
-	}
+	return &Backend{}
 }
 
+// newHandle returns a handle not used before. It is safe for concurrent
+// use.
 func (b *Backend) newHandle() uintptr {
-	h := b.nextHandle
-	b.nextHandle++
-	return h
+	return b.nextHandle.Add(1)
 }
 
 // Releasable is implemented by all wgpu resource types.
@@ -79,10 +105,9 @@ type Releasable interface {
 }
 
 // releaseMap releases all resources in a map (Rust Drop pattern).
-func releaseMap[K comparable, V Releasable](m map[K]V) {
-	for k, v := range m {
+func releaseMap[H ~uintptr, V Releasable](m *handleMap[H, V]) {
+	for _, v := range m.takeAll() {
 		v.Release()
-		delete(m, k)
 	}
 }
 
@@ -98,20 +123,24 @@ func (b *Backend) Init() error {
 
 // Destroy releases all backend resources in reverse order of creation.
 func (b *Backend) Destroy() {
-	releaseMap(b.pipelineLayouts)
-	releaseMap(b.bindGroups)
-	releaseMap(b.bindGroupLayouts)
-	releaseMap(b.gpuBuffers)
-	releaseMap(b.samplers)
-	releaseMap(b.views)
-	releaseMap(b.textures)
-	releaseMap(b.pipelines)
-	releaseMap(b.shaders)
-	releaseMap(b.surfaces)
-	releaseMap(b.queues)
-	releaseMap(b.devices)
-	releaseMap(b.adapters)
-	releaseMap(b.instances)
+	releaseMap(&b.bundles)
+	releaseMap(&b.bundleEncoders)
+	releaseMap(&b.querySets)
+	releaseMap(&b.computePipelines)
+	releaseMap(&b.pipelineLayouts)
+	releaseMap(&b.bindGroups)
+	releaseMap(&b.bindGroupLayouts)
+	releaseMap(&b.gpuBuffers)
+	releaseMap(&b.samplers)
+	releaseMap(&b.views)
+	releaseMap(&b.textures)
+	releaseMap(&b.pipelines)
+	releaseMap(&b.shaders)
+	releaseMap(&b.surfaces)
+	releaseMap(&b.queues)
+	releaseMap(&b.devices)
+	releaseMap(&b.adapters)
+	releaseMap(&b.instances)
 }
 
 // CreateInstance creates a WebGPU instance.
@@ -121,13 +150,13 @@ func (b *Backend) CreateInstance() (types.Instance, error) {
 		return 0, fmt.Errorf("rust backend: create instance: %w", err)
 	}
 	handle := types.Instance(b.newHandle())
-	b.instances[handle] = inst
+	b.instances.put(handle, inst)
 	return handle, nil
 }
 
 // RequestAdapter requests a GPU adapter.
 func (b *Backend) RequestAdapter(instance types.Instance, opts *types.AdapterOptions) (types.Adapter, error) {
-	inst := b.instances[instance]
+	inst := b.instances.get(instance)
 	if inst == nil {
 		return 0, fmt.Errorf("rust backend: invalid instance")
 	}
@@ -145,64 +174,277 @@ func (b *Backend) RequestAdapter(instance types.Instance, opts *types.AdapterOpt
 	}
 
 	handle := types.Adapter(b.newHandle())
-	b.adapters[handle] = adapter
+	b.adapters.put(handle, adapter)
+	b.adapterInstances.put(handle, inst)
 	return handle, nil
 }
 
-// RequestDevice requests a GPU device.
+// RequestDevice requests a GPU device with the default limits and no
+// optional features. Requests for features, or for limits the defaults do
+// not meet, fail with an error wrapping gpu.ErrNotImplemented.
 func (b *Backend) RequestDevice(adapter types.Adapter, opts *types.DeviceOptions) (types.Device, error) {
-	adpt := b.adapters[adapter]
+	adpt := b.adapters.get(adapter)
 	if adpt == nil {
 		return 0, fmt.Errorf("rust backend: invalid adapter")
 	}
 
+	// go-webgpu v0.1.3 cannot request features or limits, its devices get
+	// none and the defaults; requests beyond those fail instead of
+	// returning a device without them
+	if opts != nil && opts.RequiredFeatures != 0 {
+		return 0, fmt.Errorf("rust backend: required features %#x: %w", uint64(opts.RequiredFeatures), gpu.ErrNotImplemented)
+	}
+	if opts != nil && opts.RequiredLimits != nil {
+		defaults := types.DefaultLimits()
+		if name := unmetLimit(opts.RequiredLimits, &defaults); name != "" {
+			return 0, fmt.Errorf("rust backend: required limit %s beyond the defaults: %w", name, gpu.ErrNotImplemented)
+		}
+	}
+
 	device, err := adpt.RequestDevice(nil)
 	if err != nil {
 		return 0, fmt.Errorf("rust backend: request device: %w", err)
 	}
 
 	handle := types.Device(b.newHandle())
-	b.devices[handle] = device
+	b.devices.put(handle, device)
+	b.deviceInstances.put(handle, b.adapterInstances.get(adapter))
+
+	// Each scope is also pushed on the device, which captures the
+	// errors; the stack on this side guards wgpu-native, which panics
+	// when popping an empty one
+	b.scopes.put(handle, &gpu.ErrorScopes{})
+	b.workDone.put(handle, &workDoneCallbacks{})
+
 	return handle, nil
 }
 
 // GetQueue gets the device queue.
 func (b *Backend) GetQueue(device types.Device) types.Queue {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0
 	}
 	queue := dev.GetQueue()
 	handle := types.Queue(b.newHandle())
-	b.queues[handle] = queue
+	b.queues.put(handle, queue)
+	b.submissions.put(handle, new(atomic.Uint64))
+	b.queueDevices.put(handle, device)
 	return handle
 }
 
+// PushErrorScope opens an error scope on a device.
+func (b *Backend) PushErrorScope(device types.Device, filter types.ErrorFilter) {
+	scopes := b.scopes.get(device)
+	dev := b.devices.get(device)
+	if scopes == nil || dev == nil {
+		return
+	}
+	scopes.Push(filter)
+	dev.PushErrorScope(wgpu.ErrorFilter(filter))
+}
+
+// PopErrorScope closes the innermost error scope of a device and returns
+// the first error it captured.
+func (b *Backend) PopErrorScope(device types.Device) error {
+	scopes := b.scopes.get(device)
+	dev := b.devices.get(device)
+	if scopes == nil || dev == nil {
+		return fmt.Errorf("rust backend: invalid device")
+	}
+	// The scope on this side captured the errors of this backend, the
+	// one on the device those of wgpu-native; both are popped
+	captured := scopes.Pop()
+	if errors.Is(captured, gpu.ErrErrorScopeEmpty) {
+		return captured
+	}
+
+	typ, message, err := dev.PopErrorScopeAsync(b.deviceInstances.get(device))
+	if err != nil {
+		return fmt.Errorf("rust backend: pop error scope: %w", err)
+	}
+	if captured != nil || typ == wgpu.ErrorTypeNoError {
+		return captured
+	}
+	return &types.Error{Type: convertErrorType(typ), Message: message}
+}
+
+// reportUnsupported reports a call on device that go-webgpu v0.1.3 gives
+// no way to carry out as a validation error wrapping gpu.ErrNotImplemented,
+// like the errors wgpu-native reports for invalid calls.
+func (b *Backend) reportUnsupported(device types.Device, what string) {
+	scopes := b.scopes.get(device)
+	if scopes == nil {
+		return
+	}
+	err := fmt.Errorf("rust backend: %s: %w", what, gpu.ErrNotImplemented)
+	scopes.Report(&types.Error{Type: types.ErrorFilterValidation, Message: err.Error(), Err: err})
+}
+
+// SetUncapturedErrorCallback sets the function called with the errors of
+// a device no error scope captures. go-webgpu v0.1.3 cannot set the
+// callback on the wgpu-native device, so the calls that create resources,
+// finish encoders, write or submit catch their errors in error scopes of
+// their own instead, see captureErrors. Errors of other calls are left to
+// wgpu-native, which logs them.
+func (b *Backend) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {
+	if scopes := b.scopes.get(device); scopes != nil {
+		scopes.SetUncapturedCallback(callback)
+	}
+}
+
+// nativeErrorFilters are the filters of the error scopes captureErrors
+// pushes, one per kind of error.
+var nativeErrorFilters = [...]wgpu.ErrorFilter{
+	wgpu.ErrorFilterValidation,
+	wgpu.ErrorFilterOutOfMemory,
+	wgpu.ErrorFilterInternal,
+}
+
+// captureErrors pushes error scopes on the wgpu-native device for the
+// errors of a call, if they would be uncaptured, and returns the function
+// that pops them after the call and reports the errors to the device's
+// uncaptured-error callback. Callers defer it:
+//
+//	defer b.captureErrors(device)()
+//
+// wgpu-native keeps error scopes per thread, so the goroutine stays on
+// its thread until the scopes are popped.
+func (b *Backend) captureErrors(device types.Device) (pop func()) {
+	scopes := b.scopes.get(device)
+	dev := b.devices.get(device)
+	if scopes == nil || dev == nil || !scopes.Uncaptured() {
+		return func() {}
+	}
+
+	runtime.LockOSThread()
+	for _, filter := range nativeErrorFilters {
+		dev.PushErrorScope(filter)
+	}
+	return func() {
+		defer runtime.UnlockOSThread()
+		instance := b.deviceInstances.get(device)
+		for range nativeErrorFilters {
+			typ, message, err := dev.PopErrorScopeAsync(instance)
+			if err == nil && typ != wgpu.ErrorTypeNoError {
+				scopes.Report(&types.Error{Type: convertErrorType(typ), Message: message})
+			}
+		}
+	}
+}
+
+// SetDeviceLostCallback sets the function called once when a device is
+// lost. go-webgpu v0.1.3 cannot set the callback on the wgpu-native
+// device, so the loss is seen when GetCurrentTexture on a surface
+// configured for the device reports it, with DeviceLostReasonUnknown.
+// Losses of devices without a surface, and devices destroyed on purpose,
+// are not reported.
+func (b *Backend) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+	b.deviceLost.put(device, callback)
+}
+
+// loseDevice calls the device-lost callback of a device, once.
+func (b *Backend) loseDevice(device types.Device, message string) {
+	if callback, ok := b.deviceLost.take(device); ok && callback != nil {
+		callback(types.DeviceLostReasonUnknown, message)
+	}
+}
+
+// GetAdapterInfo returns information about an adapter.
+func (b *Backend) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	adpt := b.adapters.get(adapter)
+	if adpt == nil {
+		return types.AdapterInfo{}
+	}
+
+	info, err := adpt.GetInfo()
+	if err != nil {
+		return types.AdapterInfo{}
+	}
+
+	return types.AdapterInfo{
+		Name:        info.Device,
+		Vendor:      info.Vendor,
+		Driver:      info.Description,
+		VendorID:    info.VendorID,
+		DeviceID:    info.DeviceID,
+		Backend:     convertGraphicsAPI(info.BackendType),
+		AdapterType: convertAdapterType(info.AdapterType),
+	}
+}
+
+// GetAdapterFeatures returns the features an adapter supports.
+func (b *Backend) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	adpt := b.adapters.get(adapter)
+	if adpt == nil {
+		return 0
+	}
+	return supportedFeatures(adpt.HasFeature)
+}
+
+// GetAdapterLimits returns the limits of an adapter.
+func (b *Backend) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	adpt := b.adapters.get(adapter)
+	if adpt == nil {
+		return types.Limits{}
+	}
+
+	limits, err := adpt.GetLimits()
+	if err != nil {
+		return types.Limits{}
+	}
+	return convertLimits(&limits.Limits)
+}
+
+// GetDeviceFeatures returns the features enabled on a device, which are
+// none, see RequestDevice.
+func (b *Backend) GetDeviceFeatures(device types.Device) types.Features {
+	return 0
+}
+
+// GetDeviceLimits returns the limits of a device, which are the
+// defaults wgpu-native gives devices requested without limits, see
+// RequestDevice.
+func (b *Backend) GetDeviceLimits(device types.Device) types.Limits {
+	if b.devices.get(device) == nil {
+		return types.Limits{}
+	}
+	return types.DefaultLimits()
+}
+
 // CreateSurface creates a rendering surface.
 func (b *Backend) CreateSurface(instance types.Instance, sh types.SurfaceHandle) (types.Surface, error) {
-	inst := b.instances[instance]
+	inst := b.instances.get(instance)
 	if inst == nil {
 		return 0, fmt.Errorf("rust backend: invalid instance")
 	}
 
-	surface, err := inst.CreateSurfaceFromWindowsHWND(sh.Instance, sh.Window)
+	surface, err := createSurface(inst, sh)
 	if err != nil {
 		return 0, fmt.Errorf("rust backend: create surface: %w", err)
 	}
 
 	handle := types.Surface(b.newHandle())
-	b.surfaces[handle] = surface
+	b.surfaces.put(handle, surface)
 	return handle, nil
 }
 
+// GetSurfaceCapabilities returns empty capabilities: go-webgpu v0.1.3
+// cannot query them, so the renderer falls back to BGRA8Unorm, Fifo and
+// the requested alpha mode.
+func (b *Backend) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	return types.SurfaceCapabilities{}
+}
+
 // ConfigureSurface configures the surface.
 func (b *Backend) ConfigureSurface(surface types.Surface, device types.Device, config *types.SurfaceConfig) {
-	surf := b.surfaces[surface]
-	dev := b.devices[device]
+	surf := b.surfaces.get(surface)
+	dev := b.devices.get(device)
 	if surf == nil || dev == nil {
 		return
 	}
 
+	b.surfaceDevices.put(surface, device)
 	surf.Configure(&wgpu.SurfaceConfiguration{
 		Device:      dev,
 		Format:      wgpu.TextureFormat(config.Format),
@@ -216,18 +458,21 @@ func (b *Backend) ConfigureSurface(surface types.Surface, device types.Device, c
 
 // GetCurrentTexture gets the current surface texture.
 func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture, error) {
-	surf := b.surfaces[surface]
+	surf := b.surfaces.get(surface)
 	if surf == nil {
 		return types.SurfaceTexture{}, fmt.Errorf("rust backend: invalid surface")
 	}
 
 	tex, err := surf.GetCurrentTexture()
+	if errors.Is(err, wgpu.ErrSurfaceDeviceLost) {
+		b.loseDevice(b.surfaceDevices.get(surface), err.Error())
+	}
 	if err != nil {
 		return types.SurfaceTexture{Status: types.SurfaceStatusError}, err
 	}
 
 	handle := types.Texture(b.newHandle())
-	b.textures[handle] = tex.Texture
+	b.textures.put(handle, tex.Texture)
 
 	return types.SurfaceTexture{
 		Texture: handle,
@@ -237,100 +482,226 @@ func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture
 
 // Present presents the surface.
 func (b *Backend) Present(surface types.Surface) {
-	surf := b.surfaces[surface]
+	surf := b.surfaces.get(surface)
 	if surf != nil {
 		surf.Present()
 	}
 }
 
+// ReleaseSurface releases a surface. wgpu-native keeps it alive until
+// the frames in flight on it have completed.
+func (b *Backend) ReleaseSurface(surface types.Surface) {
+	b.surfaceDevices.take(surface)
+	if surf, ok := b.surfaces.take(surface); ok {
+		surf.Release()
+	}
+}
+
 // CreateShaderModuleWGSL creates a shader module from WGSL code.
 func (b *Backend) CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
+	defer b.captureErrors(device)()
 	shader := dev.CreateShaderModuleWGSL(code)
 	if shader == nil {
 		return 0, fmt.Errorf("rust backend: failed to create shader module")
 	}
 
 	handle := types.ShaderModule(b.newHandle())
-	b.shaders[handle] = shader
+	b.shaders.put(handle, shader)
 	return handle, nil
 }
 
+// CreateShaderModuleSPIRV creates a shader module from SPIR-V words.
+// wgpu-native translates it for Metal and Direct3D 12.
+func (b *Backend) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	dev := b.devices.get(device)
+	if dev == nil {
+		return 0, fmt.Errorf("rust backend: invalid device")
+	}
+	if len(code) == 0 {
+		return 0, fmt.Errorf("rust backend: empty SPIR-V shader")
+	}
+
+	// go-webgpu v0.1.3 only wraps WGSL sources, so the SPIR-V source
+	// is chained by hand
+	source := shaderSourceSPIRV{
+		Chain:    wgpu.ChainedStruct{SType: uint32(wgpu.STypeShaderSourceSPIRV)},
+		CodeSize: uint32(len(code)),
+		Code:     &code[0],
+	}
+	defer b.captureErrors(device)()
+	shader := dev.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
+		NextInChain: uintptr(unsafe.Pointer(&source)),
+		Label:       wgpu.EmptyStringView(),
+	})
+	runtime.KeepAlive(&source)
+	if shader == nil {
+		return 0, fmt.Errorf("rust backend: failed to create shader module from SPIR-V")
+	}
+
+	handle := types.ShaderModule(b.newHandle())
+	b.shaders.put(handle, shader)
+	return handle, nil
+}
+
+// shaderSourceSPIRV is WGPUShaderSourceSPIRV of webgpu.h.
+type shaderSourceSPIRV struct {
+	Chain    wgpu.ChainedStruct
+	CodeSize uint32 // In words
+	Code     *uint32
+}
+
 // CreateRenderPipeline creates a render pipeline.
 func (b *Backend) CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
-	vertShader := b.shaders[desc.VertexShader]
-	fragShader := b.shaders[desc.FragmentShader]
-	if vertShader == nil || fragShader == nil {
+	vertShader := b.shaders.get(desc.VertexShader)
+	if vertShader == nil {
 		return 0, fmt.Errorf("rust backend: invalid shader module")
 	}
 
-	pipeline := dev.CreateRenderPipelineSimple(
-		nil,
-		vertShader, desc.VertexEntryPoint,
-		fragShader, desc.FragmentEntry,
-		wgpu.TextureFormat(desc.TargetFormat),
-	)
+	var layout *wgpu.PipelineLayout // nil = auto layout
+	if desc.Layout != 0 {
+		layout = b.pipelineLayouts.get(desc.Layout)
+		if layout == nil {
+			return 0, fmt.Errorf("rust backend: invalid pipeline layout")
+		}
+	}
+
+	buffers := make([]wgpu.VertexBufferLayout, len(desc.VertexBuffers))
+	for i, vb := range desc.VertexBuffers {
+		attributes := make([]wgpu.VertexAttribute, len(vb.Attributes))
+		for j, attr := range vb.Attributes {
+			attributes[j] = wgpu.VertexAttribute{
+				Format:         convertVertexFormat(attr.Format),
+				Offset:         attr.Offset,
+				ShaderLocation: attr.ShaderLocation,
+			}
+		}
+		buffers[i] = wgpu.VertexBufferLayout{
+			ArrayStride:    vb.ArrayStride,
+			StepMode:       convertVertexStepMode(vb.StepMode),
+			AttributeCount: uintptr(len(attributes)),
+		}
+		if len(attributes) > 0 {
+			buffers[i].Attributes = &attributes[0]
+		}
+	}
+
+	multisample := desc.Multisample.WithDefaults()
+	wgpuDesc := &wgpu.RenderPipelineDescriptor{
+		Label:  desc.Label,
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     vertShader,
+			EntryPoint: desc.VertexEntryPoint,
+			Buffers:    buffers,
+		},
+		Primitive: wgpu.PrimitiveState{
+			Topology:  convertPrimitiveTopology(desc.Topology),
+			FrontFace: convertFrontFace(desc.FrontFace),
+			CullMode:  convertCullMode(desc.CullMode),
+		},
+		Multisample: wgpu.MultisampleState{
+			Count:                  multisample.Count,
+			Mask:                   multisample.Mask,
+			AlphaToCoverageEnabled: multisample.AlphaToCoverageEnabled,
+		},
+	}
+
+	if desc.FragmentShader != 0 {
+		fragShader := b.shaders.get(desc.FragmentShader)
+		if fragShader == nil {
+			return 0, fmt.Errorf("rust backend: invalid shader module")
+		}
+
+		colorTargets := desc.ColorTargets()
+		targets := make([]wgpu.ColorTargetState, len(colorTargets))
+		for i, target := range colorTargets {
+			targets[i] = wgpu.ColorTargetState{
+				Format:    wgpu.TextureFormat(target.Format),
+				Blend:     convertBlendState(target.Blend),
+				WriteMask: wgpu.ColorWriteMask(target.WriteMask),
+			}
+		}
+
+		wgpuDesc.Fragment = &wgpu.FragmentState{
+			Module:     fragShader,
+			EntryPoint: desc.FragmentEntry,
+			Targets:    targets,
+		}
+	}
+
+	defer b.captureErrors(device)()
+	pipeline := dev.CreateRenderPipeline(wgpuDesc)
 	if pipeline == nil {
 		return 0, fmt.Errorf("rust backend: failed to create pipeline")
 	}
 
 	handle := types.RenderPipeline(b.newHandle())
-	b.pipelines[handle] = pipeline
+	b.pipelines.put(handle, pipeline)
 	return handle, nil
 }
 
 // CreateCommandEncoder creates a command encoder.
 func (b *Backend) CreateCommandEncoder(device types.Device) types.CommandEncoder {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0
 	}
 
 	encoder := dev.CreateCommandEncoder(nil)
 	handle := types.CommandEncoder(b.newHandle())
-	b.encoders[handle] = encoder
+	b.encoders.put(handle, encoder)
+	b.encoderDevices.put(handle, device)
 	return handle
 }
 
 // BeginRenderPass begins a render pass.
 func (b *Backend) BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass {
-	enc := b.encoders[encoder]
+	enc := b.encoders.get(encoder)
 	if enc == nil {
 		return 0
 	}
 
 	attachments := make([]wgpu.RenderPassColorAttachment, len(desc.ColorAttachments))
 	for i, att := range desc.ColorAttachments {
-		view := b.views[att.View]
+		view := b.views.get(att.View)
 		attachments[i] = wgpu.RenderPassColorAttachment{
-			View:       view,
-			LoadOp:     wgpu.LoadOp(att.LoadOp),
-			StoreOp:    wgpu.StoreOp(att.StoreOp),
-			ClearValue: wgpu.Color{R: att.ClearValue.R, G: att.ClearValue.G, B: att.ClearValue.B, A: att.ClearValue.A},
+			View:          view,
+			ResolveTarget: b.views.get(att.ResolveTarget), // nil unless multisampled
+			LoadOp:        wgpu.LoadOp(att.LoadOp),
+			StoreOp:       wgpu.StoreOp(att.StoreOp),
+			ClearValue:    wgpu.Color{R: att.ClearValue.R, G: att.ClearValue.G, B: att.ClearValue.B, A: att.ClearValue.A},
 		}
 	}
 
+	// CreateQuerySet makes no occlusion query sets, see there
+	device := b.encoderDevices.get(encoder)
+	if desc.OcclusionQuerySet != 0 {
+		b.reportUnsupported(device, "occlusion queries")
+	}
 	pass := enc.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label:            desc.Label,
 		ColorAttachments: attachments,
 	})
 
 	handle := types.RenderPass(b.newHandle())
-	b.passes[handle] = pass
+	b.passes.put(handle, pass)
+	b.passDevices.put(handle, device)
 	return handle
 }
 
 // EndRenderPass ends a render pass.
 func (b *Backend) EndRenderPass(pass types.RenderPass) {
-	p := b.passes[pass]
+	p := b.passes.get(pass)
 	if p != nil {
 		p.End()
 	}
@@ -338,30 +709,197 @@ func (b *Backend) EndRenderPass(pass types.RenderPass) {
 
 // FinishEncoder finishes the command encoder.
 func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffer {
-	enc := b.encoders[encoder]
+	enc := b.encoders.get(encoder)
 	if enc == nil {
 		return 0
 	}
 
+	defer b.captureErrors(b.encoderDevices.get(encoder))()
 	buffer := enc.Finish(nil)
 	handle := types.CommandBuffer(b.newHandle())
-	b.cmdBuffers[handle] = buffer
+	b.cmdBuffers.put(handle, buffer)
 	return handle
 }
 
 // Submit submits commands to the queue.
-func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) {
-	q := b.queues[queue]
-	buf := b.cmdBuffers[commands]
-	if q != nil && buf != nil {
-		q.Submit(buf)
+func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
+	q := b.queues.get(queue)
+	buf := b.cmdBuffers.get(commands)
+	if q == nil || buf == nil {
+		return 0
+	}
+	defer b.captureErrors(b.queueDevices.get(queue))()
+	q.Submit(buf)
+	return types.SubmissionIndex(b.submissions.get(queue).Add(1))
+}
+
+// CopyBufferToBuffer records a copy between buffers.
+func (b *Backend) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+	enc := b.encoders.get(encoder)
+	srcBuf := b.gpuBuffers.get(src)
+	dstBuf := b.gpuBuffers.get(dst)
+	if enc == nil || srcBuf == nil || dstBuf == nil {
+		return
+	}
+
+	enc.CopyBufferToBuffer(srcBuf, srcOffset, dstBuf, dstOffset, size)
+}
+
+// CopyTextureToBuffer records a copy from a texture into a buffer.
+func (b *Backend) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+	enc := b.encoders.get(encoder)
+	tex := b.textures.get(src.Texture)
+	buf := b.gpuBuffers.get(dst.Buffer)
+	if enc == nil || tex == nil || buf == nil {
+		return
+	}
+
+	enc.CopyTextureToBuffer(texelCopyTexture(tex, src), texelCopyBuffer(buf, dst), extent(size))
+}
+
+// CopyBufferToTexture records a copy from a buffer into a texture.
+func (b *Backend) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	enc := b.encoders.get(encoder)
+	buf := b.gpuBuffers.get(src.Buffer)
+	tex := b.textures.get(dst.Texture)
+	if enc == nil || buf == nil || tex == nil {
+		return
+	}
+
+	enc.CopyBufferToTexture(texelCopyBuffer(buf, src), texelCopyTexture(tex, dst), extent(size))
+}
+
+// CopyTextureToTexture records a copy between textures.
+func (b *Backend) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	enc := b.encoders.get(encoder)
+	srcTex := b.textures.get(src.Texture)
+	dstTex := b.textures.get(dst.Texture)
+	if enc == nil || srcTex == nil || dstTex == nil {
+		return
+	}
+
+	enc.CopyTextureToTexture(texelCopyTexture(srcTex, src), texelCopyTexture(dstTex, dst), extent(size))
+}
+
+// ClearBuffer records filling a range of a buffer with zeros.
+func (b *Backend) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+	enc := b.encoders.get(encoder)
+	buf := b.gpuBuffers.get(buffer)
+	if enc == nil || buf == nil {
+		return
+	}
+
+	enc.ClearBuffer(buf, offset, size)
+}
+
+// texelCopyTexture converts an ImageCopyTexture of tex.
+func texelCopyTexture(tex *wgpu.Texture, c *types.ImageCopyTexture) *wgpu.TexelCopyTextureInfo {
+	return &wgpu.TexelCopyTextureInfo{
+		Texture:  tex.Handle(),
+		MipLevel: c.MipLevel,
+		Origin: wgpu.Origin3D{
+			X: c.Origin.X,
+			Y: c.Origin.Y,
+			Z: c.Origin.Z,
+		},
+		Aspect: convertTextureAspect(c.Aspect),
+	}
+}
+
+// texelCopyBuffer converts an ImageCopyBuffer of buf.
+func texelCopyBuffer(buf *wgpu.Buffer, c *types.ImageCopyBuffer) *wgpu.TexelCopyBufferInfo {
+	return &wgpu.TexelCopyBufferInfo{
+		Layout: wgpu.TexelCopyBufferLayout{
+			Offset:       c.Layout.Offset,
+			BytesPerRow:  c.Layout.BytesPerRow,
+			RowsPerImage: c.Layout.RowsPerImage,
+		},
+		Buffer: buf.Handle(),
+	}
+}
+
+// extent converts an Extent3D.
+func extent(size *types.Extent3D) *wgpu.Extent3D {
+	return &wgpu.Extent3D{
+		Width:              size.Width,
+		Height:             size.Height,
+		DepthOrArrayLayers: size.DepthOrArrayLayers,
+	}
+}
+
+// Poll processes completed GPU work and runs pending callbacks.
+func (b *Backend) Poll(device types.Device, wait bool) bool {
+	dev := b.devices.get(device)
+	if dev == nil {
+		return true
+	}
+
+	// Callbacks registered after this point may be for work the poll
+	// has not seen
+	callbacks := b.workDone.get(device)
+	n := callbacks.len()
+	empty := dev.Poll(wait)
+	if wait || empty {
+		callbacks.run(n)
+	}
+	return empty
+}
+
+// OnSubmittedWorkDone calls callback once all work submitted to the
+// queue so far has completed. wgpu-native reports no completion of
+// single submissions, so the callback runs during the first Poll that
+// waits, or that finds the queue empty.
+func (b *Backend) OnSubmittedWorkDone(queue types.Queue, callback func()) {
+	callbacks := b.workDone.get(b.queueDevices.get(queue))
+	if callbacks == nil {
+		return
+	}
+	callbacks.add(callback)
+}
+
+// workDoneCallbacks holds the work-done callbacks of a device in the
+// order they were registered.
+type workDoneCallbacks struct {
+	mu        sync.Mutex
+	callbacks []func()
+}
+
+// add registers a callback.
+func (w *workDoneCallbacks) add(callback func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, callback)
+}
+
+// len returns the number of registered callbacks.
+func (w *workDoneCallbacks) len() int {
+	if w == nil {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.callbacks)
+}
+
+// run removes the first n callbacks and calls them.
+func (w *workDoneCallbacks) run(n int) {
+	if n == 0 {
+		return
+	}
+	w.mu.Lock()
+	done := w.callbacks[:n:n]
+	w.callbacks = w.callbacks[n:]
+	w.mu.Unlock()
+
+	for _, callback := range done {
+		callback()
 	}
 }
 
 // SetPipeline sets the render pipeline.
 func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline) {
-	p := b.passes[pass]
-	pipe := b.pipelines[pipeline]
+	p := b.passes.get(pass)
+	pipe := b.pipelines.get(pipeline)
 	if p != nil && pipe != nil {
 		p.SetPipeline(pipe)
 	}
@@ -369,21 +907,78 @@ func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeli
 
 // Draw issues a draw call.
 func (b *Backend) Draw(pass types.RenderPass, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
-	p := b.passes[pass]
+	p := b.passes.get(pass)
 	if p != nil {
 		p.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
 	}
 }
 
+// SetPushConstants reports a validation error: go-webgpu v0.1.3 has no
+// push constants, so devices never get FeaturePushConstants and the
+// renderer falls back to a dynamic uniform buffer.
+func (b *Backend) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+	b.reportUnsupported(b.passDevices.get(pass), "push constants")
+}
+
+// DrawIndirect issues a draw call with arguments read from a buffer.
+func (b *Backend) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	p := b.passes.get(pass)
+	buf := b.gpuBuffers.get(buffer)
+	if p != nil && buf != nil {
+		p.DrawIndirect(buf, offset)
+	}
+}
+
+// DrawIndexedIndirect issues an indexed draw call with arguments read from a buffer.
+func (b *Backend) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	p := b.passes.get(pass)
+	buf := b.gpuBuffers.get(buffer)
+	if p != nil && buf != nil {
+		p.DrawIndexedIndirect(buf, offset)
+	}
+}
+
+// SetViewport sets the viewport transform of a render pass.
+func (b *Backend) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.SetViewport(x, y, width, height, minDepth, maxDepth)
+	}
+}
+
+// SetScissorRect restricts rendering to a rectangle of the attachments.
+func (b *Backend) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.SetScissorRect(x, y, width, height)
+	}
+}
+
+// SetBlendConstant sets the color used by BlendFactorConstant.
+func (b *Backend) SetBlendConstant(pass types.RenderPass, color *types.Color) {
+	p := b.passes.get(pass)
+	if p != nil && color != nil {
+		p.SetBlendConstant(&wgpu.Color{R: color.R, G: color.G, B: color.B, A: color.A})
+	}
+}
+
+// SetStencilReference sets the reference value used by stencil tests.
+func (b *Backend) SetStencilReference(pass types.RenderPass, reference uint32) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.SetStencilReference(reference)
+	}
+}
+
 // CreateTexture creates a texture.
 func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescriptor) (types.Texture, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
 	wgpuDesc := &wgpu.TextureDescriptor{
-		Label: wgpu.EmptyStringView(),
+		Label: toStringView(desc.Label),
 		Size: wgpu.Extent3D{
 			Width:              desc.Size.Width,
 			Height:             desc.Size.Height,
@@ -396,33 +991,71 @@ func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescript
 		Usage:         wgpu.TextureUsage(desc.Usage),
 	}
 
+	// go-webgpu v0.1.3 takes view formats as a count and a pointer
+	viewFormats := make([]wgpu.TextureFormat, len(desc.ViewFormats))
+	for i, format := range desc.ViewFormats {
+		viewFormats[i] = wgpu.TextureFormat(format)
+	}
+	if len(viewFormats) > 0 {
+		wgpuDesc.ViewFormatCount = uintptr(len(viewFormats))
+		wgpuDesc.ViewFormats = uintptr(unsafe.Pointer(&viewFormats[0]))
+	}
+
+	defer b.captureErrors(device)()
 	texture := dev.CreateTexture(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
+	runtime.KeepAlive(viewFormats)
 	if texture == nil {
 		return 0, fmt.Errorf("rust backend: failed to create texture")
 	}
 
 	handle := types.Texture(b.newHandle())
-	b.textures[handle] = texture
+	b.textures.put(handle, texture)
 	return handle, nil
 }
 
+// SupportedSampleCounts returns the sample counts a texture format supports
+// for multisampled rendering.
+func (b *Backend) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	// WebGPU guarantees 1 and 4 samples for all renderable color formats
+	return []uint32{1, 4}
+}
+
 // CreateTextureView creates a texture view.
 func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
-	tex := b.textures[texture]
+	tex := b.textures.get(texture)
 	if tex == nil {
 		return 0
 	}
 
-	view := tex.CreateView(nil)
+	var wgpuDesc *wgpu.TextureViewDescriptor // nil = whole texture
+	if desc != nil {
+		wgpuDesc = &wgpu.TextureViewDescriptor{
+			Label:           toStringView(desc.Label),
+			Format:          wgpu.TextureFormat(desc.Format), // Undefined = texture format
+			Dimension:       wgpu.TextureViewDimension(desc.Dimension),
+			BaseMipLevel:    desc.BaseMipLevel,
+			MipLevelCount:   countOrUndefined(desc.MipLevelCount),
+			BaseArrayLayer:  desc.BaseArrayLayer,
+			ArrayLayerCount: countOrUndefined(desc.ArrayLayerCount),
+			Aspect:          convertTextureAspect(desc.Aspect),
+		}
+	}
+
+	view := tex.CreateView(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
+	if view == nil {
+		return 0
+	}
 	handle := types.TextureView(b.newHandle())
-	b.views[handle] = view
+	b.views.put(handle, view)
 	return handle
 }
 
 // WriteTexture writes data to a texture.
 func (b *Backend) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, data []byte, layout *types.ImageDataLayout, size *types.Extent3D) {
-	q := b.queues[queue]
-	tex := b.textures[dst.Texture]
+	q := b.queues.get(queue)
+	tex := b.textures.get(dst.Texture)
 	if q == nil || tex == nil {
 		return
 	}
@@ -435,7 +1068,7 @@ func (b *Backend) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, d
 			Y: dst.Origin.Y,
 			Z: dst.Origin.Z,
 		},
-		Aspect: wgpu.TextureAspect(dst.Aspect),
+		Aspect: convertTextureAspect(dst.Aspect),
 	}
 
 	wgpuLayout := &wgpu.TexelCopyBufferLayout{
@@ -450,18 +1083,27 @@ func (b *Backend) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, d
 		DepthOrArrayLayers: size.DepthOrArrayLayers,
 	}
 
+	defer b.captureErrors(b.queueDevices.get(queue))()
 	q.WriteTexture(wgpuDst, data, wgpuLayout, wgpuSize)
 }
 
 // CreateSampler creates a sampler.
 func (b *Backend) CreateSampler(device types.Device, desc *types.SamplerDescriptor) (types.Sampler, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
+	// wgpu-native has no border color, so it never reports
+	// FeatureAddressModeClampToBorder
+	normalized, err := gpu.NormalizeSampler(desc, b.GetDeviceFeatures(device))
+	if err != nil {
+		return 0, err
+	}
+	desc = &normalized
+
 	wgpuDesc := &wgpu.SamplerDescriptor{
-		Label:         wgpu.EmptyStringView(),
+		Label:         toStringView(desc.Label),
 		AddressModeU:  wgpu.AddressMode(desc.AddressModeU),
 		AddressModeV:  wgpu.AddressMode(desc.AddressModeV),
 		AddressModeW:  wgpu.AddressMode(desc.AddressModeW),
@@ -474,19 +1116,21 @@ func (b *Backend) CreateSampler(device types.Device, desc *types.SamplerDescript
 		MaxAnisotropy: desc.MaxAnisotropy,
 	}
 
+	defer b.captureErrors(device)()
 	sampler := dev.CreateSampler(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
 	if sampler == nil {
 		return 0, fmt.Errorf("rust backend: failed to create sampler")
 	}
 
 	handle := types.Sampler(b.newHandle())
-	b.samplers[handle] = sampler
+	b.samplers.put(handle, sampler)
 	return handle, nil
 }
 
 // CreateBuffer creates a buffer.
 func (b *Backend) CreateBuffer(device types.Device, desc *types.BufferDescriptor) (types.Buffer, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
@@ -499,36 +1143,80 @@ func (b *Backend) CreateBuffer(device types.Device, desc *types.BufferDescriptor
 	}
 
 	wgpuDesc := &wgpu.BufferDescriptor{
-		Label:            wgpu.EmptyStringView(),
+		Label:            toStringView(desc.Label),
 		Size:             desc.Size,
 		Usage:            wgpu.BufferUsage(desc.Usage),
 		MappedAtCreation: mappedAtCreation,
 	}
 
+	defer b.captureErrors(device)()
 	buffer := dev.CreateBuffer(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
 	if buffer == nil {
 		return 0, fmt.Errorf("rust backend: failed to create buffer")
 	}
 
 	handle := types.Buffer(b.newHandle())
-	b.gpuBuffers[handle] = buffer
+	b.gpuBuffers.put(handle, buffer)
 	return handle, nil
 }
 
 // WriteBuffer writes data to a buffer.
 func (b *Backend) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uint64, data []byte) {
-	q := b.queues[queue]
-	buf := b.gpuBuffers[buffer]
+	q := b.queues.get(queue)
+	buf := b.gpuBuffers.get(buffer)
 	if q == nil || buf == nil {
 		return
 	}
 
+	defer b.captureErrors(b.queueDevices.get(queue))()
 	q.WriteBuffer(buf, offset, data)
 }
 
+// MapBufferAsync maps a buffer range for CPU access.
+// wgpu-native completes the mapping while polling the device, which
+// MapAsync does before returning, so the callback runs immediately.
+func (b *Backend) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	dev := b.devices.get(device)
+	buf := b.gpuBuffers.get(buffer)
+	if dev == nil || buf == nil {
+		callback(fmt.Errorf("rust backend: invalid buffer"))
+		return
+	}
+
+	if err := buf.MapAsync(dev, wgpu.MapMode(mode), offset, size); err != nil {
+		callback(fmt.Errorf("rust backend: map buffer: %w", err))
+		return
+	}
+	callback(nil)
+}
+
+// GetMappedRange returns the mapped memory of a buffer. The slice is only
+// valid until the buffer is unmapped.
+func (b *Backend) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	buf := b.gpuBuffers.get(buffer)
+	if buf == nil {
+		return nil
+	}
+
+	ptr := buf.GetMappedRange(offset, size)
+	if ptr == nil {
+		return nil
+	}
+	return unsafe.Slice((*byte)(ptr), size)
+}
+
+// UnmapBuffer unmaps a buffer.
+func (b *Backend) UnmapBuffer(buffer types.Buffer) {
+	buf := b.gpuBuffers.get(buffer)
+	if buf != nil {
+		buf.Unmap()
+	}
+}
+
 // CreateBindGroupLayout creates a bind group layout.
 func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
@@ -574,27 +1262,36 @@ func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGro
 			}
 		}
 
+		if entry.StorageTexture != nil {
+			wgpuEntry.StorageTexture = wgpu.StorageTextureBindingLayout{
+				Access:        wgpu.StorageTextureAccess(entry.StorageTexture.Access),
+				Format:        wgpu.TextureFormat(entry.StorageTexture.Format),
+				ViewDimension: wgpu.TextureViewDimension(entry.StorageTexture.ViewDimension),
+			}
+		}
+
 		entries[i] = wgpuEntry
 	}
 
+	defer b.captureErrors(device)()
 	layout := dev.CreateBindGroupLayoutSimple(entries)
 	if layout == nil {
 		return 0, fmt.Errorf("rust backend: failed to create bind group layout")
 	}
 
 	handle := types.BindGroupLayout(b.newHandle())
-	b.bindGroupLayouts[handle] = layout
+	b.bindGroupLayouts.put(handle, layout)
 	return handle, nil
 }
 
 // CreateBindGroup creates a bind group.
 func (b *Backend) CreateBindGroup(device types.Device, desc *types.BindGroupDescriptor) (types.BindGroup, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
-	layout := b.bindGroupLayouts[desc.Layout]
+	layout := b.bindGroupLayouts.get(desc.Layout)
 	if layout == nil {
 		return 0, fmt.Errorf("rust backend: invalid bind group layout")
 	}
@@ -606,7 +1303,7 @@ func (b *Backend) CreateBindGroup(device types.Device, desc *types.BindGroupDesc
 		}
 
 		if entry.Buffer != 0 {
-			buf := b.gpuBuffers[entry.Buffer]
+			buf := b.gpuBuffers.get(entry.Buffer)
 			if buf != nil {
 				wgpuEntry.Buffer = buf.Handle()
 				wgpuEntry.Offset = entry.Offset
@@ -615,14 +1312,14 @@ func (b *Backend) CreateBindGroup(device types.Device, desc *types.BindGroupDesc
 		}
 
 		if entry.Sampler != 0 {
-			sampler := b.samplers[entry.Sampler]
+			sampler := b.samplers.get(entry.Sampler)
 			if sampler != nil {
 				wgpuEntry.Sampler = sampler.Handle()
 			}
 		}
 
 		if entry.TextureView != 0 {
-			view := b.views[entry.TextureView]
+			view := b.views.get(entry.TextureView)
 			if view != nil {
 				wgpuEntry.TextureView = view.Handle()
 			}
@@ -631,46 +1328,53 @@ func (b *Backend) CreateBindGroup(device types.Device, desc *types.BindGroupDesc
 		entries[i] = wgpuEntry
 	}
 
+	defer b.captureErrors(device)()
 	bindGroup := dev.CreateBindGroupSimple(layout, entries)
 	if bindGroup == nil {
 		return 0, fmt.Errorf("rust backend: failed to create bind group")
 	}
 
 	handle := types.BindGroup(b.newHandle())
-	b.bindGroups[handle] = bindGroup
+	b.bindGroups.put(handle, bindGroup)
 	return handle, nil
 }
 
 // CreatePipelineLayout creates a pipeline layout.
 func (b *Backend) CreatePipelineLayout(device types.Device, desc *types.PipelineLayoutDescriptor) (types.PipelineLayout, error) {
-	dev := b.devices[device]
+	dev := b.devices.get(device)
 	if dev == nil {
 		return 0, fmt.Errorf("rust backend: invalid device")
 	}
 
 	layouts := make([]*wgpu.BindGroupLayout, len(desc.BindGroupLayouts))
 	for i, layoutHandle := range desc.BindGroupLayouts {
-		layout := b.bindGroupLayouts[layoutHandle]
+		layout := b.bindGroupLayouts.get(layoutHandle)
 		if layout == nil {
 			return 0, fmt.Errorf("rust backend: invalid bind group layout at index %d", i)
 		}
 		layouts[i] = layout
 	}
 
+	// Push constants are not supported, see SetPushConstants
+	if len(desc.PushConstantRanges) > 0 {
+		return 0, fmt.Errorf("rust backend: push constant ranges: %w", gpu.ErrNotImplemented)
+	}
+
+	defer b.captureErrors(device)()
 	pipelineLayout := dev.CreatePipelineLayoutSimple(layouts)
 	if pipelineLayout == nil {
 		return 0, fmt.Errorf("rust backend: failed to create pipeline layout")
 	}
 
 	handle := types.PipelineLayout(b.newHandle())
-	b.pipelineLayouts[handle] = pipelineLayout
+	b.pipelineLayouts.put(handle, pipelineLayout)
 	return handle, nil
 }
 
 // SetBindGroup sets a bind group for rendering.
 func (b *Backend) SetBindGroup(pass types.RenderPass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
-	p := b.passes[pass]
-	bg := b.bindGroups[bindGroup]
+	p := b.passes.get(pass)
+	bg := b.bindGroups.get(bindGroup)
 	if p == nil || bg == nil {
 		return
 	}
@@ -680,8 +1384,8 @@ func (b *Backend) SetBindGroup(pass types.RenderPass, index uint32, bindGroup ty
 
 // SetVertexBuffer sets a vertex buffer for rendering.
 func (b *Backend) SetVertexBuffer(pass types.RenderPass, slot uint32, buffer types.Buffer, offset, size uint64) {
-	p := b.passes[pass]
-	buf := b.gpuBuffers[buffer]
+	p := b.passes.get(pass)
+	buf := b.gpuBuffers.get(buffer)
 	if p == nil || buf == nil {
 		return
 	}
@@ -691,8 +1395,8 @@ func (b *Backend) SetVertexBuffer(pass types.RenderPass, slot uint32, buffer typ
 
 // SetIndexBuffer sets an index buffer for rendering.
 func (b *Backend) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
-	p := b.passes[pass]
-	buf := b.gpuBuffers[buffer]
+	p := b.passes.get(pass)
+	buf := b.gpuBuffers.get(buffer)
 	if p == nil || buf == nil {
 		return
 	}
@@ -702,7 +1406,7 @@ func (b *Backend) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, for
 
 // DrawIndexed issues an indexed draw call.
 func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
-	p := b.passes[pass]
+	p := b.passes.get(pass)
 	if p == nil {
 		return
 	}
@@ -710,93 +1414,499 @@ func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount,
 	p.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
 }
 
+// CreateRenderBundleEncoder creates a render bundle encoder.
+func (b *Backend) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	dev := b.devices.get(device)
+	if dev == nil {
+		return 0, fmt.Errorf("rust backend: invalid device")
+	}
+
+	formats := make([]wgpu.TextureFormat, len(desc.ColorFormats))
+	for i, format := range desc.ColorFormats {
+		formats[i] = wgpu.TextureFormat(format)
+	}
+
+	sampleCount := desc.SampleCount
+	if sampleCount == 0 {
+		sampleCount = 1
+	}
+
+	wgpuDesc := &wgpu.RenderBundleEncoderDescriptor{
+		Label:              toStringView(desc.Label),
+		ColorFormatCount:   uintptr(len(formats)),
+		DepthStencilFormat: wgpu.TextureFormat(desc.DepthStencilFormat),
+		SampleCount:        sampleCount,
+		DepthReadOnly:      toBool(desc.DepthReadOnly),
+		StencilReadOnly:    toBool(desc.StencilReadOnly),
+	}
+	if len(formats) > 0 {
+		wgpuDesc.ColorFormats = &formats[0]
+	}
+
+	defer b.captureErrors(device)()
+	encoder := dev.CreateRenderBundleEncoder(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
+	if encoder == nil {
+		return 0, fmt.Errorf("rust backend: failed to create render bundle encoder")
+	}
+
+	handle := types.RenderBundleEncoder(b.newHandle())
+	b.bundleEncoders.put(handle, encoder)
+	b.bundleEncoderDevices.put(handle, device)
+	return handle, nil
+}
+
+// SetBundlePipeline sets the render pipeline of a render bundle.
+func (b *Backend) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+	enc := b.bundleEncoders.get(encoder)
+	pipe := b.pipelines.get(pipeline)
+	if enc != nil && pipe != nil {
+		enc.SetPipeline(pipe)
+	}
+}
+
+// SetBundleBindGroup sets a bind group of a render bundle.
+func (b *Backend) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	enc := b.bundleEncoders.get(encoder)
+	bg := b.bindGroups.get(bindGroup)
+	if enc == nil || bg == nil {
+		return
+	}
+
+	enc.SetBindGroup(index, bg, dynamicOffsets)
+}
+
+// SetBundleVertexBuffer sets a vertex buffer of a render bundle.
+func (b *Backend) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+	enc := b.bundleEncoders.get(encoder)
+	buf := b.gpuBuffers.get(buffer)
+	if enc == nil || buf == nil {
+		return
+	}
+
+	enc.SetVertexBuffer(slot, buf, offset, size)
+}
+
+// SetBundleIndexBuffer sets the index buffer of a render bundle.
+func (b *Backend) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	enc := b.bundleEncoders.get(encoder)
+	buf := b.gpuBuffers.get(buffer)
+	if enc == nil || buf == nil {
+		return
+	}
+
+	enc.SetIndexBuffer(buf, wgpu.IndexFormat(format), offset, size)
+}
+
+// BundleDraw records a draw call into a render bundle.
+func (b *Backend) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	enc := b.bundleEncoders.get(encoder)
+	if enc != nil {
+		enc.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+	}
+}
+
+// BundleDrawIndexed records an indexed draw call into a render bundle.
+func (b *Backend) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	enc := b.bundleEncoders.get(encoder)
+	if enc != nil {
+		enc.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	}
+}
+
+// SetBundlePushConstants reports a validation error, see
+// SetPushConstants.
+func (b *Backend) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+	b.reportUnsupported(b.bundleEncoderDevices.get(encoder), "push constants")
+}
+
+// BundleDrawIndirect records an indirect draw call into a render bundle.
+func (b *Backend) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	enc := b.bundleEncoders.get(encoder)
+	buf := b.gpuBuffers.get(buffer)
+	if enc != nil && buf != nil {
+		enc.DrawIndirect(buf, offset)
+	}
+}
+
+// BundleDrawIndexedIndirect records an indirect indexed draw call into a render bundle.
+func (b *Backend) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	enc := b.bundleEncoders.get(encoder)
+	buf := b.gpuBuffers.get(buffer)
+	if enc != nil && buf != nil {
+		enc.DrawIndexedIndirect(buf, offset)
+	}
+}
+
+// FinishRenderBundle finishes recording a render bundle.
+func (b *Backend) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	enc := b.bundleEncoders.get(encoder)
+	if enc == nil {
+		return 0
+	}
+
+	defer b.captureErrors(b.bundleEncoderDevices.get(encoder))()
+	bundle := enc.Finish(nil)
+	if bundle == nil {
+		return 0
+	}
+
+	handle := types.RenderBundle(b.newHandle())
+	b.bundles.put(handle, bundle)
+	return handle
+}
+
+// ExecuteBundles executes render bundles in a render pass.
+func (b *Backend) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {
+	p := b.passes.get(pass)
+	if p == nil {
+		return
+	}
+
+	wgpuBundles := make([]*wgpu.RenderBundle, 0, len(bundles))
+	for _, bundle := range bundles {
+		if rb := b.bundles.get(bundle); rb != nil {
+			wgpuBundles = append(wgpuBundles, rb)
+		}
+	}
+	p.ExecuteBundles(wgpuBundles)
+}
+
+// CreateComputePipeline creates a compute pipeline.
+func (b *Backend) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	dev := b.devices.get(device)
+	if dev == nil {
+		return 0, fmt.Errorf("rust backend: invalid device")
+	}
+
+	shader := b.shaders.get(desc.Module)
+	if shader == nil {
+		return 0, fmt.Errorf("rust backend: invalid shader module")
+	}
+
+	var layout *wgpu.PipelineLayout // nil = auto layout
+	if desc.Layout != 0 {
+		layout = b.pipelineLayouts.get(desc.Layout)
+		if layout == nil {
+			return 0, fmt.Errorf("rust backend: invalid pipeline layout")
+		}
+	}
+
+	defer b.captureErrors(device)()
+	pipeline := dev.CreateComputePipelineSimple(layout, shader, desc.EntryPoint)
+	if pipeline == nil {
+		return 0, fmt.Errorf("rust backend: failed to create compute pipeline")
+	}
+
+	handle := types.ComputePipeline(b.newHandle())
+	b.computePipelines.put(handle, pipeline)
+	return handle, nil
+}
+
+// BeginComputePass begins a compute pass.
+func (b *Backend) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	enc := b.encoders.get(encoder)
+	if enc == nil {
+		return 0
+	}
+
+	var wgpuDesc *wgpu.ComputePassDescriptor
+	if desc != nil {
+		wgpuDesc = &wgpu.ComputePassDescriptor{Label: toStringView(desc.Label)}
+	}
+	pass := enc.BeginComputePass(wgpuDesc)
+	runtime.KeepAlive(desc) // label, see toStringView
+
+	handle := types.ComputePass(b.newHandle())
+	b.computePasses.put(handle, pass)
+	return handle
+}
+
+// SetComputePipeline sets the compute pipeline.
+func (b *Backend) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {
+	p := b.computePasses.get(pass)
+	pipe := b.computePipelines.get(pipeline)
+	if p != nil && pipe != nil {
+		p.SetPipeline(pipe)
+	}
+}
+
+// SetComputeBindGroup sets a bind group for a compute pass.
+func (b *Backend) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	p := b.computePasses.get(pass)
+	bg := b.bindGroups.get(bindGroup)
+	if p == nil || bg == nil {
+		return
+	}
+
+	p.SetBindGroup(index, bg, dynamicOffsets)
+}
+
+// DispatchWorkgroups dispatches compute workgroups.
+func (b *Backend) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {
+	p := b.computePasses.get(pass)
+	if p != nil {
+		p.DispatchWorkgroups(x, y, z)
+	}
+}
+
+// DispatchWorkgroupsIndirect dispatches compute workgroups with counts read from a buffer.
+func (b *Backend) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+	p := b.computePasses.get(pass)
+	buf := b.gpuBuffers.get(buffer)
+	if p == nil || buf == nil {
+		return
+	}
+
+	p.DispatchWorkgroupsIndirect(buf, offset)
+}
+
+// EndComputePass ends a compute pass.
+func (b *Backend) EndComputePass(pass types.ComputePass) {
+	p := b.computePasses.get(pass)
+	if p != nil {
+		p.End()
+	}
+}
+
+// PushDebugGroup opens a debug group on a command encoder.
+func (b *Backend) PushDebugGroup(encoder types.CommandEncoder, label string) {
+	enc := b.encoders.get(encoder)
+	if enc != nil {
+		enc.PushDebugGroup(label)
+	}
+}
+
+// PopDebugGroup closes the innermost debug group on a command encoder.
+func (b *Backend) PopDebugGroup(encoder types.CommandEncoder) {
+	enc := b.encoders.get(encoder)
+	if enc != nil {
+		enc.PopDebugGroup()
+	}
+}
+
+// InsertDebugMarker inserts a debug marker on a command encoder.
+func (b *Backend) InsertDebugMarker(encoder types.CommandEncoder, label string) {
+	enc := b.encoders.get(encoder)
+	if enc != nil {
+		enc.InsertDebugMarker(label)
+	}
+}
+
+// PushRenderPassDebugGroup opens a debug group on a render pass.
+func (b *Backend) PushRenderPassDebugGroup(pass types.RenderPass, label string) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.PushDebugGroup(label)
+	}
+}
+
+// PopRenderPassDebugGroup closes the innermost debug group on a render pass.
+func (b *Backend) PopRenderPassDebugGroup(pass types.RenderPass) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.PopDebugGroup()
+	}
+}
+
+// InsertRenderPassDebugMarker inserts a debug marker on a render pass.
+func (b *Backend) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {
+	p := b.passes.get(pass)
+	if p != nil {
+		p.InsertDebugMarker(label)
+	}
+}
+
+// PushComputePassDebugGroup does nothing: go-webgpu v0.1.3 has no debug
+// groups or markers on compute passes.
+func (b *Backend) PushComputePassDebugGroup(pass types.ComputePass, label string) {}
+
+// PopComputePassDebugGroup does nothing, see PushComputePassDebugGroup.
+func (b *Backend) PopComputePassDebugGroup(pass types.ComputePass) {}
+
+// InsertComputePassDebugMarker does nothing, see PushComputePassDebugGroup.
+func (b *Backend) InsertComputePassDebugMarker(pass types.ComputePass, label string) {}
+
+// CreateQuerySet creates a query set.
+func (b *Backend) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	dev := b.devices.get(device)
+	if dev == nil {
+		return 0, fmt.Errorf("rust backend: invalid device")
+	}
+
+	// go-webgpu v0.1.3 can neither attach occlusion query sets to render
+	// passes nor begin and end occlusion queries
+	if desc.Type == types.QueryTypeOcclusion {
+		return 0, fmt.Errorf("rust backend: occlusion queries: %w", gpu.ErrNotImplemented)
+	}
+
+	defer b.captureErrors(device)()
+	querySet := dev.CreateQuerySet(&wgpu.QuerySetDescriptor{
+		Label: desc.Label,
+		Type:  wgpu.QueryType(desc.Type),
+		Count: desc.Count,
+	})
+	if querySet == nil {
+		return 0, fmt.Errorf("rust backend: failed to create query set")
+	}
+
+	handle := types.QuerySet(b.newHandle())
+	b.querySets.put(handle, querySet)
+	return handle, nil
+}
+
+// WriteTimestamp records a timestamp query.
+func (b *Backend) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+	enc := b.encoders.get(encoder)
+	qs := b.querySets.get(querySet)
+	if enc != nil && qs != nil {
+		enc.WriteTimestamp(qs, index)
+	}
+}
+
+// BeginOcclusionQuery reports a validation error wrapping
+// gpu.ErrNotImplemented: occlusion queries are not supported on this
+// backend, see CreateQuerySet.
+func (b *Backend) BeginOcclusionQuery(pass types.RenderPass, index uint32) {
+	b.reportUnsupported(b.passDevices.get(pass), "occlusion queries")
+}
+
+// EndOcclusionQuery reports a validation error, see BeginOcclusionQuery.
+func (b *Backend) EndOcclusionQuery(pass types.RenderPass) {
+	b.reportUnsupported(b.passDevices.get(pass), "occlusion queries")
+}
+
+// ResolveQuerySet copies query results into a buffer as uint64 values.
+func (b *Backend) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+	enc := b.encoders.get(encoder)
+	qs := b.querySets.get(querySet)
+	buf := b.gpuBuffers.get(dst)
+	if enc == nil || qs == nil || buf == nil {
+		return
+	}
+
+	enc.ResolveQuerySet(qs, firstQuery, queryCount, buf, dstOffset)
+}
+
 // ReleaseTextureView releases a texture view.
 func (b *Backend) ReleaseTextureView(view types.TextureView) {
-	v := b.views[view]
-	if v != nil {
+	if v, ok := b.views.take(view); ok {
 		v.Release()
-		delete(b.views, view)
 	}
 }
 
 // ReleaseTexture releases a texture.
 func (b *Backend) ReleaseTexture(texture types.Texture) {
-	t := b.textures[texture]
-	if t != nil {
+	if t, ok := b.textures.take(texture); ok {
 		t.Release()
-		delete(b.textures, texture)
 	}
 }
 
 // ReleaseSampler releases a sampler.
 func (b *Backend) ReleaseSampler(sampler types.Sampler) {
-	s := b.samplers[sampler]
-	if s != nil {
+	if s, ok := b.samplers.take(sampler); ok {
 		s.Release()
-		delete(b.samplers, sampler)
 	}
 }
 
 // ReleaseBuffer releases a buffer.
 func (b *Backend) ReleaseBuffer(buffer types.Buffer) {
-	buf := b.gpuBuffers[buffer]
-	if buf != nil {
+	if buf, ok := b.gpuBuffers.take(buffer); ok {
 		buf.Release()
-		delete(b.gpuBuffers, buffer)
 	}
 }
 
 // ReleaseBindGroupLayout releases a bind group layout.
 func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout) {
-	l := b.bindGroupLayouts[layout]
-	if l != nil {
+	if l, ok := b.bindGroupLayouts.take(layout); ok {
 		l.Release()
-		delete(b.bindGroupLayouts, layout)
 	}
 }
 
 // ReleaseBindGroup releases a bind group.
 func (b *Backend) ReleaseBindGroup(group types.BindGroup) {
-	g := b.bindGroups[group]
-	if g != nil {
+	if g, ok := b.bindGroups.take(group); ok {
 		g.Release()
-		delete(b.bindGroups, group)
 	}
 }
 
 // ReleasePipelineLayout releases a pipeline layout.
 func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout) {
-	l := b.pipelineLayouts[layout]
-	if l != nil {
+	if l, ok := b.pipelineLayouts.take(layout); ok {
 		l.Release()
-		delete(b.pipelineLayouts, layout)
+	}
+}
+
+// ReleaseShaderModule releases a shader module.
+func (b *Backend) ReleaseShaderModule(module types.ShaderModule) {
+	if m, ok := b.shaders.take(module); ok {
+		m.Release()
+	}
+}
+
+// ReleaseRenderPipeline releases a render pipeline.
+func (b *Backend) ReleaseRenderPipeline(pipeline types.RenderPipeline) {
+	if p, ok := b.pipelines.take(pipeline); ok {
+		p.Release()
 	}
 }
 
 // ReleaseCommandBuffer releases a command buffer.
 func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer) {
-	buf := b.cmdBuffers[buffer]
-	if buf != nil {
+	if buf, ok := b.cmdBuffers.take(buffer); ok {
 		buf.Release()
-		delete(b.cmdBuffers, buffer)
 	}
 }
 
 // ReleaseCommandEncoder releases a command encoder.
 func (b *Backend) ReleaseCommandEncoder(encoder types.CommandEncoder) {
-	enc := b.encoders[encoder]
-	if enc != nil {
+	if enc, ok := b.encoders.take(encoder); ok {
 		enc.Release()
-		delete(b.encoders, encoder)
 	}
+	b.encoderDevices.take(encoder)
 }
 
 // ReleaseRenderPass releases a render pass.
 func (b *Backend) ReleaseRenderPass(pass types.RenderPass) {
-	p := b.passes[pass]
-	if p != nil {
+	if p, ok := b.passes.take(pass); ok {
 		p.Release()
-		delete(b.passes, pass)
+	}
+	b.passDevices.take(pass)
+}
+
+// ReleaseComputePipeline releases a compute pipeline.
+func (b *Backend) ReleaseComputePipeline(pipeline types.ComputePipeline) {
+	if p, ok := b.computePipelines.take(pipeline); ok {
+		p.Release()
+	}
+}
+
+// ReleaseComputePass releases a compute pass.
+func (b *Backend) ReleaseComputePass(pass types.ComputePass) {
+	if p, ok := b.computePasses.take(pass); ok {
+		p.Release()
+	}
+}
+
+// ReleaseQuerySet releases a query set.
+func (b *Backend) ReleaseQuerySet(querySet types.QuerySet) {
+	if qs, ok := b.querySets.take(querySet); ok {
+		qs.Release()
+	}
+}
+
+// ReleaseRenderBundleEncoder releases a render bundle encoder.
+func (b *Backend) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {
+	if enc, ok := b.bundleEncoders.take(encoder); ok {
+		enc.Release()
+	}
+	b.bundleEncoderDevices.take(encoder)
+}
+
+// ReleaseRenderBundle releases a render bundle.
+func (b *Backend) ReleaseRenderBundle(bundle types.RenderBundle) {
+	if rb, ok := b.bundles.take(bundle); ok {
+		rb.Release()
 	}
 }
 
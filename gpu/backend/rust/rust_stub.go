@@ -1,7 +1,7 @@
-//go:build !windows
+//go:build !windows && !linux && !darwin
 
 // Package rust provides the WebGPU backend using wgpu-native (Rust).
-// This stub is used on non-Windows platforms where go-webgpu/goffi is not yet supported.
+// This stub is used on platforms go-webgpu/goffi does not support, such as js/wasm.
 package rust
 
 import (
@@ -9,16 +9,16 @@ import (
 	"github.com/gogpu/gogpu/gpu/types"
 )
 
-// Backend is a stub for non-Windows platforms.
+// Backend is a stub for unsupported platforms.
 type Backend struct{}
 
-// New returns nil on non-Windows platforms.
+// New returns nil on unsupported platforms.
 // Use the native backend instead.
 func New() *Backend {
 	return nil
 }
 
-// IsAvailable returns false on non-Windows platforms.
+// IsAvailable returns false on unsupported platforms.
 func IsAvailable() bool {
 	return false
 }
@@ -28,12 +28,12 @@ func (b *Backend) Name() string {
 	return "Rust (not available on this platform)"
 }
 
-// Init returns an error on non-Windows platforms.
+// Init returns an error on unsupported platforms.
 func (b *Backend) Init() error {
 	return gpu.ErrBackendNotAvailable
 }
 
-// Destroy is a no-op on non-Windows platforms.
+// Destroy is a no-op on unsupported platforms.
 func (b *Backend) Destroy() {}
 
 // All other methods return zero values or errors.
@@ -54,6 +54,37 @@ func (b *Backend) GetQueue(device types.Device) types.Queue {
 	return 0
 }
 
+func (b *Backend) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	return types.AdapterInfo{}
+}
+
+func (b *Backend) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	return 0
+}
+
+func (b *Backend) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	return types.Limits{}
+}
+
+func (b *Backend) GetDeviceFeatures(device types.Device) types.Features {
+	return 0
+}
+
+func (b *Backend) GetDeviceLimits(device types.Device) types.Limits {
+	return types.Limits{}
+}
+
+func (b *Backend) PushErrorScope(device types.Device, filter types.ErrorFilter) {}
+
+func (b *Backend) PopErrorScope(device types.Device) error {
+	return gpu.ErrBackendNotAvailable
+}
+
+func (b *Backend) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {}
+
+func (b *Backend) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+}
+
 func (b *Backend) CreateSurface(instance types.Instance, handle types.SurfaceHandle) (types.Surface, error) {
 	return 0, gpu.ErrBackendNotAvailable
 }
@@ -67,10 +98,16 @@ func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture
 
 func (b *Backend) Present(surface types.Surface) {}
 
+func (b *Backend) ReleaseSurface(surface types.Surface) {}
+
 func (b *Backend) CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error) {
 	return 0, gpu.ErrBackendNotAvailable
 }
 
+func (b *Backend) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	return 0, gpu.ErrBackendNotAvailable
+}
+
 func (b *Backend) CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
 	return 0, gpu.ErrBackendNotAvailable
 }
@@ -89,7 +126,30 @@ func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffe
 	return 0
 }
 
-func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) {}
+func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
+	return 0
+}
+
+func (b *Backend) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+}
+
+func (b *Backend) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+}
+
+func (b *Backend) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+}
+
+func (b *Backend) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+}
+
+func (b *Backend) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+}
+
+func (b *Backend) Poll(device types.Device, wait bool) bool {
+	return true
+}
+
+func (b *Backend) OnSubmittedWorkDone(queue types.Queue, callback func()) {}
 
 func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline) {}
 
@@ -100,6 +160,14 @@ func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescript
 	return 0, gpu.ErrBackendNotAvailable
 }
 
+func (b *Backend) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	return types.SurfaceCapabilities{}
+}
+
+func (b *Backend) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	return []uint32{1}
+}
+
 func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
 	return 0
 }
@@ -117,6 +185,16 @@ func (b *Backend) CreateBuffer(device types.Device, desc *types.BufferDescriptor
 
 func (b *Backend) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uint64, data []byte) {}
 
+func (b *Backend) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	callback(gpu.ErrBackendNotAvailable)
+}
+
+func (b *Backend) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	return nil
+}
+
+func (b *Backend) UnmapBuffer(buffer types.Buffer) {}
+
 func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
 	return 0, gpu.ErrBackendNotAvailable
 }
@@ -141,16 +219,128 @@ func (b *Backend) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, for
 func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
 }
 
-func (b *Backend) ReleaseTexture(texture types.Texture)                {}
-func (b *Backend) ReleaseTextureView(view types.TextureView)           {}
-func (b *Backend) ReleaseSampler(sampler types.Sampler)                {}
-func (b *Backend) ReleaseBuffer(buffer types.Buffer)                   {}
-func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout) {}
-func (b *Backend) ReleaseBindGroup(group types.BindGroup)              {}
-func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout)   {}
-func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer)     {}
-func (b *Backend) ReleaseCommandEncoder(encoder types.CommandEncoder)  {}
-func (b *Backend) ReleaseRenderPass(pass types.RenderPass)             {}
+func (b *Backend) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	return 0, gpu.ErrBackendNotAvailable
+}
+
+func (b *Backend) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	return 0
+}
+
+func (b *Backend) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {}
+
+func (b *Backend) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+}
+
+func (b *Backend) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {}
+
+func (b *Backend) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+}
+
+func (b *Backend) EndComputePass(pass types.ComputePass) {}
+
+func (b *Backend) PushDebugGroup(encoder types.CommandEncoder, label string) {}
+
+func (b *Backend) PopDebugGroup(encoder types.CommandEncoder) {}
+
+func (b *Backend) InsertDebugMarker(encoder types.CommandEncoder, label string) {}
+
+func (b *Backend) PushRenderPassDebugGroup(pass types.RenderPass, label string) {}
+
+func (b *Backend) PopRenderPassDebugGroup(pass types.RenderPass) {}
+
+func (b *Backend) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {}
+
+func (b *Backend) PushComputePassDebugGroup(pass types.ComputePass, label string) {}
+
+func (b *Backend) PopComputePassDebugGroup(pass types.ComputePass) {}
+
+func (b *Backend) InsertComputePassDebugMarker(pass types.ComputePass, label string) {}
+
+func (b *Backend) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	return 0, gpu.ErrBackendNotAvailable
+}
+
+func (b *Backend) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+}
+
+func (b *Backend) BeginOcclusionQuery(pass types.RenderPass, index uint32) {}
+
+func (b *Backend) EndOcclusionQuery(pass types.RenderPass) {}
+
+func (b *Backend) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+}
+
+func (b *Backend) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	return 0, gpu.ErrBackendNotAvailable
+}
+
+func (b *Backend) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+}
+
+func (b *Backend) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+}
+
+func (b *Backend) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+}
+
+func (b *Backend) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+}
+
+func (b *Backend) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+}
+
+func (b *Backend) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+}
+
+func (b *Backend) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {}
+
+func (b *Backend) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {}
+
+func (b *Backend) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+}
+
+func (b *Backend) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {}
+
+func (b *Backend) SetBlendConstant(pass types.RenderPass, color *types.Color) {}
+
+func (b *Backend) SetStencilReference(pass types.RenderPass, reference uint32) {}
+
+func (b *Backend) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+}
+
+func (b *Backend) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+}
+
+func (b *Backend) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+}
+
+func (b *Backend) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+}
+
+func (b *Backend) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	return 0
+}
+
+func (b *Backend) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {}
+
+func (b *Backend) ReleaseTexture(texture types.Texture)                         {}
+func (b *Backend) ReleaseTextureView(view types.TextureView)                    {}
+func (b *Backend) ReleaseSampler(sampler types.Sampler)                         {}
+func (b *Backend) ReleaseBuffer(buffer types.Buffer)                            {}
+func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout)          {}
+func (b *Backend) ReleaseBindGroup(group types.BindGroup)                       {}
+func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout)            {}
+func (b *Backend) ReleaseShaderModule(module types.ShaderModule)                {}
+func (b *Backend) ReleaseRenderPipeline(pipeline types.RenderPipeline)          {}
+func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer)              {}
+func (b *Backend) ReleaseCommandEncoder(encoder types.CommandEncoder)           {}
+func (b *Backend) ReleaseRenderPass(pass types.RenderPass)                      {}
+func (b *Backend) ReleaseComputePipeline(pipeline types.ComputePipeline)        {}
+func (b *Backend) ReleaseComputePass(pass types.ComputePass)                    {}
+func (b *Backend) ReleaseQuerySet(querySet types.QuerySet)                      {}
+func (b *Backend) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {}
+func (b *Backend) ReleaseRenderBundle(bundle types.RenderBundle)                {}
 
 // Ensure Backend implements gpu.Backend.
 var _ gpu.Backend = (*Backend)(nil)
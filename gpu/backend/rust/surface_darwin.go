@@ -0,0 +1,15 @@
+//go:build darwin
+
+package rust
+
+import (
+	"github.com/go-webgpu/webgpu/wgpu"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// createSurface creates a surface for the CAMetalLayer the darwin
+// platform attaches to the window's content view, passed as sh.Window.
+func createSurface(inst *wgpu.Instance, sh types.SurfaceHandle) (*wgpu.Surface, error) {
+	return inst.CreateSurfaceFromMetalLayer(sh.Window)
+}
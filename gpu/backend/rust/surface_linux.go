@@ -0,0 +1,24 @@
+//go:build linux
+
+package rust
+
+import (
+	"github.com/go-webgpu/webgpu/wgpu"
+
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/gogpu/internal/xlib"
+)
+
+// createSurface creates a surface for the X11 window of sh through the
+// shared Xlib display, see package xlib. Handles of Wayland windows get
+// no surface, see xlib.ErrWayland.
+func createSurface(inst *wgpu.Instance, sh types.SurfaceHandle) (*wgpu.Surface, error) {
+	if sh.Wayland {
+		return nil, xlib.ErrWayland
+	}
+	display, err := xlib.Display()
+	if err != nil {
+		return nil, err
+	}
+	return inst.CreateSurfaceFromXlibWindow(display, uint64(sh.Window))
+}
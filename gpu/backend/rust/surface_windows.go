@@ -0,0 +1,14 @@
+//go:build windows
+
+package rust
+
+import (
+	"github.com/go-webgpu/webgpu/wgpu"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// createSurface creates a surface for the HINSTANCE and HWND of sh.
+func createSurface(inst *wgpu.Instance, sh types.SurfaceHandle) (*wgpu.Surface, error) {
+	return inst.CreateSurfaceFromWindowsHWND(sh.Instance, sh.Window)
+}
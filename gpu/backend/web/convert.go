@@ -0,0 +1,379 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"syscall/js"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// The browser API takes enums as strings; the tables below map the gogpu
+// values to them. Values missing from a table map to "", which callers
+// leave out of descriptors so the browser applies its default.
+
+var textureFormats = map[types.TextureFormat]string{
+	types.TextureFormatRGBA8Unorm:     "rgba8unorm",
+	types.TextureFormatRGBA8UnormSrgb: "rgba8unorm-srgb",
+	types.TextureFormatBGRA8Unorm:     "bgra8unorm",
+	types.TextureFormatBGRA8UnormSrgb: "bgra8unorm-srgb",
+	types.TextureFormatRGB10A2Unorm:   "rgb10a2unorm",
+	types.TextureFormatRGBA16Float:    "rgba16float",
+
+	types.TextureFormatStencil8:             "stencil8",
+	types.TextureFormatDepth16Unorm:         "depth16unorm",
+	types.TextureFormatDepth24Plus:          "depth24plus",
+	types.TextureFormatDepth24PlusStencil8:  "depth24plus-stencil8",
+	types.TextureFormatDepth32Float:         "depth32float",
+	types.TextureFormatDepth32FloatStencil8: "depth32float-stencil8",
+}
+
+// convertTextureFormat converts a TextureFormat to its GPUTextureFormat.
+func convertTextureFormat(format types.TextureFormat) string {
+	return textureFormats[format]
+}
+
+// formatNames converts TextureFormats to a JS array of GPUTextureFormats.
+func formatNames(formats []types.TextureFormat) []any {
+	names := make([]any, len(formats))
+	for i, f := range formats {
+		names[i] = convertTextureFormat(f)
+	}
+	return names
+}
+
+// convertTextureFormatName converts a GPUTextureFormat to a TextureFormat,
+// or 0 if gogpu has no constant for it.
+func convertTextureFormatName(name string) types.TextureFormat {
+	for format, n := range textureFormats {
+		if n == name {
+			return format
+		}
+	}
+	return 0
+}
+
+var textureDimensions = map[types.TextureDimension]string{
+	types.TextureDimension1D: "1d",
+	types.TextureDimension2D: "2d",
+	types.TextureDimension3D: "3d",
+}
+
+var textureViewDimensions = map[types.TextureViewDimension]string{
+	types.TextureViewDimension1D:        "1d",
+	types.TextureViewDimension2D:        "2d",
+	types.TextureViewDimension2DArray:   "2d-array",
+	types.TextureViewDimensionCube:      "cube",
+	types.TextureViewDimensionCubeArray: "cube-array",
+	types.TextureViewDimension3D:        "3d",
+}
+
+var textureAspects = map[types.TextureAspect]string{
+	types.TextureAspectAll:         "all",
+	types.TextureAspectStencilOnly: "stencil-only",
+	types.TextureAspectDepthOnly:   "depth-only",
+}
+
+var primitiveTopologies = map[types.PrimitiveTopology]string{
+	types.PrimitiveTopologyPointList:     "point-list",
+	types.PrimitiveTopologyLineList:      "line-list",
+	types.PrimitiveTopologyLineStrip:     "line-strip",
+	types.PrimitiveTopologyTriangleList:  "triangle-list",
+	types.PrimitiveTopologyTriangleStrip: "triangle-strip",
+}
+
+var frontFaces = map[types.FrontFace]string{
+	types.FrontFaceCCW: "ccw",
+	types.FrontFaceCW:  "cw",
+}
+
+var cullModes = map[types.CullMode]string{
+	types.CullModeNone:  "none",
+	types.CullModeFront: "front",
+	types.CullModeBack:  "back",
+}
+
+var vertexStepModes = map[types.VertexStepMode]string{
+	types.VertexStepModeVertex:   "vertex",
+	types.VertexStepModeInstance: "instance",
+}
+
+var vertexFormats = map[types.VertexFormat]string{
+	types.VertexFormatUint8x2:   "uint8x2",
+	types.VertexFormatUint8x4:   "uint8x4",
+	types.VertexFormatSint8x2:   "sint8x2",
+	types.VertexFormatSint8x4:   "sint8x4",
+	types.VertexFormatUnorm8x2:  "unorm8x2",
+	types.VertexFormatUnorm8x4:  "unorm8x4",
+	types.VertexFormatSnorm8x2:  "snorm8x2",
+	types.VertexFormatSnorm8x4:  "snorm8x4",
+	types.VertexFormatUint16x2:  "uint16x2",
+	types.VertexFormatUint16x4:  "uint16x4",
+	types.VertexFormatSint16x2:  "sint16x2",
+	types.VertexFormatSint16x4:  "sint16x4",
+	types.VertexFormatUnorm16x2: "unorm16x2",
+	types.VertexFormatUnorm16x4: "unorm16x4",
+	types.VertexFormatSnorm16x2: "snorm16x2",
+	types.VertexFormatSnorm16x4: "snorm16x4",
+	types.VertexFormatFloat16x2: "float16x2",
+	types.VertexFormatFloat16x4: "float16x4",
+	types.VertexFormatFloat32:   "float32",
+	types.VertexFormatFloat32x2: "float32x2",
+	types.VertexFormatFloat32x3: "float32x3",
+	types.VertexFormatFloat32x4: "float32x4",
+	types.VertexFormatUint32:    "uint32",
+	types.VertexFormatUint32x2:  "uint32x2",
+	types.VertexFormatUint32x3:  "uint32x3",
+	types.VertexFormatUint32x4:  "uint32x4",
+	types.VertexFormatSint32:    "sint32",
+	types.VertexFormatSint32x2:  "sint32x2",
+	types.VertexFormatSint32x3:  "sint32x3",
+	types.VertexFormatSint32x4:  "sint32x4",
+}
+
+var indexFormats = map[types.IndexFormat]string{
+	types.IndexFormatUint16: "uint16",
+	types.IndexFormatUint32: "uint32",
+}
+
+var blendFactors = map[types.BlendFactor]string{
+	types.BlendFactorZero:              "zero",
+	types.BlendFactorOne:               "one",
+	types.BlendFactorSrc:               "src",
+	types.BlendFactorOneMinusSrc:       "one-minus-src",
+	types.BlendFactorSrcAlpha:          "src-alpha",
+	types.BlendFactorOneMinusSrcAlpha:  "one-minus-src-alpha",
+	types.BlendFactorDst:               "dst",
+	types.BlendFactorOneMinusDst:       "one-minus-dst",
+	types.BlendFactorDstAlpha:          "dst-alpha",
+	types.BlendFactorOneMinusDstAlpha:  "one-minus-dst-alpha",
+	types.BlendFactorSrcAlphaSaturated: "src-alpha-saturated",
+	types.BlendFactorConstant:          "constant",
+	types.BlendFactorOneMinusConstant:  "one-minus-constant",
+}
+
+var blendOperations = map[types.BlendOperation]string{
+	types.BlendOperationAdd:             "add",
+	types.BlendOperationSubtract:        "subtract",
+	types.BlendOperationReverseSubtract: "reverse-subtract",
+	types.BlendOperationMin:             "min",
+	types.BlendOperationMax:             "max",
+}
+
+var loadOps = map[types.LoadOp]string{
+	types.LoadOpClear: "clear",
+	types.LoadOpLoad:  "load",
+}
+
+var storeOps = map[types.StoreOp]string{
+	types.StoreOpStore:   "store",
+	types.StoreOpDiscard: "discard",
+}
+
+var addressModes = map[types.AddressMode]string{
+	types.AddressModeClampToEdge:  "clamp-to-edge",
+	types.AddressModeRepeat:       "repeat",
+	types.AddressModeMirrorRepeat: "mirror-repeat",
+}
+
+var filterModes = map[types.FilterMode]string{
+	types.FilterModeNearest: "nearest",
+	types.FilterModeLinear:  "linear",
+}
+
+var mipmapFilterModes = map[types.MipmapFilterMode]string{
+	types.MipmapFilterModeNearest: "nearest",
+	types.MipmapFilterModeLinear:  "linear",
+}
+
+var compareFunctions = map[types.CompareFunction]string{
+	types.CompareFunctionNever:        "never",
+	types.CompareFunctionLess:         "less",
+	types.CompareFunctionEqual:        "equal",
+	types.CompareFunctionLessEqual:    "less-equal",
+	types.CompareFunctionGreater:      "greater",
+	types.CompareFunctionNotEqual:     "not-equal",
+	types.CompareFunctionGreaterEqual: "greater-equal",
+	types.CompareFunctionAlways:       "always",
+}
+
+var bufferBindingTypes = map[types.BufferBindingType]string{
+	types.BufferBindingTypeUniform:         "uniform",
+	types.BufferBindingTypeStorage:         "storage",
+	types.BufferBindingTypeReadOnlyStorage: "read-only-storage",
+}
+
+var samplerBindingTypes = map[types.SamplerBindingType]string{
+	types.SamplerBindingTypeFiltering:    "filtering",
+	types.SamplerBindingTypeNonFiltering: "non-filtering",
+	types.SamplerBindingTypeComparison:   "comparison",
+}
+
+var textureSampleTypes = map[types.TextureSampleType]string{
+	types.TextureSampleTypeFloat:             "float",
+	types.TextureSampleTypeUnfilterableFloat: "unfilterable-float",
+	types.TextureSampleTypeDepth:             "depth",
+	types.TextureSampleTypeSint:              "sint",
+	types.TextureSampleTypeUint:              "uint",
+}
+
+var storageTextureAccesses = map[types.StorageTextureAccess]string{
+	types.StorageTextureAccessWriteOnly: "write-only",
+	types.StorageTextureAccessReadOnly:  "read-only",
+	types.StorageTextureAccessReadWrite: "read-write",
+}
+
+var queryTypes = map[types.QueryType]string{
+	types.QueryTypeOcclusion: "occlusion",
+	types.QueryTypeTimestamp: "timestamp",
+}
+
+var alphaModes = map[types.AlphaMode]string{
+	types.AlphaModeOpaque:        "opaque",
+	types.AlphaModePremultiplied: "premultiplied",
+}
+
+var powerPreferences = map[types.PowerPreference]string{
+	types.PowerPreferenceLowPower:        "low-power",
+	types.PowerPreferenceHighPerformance: "high-performance",
+}
+
+// featureNames maps gogpu features to GPUFeatureName. Push constants are
+// a native extension browsers do not offer.
+var featureNames = []struct {
+	feature types.Features
+	name    string
+}{
+	{types.FeatureDepthClipControl, "depth-clip-control"},
+	{types.FeatureDepth32FloatStencil8, "depth32float-stencil8"},
+	{types.FeatureTimestampQuery, "timestamp-query"},
+	{types.FeatureTextureCompressionBC, "texture-compression-bc"},
+	{types.FeatureTextureCompressionETC2, "texture-compression-etc2"},
+	{types.FeatureTextureCompressionASTC, "texture-compression-astc"},
+	{types.FeatureIndirectFirstInstance, "indirect-first-instance"},
+	{types.FeatureShaderF16, "shader-f16"},
+	{types.FeatureRG11B10UfloatRenderable, "rg11b10ufloat-renderable"},
+	{types.FeatureBGRA8UnormStorage, "bgra8unorm-storage"},
+	{types.FeatureFloat32Filterable, "float32-filterable"},
+}
+
+// convertFeatureSet converts a GPUSupportedFeatures set.
+func convertFeatureSet(set js.Value) types.Features {
+	var result types.Features
+	for _, f := range featureNames {
+		if set.Call("has", f.name).Bool() {
+			result |= f.feature
+		}
+	}
+	return result
+}
+
+// convertFeatures converts features to a list of GPUFeatureName.
+func convertFeatures(features types.Features) []any {
+	names := []any{}
+	for _, f := range featureNames {
+		if features.Has(f.feature) {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// limitNames maps the Limits fields to their GPUSupportedLimits names.
+var limitNames = []struct {
+	name  string
+	field func(l *types.Limits) *uint32
+}{
+	{"maxTextureDimension1D", func(l *types.Limits) *uint32 { return &l.MaxTextureDimension1D }},
+	{"maxTextureDimension2D", func(l *types.Limits) *uint32 { return &l.MaxTextureDimension2D }},
+	{"maxTextureDimension3D", func(l *types.Limits) *uint32 { return &l.MaxTextureDimension3D }},
+	{"maxTextureArrayLayers", func(l *types.Limits) *uint32 { return &l.MaxTextureArrayLayers }},
+	{"maxBindGroups", func(l *types.Limits) *uint32 { return &l.MaxBindGroups }},
+	{"maxBindingsPerBindGroup", func(l *types.Limits) *uint32 { return &l.MaxBindingsPerBindGroup }},
+	{"maxDynamicUniformBuffersPerPipelineLayout", func(l *types.Limits) *uint32 { return &l.MaxDynamicUniformBuffersPerPipelineLayout }},
+	{"maxDynamicStorageBuffersPerPipelineLayout", func(l *types.Limits) *uint32 { return &l.MaxDynamicStorageBuffersPerPipelineLayout }},
+	{"maxSampledTexturesPerShaderStage", func(l *types.Limits) *uint32 { return &l.MaxSampledTexturesPerShaderStage }},
+	{"maxSamplersPerShaderStage", func(l *types.Limits) *uint32 { return &l.MaxSamplersPerShaderStage }},
+	{"maxStorageBuffersPerShaderStage", func(l *types.Limits) *uint32 { return &l.MaxStorageBuffersPerShaderStage }},
+	{"maxStorageTexturesPerShaderStage", func(l *types.Limits) *uint32 { return &l.MaxStorageTexturesPerShaderStage }},
+	{"maxUniformBuffersPerShaderStage", func(l *types.Limits) *uint32 { return &l.MaxUniformBuffersPerShaderStage }},
+	{"minUniformBufferOffsetAlignment", func(l *types.Limits) *uint32 { return &l.MinUniformBufferOffsetAlignment }},
+	{"minStorageBufferOffsetAlignment", func(l *types.Limits) *uint32 { return &l.MinStorageBufferOffsetAlignment }},
+	{"maxVertexBuffers", func(l *types.Limits) *uint32 { return &l.MaxVertexBuffers }},
+	{"maxVertexAttributes", func(l *types.Limits) *uint32 { return &l.MaxVertexAttributes }},
+	{"maxVertexBufferArrayStride", func(l *types.Limits) *uint32 { return &l.MaxVertexBufferArrayStride }},
+	{"maxColorAttachments", func(l *types.Limits) *uint32 { return &l.MaxColorAttachments }},
+	{"maxComputeWorkgroupStorageSize", func(l *types.Limits) *uint32 { return &l.MaxComputeWorkgroupStorageSize }},
+	{"maxComputeInvocationsPerWorkgroup", func(l *types.Limits) *uint32 { return &l.MaxComputeInvocationsPerWorkgroup }},
+	{"maxComputeWorkgroupSizeX", func(l *types.Limits) *uint32 { return &l.MaxComputeWorkgroupSizeX }},
+	{"maxComputeWorkgroupSizeY", func(l *types.Limits) *uint32 { return &l.MaxComputeWorkgroupSizeY }},
+	{"maxComputeWorkgroupSizeZ", func(l *types.Limits) *uint32 { return &l.MaxComputeWorkgroupSizeZ }},
+	{"maxComputeWorkgroupsPerDimension", func(l *types.Limits) *uint32 { return &l.MaxComputeWorkgroupsPerDimension }},
+}
+
+// limitNames64 are the 64-bit Limits fields.
+var limitNames64 = []struct {
+	name  string
+	field func(l *types.Limits) *uint64
+}{
+	{"maxUniformBufferBindingSize", func(l *types.Limits) *uint64 { return &l.MaxUniformBufferBindingSize }},
+	{"maxStorageBufferBindingSize", func(l *types.Limits) *uint64 { return &l.MaxStorageBufferBindingSize }},
+	{"maxBufferSize", func(l *types.Limits) *uint64 { return &l.MaxBufferSize }},
+}
+
+// convertLimits converts a GPUSupportedLimits object.
+func convertLimits(limits js.Value) types.Limits {
+	var result types.Limits
+	for _, l := range limitNames {
+		*l.field(&result) = uint32(limits.Get(l.name).Int()) //nolint:gosec // G115: limits fit their WebGPU types
+	}
+	for _, l := range limitNames64 {
+		*l.field(&result) = uint64(limits.Get(l.name).Float())
+	}
+	return result
+}
+
+// convertRequiredLimits converts limits to a record for requestDevice.
+func convertRequiredLimits(limits *types.Limits) map[string]any {
+	result := make(map[string]any, len(limitNames)+len(limitNames64))
+	for _, l := range limitNames {
+		result[l.name] = *l.field(limits)
+	}
+	for _, l := range limitNames64 {
+		result[l.name] = *l.field(limits)
+	}
+	return result
+}
+
+// convertErrorType returns the filter capturing a GPUError, by its class.
+func convertErrorType(err js.Value) types.ErrorFilter {
+	switch err.Get("constructor").Get("name").String() {
+	case "GPUOutOfMemoryError":
+		return types.ErrorFilterOutOfMemory
+	case "GPUInternalError":
+		return types.ErrorFilterInternal
+	default:
+		return types.ErrorFilterValidation
+	}
+}
+
+// setString sets key of obj to value unless value is "".
+func setString(obj map[string]any, key, value string) {
+	if value != "" {
+		obj[key] = value
+	}
+}
+
+// colorObject converts a Color to a GPUColorDict.
+func colorObject(c types.Color) map[string]any {
+	return map[string]any{"r": c.R, "g": c.G, "b": c.B, "a": c.A}
+}
+
+// extentObject converts an Extent3D to a GPUExtent3DDict.
+func extentObject(size *types.Extent3D) map[string]any {
+	return map[string]any{
+		"width":              size.Width,
+		"height":             size.Height,
+		"depthOrArrayLayers": size.DepthOrArrayLayers,
+	}
+}
@@ -0,0 +1,15 @@
+//go:build js && wasm
+
+package web
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+)
+
+func init() {
+	if IsAvailable() {
+		gpu.RegisterBackend("web", func() gpu.Backend {
+			return New()
+		})
+	}
+}
@@ -0,0 +1,1635 @@
+//go:build js && wasm
+
+// Package web provides the WebGPU backend of the browser (navigator.gpu)
+// for programs compiled with GOOS=js GOARCH=wasm.
+//
+// The backend maps gpu.Backend onto the JavaScript WebGPU API through
+// syscall/js. Calls that return promises in JavaScript, such as requesting
+// an adapter or a device, block the calling goroutine until the promise
+// settles. They must not be made from a js.Func callback, which would
+// block the browser event loop the promise needs.
+package web
+
+import (
+	"errors"
+	"fmt"
+
+	"syscall/js"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// Backend implements gpu.Backend using the browser WebGPU API.
+type Backend struct {
+	// JavaScript objects by handle; handles of all types share one space
+	objects map[uintptr]js.Value
+
+	devices map[types.Device]*device
+	queues  map[types.Queue]*device
+	buffers map[types.Buffer]*buffer
+
+	// Textures of canvas contexts, owned by the browser
+	surfaceTextures map[types.Texture]bool
+
+	nextHandle uintptr
+}
+
+// device is the state kept on the Go side for a GPUDevice.
+type device struct {
+	value js.Value
+	queue js.Value
+
+	// Error scopes fed by the uncapturederror event
+	scopes  *gpu.ErrorScopes
+	onError js.Func
+
+	// Index of the last submission to the queue
+	submitted types.SubmissionIndex
+
+	// Mappings and work-done notifications waiting for Poll to run
+	// their callbacks
+	pending []*pendingPromise
+}
+
+// buffer is a GPUBuffer and the ranges of it mapped into Go memory.
+type buffer struct {
+	value  js.Value
+	write  bool // Mapped for writing; ranges are copied back on unmap
+	ranges []mappedRange
+}
+
+// mappedRange is a copy of a range of a mapped buffer.
+type mappedRange struct {
+	array js.Value // Uint8Array over the mapped ArrayBuffer
+	data  []byte
+}
+
+// pendingPromise is a mapAsync or onSubmittedWorkDone call whose
+// callback has not run yet.
+type pendingPromise struct {
+	done     chan error
+	callback func(err error)
+}
+
+// IsAvailable reports whether the browser supports WebGPU.
+func IsAvailable() bool {
+	return navigatorGPU().Truthy()
+}
+
+// New creates a new browser WebGPU backend.
+func New() *Backend {
+	return &Backend{
+		objects:         make(map[uintptr]js.Value),
+		devices:         make(map[types.Device]*device),
+		queues:          make(map[types.Queue]*device),
+		buffers:         make(map[types.Buffer]*buffer),
+		surfaceTextures: make(map[types.Texture]bool),
+		nextHandle:      1,
+	}
+}
+
+// navigatorGPU returns navigator.gpu, or undefined outside of browsers.
+func navigatorGPU() js.Value {
+	navigator := js.Global().Get("navigator")
+	if !navigator.Truthy() {
+		return js.Undefined()
+	}
+	return navigator.Get("gpu")
+}
+
+// add stores a JavaScript object under a new handle.
+func (b *Backend) add(v js.Value) uintptr {
+	h := b.nextHandle
+	b.nextHandle++
+	b.objects[h] = v
+	return h
+}
+
+// get returns the object of a handle, or undefined for unknown handles.
+func (b *Backend) get(h uintptr) js.Value {
+	if v, ok := b.objects[h]; ok {
+		return v
+	}
+	return js.Undefined()
+}
+
+// then calls onResolve or onReject once promise settles.
+func then(promise js.Value, onResolve, onReject func(v js.Value)) {
+	var resolve, reject js.Func
+	settle := func(fn func(v js.Value)) js.Func {
+		return js.FuncOf(func(_ js.Value, args []js.Value) any {
+			resolve.Release()
+			reject.Release()
+			v := js.Undefined()
+			if len(args) > 0 {
+				v = args[0]
+			}
+			fn(v)
+			return nil
+		})
+	}
+	resolve = settle(onResolve)
+	reject = settle(onReject)
+	promise.Call("then", resolve, reject)
+}
+
+// await blocks until promise settles and returns its value.
+func await(promise js.Value) (js.Value, error) {
+	type result struct {
+		value js.Value
+		err   error
+	}
+	ch := make(chan result, 1)
+	then(promise,
+		func(v js.Value) { ch <- result{value: v} },
+		func(v js.Value) { ch <- result{err: jsError(v)} })
+	r := <-ch
+	return r.value, r.err
+}
+
+// jsError converts a rejection reason to an error.
+func jsError(v js.Value) error {
+	if v.Type() == js.TypeObject && v.Get("message").Type() == js.TypeString {
+		return errors.New(v.Get("message").String())
+	}
+	return errors.New(v.String())
+}
+
+// bytesToJS copies data into a new Uint8Array.
+func bytesToJS(data []byte) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	return array
+}
+
+// Name returns the backend identifier.
+func (b *Backend) Name() string {
+	return "WebGPU (browser)"
+}
+
+// Init initializes the backend.
+func (b *Backend) Init() error {
+	if !IsAvailable() {
+		return gpu.ErrBackendNotAvailable
+	}
+	return nil
+}
+
+// Destroy destroys all devices and drops the references to JavaScript
+// objects, leaving the rest to the garbage collector.
+func (b *Backend) Destroy() {
+	for handle, dev := range b.devices {
+		dev.value.Call("removeEventListener", "uncapturederror", dev.onError)
+		dev.onError.Release()
+		dev.value.Call("destroy")
+		delete(b.devices, handle)
+	}
+	clear(b.queues)
+	clear(b.buffers)
+	clear(b.surfaceTextures)
+	clear(b.objects)
+}
+
+// CreateInstance returns a handle to navigator.gpu.
+func (b *Backend) CreateInstance() (types.Instance, error) {
+	gpuObj := navigatorGPU()
+	if !gpuObj.Truthy() {
+		return 0, fmt.Errorf("web backend: WebGPU is not supported by this browser")
+	}
+	return types.Instance(b.add(gpuObj)), nil
+}
+
+// RequestAdapter requests a GPU adapter.
+func (b *Backend) RequestAdapter(instance types.Instance, opts *types.AdapterOptions) (types.Adapter, error) {
+	gpuObj := b.get(uintptr(instance))
+	if gpuObj.IsUndefined() {
+		return 0, fmt.Errorf("web backend: invalid instance")
+	}
+
+	options := map[string]any{}
+	if opts != nil {
+		setString(options, "powerPreference", powerPreferences[opts.PowerPreference])
+	}
+
+	adapter, err := await(gpuObj.Call("requestAdapter", options))
+	if err != nil {
+		return 0, fmt.Errorf("web backend: request adapter: %w", err)
+	}
+	if !adapter.Truthy() {
+		return 0, fmt.Errorf("web backend: no suitable adapter")
+	}
+	return types.Adapter(b.add(adapter)), nil
+}
+
+// RequestDevice requests a GPU device.
+func (b *Backend) RequestDevice(adapter types.Adapter, opts *types.DeviceOptions) (types.Device, error) {
+	adpt := b.get(uintptr(adapter))
+	if adpt.IsUndefined() {
+		return 0, fmt.Errorf("web backend: invalid adapter")
+	}
+
+	desc := map[string]any{}
+	if opts != nil {
+		setString(desc, "label", opts.Label)
+		desc["requiredFeatures"] = convertFeatures(opts.RequiredFeatures)
+		if opts.RequiredLimits != nil {
+			desc["requiredLimits"] = convertRequiredLimits(opts.RequiredLimits)
+		}
+	}
+
+	value, err := await(adpt.Call("requestDevice", desc))
+	if err != nil {
+		return 0, fmt.Errorf("web backend: request device: %w", err)
+	}
+
+	// Browsers report errors asynchronously through the uncapturederror
+	// event; error scopes are kept on this side so all backends behave alike
+	dev := &device{
+		value:  value,
+		queue:  value.Get("queue"),
+		scopes: &gpu.ErrorScopes{},
+	}
+	dev.onError = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		gpuErr := args[0].Get("error")
+		dev.scopes.Report(&types.Error{Type: convertErrorType(gpuErr), Message: gpuErr.Get("message").String()})
+		return nil
+	})
+	value.Call("addEventListener", "uncapturederror", dev.onError)
+
+	handle := types.Device(b.add(value))
+	b.devices[handle] = dev
+	return handle, nil
+}
+
+// GetQueue gets the device queue.
+func (b *Backend) GetQueue(device types.Device) types.Queue {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0
+	}
+	queue := types.Queue(b.add(dev.queue))
+	b.queues[queue] = dev
+	return queue
+}
+
+// PushErrorScope opens an error scope on a device.
+func (b *Backend) PushErrorScope(device types.Device, filter types.ErrorFilter) {
+	if dev := b.devices[device]; dev != nil {
+		dev.scopes.Push(filter)
+	}
+}
+
+// PopErrorScope closes the innermost error scope of a device and returns
+// the first error it captured. Errors arrive asynchronously in the
+// browser, so the scope only holds errors reported before it is popped.
+func (b *Backend) PopErrorScope(device types.Device) error {
+	dev := b.devices[device]
+	if dev == nil {
+		return fmt.Errorf("web backend: invalid device")
+	}
+	return dev.scopes.Pop()
+}
+
+// SetUncapturedErrorCallback sets the callback for device errors no
+// error scope captures.
+func (b *Backend) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {
+	if dev := b.devices[device]; dev != nil {
+		dev.scopes.SetUncapturedCallback(callback)
+	}
+}
+
+// SetDeviceLostCallback sets the function called once when the device
+// is lost. The callback runs on the browser event loop and must not block.
+func (b *Backend) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+	dev := b.devices[device]
+	if dev == nil {
+		return
+	}
+
+	then(dev.value.Get("lost"), func(info js.Value) {
+		reason := types.DeviceLostReasonUnknown
+		if info.Get("reason").String() == "destroyed" {
+			reason = types.DeviceLostReasonDestroyed
+		}
+		callback(reason, info.Get("message").String())
+	}, func(js.Value) {})
+}
+
+// GetAdapterInfo returns information about an adapter.
+func (b *Backend) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	adpt := b.get(uintptr(adapter))
+	if adpt.IsUndefined() {
+		return types.AdapterInfo{}
+	}
+
+	info := adpt.Get("info")
+	if !info.Truthy() {
+		return types.AdapterInfo{Backend: types.GraphicsAPIWebGPU}
+	}
+
+	adapterType := types.AdapterTypeUnknown
+	if adpt.Get("isFallbackAdapter").Truthy() {
+		adapterType = types.AdapterTypeCPU
+	}
+	return types.AdapterInfo{
+		Name:        info.Get("device").String(),
+		Vendor:      info.Get("vendor").String(),
+		Driver:      info.Get("description").String(),
+		Backend:     types.GraphicsAPIWebGPU,
+		AdapterType: adapterType,
+	}
+}
+
+// GetAdapterFeatures returns the features an adapter supports.
+func (b *Backend) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	adpt := b.get(uintptr(adapter))
+	if adpt.IsUndefined() {
+		return 0
+	}
+	return convertFeatureSet(adpt.Get("features"))
+}
+
+// GetAdapterLimits returns the limits of an adapter.
+func (b *Backend) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	adpt := b.get(uintptr(adapter))
+	if adpt.IsUndefined() {
+		return types.Limits{}
+	}
+	return convertLimits(adpt.Get("limits"))
+}
+
+// GetDeviceFeatures returns the features enabled on a device.
+func (b *Backend) GetDeviceFeatures(device types.Device) types.Features {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0
+	}
+	return convertFeatureSet(dev.value.Get("features"))
+}
+
+// GetDeviceLimits returns the limits of a device.
+func (b *Backend) GetDeviceLimits(device types.Device) types.Limits {
+	dev := b.devices[device]
+	if dev == nil {
+		return types.Limits{}
+	}
+	return convertLimits(dev.value.Get("limits"))
+}
+
+// CanvasID returns the element id of the canvas a SurfaceHandle with
+// the given window refers to.
+func CanvasID(window uintptr) string {
+	return fmt.Sprintf("gogpu-canvas-%d", window)
+}
+
+// CreateSurface creates a rendering surface on a canvas. The Window of
+// the handle selects the canvas element by CanvasID.
+func (b *Backend) CreateSurface(instance types.Instance, sh types.SurfaceHandle) (types.Surface, error) {
+	canvas := js.Global().Get("document").Call("getElementById", CanvasID(sh.Window))
+	if !canvas.Truthy() {
+		return 0, fmt.Errorf("web backend: no canvas with id %q", CanvasID(sh.Window))
+	}
+
+	ctx := canvas.Call("getContext", "webgpu")
+	if !ctx.Truthy() {
+		return 0, fmt.Errorf("web backend: canvas has no webgpu context")
+	}
+	return types.Surface(b.add(ctx)), nil
+}
+
+// GetSurfaceCapabilities returns the formats, present modes and alpha
+// modes a canvas supports with an adapter.
+func (b *Backend) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	if b.get(uintptr(surface)).IsUndefined() {
+		return types.SurfaceCapabilities{}
+	}
+
+	// The preferred format first, as it avoids a copy when compositing
+	formats := []types.TextureFormat{convertTextureFormatName(navigatorGPU().Call("getPreferredCanvasFormat").String())}
+	for _, format := range []types.TextureFormat{types.TextureFormatBGRA8Unorm, types.TextureFormatRGBA8Unorm, types.TextureFormatRGBA16Float} {
+		if format != formats[0] {
+			formats = append(formats, format)
+		}
+	}
+
+	return types.SurfaceCapabilities{
+		Formats:      formats,
+		PresentModes: []types.PresentMode{types.PresentModeFifo}, // The browser presents with the display
+		AlphaModes:   []types.AlphaMode{types.AlphaModeOpaque, types.AlphaModePremultiplied},
+	}
+}
+
+// ConfigureSurface configures a canvas context and sizes the canvas.
+// The present mode is ignored; browsers always present in sync with the
+// display.
+func (b *Backend) ConfigureSurface(surface types.Surface, device types.Device, config *types.SurfaceConfig) {
+	ctx := b.get(uintptr(surface))
+	dev := b.devices[device]
+	if ctx.IsUndefined() || dev == nil {
+		return
+	}
+
+	canvas := ctx.Get("canvas")
+	canvas.Set("width", config.Width)
+	canvas.Set("height", config.Height)
+
+	desc := map[string]any{
+		"device": dev.value,
+		"format": convertTextureFormat(config.Format),
+		"usage":  uint32(config.Usage),
+	}
+	setString(desc, "alphaMode", alphaModes[config.AlphaMode])
+	if len(config.ViewFormats) > 0 {
+		desc["viewFormats"] = formatNames(config.ViewFormats)
+	}
+	ctx.Call("configure", desc)
+}
+
+// GetCurrentTexture gets the texture of the canvas for this frame.
+func (b *Backend) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture, error) {
+	ctx := b.get(uintptr(surface))
+	if ctx.IsUndefined() {
+		return types.SurfaceTexture{}, fmt.Errorf("web backend: invalid surface")
+	}
+
+	texture := ctx.Call("getCurrentTexture")
+	handle := types.Texture(b.add(texture))
+	b.surfaceTextures[handle] = true
+	return types.SurfaceTexture{Texture: handle, Status: types.SurfaceStatusSuccess}, nil
+}
+
+// Present is a no-op: the browser presents the canvas texture once
+// control returns to its event loop.
+func (b *Backend) Present(surface types.Surface) {}
+
+// ReleaseSurface unconfigures the canvas context of a surface and drops
+// it. The canvas itself belongs to the page.
+func (b *Backend) ReleaseSurface(surface types.Surface) {
+	ctx := b.get(uintptr(surface))
+	if ctx.IsUndefined() {
+		return
+	}
+	ctx.Call("unconfigure")
+	b.release(uintptr(surface), false)
+}
+
+// CreateShaderModuleWGSL creates a shader module from WGSL code.
+// Compilation errors are reported through the device error scopes.
+func (b *Backend) CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	module := dev.value.Call("createShaderModule", map[string]any{"code": code})
+	return types.ShaderModule(b.add(module)), nil
+}
+
+// CreateShaderModuleSPIRV fails: browsers only accept WGSL.
+func (b *Backend) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	return 0, fmt.Errorf("web backend: SPIR-V shaders are not supported, browsers only accept WGSL")
+}
+
+// CreateRenderPipeline creates a render pipeline.
+func (b *Backend) CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	vertShader := b.get(uintptr(desc.VertexShader))
+	if vertShader.IsUndefined() {
+		return 0, fmt.Errorf("web backend: invalid shader module")
+	}
+
+	var layout any = "auto"
+	if desc.Layout != 0 {
+		layout = b.get(uintptr(desc.Layout))
+		if layout.(js.Value).IsUndefined() {
+			return 0, fmt.Errorf("web backend: invalid pipeline layout")
+		}
+	}
+
+	buffers := make([]any, len(desc.VertexBuffers))
+	for i, vb := range desc.VertexBuffers {
+		attributes := make([]any, len(vb.Attributes))
+		for j, attr := range vb.Attributes {
+			attributes[j] = map[string]any{
+				"format":         vertexFormats[attr.Format],
+				"offset":         attr.Offset,
+				"shaderLocation": attr.ShaderLocation,
+			}
+		}
+		buffers[i] = map[string]any{
+			"arrayStride": vb.ArrayStride,
+			"stepMode":    vertexStepModes[vb.StepMode],
+			"attributes":  attributes,
+		}
+	}
+
+	vertex := map[string]any{"module": vertShader, "buffers": buffers}
+	setString(vertex, "entryPoint", desc.VertexEntryPoint)
+
+	primitive := map[string]any{}
+	setString(primitive, "topology", primitiveTopologies[desc.Topology])
+	setString(primitive, "frontFace", frontFaces[desc.FrontFace])
+	setString(primitive, "cullMode", cullModes[desc.CullMode])
+
+	multisample := desc.Multisample.WithDefaults()
+	jsDesc := map[string]any{
+		"layout":    layout,
+		"vertex":    vertex,
+		"primitive": primitive,
+		"multisample": map[string]any{
+			"count":                  multisample.Count,
+			"mask":                   multisample.Mask,
+			"alphaToCoverageEnabled": multisample.AlphaToCoverageEnabled,
+		},
+	}
+	setString(jsDesc, "label", desc.Label)
+
+	if desc.FragmentShader != 0 {
+		fragShader := b.get(uintptr(desc.FragmentShader))
+		if fragShader.IsUndefined() {
+			return 0, fmt.Errorf("web backend: invalid shader module")
+		}
+
+		colorTargets := desc.ColorTargets()
+		targets := make([]any, len(colorTargets))
+		for i, target := range colorTargets {
+			t := map[string]any{
+				"format":    convertTextureFormat(target.Format),
+				"writeMask": uint32(target.WriteMask),
+			}
+			if target.Blend != nil {
+				t["blend"] = map[string]any{
+					"color": blendComponent(target.Blend.Color),
+					"alpha": blendComponent(target.Blend.Alpha),
+				}
+			}
+			targets[i] = t
+		}
+
+		fragment := map[string]any{"module": fragShader, "targets": targets}
+		setString(fragment, "entryPoint", desc.FragmentEntry)
+		jsDesc["fragment"] = fragment
+	}
+
+	pipeline := dev.value.Call("createRenderPipeline", jsDesc)
+	return types.RenderPipeline(b.add(pipeline)), nil
+}
+
+// blendComponent converts a BlendComponent to a GPUBlendComponent.
+func blendComponent(c types.BlendComponent) map[string]any {
+	return map[string]any{
+		"operation": blendOperations[c.Operation],
+		"srcFactor": blendFactors[c.SrcFactor],
+		"dstFactor": blendFactors[c.DstFactor],
+	}
+}
+
+// CreateCommandEncoder creates a command encoder.
+func (b *Backend) CreateCommandEncoder(device types.Device) types.CommandEncoder {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0
+	}
+	return types.CommandEncoder(b.add(dev.value.Call("createCommandEncoder")))
+}
+
+// BeginRenderPass begins a render pass.
+func (b *Backend) BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return 0
+	}
+
+	attachments := make([]any, len(desc.ColorAttachments))
+	for i, att := range desc.ColorAttachments {
+		a := map[string]any{
+			"view":       b.get(uintptr(att.View)),
+			"loadOp":     loadOps[att.LoadOp],
+			"storeOp":    storeOps[att.StoreOp],
+			"clearValue": colorObject(att.ClearValue),
+		}
+		if att.ResolveTarget != 0 {
+			a["resolveTarget"] = b.get(uintptr(att.ResolveTarget))
+		}
+		attachments[i] = a
+	}
+
+	jsDesc := map[string]any{"colorAttachments": attachments}
+	setString(jsDesc, "label", desc.Label)
+	if ds := desc.DepthStencil; ds != nil {
+		// Stencil operations must be left out for formats without stencil
+		depthStencil := map[string]any{
+			"view":              b.get(uintptr(ds.View)),
+			"depthClearValue":   ds.DepthClearValue,
+			"stencilClearValue": ds.StencilClearValue,
+		}
+		setString(depthStencil, "depthLoadOp", loadOps[ds.DepthLoadOp])
+		setString(depthStencil, "depthStoreOp", storeOps[ds.DepthStoreOp])
+		setString(depthStencil, "stencilLoadOp", loadOps[ds.StencilLoadOp])
+		setString(depthStencil, "stencilStoreOp", storeOps[ds.StencilStoreOp])
+		jsDesc["depthStencilAttachment"] = depthStencil
+	}
+	if desc.OcclusionQuerySet != 0 {
+		jsDesc["occlusionQuerySet"] = b.get(uintptr(desc.OcclusionQuerySet))
+	}
+
+	pass := enc.Call("beginRenderPass", jsDesc)
+	return types.RenderPass(b.add(pass))
+}
+
+// EndRenderPass ends a render pass.
+func (b *Backend) EndRenderPass(pass types.RenderPass) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("end")
+	}
+}
+
+// FinishEncoder finishes the command encoder.
+func (b *Backend) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffer {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return 0
+	}
+	return types.CommandBuffer(b.add(enc.Call("finish")))
+}
+
+// Submit submits commands to the queue.
+func (b *Backend) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
+	dev := b.queues[queue]
+	buf := b.get(uintptr(commands))
+	if dev == nil || buf.IsUndefined() {
+		return 0
+	}
+	dev.queue.Call("submit", []any{buf})
+	dev.submitted++
+	return dev.submitted
+}
+
+// CopyBufferToBuffer records a copy between buffers.
+func (b *Backend) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+	enc := b.get(uintptr(encoder))
+	srcBuf := b.get(uintptr(src))
+	dstBuf := b.get(uintptr(dst))
+	if enc.IsUndefined() || srcBuf.IsUndefined() || dstBuf.IsUndefined() {
+		return
+	}
+	enc.Call("copyBufferToBuffer", srcBuf, srcOffset, dstBuf, dstOffset, size)
+}
+
+// CopyTextureToBuffer records a copy from a texture to a buffer.
+func (b *Backend) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return
+	}
+	enc.Call("copyTextureToBuffer", b.texelCopyTexture(src), b.texelCopyBuffer(dst), extentObject(size))
+}
+
+// CopyBufferToTexture records a copy from a buffer to a texture.
+func (b *Backend) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return
+	}
+	enc.Call("copyBufferToTexture", b.texelCopyBuffer(src), b.texelCopyTexture(dst), extentObject(size))
+}
+
+// CopyTextureToTexture records a copy between textures.
+func (b *Backend) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return
+	}
+	enc.Call("copyTextureToTexture", b.texelCopyTexture(src), b.texelCopyTexture(dst), extentObject(size))
+}
+
+// ClearBuffer records filling a buffer range with zeros.
+func (b *Backend) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+	enc := b.get(uintptr(encoder))
+	buf := b.get(uintptr(buffer))
+	if enc.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	enc.Call("clearBuffer", buf, offset, size)
+}
+
+// texelCopyTexture converts an ImageCopyTexture to a GPUTexelCopyTextureInfo.
+func (b *Backend) texelCopyTexture(c *types.ImageCopyTexture) map[string]any {
+	return map[string]any{
+		"texture":  b.get(uintptr(c.Texture)),
+		"mipLevel": c.MipLevel,
+		"origin":   map[string]any{"x": c.Origin.X, "y": c.Origin.Y, "z": c.Origin.Z},
+		"aspect":   textureAspects[c.Aspect],
+	}
+}
+
+// texelCopyBuffer converts an ImageCopyBuffer to a GPUTexelCopyBufferInfo.
+func (b *Backend) texelCopyBuffer(c *types.ImageCopyBuffer) map[string]any {
+	info := dataLayout(&c.Layout)
+	info["buffer"] = b.get(uintptr(c.Buffer))
+	return info
+}
+
+// dataLayout converts an ImageDataLayout to a GPUTexelCopyBufferLayout.
+func dataLayout(layout *types.ImageDataLayout) map[string]any {
+	result := map[string]any{
+		"offset":      layout.Offset,
+		"bytesPerRow": layout.BytesPerRow,
+	}
+	if layout.RowsPerImage != 0 {
+		result["rowsPerImage"] = layout.RowsPerImage
+	}
+	return result
+}
+
+// Poll runs the callbacks of completed buffer mappings. With wait it
+// first waits for all submitted work and pending mappings.
+func (b *Backend) Poll(device types.Device, wait bool) bool {
+	dev := b.devices[device]
+	if dev == nil {
+		return true
+	}
+
+	if wait {
+		_, _ = await(dev.queue.Call("onSubmittedWorkDone"))
+	}
+
+	pending := dev.pending[:0]
+	for _, p := range dev.pending {
+		if wait {
+			p.callback(<-p.done)
+			continue
+		}
+		select {
+		case err := <-p.done:
+			p.callback(err)
+		default:
+			pending = append(pending, p)
+		}
+	}
+	clear(dev.pending[len(pending):])
+	dev.pending = pending
+	return len(pending) == 0
+}
+
+// OnSubmittedWorkDone calls callback during Poll once all work submitted
+// to the queue so far has completed.
+func (b *Backend) OnSubmittedWorkDone(queue types.Queue, callback func()) {
+	dev := b.queues[queue]
+	if dev == nil {
+		return
+	}
+	p := &pendingPromise{done: make(chan error, 1), callback: func(error) { callback() }}
+	then(dev.queue.Call("onSubmittedWorkDone"),
+		func(js.Value) { p.done <- nil },
+		func(js.Value) { p.done <- nil })
+	dev.pending = append(dev.pending, p)
+}
+
+// SetPipeline sets the render pipeline.
+func (b *Backend) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline) {
+	p := b.get(uintptr(pass))
+	pipe := b.get(uintptr(pipeline))
+	if !p.IsUndefined() && !pipe.IsUndefined() {
+		p.Call("setPipeline", pipe)
+	}
+}
+
+// Draw issues a draw call.
+func (b *Backend) Draw(pass types.RenderPass, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("draw", vertexCount, instanceCount, firstVertex, firstInstance)
+	}
+}
+
+// SetPushConstants is a no-op: browsers do not support push constants.
+func (b *Backend) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+}
+
+// DrawIndirect issues a draw call with arguments from a buffer.
+func (b *Backend) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	p := b.get(uintptr(pass))
+	buf := b.get(uintptr(buffer))
+	if !p.IsUndefined() && !buf.IsUndefined() {
+		p.Call("drawIndirect", buf, offset)
+	}
+}
+
+// DrawIndexedIndirect issues an indexed draw call with arguments from a buffer.
+func (b *Backend) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	p := b.get(uintptr(pass))
+	buf := b.get(uintptr(buffer))
+	if !p.IsUndefined() && !buf.IsUndefined() {
+		p.Call("drawIndexedIndirect", buf, offset)
+	}
+}
+
+// SetViewport sets the viewport transform of a render pass.
+func (b *Backend) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("setViewport", x, y, width, height, minDepth, maxDepth)
+	}
+}
+
+// SetScissorRect sets the scissor rectangle of a render pass.
+func (b *Backend) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("setScissorRect", x, y, width, height)
+	}
+}
+
+// SetBlendConstant sets the constant color of the constant blend factors.
+func (b *Backend) SetBlendConstant(pass types.RenderPass, color *types.Color) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("setBlendConstant", colorObject(*color))
+	}
+}
+
+// SetStencilReference sets the stencil reference value of a render pass.
+func (b *Backend) SetStencilReference(pass types.RenderPass, reference uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("setStencilReference", reference)
+	}
+}
+
+// CreateTexture creates a texture.
+func (b *Backend) CreateTexture(device types.Device, desc *types.TextureDescriptor) (types.Texture, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	format := convertTextureFormat(desc.Format)
+	if format == "" {
+		return 0, fmt.Errorf("web backend: unsupported texture format %#x", uint32(desc.Format))
+	}
+
+	jsDesc := map[string]any{
+		"size":      extentObject(&desc.Size),
+		"dimension": textureDimensions[desc.Dimension],
+		"format":    format,
+		"usage":     uint32(desc.Usage),
+	}
+	setString(jsDesc, "label", desc.Label)
+	if desc.MipLevelCount != 0 {
+		jsDesc["mipLevelCount"] = desc.MipLevelCount
+	}
+	if desc.SampleCount != 0 {
+		jsDesc["sampleCount"] = desc.SampleCount
+	}
+	if len(desc.ViewFormats) > 0 {
+		jsDesc["viewFormats"] = formatNames(desc.ViewFormats)
+	}
+
+	texture := dev.value.Call("createTexture", jsDesc)
+	return types.Texture(b.add(texture)), nil
+}
+
+// SupportedSampleCounts returns the sample counts a texture format supports
+// for multisampled rendering.
+func (b *Backend) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	// WebGPU guarantees 1 and 4 samples for all renderable color formats
+	return []uint32{1, 4}
+}
+
+// CreateTextureView creates a texture view.
+func (b *Backend) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
+	tex := b.get(uintptr(texture))
+	if tex.IsUndefined() {
+		return 0
+	}
+
+	if desc == nil {
+		return types.TextureView(b.add(tex.Call("createView")))
+	}
+
+	jsDesc := map[string]any{
+		"aspect":         textureAspects[desc.Aspect],
+		"baseMipLevel":   desc.BaseMipLevel,
+		"baseArrayLayer": desc.BaseArrayLayer,
+	}
+	setString(jsDesc, "label", desc.Label)
+	setString(jsDesc, "format", convertTextureFormat(desc.Format))
+	setString(jsDesc, "dimension", textureViewDimensions[desc.Dimension])
+	if desc.MipLevelCount != 0 {
+		jsDesc["mipLevelCount"] = desc.MipLevelCount
+	}
+	if desc.ArrayLayerCount != 0 {
+		jsDesc["arrayLayerCount"] = desc.ArrayLayerCount
+	}
+
+	return types.TextureView(b.add(tex.Call("createView", jsDesc)))
+}
+
+// WriteTexture writes data to a texture.
+func (b *Backend) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, data []byte, layout *types.ImageDataLayout, size *types.Extent3D) {
+	q := b.get(uintptr(queue))
+	if q.IsUndefined() {
+		return
+	}
+	q.Call("writeTexture", b.texelCopyTexture(dst), bytesToJS(data), dataLayout(layout), extentObject(size))
+}
+
+// CreateSampler creates a sampler.
+func (b *Backend) CreateSampler(device types.Device, desc *types.SamplerDescriptor) (types.Sampler, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	normalized, err := gpu.NormalizeSampler(desc, b.GetDeviceFeatures(device))
+	if err != nil {
+		return 0, err
+	}
+	desc = &normalized
+
+	jsDesc := map[string]any{
+		"addressModeU": addressModes[desc.AddressModeU],
+		"addressModeV": addressModes[desc.AddressModeV],
+		"addressModeW": addressModes[desc.AddressModeW],
+		"magFilter":    filterModes[desc.MagFilter],
+		"minFilter":    filterModes[desc.MinFilter],
+		"mipmapFilter": mipmapFilterModes[desc.MipmapFilter],
+		"lodMinClamp":  desc.LodMinClamp,
+		"lodMaxClamp":  desc.LodMaxClamp,
+	}
+	setString(jsDesc, "label", desc.Label)
+	setString(jsDesc, "compare", compareFunctions[desc.Compare])
+	jsDesc["maxAnisotropy"] = desc.MaxAnisotropy
+
+	sampler := dev.value.Call("createSampler", jsDesc)
+	return types.Sampler(b.add(sampler)), nil
+}
+
+// CreateBuffer creates a buffer.
+func (b *Backend) CreateBuffer(device types.Device, desc *types.BufferDescriptor) (types.Buffer, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	jsDesc := map[string]any{
+		"size":             desc.Size,
+		"usage":            uint32(desc.Usage),
+		"mappedAtCreation": desc.MappedAtCreation,
+	}
+	setString(jsDesc, "label", desc.Label)
+
+	value := dev.value.Call("createBuffer", jsDesc)
+	handle := types.Buffer(b.add(value))
+	b.buffers[handle] = &buffer{value: value, write: desc.MappedAtCreation}
+	return handle, nil
+}
+
+// WriteBuffer writes data to a buffer.
+func (b *Backend) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uint64, data []byte) {
+	q := b.get(uintptr(queue))
+	buf := b.get(uintptr(buffer))
+	if q.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	q.Call("writeBuffer", buf, offset, bytesToJS(data))
+}
+
+// MapBufferAsync maps a buffer range for CPU access. The browser maps
+// the buffer in the background; the callback runs during the first Poll
+// of the device after the mapping completed.
+func (b *Backend) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	dev := b.devices[device]
+	buf := b.buffers[buffer]
+	if dev == nil || buf == nil {
+		callback(fmt.Errorf("web backend: invalid buffer"))
+		return
+	}
+
+	buf.write = mode == types.MapModeWrite
+	p := &pendingPromise{done: make(chan error, 1), callback: callback}
+	then(buf.value.Call("mapAsync", uint32(mode), offset, size),
+		func(js.Value) { p.done <- nil },
+		func(reason js.Value) { p.done <- fmt.Errorf("web backend: map buffer: %w", jsError(reason)) })
+	dev.pending = append(dev.pending, p)
+}
+
+// GetMappedRange returns a copy of the mapped memory of a buffer. For
+// buffers mapped for writing, the copy is written back by UnmapBuffer.
+func (b *Backend) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	buf := b.buffers[buffer]
+	if buf == nil {
+		return nil
+	}
+
+	mapped := buf.value.Call("getMappedRange", offset, size)
+	array := js.Global().Get("Uint8Array").New(mapped)
+	data := make([]byte, size)
+	js.CopyBytesToGo(data, array)
+	buf.ranges = append(buf.ranges, mappedRange{array: array, data: data})
+	return data
+}
+
+// UnmapBuffer unmaps a buffer.
+func (b *Backend) UnmapBuffer(buffer types.Buffer) {
+	buf := b.buffers[buffer]
+	if buf == nil {
+		return
+	}
+
+	if buf.write {
+		for _, r := range buf.ranges {
+			js.CopyBytesToJS(r.array, r.data)
+		}
+	}
+	buf.ranges = nil
+	buf.write = false
+	buf.value.Call("unmap")
+}
+
+// CreateBindGroupLayout creates a bind group layout.
+func (b *Backend) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	entries := make([]any, len(desc.Entries))
+	for i, entry := range desc.Entries {
+		e := map[string]any{
+			"binding":    entry.Binding,
+			"visibility": uint32(entry.Visibility),
+		}
+		if entry.Buffer != nil {
+			layout := map[string]any{
+				"hasDynamicOffset": entry.Buffer.HasDynamicOffset,
+				"minBindingSize":   entry.Buffer.MinBindingSize,
+			}
+			setString(layout, "type", bufferBindingTypes[entry.Buffer.Type])
+			e["buffer"] = layout
+		}
+		if entry.Sampler != nil {
+			layout := map[string]any{}
+			setString(layout, "type", samplerBindingTypes[entry.Sampler.Type])
+			e["sampler"] = layout
+		}
+		if entry.Texture != nil {
+			layout := map[string]any{"multisampled": entry.Texture.Multisampled}
+			setString(layout, "sampleType", textureSampleTypes[entry.Texture.SampleType])
+			setString(layout, "viewDimension", textureViewDimensions[entry.Texture.ViewDimension])
+			e["texture"] = layout
+		}
+		if entry.StorageTexture != nil {
+			layout := map[string]any{"format": convertTextureFormat(entry.StorageTexture.Format)}
+			setString(layout, "access", storageTextureAccesses[entry.StorageTexture.Access])
+			setString(layout, "viewDimension", textureViewDimensions[entry.StorageTexture.ViewDimension])
+			e["storageTexture"] = layout
+		}
+		entries[i] = e
+	}
+
+	jsDesc := map[string]any{"entries": entries}
+	setString(jsDesc, "label", desc.Label)
+	layout := dev.value.Call("createBindGroupLayout", jsDesc)
+	return types.BindGroupLayout(b.add(layout)), nil
+}
+
+// CreateBindGroup creates a bind group.
+func (b *Backend) CreateBindGroup(device types.Device, desc *types.BindGroupDescriptor) (types.BindGroup, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	layout := b.get(uintptr(desc.Layout))
+	if layout.IsUndefined() {
+		return 0, fmt.Errorf("web backend: invalid bind group layout")
+	}
+
+	entries := make([]any, len(desc.Entries))
+	for i, entry := range desc.Entries {
+		var resource any
+		switch {
+		case entry.Buffer != 0:
+			binding := map[string]any{
+				"buffer": b.get(uintptr(entry.Buffer)),
+				"offset": entry.Offset,
+			}
+			if entry.Size != 0 {
+				binding["size"] = entry.Size
+			}
+			resource = binding
+		case entry.Sampler != 0:
+			resource = b.get(uintptr(entry.Sampler))
+		default:
+			resource = b.get(uintptr(entry.TextureView))
+		}
+		entries[i] = map[string]any{"binding": entry.Binding, "resource": resource}
+	}
+
+	jsDesc := map[string]any{"layout": layout, "entries": entries}
+	setString(jsDesc, "label", desc.Label)
+	group := dev.value.Call("createBindGroup", jsDesc)
+	return types.BindGroup(b.add(group)), nil
+}
+
+// CreatePipelineLayout creates a pipeline layout.
+func (b *Backend) CreatePipelineLayout(device types.Device, desc *types.PipelineLayoutDescriptor) (types.PipelineLayout, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+	if len(desc.PushConstantRanges) > 0 {
+		return 0, fmt.Errorf("web backend: push constants are not supported")
+	}
+
+	layouts := make([]any, len(desc.BindGroupLayouts))
+	for i, handle := range desc.BindGroupLayouts {
+		layouts[i] = b.get(uintptr(handle))
+	}
+
+	jsDesc := map[string]any{"bindGroupLayouts": layouts}
+	setString(jsDesc, "label", desc.Label)
+	layout := dev.value.Call("createPipelineLayout", jsDesc)
+	return types.PipelineLayout(b.add(layout)), nil
+}
+
+// dynamicOffsets converts dynamic offsets to a JavaScript array.
+func dynamicOffsets(offsets []uint32) []any {
+	result := make([]any, len(offsets))
+	for i, offset := range offsets {
+		result[i] = offset
+	}
+	return result
+}
+
+// SetBindGroup sets a bind group.
+func (b *Backend) SetBindGroup(pass types.RenderPass, index uint32, bindGroup types.BindGroup, offsets []uint32) {
+	p := b.get(uintptr(pass))
+	group := b.get(uintptr(bindGroup))
+	if !p.IsUndefined() && !group.IsUndefined() {
+		p.Call("setBindGroup", index, group, dynamicOffsets(offsets))
+	}
+}
+
+// SetVertexBuffer sets a vertex buffer. A size of 0 binds the rest of
+// the buffer.
+func (b *Backend) SetVertexBuffer(pass types.RenderPass, slot uint32, buffer types.Buffer, offset, size uint64) {
+	p := b.get(uintptr(pass))
+	buf := b.get(uintptr(buffer))
+	if p.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	if size == 0 {
+		p.Call("setVertexBuffer", slot, buf, offset)
+		return
+	}
+	p.Call("setVertexBuffer", slot, buf, offset, size)
+}
+
+// SetIndexBuffer sets the index buffer. A size of 0 binds the rest of
+// the buffer.
+func (b *Backend) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	p := b.get(uintptr(pass))
+	buf := b.get(uintptr(buffer))
+	if p.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	if size == 0 {
+		p.Call("setIndexBuffer", buf, indexFormats[format], offset)
+		return
+	}
+	p.Call("setIndexBuffer", buf, indexFormats[format], offset, size)
+}
+
+// DrawIndexed issues an indexed draw call.
+func (b *Backend) DrawIndexed(pass types.RenderPass, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("drawIndexed", indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	}
+}
+
+// CreateRenderBundleEncoder creates a render bundle encoder.
+func (b *Backend) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	formats := make([]any, len(desc.ColorFormats))
+	for i, format := range desc.ColorFormats {
+		formats[i] = convertTextureFormat(format)
+	}
+
+	sampleCount := desc.SampleCount
+	if sampleCount == 0 {
+		sampleCount = 1
+	}
+	jsDesc := map[string]any{
+		"colorFormats":    formats,
+		"sampleCount":     sampleCount,
+		"depthReadOnly":   desc.DepthReadOnly,
+		"stencilReadOnly": desc.StencilReadOnly,
+	}
+	setString(jsDesc, "label", desc.Label)
+	setString(jsDesc, "depthStencilFormat", convertTextureFormat(desc.DepthStencilFormat))
+
+	encoder := dev.value.Call("createRenderBundleEncoder", jsDesc)
+	return types.RenderBundleEncoder(b.add(encoder)), nil
+}
+
+// SetBundlePipeline sets the render pipeline of a bundle.
+func (b *Backend) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+	enc := b.get(uintptr(encoder))
+	pipe := b.get(uintptr(pipeline))
+	if !enc.IsUndefined() && !pipe.IsUndefined() {
+		enc.Call("setPipeline", pipe)
+	}
+}
+
+// SetBundleBindGroup sets a bind group of a bundle.
+func (b *Backend) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, offsets []uint32) {
+	enc := b.get(uintptr(encoder))
+	group := b.get(uintptr(bindGroup))
+	if !enc.IsUndefined() && !group.IsUndefined() {
+		enc.Call("setBindGroup", index, group, dynamicOffsets(offsets))
+	}
+}
+
+// SetBundleVertexBuffer sets a vertex buffer of a bundle.
+func (b *Backend) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+	enc := b.get(uintptr(encoder))
+	buf := b.get(uintptr(buffer))
+	if enc.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	if size == 0 {
+		enc.Call("setVertexBuffer", slot, buf, offset)
+		return
+	}
+	enc.Call("setVertexBuffer", slot, buf, offset, size)
+}
+
+// SetBundleIndexBuffer sets the index buffer of a bundle.
+func (b *Backend) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	enc := b.get(uintptr(encoder))
+	buf := b.get(uintptr(buffer))
+	if enc.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	if size == 0 {
+		enc.Call("setIndexBuffer", buf, indexFormats[format], offset)
+		return
+	}
+	enc.Call("setIndexBuffer", buf, indexFormats[format], offset, size)
+}
+
+// BundleDraw records a draw call into a bundle.
+func (b *Backend) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	if enc := b.get(uintptr(encoder)); !enc.IsUndefined() {
+		enc.Call("draw", vertexCount, instanceCount, firstVertex, firstInstance)
+	}
+}
+
+// BundleDrawIndexed records an indexed draw call into a bundle.
+func (b *Backend) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	if enc := b.get(uintptr(encoder)); !enc.IsUndefined() {
+		enc.Call("drawIndexed", indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	}
+}
+
+// SetBundlePushConstants is a no-op: browsers do not support push constants.
+func (b *Backend) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+}
+
+// BundleDrawIndirect records an indirect draw call into a bundle.
+func (b *Backend) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	enc := b.get(uintptr(encoder))
+	buf := b.get(uintptr(buffer))
+	if !enc.IsUndefined() && !buf.IsUndefined() {
+		enc.Call("drawIndirect", buf, offset)
+	}
+}
+
+// BundleDrawIndexedIndirect records an indexed indirect draw call into a bundle.
+func (b *Backend) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	enc := b.get(uintptr(encoder))
+	buf := b.get(uintptr(buffer))
+	if !enc.IsUndefined() && !buf.IsUndefined() {
+		enc.Call("drawIndexedIndirect", buf, offset)
+	}
+}
+
+// FinishRenderBundle finishes recording a bundle.
+func (b *Backend) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return 0
+	}
+	return types.RenderBundle(b.add(enc.Call("finish")))
+}
+
+// ExecuteBundles replays bundles in a render pass.
+func (b *Backend) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {
+	p := b.get(uintptr(pass))
+	if p.IsUndefined() {
+		return
+	}
+
+	values := make([]any, 0, len(bundles))
+	for _, bundle := range bundles {
+		if v := b.get(uintptr(bundle)); !v.IsUndefined() {
+			values = append(values, v)
+		}
+	}
+	p.Call("executeBundles", values)
+}
+
+// CreateComputePipeline creates a compute pipeline.
+func (b *Backend) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	module := b.get(uintptr(desc.Module))
+	if module.IsUndefined() {
+		return 0, fmt.Errorf("web backend: invalid shader module")
+	}
+
+	var layout any = "auto"
+	if desc.Layout != 0 {
+		layout = b.get(uintptr(desc.Layout))
+		if layout.(js.Value).IsUndefined() {
+			return 0, fmt.Errorf("web backend: invalid pipeline layout")
+		}
+	}
+
+	compute := map[string]any{"module": module}
+	setString(compute, "entryPoint", desc.EntryPoint)
+	jsDesc := map[string]any{"layout": layout, "compute": compute}
+	setString(jsDesc, "label", desc.Label)
+
+	pipeline := dev.value.Call("createComputePipeline", jsDesc)
+	return types.ComputePipeline(b.add(pipeline)), nil
+}
+
+// BeginComputePass begins a compute pass.
+func (b *Backend) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	enc := b.get(uintptr(encoder))
+	if enc.IsUndefined() {
+		return 0
+	}
+
+	jsDesc := map[string]any{}
+	if desc != nil {
+		setString(jsDesc, "label", desc.Label)
+	}
+	return types.ComputePass(b.add(enc.Call("beginComputePass", jsDesc)))
+}
+
+// SetComputePipeline sets the compute pipeline.
+func (b *Backend) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {
+	p := b.get(uintptr(pass))
+	pipe := b.get(uintptr(pipeline))
+	if !p.IsUndefined() && !pipe.IsUndefined() {
+		p.Call("setPipeline", pipe)
+	}
+}
+
+// SetComputeBindGroup sets a bind group of a compute pass.
+func (b *Backend) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, offsets []uint32) {
+	p := b.get(uintptr(pass))
+	group := b.get(uintptr(bindGroup))
+	if !p.IsUndefined() && !group.IsUndefined() {
+		p.Call("setBindGroup", index, group, dynamicOffsets(offsets))
+	}
+}
+
+// DispatchWorkgroups dispatches compute workgroups.
+func (b *Backend) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("dispatchWorkgroups", x, y, z)
+	}
+}
+
+// DispatchWorkgroupsIndirect dispatches compute workgroups with counts
+// from a buffer.
+func (b *Backend) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+	p := b.get(uintptr(pass))
+	buf := b.get(uintptr(buffer))
+	if !p.IsUndefined() && !buf.IsUndefined() {
+		p.Call("dispatchWorkgroupsIndirect", buf, offset)
+	}
+}
+
+// EndComputePass ends a compute pass.
+func (b *Backend) EndComputePass(pass types.ComputePass) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("end")
+	}
+}
+
+// debugCall calls a debug method on an encoder or pass, if it exists.
+func (b *Backend) debugCall(handle uintptr, method string, args ...any) {
+	if v := b.get(handle); !v.IsUndefined() {
+		v.Call(method, args...)
+	}
+}
+
+// PushDebugGroup opens a debug group on a command encoder.
+func (b *Backend) PushDebugGroup(encoder types.CommandEncoder, label string) {
+	b.debugCall(uintptr(encoder), "pushDebugGroup", label)
+}
+
+// PopDebugGroup closes the innermost debug group of a command encoder.
+func (b *Backend) PopDebugGroup(encoder types.CommandEncoder) {
+	b.debugCall(uintptr(encoder), "popDebugGroup")
+}
+
+// InsertDebugMarker inserts a debug marker into a command encoder.
+func (b *Backend) InsertDebugMarker(encoder types.CommandEncoder, label string) {
+	b.debugCall(uintptr(encoder), "insertDebugMarker", label)
+}
+
+// PushRenderPassDebugGroup opens a debug group in a render pass.
+func (b *Backend) PushRenderPassDebugGroup(pass types.RenderPass, label string) {
+	b.debugCall(uintptr(pass), "pushDebugGroup", label)
+}
+
+// PopRenderPassDebugGroup closes the innermost debug group of a render pass.
+func (b *Backend) PopRenderPassDebugGroup(pass types.RenderPass) {
+	b.debugCall(uintptr(pass), "popDebugGroup")
+}
+
+// InsertRenderPassDebugMarker inserts a debug marker into a render pass.
+func (b *Backend) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {
+	b.debugCall(uintptr(pass), "insertDebugMarker", label)
+}
+
+// PushComputePassDebugGroup opens a debug group in a compute pass.
+func (b *Backend) PushComputePassDebugGroup(pass types.ComputePass, label string) {
+	b.debugCall(uintptr(pass), "pushDebugGroup", label)
+}
+
+// PopComputePassDebugGroup closes the innermost debug group of a compute pass.
+func (b *Backend) PopComputePassDebugGroup(pass types.ComputePass) {
+	b.debugCall(uintptr(pass), "popDebugGroup")
+}
+
+// InsertComputePassDebugMarker inserts a debug marker into a compute pass.
+func (b *Backend) InsertComputePassDebugMarker(pass types.ComputePass, label string) {
+	b.debugCall(uintptr(pass), "insertDebugMarker", label)
+}
+
+// CreateQuerySet creates a query set.
+func (b *Backend) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	dev := b.devices[device]
+	if dev == nil {
+		return 0, fmt.Errorf("web backend: invalid device")
+	}
+
+	jsDesc := map[string]any{
+		"type":  queryTypes[desc.Type],
+		"count": desc.Count,
+	}
+	setString(jsDesc, "label", desc.Label)
+	querySet := dev.value.Call("createQuerySet", jsDesc)
+	return types.QuerySet(b.add(querySet)), nil
+}
+
+// WriteTimestamp is a no-op: browsers only write timestamps at the
+// boundaries of passes.
+func (b *Backend) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+}
+
+// BeginOcclusionQuery begins an occlusion query in a render pass.
+func (b *Backend) BeginOcclusionQuery(pass types.RenderPass, index uint32) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("beginOcclusionQuery", index)
+	}
+}
+
+// EndOcclusionQuery ends the current occlusion query of a render pass.
+func (b *Backend) EndOcclusionQuery(pass types.RenderPass) {
+	if p := b.get(uintptr(pass)); !p.IsUndefined() {
+		p.Call("endOcclusionQuery")
+	}
+}
+
+// ResolveQuerySet records copying query results into a buffer.
+func (b *Backend) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+	enc := b.get(uintptr(encoder))
+	qs := b.get(uintptr(querySet))
+	buf := b.get(uintptr(dst))
+	if enc.IsUndefined() || qs.IsUndefined() || buf.IsUndefined() {
+		return
+	}
+	enc.Call("resolveQuerySet", qs, firstQuery, queryCount, buf, dstOffset)
+}
+
+// release drops the reference to the object of a handle, destroying it
+// first with destroy set.
+func (b *Backend) release(handle uintptr, destroy bool) {
+	v, ok := b.objects[handle]
+	if !ok {
+		return
+	}
+	if destroy {
+		v.Call("destroy")
+	}
+	delete(b.objects, handle)
+}
+
+// ReleaseTexture destroys a texture. Canvas textures belong to the
+// browser and are only dropped.
+func (b *Backend) ReleaseTexture(texture types.Texture) {
+	owned := !b.surfaceTextures[texture]
+	delete(b.surfaceTextures, texture)
+	b.release(uintptr(texture), owned)
+}
+
+// ReleaseTextureView releases a texture view.
+func (b *Backend) ReleaseTextureView(view types.TextureView) {
+	b.release(uintptr(view), false)
+}
+
+// ReleaseSampler releases a sampler.
+func (b *Backend) ReleaseSampler(sampler types.Sampler) {
+	b.release(uintptr(sampler), false)
+}
+
+// ReleaseBuffer destroys a buffer.
+func (b *Backend) ReleaseBuffer(buffer types.Buffer) {
+	delete(b.buffers, buffer)
+	b.release(uintptr(buffer), true)
+}
+
+// ReleaseBindGroupLayout releases a bind group layout.
+func (b *Backend) ReleaseBindGroupLayout(layout types.BindGroupLayout) {
+	b.release(uintptr(layout), false)
+}
+
+// ReleaseShaderModule releases a shader module.
+func (b *Backend) ReleaseShaderModule(module types.ShaderModule) {
+	b.release(uintptr(module), false)
+}
+
+// ReleaseRenderPipeline releases a render pipeline.
+func (b *Backend) ReleaseRenderPipeline(pipeline types.RenderPipeline) {
+	b.release(uintptr(pipeline), false)
+}
+
+// ReleaseBindGroup releases a bind group.
+func (b *Backend) ReleaseBindGroup(group types.BindGroup) {
+	b.release(uintptr(group), false)
+}
+
+// ReleasePipelineLayout releases a pipeline layout.
+func (b *Backend) ReleasePipelineLayout(layout types.PipelineLayout) {
+	b.release(uintptr(layout), false)
+}
+
+// ReleaseCommandBuffer releases a command buffer.
+func (b *Backend) ReleaseCommandBuffer(buffer types.CommandBuffer) {
+	b.release(uintptr(buffer), false)
+}
+
+// ReleaseCommandEncoder releases a command encoder.
+func (b *Backend) ReleaseCommandEncoder(encoder types.CommandEncoder) {
+	b.release(uintptr(encoder), false)
+}
+
+// ReleaseRenderPass releases a render pass.
+func (b *Backend) ReleaseRenderPass(pass types.RenderPass) {
+	b.release(uintptr(pass), false)
+}
+
+// ReleaseComputePipeline releases a compute pipeline.
+func (b *Backend) ReleaseComputePipeline(pipeline types.ComputePipeline) {
+	b.release(uintptr(pipeline), false)
+}
+
+// ReleaseComputePass releases a compute pass.
+func (b *Backend) ReleaseComputePass(pass types.ComputePass) {
+	b.release(uintptr(pass), false)
+}
+
+// ReleaseQuerySet destroys a query set.
+func (b *Backend) ReleaseQuerySet(querySet types.QuerySet) {
+	b.release(uintptr(querySet), true)
+}
+
+// ReleaseRenderBundleEncoder releases a render bundle encoder.
+func (b *Backend) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {
+	b.release(uintptr(encoder), false)
+}
+
+// ReleaseRenderBundle releases a render bundle.
+func (b *Backend) ReleaseRenderBundle(bundle types.RenderBundle) {
+	b.release(uintptr(bundle), false)
+}
+
+// Ensure Backend implements gpu.Backend.
+var _ gpu.Backend = (*Backend)(nil)
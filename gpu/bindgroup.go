@@ -0,0 +1,206 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// ErrBindGroupMismatch is returned when the resources of a bind group do
+// not match its layout. Backends report such bind groups only as a
+// validation error, after which draws using them do nothing.
+var ErrBindGroupMismatch = errors.New("gpu: bind group does not match its layout")
+
+// BindingResource is the resource bound to one binding of a bind group,
+// with what is known about it for ValidateBindGroup. Zero values of the
+// descriptive fields mean unknown and skip their checks.
+type BindingResource struct {
+	Entry types.BindGroupEntry
+
+	// Buffers
+	BufferSize  uint64
+	BufferUsage types.BufferUsage
+
+	// Texture views
+	TextureFormat types.TextureFormat
+	ViewDimension types.TextureViewDimension
+}
+
+// ValidateBindGroup checks resources against the layout they are bound
+// with: every binding of the layout needs exactly one resource of the
+// type it declares, buffer ranges must lie within their buffer, be
+// aligned and large enough, and textures must have the declared view
+// dimension and a fitting format.
+func ValidateBindGroup(layout *types.BindGroupLayoutDescriptor, resources []BindingResource, limits *types.Limits) error {
+	seen := make(map[uint32]bool, len(resources))
+	for i := range resources {
+		res := &resources[i]
+		binding := res.Entry.Binding
+		if seen[binding] {
+			return bindingError(binding, "bound twice")
+		}
+		seen[binding] = true
+
+		entry := layoutEntry(layout, binding)
+		if entry == nil {
+			return bindingError(binding, "not in the layout")
+		}
+		if err := validateBinding(entry, res, limits); err != nil {
+			return err
+		}
+	}
+
+	for i := range layout.Entries {
+		if binding := layout.Entries[i].Binding; !seen[binding] {
+			return bindingError(binding, "has no resource")
+		}
+	}
+	return nil
+}
+
+// layoutEntry returns the entry of binding, or nil.
+func layoutEntry(layout *types.BindGroupLayoutDescriptor, binding uint32) *types.BindGroupLayoutEntry {
+	for i := range layout.Entries {
+		if layout.Entries[i].Binding == binding {
+			return &layout.Entries[i]
+		}
+	}
+	return nil
+}
+
+// validateBinding checks one resource against its layout entry.
+func validateBinding(entry *types.BindGroupLayoutEntry, res *BindingResource, limits *types.Limits) error {
+	e := &res.Entry
+	set := 0
+	for _, ok := range []bool{e.Buffer != 0, e.Sampler != 0, e.TextureView != 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return bindingError(e.Binding, "needs exactly one of a buffer, sampler or texture view")
+	}
+
+	switch {
+	case entry.Buffer != nil:
+		if e.Buffer == 0 {
+			return bindingError(e.Binding, "expects a buffer")
+		}
+		return validateBufferBinding(entry.Buffer, res, limits)
+	case entry.Sampler != nil:
+		if e.Sampler == 0 {
+			return bindingError(e.Binding, "expects a sampler")
+		}
+	case entry.Texture != nil:
+		if e.TextureView == 0 {
+			return bindingError(e.Binding, "expects a texture view")
+		}
+		return validateTextureBinding(entry.Texture, res)
+	case entry.StorageTexture != nil:
+		if e.TextureView == 0 {
+			return bindingError(e.Binding, "expects a storage texture view")
+		}
+		return validateStorageTextureBinding(entry.StorageTexture, res)
+	default:
+		return bindingError(e.Binding, "declares no resource type in the layout")
+	}
+	return nil
+}
+
+// validateBufferBinding checks the buffer range of a buffer binding.
+func validateBufferBinding(layout *types.BufferBindingLayout, res *BindingResource, limits *types.Limits) error {
+	e := &res.Entry
+	uniform := layout.Type == types.BufferBindingTypeUniform || layout.Type == types.BufferBindingTypeUndefined
+
+	var usage types.BufferUsage
+	var align uint32
+	var maxSize uint64
+	if uniform {
+		usage, align, maxSize = types.BufferUsageUniform, limits.MinUniformBufferOffsetAlignment, limits.MaxUniformBufferBindingSize
+	} else {
+		usage, align, maxSize = types.BufferUsageStorage, limits.MinStorageBufferOffsetAlignment, limits.MaxStorageBufferBindingSize
+	}
+
+	if res.BufferUsage != 0 && res.BufferUsage&usage == 0 {
+		return bindingError(e.Binding, fmt.Sprintf("buffer lacks usage %#x", uint32(usage)))
+	}
+	if align != 0 && e.Offset%uint64(align) != 0 {
+		return bindingError(e.Binding, fmt.Sprintf("has offset %d, not a multiple of %d", e.Offset, align))
+	}
+
+	size := e.Size
+	if res.BufferSize != 0 {
+		if e.Offset > res.BufferSize || e.Offset+size > res.BufferSize {
+			return bindingError(e.Binding, fmt.Sprintf("range at %d of %d bytes exceeds the buffer of %d bytes", e.Offset, size, res.BufferSize))
+		}
+		if size == 0 {
+			size = res.BufferSize - e.Offset
+		}
+	}
+	if size == 0 {
+		return nil // Rest of a buffer of unknown size
+	}
+	if size < layout.MinBindingSize {
+		return bindingError(e.Binding, fmt.Sprintf("binds %d bytes, less than the minimum binding size %d", size, layout.MinBindingSize))
+	}
+	if maxSize != 0 && size > maxSize {
+		return bindingError(e.Binding, fmt.Sprintf("binds %d bytes, more than the binding size limit %d", size, maxSize))
+	}
+	if !uniform && size%4 != 0 {
+		return bindingError(e.Binding, fmt.Sprintf("binds %d bytes of storage, not a multiple of 4", size))
+	}
+	return nil
+}
+
+// validateTextureBinding checks the view of a sampled texture binding.
+func validateTextureBinding(layout *types.TextureBindingLayout, res *BindingResource) error {
+	if err := checkViewDimension(res, layout.ViewDimension); err != nil {
+		return err
+	}
+	if res.TextureFormat == 0 {
+		return nil
+	}
+	depth := res.TextureFormat.HasDepth()
+	switch layout.SampleType {
+	case types.TextureSampleTypeDepth:
+		if !depth {
+			return bindingError(res.Entry.Binding, fmt.Sprintf("expects a depth texture, not format %#x", uint32(res.TextureFormat)))
+		}
+	case types.TextureSampleTypeUnfilterableFloat:
+	default:
+		if depth {
+			return bindingError(res.Entry.Binding, fmt.Sprintf("has depth format %#x but expects a filterable or integer texture", uint32(res.TextureFormat)))
+		}
+	}
+	return nil
+}
+
+// validateStorageTextureBinding checks the view of a storage texture
+// binding.
+func validateStorageTextureBinding(layout *types.StorageTextureBindingLayout, res *BindingResource) error {
+	if err := checkViewDimension(res, layout.ViewDimension); err != nil {
+		return err
+	}
+	if res.TextureFormat != 0 && res.TextureFormat != layout.Format {
+		return bindingError(res.Entry.Binding, fmt.Sprintf("has texture format %#x, not the storage format %#x", uint32(res.TextureFormat), uint32(layout.Format)))
+	}
+	return nil
+}
+
+// checkViewDimension checks the view dimension against the layout's,
+// which defaults to 2D.
+func checkViewDimension(res *BindingResource, want types.TextureViewDimension) error {
+	if want == types.TextureViewDimensionUndefined {
+		want = types.TextureViewDimension2D
+	}
+	if res.ViewDimension != types.TextureViewDimensionUndefined && res.ViewDimension != want {
+		return bindingError(res.Entry.Binding, fmt.Sprintf("has view dimension %d, not the layout's %d", res.ViewDimension, want))
+	}
+	return nil
+}
+
+// bindingError returns an ErrBindGroupMismatch for binding.
+func bindingError(binding uint32, msg string) error {
+	return fmt.Errorf("%w: binding %d %s", ErrBindGroupMismatch, binding, msg)
+}
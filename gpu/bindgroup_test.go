@@ -0,0 +1,84 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestValidateBindGroup(t *testing.T) {
+	layout := &types.BindGroupLayoutDescriptor{
+		Entries: []types.BindGroupLayoutEntry{
+			{Binding: 0, Buffer: &types.BufferBindingLayout{Type: types.BufferBindingTypeUniform, MinBindingSize: 64}},
+			{Binding: 1, Texture: &types.TextureBindingLayout{SampleType: types.TextureSampleTypeFloat}},
+			{Binding: 2, Sampler: &types.SamplerBindingLayout{Type: types.SamplerBindingTypeFiltering}},
+		},
+	}
+	limits := types.DefaultLimits()
+
+	uniform := BindingResource{
+		Entry:       types.BindGroupEntry{Binding: 0, Buffer: 1},
+		BufferSize:  64,
+		BufferUsage: types.BufferUsageUniform,
+	}
+	texture := BindingResource{
+		Entry:         types.BindGroupEntry{Binding: 1, TextureView: 2},
+		TextureFormat: types.TextureFormatRGBA8Unorm,
+		ViewDimension: types.TextureViewDimension2D,
+	}
+	sampler := BindingResource{Entry: types.BindGroupEntry{Binding: 2, Sampler: 3}}
+
+	if err := ValidateBindGroup(layout, []BindingResource{uniform, texture, sampler}, &limits); err != nil {
+		t.Fatalf("valid bind group rejected: %v", err)
+	}
+
+	with := func(change func(r []BindingResource) []BindingResource) []BindingResource {
+		return change([]BindingResource{uniform, texture, sampler})
+	}
+	tests := []struct {
+		name      string
+		resources []BindingResource
+	}{
+		{"missing binding", with(func(r []BindingResource) []BindingResource { return r[:2] })},
+		{"unknown binding", with(func(r []BindingResource) []BindingResource {
+			return append(r, BindingResource{Entry: types.BindGroupEntry{Binding: 7, Sampler: 3}})
+		})},
+		{"duplicate binding", with(func(r []BindingResource) []BindingResource { return append(r, sampler) })},
+		{"wrong resource type", with(func(r []BindingResource) []BindingResource {
+			r[2].Entry = types.BindGroupEntry{Binding: 2, TextureView: 2}
+			return r
+		})},
+		{"buffer too small", with(func(r []BindingResource) []BindingResource {
+			r[0].BufferSize = 32
+			return r
+		})},
+		{"range outside buffer", with(func(r []BindingResource) []BindingResource {
+			r[0].Entry.Offset = 256
+			return r
+		})},
+		{"unaligned offset", with(func(r []BindingResource) []BindingResource {
+			r[0].BufferSize = 1024
+			r[0].Entry.Offset = 64
+			return r
+		})},
+		{"missing usage", with(func(r []BindingResource) []BindingResource {
+			r[0].BufferUsage = types.BufferUsageStorage
+			return r
+		})},
+		{"cube view for 2D", with(func(r []BindingResource) []BindingResource {
+			r[1].ViewDimension = types.TextureViewDimensionCube
+			return r
+		})},
+		{"depth texture for float", with(func(r []BindingResource) []BindingResource {
+			r[1].TextureFormat = types.TextureFormatDepth32Float
+			return r
+		})},
+	}
+	for _, tt := range tests {
+		err := ValidateBindGroup(layout, tt.resources, &limits)
+		if !errors.Is(err, ErrBindGroupMismatch) {
+			t.Errorf("%s: err = %v, want ErrBindGroupMismatch", tt.name, err)
+		}
+	}
+}
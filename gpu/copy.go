@@ -0,0 +1,60 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// ErrCopyAlignment is returned when a copy does not follow the WebGPU
+// alignment rules. Backends reject such copies with a validation error.
+var ErrCopyAlignment = errors.New("gpu: copy is not aligned")
+
+// ValidateBufferCopy checks a copy between buffers, for CopyBufferToBuffer:
+// offsets and size must be multiples of types.CopyBufferAlignment.
+func ValidateBufferCopy(srcOffset, dstOffset, size uint64) error {
+	if err := checkAligned("source offset", srcOffset, types.CopyBufferAlignment); err != nil {
+		return err
+	}
+	if err := checkAligned("destination offset", dstOffset, types.CopyBufferAlignment); err != nil {
+		return err
+	}
+	return checkAligned("size", size, types.CopyBufferAlignment)
+}
+
+// ValidateClearBuffer checks a range of a buffer for ClearBuffer, which
+// has the same alignment rules as ValidateBufferCopy.
+func ValidateClearBuffer(offset, size uint64) error {
+	if err := checkAligned("offset", offset, types.CopyBufferAlignment); err != nil {
+		return err
+	}
+	return checkAligned("size", size, types.CopyBufferAlignment)
+}
+
+// ValidateImageCopyBuffer checks the buffer side of a copy between a
+// buffer and a texture of size texels, for CopyBufferToTexture and
+// CopyTextureToBuffer. BytesPerRow must be a multiple of
+// types.CopyBytesPerRowAlignment, and RowsPerImage must cover size if the
+// copy has several images.
+func ValidateImageCopyBuffer(layout *types.ImageDataLayout, size *types.Extent3D) error {
+	if err := checkAligned("bytes per row", uint64(layout.BytesPerRow), types.CopyBytesPerRowAlignment); err != nil {
+		return err
+	}
+	if layout.BytesPerRow == 0 && (size.Height > 1 || size.DepthOrArrayLayers > 1) {
+		return fmt.Errorf("%w: bytes per row is required for more than one row", ErrCopyAlignment)
+	}
+	if size.DepthOrArrayLayers > 1 && layout.RowsPerImage < size.Height {
+		return fmt.Errorf("%w: rows per image %d is less than the height %d", ErrCopyAlignment, layout.RowsPerImage, size.Height)
+	}
+	return nil
+}
+
+// checkAligned returns an ErrCopyAlignment error if value is not a
+// multiple of align.
+func checkAligned(what string, value, align uint64) error {
+	if value%align != 0 {
+		return fmt.Errorf("%w: %s %d is not a multiple of %d", ErrCopyAlignment, what, value, align)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestValidateBufferCopy(t *testing.T) {
+	tests := []struct {
+		name                       string
+		srcOffset, dstOffset, size uint64
+		wantErr                    bool
+	}{
+		{"aligned", 0, 256, 64, false},
+		{"source offset", 2, 0, 64, true},
+		{"destination offset", 0, 6, 64, true},
+		{"size", 0, 0, 63, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBufferCopy(tt.srcOffset, tt.dstOffset, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateBufferCopy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrCopyAlignment) {
+				t.Errorf("error %v is not ErrCopyAlignment", err)
+			}
+		})
+	}
+}
+
+func TestValidateClearBuffer(t *testing.T) {
+	if err := ValidateClearBuffer(4, 8); err != nil {
+		t.Errorf("ValidateClearBuffer(4, 8) = %v", err)
+	}
+	if err := ValidateClearBuffer(1, 8); !errors.Is(err, ErrCopyAlignment) {
+		t.Errorf("ValidateClearBuffer(1, 8) = %v, want ErrCopyAlignment", err)
+	}
+	if err := ValidateClearBuffer(0, 3); !errors.Is(err, ErrCopyAlignment) {
+		t.Errorf("ValidateClearBuffer(0, 3) = %v, want ErrCopyAlignment", err)
+	}
+}
+
+func TestValidateImageCopyBuffer(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  types.ImageDataLayout
+		size    types.Extent3D
+		wantErr bool
+	}{
+		{"aligned", types.ImageDataLayout{BytesPerRow: 256}, types.Extent3D{Width: 64, Height: 64, DepthOrArrayLayers: 1}, false},
+		{"unaligned rows", types.ImageDataLayout{BytesPerRow: 100}, types.Extent3D{Width: 25, Height: 4, DepthOrArrayLayers: 1}, true},
+		{"single row", types.ImageDataLayout{}, types.Extent3D{Width: 25, Height: 1, DepthOrArrayLayers: 1}, false},
+		{"missing bytes per row", types.ImageDataLayout{}, types.Extent3D{Width: 25, Height: 2, DepthOrArrayLayers: 1}, true},
+		{"layers", types.ImageDataLayout{BytesPerRow: 256, RowsPerImage: 8}, types.Extent3D{Width: 8, Height: 8, DepthOrArrayLayers: 6}, false},
+		{"short images", types.ImageDataLayout{BytesPerRow: 256, RowsPerImage: 4}, types.Extent3D{Width: 8, Height: 8, DepthOrArrayLayers: 6}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImageCopyBuffer(&tt.layout, &tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateImageCopyBuffer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -43,6 +43,16 @@
 //	type ShaderModule uintptr
 //	type RenderPipeline uintptr
 //
+// # Queues
+//
+// Each device has a single queue, returned by GetQueue, that runs
+// uploads, copies, compute and rendering in submission order. WebGPU
+// and wgpu-native expose no separate transfer queue, and neither does
+// the native backend yet, so large uploads are best split across frames
+// rather than moved to another queue; gogpu.TransferQueue does so, with
+// fences on submission indices. WriteBuffer and WriteTexture copy the
+// data right away and return without waiting for the GPU.
+//
 // # Subpackages
 //
 //   - gpu/backend/rust: Rust backend using go-webgpu/webgpu
@@ -0,0 +1,90 @@
+package gpu
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// ErrErrorScopeEmpty is returned by PopErrorScope without a pushed scope.
+var ErrErrorScopeEmpty = errors.New("gpu: error scope stack is empty")
+
+// ErrorScopes is the error scope stack of a device. Backends report each
+// device error to it; the innermost scope with a matching filter captures
+// the error, and errors no scope captures go to the uncaptured-error
+// callback. It is safe for concurrent use.
+type ErrorScopes struct {
+	mu         sync.Mutex
+	scopes     []errorScope
+	uncaptured func(err error)
+}
+
+// errorScope is one pushed scope and the first error it captured.
+type errorScope struct {
+	filter types.ErrorFilter
+	err    *types.Error
+}
+
+// Push opens a scope capturing errors of the filter.
+func (s *ErrorScopes) Push(filter types.ErrorFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scopes = append(s.scopes, errorScope{filter: filter})
+}
+
+// Pop closes the innermost scope and returns the first error it captured,
+// or nil. Returns ErrErrorScopeEmpty if no scope is open.
+func (s *ErrorScopes) Pop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.scopes) == 0 {
+		return ErrErrorScopeEmpty
+	}
+	scope := s.scopes[len(s.scopes)-1]
+	s.scopes = s.scopes[:len(s.scopes)-1]
+
+	if scope.err == nil {
+		return nil
+	}
+	return scope.err
+}
+
+// SetUncapturedCallback sets the function called with errors no scope
+// captures. nil drops them.
+func (s *ErrorScopes) SetUncapturedCallback(callback func(err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uncaptured = callback
+}
+
+// Uncaptured reports whether an error reported now would go to the
+// uncaptured-error callback: one is set and no scope is open.
+func (s *ErrorScopes) Uncaptured() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.uncaptured != nil && len(s.scopes) == 0
+}
+
+// Report records a device error. Only the first error of a scope is
+// kept, as in WebGPU.
+func (s *ErrorScopes) Report(err *types.Error) {
+	s.mu.Lock()
+	for i := len(s.scopes) - 1; i >= 0; i-- {
+		if s.scopes[i].filter == err.Type {
+			if s.scopes[i].err == nil {
+				s.scopes[i].err = err
+			}
+			s.mu.Unlock()
+			return
+		}
+	}
+	callback := s.uncaptured
+	s.mu.Unlock()
+
+	// Called without the lock, so the callback may push or pop scopes
+	if callback != nil {
+		callback(err)
+	}
+}
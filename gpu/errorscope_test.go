@@ -0,0 +1,57 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestErrorScopes(t *testing.T) {
+	var s ErrorScopes
+	var uncaptured []error
+	s.SetUncapturedCallback(func(err error) {
+		uncaptured = append(uncaptured, err)
+	})
+
+	validation := &types.Error{Type: types.ErrorFilterValidation, Message: "first"}
+	second := &types.Error{Type: types.ErrorFilterValidation, Message: "second"}
+	oom := &types.Error{Type: types.ErrorFilterOutOfMemory, Message: "oom"}
+
+	s.Push(types.ErrorFilterValidation)
+	s.Push(types.ErrorFilterOutOfMemory)
+	if s.Uncaptured() {
+		t.Error("Uncaptured() = true with open scopes")
+	}
+	s.Report(validation) // Captured by the outer scope
+	s.Report(second)     // Dropped: the scope already has an error
+
+	if err := s.Pop(); err != nil {
+		t.Errorf("Pop() of out-of-memory scope = %v, want nil", err)
+	}
+	if err := s.Pop(); err != validation {
+		t.Errorf("Pop() of validation scope = %v, want %v", err, validation)
+	}
+	if len(uncaptured) != 0 {
+		t.Errorf("uncaptured errors = %v, want none", uncaptured)
+	}
+
+	if !s.Uncaptured() {
+		t.Error("Uncaptured() = false with a callback and no scopes")
+	}
+	s.Report(oom)
+	if len(uncaptured) != 1 || uncaptured[0] != oom {
+		t.Errorf("uncaptured errors = %v, want [%v]", uncaptured, oom)
+	}
+
+	if err := s.Pop(); !errors.Is(err, ErrErrorScopeEmpty) {
+		t.Errorf("Pop() of empty stack = %v, want ErrErrorScopeEmpty", err)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := &types.Error{Type: types.ErrorFilterValidation, Message: "invalid pipeline"}
+	if got, want := err.Error(), "gpu: validation error: invalid pipeline"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
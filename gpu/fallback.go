@@ -0,0 +1,17 @@
+package gpu
+
+// Rejection records why a backend, or a graphics API within a backend,
+// was not used.
+type Rejection struct {
+	Name   string // Backend name, e.g. "rust", or "native/vulkan" for a graphics API
+	Reason error
+}
+
+// RejectionReporter is implemented by backends that choose between
+// several graphics APIs, such as the native backend trying Vulkan before
+// OpenGL.
+type RejectionReporter interface {
+	// Rejections returns the graphics APIs tried and rejected before the
+	// one in use, in order.
+	Rejections() []Rejection
+}
@@ -0,0 +1,16 @@
+package gpu
+
+// DefaultFramesInFlight is the number of frames the CPU may record
+// ahead of the GPU unless configured otherwise: one being rendered while
+// the next is recorded.
+const DefaultFramesInFlight = 2
+
+// FramePacer is implemented by backends that pace frames themselves,
+// such as the native backend. Other backends leave pacing to the driver.
+type FramePacer interface {
+	// SetMaxFramesInFlight sets how many presented frames may be pending
+	// on the GPU before GetCurrentTexture waits for the oldest, at
+	// least 1. More frames smooth out uneven frame times at the cost of
+	// latency.
+	SetMaxFramesInFlight(n int)
+}
@@ -0,0 +1,268 @@
+package gpu
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/gogpu/gogpu/gmath"
+)
+
+// MemoryLayout selects the rules for packing Go values into buffer
+// memory as shaders read it.
+type MemoryLayout uint8
+
+const (
+	// LayoutStd140 packs values for uniform buffers: arrays have a
+	// stride of a multiple of 16 bytes, and structs are 16-byte aligned.
+	LayoutStd140 MemoryLayout = iota
+
+	// LayoutStd430 packs values for storage buffers, with array strides
+	// and struct alignment only as large as their elements need.
+	LayoutStd430
+)
+
+// String returns the layout name.
+func (l MemoryLayout) String() string {
+	switch l {
+	case LayoutStd140:
+		return "std140"
+	case LayoutStd430:
+		return "std430"
+	default:
+		return fmt.Sprintf("MemoryLayout(%d)", uint8(l))
+	}
+}
+
+// ErrUnsupportedType is returned when a Go type has no shader
+// counterpart. Supported are float32, int32 and uint32, gmath.Vec2, Vec3,
+// Vec4, Color and Mat4, fixed-size arrays and structs of these.
+var ErrUnsupportedType = errors.New("gpu: type cannot be packed into a buffer")
+
+// typeLayout is the size and alignment of a type in a memory layout.
+type typeLayout struct {
+	size  uint64
+	align uint64
+
+	// Arrays: distance between elements and their layout
+	stride uint64
+	elem   *typeLayout
+
+	// Structs: offset and layout of each field
+	offsets []uint64
+	fields  []*typeLayout
+}
+
+// scalarLayout is the layout of float32, int32 and uint32.
+var scalarLayout = &typeLayout{size: 4, align: 4}
+
+// layoutKey identifies a cached typeLayout.
+type layoutKey struct {
+	layout MemoryLayout
+	typ    reflect.Type
+}
+
+// layoutCache holds the typeLayout of each packed type, as *typeLayout.
+var layoutCache sync.Map
+
+var (
+	vec2Type  = reflect.TypeFor[gmath.Vec2]()
+	vec3Type  = reflect.TypeFor[gmath.Vec3]()
+	vec4Type  = reflect.TypeFor[gmath.Vec4]()
+	colorType = reflect.TypeFor[gmath.Color]()
+	mat4Type  = reflect.TypeFor[gmath.Mat4]()
+)
+
+// SizeOf returns the number of bytes a value of type t takes in layout.
+// For slices it is the array stride, the size of each element.
+func SizeOf(layout MemoryLayout, t reflect.Type) (uint64, error) {
+	if t.Kind() == reflect.Slice {
+		elem, err := layoutOf(layout, t.Elem())
+		if err != nil {
+			return 0, err
+		}
+		return arrayStride(layout, elem), nil
+	}
+	tl, err := layoutOf(layout, t)
+	if err != nil {
+		return 0, err
+	}
+	return tl.size, nil
+}
+
+// Pack returns v in layout, ready for WriteBuffer. v is a value of a
+// supported type, a pointer to one, or a slice of them, which is packed
+// like a runtime-sized array. Padding bytes are zero.
+func Pack(layout MemoryLayout, v any) ([]byte, error) {
+	return AppendPacked(nil, layout, v)
+}
+
+// AppendPacked appends v in layout to dst, like Pack. The packed value
+// starts at len(dst), which should be aligned for it.
+func AppendPacked(dst []byte, layout MemoryLayout, v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return dst, fmt.Errorf("gpu: cannot pack a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return dst, fmt.Errorf("%w: nil", ErrUnsupportedType)
+	}
+
+	if rv.Kind() == reflect.Slice {
+		elem, err := layoutOf(layout, rv.Type().Elem())
+		if err != nil {
+			return dst, err
+		}
+		stride := arrayStride(layout, elem)
+		start := len(dst)
+		dst = append(dst, make([]byte, stride*uint64(rv.Len()))...)
+		for i := range rv.Len() {
+			encodeValue(dst[start+i*int(stride):], rv.Index(i), elem)
+		}
+		return dst, nil
+	}
+
+	tl, err := layoutOf(layout, rv.Type())
+	if err != nil {
+		return dst, err
+	}
+	start := len(dst)
+	dst = append(dst, make([]byte, tl.size)...)
+	encodeValue(dst[start:], rv, tl)
+	return dst, nil
+}
+
+// layoutOf returns the cached layout of t, computing it the first time.
+func layoutOf(layout MemoryLayout, t reflect.Type) (*typeLayout, error) {
+	key := layoutKey{layout, t}
+	if tl, ok := layoutCache.Load(key); ok {
+		return tl.(*typeLayout), nil
+	}
+	tl, err := computeLayout(layout, t)
+	if err != nil {
+		return nil, err
+	}
+	layoutCache.Store(key, tl)
+	return tl, nil
+}
+
+// computeLayout returns the layout of t following the WGSL rules for
+// the uniform (std140) and storage (std430) address spaces.
+func computeLayout(layout MemoryLayout, t reflect.Type) (*typeLayout, error) {
+	switch t {
+	case vec2Type:
+		return vectorLayout(8, 8, 2), nil
+	case vec3Type:
+		return vectorLayout(12, 16, 3), nil
+	case vec4Type, colorType:
+		return vectorLayout(16, 16, 4), nil
+	case mat4Type:
+		return &typeLayout{size: 64, align: 16, stride: 4, elem: scalarLayout}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32:
+		return scalarLayout, nil
+
+	case reflect.Array:
+		if t.Len() == 0 {
+			return nil, fmt.Errorf("%w: empty array %s", ErrUnsupportedType, t)
+		}
+		elem, err := layoutOf(layout, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		stride := arrayStride(layout, elem)
+		return &typeLayout{
+			size:   stride * uint64(t.Len()),
+			align:  structAlign(layout, elem.align),
+			stride: stride,
+			elem:   elem,
+		}, nil
+
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return nil, fmt.Errorf("%w: empty struct %s", ErrUnsupportedType, t)
+		}
+		tl := &typeLayout{
+			align:   1,
+			offsets: make([]uint64, t.NumField()),
+			fields:  make([]*typeLayout, t.NumField()),
+		}
+		var offset uint64
+		for i := range t.NumField() {
+			field := t.Field(i)
+			fl, err := layoutOf(layout, field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t, field.Name, err)
+			}
+			offset = alignUp(offset, fl.align)
+			tl.offsets[i] = offset
+			tl.fields[i] = fl
+			offset += fl.size
+			tl.align = max(tl.align, fl.align)
+		}
+		tl.align = structAlign(layout, tl.align)
+		tl.size = alignUp(offset, tl.align)
+		return tl, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+// vectorLayout returns the layout of a gmath vector of n float32
+// components.
+func vectorLayout(size, align uint64, n int) *typeLayout {
+	tl := &typeLayout{size: size, align: align}
+	for i := range n {
+		tl.offsets = append(tl.offsets, uint64(i)*4) //nolint:gosec // G115: i is at most 3
+		tl.fields = append(tl.fields, scalarLayout)
+	}
+	return tl
+}
+
+// arrayStride returns the distance between array elements.
+func arrayStride(layout MemoryLayout, elem *typeLayout) uint64 {
+	return alignUp(elem.size, structAlign(layout, elem.align))
+}
+
+// structAlign returns the alignment of a struct or array whose members
+// need align; std140 rounds it up to 16 bytes.
+func structAlign(layout MemoryLayout, align uint64) uint64 {
+	if layout == LayoutStd140 {
+		return alignUp(align, 16)
+	}
+	return align
+}
+
+// alignUp rounds n up to a multiple of align.
+func alignUp(n, align uint64) uint64 {
+	return (n + align - 1) / align * align
+}
+
+// encodeValue writes v, whose layout is tl, to the start of b.
+func encodeValue(b []byte, v reflect.Value, tl *typeLayout) {
+	switch v.Kind() {
+	case reflect.Float32:
+		binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v.Float())))
+	case reflect.Int32:
+		binary.LittleEndian.PutUint32(b, uint32(v.Int())) //nolint:gosec // G115: two's complement bits are intended
+	case reflect.Uint32:
+		binary.LittleEndian.PutUint32(b, uint32(v.Uint()))
+	case reflect.Array:
+		for i := range v.Len() {
+			encodeValue(b[uint64(i)*tl.stride:], v.Index(i), tl.elem) //nolint:gosec // G115: i is non-negative
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			encodeValue(b[tl.offsets[i]:], v.Field(i), tl.fields[i])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package gpu
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gogpu/gogpu/gmath"
+)
+
+type lightUniform struct {
+	Position  gmath.Vec3
+	Intensity float32
+	Color     gmath.Color
+	Weights   [3]float32
+	Count     uint32
+}
+
+func TestSizeOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout MemoryLayout
+		typ    reflect.Type
+		want   uint64
+	}{
+		{"scalar", LayoutStd430, reflect.TypeFor[float32](), 4},
+		{"vec3", LayoutStd140, reflect.TypeFor[gmath.Vec3](), 12},
+		{"mat4", LayoutStd140, reflect.TypeFor[gmath.Mat4](), 64},
+		{"float array std140", LayoutStd140, reflect.TypeFor[[4]float32](), 64},
+		{"float array std430", LayoutStd430, reflect.TypeFor[[4]float32](), 16},
+		{"vec3 array std430", LayoutStd430, reflect.TypeFor[[2]gmath.Vec3](), 32},
+		// Position 0, Intensity 12, Color 16, Weights 32 (stride 16), Count 80
+		{"struct std140", LayoutStd140, reflect.TypeFor[lightUniform](), 96},
+		// Weights 32 (stride 4), Count 44
+		{"struct std430", LayoutStd430, reflect.TypeFor[lightUniform](), 48},
+		{"slice element", LayoutStd430, reflect.TypeFor[[]gmath.Vec3](), 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SizeOf(tt.layout, tt.typ)
+			if err != nil {
+				t.Fatalf("SizeOf: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SizeOf(%v, %v) = %d, want %d", tt.layout, tt.typ, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPack(t *testing.T) {
+	light := lightUniform{
+		Position:  gmath.Vec3{X: 1, Y: 2, Z: 3},
+		Intensity: 4,
+		Color:     gmath.Color{R: 0.5, A: 1},
+		Weights:   [3]float32{5, 6, 7},
+		Count:     8,
+	}
+
+	data, err := Pack(LayoutStd140, &light)
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(data) != 96 {
+		t.Fatalf("len = %d, want 96", len(data))
+	}
+
+	float := func(offset int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))
+	}
+	for offset, want := range map[int]float32{0: 1, 4: 2, 8: 3, 12: 4, 16: 0.5, 28: 1, 32: 5, 48: 6, 64: 7} {
+		if got := float(offset); got != want {
+			t.Errorf("float at %d = %g, want %g", offset, got, want)
+		}
+	}
+	if got := binary.LittleEndian.Uint32(data[80:]); got != 8 {
+		t.Errorf("Count = %d, want 8", got)
+	}
+	if got := float(36); got != 0 {
+		t.Errorf("padding at 36 = %g, want 0", got)
+	}
+}
+
+func TestPackSlice(t *testing.T) {
+	data, err := Pack(LayoutStd430, []gmath.Vec3{{X: 1}, {X: 2}})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(data) != 32 {
+		t.Fatalf("len = %d, want 32", len(data))
+	}
+	if got := math.Float32frombits(binary.LittleEndian.Uint32(data[16:])); got != 2 {
+		t.Errorf("second element X = %g, want 2", got)
+	}
+
+	data, err = AppendPacked(data, LayoutStd430, int32(-1))
+	if err != nil {
+		t.Fatalf("AppendPacked: %v", err)
+	}
+	if len(data) != 36 || binary.LittleEndian.Uint32(data[32:]) != math.MaxUint32 {
+		t.Errorf("AppendPacked int32(-1) = %v", data[32:])
+	}
+}
+
+func TestPackUnsupported(t *testing.T) {
+	type withBool struct {
+		Enabled bool
+	}
+	tests := []any{
+		float64(1),
+		withBool{},
+		struct{}{},
+		[0]float32{},
+		"text",
+	}
+	for _, v := range tests {
+		if _, err := Pack(LayoutStd140, v); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Pack(%T) error = %v, want ErrUnsupportedType", v, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gpu
+
+import "fmt"
+
+// Leak is a resource that was still alive when its backend was
+// destroyed, with the stack that created it.
+type Leak struct {
+	Kind   string // Resource type, e.g. "texture"
+	Handle uintptr
+	Stack  string // Created at, innermost call first
+}
+
+// String returns the leak with its creation stack.
+func (l Leak) String() string {
+	return fmt.Sprintf("%s %d was not released, created at:\n%s", l.Kind, l.Handle, l.Stack)
+}
+
+// LeakDetector is implemented by backends that can report the resources
+// never released, such as the native backend. Recording the creation
+// stacks costs time for every resource, so it is meant for development.
+type LeakDetector interface {
+	// EnableLeakDetection records where resources are created from now
+	// on. Destroy calls report with the resources not released since,
+	// if there are any. Instances, adapters, devices and surfaces end
+	// with the backend and are not reported.
+	EnableLeakDetection(report func(leaks []Leak))
+}
@@ -0,0 +1,163 @@
+package gpu
+
+import (
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// MemoryStats is the GPU memory taken by the textures and buffers of a
+// device. Sizes are estimated from the resource descriptors; drivers add
+// padding and alignment, and surface textures are not counted.
+type MemoryStats struct {
+	Textures     int    // Live textures
+	TextureBytes uint64 // Estimated size of the live textures
+	Buffers      int    // Live buffers
+	BufferBytes  uint64 // Size of the live buffers
+
+	// Budget is the memory the adapter reports as available to the
+	// application, or 0 if it reports none.
+	Budget uint64
+}
+
+// Total returns the bytes taken by textures and buffers.
+func (s MemoryStats) Total() uint64 {
+	return s.TextureBytes + s.BufferBytes
+}
+
+// NearBudget reports whether Total has reached 90% of a known Budget.
+// Going over the budget makes allocations fail or, on some drivers,
+// slows every frame as memory is paged.
+func (s MemoryStats) NearBudget() bool {
+	return s.Budget != 0 && s.Total() >= s.Budget-s.Budget/10
+}
+
+// MemoryReporter is implemented by backends that account for the memory
+// of their resources, such as the native backend.
+type MemoryReporter interface {
+	// MemoryStats returns the memory taken by the resources of device.
+	MemoryStats(device types.Device) MemoryStats
+
+	// SetMemoryWarningCallback sets the function called when an
+	// allocation brings device near its budget, see
+	// MemoryStats.NearBudget. It is called again only after the memory
+	// in use fell below the threshold.
+	SetMemoryWarningCallback(device types.Device, callback func(stats MemoryStats))
+}
+
+// MemoryTracker accounts for the memory of the resources of one device,
+// for backends implementing MemoryReporter. It is safe for concurrent
+// use.
+type MemoryTracker struct {
+	mu     sync.Mutex
+	stats  MemoryStats
+	warned bool // Whether the current excess of the budget was reported
+	warn   func(stats MemoryStats)
+}
+
+// SetBudget sets the budget reported in MemoryStats.
+func (t *MemoryTracker) SetBudget(budget uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats.Budget = budget
+}
+
+// SetWarningCallback sets the function called when an allocation brings
+// the memory in use near the budget.
+func (t *MemoryTracker) SetWarningCallback(callback func(stats MemoryStats)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.warn = callback
+}
+
+// Stats returns the memory in use.
+func (t *MemoryTracker) Stats() MemoryStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// AddTexture accounts for a created texture of bytes.
+func (t *MemoryTracker) AddTexture(bytes uint64) {
+	t.update(func(s *MemoryStats) {
+		s.Textures++
+		s.TextureBytes += bytes
+	})
+}
+
+// RemoveTexture accounts for a released texture of bytes.
+func (t *MemoryTracker) RemoveTexture(bytes uint64) {
+	t.update(func(s *MemoryStats) {
+		s.Textures--
+		s.TextureBytes -= min(bytes, s.TextureBytes)
+	})
+}
+
+// AddBuffer accounts for a created buffer of bytes.
+func (t *MemoryTracker) AddBuffer(bytes uint64) {
+	t.update(func(s *MemoryStats) {
+		s.Buffers++
+		s.BufferBytes += bytes
+	})
+}
+
+// RemoveBuffer accounts for a released buffer of bytes.
+func (t *MemoryTracker) RemoveBuffer(bytes uint64) {
+	t.update(func(s *MemoryStats) {
+		s.Buffers--
+		s.BufferBytes -= min(bytes, s.BufferBytes)
+	})
+}
+
+// update applies fn to the stats and calls the warning callback, outside
+// the lock, when they reach the budget.
+func (t *MemoryTracker) update(fn func(s *MemoryStats)) {
+	t.mu.Lock()
+	fn(&t.stats)
+	near := t.stats.NearBudget()
+	var warn func(stats MemoryStats)
+	if near && !t.warned {
+		warn = t.warn
+	}
+	t.warned = near
+	stats := t.stats
+	t.mu.Unlock()
+
+	if warn != nil {
+		warn(stats)
+	}
+}
+
+// TextureMemorySize estimates the bytes a texture created with desc
+// takes: all mip levels, array layers and samples.
+func TextureMemorySize(desc *types.TextureDescriptor) uint64 {
+	width := uint64(max(desc.Size.Width, 1))
+	height := uint64(max(desc.Size.Height, 1))
+	layers := uint64(max(desc.Size.DepthOrArrayLayers, 1))
+	texel := uint64(texelSize(desc.Format)) * uint64(max(desc.SampleCount, 1))
+
+	var size uint64
+	for range max(desc.MipLevelCount, 1) {
+		size += width * height * layers * texel
+		width = max(width/2, 1)
+		height = max(height/2, 1)
+		if desc.Dimension == types.TextureDimension3D {
+			layers = max(layers/2, 1)
+		}
+	}
+	return size
+}
+
+// texelSize returns the bytes of one texel of format.
+func texelSize(format types.TextureFormat) uint32 {
+	switch format {
+	case types.TextureFormatStencil8:
+		return 1
+	case types.TextureFormatDepth16Unorm:
+		return 2
+	case types.TextureFormatRGBA16Float, types.TextureFormatDepth32FloatStencil8:
+		return 8
+	default:
+		return 4
+	}
+}
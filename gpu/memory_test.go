@@ -0,0 +1,55 @@
+package gpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestTextureMemorySize(t *testing.T) {
+	tests := []struct {
+		name string
+		desc types.TextureDescriptor
+		want uint64
+	}{
+		{"rgba8", types.TextureDescriptor{Size: types.Extent3D{Width: 4, Height: 4}, Format: types.TextureFormatRGBA8Unorm}, 64},
+		{"mips", types.TextureDescriptor{Size: types.Extent3D{Width: 4, Height: 2}, MipLevelCount: 3, Format: types.TextureFormatRGBA8Unorm}, 32 + 8 + 4},
+		{"msaa", types.TextureDescriptor{Size: types.Extent3D{Width: 2, Height: 2}, SampleCount: 4, Format: types.TextureFormatRGBA16Float}, 128},
+		{"layers", types.TextureDescriptor{Size: types.Extent3D{Width: 2, Height: 2, DepthOrArrayLayers: 6}, Format: types.TextureFormatDepth16Unorm}, 48},
+	}
+	for _, tt := range tests {
+		if got := TextureMemorySize(&tt.desc); got != tt.want {
+			t.Errorf("%s: size = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryTrackerWarning(t *testing.T) {
+	var tracker MemoryTracker
+	tracker.SetBudget(1000)
+	var warnings []MemoryStats
+	tracker.SetWarningCallback(func(stats MemoryStats) {
+		warnings = append(warnings, stats)
+	})
+
+	tracker.AddTexture(500)
+	tracker.AddBuffer(400)
+	if len(warnings) != 1 || warnings[0].Total() != 900 {
+		t.Fatalf("warnings = %+v, want one at 900 bytes", warnings)
+	}
+	tracker.AddBuffer(50)
+	if len(warnings) != 1 {
+		t.Errorf("warned again while above the threshold: %+v", warnings)
+	}
+
+	tracker.RemoveTexture(500)
+	tracker.AddTexture(500)
+	if len(warnings) != 2 {
+		t.Errorf("no warning after going below and back above the threshold: %+v", warnings)
+	}
+
+	stats := tracker.Stats()
+	if stats.Textures != 1 || stats.Buffers != 2 || stats.Total() != 950 {
+		t.Errorf("stats = %+v, want 1 texture, 2 buffers, 950 bytes", stats)
+	}
+}
@@ -0,0 +1,105 @@
+package gpu
+
+import "github.com/gogpu/gogpu/gpu/types"
+
+// MultiDrawIndirect issues count indirect draws whose arguments
+// (types.DrawIndirectArgs) are read from buffer, the first at offset and
+// each next one stride bytes after the previous. A stride of 0 means
+// tightly packed arguments.
+//
+// The draws are emulated with one DrawIndirect each, which needs no
+// device feature and still keeps the arguments on the GPU.
+func MultiDrawIndirect(b Backend, pass types.RenderPass, buffer types.Buffer, offset uint64, count uint32, stride uint64) {
+	if stride == 0 {
+		stride = types.DrawIndirectArgsSize
+	}
+	for i := uint64(0); i < uint64(count); i++ {
+		b.DrawIndirect(pass, buffer, offset+i*stride)
+	}
+}
+
+// MultiDrawIndexedIndirect issues count indirect indexed draws whose
+// arguments (types.DrawIndexedIndirectArgs) are read from buffer, like
+// MultiDrawIndirect.
+func MultiDrawIndexedIndirect(b Backend, pass types.RenderPass, buffer types.Buffer, offset uint64, count uint32, stride uint64) {
+	if stride == 0 {
+		stride = types.DrawIndexedIndirectArgsSize
+	}
+	for i := uint64(0); i < uint64(count); i++ {
+		b.DrawIndexedIndirect(pass, buffer, offset+i*stride)
+	}
+}
+
+// IndirectCountDrawer is implemented by backends that can read the draw
+// count of a multi-draw from a GPU buffer, on devices with
+// types.FeatureMultiDrawIndirectCount. Arguments are tightly packed.
+type IndirectCountDrawer interface {
+	MultiDrawIndirectCount(pass types.RenderPass, buffer types.Buffer, offset uint64, countBuffer types.Buffer, countOffset uint64, maxCount uint32)
+	MultiDrawIndexedIndirectCount(pass types.RenderPass, buffer types.Buffer, offset uint64, countBuffer types.Buffer, countOffset uint64, maxCount uint32)
+}
+
+// MultiDrawIndirectCount issues up to maxCount indirect draws whose
+// tightly packed arguments are read from buffer at offset, with the
+// number of draws read as a uint32 from countBuffer at countOffset. A
+// culling compute pass can thus decide entirely on the GPU what to draw.
+//
+// Without FeatureMultiDrawIndirectCount on device, all maxCount draws are
+// issued with MultiDrawIndirect and the count is ignored: draws past it
+// must have an InstanceCount of 0, so they draw nothing. Either have the
+// culling pass write all maxCount arguments, or run IndirectCountClampWGSL
+// after it.
+func MultiDrawIndirectCount(b Backend, device types.Device, pass types.RenderPass, buffer types.Buffer, offset uint64, countBuffer types.Buffer, countOffset uint64, maxCount uint32) {
+	if d, ok := indirectCountDrawer(b, device); ok {
+		d.MultiDrawIndirectCount(pass, buffer, offset, countBuffer, countOffset, maxCount)
+		return
+	}
+	MultiDrawIndirect(b, pass, buffer, offset, maxCount, 0)
+}
+
+// MultiDrawIndexedIndirectCount issues up to maxCount indirect indexed
+// draws, like MultiDrawIndirectCount.
+func MultiDrawIndexedIndirectCount(b Backend, device types.Device, pass types.RenderPass, buffer types.Buffer, offset uint64, countBuffer types.Buffer, countOffset uint64, maxCount uint32) {
+	if d, ok := indirectCountDrawer(b, device); ok {
+		d.MultiDrawIndexedIndirectCount(pass, buffer, offset, countBuffer, countOffset, maxCount)
+		return
+	}
+	MultiDrawIndexedIndirect(b, pass, buffer, offset, maxCount, 0)
+}
+
+// indirectCountDrawer returns b as an IndirectCountDrawer if device can
+// read draw counts from buffers.
+func indirectCountDrawer(b Backend, device types.Device) (IndirectCountDrawer, bool) {
+	d, ok := b.(IndirectCountDrawer)
+	if !ok || !b.GetDeviceFeatures(device).Has(types.FeatureMultiDrawIndirectCount) {
+		return nil, false
+	}
+	return d, true
+}
+
+// IndirectCountClampWGSL is a compute shader for the emulation in
+// MultiDrawIndirectCount: it sets the instance count of every draw at or
+// past the draw count to 0. Bind the tightly packed arguments at binding
+// 0 and the count at binding 1 of group 0, and dispatch
+// ceil(maxCount / 64) workgroups of entry point "clamp_draws", or
+// "clamp_indexed_draws" for indexed arguments.
+const IndirectCountClampWGSL = `
+@group(0) @binding(0) var<storage, read_write> args: array<u32>;
+@group(0) @binding(1) var<storage, read> draw_count: u32;
+
+// Word 1 is the instance count in both argument layouts.
+fn clamp_draw(i: u32, stride: u32) {
+	if i >= draw_count && (i + 1u) * stride <= arrayLength(&args) {
+		args[i * stride + 1u] = 0u;
+	}
+}
+
+@compute @workgroup_size(64)
+fn clamp_draws(@builtin(global_invocation_id) id: vec3<u32>) {
+	clamp_draw(id.x, 4u);
+}
+
+@compute @workgroup_size(64)
+fn clamp_indexed_draws(@builtin(global_invocation_id) id: vec3<u32>) {
+	clamp_draw(id.x, 5u);
+}
+`
@@ -0,0 +1,86 @@
+package gpu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// indirectRecorder records the offsets of indirect draws.
+type indirectRecorder struct {
+	mockBackend
+	draws, indexedDraws []uint64
+}
+
+func (r *indirectRecorder) DrawIndirect(_ types.RenderPass, _ types.Buffer, offset uint64) {
+	r.draws = append(r.draws, offset)
+}
+
+func (r *indirectRecorder) DrawIndexedIndirect(_ types.RenderPass, _ types.Buffer, offset uint64) {
+	r.indexedDraws = append(r.indexedDraws, offset)
+}
+
+func TestMultiDrawIndirect(t *testing.T) {
+	r := &indirectRecorder{}
+
+	MultiDrawIndirect(r, 1, 1, 32, 3, 0)
+	if want := []uint64{32, 48, 64}; !slices.Equal(r.draws, want) {
+		t.Errorf("packed draw offsets = %v, want %v", r.draws, want)
+	}
+
+	r.draws = nil
+	MultiDrawIndirect(r, 1, 1, 0, 2, 64)
+	if want := []uint64{0, 64}; !slices.Equal(r.draws, want) {
+		t.Errorf("strided draw offsets = %v, want %v", r.draws, want)
+	}
+
+	MultiDrawIndexedIndirect(r, 1, 1, 0, 3, 0)
+	if want := []uint64{0, 20, 40}; !slices.Equal(r.indexedDraws, want) {
+		t.Errorf("indexed draw offsets = %v, want %v", r.indexedDraws, want)
+	}
+}
+
+// countRecorder is an indirectRecorder that can read draw counts from
+// buffers.
+type countRecorder struct {
+	indirectRecorder
+	features  types.Features
+	maxCounts []uint32
+}
+
+func (r *countRecorder) GetDeviceFeatures(types.Device) types.Features { return r.features }
+
+func (r *countRecorder) MultiDrawIndirectCount(_ types.RenderPass, _ types.Buffer, _ uint64, _ types.Buffer, _ uint64, maxCount uint32) {
+	r.maxCounts = append(r.maxCounts, maxCount)
+}
+
+func (r *countRecorder) MultiDrawIndexedIndirectCount(_ types.RenderPass, _ types.Buffer, _ uint64, _ types.Buffer, _ uint64, maxCount uint32) {
+	r.maxCounts = append(r.maxCounts, maxCount)
+}
+
+func TestMultiDrawIndirectCount(t *testing.T) {
+	r := &countRecorder{features: types.FeatureMultiDrawIndirectCount}
+	MultiDrawIndirectCount(r, 1, 1, 1, 0, 2, 0, 8)
+	MultiDrawIndexedIndirectCount(r, 1, 1, 1, 0, 2, 0, 4)
+	if want := []uint32{8, 4}; !slices.Equal(r.maxCounts, want) {
+		t.Errorf("native max counts = %v, want %v", r.maxCounts, want)
+	}
+	if len(r.draws) != 0 || len(r.indexedDraws) != 0 {
+		t.Error("draws emulated although the device has the feature")
+	}
+
+	// Without the feature every draw up to maxCount is issued
+	r = &countRecorder{}
+	MultiDrawIndirectCount(r, 1, 1, 1, 16, 2, 0, 3)
+	if want := []uint64{16, 32, 48}; !slices.Equal(r.draws, want) {
+		t.Errorf("emulated draw offsets = %v, want %v", r.draws, want)
+	}
+	MultiDrawIndexedIndirectCount(r, 1, 1, 1, 0, 2, 0, 2)
+	if want := []uint64{0, 20}; !slices.Equal(r.indexedDraws, want) {
+		t.Errorf("emulated indexed draw offsets = %v, want %v", r.indexedDraws, want)
+	}
+	if len(r.maxCounts) != 0 {
+		t.Error("native count draw used without the feature")
+	}
+}
@@ -0,0 +1,247 @@
+package gpu
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// PipelineCache creates shader modules and pipelines of a device once per
+// distinct source or descriptor and returns the same handle afterwards.
+// Descriptors are keyed by an encoding of all fields that affect the
+// pipeline, so distinct descriptors never share an entry; labels are
+// ignored. It is safe for concurrent use.
+type PipelineCache struct {
+	backend Backend
+	device  types.Device
+
+	mu      sync.Mutex
+	shaders map[string]types.ShaderModule
+	render  map[string]types.RenderPipeline
+	compute map[string]types.ComputePipeline
+	hits    uint64
+	misses  uint64
+}
+
+// NewPipelineCache creates an empty pipeline cache for the device.
+func NewPipelineCache(backend Backend, device types.Device) *PipelineCache {
+	return &PipelineCache{
+		backend: backend,
+		device:  device,
+		shaders: make(map[string]types.ShaderModule),
+		render:  make(map[string]types.RenderPipeline),
+		compute: make(map[string]types.ComputePipeline),
+	}
+}
+
+// ShaderModule returns the shader module compiled from WGSL code.
+func (c *PipelineCache) ShaderModule(code string) (types.ShaderModule, error) {
+	var w keyWriter
+	w.string("wgsl")
+	w.string(code)
+	key := w.key()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if module, ok := c.shaders[key]; ok {
+		c.hits++
+		return module, nil
+	}
+	c.misses++
+
+	module, err := c.backend.CreateShaderModuleWGSL(c.device, code)
+	if err != nil {
+		return 0, err
+	}
+	c.shaders[key] = module
+	return module, nil
+}
+
+// ShaderModuleSPIRV returns the shader module created from a SPIR-V
+// binary, see ParseSPIRV.
+func (c *PipelineCache) ShaderModuleSPIRV(code []byte) (types.ShaderModule, error) {
+	words, err := ParseSPIRV(code)
+	if err != nil {
+		return 0, err
+	}
+
+	var w keyWriter
+	w.string("spirv")
+	w.string(string(code))
+	key := w.key()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if module, ok := c.shaders[key]; ok {
+		c.hits++
+		return module, nil
+	}
+	c.misses++
+
+	module, err := c.backend.CreateShaderModuleSPIRV(c.device, words)
+	if err != nil {
+		return 0, err
+	}
+	c.shaders[key] = module
+	return module, nil
+}
+
+// RenderPipeline returns the render pipeline for the descriptor.
+func (c *PipelineCache) RenderPipeline(desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
+	key := renderPipelineKey(desc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pipeline, ok := c.render[key]; ok {
+		c.hits++
+		return pipeline, nil
+	}
+	c.misses++
+
+	pipeline, err := c.backend.CreateRenderPipeline(c.device, desc)
+	if err != nil {
+		return 0, err
+	}
+	c.render[key] = pipeline
+	return pipeline, nil
+}
+
+// ComputePipeline returns the compute pipeline for the descriptor.
+func (c *PipelineCache) ComputePipeline(desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	key := computePipelineKey(desc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if pipeline, ok := c.compute[key]; ok {
+		c.hits++
+		return pipeline, nil
+	}
+	c.misses++
+
+	pipeline, err := c.backend.CreateComputePipeline(c.device, desc)
+	if err != nil {
+		return 0, err
+	}
+	c.compute[key] = pipeline
+	return pipeline, nil
+}
+
+// Stats returns how many lookups found a cached object and how many
+// created one.
+func (c *PipelineCache) Stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Release releases the cached pipelines and shader modules and forgets
+// them.
+func (c *PipelineCache) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, pipeline := range c.render {
+		c.backend.ReleaseRenderPipeline(pipeline)
+	}
+	for _, pipeline := range c.compute {
+		c.backend.ReleaseComputePipeline(pipeline)
+	}
+	for _, module := range c.shaders {
+		c.backend.ReleaseShaderModule(module)
+	}
+	clear(c.shaders)
+	clear(c.render)
+	clear(c.compute)
+}
+
+// renderPipelineKey returns the cache key of the fields of desc that
+// affect the created pipeline.
+func renderPipelineKey(desc *types.RenderPipelineDescriptor) string {
+	var w keyWriter
+	w.uint(uint64(desc.Layout))
+	w.uint(uint64(desc.VertexShader))
+	w.string(desc.VertexEntryPoint)
+
+	w.uint(uint64(len(desc.VertexBuffers)))
+	for _, vb := range desc.VertexBuffers {
+		w.uint(vb.ArrayStride)
+		w.uint(uint64(vb.StepMode))
+		w.uint(uint64(len(vb.Attributes)))
+		for _, attr := range vb.Attributes {
+			w.uint(uint64(attr.Format))
+			w.uint(attr.Offset)
+			w.uint(uint64(attr.ShaderLocation))
+		}
+	}
+
+	w.uint(uint64(desc.FragmentShader))
+	w.string(desc.FragmentEntry)
+	if desc.FragmentShader != 0 {
+		targets := desc.ColorTargets()
+		w.uint(uint64(len(targets)))
+		for _, target := range targets {
+			w.uint(uint64(target.Format))
+			w.uint(uint64(target.WriteMask))
+			w.bool(target.Blend != nil)
+			if target.Blend != nil {
+				for _, c := range []types.BlendComponent{target.Blend.Color, target.Blend.Alpha} {
+					w.uint(uint64(c.Operation))
+					w.uint(uint64(c.SrcFactor))
+					w.uint(uint64(c.DstFactor))
+				}
+			}
+		}
+	}
+
+	w.uint(uint64(desc.Topology))
+	w.uint(uint64(desc.FrontFace))
+	w.uint(uint64(desc.CullMode))
+
+	multisample := desc.Multisample.WithDefaults()
+	w.uint(uint64(multisample.Count))
+	w.uint(uint64(multisample.Mask))
+	w.bool(multisample.AlphaToCoverageEnabled)
+	return w.key()
+}
+
+// computePipelineKey returns the cache key of the fields of desc that
+// affect the created pipeline.
+func computePipelineKey(desc *types.ComputePipelineDescriptor) string {
+	var w keyWriter
+	w.uint(uint64(desc.Layout))
+	w.uint(uint64(desc.Module))
+	w.string(desc.EntryPoint)
+	return w.key()
+}
+
+// keyWriter encodes values into a cache key. Variable-size values are
+// length-prefixed so adjacent fields cannot run together.
+type keyWriter struct {
+	buf []byte
+}
+
+func (w *keyWriter) uint(v uint64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, v)
+}
+
+func (w *keyWriter) bool(v bool) {
+	if v {
+		w.uint(1)
+	} else {
+		w.uint(0)
+	}
+}
+
+func (w *keyWriter) string(s string) {
+	w.uint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *keyWriter) key() string {
+	return string(w.buf)
+}
@@ -0,0 +1,135 @@
+package gpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// pipelineCounter counts created and released shader modules and
+// pipelines.
+type pipelineCounter struct {
+	mockBackend
+	shaders, render, compute int
+	released                 int
+}
+
+func (c *pipelineCounter) CreateShaderModuleWGSL(types.Device, string) (types.ShaderModule, error) {
+	c.shaders++
+	return types.ShaderModule(c.shaders), nil
+}
+
+func (c *pipelineCounter) CreateShaderModuleSPIRV(types.Device, []uint32) (types.ShaderModule, error) {
+	c.shaders++
+	return types.ShaderModule(c.shaders), nil
+}
+
+func (c *pipelineCounter) CreateRenderPipeline(types.Device, *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
+	c.render++
+	return types.RenderPipeline(c.render), nil
+}
+
+func (c *pipelineCounter) CreateComputePipeline(types.Device, *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	c.compute++
+	return types.ComputePipeline(c.compute), nil
+}
+
+func (c *pipelineCounter) ReleaseShaderModule(types.ShaderModule) {
+	c.released++
+}
+
+func (c *pipelineCounter) ReleaseRenderPipeline(types.RenderPipeline) {
+	c.released++
+}
+
+func (c *pipelineCounter) ReleaseComputePipeline(types.ComputePipeline) {
+	c.released++
+}
+
+func TestPipelineCache(t *testing.T) {
+	backend := &pipelineCounter{}
+	cache := NewPipelineCache(backend, 1)
+
+	a, _ := cache.ShaderModule("@vertex fn vs() {}")
+	b, _ := cache.ShaderModule("@vertex fn vs() {}")
+	if a != b || backend.shaders != 1 {
+		t.Errorf("same source: modules %d, %d, created %d, want one", a, b, backend.shaders)
+	}
+
+	desc := types.RenderPipelineDescriptor{
+		Label:            "first",
+		VertexShader:     a,
+		VertexEntryPoint: "vs",
+		FragmentShader:   a,
+		FragmentEntry:    "fs",
+		TargetFormat:     types.TextureFormatBGRA8Unorm,
+	}
+	p1, _ := cache.RenderPipeline(&desc)
+
+	same := desc
+	same.Label = "second"
+	p2, _ := cache.RenderPipeline(&same)
+	if p1 != p2 || backend.render != 1 {
+		t.Errorf("same descriptor: pipelines %d, %d, created %d, want one", p1, p2, backend.render)
+	}
+
+	blended := desc
+	blended.Targets = []types.ColorTargetState{{Format: types.TextureFormatBGRA8Unorm, Blend: &types.BlendStateAlpha}}
+	if p3, _ := cache.RenderPipeline(&blended); p3 == p1 || backend.render != 2 {
+		t.Errorf("different blend state: pipeline %d, created %d, want a new one", p3, backend.render)
+	}
+
+	compute := types.ComputePipelineDescriptor{Module: a, EntryPoint: "main"}
+	_, _ = cache.ComputePipeline(&compute)
+	_, _ = cache.ComputePipeline(&compute)
+	if backend.compute != 1 {
+		t.Errorf("created %d compute pipelines, want 1", backend.compute)
+	}
+
+	if hits, misses := cache.Stats(); hits != 3 || misses != 4 {
+		t.Errorf("Stats() = %d hits, %d misses, want 3, 4", hits, misses)
+	}
+
+	cache.Release()
+	if backend.released != 4 {
+		t.Errorf("Release released %d objects, want 4", backend.released)
+	}
+	_, _ = cache.ShaderModule("@vertex fn vs() {}")
+	if backend.shaders != 2 {
+		t.Errorf("created %d shader modules after Release, want 2", backend.shaders)
+	}
+}
+
+func TestRenderPipelineKeyDefaults(t *testing.T) {
+	// Zero multisample fields mean their defaults
+	a := types.RenderPipelineDescriptor{VertexShader: 1}
+	b := types.RenderPipelineDescriptor{VertexShader: 1, Multisample: types.MultisampleState{Count: 1, Mask: 0xFFFFFFFF}}
+	if renderPipelineKey(&a) != renderPipelineKey(&b) {
+		t.Error("descriptors with default multisample state have different keys")
+	}
+
+	c := types.RenderPipelineDescriptor{VertexShader: 1, VertexEntryPoint: "a", FragmentEntry: "b"}
+	d := types.RenderPipelineDescriptor{VertexShader: 1, VertexEntryPoint: "ab"}
+	if renderPipelineKey(&c) == renderPipelineKey(&d) {
+		t.Error("entry points run together in the key")
+	}
+}
+
+func TestPipelineCacheSPIRV(t *testing.T) {
+	backend := &pipelineCounter{}
+	cache := NewPipelineCache(backend, 1)
+
+	code := []byte{0x03, 0x02, 0x23, 0x07, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}
+	a, err := cache.ShaderModuleSPIRV(code)
+	if err != nil {
+		t.Fatalf("ShaderModuleSPIRV: %v", err)
+	}
+	b, _ := cache.ShaderModuleSPIRV(code)
+	if a != b || backend.shaders != 1 {
+		t.Errorf("same binary: modules %d, %d, created %d, want one", a, b, backend.shaders)
+	}
+
+	if _, err := cache.ShaderModuleSPIRV([]byte("@vertex fn vs() {}  ")); err == nil {
+		t.Error("WGSL source accepted as SPIR-V")
+	}
+}
@@ -0,0 +1,622 @@
+package gpu
+
+import "github.com/gogpu/gogpu/gpu/types"
+
+// The Backend methods of Recorder: each records the call and forwards
+// it. Name, GetMappedRange and UnmapBuffer are in trace.go.
+
+func (r *Recorder) Init() error {
+	r.record("Init", nil)
+	return r.backend.Init()
+}
+
+func (r *Recorder) Destroy() {
+	r.record("Destroy", nil)
+	r.backend.Destroy()
+}
+
+func (r *Recorder) CreateInstance() (types.Instance, error) {
+	i := r.begin("CreateInstance", nil)
+	instance, err := r.backend.CreateInstance()
+	r.end(i, instance)
+	return instance, err
+}
+
+func (r *Recorder) RequestAdapter(instance types.Instance, opts *types.AdapterOptions) (types.Adapter, error) {
+	i := r.begin("RequestAdapter", []any{instance, opts})
+	adapter, err := r.backend.RequestAdapter(instance, opts)
+	r.end(i, adapter)
+	return adapter, err
+}
+
+func (r *Recorder) GetAdapterInfo(adapter types.Adapter) types.AdapterInfo {
+	i := r.begin("GetAdapterInfo", []any{adapter})
+	info := r.backend.GetAdapterInfo(adapter)
+	r.end(i, info)
+	return info
+}
+
+func (r *Recorder) GetAdapterFeatures(adapter types.Adapter) types.Features {
+	i := r.begin("GetAdapterFeatures", []any{adapter})
+	features := r.backend.GetAdapterFeatures(adapter)
+	r.end(i, features)
+	return features
+}
+
+func (r *Recorder) GetAdapterLimits(adapter types.Adapter) types.Limits {
+	i := r.begin("GetAdapterLimits", []any{adapter})
+	limits := r.backend.GetAdapterLimits(adapter)
+	r.end(i, limits)
+	return limits
+}
+
+func (r *Recorder) RequestDevice(adapter types.Adapter, opts *types.DeviceOptions) (types.Device, error) {
+	i := r.begin("RequestDevice", []any{adapter, opts})
+	device, err := r.backend.RequestDevice(adapter, opts)
+	r.end(i, device)
+	return device, err
+}
+
+func (r *Recorder) GetQueue(device types.Device) types.Queue {
+	i := r.begin("GetQueue", []any{device})
+	queue := r.backend.GetQueue(device)
+	r.end(i, queue)
+	return queue
+}
+
+func (r *Recorder) GetDeviceFeatures(device types.Device) types.Features {
+	i := r.begin("GetDeviceFeatures", []any{device})
+	features := r.backend.GetDeviceFeatures(device)
+	r.end(i, features)
+	return features
+}
+
+func (r *Recorder) GetDeviceLimits(device types.Device) types.Limits {
+	i := r.begin("GetDeviceLimits", []any{device})
+	limits := r.backend.GetDeviceLimits(device)
+	r.end(i, limits)
+	return limits
+}
+
+func (r *Recorder) PushErrorScope(device types.Device, filter types.ErrorFilter) {
+	r.record("PushErrorScope", []any{device, filter})
+	r.backend.PushErrorScope(device, filter)
+}
+
+func (r *Recorder) PopErrorScope(device types.Device) error {
+	r.record("PopErrorScope", []any{device})
+	return r.backend.PopErrorScope(device)
+}
+
+func (r *Recorder) SetUncapturedErrorCallback(device types.Device, callback func(err error)) {
+	r.record("SetUncapturedErrorCallback", []any{device, nil})
+	r.backend.SetUncapturedErrorCallback(device, callback)
+}
+
+func (r *Recorder) SetDeviceLostCallback(device types.Device, callback func(reason types.DeviceLostReason, message string)) {
+	r.record("SetDeviceLostCallback", []any{device, nil})
+	r.backend.SetDeviceLostCallback(device, callback)
+}
+
+func (r *Recorder) CreateSurface(instance types.Instance, handle types.SurfaceHandle) (types.Surface, error) {
+	i := r.begin("CreateSurface", []any{instance, handle})
+	surface, err := r.backend.CreateSurface(instance, handle)
+	r.end(i, surface)
+	return surface, err
+}
+
+func (r *Recorder) GetSurfaceCapabilities(surface types.Surface, adapter types.Adapter) types.SurfaceCapabilities {
+	i := r.begin("GetSurfaceCapabilities", []any{surface, adapter})
+	caps := r.backend.GetSurfaceCapabilities(surface, adapter)
+	r.end(i, caps)
+	return caps
+}
+
+func (r *Recorder) ConfigureSurface(surface types.Surface, device types.Device, config *types.SurfaceConfig) {
+	r.record("ConfigureSurface", []any{surface, device, config})
+	r.backend.ConfigureSurface(surface, device, config)
+}
+
+func (r *Recorder) GetCurrentTexture(surface types.Surface) (types.SurfaceTexture, error) {
+	i := r.begin("GetCurrentTexture", []any{surface})
+	texture, err := r.backend.GetCurrentTexture(surface)
+	r.end(i, texture)
+	return texture, err
+}
+
+func (r *Recorder) Present(surface types.Surface) {
+	r.record("Present", []any{surface})
+	r.backend.Present(surface)
+}
+
+func (r *Recorder) ReleaseSurface(surface types.Surface) {
+	r.record("ReleaseSurface", []any{surface})
+	r.backend.ReleaseSurface(surface)
+}
+
+func (r *Recorder) CreateShaderModuleWGSL(device types.Device, code string) (types.ShaderModule, error) {
+	i := r.begin("CreateShaderModuleWGSL", []any{device, code})
+	module, err := r.backend.CreateShaderModuleWGSL(device, code)
+	r.end(i, module)
+	return module, err
+}
+
+func (r *Recorder) CreateShaderModuleSPIRV(device types.Device, code []uint32) (types.ShaderModule, error) {
+	i := r.begin("CreateShaderModuleSPIRV", []any{device, code})
+	module, err := r.backend.CreateShaderModuleSPIRV(device, code)
+	r.end(i, module)
+	return module, err
+}
+
+func (r *Recorder) CreateRenderPipeline(device types.Device, desc *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
+	i := r.begin("CreateRenderPipeline", []any{device, desc})
+	pipeline, err := r.backend.CreateRenderPipeline(device, desc)
+	r.end(i, pipeline)
+	return pipeline, err
+}
+
+func (r *Recorder) CreateCommandEncoder(device types.Device) types.CommandEncoder {
+	i := r.begin("CreateCommandEncoder", []any{device})
+	encoder := r.backend.CreateCommandEncoder(device)
+	r.end(i, encoder)
+	return encoder
+}
+
+func (r *Recorder) BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass {
+	i := r.begin("BeginRenderPass", []any{encoder, desc})
+	pass := r.backend.BeginRenderPass(encoder, desc)
+	r.end(i, pass)
+	return pass
+}
+
+func (r *Recorder) EndRenderPass(pass types.RenderPass) {
+	r.record("EndRenderPass", []any{pass})
+	r.backend.EndRenderPass(pass)
+}
+
+func (r *Recorder) FinishEncoder(encoder types.CommandEncoder) types.CommandBuffer {
+	i := r.begin("FinishEncoder", []any{encoder})
+	commands := r.backend.FinishEncoder(encoder)
+	r.end(i, commands)
+	return commands
+}
+
+func (r *Recorder) Submit(queue types.Queue, commands types.CommandBuffer) types.SubmissionIndex {
+	i := r.begin("Submit", []any{queue, commands})
+	index := r.backend.Submit(queue, commands)
+	r.end(i, index)
+	return index
+}
+
+func (r *Recorder) CopyBufferToBuffer(encoder types.CommandEncoder, src types.Buffer, srcOffset uint64, dst types.Buffer, dstOffset, size uint64) {
+	r.record("CopyBufferToBuffer", []any{encoder, src, srcOffset, dst, dstOffset, size})
+	r.backend.CopyBufferToBuffer(encoder, src, srcOffset, dst, dstOffset, size)
+}
+
+func (r *Recorder) CopyTextureToBuffer(encoder types.CommandEncoder, src *types.ImageCopyTexture, dst *types.ImageCopyBuffer, size *types.Extent3D) {
+	r.record("CopyTextureToBuffer", []any{encoder, src, dst, size})
+	r.backend.CopyTextureToBuffer(encoder, src, dst, size)
+}
+
+func (r *Recorder) CopyBufferToTexture(encoder types.CommandEncoder, src *types.ImageCopyBuffer, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	r.record("CopyBufferToTexture", []any{encoder, src, dst, size})
+	r.backend.CopyBufferToTexture(encoder, src, dst, size)
+}
+
+func (r *Recorder) CopyTextureToTexture(encoder types.CommandEncoder, src, dst *types.ImageCopyTexture, size *types.Extent3D) {
+	r.record("CopyTextureToTexture", []any{encoder, src, dst, size})
+	r.backend.CopyTextureToTexture(encoder, src, dst, size)
+}
+
+func (r *Recorder) ClearBuffer(encoder types.CommandEncoder, buffer types.Buffer, offset, size uint64) {
+	r.record("ClearBuffer", []any{encoder, buffer, offset, size})
+	r.backend.ClearBuffer(encoder, buffer, offset, size)
+}
+
+func (r *Recorder) PushDebugGroup(encoder types.CommandEncoder, label string) {
+	r.record("PushDebugGroup", []any{encoder, label})
+	r.backend.PushDebugGroup(encoder, label)
+}
+
+func (r *Recorder) PopDebugGroup(encoder types.CommandEncoder) {
+	r.record("PopDebugGroup", []any{encoder})
+	r.backend.PopDebugGroup(encoder)
+}
+
+func (r *Recorder) InsertDebugMarker(encoder types.CommandEncoder, label string) {
+	r.record("InsertDebugMarker", []any{encoder, label})
+	r.backend.InsertDebugMarker(encoder, label)
+}
+
+func (r *Recorder) PushRenderPassDebugGroup(pass types.RenderPass, label string) {
+	r.record("PushRenderPassDebugGroup", []any{pass, label})
+	r.backend.PushRenderPassDebugGroup(pass, label)
+}
+
+func (r *Recorder) PopRenderPassDebugGroup(pass types.RenderPass) {
+	r.record("PopRenderPassDebugGroup", []any{pass})
+	r.backend.PopRenderPassDebugGroup(pass)
+}
+
+func (r *Recorder) InsertRenderPassDebugMarker(pass types.RenderPass, label string) {
+	r.record("InsertRenderPassDebugMarker", []any{pass, label})
+	r.backend.InsertRenderPassDebugMarker(pass, label)
+}
+
+func (r *Recorder) PushComputePassDebugGroup(pass types.ComputePass, label string) {
+	r.record("PushComputePassDebugGroup", []any{pass, label})
+	r.backend.PushComputePassDebugGroup(pass, label)
+}
+
+func (r *Recorder) PopComputePassDebugGroup(pass types.ComputePass) {
+	r.record("PopComputePassDebugGroup", []any{pass})
+	r.backend.PopComputePassDebugGroup(pass)
+}
+
+func (r *Recorder) InsertComputePassDebugMarker(pass types.ComputePass, label string) {
+	r.record("InsertComputePassDebugMarker", []any{pass, label})
+	r.backend.InsertComputePassDebugMarker(pass, label)
+}
+
+func (r *Recorder) CreateQuerySet(device types.Device, desc *types.QuerySetDescriptor) (types.QuerySet, error) {
+	i := r.begin("CreateQuerySet", []any{device, desc})
+	querySet, err := r.backend.CreateQuerySet(device, desc)
+	r.end(i, querySet)
+	return querySet, err
+}
+
+func (r *Recorder) WriteTimestamp(encoder types.CommandEncoder, querySet types.QuerySet, index uint32) {
+	r.record("WriteTimestamp", []any{encoder, querySet, index})
+	r.backend.WriteTimestamp(encoder, querySet, index)
+}
+
+func (r *Recorder) BeginOcclusionQuery(pass types.RenderPass, index uint32) {
+	r.record("BeginOcclusionQuery", []any{pass, index})
+	r.backend.BeginOcclusionQuery(pass, index)
+}
+
+func (r *Recorder) EndOcclusionQuery(pass types.RenderPass) {
+	r.record("EndOcclusionQuery", []any{pass})
+	r.backend.EndOcclusionQuery(pass)
+}
+
+func (r *Recorder) ResolveQuerySet(encoder types.CommandEncoder, querySet types.QuerySet, firstQuery, queryCount uint32, dst types.Buffer, dstOffset uint64) {
+	r.record("ResolveQuerySet", []any{encoder, querySet, firstQuery, queryCount, dst, dstOffset})
+	r.backend.ResolveQuerySet(encoder, querySet, firstQuery, queryCount, dst, dstOffset)
+}
+
+func (r *Recorder) Poll(device types.Device, wait bool) bool {
+	i := r.begin("Poll", []any{device, wait})
+	idle := r.backend.Poll(device, wait)
+	r.end(i, idle)
+	return idle
+}
+
+func (r *Recorder) OnSubmittedWorkDone(queue types.Queue, callback func()) {
+	r.record("OnSubmittedWorkDone", []any{queue, nil})
+	r.backend.OnSubmittedWorkDone(queue, callback)
+}
+
+func (r *Recorder) SetPipeline(pass types.RenderPass, pipeline types.RenderPipeline) {
+	r.record("SetPipeline", []any{pass, pipeline})
+	r.backend.SetPipeline(pass, pipeline)
+}
+
+func (r *Recorder) Draw(pass types.RenderPass, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	r.record("Draw", []any{pass, vertexCount, instanceCount, firstVertex, firstInstance})
+	r.backend.Draw(pass, vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (r *Recorder) CreateTexture(device types.Device, desc *types.TextureDescriptor) (types.Texture, error) {
+	i := r.begin("CreateTexture", []any{device, desc})
+	texture, err := r.backend.CreateTexture(device, desc)
+	r.end(i, texture)
+	return texture, err
+}
+
+func (r *Recorder) SupportedSampleCounts(adapter types.Adapter, format types.TextureFormat) []uint32 {
+	i := r.begin("SupportedSampleCounts", []any{adapter, format})
+	counts := r.backend.SupportedSampleCounts(adapter, format)
+	r.end(i, counts)
+	return counts
+}
+
+func (r *Recorder) CreateTextureView(texture types.Texture, desc *types.TextureViewDescriptor) types.TextureView {
+	i := r.begin("CreateTextureView", []any{texture, desc})
+	view := r.backend.CreateTextureView(texture, desc)
+	r.end(i, view)
+	return view
+}
+
+func (r *Recorder) WriteTexture(queue types.Queue, dst *types.ImageCopyTexture, data []byte, layout *types.ImageDataLayout, size *types.Extent3D) {
+	r.record("WriteTexture", []any{queue, dst, data, layout, size})
+	r.backend.WriteTexture(queue, dst, data, layout, size)
+}
+
+func (r *Recorder) CreateSampler(device types.Device, desc *types.SamplerDescriptor) (types.Sampler, error) {
+	i := r.begin("CreateSampler", []any{device, desc})
+	sampler, err := r.backend.CreateSampler(device, desc)
+	r.end(i, sampler)
+	return sampler, err
+}
+
+func (r *Recorder) CreateBuffer(device types.Device, desc *types.BufferDescriptor) (types.Buffer, error) {
+	i := r.begin("CreateBuffer", []any{device, desc})
+	buffer, err := r.backend.CreateBuffer(device, desc)
+	r.end(i, buffer)
+	return buffer, err
+}
+
+func (r *Recorder) WriteBuffer(queue types.Queue, buffer types.Buffer, offset uint64, data []byte) {
+	r.record("WriteBuffer", []any{queue, buffer, offset, data})
+	r.backend.WriteBuffer(queue, buffer, offset, data)
+}
+
+func (r *Recorder) MapBufferAsync(device types.Device, buffer types.Buffer, mode types.MapMode, offset, size uint64, callback func(err error)) {
+	r.record("MapBufferAsync", []any{device, buffer, mode, offset, size, nil})
+	r.backend.MapBufferAsync(device, buffer, mode, offset, size, callback)
+}
+
+func (r *Recorder) CreateBindGroupLayout(device types.Device, desc *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
+	i := r.begin("CreateBindGroupLayout", []any{device, desc})
+	layout, err := r.backend.CreateBindGroupLayout(device, desc)
+	r.end(i, layout)
+	return layout, err
+}
+
+func (r *Recorder) CreateBindGroup(device types.Device, desc *types.BindGroupDescriptor) (types.BindGroup, error) {
+	i := r.begin("CreateBindGroup", []any{device, desc})
+	group, err := r.backend.CreateBindGroup(device, desc)
+	r.end(i, group)
+	return group, err
+}
+
+func (r *Recorder) CreatePipelineLayout(device types.Device, desc *types.PipelineLayoutDescriptor) (types.PipelineLayout, error) {
+	i := r.begin("CreatePipelineLayout", []any{device, desc})
+	layout, err := r.backend.CreatePipelineLayout(device, desc)
+	r.end(i, layout)
+	return layout, err
+}
+
+func (r *Recorder) SetBindGroup(pass types.RenderPass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	r.record("SetBindGroup", []any{pass, index, bindGroup, dynamicOffsets})
+	r.backend.SetBindGroup(pass, index, bindGroup, dynamicOffsets)
+}
+
+func (r *Recorder) SetVertexBuffer(pass types.RenderPass, slot uint32, buffer types.Buffer, offset, size uint64) {
+	r.record("SetVertexBuffer", []any{pass, slot, buffer, offset, size})
+	r.backend.SetVertexBuffer(pass, slot, buffer, offset, size)
+}
+
+func (r *Recorder) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	r.record("SetIndexBuffer", []any{pass, buffer, format, offset, size})
+	r.backend.SetIndexBuffer(pass, buffer, format, offset, size)
+}
+
+func (r *Recorder) DrawIndexed(pass types.RenderPass, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	r.record("DrawIndexed", []any{pass, indexCount, instanceCount, firstIndex, baseVertex, firstInstance})
+	r.backend.DrawIndexed(pass, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (r *Recorder) SetViewport(pass types.RenderPass, x, y, width, height, minDepth, maxDepth float32) {
+	r.record("SetViewport", []any{pass, x, y, width, height, minDepth, maxDepth})
+	r.backend.SetViewport(pass, x, y, width, height, minDepth, maxDepth)
+}
+
+func (r *Recorder) SetScissorRect(pass types.RenderPass, x, y, width, height uint32) {
+	r.record("SetScissorRect", []any{pass, x, y, width, height})
+	r.backend.SetScissorRect(pass, x, y, width, height)
+}
+
+func (r *Recorder) SetBlendConstant(pass types.RenderPass, color *types.Color) {
+	r.record("SetBlendConstant", []any{pass, color})
+	r.backend.SetBlendConstant(pass, color)
+}
+
+func (r *Recorder) SetStencilReference(pass types.RenderPass, reference uint32) {
+	r.record("SetStencilReference", []any{pass, reference})
+	r.backend.SetStencilReference(pass, reference)
+}
+
+func (r *Recorder) SetPushConstants(pass types.RenderPass, stages types.ShaderStage, offset uint32, data []byte) {
+	r.record("SetPushConstants", []any{pass, stages, offset, data})
+	r.backend.SetPushConstants(pass, stages, offset, data)
+}
+
+func (r *Recorder) DrawIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	r.record("DrawIndirect", []any{pass, buffer, offset})
+	r.backend.DrawIndirect(pass, buffer, offset)
+}
+
+func (r *Recorder) DrawIndexedIndirect(pass types.RenderPass, buffer types.Buffer, offset uint64) {
+	r.record("DrawIndexedIndirect", []any{pass, buffer, offset})
+	r.backend.DrawIndexedIndirect(pass, buffer, offset)
+}
+
+func (r *Recorder) CreateRenderBundleEncoder(device types.Device, desc *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	i := r.begin("CreateRenderBundleEncoder", []any{device, desc})
+	encoder, err := r.backend.CreateRenderBundleEncoder(device, desc)
+	r.end(i, encoder)
+	return encoder, err
+}
+
+func (r *Recorder) SetBundlePipeline(encoder types.RenderBundleEncoder, pipeline types.RenderPipeline) {
+	r.record("SetBundlePipeline", []any{encoder, pipeline})
+	r.backend.SetBundlePipeline(encoder, pipeline)
+}
+
+func (r *Recorder) SetBundleBindGroup(encoder types.RenderBundleEncoder, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	r.record("SetBundleBindGroup", []any{encoder, index, bindGroup, dynamicOffsets})
+	r.backend.SetBundleBindGroup(encoder, index, bindGroup, dynamicOffsets)
+}
+
+func (r *Recorder) SetBundleVertexBuffer(encoder types.RenderBundleEncoder, slot uint32, buffer types.Buffer, offset, size uint64) {
+	r.record("SetBundleVertexBuffer", []any{encoder, slot, buffer, offset, size})
+	r.backend.SetBundleVertexBuffer(encoder, slot, buffer, offset, size)
+}
+
+func (r *Recorder) SetBundleIndexBuffer(encoder types.RenderBundleEncoder, buffer types.Buffer, format types.IndexFormat, offset, size uint64) {
+	r.record("SetBundleIndexBuffer", []any{encoder, buffer, format, offset, size})
+	r.backend.SetBundleIndexBuffer(encoder, buffer, format, offset, size)
+}
+
+func (r *Recorder) BundleDraw(encoder types.RenderBundleEncoder, vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	r.record("BundleDraw", []any{encoder, vertexCount, instanceCount, firstVertex, firstInstance})
+	r.backend.BundleDraw(encoder, vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+func (r *Recorder) BundleDrawIndexed(encoder types.RenderBundleEncoder, indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	r.record("BundleDrawIndexed", []any{encoder, indexCount, instanceCount, firstIndex, baseVertex, firstInstance})
+	r.backend.BundleDrawIndexed(encoder, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+func (r *Recorder) SetBundlePushConstants(encoder types.RenderBundleEncoder, stages types.ShaderStage, offset uint32, data []byte) {
+	r.record("SetBundlePushConstants", []any{encoder, stages, offset, data})
+	r.backend.SetBundlePushConstants(encoder, stages, offset, data)
+}
+
+func (r *Recorder) BundleDrawIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	r.record("BundleDrawIndirect", []any{encoder, buffer, offset})
+	r.backend.BundleDrawIndirect(encoder, buffer, offset)
+}
+
+func (r *Recorder) BundleDrawIndexedIndirect(encoder types.RenderBundleEncoder, buffer types.Buffer, offset uint64) {
+	r.record("BundleDrawIndexedIndirect", []any{encoder, buffer, offset})
+	r.backend.BundleDrawIndexedIndirect(encoder, buffer, offset)
+}
+
+func (r *Recorder) FinishRenderBundle(encoder types.RenderBundleEncoder) types.RenderBundle {
+	i := r.begin("FinishRenderBundle", []any{encoder})
+	bundle := r.backend.FinishRenderBundle(encoder)
+	r.end(i, bundle)
+	return bundle
+}
+
+func (r *Recorder) ExecuteBundles(pass types.RenderPass, bundles []types.RenderBundle) {
+	r.record("ExecuteBundles", []any{pass, bundles})
+	r.backend.ExecuteBundles(pass, bundles)
+}
+
+func (r *Recorder) CreateComputePipeline(device types.Device, desc *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	i := r.begin("CreateComputePipeline", []any{device, desc})
+	pipeline, err := r.backend.CreateComputePipeline(device, desc)
+	r.end(i, pipeline)
+	return pipeline, err
+}
+
+func (r *Recorder) BeginComputePass(encoder types.CommandEncoder, desc *types.ComputePassDescriptor) types.ComputePass {
+	i := r.begin("BeginComputePass", []any{encoder, desc})
+	pass := r.backend.BeginComputePass(encoder, desc)
+	r.end(i, pass)
+	return pass
+}
+
+func (r *Recorder) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) {
+	r.record("SetComputePipeline", []any{pass, pipeline})
+	r.backend.SetComputePipeline(pass, pipeline)
+}
+
+func (r *Recorder) SetComputeBindGroup(pass types.ComputePass, index uint32, bindGroup types.BindGroup, dynamicOffsets []uint32) {
+	r.record("SetComputeBindGroup", []any{pass, index, bindGroup, dynamicOffsets})
+	r.backend.SetComputeBindGroup(pass, index, bindGroup, dynamicOffsets)
+}
+
+func (r *Recorder) DispatchWorkgroups(pass types.ComputePass, x, y, z uint32) {
+	r.record("DispatchWorkgroups", []any{pass, x, y, z})
+	r.backend.DispatchWorkgroups(pass, x, y, z)
+}
+
+func (r *Recorder) DispatchWorkgroupsIndirect(pass types.ComputePass, buffer types.Buffer, offset uint64) {
+	r.record("DispatchWorkgroupsIndirect", []any{pass, buffer, offset})
+	r.backend.DispatchWorkgroupsIndirect(pass, buffer, offset)
+}
+
+func (r *Recorder) EndComputePass(pass types.ComputePass) {
+	r.record("EndComputePass", []any{pass})
+	r.backend.EndComputePass(pass)
+}
+
+func (r *Recorder) ReleaseTexture(texture types.Texture) {
+	r.record("ReleaseTexture", []any{texture})
+	r.backend.ReleaseTexture(texture)
+}
+
+func (r *Recorder) ReleaseTextureView(view types.TextureView) {
+	r.record("ReleaseTextureView", []any{view})
+	r.backend.ReleaseTextureView(view)
+}
+
+func (r *Recorder) ReleaseSampler(sampler types.Sampler) {
+	r.record("ReleaseSampler", []any{sampler})
+	r.backend.ReleaseSampler(sampler)
+}
+
+func (r *Recorder) ReleaseBuffer(buffer types.Buffer) {
+	r.record("ReleaseBuffer", []any{buffer})
+	r.backend.ReleaseBuffer(buffer)
+}
+
+func (r *Recorder) ReleaseBindGroupLayout(layout types.BindGroupLayout) {
+	r.record("ReleaseBindGroupLayout", []any{layout})
+	r.backend.ReleaseBindGroupLayout(layout)
+}
+
+func (r *Recorder) ReleaseBindGroup(group types.BindGroup) {
+	r.record("ReleaseBindGroup", []any{group})
+	r.backend.ReleaseBindGroup(group)
+}
+
+func (r *Recorder) ReleasePipelineLayout(layout types.PipelineLayout) {
+	r.record("ReleasePipelineLayout", []any{layout})
+	r.backend.ReleasePipelineLayout(layout)
+}
+
+func (r *Recorder) ReleaseShaderModule(module types.ShaderModule) {
+	r.record("ReleaseShaderModule", []any{module})
+	r.backend.ReleaseShaderModule(module)
+}
+
+func (r *Recorder) ReleaseRenderPipeline(pipeline types.RenderPipeline) {
+	r.record("ReleaseRenderPipeline", []any{pipeline})
+	r.backend.ReleaseRenderPipeline(pipeline)
+}
+
+func (r *Recorder) ReleaseCommandBuffer(buffer types.CommandBuffer) {
+	r.record("ReleaseCommandBuffer", []any{buffer})
+	r.backend.ReleaseCommandBuffer(buffer)
+}
+
+func (r *Recorder) ReleaseCommandEncoder(encoder types.CommandEncoder) {
+	r.record("ReleaseCommandEncoder", []any{encoder})
+	r.backend.ReleaseCommandEncoder(encoder)
+}
+
+func (r *Recorder) ReleaseRenderPass(pass types.RenderPass) {
+	r.record("ReleaseRenderPass", []any{pass})
+	r.backend.ReleaseRenderPass(pass)
+}
+
+func (r *Recorder) ReleaseComputePipeline(pipeline types.ComputePipeline) {
+	r.record("ReleaseComputePipeline", []any{pipeline})
+	r.backend.ReleaseComputePipeline(pipeline)
+}
+
+func (r *Recorder) ReleaseComputePass(pass types.ComputePass) {
+	r.record("ReleaseComputePass", []any{pass})
+	r.backend.ReleaseComputePass(pass)
+}
+
+func (r *Recorder) ReleaseQuerySet(querySet types.QuerySet) {
+	r.record("ReleaseQuerySet", []any{querySet})
+	r.backend.ReleaseQuerySet(querySet)
+}
+
+func (r *Recorder) ReleaseRenderBundleEncoder(encoder types.RenderBundleEncoder) {
+	r.record("ReleaseRenderBundleEncoder", []any{encoder})
+	r.backend.ReleaseRenderBundleEncoder(encoder)
+}
+
+func (r *Recorder) ReleaseRenderBundle(bundle types.RenderBundle) {
+	r.record("ReleaseRenderBundle", []any{bundle})
+	r.backend.ReleaseRenderBundle(bundle)
+}
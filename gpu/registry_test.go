@@ -25,6 +25,19 @@ func (m *mockBackend) RequestDevice(types.Adapter, *types.DeviceOptions) (types.
 	return 1, nil
 }
 func (m *mockBackend) GetQueue(types.Device) types.Queue { return 1 }
+func (m *mockBackend) GetAdapterInfo(types.Adapter) types.AdapterInfo {
+	return types.AdapterInfo{Name: "mock"}
+}
+func (m *mockBackend) GetAdapterFeatures(types.Adapter) types.Features { return 0 }
+func (m *mockBackend) GetAdapterLimits(types.Adapter) types.Limits     { return types.DefaultLimits() }
+func (m *mockBackend) GetDeviceFeatures(types.Device) types.Features   { return 0 }
+func (m *mockBackend) GetDeviceLimits(types.Device) types.Limits       { return types.DefaultLimits() }
+func (m *mockBackend) PushErrorScope(types.Device, types.ErrorFilter)  {}
+func (m *mockBackend) PopErrorScope(types.Device) error                { return nil }
+func (m *mockBackend) SetUncapturedErrorCallback(types.Device, func(error)) {
+}
+func (m *mockBackend) SetDeviceLostCallback(types.Device, func(types.DeviceLostReason, string)) {
+}
 func (m *mockBackend) CreateSurface(types.Instance, types.SurfaceHandle) (types.Surface, error) {
 	return 1, nil
 }
@@ -32,10 +45,14 @@ func (m *mockBackend) ConfigureSurface(types.Surface, types.Device, *types.Surfa
 func (m *mockBackend) GetCurrentTexture(types.Surface) (types.SurfaceTexture, error) {
 	return types.SurfaceTexture{Texture: 1}, nil
 }
-func (m *mockBackend) Present(types.Surface) {}
+func (m *mockBackend) Present(types.Surface)        {}
+func (m *mockBackend) ReleaseSurface(types.Surface) {}
 func (m *mockBackend) CreateShaderModuleWGSL(types.Device, string) (types.ShaderModule, error) {
 	return 1, nil
 }
+func (m *mockBackend) CreateShaderModuleSPIRV(types.Device, []uint32) (types.ShaderModule, error) {
+	return 1, nil
+}
 func (m *mockBackend) CreateRenderPipeline(types.Device, *types.RenderPipelineDescriptor) (types.RenderPipeline, error) {
 	return 1, nil
 }
@@ -43,14 +60,35 @@ func (m *mockBackend) CreateCommandEncoder(types.Device) types.CommandEncoder {
 func (m *mockBackend) BeginRenderPass(types.CommandEncoder, *types.RenderPassDescriptor) types.RenderPass {
 	return 1
 }
-func (m *mockBackend) EndRenderPass(types.RenderPass)                         {}
-func (m *mockBackend) FinishEncoder(types.CommandEncoder) types.CommandBuffer { return 1 }
-func (m *mockBackend) Submit(types.Queue, types.CommandBuffer)                {}
-func (m *mockBackend) SetPipeline(types.RenderPass, types.RenderPipeline)     {}
-func (m *mockBackend) Draw(types.RenderPass, uint32, uint32, uint32, uint32)  {}
+func (m *mockBackend) EndRenderPass(types.RenderPass)                                {}
+func (m *mockBackend) FinishEncoder(types.CommandEncoder) types.CommandBuffer        { return 1 }
+func (m *mockBackend) Submit(types.Queue, types.CommandBuffer) types.SubmissionIndex { return 0 }
+func (m *mockBackend) CopyBufferToBuffer(types.CommandEncoder, types.Buffer, uint64, types.Buffer, uint64, uint64) {
+}
+func (m *mockBackend) CopyTextureToBuffer(types.CommandEncoder, *types.ImageCopyTexture, *types.ImageCopyBuffer, *types.Extent3D) {
+}
+func (m *mockBackend) CopyBufferToTexture(types.CommandEncoder, *types.ImageCopyBuffer, *types.ImageCopyTexture, *types.Extent3D) {
+}
+func (m *mockBackend) CopyTextureToTexture(types.CommandEncoder, *types.ImageCopyTexture, *types.ImageCopyTexture, *types.Extent3D) {
+}
+func (m *mockBackend) ClearBuffer(types.CommandEncoder, types.Buffer, uint64, uint64) {}
+func (m *mockBackend) Poll(types.Device, bool) bool                                   { return true }
+func (m *mockBackend) OnSubmittedWorkDone(types.Queue, func())                        {}
+func (m *mockBackend) SetPipeline(types.RenderPass, types.RenderPipeline)             {}
+func (m *mockBackend) Draw(types.RenderPass, uint32, uint32, uint32, uint32)          {}
 func (m *mockBackend) CreateTexture(types.Device, *types.TextureDescriptor) (types.Texture, error) {
 	return 1, nil
 }
+func (m *mockBackend) GetSurfaceCapabilities(types.Surface, types.Adapter) types.SurfaceCapabilities {
+	return types.SurfaceCapabilities{
+		Formats:      []types.TextureFormat{types.TextureFormatBGRA8Unorm},
+		PresentModes: []types.PresentMode{types.PresentModeFifo},
+		AlphaModes:   []types.AlphaMode{types.AlphaModeOpaque},
+	}
+}
+func (m *mockBackend) SupportedSampleCounts(types.Adapter, types.TextureFormat) []uint32 {
+	return []uint32{1, 4}
+}
 func (m *mockBackend) CreateTextureView(types.Texture, *types.TextureViewDescriptor) types.TextureView {
 	return 1
 }
@@ -63,6 +101,13 @@ func (m *mockBackend) CreateBuffer(types.Device, *types.BufferDescriptor) (types
 	return 1, nil
 }
 func (m *mockBackend) WriteBuffer(types.Queue, types.Buffer, uint64, []byte) {}
+func (m *mockBackend) MapBufferAsync(_ types.Device, _ types.Buffer, _ types.MapMode, _, _ uint64, callback func(error)) {
+	callback(nil)
+}
+func (m *mockBackend) GetMappedRange(_ types.Buffer, _, size uint64) []byte {
+	return make([]byte, size)
+}
+func (m *mockBackend) UnmapBuffer(types.Buffer) {}
 func (m *mockBackend) CreateBindGroupLayout(types.Device, *types.BindGroupLayoutDescriptor) (types.BindGroupLayout, error) {
 	return 1, nil
 }
@@ -84,9 +129,71 @@ func (m *mockBackend) ReleaseBuffer(types.Buffer)
 func (m *mockBackend) ReleaseBindGroupLayout(types.BindGroupLayout)                        {}
 func (m *mockBackend) ReleaseBindGroup(types.BindGroup)                                    {}
 func (m *mockBackend) ReleasePipelineLayout(types.PipelineLayout)                          {}
+func (m *mockBackend) ReleaseShaderModule(types.ShaderModule)                              {}
+func (m *mockBackend) ReleaseRenderPipeline(types.RenderPipeline)                          {}
 func (m *mockBackend) ReleaseCommandBuffer(types.CommandBuffer)                            {}
 func (m *mockBackend) ReleaseCommandEncoder(types.CommandEncoder)                          {}
 func (m *mockBackend) ReleaseRenderPass(types.RenderPass)                                  {}
+func (m *mockBackend) CreateComputePipeline(types.Device, *types.ComputePipelineDescriptor) (types.ComputePipeline, error) {
+	return 1, nil
+}
+func (m *mockBackend) BeginComputePass(types.CommandEncoder, *types.ComputePassDescriptor) types.ComputePass {
+	return 1
+}
+func (m *mockBackend) SetComputePipeline(types.ComputePass, types.ComputePipeline)              {}
+func (m *mockBackend) SetComputeBindGroup(types.ComputePass, uint32, types.BindGroup, []uint32) {}
+func (m *mockBackend) DispatchWorkgroups(types.ComputePass, uint32, uint32, uint32)             {}
+func (m *mockBackend) DispatchWorkgroupsIndirect(types.ComputePass, types.Buffer, uint64)       {}
+func (m *mockBackend) EndComputePass(types.ComputePass)                                         {}
+func (m *mockBackend) ReleaseComputePipeline(types.ComputePipeline)                             {}
+func (m *mockBackend) ReleaseComputePass(types.ComputePass)                                     {}
+func (m *mockBackend) PushDebugGroup(types.CommandEncoder, string)                              {}
+func (m *mockBackend) PopDebugGroup(types.CommandEncoder)                                       {}
+func (m *mockBackend) InsertDebugMarker(types.CommandEncoder, string)                           {}
+func (m *mockBackend) PushRenderPassDebugGroup(types.RenderPass, string)                        {}
+func (m *mockBackend) PopRenderPassDebugGroup(types.RenderPass)                                 {}
+func (m *mockBackend) InsertRenderPassDebugMarker(types.RenderPass, string)                     {}
+func (m *mockBackend) PushComputePassDebugGroup(types.ComputePass, string)                      {}
+func (m *mockBackend) PopComputePassDebugGroup(types.ComputePass)                               {}
+func (m *mockBackend) InsertComputePassDebugMarker(types.ComputePass, string)                   {}
+func (m *mockBackend) CreateQuerySet(types.Device, *types.QuerySetDescriptor) (types.QuerySet, error) {
+	return 1, nil
+}
+func (m *mockBackend) WriteTimestamp(types.CommandEncoder, types.QuerySet, uint32) {}
+func (m *mockBackend) BeginOcclusionQuery(types.RenderPass, uint32)                {}
+func (m *mockBackend) EndOcclusionQuery(types.RenderPass)                          {}
+func (m *mockBackend) ResolveQuerySet(types.CommandEncoder, types.QuerySet, uint32, uint32, types.Buffer, uint64) {
+}
+func (m *mockBackend) ReleaseQuerySet(types.QuerySet) {}
+func (m *mockBackend) CreateRenderBundleEncoder(types.Device, *types.RenderBundleEncoderDescriptor) (types.RenderBundleEncoder, error) {
+	return 1, nil
+}
+func (m *mockBackend) SetBundlePipeline(types.RenderBundleEncoder, types.RenderPipeline) {}
+func (m *mockBackend) SetBundleBindGroup(types.RenderBundleEncoder, uint32, types.BindGroup, []uint32) {
+}
+func (m *mockBackend) SetBundleVertexBuffer(types.RenderBundleEncoder, uint32, types.Buffer, uint64, uint64) {
+}
+func (m *mockBackend) SetBundleIndexBuffer(types.RenderBundleEncoder, types.Buffer, types.IndexFormat, uint64, uint64) {
+}
+func (m *mockBackend) BundleDraw(types.RenderBundleEncoder, uint32, uint32, uint32, uint32) {}
+func (m *mockBackend) BundleDrawIndexed(types.RenderBundleEncoder, uint32, uint32, uint32, int32, uint32) {
+}
+func (m *mockBackend) DrawIndirect(types.RenderPass, types.Buffer, uint64)                       {}
+func (m *mockBackend) DrawIndexedIndirect(types.RenderPass, types.Buffer, uint64)                {}
+func (m *mockBackend) BundleDrawIndirect(types.RenderBundleEncoder, types.Buffer, uint64)        {}
+func (m *mockBackend) BundleDrawIndexedIndirect(types.RenderBundleEncoder, types.Buffer, uint64) {}
+func (m *mockBackend) SetPushConstants(types.RenderPass, types.ShaderStage, uint32, []byte)      {}
+func (m *mockBackend) SetBundlePushConstants(types.RenderBundleEncoder, types.ShaderStage, uint32, []byte) {
+}
+func (m *mockBackend) SetViewport(types.RenderPass, float32, float32, float32, float32, float32, float32) {
+}
+func (m *mockBackend) SetScissorRect(types.RenderPass, uint32, uint32, uint32, uint32) {}
+func (m *mockBackend) SetBlendConstant(types.RenderPass, *types.Color)                 {}
+func (m *mockBackend) SetStencilReference(types.RenderPass, uint32)                    {}
+func (m *mockBackend) FinishRenderBundle(types.RenderBundleEncoder) types.RenderBundle { return 1 }
+func (m *mockBackend) ExecuteBundles(types.RenderPass, []types.RenderBundle)           {}
+func (m *mockBackend) ReleaseRenderBundleEncoder(types.RenderBundleEncoder)            {}
+func (m *mockBackend) ReleaseRenderBundle(types.RenderBundle)                          {}
 
 func TestRegisterBackend(t *testing.T) {
 	// Clean up any existing backends first
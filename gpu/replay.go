@@ -0,0 +1,348 @@
+package gpu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// Replay plays trace back on backend. Handles recorded in the trace are
+// mapped to the handles backend returns for the same calls.
+//
+// Replay needs no window: surfaces are emulated with textures of the
+// configured size and format, and onPresent, which may be nil, is called
+// with the texture of each presented frame, e.g. to read it back. Calls
+// that fail are collected in the returned error and replay continues.
+func Replay(backend Backend, trace *Trace, onPresent func(texture types.Texture)) error {
+	p := &player{
+		backend:   backend,
+		onPresent: onPresent,
+		handles:   make(map[handleKey]uint64),
+		surfaces:  make(map[types.Surface]*replaySurface),
+	}
+	var errs []error
+	for i := range trace.Calls {
+		if err := p.play(&trace.Calls[i]); err != nil {
+			errs = append(errs, fmt.Errorf("gpu: replay call %d (%s): %w", i, trace.Calls[i].Method, err))
+		}
+	}
+	p.releaseFrames()
+	return errors.Join(errs...)
+}
+
+// handleKey identifies a recorded handle of one type.
+type handleKey struct {
+	typ   reflect.Type
+	value uint64
+}
+
+// replaySurface is a recorded surface emulated with textures.
+type replaySurface struct {
+	device types.Device
+	config types.SurfaceConfig
+	frame  types.Texture // Texture of the current frame
+}
+
+// player replays the calls of a trace.
+type player struct {
+	backend   Backend
+	onPresent func(texture types.Texture)
+
+	// Recorded handles and the handles backend returned for them
+	handles  map[handleKey]uint64
+	surfaces map[types.Surface]*replaySurface // By recorded handle
+}
+
+var (
+	backendType = reflect.TypeOf((*Backend)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	handlePkg   = reflect.TypeOf(types.Texture(0)).PkgPath()
+)
+
+// play replays one call.
+func (p *player) play(call *TraceCall) error {
+	switch call.Method {
+	case "ReleaseTexture":
+		// A released surface texture is no longer the frame to release
+		var texture types.Texture
+		if err := decode(call, &texture); err == nil {
+			texture = mapHandle(p, texture)
+			for _, s := range p.surfaces {
+				if s.frame == texture {
+					s.frame = 0
+				}
+			}
+		}
+	case "Name", "Destroy":
+		// The caller owns backend
+		return nil
+	case "CreateSurface", "GetSurfaceCapabilities":
+		return nil
+	case "ConfigureSurface":
+		return p.configureSurface(call)
+	case "GetCurrentTexture":
+		return p.getCurrentTexture(call)
+	case "Present":
+		return p.present(call)
+	case "ReleaseSurface":
+		return p.releaseSurface(call)
+	case writeMappedRange:
+		return p.writeMappedRange(call)
+	}
+
+	m, ok := backendType.MethodByName(call.Method)
+	if !ok {
+		return errors.New("unknown method")
+	}
+	args, err := p.decodeArgs(m.Type, call.Args)
+	if err != nil {
+		return err
+	}
+	results := reflect.ValueOf(p.backend).MethodByName(call.Method).Call(args)
+
+	var recorded int
+	for _, result := range results {
+		if result.Type() == errorType {
+			if !result.IsNil() {
+				return result.Interface().(error)
+			}
+			continue
+		}
+		if recorded < len(call.Results) {
+			want := reflect.New(result.Type())
+			if err := json.Unmarshal(call.Results[recorded], want.Interface()); err != nil {
+				return fmt.Errorf("result %d: %w", recorded, err)
+			}
+			p.learn(want.Elem(), result)
+		}
+		recorded++
+	}
+	return nil
+}
+
+// decodeArgs decodes the arguments of a call to a method of type
+// method, mapping recorded handles. Callbacks, recorded as null, are
+// replaced by functions doing nothing.
+func (p *player) decodeArgs(method reflect.Type, encoded []json.RawMessage) ([]reflect.Value, error) {
+	if len(encoded) != method.NumIn() {
+		return nil, fmt.Errorf("%d arguments recorded, want %d", len(encoded), method.NumIn())
+	}
+	args := make([]reflect.Value, len(encoded))
+	for i, data := range encoded {
+		typ := method.In(i)
+		if typ.Kind() == reflect.Func {
+			args[i] = reflect.MakeFunc(typ, func([]reflect.Value) []reflect.Value {
+				results := make([]reflect.Value, typ.NumOut())
+				for j := range results {
+					results[j] = reflect.Zero(typ.Out(j))
+				}
+				return results
+			})
+			continue
+		}
+		v := reflect.New(typ)
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		p.remap(v.Elem())
+		args[i] = v.Elem()
+	}
+	return args, nil
+}
+
+// isHandle reports whether typ is one of the handle types of package
+// types.
+func isHandle(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Uintptr && typ.PkgPath() == handlePkg
+}
+
+// remap replaces the recorded handles in v by the replayed ones. Handles
+// without a mapping are left alone, so a call using a handle whose
+// creation failed fails too.
+func (p *player) remap(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Uintptr:
+		if isHandle(v.Type()) && v.Uint() != 0 && v.CanSet() {
+			if h, ok := p.handles[handleKey{v.Type(), v.Uint()}]; ok {
+				v.SetUint(h)
+			}
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			p.remap(v.Elem())
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				p.remap(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := range v.Len() {
+			p.remap(v.Index(i))
+		}
+	}
+}
+
+// learn maps the handles in recorded, a recorded result, to those at the
+// same place in actual, the replayed one.
+func (p *player) learn(recorded, actual reflect.Value) {
+	switch recorded.Kind() {
+	case reflect.Uintptr:
+		if isHandle(recorded.Type()) && recorded.Uint() != 0 {
+			p.handles[handleKey{recorded.Type(), recorded.Uint()}] = actual.Uint()
+		}
+	case reflect.Pointer:
+		if !recorded.IsNil() && !actual.IsNil() {
+			p.learn(recorded.Elem(), actual.Elem())
+		}
+	case reflect.Struct:
+		for i := range recorded.NumField() {
+			if recorded.Type().Field(i).IsExported() {
+				p.learn(recorded.Field(i), actual.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range min(recorded.Len(), actual.Len()) {
+			p.learn(recorded.Index(i), actual.Index(i))
+		}
+	}
+}
+
+// mapHandle returns the replayed handle of a recorded one.
+func mapHandle[H ~uintptr](p *player, handle H) H {
+	v := reflect.ValueOf(&handle).Elem()
+	p.remap(v)
+	return handle
+}
+
+// decode decodes the arguments of a call handled by the player itself.
+func decode(call *TraceCall, args ...any) error {
+	if len(call.Args) != len(args) {
+		return fmt.Errorf("%d arguments recorded, want %d", len(call.Args), len(args))
+	}
+	for i, arg := range args {
+		if err := json.Unmarshal(call.Args[i], arg); err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p *player) configureSurface(call *TraceCall) error {
+	var surface types.Surface
+	var device types.Device
+	var config types.SurfaceConfig
+	if err := decode(call, &surface, &device, &config); err != nil {
+		return err
+	}
+	s := p.surfaces[surface]
+	if s == nil {
+		s = &replaySurface{}
+		p.surfaces[surface] = s
+	}
+	s.device = mapHandle(p, device)
+	s.config = config
+	return nil
+}
+
+func (p *player) getCurrentTexture(call *TraceCall) error {
+	var surface types.Surface
+	if err := decode(call, &surface); err != nil {
+		return err
+	}
+	s := p.surfaces[surface]
+	if s == nil {
+		return errors.New("surface is not configured")
+	}
+	var recorded types.SurfaceTexture
+	if len(call.Results) > 0 {
+		if err := json.Unmarshal(call.Results[0], &recorded); err != nil {
+			return fmt.Errorf("result 0: %w", err)
+		}
+	}
+	if recorded.Texture == 0 {
+		return nil // The frame was skipped when recorded
+	}
+
+	p.releaseFrame(s)
+	texture, err := p.backend.CreateTexture(s.device, &types.TextureDescriptor{
+		Label:         "replayed surface texture",
+		Size:          types.Extent3D{Width: s.config.Width, Height: s.config.Height, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     types.TextureDimension2D,
+		Format:        s.config.Format,
+		Usage:         s.config.Usage | types.TextureUsageCopySrc,
+		ViewFormats:   s.config.ViewFormats,
+	})
+	if err != nil {
+		return err
+	}
+	s.frame = texture
+	p.handles[handleKey{reflect.TypeOf(recorded.Texture), uint64(recorded.Texture)}] = uint64(texture)
+	return nil
+}
+
+func (p *player) present(call *TraceCall) error {
+	var surface types.Surface
+	if err := decode(call, &surface); err != nil {
+		return err
+	}
+	s := p.surfaces[surface]
+	if s == nil || s.frame == 0 {
+		return errors.New("no frame to present")
+	}
+	if p.onPresent != nil {
+		p.onPresent(s.frame)
+	}
+	return nil
+}
+
+func (p *player) writeMappedRange(call *TraceCall) error {
+	var buffer types.Buffer
+	var offset uint64
+	var data []byte
+	if err := decode(call, &buffer, &offset, &data); err != nil {
+		return err
+	}
+	dst := p.backend.GetMappedRange(mapHandle(p, buffer), offset, uint64(len(data)))
+	if len(dst) < len(data) {
+		return errors.New("buffer is not mapped")
+	}
+	copy(dst, data)
+	return nil
+}
+
+func (p *player) releaseSurface(call *TraceCall) error {
+	var surface types.Surface
+	if err := decode(call, &surface); err != nil {
+		return err
+	}
+	if s := p.surfaces[surface]; s != nil {
+		p.releaseFrame(s)
+		delete(p.surfaces, surface)
+	}
+	return nil
+}
+
+// releaseFrame releases the emulated texture of the current frame of s.
+func (p *player) releaseFrame(s *replaySurface) {
+	if s.frame != 0 {
+		p.backend.ReleaseTexture(s.frame)
+		s.frame = 0
+	}
+}
+
+// releaseFrames releases the emulated textures of all surfaces.
+func (p *player) releaseFrames() {
+	for _, s := range p.surfaces {
+		p.releaseFrame(s)
+	}
+}
@@ -0,0 +1,103 @@
+package gpu
+
+import "github.com/gogpu/gogpu/gpu/types"
+
+// RingAllocator suballocates a ring buffer of fixed size, such as a
+// staging buffer for per-frame uploads. Allocations are handed out in
+// order, wrapping to the start when the end is reached, and are freed in
+// the same order once the GPU is done with them: Fence ties the
+// allocations made since the previous Fence to a submission, and Release
+// frees those of completed submissions.
+//
+// RingAllocator is not safe for concurrent use.
+type RingAllocator struct {
+	size uint64
+	head uint64 // Offset of the next allocation
+	used uint64 // Bytes in use, ending at head, including padding
+
+	// Bytes allocated since the last Fence
+	unfenced uint64
+
+	// Bytes of each fenced submission, oldest first
+	fenced []ringFence
+}
+
+// ringFence is a run of allocations used by one submission.
+type ringFence struct {
+	size  uint64
+	index types.SubmissionIndex
+}
+
+// NewRingAllocator returns an allocator for a ring of size bytes.
+func NewRingAllocator(size uint64) *RingAllocator {
+	return &RingAllocator{size: size}
+}
+
+// Size returns the size of the ring in bytes.
+func (a *RingAllocator) Size() uint64 {
+	return a.size
+}
+
+// Used returns the number of bytes not yet released, including the
+// padding and space skipped when wrapping.
+func (a *RingAllocator) Used() uint64 {
+	return a.used
+}
+
+// Alloc returns the offset of size free bytes aligned to align, which is
+// a power of two or 0 for no alignment. It returns false if the ring has
+// no room until more is released.
+func (a *RingAllocator) Alloc(size, align uint64) (uint64, bool) {
+	if size == 0 || size > a.size {
+		return 0, false
+	}
+	if align == 0 {
+		align = 1
+	}
+
+	offset := alignUp(a.head, align)
+	if offset+size > a.size {
+		// Skip the rest of the ring and start over at offset 0
+		offset = 0
+	}
+	var taken uint64
+	if offset >= a.head {
+		taken = offset + size - a.head
+	} else {
+		taken = a.size - a.head + size
+	}
+	if a.used+taken > a.size {
+		return 0, false
+	}
+
+	a.head = (offset + size) % a.size
+	a.used += taken
+	a.unfenced += taken
+	return offset, true
+}
+
+// Fence marks the allocations made since the last Fence as used by
+// submission index. Index 0 stands for work that was never submitted, so
+// its allocations are freed by the next Release.
+func (a *RingAllocator) Fence(index types.SubmissionIndex) {
+	if a.unfenced == 0 {
+		return
+	}
+	a.fenced = append(a.fenced, ringFence{size: a.unfenced, index: index})
+	a.unfenced = 0
+}
+
+// Release frees the allocations of submissions up to and including
+// completed.
+func (a *RingAllocator) Release(completed types.SubmissionIndex) {
+	n := 0
+	for n < len(a.fenced) && a.fenced[n].index <= completed {
+		a.used -= a.fenced[n].size
+		n++
+	}
+	a.fenced = a.fenced[n:]
+	if a.used == 0 {
+		// Nothing in flight: start over to keep allocations contiguous
+		a.head = 0
+	}
+}
@@ -0,0 +1,61 @@
+package gpu
+
+import "testing"
+
+func TestRingAllocator(t *testing.T) {
+	a := NewRingAllocator(256)
+
+	alloc := func(size, align, want uint64) {
+		t.Helper()
+		offset, ok := a.Alloc(size, align)
+		if !ok || offset != want {
+			t.Fatalf("Alloc(%d, %d) = %d, %v, want %d, true", size, align, offset, ok, want)
+		}
+	}
+
+	alloc(100, 4, 0)
+	alloc(10, 16, 112)
+	a.Fence(1)
+	alloc(100, 4, 124)
+	a.Fence(2)
+	if got := a.Used(); got != 224 {
+		t.Errorf("Used() = %d, want 224", got)
+	}
+
+	// Full until submission 1 completes
+	if _, ok := a.Alloc(64, 4); ok {
+		t.Fatal("Alloc succeeded in a full ring")
+	}
+	a.Release(1)
+	if got := a.Used(); got != 102 {
+		t.Errorf("Used() after Release(1) = %d, want 102", got)
+	}
+
+	// Does not fit before the end, so wraps to the start
+	alloc(64, 4, 0)
+	a.Fence(3)
+	if got := a.Used(); got != 198 {
+		t.Errorf("Used() after wrapping = %d, want 198", got)
+	}
+
+	a.Release(3)
+	if got := a.Used(); got != 0 {
+		t.Errorf("Used() after releasing all = %d, want 0", got)
+	}
+	alloc(256, 0, 0)
+	if _, ok := a.Alloc(300, 0); ok {
+		t.Error("Alloc larger than the ring succeeded")
+	}
+}
+
+func TestRingAllocatorUnsubmitted(t *testing.T) {
+	a := NewRingAllocator(64)
+	if _, ok := a.Alloc(64, 0); !ok {
+		t.Fatal("Alloc of the whole ring failed")
+	}
+	a.Fence(0)
+	a.Release(0)
+	if got := a.Used(); got != 0 {
+		t.Errorf("Used() after releasing unsubmitted work = %d, want 0", got)
+	}
+}
@@ -0,0 +1,41 @@
+package gpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// MaxSamplerAnisotropy is the highest MaxAnisotropy a sampler uses.
+const MaxSamplerAnisotropy = 16
+
+// NormalizeSampler returns a copy of desc as backends pass it to the GPU:
+// MaxAnisotropy clamped to 1..MaxSamplerAnisotropy, and 1 unless all
+// filters are linear, as WebGPU requires. It fails when the LOD clamps
+// are out of order or AddressModeClampToBorder is used without
+// types.FeatureAddressModeClampToBorder in features.
+func NormalizeSampler(desc *types.SamplerDescriptor, features types.Features) (types.SamplerDescriptor, error) {
+	d := *desc
+	if d.LodMinClamp < 0 || d.LodMaxClamp < d.LodMinClamp {
+		return d, fmt.Errorf("gpu: invalid sampler LOD clamp %g..%g", d.LodMinClamp, d.LodMaxClamp)
+	}
+
+	if usesClampToBorder(&d) && !features.Has(types.FeatureAddressModeClampToBorder) {
+		return d, fmt.Errorf("gpu: AddressModeClampToBorder requires FeatureAddressModeClampToBorder")
+	}
+
+	d.MaxAnisotropy = min(max(d.MaxAnisotropy, 1), MaxSamplerAnisotropy)
+	if d.MagFilter != types.FilterModeLinear || d.MinFilter != types.FilterModeLinear ||
+		d.MipmapFilter != types.MipmapFilterModeLinear {
+		d.MaxAnisotropy = 1
+	}
+	return d, nil
+}
+
+// usesClampToBorder reports whether a sampler returns the border color in
+// any direction.
+func usesClampToBorder(desc *types.SamplerDescriptor) bool {
+	return desc.AddressModeU == types.AddressModeClampToBorder ||
+		desc.AddressModeV == types.AddressModeClampToBorder ||
+		desc.AddressModeW == types.AddressModeClampToBorder
+}
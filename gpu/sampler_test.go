@@ -0,0 +1,66 @@
+package gpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestNormalizeSamplerAnisotropy(t *testing.T) {
+	linear := types.SamplerDescriptor{
+		MagFilter:    types.FilterModeLinear,
+		MinFilter:    types.FilterModeLinear,
+		MipmapFilter: types.MipmapFilterModeLinear,
+		LodMaxClamp:  32,
+	}
+	nearest := linear
+	nearest.MinFilter = types.FilterModeNearest
+
+	tests := []struct {
+		name       string
+		desc       types.SamplerDescriptor
+		anisotropy uint16
+		want       uint16
+	}{
+		{"unset", linear, 0, 1},
+		{"in range", linear, 8, 8},
+		{"clamped", linear, 64, MaxSamplerAnisotropy},
+		{"nearest filter", nearest, 8, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := tt.desc
+			desc.MaxAnisotropy = tt.anisotropy
+			got, err := NormalizeSampler(&desc, 0)
+			if err != nil {
+				t.Fatalf("NormalizeSampler: %v", err)
+			}
+			if got.MaxAnisotropy != tt.want {
+				t.Errorf("MaxAnisotropy = %d, want %d", got.MaxAnisotropy, tt.want)
+			}
+			if desc.MaxAnisotropy != tt.anisotropy {
+				t.Errorf("NormalizeSampler modified desc")
+			}
+		})
+	}
+}
+
+func TestNormalizeSamplerErrors(t *testing.T) {
+	border := types.SamplerDescriptor{
+		AddressModeU: types.AddressModeClampToBorder,
+		LodMaxClamp:  32,
+		BorderColor:  types.SamplerBorderColorOpaqueWhite,
+	}
+	if _, err := NormalizeSampler(&border, 0); err == nil {
+		t.Error("ClampToBorder without the feature: no error")
+	}
+	if _, err := NormalizeSampler(&border, types.FeatureAddressModeClampToBorder); err != nil {
+		t.Errorf("ClampToBorder with the feature: %v", err)
+	}
+
+	lod := types.SamplerDescriptor{LodMinClamp: 4, LodMaxClamp: 2}
+	if _, err := NormalizeSampler(&lod, 0); err == nil {
+		t.Error("LodMinClamp > LodMaxClamp: no error")
+	}
+}
@@ -0,0 +1,34 @@
+package gpu
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// spirvMagic is the first word of every SPIR-V module.
+const spirvMagic = 0x07230203
+
+// ParseSPIRV returns the words of a SPIR-V binary, such as the output of
+// glslangValidator or glslc, for CreateShaderModuleSPIRV. Binaries of
+// either byte order are accepted.
+func ParseSPIRV(code []byte) ([]uint32, error) {
+	if len(code) < 20 || len(code)%4 != 0 {
+		return nil, fmt.Errorf("gpu: SPIR-V binary of %d bytes is not a whole number of words with a header", len(code))
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(code) == spirvMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(code) == spirvMagic:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("gpu: not a SPIR-V binary, magic number %#08x", binary.LittleEndian.Uint32(code))
+	}
+
+	words := make([]uint32, len(code)/4)
+	for i := range words {
+		words[i] = order.Uint32(code[i*4:])
+	}
+	return words, nil
+}
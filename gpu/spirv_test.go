@@ -0,0 +1,36 @@
+package gpu
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestParseSPIRV(t *testing.T) {
+	// Header: magic, version 1.0, generator, bound, schema
+	want := []uint32{spirvMagic, 0x00010000, 0, 1, 0}
+
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+		var code []byte
+		for _, w := range want {
+			code = order.AppendUint32(code, w)
+		}
+		got, err := ParseSPIRV(code)
+		if err != nil {
+			t.Fatalf("%v: ParseSPIRV: %v", order, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%v: ParseSPIRV = %#x, want %#x", order, got, want)
+		}
+	}
+
+	for name, code := range map[string][]byte{
+		"empty":     nil,
+		"truncated": make([]byte, 22),
+		"not spirv": []byte("@vertex fn main() {} "),
+	} {
+		if _, err := ParseSPIRV(code); err == nil {
+			t.Errorf("%s: ParseSPIRV did not fail", name)
+		}
+	}
+}
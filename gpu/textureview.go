@@ -0,0 +1,67 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// ErrTextureViewFormat is returned for a texture view whose format or
+// aspect does not fit the texture.
+var ErrTextureViewFormat = errors.New("gpu: texture view format does not fit the texture")
+
+// ValidateViewFormats checks the ViewFormats of a texture of format,
+// which WebGPU limits to its sRGB or linear variant.
+func ValidateViewFormats(format types.TextureFormat, viewFormats []types.TextureFormat) error {
+	for _, f := range viewFormats {
+		if f.Linear() != format.Linear() {
+			return fmt.Errorf("%w: %#x cannot view a texture of format %#x", ErrTextureViewFormat, uint32(f), uint32(format))
+		}
+	}
+	return nil
+}
+
+// ValidateTextureView checks desc for a view of a texture of format
+// created with viewFormats: the aspect must exist in the format, and the
+// view format must be undefined, the format of the viewed aspect, or one
+// of viewFormats.
+func ValidateTextureView(format types.TextureFormat, viewFormats []types.TextureFormat, desc *types.TextureViewDescriptor) error {
+	switch desc.Aspect {
+	case types.TextureAspectAll:
+	case types.TextureAspectDepthOnly:
+		if !format.HasDepth() {
+			return fmt.Errorf("%w: depth-only view of format %#x without depth", ErrTextureViewFormat, uint32(format))
+		}
+	case types.TextureAspectStencilOnly:
+		if !format.HasStencil() {
+			return fmt.Errorf("%w: stencil-only view of format %#x without stencil", ErrTextureViewFormat, uint32(format))
+		}
+	default:
+		return fmt.Errorf("%w: unknown aspect %d", ErrTextureViewFormat, desc.Aspect)
+	}
+
+	if desc.Format == 0 || desc.Format == format || desc.Format == AspectFormat(format, desc.Aspect) {
+		return nil
+	}
+	if !slices.Contains(viewFormats, desc.Format) {
+		return fmt.Errorf("%w: %#x is not a view format of a texture of format %#x", ErrTextureViewFormat, uint32(desc.Format), uint32(format))
+	}
+	return nil
+}
+
+// AspectFormat returns the format of one aspect of a depth-stencil
+// format, e.g. Depth24Plus for the depth of Depth24PlusStencil8. Other
+// formats are returned as they are.
+func AspectFormat(format types.TextureFormat, aspect types.TextureAspect) types.TextureFormat {
+	switch {
+	case aspect == types.TextureAspectStencilOnly && format.HasStencil():
+		return types.TextureFormatStencil8
+	case aspect == types.TextureAspectDepthOnly && format == types.TextureFormatDepth24PlusStencil8:
+		return types.TextureFormatDepth24Plus
+	case aspect == types.TextureAspectDepthOnly && format == types.TextureFormatDepth32FloatStencil8:
+		return types.TextureFormatDepth32Float
+	}
+	return format
+}
@@ -0,0 +1,47 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestValidateViewFormats(t *testing.T) {
+	srgb := []types.TextureFormat{types.TextureFormatRGBA8UnormSrgb}
+	if err := ValidateViewFormats(types.TextureFormatRGBA8Unorm, srgb); err != nil {
+		t.Errorf("sRGB view format rejected: %v", err)
+	}
+	bgra := []types.TextureFormat{types.TextureFormatBGRA8Unorm}
+	if err := ValidateViewFormats(types.TextureFormatRGBA8Unorm, bgra); !errors.Is(err, ErrTextureViewFormat) {
+		t.Errorf("BGRA view of an RGBA texture: err = %v, want ErrTextureViewFormat", err)
+	}
+}
+
+func TestValidateTextureView(t *testing.T) {
+	srgb := []types.TextureFormat{types.TextureFormatRGBA8UnormSrgb}
+	tests := []struct {
+		name        string
+		format      types.TextureFormat
+		viewFormats []types.TextureFormat
+		desc        types.TextureViewDescriptor
+		ok          bool
+	}{
+		{"default", types.TextureFormatRGBA8Unorm, nil, types.TextureViewDescriptor{}, true},
+		{"same format", types.TextureFormatRGBA8Unorm, nil, types.TextureViewDescriptor{Format: types.TextureFormatRGBA8Unorm}, true},
+		{"srgb listed", types.TextureFormatRGBA8Unorm, srgb, types.TextureViewDescriptor{Format: types.TextureFormatRGBA8UnormSrgb}, true},
+		{"srgb not listed", types.TextureFormatRGBA8Unorm, nil, types.TextureViewDescriptor{Format: types.TextureFormatRGBA8UnormSrgb}, false},
+		{"depth only", types.TextureFormatDepth24PlusStencil8, nil, types.TextureViewDescriptor{
+			Format: types.TextureFormatDepth24Plus, Aspect: types.TextureAspectDepthOnly}, true},
+		{"stencil only", types.TextureFormatDepth24PlusStencil8, nil, types.TextureViewDescriptor{
+			Format: types.TextureFormatStencil8, Aspect: types.TextureAspectStencilOnly}, true},
+		{"depth of color", types.TextureFormatRGBA8Unorm, nil, types.TextureViewDescriptor{Aspect: types.TextureAspectDepthOnly}, false},
+		{"stencil of depth", types.TextureFormatDepth32Float, nil, types.TextureViewDescriptor{Aspect: types.TextureAspectStencilOnly}, false},
+	}
+	for _, tt := range tests {
+		err := ValidateTextureView(tt.format, tt.viewFormats, &tt.desc)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package gpu
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// Trace is a recording of Backend calls, made by a Recorder and played
+// back with Replay, e.g. to reproduce a frame that renders wrongly on
+// another machine.
+type Trace struct {
+	Calls []TraceCall
+}
+
+// TraceCall is one recorded Backend call. Arguments and results are
+// JSON; callbacks are recorded as null and errors not at all.
+type TraceCall struct {
+	Method  string            `json:"method"`
+	Args    []json.RawMessage `json:"args,omitempty"`
+	Results []json.RawMessage `json:"results,omitempty"`
+}
+
+// Encode writes the trace as JSON, one call per line.
+func (t *Trace) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for i := range t.Calls {
+		if err := enc.Encode(&t.Calls[i]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeTrace reads a trace written by Trace.Encode.
+func DecodeTrace(r io.Reader) (*Trace, error) {
+	t := &Trace{}
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var call TraceCall
+		if err := dec.Decode(&call); err != nil {
+			return nil, fmt.Errorf("gpu: invalid trace call %d: %w", len(t.Calls), err)
+		}
+		t.Calls = append(t.Calls, call)
+	}
+	return t, nil
+}
+
+// Recorder is a Backend that forwards every call to another backend and
+// records it in a Trace. Handles are recorded as the wrapped backend
+// returns them; Replay maps them to the handles of the backend it plays
+// the trace on.
+//
+// Data written into mapped buffer ranges is recorded when the buffer is
+// unmapped. The optional interfaces of the wrapped backend, such as
+// RejectionReporter, are not forwarded.
+type Recorder struct {
+	backend Backend
+
+	mu     sync.Mutex
+	trace  Trace
+	err    error                          // First argument that could not be recorded
+	mapped map[types.Buffer][]mappedRange // Ranges returned by GetMappedRange
+}
+
+// mappedRange is a mapped buffer range handed out by GetMappedRange.
+type mappedRange struct {
+	offset uint64
+	data   []byte
+}
+
+// NewRecorder creates a Recorder forwarding to backend.
+func NewRecorder(backend Backend) *Recorder {
+	return &Recorder{backend: backend, mapped: make(map[types.Buffer][]mappedRange)}
+}
+
+// Backend returns the backend the recorder forwards to.
+func (r *Recorder) Backend() Backend {
+	return r.backend
+}
+
+// Trace returns a copy of the calls recorded so far.
+func (r *Recorder) Trace() *Trace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Trace{Calls: slices.Clone(r.trace.Calls)}
+}
+
+// Err returns the first error encoding a call, such as a NaN float
+// argument, which JSON cannot represent. The trace records such
+// arguments as null.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record appends a call without results.
+func (r *Recorder) record(method string, args []any) {
+	r.begin(method, args)
+}
+
+// begin appends a call and returns its index for end. Calls are recorded
+// before they are forwarded, so calls made from callbacks during the
+// call, such as in Poll, follow it in the trace.
+func (r *Recorder) begin(method string, args []any) int {
+	call := TraceCall{Method: method}
+	if len(args) > 0 {
+		call.Args = r.encode(args)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Calls = append(r.trace.Calls, call)
+	return len(r.trace.Calls) - 1
+}
+
+// end records the results of call i.
+func (r *Recorder) end(i int, results ...any) {
+	encoded := r.encode(results)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trace.Calls[i].Results = encoded
+}
+
+// encode encodes values, recording null for those JSON cannot represent.
+func (r *Recorder) encode(values []any) []json.RawMessage {
+	encoded := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			r.mu.Lock()
+			if r.err == nil {
+				r.err = fmt.Errorf("gpu: cannot record argument: %w", err)
+			}
+			r.mu.Unlock()
+			data = []byte("null")
+		}
+		encoded[i] = data
+	}
+	return encoded
+}
+
+func (r *Recorder) Name() string {
+	return r.backend.Name()
+}
+
+func (r *Recorder) GetMappedRange(buffer types.Buffer, offset, size uint64) []byte {
+	r.record("GetMappedRange", []any{buffer, offset, size})
+	data := r.backend.GetMappedRange(buffer, offset, size)
+	if data != nil {
+		r.mu.Lock()
+		r.mapped[buffer] = append(r.mapped[buffer], mappedRange{offset: offset, data: data})
+		r.mu.Unlock()
+	}
+	return data
+}
+
+func (r *Recorder) UnmapBuffer(buffer types.Buffer) {
+	// What the application wrote into the ranges is only known now
+	r.mu.Lock()
+	ranges := r.mapped[buffer]
+	delete(r.mapped, buffer)
+	r.mu.Unlock()
+	for _, m := range ranges {
+		r.record(writeMappedRange, []any{buffer, m.offset, m.data})
+	}
+
+	r.record("UnmapBuffer", []any{buffer})
+	r.backend.UnmapBuffer(buffer)
+}
+
+// writeMappedRange is the pseudo call recording the contents of a mapped
+// range at UnmapBuffer, with the buffer, offset and data as arguments.
+const writeMappedRange = "WriteMappedRange"
+
+var _ Backend = (*Recorder)(nil)
@@ -0,0 +1,116 @@
+package gpu
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// handleBackend hands out distinct handles starting at base and records
+// what is written and rendered to.
+type handleBackend struct {
+	mockBackend
+	base   uintptr
+	next   uintptr
+	writes map[types.Buffer][]byte
+	views  []types.TextureView // Color attachments of render passes
+	mapped []byte
+}
+
+func (b *handleBackend) handle() uintptr {
+	b.next++
+	return b.base + b.next
+}
+
+func (b *handleBackend) RequestDevice(types.Adapter, *types.DeviceOptions) (types.Device, error) {
+	return types.Device(b.handle()), nil
+}
+
+func (b *handleBackend) CreateBuffer(types.Device, *types.BufferDescriptor) (types.Buffer, error) {
+	return types.Buffer(b.handle()), nil
+}
+
+func (b *handleBackend) CreateTexture(types.Device, *types.TextureDescriptor) (types.Texture, error) {
+	return types.Texture(b.handle()), nil
+}
+
+func (b *handleBackend) GetCurrentTexture(types.Surface) (types.SurfaceTexture, error) {
+	return types.SurfaceTexture{Texture: types.Texture(b.handle())}, nil
+}
+
+func (b *handleBackend) CreateTextureView(texture types.Texture, _ *types.TextureViewDescriptor) types.TextureView {
+	return types.TextureView(texture) // Tells which texture was viewed
+}
+
+func (b *handleBackend) WriteBuffer(_ types.Queue, buffer types.Buffer, _ uint64, data []byte) {
+	if b.writes == nil {
+		b.writes = make(map[types.Buffer][]byte)
+	}
+	b.writes[buffer] = slices.Clone(data)
+}
+
+func (b *handleBackend) BeginRenderPass(_ types.CommandEncoder, desc *types.RenderPassDescriptor) types.RenderPass {
+	for _, a := range desc.ColorAttachments {
+		b.views = append(b.views, a.View)
+	}
+	return 1
+}
+
+func (b *handleBackend) GetMappedRange(_ types.Buffer, _, size uint64) []byte {
+	b.mapped = make([]byte, size)
+	return b.mapped
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	r := NewRecorder(&handleBackend{base: 100})
+
+	device, _ := r.RequestDevice(1, nil)
+	buffer, _ := r.CreateBuffer(device, &types.BufferDescriptor{Size: 4})
+	r.WriteBuffer(1, buffer, 0, []byte{1, 2, 3, 4})
+	staging, _ := r.CreateBuffer(device, &types.BufferDescriptor{Size: 2})
+	copy(r.GetMappedRange(staging, 0, 2), []byte{5, 6})
+	r.UnmapBuffer(staging)
+
+	surface, _ := r.CreateSurface(1, types.SurfaceHandle{})
+	r.ConfigureSurface(surface, device, &types.SurfaceConfig{Format: types.TextureFormatBGRA8Unorm, Width: 8, Height: 8})
+	frame, _ := r.GetCurrentTexture(surface)
+	view := r.CreateTextureView(frame.Texture, nil)
+	r.BeginRenderPass(1, &types.RenderPassDescriptor{ColorAttachments: []types.ColorAttachment{{View: view}}})
+	r.Present(surface)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Trace().Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	trace, err := DecodeTrace(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replay := &handleBackend{base: 500}
+	var presented []types.Texture
+	if err := Replay(replay, trace, func(texture types.Texture) {
+		presented = append(presented, texture)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Device 501, buffers 502 and 503, surface texture 504
+	if got := replay.writes[502]; !bytes.Equal(got, []byte{1, 2, 3, 4}) {
+		t.Errorf("buffer 502 written with %v, want [1 2 3 4]", got)
+	}
+	if !bytes.Equal(replay.mapped, []byte{5, 6}) {
+		t.Errorf("mapped range = %v, want [5 6]", replay.mapped)
+	}
+	if want := []types.TextureView{504}; !slices.Equal(replay.views, want) {
+		t.Errorf("render pass views = %v, want %v", replay.views, want)
+	}
+	if want := []types.Texture{504}; !slices.Equal(presented, want) {
+		t.Errorf("presented textures = %v, want %v", presented, want)
+	}
+}
@@ -7,7 +7,20 @@ type AdapterOptions struct {
 
 // DeviceOptions configures device request.
 type DeviceOptions struct {
-	Label string
+	Label            string
+	RequiredFeatures Features // Must be supported by the adapter
+	RequiredLimits   *Limits  // nil for DefaultLimits
+}
+
+// AdapterInfo describes a GPU adapter.
+type AdapterInfo struct {
+	Name        string
+	Vendor      string
+	Driver      string
+	VendorID    uint32
+	DeviceID    uint32
+	Backend     GraphicsAPI
+	AdapterType AdapterType
 }
 
 // SurfaceConfig configures surface presentation.
@@ -18,6 +31,21 @@ type SurfaceConfig struct {
 	Height      uint32
 	PresentMode PresentMode
 	AlphaMode   AlphaMode
+
+	// ViewFormats lists other formats the surface textures may be viewed
+	// in, e.g. the sRGB variant of a linear Format, see SrgbPolicy. Only
+	// the web backend supports them; the surface configurations of
+	// gogpu/wgpu v0.8.6 and go-webgpu v0.1.3 have no view formats.
+	ViewFormats []TextureFormat
+}
+
+// SurfaceCapabilities lists what a surface supports with an adapter,
+// in the backend's order of preference. Empty lists mean the backend
+// could not query them.
+type SurfaceCapabilities struct {
+	Formats      []TextureFormat
+	PresentModes []PresentMode
+	AlphaModes   []AlphaMode
 }
 
 // TextureDescriptor describes a texture to create.
@@ -29,6 +57,10 @@ type TextureDescriptor struct {
 	Dimension     TextureDimension
 	Format        TextureFormat
 	Usage         TextureUsage
+
+	// ViewFormats lists the other formats views of the texture may have,
+	// the sRGB or linear variant of Format, see TextureFormat.Srgb.
+	ViewFormats []TextureFormat
 }
 
 // Extent3D represents 3D dimensions.
@@ -47,8 +79,31 @@ const (
 	TextureDimension3D TextureDimension = 0x02
 )
 
+// DefaultViewDimension returns the view dimension of a texture view
+// covering a whole texture of the dimension: an array view for 2D
+// textures with more than one layer. Cube views must be requested
+// explicitly.
+func DefaultViewDimension(dimension TextureDimension, depthOrArrayLayers uint32) TextureViewDimension {
+	switch dimension {
+	case TextureDimension1D:
+		return TextureViewDimension1D
+	case TextureDimension3D:
+		return TextureViewDimension3D
+	default:
+		if depthOrArrayLayers > 1 {
+			return TextureViewDimension2DArray
+		}
+		return TextureViewDimension2D
+	}
+}
+
 // TextureViewDescriptor describes how to create a texture view.
+// Zero counts select all mip levels or array layers from the base on,
+// and an undefined dimension the texture's default. An undefined Format
+// is the texture's format, or that of the viewed aspect, and another
+// format must be in the texture's ViewFormats.
 type TextureViewDescriptor struct {
+	Label           string
 	Format          TextureFormat
 	Dimension       TextureViewDimension
 	BaseMipLevel    uint32
@@ -83,21 +138,105 @@ const (
 // RenderPipelineDescriptor describes a render pipeline.
 type RenderPipelineDescriptor struct {
 	Label            string
+	Layout           PipelineLayout // 0 to derive the layout from the shaders
 	VertexShader     ShaderModule
 	VertexEntryPoint string
-	FragmentShader   ShaderModule
+	VertexBuffers    []VertexBufferLayout
+	FragmentShader   ShaderModule // 0 for a pipeline without fragment stage
 	FragmentEntry    string
-	TargetFormat     TextureFormat
+	TargetFormat     TextureFormat // Single target without blending, if Targets is empty
+	Targets          []ColorTargetState
 	Topology         PrimitiveTopology
 	FrontFace        FrontFace
 	CullMode         CullMode
+	Multisample      MultisampleState
+}
+
+// ColorTargets returns the color targets of the pipeline: Targets, or a
+// single target of TargetFormat without blending. Zero write masks are
+// replaced with ColorWriteMaskAll.
+func (d *RenderPipelineDescriptor) ColorTargets() []ColorTargetState {
+	if len(d.Targets) == 0 {
+		return []ColorTargetState{{Format: d.TargetFormat, WriteMask: ColorWriteMaskAll}}
+	}
+
+	targets := make([]ColorTargetState, len(d.Targets))
+	for i, target := range d.Targets {
+		if target.WriteMask == ColorWriteMaskNone {
+			target.WriteMask = ColorWriteMaskAll
+		}
+		targets[i] = target
+	}
+	return targets
+}
+
+// ColorTargetState describes a color target of a render pipeline.
+type ColorTargetState struct {
+	Format    TextureFormat
+	Blend     *BlendState    // nil replaces the target color
+	WriteMask ColorWriteMask // 0 writes all channels
+}
+
+// BlendState describes how fragment colors are blended with the target.
+type BlendState struct {
+	Color BlendComponent
+	Alpha BlendComponent
+}
+
+// BlendComponent describes the blend equation of the color or alpha
+// channels: src*SrcFactor <Operation> dst*DstFactor.
+type BlendComponent struct {
+	Operation BlendOperation
+	SrcFactor BlendFactor
+	DstFactor BlendFactor
+}
+
+// Common blend states.
+var (
+	// BlendStateAlpha blends straight (non-premultiplied) alpha colors.
+	BlendStateAlpha = BlendState{
+		Color: BlendComponent{BlendOperationAdd, BlendFactorSrcAlpha, BlendFactorOneMinusSrcAlpha},
+		Alpha: BlendComponent{BlendOperationAdd, BlendFactorOne, BlendFactorOneMinusSrcAlpha},
+	}
+
+	// BlendStatePremultipliedAlpha blends premultiplied alpha colors.
+	BlendStatePremultipliedAlpha = BlendState{
+		Color: BlendComponent{BlendOperationAdd, BlendFactorOne, BlendFactorOneMinusSrcAlpha},
+		Alpha: BlendComponent{BlendOperationAdd, BlendFactorOne, BlendFactorOneMinusSrcAlpha},
+	}
+
+	// BlendStateAdditive adds colors to the target.
+	BlendStateAdditive = BlendState{
+		Color: BlendComponent{BlendOperationAdd, BlendFactorOne, BlendFactorOne},
+		Alpha: BlendComponent{BlendOperationAdd, BlendFactorOne, BlendFactorOne},
+	}
+)
+
+// MultisampleState describes multisampling of a render pipeline.
+type MultisampleState struct {
+	Count                  uint32 // Samples per pixel; 0 means 1
+	Mask                   uint32 // Enabled samples; 0 enables all
+	AlphaToCoverageEnabled bool
+}
+
+// WithDefaults returns the state with zero fields replaced by their
+// defaults: one sample and all samples enabled.
+func (m MultisampleState) WithDefaults() MultisampleState {
+	if m.Count == 0 {
+		m.Count = 1
+	}
+	if m.Mask == 0 {
+		m.Mask = 0xFFFFFFFF
+	}
+	return m
 }
 
 // RenderPassDescriptor describes a render pass.
 type RenderPassDescriptor struct {
-	Label            string
-	ColorAttachments []ColorAttachment
-	DepthStencil     *DepthStencilAttachment
+	Label             string
+	ColorAttachments  []ColorAttachment
+	DepthStencil      *DepthStencilAttachment
+	OcclusionQuerySet QuerySet // Target of occlusion queries, 0 if unused
 }
 
 // ColorAttachment describes a color render target.
@@ -120,6 +259,54 @@ type DepthStencilAttachment struct {
 	StencilClearValue uint32
 }
 
+// RenderBundleEncoderDescriptor describes a render bundle encoder. The
+// formats and sample count must match the render passes that execute
+// the bundle.
+type RenderBundleEncoderDescriptor struct {
+	Label              string
+	ColorFormats       []TextureFormat
+	DepthStencilFormat TextureFormat // Undefined without depth/stencil attachment
+	SampleCount        uint32        // 0 means 1
+	DepthReadOnly      bool
+	StencilReadOnly    bool
+}
+
+// ComputePipelineDescriptor describes a compute pipeline.
+type ComputePipelineDescriptor struct {
+	Label      string
+	Layout     PipelineLayout // 0 to derive the layout from the shader
+	Module     ShaderModule
+	EntryPoint string
+}
+
+// ComputePassDescriptor describes a compute pass.
+type ComputePassDescriptor struct {
+	Label string
+}
+
+// QuerySetDescriptor describes a query set to create.
+type QuerySetDescriptor struct {
+	Label string
+	Type  QueryType
+	Count uint32
+}
+
+// QueryType specifies the kind of queries in a query set.
+type QueryType uint32
+
+const (
+	// QueryTypeOcclusion counts the samples that pass the depth and
+	// stencil tests between BeginOcclusionQuery and EndOcclusionQuery.
+	QueryTypeOcclusion QueryType = 0x01
+	// QueryTypeTimestamp records GPU timestamps in nanoseconds.
+	// Requires the timestamp query feature of the device.
+	QueryTypeTimestamp QueryType = 0x02
+)
+
+// QueryResultSize is the size in bytes of one resolved query result,
+// a uint64.
+const QueryResultSize = 8
+
 // Color represents an RGBA color with float64 components.
 // Values are typically in range [0.0, 1.0].
 type Color struct {
@@ -150,19 +337,40 @@ const (
 	BufferUsageQueryResolve BufferUsage = 0x0200
 )
 
+// MapMode specifies how a buffer is mapped for CPU access.
+type MapMode uint32
+
+const (
+	MapModeNone  MapMode = 0x0000
+	MapModeRead  MapMode = 0x0001
+	MapModeWrite MapMode = 0x0002
+)
+
 // SamplerDescriptor describes a sampler to create.
 type SamplerDescriptor struct {
-	Label         string
-	AddressModeU  AddressMode
-	AddressModeV  AddressMode
-	AddressModeW  AddressMode
-	MagFilter     FilterMode
-	MinFilter     FilterMode
-	MipmapFilter  MipmapFilterMode
-	LodMinClamp   float32
-	LodMaxClamp   float32
-	Compare       CompareFunction
+	Label        string
+	AddressModeU AddressMode
+	AddressModeV AddressMode
+	AddressModeW AddressMode
+	MagFilter    FilterMode
+	MinFilter    FilterMode
+	MipmapFilter MipmapFilterMode
+	LodMinClamp  float32
+	LodMaxClamp  float32
+
+	// Compare makes a comparison sampler, e.g. for shadow maps: sampling
+	// a depth texture returns the result of comparing the reference value
+	// with the texel. Bind it with SamplerBindingTypeComparison.
+	Compare CompareFunction
+
+	// MaxAnisotropy enables anisotropic filtering with up to this many
+	// samples. It is clamped to 1..16 and only takes effect when all
+	// filters are linear.
 	MaxAnisotropy uint16
+
+	// BorderColor is returned for coordinates outside the texture in
+	// directions using AddressModeClampToBorder.
+	BorderColor SamplerBorderColor
 }
 
 // AddressMode specifies texture coordinate wrapping behavior.
@@ -172,6 +380,20 @@ const (
 	AddressModeClampToEdge AddressMode = iota
 	AddressModeRepeat
 	AddressModeMirrorRepeat
+
+	// AddressModeClampToBorder returns SamplerDescriptor.BorderColor
+	// outside the texture. It is a native extension, not in WebGPU, and
+	// requires FeatureAddressModeClampToBorder.
+	AddressModeClampToBorder
+)
+
+// SamplerBorderColor is the color of AddressModeClampToBorder.
+type SamplerBorderColor uint32
+
+const (
+	SamplerBorderColorTransparentBlack SamplerBorderColor = iota
+	SamplerBorderColorOpaqueBlack
+	SamplerBorderColorOpaqueWhite
 )
 
 // FilterMode specifies texture sampling filter.
@@ -218,6 +440,8 @@ type BindGroupLayoutEntry struct {
 	Buffer     *BufferBindingLayout
 	Sampler    *SamplerBindingLayout
 	Texture    *TextureBindingLayout
+
+	StorageTexture *StorageTextureBindingLayout
 }
 
 // ShaderStage flags indicate which shader stages can access a resource.
@@ -281,6 +505,25 @@ const (
 	TextureSampleTypeUint
 )
 
+// StorageTextureBindingLayout describes a storage texture binding, which
+// shaders read and write without a sampler. The texture needs
+// TextureUsageStorageBinding.
+type StorageTextureBindingLayout struct {
+	Access        StorageTextureAccess
+	Format        TextureFormat
+	ViewDimension TextureViewDimension
+}
+
+// StorageTextureAccess specifies how shaders access a storage texture.
+type StorageTextureAccess uint32
+
+const (
+	StorageTextureAccessUndefined StorageTextureAccess = iota
+	StorageTextureAccessWriteOnly
+	StorageTextureAccessReadOnly
+	StorageTextureAccessReadWrite
+)
+
 // BindGroupDescriptor describes a bind group to create.
 type BindGroupDescriptor struct {
 	Label   string
@@ -300,8 +543,17 @@ type BindGroupEntry struct {
 
 // PipelineLayoutDescriptor describes a pipeline layout.
 type PipelineLayoutDescriptor struct {
-	Label            string
-	BindGroupLayouts []BindGroupLayout
+	Label              string
+	BindGroupLayouts   []BindGroupLayout
+	PushConstantRanges []PushConstantRange // Requires FeaturePushConstants
+}
+
+// PushConstantRange is a byte range of push constants visible to the
+// shader stages.
+type PushConstantRange struct {
+	Stages ShaderStage
+	Start  uint32
+	End    uint32
 }
 
 // ImageCopyTexture identifies a texture subresource for copy operations.
@@ -324,6 +576,20 @@ type ImageDataLayout struct {
 	RowsPerImage uint32
 }
 
+// ImageCopyBuffer identifies image data in a buffer for copy operations.
+type ImageCopyBuffer struct {
+	Buffer Buffer
+	Layout ImageDataLayout // BytesPerRow must be a multiple of CopyBytesPerRowAlignment
+}
+
+// CopyBytesPerRowAlignment is the alignment of BytesPerRow in copies
+// between textures and buffers.
+const CopyBytesPerRowAlignment = 256
+
+// CopyBufferAlignment is the alignment of offsets and sizes in copies
+// between buffers and in ClearBuffer.
+const CopyBufferAlignment = 4
+
 // VertexBufferLayout describes vertex buffer layout for a pipeline.
 type VertexBufferLayout struct {
 	ArrayStride uint64
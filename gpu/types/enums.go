@@ -15,6 +15,10 @@ const (
 	// BackendGo uses pure Go WebGPU implementation (gogpu/wgpu).
 	// Zero dependencies, just `go build`, may be slower.
 	BackendGo
+
+	// BackendWeb uses the browser's WebGPU (navigator.gpu) via
+	// syscall/js. Only available when built for js/wasm.
+	BackendWeb
 )
 
 // String returns the backend name.
@@ -24,6 +28,8 @@ func (b BackendType) String() string {
 		return "Rust (wgpu-native)"
 	case BackendGo:
 		return "Pure Go"
+	case BackendWeb:
+		return "WebGPU (browser)"
 	default:
 		return "Auto"
 	}
@@ -34,8 +40,82 @@ func (b BackendType) String() string {
 type TextureFormat uint32
 
 const (
-	TextureFormatRGBA8Unorm TextureFormat = 0x12
-	TextureFormatBGRA8Unorm TextureFormat = 0x17
+	TextureFormatRGBA8Unorm     TextureFormat = 0x12
+	TextureFormatRGBA8UnormSrgb TextureFormat = 0x13
+	TextureFormatBGRA8Unorm     TextureFormat = 0x17
+	TextureFormatBGRA8UnormSrgb TextureFormat = 0x18
+	TextureFormatRGB10A2Unorm   TextureFormat = 0x1A
+	TextureFormatRGBA16Float    TextureFormat = 0x22
+
+	// Depth and stencil formats
+	TextureFormatStencil8             TextureFormat = 0x26
+	TextureFormatDepth16Unorm         TextureFormat = 0x27
+	TextureFormatDepth24Plus          TextureFormat = 0x28
+	TextureFormatDepth24PlusStencil8  TextureFormat = 0x29
+	TextureFormatDepth32Float         TextureFormat = 0x2A
+	TextureFormatDepth32FloatStencil8 TextureFormat = 0x2B // Requires FeatureDepth32FloatStencil8
+)
+
+// HasDepth reports whether the format has a depth aspect.
+func (f TextureFormat) HasDepth() bool {
+	switch f {
+	case TextureFormatDepth16Unorm, TextureFormatDepth24Plus, TextureFormatDepth24PlusStencil8,
+		TextureFormatDepth32Float, TextureFormatDepth32FloatStencil8:
+		return true
+	}
+	return false
+}
+
+// HasStencil reports whether the format has a stencil aspect.
+func (f TextureFormat) HasStencil() bool {
+	switch f {
+	case TextureFormatStencil8, TextureFormatDepth24PlusStencil8, TextureFormatDepth32FloatStencil8:
+		return true
+	}
+	return false
+}
+
+// Srgb returns the sRGB variant of the format, or f if it has none.
+func (f TextureFormat) Srgb() TextureFormat {
+	switch f {
+	case TextureFormatRGBA8Unorm:
+		return TextureFormatRGBA8UnormSrgb
+	case TextureFormatBGRA8Unorm:
+		return TextureFormatBGRA8UnormSrgb
+	}
+	return f
+}
+
+// Linear returns the format without sRGB encoding, or f if it is not an
+// sRGB format.
+func (f TextureFormat) Linear() TextureFormat {
+	switch f {
+	case TextureFormatRGBA8UnormSrgb:
+		return TextureFormatRGBA8Unorm
+	case TextureFormatBGRA8UnormSrgb:
+		return TextureFormatBGRA8Unorm
+	}
+	return f
+}
+
+// SrgbPolicy selects how colors written by shaders are encoded for the
+// display. Colors are usually given in sRGB, like gmath.Color, while
+// lighting and blending are correct only on linear values.
+type SrgbPolicy uint8
+
+const (
+	// SrgbPolicyPassthrough draws into a linear (Unorm) surface format
+	// where available, so values written by shaders reach the display
+	// unchanged and sRGB colors look as specified, on every backend.
+	// Blending operates on the encoded values.
+	SrgbPolicyPassthrough SrgbPolicy = iota
+
+	// SrgbPolicyEncode draws into an sRGB format, the surface's own or an
+	// sRGB view of a linear surface, so the GPU encodes the linear values
+	// shaders write and blending is gamma-correct. sRGB colors must be
+	// converted with gmath.Color.ToLinear first, as the renderer does for
+	// clear colors.
+	SrgbPolicyEncode
 )
 
 // TextureUsage specifies how a texture can be used.
@@ -78,6 +158,140 @@ const (
 	PowerPreferenceHighPerformance
 )
 
+// GraphicsAPI specifies the native graphics API an adapter runs on.
+type GraphicsAPI uint32
+
+const (
+	GraphicsAPIUndefined GraphicsAPI = iota
+	GraphicsAPIVulkan
+	GraphicsAPIMetal
+	GraphicsAPID3D12
+	GraphicsAPIOpenGL
+	GraphicsAPIOpenGLES
+	GraphicsAPIWebGPU
+)
+
+// String returns the API name.
+func (g GraphicsAPI) String() string {
+	switch g {
+	case GraphicsAPIVulkan:
+		return "Vulkan"
+	case GraphicsAPIMetal:
+		return "Metal"
+	case GraphicsAPID3D12:
+		return "D3D12"
+	case GraphicsAPIOpenGL:
+		return "OpenGL"
+	case GraphicsAPIOpenGLES:
+		return "OpenGL ES"
+	case GraphicsAPIWebGPU:
+		return "WebGPU"
+	default:
+		return "Unknown"
+	}
+}
+
+// AdapterType specifies the kind of GPU an adapter is.
+type AdapterType uint32
+
+const (
+	AdapterTypeUnknown AdapterType = iota
+	AdapterTypeDiscreteGPU
+	AdapterTypeIntegratedGPU
+	AdapterTypeVirtualGPU
+	AdapterTypeCPU
+)
+
+// String returns the adapter type name.
+func (a AdapterType) String() string {
+	switch a {
+	case AdapterTypeDiscreteGPU:
+		return "Discrete GPU"
+	case AdapterTypeIntegratedGPU:
+		return "Integrated GPU"
+	case AdapterTypeVirtualGPU:
+		return "Virtual GPU"
+	case AdapterTypeCPU:
+		return "CPU"
+	default:
+		return "Unknown"
+	}
+}
+
+// Features is a set of optional WebGPU features. Adapters report the
+// features they support; devices only have the features requested in
+// DeviceOptions.RequiredFeatures.
+type Features uint64
+
+const (
+	FeatureDepthClipControl Features = 1 << iota
+	FeatureDepth32FloatStencil8
+	FeatureTimestampQuery
+	FeatureTextureCompressionBC
+	FeatureTextureCompressionETC2
+	FeatureTextureCompressionASTC
+	FeatureIndirectFirstInstance
+	FeatureShaderF16
+	FeatureRG11B10UfloatRenderable
+	FeatureBGRA8UnormStorage
+	FeatureFloat32Filterable
+
+	// FeaturePushConstants is a native extension, not in WebGPU: small
+	// per-draw data set with SetPushConstants, up to
+	// Limits.MaxPushConstantSize bytes.
+	FeaturePushConstants
+
+	// FeatureAddressModeClampToBorder is a native extension, not in
+	// WebGPU: samplers with AddressModeClampToBorder.
+	FeatureAddressModeClampToBorder
+
+	// FeatureMultiDrawIndirectCount is a native extension, not in WebGPU:
+	// multi-draws whose draw count is read from a GPU buffer. See
+	// gpu.MultiDrawIndirectCount, which emulates it without the feature.
+	FeatureMultiDrawIndirectCount
+)
+
+// Has reports whether all features of other are in f.
+func (f Features) Has(other Features) bool {
+	return f&other == other
+}
+
+// ErrorFilter specifies the kind of GPU errors an error scope captures.
+// Values match WebGPU specification.
+type ErrorFilter uint32
+
+const (
+	ErrorFilterValidation  ErrorFilter = 0x01
+	ErrorFilterOutOfMemory ErrorFilter = 0x02
+	ErrorFilterInternal    ErrorFilter = 0x03
+)
+
+// String returns the filter name.
+func (f ErrorFilter) String() string {
+	switch f {
+	case ErrorFilterValidation:
+		return "validation"
+	case ErrorFilterOutOfMemory:
+		return "out of memory"
+	case ErrorFilterInternal:
+		return "internal"
+	default:
+		return "unknown"
+	}
+}
+
+// DeviceLostReason specifies why a device was lost.
+// Values match WebGPU specification.
+type DeviceLostReason uint32
+
+const (
+	// DeviceLostReasonUnknown covers GPU resets, driver updates and
+	// removed GPUs.
+	DeviceLostReasonUnknown DeviceLostReason = 0x01
+	// DeviceLostReasonDestroyed means the device was destroyed on purpose.
+	DeviceLostReasonDestroyed DeviceLostReason = 0x02
+)
+
 // LoadOp specifies how to load render target at pass start.
 type LoadOp uint32
 
@@ -121,3 +335,47 @@ const (
 	CullModeFront CullMode = 0x01
 	CullModeBack  CullMode = 0x02
 )
+
+// BlendFactor scales the source or destination color of a blend equation.
+// Values match webgpu.h.
+type BlendFactor uint32
+
+const (
+	BlendFactorZero              BlendFactor = 0x01
+	BlendFactorOne               BlendFactor = 0x02
+	BlendFactorSrc               BlendFactor = 0x03
+	BlendFactorOneMinusSrc       BlendFactor = 0x04
+	BlendFactorSrcAlpha          BlendFactor = 0x05
+	BlendFactorOneMinusSrcAlpha  BlendFactor = 0x06
+	BlendFactorDst               BlendFactor = 0x07
+	BlendFactorOneMinusDst       BlendFactor = 0x08
+	BlendFactorDstAlpha          BlendFactor = 0x09
+	BlendFactorOneMinusDstAlpha  BlendFactor = 0x0A
+	BlendFactorSrcAlphaSaturated BlendFactor = 0x0B
+	BlendFactorConstant          BlendFactor = 0x0C
+	BlendFactorOneMinusConstant  BlendFactor = 0x0D
+)
+
+// BlendOperation combines the scaled source and destination colors.
+// Values match webgpu.h.
+type BlendOperation uint32
+
+const (
+	BlendOperationAdd             BlendOperation = 0x01
+	BlendOperationSubtract        BlendOperation = 0x02
+	BlendOperationReverseSubtract BlendOperation = 0x03
+	BlendOperationMin             BlendOperation = 0x04
+	BlendOperationMax             BlendOperation = 0x05
+)
+
+// ColorWriteMask selects the color channels a pipeline writes.
+type ColorWriteMask uint32
+
+const (
+	ColorWriteMaskNone  ColorWriteMask = 0x0
+	ColorWriteMaskRed   ColorWriteMask = 0x1
+	ColorWriteMaskGreen ColorWriteMask = 0x2
+	ColorWriteMaskBlue  ColorWriteMask = 0x4
+	ColorWriteMaskAlpha ColorWriteMask = 0x8
+	ColorWriteMaskAll   ColorWriteMask = 0xF
+)
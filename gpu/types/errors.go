@@ -0,0 +1,19 @@
+package types
+
+// Error is an error reported by a GPU device, such as a validation
+// failure of a command.
+type Error struct {
+	Type    ErrorFilter // Kind of error; error scopes with this filter capture it
+	Message string
+	Err     error // Underlying error with details, if the backend has one
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return "gpu: " + e.Type.String() + " error: " + e.Message
+}
+
+// Unwrap returns the underlying error, e.g. for errors.As.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
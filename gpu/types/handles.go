@@ -74,6 +74,27 @@ type (
 	// PipelineLayout defines the layout of bind groups for a pipeline.
 	// Created via Backend.CreatePipelineLayout().
 	PipelineLayout uintptr
+
+	// ComputePipeline represents a compute pipeline state.
+	// Created via Backend.CreateComputePipeline().
+	ComputePipeline uintptr
+
+	// ComputePass represents an active compute pass.
+	// Created via Backend.BeginComputePass().
+	ComputePass uintptr
+
+	// QuerySet represents a set of timestamp or occlusion queries.
+	// Created via Backend.CreateQuerySet().
+	QuerySet uintptr
+
+	// RenderBundleEncoder records draw commands into a render bundle.
+	// Created via Backend.CreateRenderBundleEncoder().
+	RenderBundleEncoder uintptr
+
+	// RenderBundle contains prerecorded draw commands that render passes
+	// can execute many times.
+	// Created via Backend.FinishRenderBundle().
+	RenderBundle uintptr
 )
 
 // SurfaceTexture is returned by GetCurrentTexture.
@@ -86,9 +107,14 @@ type SurfaceTexture struct {
 type SurfaceHandle struct {
 	// Windows: HINSTANCE and HWND
 	// macOS: NSView pointer
-	// Linux: Display and Window (X11)
+	// Linux: display connection and Window (X11), or display and
+	// wl_surface (Wayland)
 	Instance uintptr
 	Window   uintptr
+
+	// Wayland marks the handles of a Wayland window; backends create
+	// the surface from the kind of handle, not from the environment
+	Wayland bool
 }
 
 // SurfaceStatus indicates the result of GetCurrentTexture.
@@ -101,3 +127,8 @@ const (
 	SurfaceStatusLost
 	SurfaceStatusError
 )
+
+// SubmissionIndex identifies a submission to a queue. The indices of a
+// queue start at 1 and increase by one with each submission; 0 means
+// nothing was submitted.
+type SubmissionIndex uint64
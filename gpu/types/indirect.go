@@ -0,0 +1,49 @@
+package types
+
+import "encoding/binary"
+
+// Sizes in bytes of the arguments of indirect draws and dispatches, as
+// laid out in GPU buffers.
+const (
+	DrawIndirectArgsSize        = 16
+	DrawIndexedIndirectArgsSize = 20
+	DispatchIndirectArgsSize    = 12
+)
+
+// DrawIndirectArgs are the arguments of a DrawIndirect call, read by the
+// GPU from a buffer created with BufferUsageIndirect.
+type DrawIndirectArgs struct {
+	VertexCount   uint32
+	InstanceCount uint32
+	FirstVertex   uint32
+	FirstInstance uint32
+}
+
+// Bytes returns the arguments in their buffer layout.
+func (a DrawIndirectArgs) Bytes() []byte {
+	b := make([]byte, 0, DrawIndirectArgsSize)
+	b = binary.LittleEndian.AppendUint32(b, a.VertexCount)
+	b = binary.LittleEndian.AppendUint32(b, a.InstanceCount)
+	b = binary.LittleEndian.AppendUint32(b, a.FirstVertex)
+	return binary.LittleEndian.AppendUint32(b, a.FirstInstance)
+}
+
+// DrawIndexedIndirectArgs are the arguments of a DrawIndexedIndirect
+// call, read by the GPU from a buffer created with BufferUsageIndirect.
+type DrawIndexedIndirectArgs struct {
+	IndexCount    uint32
+	InstanceCount uint32
+	FirstIndex    uint32
+	BaseVertex    int32
+	FirstInstance uint32
+}
+
+// Bytes returns the arguments in their buffer layout.
+func (a DrawIndexedIndirectArgs) Bytes() []byte {
+	b := make([]byte, 0, DrawIndexedIndirectArgsSize)
+	b = binary.LittleEndian.AppendUint32(b, a.IndexCount)
+	b = binary.LittleEndian.AppendUint32(b, a.InstanceCount)
+	b = binary.LittleEndian.AppendUint32(b, a.FirstIndex)
+	b = binary.LittleEndian.AppendUint32(b, uint32(a.BaseVertex)) //nolint:gosec // G115: two's complement layout
+	return binary.LittleEndian.AppendUint32(b, a.FirstInstance)
+}
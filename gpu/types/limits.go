@@ -0,0 +1,72 @@
+package types
+
+// Limits describes resource limits of an adapter or device.
+// Field names match the WebGPU specification.
+type Limits struct {
+	MaxTextureDimension1D                     uint32
+	MaxTextureDimension2D                     uint32
+	MaxTextureDimension3D                     uint32
+	MaxTextureArrayLayers                     uint32
+	MaxBindGroups                             uint32
+	MaxBindingsPerBindGroup                   uint32
+	MaxDynamicUniformBuffersPerPipelineLayout uint32
+	MaxDynamicStorageBuffersPerPipelineLayout uint32
+	MaxSampledTexturesPerShaderStage          uint32
+	MaxSamplersPerShaderStage                 uint32
+	MaxStorageBuffersPerShaderStage           uint32
+	MaxStorageTexturesPerShaderStage          uint32
+	MaxUniformBuffersPerShaderStage           uint32
+	MaxUniformBufferBindingSize               uint64
+	MaxStorageBufferBindingSize               uint64
+	MinUniformBufferOffsetAlignment           uint32
+	MinStorageBufferOffsetAlignment           uint32
+	MaxVertexBuffers                          uint32
+	MaxBufferSize                             uint64
+	MaxVertexAttributes                       uint32
+	MaxVertexBufferArrayStride                uint32
+	MaxColorAttachments                       uint32
+	MaxComputeWorkgroupStorageSize            uint32
+	MaxComputeInvocationsPerWorkgroup         uint32
+	MaxComputeWorkgroupSizeX                  uint32
+	MaxComputeWorkgroupSizeY                  uint32
+	MaxComputeWorkgroupSizeZ                  uint32
+	MaxComputeWorkgroupsPerDimension          uint32
+
+	// MaxPushConstantSize is a native extension; 0 without
+	// FeaturePushConstants.
+	MaxPushConstantSize uint32
+}
+
+// DefaultLimits returns the limits every WebGPU device supports.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxTextureDimension1D:                     8192,
+		MaxTextureDimension2D:                     8192,
+		MaxTextureDimension3D:                     2048,
+		MaxTextureArrayLayers:                     256,
+		MaxBindGroups:                             4,
+		MaxBindingsPerBindGroup:                   1000,
+		MaxDynamicUniformBuffersPerPipelineLayout: 8,
+		MaxDynamicStorageBuffersPerPipelineLayout: 4,
+		MaxSampledTexturesPerShaderStage:          16,
+		MaxSamplersPerShaderStage:                 16,
+		MaxStorageBuffersPerShaderStage:           8,
+		MaxStorageTexturesPerShaderStage:          4,
+		MaxUniformBuffersPerShaderStage:           12,
+		MaxUniformBufferBindingSize:               64 << 10,
+		MaxStorageBufferBindingSize:               128 << 20,
+		MinUniformBufferOffsetAlignment:           256,
+		MinStorageBufferOffsetAlignment:           256,
+		MaxVertexBuffers:                          8,
+		MaxBufferSize:                             256 << 20,
+		MaxVertexAttributes:                       16,
+		MaxVertexBufferArrayStride:                2048,
+		MaxColorAttachments:                       8,
+		MaxComputeWorkgroupStorageSize:            16384,
+		MaxComputeInvocationsPerWorkgroup:         256,
+		MaxComputeWorkgroupSizeX:                  256,
+		MaxComputeWorkgroupSizeY:                  256,
+		MaxComputeWorkgroupSizeZ:                  64,
+		MaxComputeWorkgroupsPerDimension:          65535,
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -12,6 +13,7 @@ func TestBackendTypeString(t *testing.T) {
 		{BackendAuto, "Auto"},
 		{BackendRust, "Rust (wgpu-native)"},
 		{BackendGo, "Pure Go"},
+		{BackendWeb, "WebGPU (browser)"},
 		{BackendType(99), "Auto"}, // Unknown defaults to Auto
 	}
 
@@ -36,6 +38,9 @@ func TestBackendTypeValues(t *testing.T) {
 	if BackendGo != 2 {
 		t.Errorf("BackendGo = %d, want 2", BackendGo)
 	}
+	if BackendWeb != 3 {
+		t.Errorf("BackendWeb = %d, want 3", BackendWeb)
+	}
 }
 
 func TestSurfaceStatusValues(t *testing.T) {
@@ -65,6 +70,18 @@ func TestTextureFormatValues(t *testing.T) {
 	if TextureFormatBGRA8Unorm != 0x17 {
 		t.Errorf("TextureFormatBGRA8Unorm = 0x%x, want 0x17", TextureFormatBGRA8Unorm)
 	}
+	if TextureFormatRGBA8UnormSrgb != 0x13 {
+		t.Errorf("TextureFormatRGBA8UnormSrgb = 0x%x, want 0x13", TextureFormatRGBA8UnormSrgb)
+	}
+	if TextureFormatBGRA8UnormSrgb != 0x18 {
+		t.Errorf("TextureFormatBGRA8UnormSrgb = 0x%x, want 0x18", TextureFormatBGRA8UnormSrgb)
+	}
+	if TextureFormatRGB10A2Unorm != 0x1A {
+		t.Errorf("TextureFormatRGB10A2Unorm = 0x%x, want 0x1A", TextureFormatRGB10A2Unorm)
+	}
+	if TextureFormatRGBA16Float != 0x22 {
+		t.Errorf("TextureFormatRGBA16Float = 0x%x, want 0x22", TextureFormatRGBA16Float)
+	}
 }
 
 func TestTextureUsageValues(t *testing.T) {
@@ -518,11 +535,16 @@ func TestImageDataLayout(t *testing.T) {
 func TestNewHandleTypes(t *testing.T) {
 	// Test new handle types added for texture support
 	var (
-		buffer          Buffer          = 1
-		sampler         Sampler         = 2
-		bindGroupLayout BindGroupLayout = 3
-		bindGroup       BindGroup       = 4
-		pipelineLayout  PipelineLayout  = 5
+		buffer          Buffer              = 1
+		sampler         Sampler             = 2
+		bindGroupLayout BindGroupLayout     = 3
+		bindGroup       BindGroup           = 4
+		pipelineLayout  PipelineLayout      = 5
+		computePipeline ComputePipeline     = 6
+		computePass     ComputePass         = 7
+		querySet        QuerySet            = 8
+		bundleEncoder   RenderBundleEncoder = 9
+		renderBundle    RenderBundle        = 10
 	)
 
 	handles := []uintptr{
@@ -531,6 +553,11 @@ func TestNewHandleTypes(t *testing.T) {
 		uintptr(bindGroupLayout),
 		uintptr(bindGroup),
 		uintptr(pipelineLayout),
+		uintptr(computePipeline),
+		uintptr(computePass),
+		uintptr(querySet),
+		uintptr(bundleEncoder),
+		uintptr(renderBundle),
 	}
 
 	for i, h := range handles {
@@ -581,3 +608,150 @@ func TestHandleTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestBlendValues(t *testing.T) {
+	// Verify WebGPU spec values
+	if BlendFactorZero != 0x01 || BlendFactorOneMinusConstant != 0x0D {
+		t.Errorf("BlendFactor values = %d..%d, want 1..13", BlendFactorZero, BlendFactorOneMinusConstant)
+	}
+	if BlendOperationAdd != 0x01 || BlendOperationMax != 0x05 {
+		t.Errorf("BlendOperation values = %d..%d, want 1..5", BlendOperationAdd, BlendOperationMax)
+	}
+	if ColorWriteMaskRed|ColorWriteMaskGreen|ColorWriteMaskBlue|ColorWriteMaskAlpha != ColorWriteMaskAll {
+		t.Error("ColorWriteMaskAll should combine all channels")
+	}
+}
+
+func TestRenderPipelineColorTargets(t *testing.T) {
+	desc := RenderPipelineDescriptor{TargetFormat: TextureFormatBGRA8Unorm}
+	targets := desc.ColorTargets()
+	if len(targets) != 1 {
+		t.Fatalf("len(ColorTargets()) = %d, want 1", len(targets))
+	}
+	if targets[0].Format != TextureFormatBGRA8Unorm || targets[0].Blend != nil || targets[0].WriteMask != ColorWriteMaskAll {
+		t.Errorf("ColorTargets()[0] = %+v, want BGRA8Unorm target without blending", targets[0])
+	}
+
+	blend := BlendStateAlpha
+	desc.Targets = []ColorTargetState{
+		{Format: TextureFormatRGBA8Unorm, Blend: &blend},
+		{Format: TextureFormatRGBA8Unorm, WriteMask: ColorWriteMaskRed},
+	}
+	targets = desc.ColorTargets()
+	if len(targets) != 2 {
+		t.Fatalf("len(ColorTargets()) = %d, want 2", len(targets))
+	}
+	if targets[0].WriteMask != ColorWriteMaskAll || targets[0].Blend != &blend {
+		t.Errorf("ColorTargets()[0] = %+v, want blended target writing all channels", targets[0])
+	}
+	if targets[1].WriteMask != ColorWriteMaskRed {
+		t.Errorf("ColorTargets()[1].WriteMask = %d, want %d", targets[1].WriteMask, ColorWriteMaskRed)
+	}
+	if desc.Targets[0].WriteMask != ColorWriteMaskNone {
+		t.Error("ColorTargets() should not modify Targets")
+	}
+}
+
+func TestMultisampleStateWithDefaults(t *testing.T) {
+	got := MultisampleState{}.WithDefaults()
+	if got.Count != 1 || got.Mask != 0xFFFFFFFF {
+		t.Errorf("MultisampleState{}.WithDefaults() = %+v, want Count 1, all samples", got)
+	}
+
+	got = MultisampleState{Count: 4, Mask: 0x3, AlphaToCoverageEnabled: true}.WithDefaults()
+	if got.Count != 4 || got.Mask != 0x3 || !got.AlphaToCoverageEnabled {
+		t.Errorf("WithDefaults() changed set fields: %+v", got)
+	}
+}
+
+func TestFeaturesHas(t *testing.T) {
+	features := FeatureTimestampQuery | FeatureTextureCompressionBC
+
+	if !features.Has(FeatureTimestampQuery) {
+		t.Error("Has(FeatureTimestampQuery) = false, want true")
+	}
+	if !features.Has(FeatureTimestampQuery | FeatureTextureCompressionBC) {
+		t.Error("Has(both) = false, want true")
+	}
+	if features.Has(FeatureTimestampQuery | FeatureShaderF16) {
+		t.Error("Has(FeatureTimestampQuery | FeatureShaderF16) = true, want false")
+	}
+	if !features.Has(0) {
+		t.Error("Has(0) = false, want true")
+	}
+}
+
+func TestTextureFormatVariants(t *testing.T) {
+	if got := TextureFormatBGRA8Unorm.Srgb(); got != TextureFormatBGRA8UnormSrgb {
+		t.Errorf("BGRA8Unorm.Srgb() = %#x, want BGRA8UnormSrgb", uint32(got))
+	}
+	if got := TextureFormatRGBA8UnormSrgb.Linear(); got != TextureFormatRGBA8Unorm {
+		t.Errorf("RGBA8UnormSrgb.Linear() = %#x, want RGBA8Unorm", uint32(got))
+	}
+	if got := TextureFormatRGBA16Float.Srgb(); got != TextureFormatRGBA16Float {
+		t.Errorf("RGBA16Float.Srgb() = %#x, want itself", uint32(got))
+	}
+
+	if !TextureFormatDepth24PlusStencil8.HasDepth() || !TextureFormatDepth24PlusStencil8.HasStencil() {
+		t.Error("Depth24PlusStencil8 lacks depth or stencil")
+	}
+	if TextureFormatDepth32Float.HasStencil() || TextureFormatStencil8.HasDepth() {
+		t.Error("single-aspect depth or stencil format reports both aspects")
+	}
+	if TextureFormatRGBA8Unorm.HasDepth() {
+		t.Error("RGBA8Unorm.HasDepth() = true")
+	}
+}
+
+func TestGraphicsAPIString(t *testing.T) {
+	tests := []struct {
+		api      GraphicsAPI
+		expected string
+	}{
+		{GraphicsAPIVulkan, "Vulkan"},
+		{GraphicsAPIMetal, "Metal"},
+		{GraphicsAPID3D12, "D3D12"},
+		{GraphicsAPIUndefined, "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.api.String(); got != tt.expected {
+			t.Errorf("GraphicsAPI(%d).String() = %q, want %q", tt.api, got, tt.expected)
+		}
+	}
+}
+
+func TestIndirectArgsBytes(t *testing.T) {
+	draw := DrawIndirectArgs{VertexCount: 3, InstanceCount: 2, FirstVertex: 1, FirstInstance: 4}.Bytes()
+	wantDraw := []byte{3, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0}
+	if !bytes.Equal(draw, wantDraw) {
+		t.Errorf("DrawIndirectArgs.Bytes() = %v, want %v", draw, wantDraw)
+	}
+
+	indexed := DrawIndexedIndirectArgs{IndexCount: 6, InstanceCount: 1, FirstIndex: 2, BaseVertex: -1, FirstInstance: 0}.Bytes()
+	if len(indexed) != DrawIndexedIndirectArgsSize {
+		t.Fatalf("len(DrawIndexedIndirectArgs.Bytes()) = %d, want %d", len(indexed), DrawIndexedIndirectArgsSize)
+	}
+	if !bytes.Equal(indexed[12:16], []byte{0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("BaseVertex bytes = %v, want -1", indexed[12:16])
+	}
+}
+
+func TestDefaultViewDimension(t *testing.T) {
+	tests := []struct {
+		dimension TextureDimension
+		layers    uint32
+		want      TextureViewDimension
+	}{
+		{TextureDimension1D, 1, TextureViewDimension1D},
+		{TextureDimension2D, 1, TextureViewDimension2D},
+		{TextureDimension2D, 6, TextureViewDimension2DArray},
+		{TextureDimension3D, 32, TextureViewDimension3D},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultViewDimension(tt.dimension, tt.layers); got != tt.want {
+			t.Errorf("DefaultViewDimension(%d, %d) = %d, want %d", tt.dimension, tt.layers, got, tt.want)
+		}
+	}
+}
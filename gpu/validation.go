@@ -0,0 +1,565 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// Errors of command validation, see CommandValidator. ValidationError
+// wraps one of them.
+var (
+	ErrEncoderState          = errors.New("command encoder is not recording")
+	ErrPassState             = errors.New("pass state")
+	ErrNoPipeline            = errors.New("no pipeline set")
+	ErrNoIndexBuffer         = errors.New("no index buffer set")
+	ErrIncompatibleBindGroup = errors.New("bind group incompatible with the pipeline")
+	ErrMissingUsage          = errors.New("resource lacks usage")
+)
+
+// ValidationError describes a command rejected by a CommandValidator.
+type ValidationError struct {
+	Op     string       // Backend call, e.g. "Draw"
+	Device types.Device // Device of the command encoder, 0 if unknown
+	Reason error        // One of the Err... values above
+	Detail string
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("gpu: %s: %v", e.Op, e.Reason)
+	}
+	return fmt.Sprintf("gpu: %s: %v: %s", e.Op, e.Reason, e.Detail)
+}
+
+// Unwrap returns Reason.
+func (e *ValidationError) Unwrap() error {
+	return e.Reason
+}
+
+// CommandValidation is implemented by backends with an opt-in
+// validation layer for command recording.
+type CommandValidation interface {
+	// EnableValidation makes the backend check commands as they are
+	// recorded. Invalid commands are skipped and reported as validation
+	// errors wrapping a *ValidationError. Called before CreateInstance.
+	EnableValidation()
+}
+
+// CommandValidator checks command recording for backends without
+// validation of their own: the state machines of encoders and passes,
+// that draws and dispatches have a pipeline and bind groups matching its
+// layout, and that resources have the usage their use requires.
+//
+// Backends report resources as they create them and commands as they
+// record them; methods return a *ValidationError for invalid commands,
+// which the backend then skips. Resources the validator does not know,
+// such as those created before validation was enabled, pass unchecked.
+// It is safe for concurrent use.
+type CommandValidator struct {
+	mu sync.Mutex
+
+	textures        map[types.Texture]types.TextureUsage
+	views           map[types.TextureView]types.Texture
+	buffers         map[types.Buffer]types.BufferUsage
+	groupLayouts    map[types.BindGroupLayout]*types.BindGroupLayoutDescriptor
+	groups          map[types.BindGroup]types.BindGroupLayout
+	pipelineLayouts map[types.PipelineLayout][]types.BindGroupLayout
+
+	// Bind group layouts of pipelines, nil for derived layouts
+	renderPipelines  map[types.RenderPipeline][]types.BindGroupLayout
+	computePipelines map[types.ComputePipeline][]types.BindGroupLayout
+
+	encoders      map[types.CommandEncoder]*encoderState
+	renderPasses  map[types.RenderPass]*passState
+	computePasses map[types.ComputePass]*passState
+}
+
+// encoderState is the recording state of a command encoder.
+type encoderState struct {
+	device   types.Device
+	inPass   bool
+	finished bool
+}
+
+// passState is the recording state of a render or compute pass.
+type passState struct {
+	encoder *encoderState
+	ended   bool
+
+	pipeline    bool
+	layouts     []types.BindGroupLayout // Of the pipeline; nil if unknown
+	groups      map[uint32]types.BindGroupLayout
+	indexBuffer bool
+}
+
+// NewCommandValidator creates a validator that knows no resources yet.
+func NewCommandValidator() *CommandValidator {
+	return &CommandValidator{
+		textures:         make(map[types.Texture]types.TextureUsage),
+		views:            make(map[types.TextureView]types.Texture),
+		buffers:          make(map[types.Buffer]types.BufferUsage),
+		groupLayouts:     make(map[types.BindGroupLayout]*types.BindGroupLayoutDescriptor),
+		groups:           make(map[types.BindGroup]types.BindGroupLayout),
+		pipelineLayouts:  make(map[types.PipelineLayout][]types.BindGroupLayout),
+		renderPipelines:  make(map[types.RenderPipeline][]types.BindGroupLayout),
+		computePipelines: make(map[types.ComputePipeline][]types.BindGroupLayout),
+		encoders:         make(map[types.CommandEncoder]*encoderState),
+		renderPasses:     make(map[types.RenderPass]*passState),
+		computePasses:    make(map[types.ComputePass]*passState),
+	}
+}
+
+// --- Resources ---
+
+// CreateTexture records the usage of a texture.
+func (v *CommandValidator) CreateTexture(texture types.Texture, desc *types.TextureDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.textures[texture] = desc.Usage
+}
+
+// CreateTextureView records the texture of a view.
+func (v *CommandValidator) CreateTextureView(view types.TextureView, texture types.Texture) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.views[view] = texture
+}
+
+// CreateBuffer records the usage of a buffer.
+func (v *CommandValidator) CreateBuffer(buffer types.Buffer, desc *types.BufferDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.buffers[buffer] = desc.Usage
+}
+
+// CreateBindGroupLayout records the entries of a bind group layout.
+func (v *CommandValidator) CreateBindGroupLayout(layout types.BindGroupLayout, desc *types.BindGroupLayoutDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.groupLayouts[layout] = desc
+}
+
+// CreateBindGroup checks that the resources of a bind group have the
+// usage their bindings require, and records its layout.
+func (v *CommandValidator) CreateBindGroup(desc *types.BindGroupDescriptor) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	layout := v.groupLayouts[desc.Layout]
+	for i := range desc.Entries {
+		if layout == nil {
+			break
+		}
+		e := &desc.Entries[i]
+		entry := layoutEntry(layout, e.Binding)
+		if entry == nil {
+			continue
+		}
+		var err error
+		switch {
+		case entry.Buffer != nil:
+			usage := types.BufferUsageStorage
+			if entry.Buffer.Type == types.BufferBindingTypeUniform || entry.Buffer.Type == types.BufferBindingTypeUndefined {
+				usage = types.BufferUsageUniform
+			}
+			err = v.bufferUsage("CreateBindGroup", e.Buffer, usage)
+		case entry.Texture != nil:
+			err = v.viewUsage("CreateBindGroup", e.TextureView, types.TextureUsageTextureBinding)
+		case entry.StorageTexture != nil:
+			err = v.viewUsage("CreateBindGroup", e.TextureView, types.TextureUsageStorageBinding)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BindGroupCreated records the layout of a bind group that passed
+// CreateBindGroup.
+func (v *CommandValidator) BindGroupCreated(group types.BindGroup, layout types.BindGroupLayout) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.groups[group] = layout
+}
+
+// CreatePipelineLayout records the bind group layouts of a pipeline
+// layout.
+func (v *CommandValidator) CreatePipelineLayout(layout types.PipelineLayout, desc *types.PipelineLayoutDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.pipelineLayouts[layout] = desc.BindGroupLayouts
+}
+
+// CreateRenderPipeline records the layout of a render pipeline.
+func (v *CommandValidator) CreateRenderPipeline(pipeline types.RenderPipeline, desc *types.RenderPipelineDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.renderPipelines[pipeline] = v.pipelineLayouts[desc.Layout]
+}
+
+// CreateComputePipeline records the layout of a compute pipeline.
+func (v *CommandValidator) CreateComputePipeline(pipeline types.ComputePipeline, desc *types.ComputePipelineDescriptor) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.computePipelines[pipeline] = v.pipelineLayouts[desc.Layout]
+}
+
+// Release forgets a released resource, command encoder or pass; handle
+// is any of the handle types of package types.
+func (v *CommandValidator) Release(handle any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	switch h := handle.(type) {
+	case types.Texture:
+		delete(v.textures, h)
+	case types.TextureView:
+		delete(v.views, h)
+	case types.Buffer:
+		delete(v.buffers, h)
+	case types.BindGroupLayout:
+		delete(v.groupLayouts, h)
+	case types.BindGroup:
+		delete(v.groups, h)
+	case types.PipelineLayout:
+		delete(v.pipelineLayouts, h)
+	case types.RenderPipeline:
+		delete(v.renderPipelines, h)
+	case types.ComputePipeline:
+		delete(v.computePipelines, h)
+	case types.CommandEncoder:
+		delete(v.encoders, h)
+	case types.RenderPass:
+		delete(v.renderPasses, h)
+	case types.ComputePass:
+		delete(v.computePasses, h)
+	}
+}
+
+// --- Command encoders ---
+
+// CreateCommandEncoder starts tracking an encoder of device.
+func (v *CommandValidator) CreateCommandEncoder(encoder types.CommandEncoder, device types.Device) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.encoders[encoder] = &encoderState{device: device}
+}
+
+// FinishEncoder checks that the encoder has no open pass and ends its
+// recording.
+func (v *CommandValidator) FinishEncoder(encoder types.CommandEncoder) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e := v.encoders[encoder]
+	if err := v.recording("FinishEncoder", e); err != nil {
+		return err
+	}
+	if e != nil {
+		e.finished = true
+	}
+	return nil
+}
+
+// EncoderCommand checks that an encoder can record a command outside of
+// passes, such as a copy.
+func (v *CommandValidator) EncoderCommand(op string, encoder types.CommandEncoder) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.recording(op, v.encoders[encoder])
+}
+
+// recording checks that e, if known, records and has no open pass.
+func (v *CommandValidator) recording(op string, e *encoderState) error {
+	switch {
+	case e == nil:
+		return nil
+	case e.finished:
+		return &ValidationError{Op: op, Device: e.device, Reason: ErrEncoderState, Detail: "already finished"}
+	case e.inPass:
+		return &ValidationError{Op: op, Device: e.device, Reason: ErrPassState, Detail: "a pass is still open"}
+	}
+	return nil
+}
+
+// --- Copies ---
+
+// CopyBuffer checks a buffer used by a copy for usage.
+func (v *CommandValidator) CopyBuffer(op string, buffer types.Buffer, usage types.BufferUsage) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.bufferUsage(op, buffer, usage)
+}
+
+// CopyTexture checks a texture used by a copy for usage.
+func (v *CommandValidator) CopyTexture(op string, texture types.Texture, usage types.TextureUsage) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.textureUsage(op, texture, usage)
+}
+
+// --- Render passes ---
+
+// BeginRenderPass checks that the encoder can begin a pass and that the
+// attachments are render attachments.
+func (v *CommandValidator) BeginRenderPass(encoder types.CommandEncoder, desc *types.RenderPassDescriptor) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err := v.recording("BeginRenderPass", v.encoders[encoder]); err != nil {
+		return err
+	}
+	for _, ca := range desc.ColorAttachments {
+		if err := v.viewUsage("BeginRenderPass", ca.View, types.TextureUsageRenderAttachment); err != nil {
+			return err
+		}
+		if ca.ResolveTarget != 0 {
+			if err := v.viewUsage("BeginRenderPass", ca.ResolveTarget, types.TextureUsageRenderAttachment); err != nil {
+				return err
+			}
+		}
+	}
+	if desc.DepthStencil != nil {
+		return v.viewUsage("BeginRenderPass", desc.DepthStencil.View, types.TextureUsageRenderAttachment)
+	}
+	return nil
+}
+
+// RenderPassBegun starts tracking a pass begun on encoder.
+func (v *CommandValidator) RenderPassBegun(encoder types.CommandEncoder, pass types.RenderPass) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.renderPasses[pass] = v.beginPass(encoder)
+}
+
+// EndRenderPass checks that the pass is open and ends it.
+func (v *CommandValidator) EndRenderPass(pass types.RenderPass) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.endPass("EndRenderPass", v.renderPasses[pass])
+}
+
+// SetRenderPipeline records the pipeline of a render pass.
+func (v *CommandValidator) SetRenderPipeline(pass types.RenderPass, pipeline types.RenderPipeline) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	p := v.renderPasses[pass]
+	if err := v.open("SetPipeline", p); err != nil || p == nil {
+		return err
+	}
+	p.pipeline = true
+	p.layouts = v.renderPipelines[pipeline]
+	return nil
+}
+
+// SetRenderBindGroup records the bind group at index of a render pass.
+func (v *CommandValidator) SetRenderBindGroup(pass types.RenderPass, index uint32, group types.BindGroup) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.setBindGroup(v.renderPasses[pass], index, group)
+}
+
+// SetVertexBuffer checks a vertex buffer for BufferUsageVertex.
+func (v *CommandValidator) SetVertexBuffer(pass types.RenderPass, buffer types.Buffer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err := v.open("SetVertexBuffer", v.renderPasses[pass]); err != nil {
+		return err
+	}
+	return v.bufferUsage("SetVertexBuffer", buffer, types.BufferUsageVertex)
+}
+
+// SetIndexBuffer checks an index buffer for BufferUsageIndex and records
+// it for indexed draws.
+func (v *CommandValidator) SetIndexBuffer(pass types.RenderPass, buffer types.Buffer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	p := v.renderPasses[pass]
+	if err := v.open("SetIndexBuffer", p); err != nil {
+		return err
+	}
+	if err := v.bufferUsage("SetIndexBuffer", buffer, types.BufferUsageIndex); err != nil {
+		return err
+	}
+	if p != nil {
+		p.indexBuffer = true
+	}
+	return nil
+}
+
+// Draw checks that a render pass can draw: it is open, has a pipeline,
+// bind groups matching the pipeline's layout and, for indexed draws, an
+// index buffer. Indirect draws pass their argument buffer, which needs
+// BufferUsageIndirect, or 0.
+func (v *CommandValidator) Draw(op string, pass types.RenderPass, indexed bool, indirect types.Buffer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	p := v.renderPasses[pass]
+	if err := v.ready(op, p); err != nil {
+		return err
+	}
+	if p != nil && indexed && !p.indexBuffer {
+		return &ValidationError{Op: op, Device: p.encoder.device, Reason: ErrNoIndexBuffer}
+	}
+	if indirect != 0 {
+		return v.bufferUsage(op, indirect, types.BufferUsageIndirect)
+	}
+	return nil
+}
+
+// --- Compute passes ---
+
+// BeginComputePass checks that the encoder can begin a pass.
+func (v *CommandValidator) BeginComputePass(encoder types.CommandEncoder) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.recording("BeginComputePass", v.encoders[encoder])
+}
+
+// ComputePassBegun starts tracking a pass begun on encoder.
+func (v *CommandValidator) ComputePassBegun(encoder types.CommandEncoder, pass types.ComputePass) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.computePasses[pass] = v.beginPass(encoder)
+}
+
+// EndComputePass checks that the pass is open and ends it.
+func (v *CommandValidator) EndComputePass(pass types.ComputePass) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.endPass("EndComputePass", v.computePasses[pass])
+}
+
+// SetComputePipeline records the pipeline of a compute pass.
+func (v *CommandValidator) SetComputePipeline(pass types.ComputePass, pipeline types.ComputePipeline) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	p := v.computePasses[pass]
+	if err := v.open("SetComputePipeline", p); err != nil || p == nil {
+		return err
+	}
+	p.pipeline = true
+	p.layouts = v.computePipelines[pipeline]
+	return nil
+}
+
+// SetComputeBindGroup records the bind group at index of a compute pass.
+func (v *CommandValidator) SetComputeBindGroup(pass types.ComputePass, index uint32, group types.BindGroup) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.setBindGroup(v.computePasses[pass], index, group)
+}
+
+// Dispatch checks that a compute pass can dispatch, like Draw.
+func (v *CommandValidator) Dispatch(op string, pass types.ComputePass, indirect types.Buffer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err := v.ready(op, v.computePasses[pass]); err != nil {
+		return err
+	}
+	if indirect != 0 {
+		return v.bufferUsage(op, indirect, types.BufferUsageIndirect)
+	}
+	return nil
+}
+
+// --- Pass state ---
+
+// beginPass marks the encoder as in a pass and returns the pass state.
+func (v *CommandValidator) beginPass(encoder types.CommandEncoder) *passState {
+	e := v.encoders[encoder]
+	if e == nil {
+		e = &encoderState{}
+		v.encoders[encoder] = e
+	}
+	e.inPass = true
+	return &passState{encoder: e, groups: make(map[uint32]types.BindGroupLayout)}
+}
+
+// endPass ends an open pass.
+func (v *CommandValidator) endPass(op string, p *passState) error {
+	if err := v.open(op, p); err != nil || p == nil {
+		return err
+	}
+	p.ended = true
+	p.encoder.inPass = false
+	return nil
+}
+
+// open checks that p, if known, has not ended.
+func (v *CommandValidator) open(op string, p *passState) error {
+	if p != nil && p.ended {
+		return &ValidationError{Op: op, Device: p.encoder.device, Reason: ErrPassState, Detail: "pass already ended"}
+	}
+	return nil
+}
+
+// setBindGroup records a bind group of an open pass.
+func (v *CommandValidator) setBindGroup(p *passState, index uint32, group types.BindGroup) error {
+	if err := v.open("SetBindGroup", p); err != nil || p == nil {
+		return err
+	}
+	if layout, ok := v.groups[group]; ok {
+		p.groups[index] = layout
+	} else {
+		p.groups[index] = 0 // Unknown layout
+	}
+	return nil
+}
+
+// ready checks that p is open, has a pipeline and bind groups for every
+// group of the pipeline's layout, created with the same bind group
+// layouts.
+func (v *CommandValidator) ready(op string, p *passState) error {
+	if err := v.open(op, p); err != nil || p == nil {
+		return err
+	}
+	if !p.pipeline {
+		return &ValidationError{Op: op, Device: p.encoder.device, Reason: ErrNoPipeline}
+	}
+	for i, want := range p.layouts {
+		got, ok := p.groups[uint32(i)]
+		if !ok {
+			return &ValidationError{Op: op, Device: p.encoder.device, Reason: ErrIncompatibleBindGroup,
+				Detail: fmt.Sprintf("no bind group set at index %d", i)}
+		}
+		if got != 0 && got != want {
+			return &ValidationError{Op: op, Device: p.encoder.device, Reason: ErrIncompatibleBindGroup,
+				Detail: fmt.Sprintf("bind group at index %d has layout %d, the pipeline expects %d", i, got, want)}
+		}
+	}
+	return nil
+}
+
+// --- Usage ---
+
+// bufferUsage checks that buffer, if known, has usage.
+func (v *CommandValidator) bufferUsage(op string, buffer types.Buffer, usage types.BufferUsage) error {
+	have, ok := v.buffers[buffer]
+	if !ok || have&usage != 0 {
+		return nil
+	}
+	return &ValidationError{Op: op, Reason: ErrMissingUsage,
+		Detail: fmt.Sprintf("buffer %d has usage %#x, needs %#x", buffer, uint32(have), uint32(usage))}
+}
+
+// textureUsage checks that texture, if known, has usage.
+func (v *CommandValidator) textureUsage(op string, texture types.Texture, usage types.TextureUsage) error {
+	have, ok := v.textures[texture]
+	if !ok || have&usage != 0 {
+		return nil
+	}
+	return &ValidationError{Op: op, Reason: ErrMissingUsage,
+		Detail: fmt.Sprintf("texture %d has usage %#x, needs %#x", texture, uint32(have), uint32(usage))}
+}
+
+// viewUsage checks the texture of view, if known, for usage.
+func (v *CommandValidator) viewUsage(op string, view types.TextureView, usage types.TextureUsage) error {
+	texture, ok := v.views[view]
+	if !ok {
+		return nil
+	}
+	return v.textureUsage(op, texture, usage)
+}
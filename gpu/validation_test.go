@@ -0,0 +1,99 @@
+package gpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+func TestCommandValidatorPassState(t *testing.T) {
+	v := NewCommandValidator()
+	const encoder, pass = types.CommandEncoder(1), types.RenderPass(2)
+	v.CreateCommandEncoder(encoder, 7)
+
+	if err := v.BeginRenderPass(encoder, &types.RenderPassDescriptor{}); err != nil {
+		t.Fatal(err)
+	}
+	v.RenderPassBegun(encoder, pass)
+
+	err := v.Draw("Draw", pass, false, 0)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Reason != ErrNoPipeline || verr.Device != 7 {
+		t.Errorf("draw without pipeline: err = %v, want ErrNoPipeline on device 7", err)
+	}
+	if err := v.FinishEncoder(encoder); !errors.Is(err, ErrPassState) {
+		t.Errorf("finish with open pass: err = %v, want ErrPassState", err)
+	}
+
+	if err := v.SetRenderPipeline(pass, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Draw("Draw", pass, false, 0); err != nil {
+		t.Errorf("draw: %v", err)
+	}
+	if err := v.Draw("DrawIndexed", pass, true, 0); !errors.Is(err, ErrNoIndexBuffer) {
+		t.Errorf("indexed draw without index buffer: err = %v", err)
+	}
+
+	if err := v.EndRenderPass(pass); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.EndRenderPass(pass); !errors.Is(err, ErrPassState) {
+		t.Errorf("second end: err = %v, want ErrPassState", err)
+	}
+	if err := v.FinishEncoder(encoder); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.BeginRenderPass(encoder, &types.RenderPassDescriptor{}); !errors.Is(err, ErrEncoderState) {
+		t.Errorf("pass on finished encoder: err = %v, want ErrEncoderState", err)
+	}
+}
+
+func TestCommandValidatorBindGroups(t *testing.T) {
+	v := NewCommandValidator()
+	const layoutA, layoutB = types.BindGroupLayout(1), types.BindGroupLayout(2)
+	v.CreatePipelineLayout(10, &types.PipelineLayoutDescriptor{BindGroupLayouts: []types.BindGroupLayout{layoutA}})
+	v.CreateComputePipeline(20, &types.ComputePipelineDescriptor{Layout: 10})
+	v.BindGroupCreated(30, layoutA)
+	v.BindGroupCreated(31, layoutB)
+
+	const encoder, pass = types.CommandEncoder(1), types.ComputePass(2)
+	v.CreateCommandEncoder(encoder, 1)
+	v.ComputePassBegun(encoder, pass)
+	_ = v.SetComputePipeline(pass, 20)
+
+	if err := v.Dispatch("Dispatch", pass, 0); !errors.Is(err, ErrIncompatibleBindGroup) {
+		t.Errorf("dispatch without bind group: err = %v", err)
+	}
+	_ = v.SetComputeBindGroup(pass, 0, 31)
+	if err := v.Dispatch("Dispatch", pass, 0); !errors.Is(err, ErrIncompatibleBindGroup) {
+		t.Errorf("dispatch with wrong layout: err = %v", err)
+	}
+	_ = v.SetComputeBindGroup(pass, 0, 30)
+	if err := v.Dispatch("Dispatch", pass, 0); err != nil {
+		t.Errorf("dispatch: %v", err)
+	}
+}
+
+func TestCommandValidatorUsage(t *testing.T) {
+	v := NewCommandValidator()
+	v.CreateTexture(1, &types.TextureDescriptor{Usage: types.TextureUsageTextureBinding})
+	v.CreateTextureView(2, 1)
+	v.CreateBuffer(3, &types.BufferDescriptor{Usage: types.BufferUsageVertex})
+
+	desc := &types.RenderPassDescriptor{ColorAttachments: []types.ColorAttachment{{View: 2}}}
+	if err := v.BeginRenderPass(4, desc); !errors.Is(err, ErrMissingUsage) {
+		t.Errorf("sampled texture as attachment: err = %v, want ErrMissingUsage", err)
+	}
+	if err := v.CopyBuffer("CopyBufferToBuffer", 3, types.BufferUsageCopySrc); !errors.Is(err, ErrMissingUsage) {
+		t.Errorf("copy from vertex buffer: err = %v, want ErrMissingUsage", err)
+	}
+	if err := v.SetVertexBuffer(5, 3); err != nil {
+		t.Errorf("vertex buffer: %v", err)
+	}
+	// Unknown resources pass
+	if err := v.CopyTexture("CopyTextureToBuffer", 9, types.TextureUsageCopySrc); err != nil {
+		t.Errorf("unknown texture: %v", err)
+	}
+}
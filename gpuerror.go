@@ -0,0 +1,73 @@
+package gogpu
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// OnGPUError sets the callback for GPU errors no error scope captures,
+// such as a pipeline that fails validation. The error is a *types.Error.
+// It runs on the goroutine that made the failing call, usually the one
+// drawing. Without a callback, the errors are dropped; error scopes, see
+// Renderer.PushErrorScope, catch them too.
+func (a *App) OnGPUError(fn func(err error)) *App {
+	a.onGPUError = fn
+	return a
+}
+
+// handleGPUError passes an uncaptured GPU error to the OnGPUError
+// callback, if any.
+func (a *App) handleGPUError(err error) {
+	if a.onGPUError != nil {
+		a.onGPUError(err)
+	}
+}
+
+// SetLeakHandler sets the function called by Destroy with the resources
+// never released, see Config.LeakDetection. App sets it to the OnLeaks
+// handling.
+func (r *Renderer) SetLeakHandler(fn func(leaks []gpu.Leak)) {
+	r.onLeaks = fn
+}
+
+// reportLeaks passes the resources the backend reports as never released
+// to the leak handler, if any.
+func (r *Renderer) reportLeaks(leaks []gpu.Leak) {
+	if r.onLeaks != nil {
+		r.onLeaks(leaks)
+	}
+}
+
+// OnLeaks sets the callback for the resources never released, with
+// their creation stacks, when the app closes; see Config.LeakDetection.
+func (a *App) OnLeaks(fn func(leaks []gpu.Leak)) *App {
+	a.onLeaks = fn
+	return a
+}
+
+// handleLeaks passes unreleased resources to the OnLeaks callback, if
+// any.
+func (a *App) handleLeaks(leaks []gpu.Leak) {
+	if a.onLeaks != nil {
+		a.onLeaks(leaks)
+	}
+}
+
+// SetErrorHandler sets the function called with GPU errors no error
+// scope captures. App sets it to the OnGPUError handling.
+func (r *Renderer) SetErrorHandler(fn func(err error)) {
+	r.backend.SetUncapturedErrorCallback(r.device, fn)
+}
+
+// PushErrorScope opens an error scope capturing GPU errors of the filter.
+// Errors of the calls until the matching PopErrorScope go to the scope
+// instead of the OnGPUError callback. Scopes nest.
+func (r *Renderer) PushErrorScope(filter types.ErrorFilter) {
+	r.backend.PushErrorScope(r.device, filter)
+}
+
+// PopErrorScope closes the innermost error scope and returns the first
+// GPU error it captured as a *types.Error, or nil if there was none.
+func (r *Renderer) PopErrorScope() error {
+	return r.backend.PopErrorScope(r.device)
+}
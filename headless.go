@@ -0,0 +1,133 @@
+package gogpu
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// NewHeadlessApp creates an app that draws frames of width by height
+// pixels without a window, for tests in CI and server-side rendering
+// with the same OnDraw code as a windowed app. Use
+// NewApp(config.WithHeadless(true)) for other settings, e.g. a
+// SampleCount.
+//
+// Start the app and draw frames with DrawFrame, reading each back with
+// Frame:
+//
+//	app := gogpu.NewHeadlessApp(256, 256)
+//	if err := app.Start(); err != nil {
+//	    return err
+//	}
+//	defer app.Close()
+//
+//	app.DrawFrame(draw)
+//	img, err := app.Frame()
+//
+// Run also works; its loop ends when Quit is called.
+func NewHeadlessApp(width, height int) *App {
+	return NewApp(DefaultConfig().WithSize(width, height).WithHeadless(true))
+}
+
+// Frame returns the last frame of a headless app, waiting for the GPU to
+// finish it. The pixels are as OnDraw left them; image.RGBA assumes
+// premultiplied alpha, which opaque frames satisfy. Called from OnUpdate
+// it returns the previous frame.
+func (a *App) Frame() (*image.RGBA, error) {
+	if a.renderer == nil || !a.renderer.Headless() {
+		return nil, fmt.Errorf("gogpu: Frame requires a started headless app")
+	}
+	pixels, err := a.renderer.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	width, height := a.renderer.Target().Size()
+	return &image.RGBA{
+		Pix:    pixels,
+		Stride: 4 * width,
+		Rect:   image.Rect(0, 0, width, height),
+	}, nil
+}
+
+// NewHeadlessRenderer creates a renderer without a window or surface,
+// for CI and server-side image generation. Frames are drawn between
+// BeginFrame and EndFrame as usual, into an RGBA8 target texture of
+// config.Width by config.Height pixels owned by the renderer; Resize
+// changes its size for the next frame. ReadFrame returns the pixels.
+//
+// Only Width, Height, SampleCount, RequiredFeatures, Backend,
+// Validation, LeakDetection and TraceFile of config are used. Destroy
+// the renderer when done.
+func NewHeadlessRenderer(config Config) (*Renderer, error) {
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("gogpu: invalid headless size %dx%d", config.Width, config.Height)
+	}
+	return newRenderer(nil, config)
+}
+
+// Headless reports whether the renderer draws into its own target
+// instead of a window surface, see NewHeadlessRenderer.
+func (r *Renderer) Headless() bool {
+	return r.frame.surface == 0
+}
+
+// Target returns the texture headless frames are drawn into, or nil
+// before the first frame and for renderers with a window. It can be
+// sampled or copied from; it is replaced when the size changes.
+func (r *Renderer) Target() *Texture {
+	return r.target
+}
+
+// ReadFrame returns the pixels of the last headless frame after
+// EndFrame, waiting for the GPU to finish it. Rows are tightly packed,
+// 4 bytes per pixel in RGBA order.
+func (r *Renderer) ReadFrame() ([]byte, error) {
+	if r.target == nil {
+		return nil, fmt.Errorf("gogpu: no headless frame has been drawn")
+	}
+	return r.ReadTexture(r.target)
+}
+
+// beginHeadlessFrame makes the target the current frame, (re)creating it
+// at the current size.
+func (r *Renderer) beginHeadlessFrame() bool {
+	if r.target == nil || r.target.width != int(r.frame.width) || r.target.height != int(r.frame.height) {
+		r.releaseTarget()
+
+		target, err := r.NewTexture(&types.TextureDescriptor{
+			Label: "headless_target",
+			Size: types.Extent3D{
+				Width:              r.frame.width,
+				Height:             r.frame.height,
+				DepthOrArrayLayers: 1,
+			},
+			MipLevelCount: 1,
+			SampleCount:   1,
+			Dimension:     types.TextureDimension2D,
+			Format:        r.format,
+			Usage:         types.TextureUsageRenderAttachment | types.TextureUsageCopySrc | types.TextureUsageTextureBinding,
+		}, types.TextureViewDimension2D)
+		if err != nil {
+			return false
+		}
+		r.target = target
+	}
+
+	r.frame.currentTexture = r.target.texture
+	r.frame.currentView = r.target.view
+
+	if err := r.ensureMSAATarget(); err != nil {
+		r.releaseFrame()
+		return false
+	}
+	return true
+}
+
+// releaseTarget releases the headless target.
+func (r *Renderer) releaseTarget() {
+	if r.target != nil {
+		r.target.Destroy()
+		r.target = nil
+	}
+}
@@ -0,0 +1,179 @@
+package gogpu
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// KeyEvent describes a key press or release in the main window.
+//
+// Key follows the keyboard layout for keys that type a letter, digit or
+// punctuation mark, so the key labeled A is input.KeyA on QWERTY and
+// AZERTY alike; on Wayland keys are reported by their position on a US
+// keyboard. Scancode identifies the physical key for positional bindings
+// such as WASD; its values are platform-specific. Use OnChar or
+// OnTextInput for typed text.
+type KeyEvent = platform.KeyEvent
+
+// MouseEvent describes a mouse button press or release, a move, or a
+// scroll in the main window. X and Y are the pointer position in window
+// pixels. Scroll deltas are in wheel steps, or fractions of one for
+// touchpads; positive ScrollY scrolls up and positive ScrollX right.
+type MouseEvent = platform.MouseEvent
+
+// OnKey sets the callback for key presses and releases, including
+// auto-repeats of a held key with Repeat set.
+func (a *App) OnKey(fn func(KeyEvent)) *App {
+	a.onKey = fn
+	return a
+}
+
+// OnMouseButton sets the callback for mouse button presses and releases.
+func (a *App) OnMouseButton(fn func(MouseEvent)) *App {
+	a.onMouseButton = fn
+	return a
+}
+
+// OnMouseMove sets the callback for mouse movement over the main window.
+// While a button is held, movement outside the window is reported too.
+func (a *App) OnMouseMove(fn func(MouseEvent)) *App {
+	a.onMouseMove = fn
+	return a
+}
+
+// OnScroll sets the callback for mouse wheel and touchpad scrolling.
+func (a *App) OnScroll(fn func(MouseEvent)) *App {
+	a.onScroll = fn
+	return a
+}
+
+// OnChar sets the callback for characters typed into the main window,
+// after the keyboard layout and input methods are applied. Text still
+// being composed by an input method is not reported; use OnTextInput to
+// show it. Currently delivered on macOS and Windows.
+func (a *App) OnChar(fn func(r rune)) *App {
+	a.onChar = fn
+	return a
+}
+
+// Input returns the keyboard, mouse and gamepad state of the main window
+// as of the start of the frame. JustPressed and JustReleased compare it
+// with the start of the previous frame, and the mouse Delta and Scroll
+// cover the time in between. Read it from OnUpdate or OnDraw.
+func (a *App) Input() *input.State {
+	return &a.input.frame
+}
+
+// inputState collects input events into the state returned by
+// App.Input. Events arrive on the main goroutine while frames may run on
+// the render goroutine, so each frame works on a snapshot.
+type inputState struct {
+	mu    sync.Mutex
+	live  input.State // Updated by events
+	frame input.State // Snapshot taken at the start of the frame
+}
+
+// handleEvent applies an input event of the main window.
+func (s *inputState) handleEvent(event platform.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mouse := s.live.Mouse()
+	switch event.Type {
+	case platform.EventKey:
+		s.live.Keyboard().SetKey(event.Key.Key, event.Key.Down)
+	case platform.EventMouseButton:
+		mouse.SetPosition(float32(event.Mouse.X), float32(event.Mouse.Y))
+		mouse.SetButton(event.Mouse.Button, event.Mouse.Down)
+	case platform.EventMouseMove:
+		mouse.SetPosition(float32(event.Mouse.X), float32(event.Mouse.Y))
+	case platform.EventScroll:
+		x, y := mouse.Scroll()
+		mouse.SetScroll(x+float32(event.Mouse.ScrollX), y+float32(event.Mouse.ScrollY))
+	}
+}
+
+// releaseAll releases all keys and mouse buttons, whose releases are not
+// reported once the main window loses focus.
+func (s *inputState) releaseAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range input.KeyCount {
+		s.live.Keyboard().SetKey(key, false)
+	}
+	for button := range input.MouseButtonCount {
+		s.live.Mouse().SetButton(button, false)
+	}
+}
+
+// sampleGamepads copies the gamepads sampled by the last PollEvents.
+func (s *inputState) sampleGamepads(reader platform.GamepadReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for slot := range input.MaxGamepads {
+		pad, connected := reader.Gamepad(slot)
+		state := s.live.Gamepad(slot)
+		state.SetConnected(connected)
+		for button, pressed := range pad.Buttons {
+			state.SetButton(input.GamepadButton(button), pressed)
+		}
+		for axis, value := range pad.Axes {
+			state.SetAxis(input.GamepadAxis(axis), value)
+		}
+	}
+}
+
+// beginFrame takes the snapshot returned by App.Input for the next frame.
+func (s *inputState) beginFrame() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.frame = s.live
+	s.live.Update()
+}
+
+// handleInputEvent dispatches an input event to the callbacks and the
+// polled input state. Only events of the main window are handled.
+func (a *App) handleInputEvent(event platform.Event) {
+	if event.Window != platform.MainWindow {
+		return
+	}
+
+	a.input.handleEvent(event)
+
+	switch event.Type {
+	case platform.EventKey:
+		if a.onKey != nil {
+			a.onKey(event.Key)
+		}
+	case platform.EventMouseButton:
+		if a.onMouseButton != nil {
+			a.onMouseButton(event.Mouse)
+		}
+	case platform.EventMouseMove:
+		if a.onMouseMove != nil {
+			a.onMouseMove(event.Mouse)
+		}
+	case platform.EventScroll:
+		if a.onScroll != nil {
+			a.onScroll(event.Mouse)
+		}
+	}
+}
+
+// handleChars calls OnChar for each character of committed text.
+func (a *App) handleChars(text platform.TextEvent) {
+	if a.onChar == nil || text.Composing {
+		return
+	}
+	for _, r := range text.Text {
+		if r != utf8.RuneError {
+			a.onChar(r)
+		}
+	}
+}
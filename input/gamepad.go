@@ -0,0 +1,138 @@
+package input
+
+import "math"
+
+// MaxGamepads is the number of gamepad slots.
+const MaxGamepads = 4
+
+// GamepadButton represents a gamepad button, named after the Xbox layout.
+type GamepadButton uint8
+
+const (
+	GamepadA GamepadButton = iota // Bottom face button
+	GamepadB                      // Right face button
+	GamepadX                      // Left face button
+	GamepadY                      // Top face button
+	GamepadLeftBumper
+	GamepadRightBumper
+	GamepadBack
+	GamepadStart
+	GamepadGuide
+	GamepadLeftThumb  // Left stick pressed
+	GamepadRightThumb // Right stick pressed
+	GamepadDpadUp
+	GamepadDpadRight
+	GamepadDpadDown
+	GamepadDpadLeft
+	GamepadButtonCount
+)
+
+// GamepadAxis represents a gamepad stick axis or trigger.
+// Stick axes range from -1 to 1 with y pointing down, like window
+// coordinates; triggers range from 0 to 1.
+type GamepadAxis uint8
+
+const (
+	GamepadLeftX GamepadAxis = iota
+	GamepadLeftY
+	GamepadRightX
+	GamepadRightY
+	GamepadLeftTrigger
+	GamepadRightTrigger
+	GamepadAxisCount
+)
+
+// GamepadState holds the input state of one gamepad slot.
+type GamepadState struct {
+	connected bool
+	current   [GamepadButtonCount]bool
+	previous  [GamepadButtonCount]bool
+	axes      [GamepadAxisCount]float32
+}
+
+func (g *GamepadState) update() {
+	g.previous = g.current
+}
+
+// SetConnected sets whether a gamepad is connected (called by platform layer).
+// Disconnecting releases all buttons and centers all axes.
+func (g *GamepadState) SetConnected(connected bool) {
+	g.connected = connected
+	if !connected {
+		g.current = [GamepadButtonCount]bool{}
+		g.axes = [GamepadAxisCount]float32{}
+	}
+}
+
+// SetButton sets button state (called by platform layer).
+func (g *GamepadState) SetButton(button GamepadButton, pressed bool) {
+	if button < GamepadButtonCount {
+		g.current[button] = pressed
+	}
+}
+
+// SetAxis sets axis value (called by platform layer).
+func (g *GamepadState) SetAxis(axis GamepadAxis, value float32) {
+	if axis < GamepadAxisCount {
+		g.axes[axis] = value
+	}
+}
+
+// Connected returns true if a gamepad is connected in this slot.
+func (g *GamepadState) Connected() bool {
+	return g.connected
+}
+
+// Pressed returns true if button is currently pressed.
+func (g *GamepadState) Pressed(button GamepadButton) bool {
+	if button >= GamepadButtonCount {
+		return false
+	}
+	return g.current[button]
+}
+
+// JustPressed returns true if button was just pressed this frame.
+func (g *GamepadState) JustPressed(button GamepadButton) bool {
+	if button >= GamepadButtonCount {
+		return false
+	}
+	return g.current[button] && !g.previous[button]
+}
+
+// JustReleased returns true if button was just released this frame.
+func (g *GamepadState) JustReleased(button GamepadButton) bool {
+	if button >= GamepadButtonCount {
+		return false
+	}
+	return !g.current[button] && g.previous[button]
+}
+
+// Axis returns the current value of an axis.
+func (g *GamepadState) Axis(axis GamepadAxis) float32 {
+	if axis >= GamepadAxisCount {
+		return 0
+	}
+	return g.axes[axis]
+}
+
+// RadialDeadzone applies a circular dead zone to a stick position with
+// axes from -1 to 1. Positions within deadzone of the center become 0;
+// outside it the distance is rescaled so the output still starts at 0 and
+// reaches 1 at the rim, without the jump of a per-axis cutoff.
+func RadialDeadzone(x, y, deadzone float32) (float32, float32) {
+	magnitude := float32(math.Hypot(float64(x), float64(y)))
+	if magnitude <= deadzone || deadzone >= 1 {
+		return 0, 0
+	}
+
+	scaled := (min(magnitude, 1) - deadzone) / (1 - deadzone)
+	return x / magnitude * scaled, y / magnitude * scaled
+}
+
+// TriggerDeadzone applies a dead zone to a trigger value from 0 to 1.
+func TriggerDeadzone(value, deadzone float32) float32 {
+	if value <= deadzone || deadzone >= 1 {
+		return 0
+	}
+	return (min(value, 1) - deadzone) / (1 - deadzone)
+}
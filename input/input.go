@@ -5,7 +5,7 @@ package input
 type State struct {
 	keyboard KeyboardState
 	mouse    MouseState
-	// Gamepads will be added later
+	gamepads [MaxGamepads]GamepadState
 }
 
 // New creates a new input state.
@@ -20,6 +20,9 @@ func New() *State {
 func (s *State) Update() {
 	s.keyboard.update()
 	s.mouse.update()
+	for i := range s.gamepads {
+		s.gamepads[i].update()
+	}
 }
 
 // Keyboard returns the keyboard state.
@@ -31,3 +34,22 @@ func (s *State) Keyboard() *KeyboardState {
 func (s *State) Mouse() *MouseState {
 	return &s.mouse
 }
+
+// Gamepad returns the state of a gamepad slot, or nil if slot is out of
+// range. Check Connected before use.
+func (s *State) Gamepad(slot int) *GamepadState {
+	if slot < 0 || slot >= MaxGamepads {
+		return nil
+	}
+	return &s.gamepads[slot]
+}
+
+// IsKeyDown reports whether key is held, like Keyboard().Pressed.
+func (s *State) IsKeyDown(key Key) bool {
+	return s.keyboard.Pressed(key)
+}
+
+// IsMouseButtonDown reports whether button is held, like Mouse().Pressed.
+func (s *State) IsMouseButtonDown(button MouseButton) bool {
+	return s.mouse.Pressed(button)
+}
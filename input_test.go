@@ -0,0 +1,70 @@
+package gogpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+func TestInputFrameSnapshot(t *testing.T) {
+	var chars []rune
+	a := &App{}
+	a.OnChar(func(r rune) { chars = append(chars, r) })
+
+	key := func(k input.Key, down bool) {
+		a.handleInputEvent(platform.Event{Type: platform.EventKey, Key: KeyEvent{Key: k, Down: down}})
+	}
+	scroll := func(y float64) {
+		a.handleInputEvent(platform.Event{Type: platform.EventScroll, Mouse: MouseEvent{ScrollY: y}})
+	}
+
+	key(input.KeyW, true)
+	scroll(1)
+	scroll(0.5)
+	a.handleInputEvent(platform.Event{Type: platform.EventMouseMove, Mouse: MouseEvent{X: 10, Y: 20}})
+	// Events of additional windows do not change the main window state
+	a.handleInputEvent(platform.Event{Type: platform.EventKey, Window: 2, Key: KeyEvent{Key: input.KeyA, Down: true}})
+	a.input.beginFrame()
+
+	in := a.Input()
+	if !in.IsKeyDown(input.KeyW) || !in.Keyboard().JustPressed(input.KeyW) {
+		t.Errorf("W not pressed in the first frame")
+	}
+	if in.IsKeyDown(input.KeyA) {
+		t.Errorf("key of another window is down")
+	}
+	if _, y := in.Mouse().Scroll(); y != 1.5 {
+		t.Errorf("scroll = %v, want 1.5", y)
+	}
+	if dx, dy := in.Mouse().Delta(); dx != 10 || dy != 20 {
+		t.Errorf("delta = %v, %v, want 10, 20", dx, dy)
+	}
+
+	// Events after the frame started wait for the next one
+	key(input.KeyW, false)
+	if !in.IsKeyDown(input.KeyW) {
+		t.Errorf("snapshot changed during the frame")
+	}
+
+	a.input.beginFrame()
+	if in.IsKeyDown(input.KeyW) || !in.Keyboard().JustReleased(input.KeyW) {
+		t.Errorf("W not released in the second frame")
+	}
+	if _, y := in.Mouse().Scroll(); y != 0 {
+		t.Errorf("scroll = %v in a frame without scrolling", y)
+	}
+
+	key(input.KeyShiftLeft, true)
+	a.input.releaseAll()
+	a.input.beginFrame()
+	if in.Keyboard().AnyPressed() {
+		t.Errorf("keys still down after losing focus")
+	}
+
+	a.handleChars(platform.TextEvent{Text: "é", Composing: true})
+	a.handleChars(platform.TextEvent{Text: "éa"})
+	if string(chars) != "éa" {
+		t.Errorf("chars = %q, want %q", string(chars), "éa")
+	}
+}
@@ -0,0 +1,93 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+)
+
+// Mouse capture constants
+const (
+	wmMove = 0x0003
+)
+
+var (
+	procClipCursor = user32.NewProc("ClipCursor")
+	procShowCursor = user32.NewProc("ShowCursor")
+)
+
+// SetMouseCaptured hides the cursor and confines it to the client area,
+// or releases it. Movement is read from raw input with MouseDelta. The
+// capture is lifted while the window is inactive and restored when it is
+// activated again. Must be called on the window's thread, as the cursor
+// display count is per thread.
+func (p *windowsPlatform) SetMouseCaptured(captured bool) error {
+	p.mouseCaptured = captured
+	p.applyMouseCapture(captured && p.active)
+	return nil
+}
+
+// applyMouseCapture applies or lifts the capture.
+func (p *windowsPlatform) applyMouseCapture(active bool) {
+	if active {
+		p.clipCursor()
+		p.hideCursor()
+		return
+	}
+	procClipCursor.Call(0)
+	p.restoreCursor()
+}
+
+// clipCursor confines the cursor to the client area in screen coordinates.
+// The clip is lost when the window moves or another application clips the
+// cursor, so it is renewed on moves, resizes and activation.
+func (p *windowsPlatform) clipCursor() {
+	var r rect
+	procGetClientRect.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&r)))
+	topLeft := point{x: r.left, y: r.top}
+	bottomRight := point{x: r.right, y: r.bottom}
+	procClientToScreen.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&topLeft)))
+	procClientToScreen.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&bottomRight)))
+
+	clip := rect{left: topLeft.x, top: topLeft.y, right: bottomRight.x, bottom: bottomRight.y}
+	procClipCursor.Call(uintptr(unsafe.Pointer(&clip)))
+}
+
+// hideCursor hides the cursor. ShowCursor keeps a display count, shown
+// while it is at least 0, which other code on the thread may have raised,
+// so it is decremented until hidden and the decrements are counted to be
+// undone exactly by restoreCursor.
+func (p *windowsPlatform) hideCursor() {
+	if p.cursorHides > 0 {
+		return
+	}
+	for {
+		count, _, _ := procShowCursor.Call(0)
+		p.cursorHides++
+		if int32(count) < 0 {
+			return
+		}
+	}
+}
+
+// restoreCursor undoes the decrements of hideCursor.
+func (p *windowsPlatform) restoreCursor() {
+	for ; p.cursorHides > 0; p.cursorHides-- {
+		procShowCursor.Call(1)
+	}
+}
+
+// updateMouseCapture lifts the capture when the window is deactivated and
+// restores it when the window is activated.
+func (p *windowsPlatform) updateMouseCapture() {
+	if p.mouseCaptured {
+		p.applyMouseCapture(p.active)
+	}
+}
+
+// handleMove renews the cursor clip after the window moved or resized.
+func (p *windowsPlatform) handleMove() {
+	if p.mouseCaptured && p.active {
+		p.clipCursor()
+	}
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+)
+
+// clipboardTimeout bounds how long reading the clipboard waits for the
+// client offering it.
+const clipboardTimeout = time.Second
+
+// Text MIME types, in order of preference. UTF8_STRING and text/plain
+// are offered by older X11 clients through Xwayland.
+var clipboardTextTypes = []string{"text/plain;charset=utf-8", "UTF8_STRING", "text/plain"}
+
+// ReadClipboardText returns the text of the CLIPBOARD selection.
+func (p *x11Platform) ReadClipboardText() (string, error) {
+	return p.inner.ReadClipboardText()
+}
+
+// WriteClipboardText makes the main window own the CLIPBOARD selection
+// with text.
+func (p *x11Platform) WriteClipboardText(text string) error {
+	return p.inner.WriteClipboardText(text)
+}
+
+// bindDataDevice binds the data device manager and gets the data device
+// of the seat, for the clipboard and file drops.
+func (p *waylandPlatform) bindDataDevice() error {
+	version := min(p.registry.GlobalVersion(wayland.InterfaceWlDataDeviceManager), 3)
+	managerID, err := p.registry.BindDataDeviceManager(version)
+	if err != nil {
+		return fmt.Errorf("failed to bind data device manager: %w", err)
+	}
+	p.dataDeviceManager = wayland.NewWlDataDeviceManager(p.display, managerID)
+
+	dataDevice, err := p.dataDeviceManager.GetDataDevice(p.seat)
+	if err != nil {
+		return fmt.Errorf("failed to get data device: %w", err)
+	}
+	p.dataDevice = dataDevice
+	p.dataDeviceVersion = version
+	p.setupDrop(dataDevice)
+	return nil
+}
+
+// ReadClipboardText returns the text of the selection, or "" if there is
+// none. Wayland only shares the selection with the focused client.
+func (p *waylandPlatform) ReadClipboardText() (string, error) {
+	p.mu.Lock()
+	dataDevice := p.dataDevice
+	owned, text := p.clipboardSource != nil, p.clipboardText
+	p.mu.Unlock()
+
+	if dataDevice == nil {
+		return "", ErrUnsupported
+	}
+	// The offer of our own source cannot be read while we wait for it
+	if owned {
+		return text, nil
+	}
+
+	offer := dataDevice.Selection()
+	if offer == nil {
+		return "", nil
+	}
+	mimeType := ""
+	for _, t := range clipboardTextTypes {
+		if offer.HasMimeType(t) {
+			mimeType = t
+			break
+		}
+	}
+	if mimeType == "" {
+		return "", nil // No text on the clipboard
+	}
+
+	var fds [2]int
+	if err := unix.Pipe2(fds[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		return "", fmt.Errorf("wayland: failed to create clipboard pipe: %w", err)
+	}
+	r := os.NewFile(uintptr(fds[0]), "clipboard")
+	defer r.Close()
+	err := offer.Receive(mimeType, fds[1])
+	_ = unix.Close(fds[1])
+	if err != nil {
+		return "", err
+	}
+
+	_ = r.SetReadDeadline(time.Now().Add(clipboardTimeout))
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("wayland: failed to read clipboard: %w", err)
+	}
+	return string(data), nil
+}
+
+// WriteClipboardText makes a data source with text the selection. The
+// text is served to other clients until another source replaces it.
+func (p *waylandPlatform) WriteClipboardText(text string) error {
+	p.mu.Lock()
+	manager, dataDevice := p.dataDeviceManager, p.dataDevice
+	var serial uint32
+	if p.keyboard != nil {
+		serial = p.keyboard.LastSerial()
+	}
+	if serial == 0 {
+		serial = p.buttonSerial
+	}
+	p.mu.Unlock()
+
+	if dataDevice == nil {
+		return ErrUnsupported
+	}
+
+	source, err := manager.CreateDataSource()
+	if err != nil {
+		return err
+	}
+	for _, t := range clipboardTextTypes {
+		if err := source.Offer(t); err != nil {
+			_ = source.Destroy()
+			return err
+		}
+	}
+	source.SetSendHandler(func(_ string, fd int) {
+		// Write from a goroutine so a slow reader does not block events
+		w := os.NewFile(uintptr(fd), "clipboard")
+		go func() {
+			_, _ = io.WriteString(w, text)
+			_ = w.Close()
+		}()
+	})
+	source.SetCancelledHandler(func() {
+		p.mu.Lock()
+		if p.clipboardSource == source {
+			p.clipboardSource = nil
+			p.clipboardText = ""
+		}
+		p.mu.Unlock()
+		_ = source.Destroy()
+	})
+
+	if err := dataDevice.SetSelection(source, serial); err != nil {
+		_ = source.Destroy()
+		return err
+	}
+
+	p.mu.Lock()
+	previous := p.clipboardSource
+	p.clipboardSource = source
+	p.clipboardText = text
+	p.mu.Unlock()
+
+	if previous != nil {
+		_ = previous.Destroy()
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+//go:build windows
+
+package platform
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// Clipboard constants
+const (
+	cfDIB         = 8
+	cfUnicodeText = 13
+
+	gmemMoveable = 0x0002
+
+	biRGB       = 0
+	biBitfields = 3
+
+	// Size of BITMAPINFOHEADER
+	bitmapInfoHeaderSize = 40
+)
+
+var (
+	procOpenClipboard              = user32.NewProc("OpenClipboard")
+	procCloseClipboard             = user32.NewProc("CloseClipboard")
+	procEmptyClipboard             = user32.NewProc("EmptyClipboard")
+	procGetClipboardData           = user32.NewProc("GetClipboardData")
+	procSetClipboardData           = user32.NewProc("SetClipboardData")
+	procIsClipboardFormatAvailable = user32.NewProc("IsClipboardFormatAvailable")
+	procGlobalAlloc                = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree                 = kernel32.NewProc("GlobalFree")
+	procGlobalLock                 = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock               = kernel32.NewProc("GlobalUnlock")
+	procGlobalSize                 = kernel32.NewProc("GlobalSize")
+)
+
+// errClipboardBusy is returned when another application keeps the
+// clipboard open.
+var errClipboardBusy = errors.New("platform: clipboard is in use by another application")
+
+// openClipboard opens the clipboard for the window. Another application
+// may hold it open briefly, so opening is retried for a short while.
+func (p *windowsPlatform) openClipboard() error {
+	for attempt := 0; attempt < 10; attempt++ {
+		ret, _, _ := procOpenClipboard.Call(uintptr(p.hwnd))
+		if ret != 0 {
+			return nil
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return errClipboardBusy
+}
+
+// memoryBytes returns size bytes of system memory at ptr, such as a locked
+// global memory object.
+func memoryBytes(ptr uintptr, size int) []byte {
+	return unsafe.Slice(*(**byte)(unsafe.Pointer(&ptr)), size)
+}
+
+// readClipboardData copies the clipboard data of a format, or returns nil
+// if the clipboard holds no such data. The clipboard must be open.
+func readClipboardData(format uintptr) []byte {
+	if ok, _, _ := procIsClipboardFormatAvailable.Call(format); ok == 0 {
+		return nil
+	}
+	handle, _, _ := procGetClipboardData.Call(format)
+	if handle == 0 {
+		return nil
+	}
+
+	size, _, _ := procGlobalSize.Call(handle)
+	ptr, _, _ := procGlobalLock.Call(handle)
+	if ptr == 0 {
+		return nil
+	}
+	defer procGlobalUnlock.Call(handle)
+
+	return append([]byte(nil), memoryBytes(ptr, int(size))...)
+}
+
+// writeClipboardData replaces the clipboard contents with data of one
+// format. The clipboard must be open.
+func writeClipboardData(format uintptr, data []byte) error {
+	handle, _, _ := procGlobalAlloc.Call(gmemMoveable, uintptr(len(data)))
+	if handle == 0 {
+		return fmt.Errorf("platform: GlobalAlloc failed")
+	}
+
+	ptr, _, _ := procGlobalLock.Call(handle)
+	if ptr == 0 {
+		procGlobalFree.Call(handle)
+		return fmt.Errorf("platform: GlobalLock failed")
+	}
+	copy(memoryBytes(ptr, len(data)), data)
+	procGlobalUnlock.Call(handle)
+
+	procEmptyClipboard.Call()
+	// On success the clipboard owns the memory
+	if ret, _, _ := procSetClipboardData.Call(format, handle); ret == 0 {
+		procGlobalFree.Call(handle)
+		return fmt.Errorf("platform: SetClipboardData failed")
+	}
+	return nil
+}
+
+// ReadClipboardText returns the CF_UNICODETEXT clipboard text.
+func (p *windowsPlatform) ReadClipboardText() (string, error) {
+	if err := p.openClipboard(); err != nil {
+		return "", err
+	}
+	defer procCloseClipboard.Call()
+
+	data := readClipboardData(cfUnicodeText)
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		u := binary.LittleEndian.Uint16(data[i:])
+		if u == 0 {
+			break
+		}
+		units = append(units, u)
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// WriteClipboardText places text on the clipboard as CF_UNICODETEXT.
+// Windows converts it to the ANSI and OEM text formats on request.
+func (p *windowsPlatform) WriteClipboardText(text string) error {
+	units := utf16.Encode([]rune(text))
+	data := make([]byte, (len(units)+1)*2) // NUL terminated
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(data[i*2:], u)
+	}
+
+	if err := p.openClipboard(); err != nil {
+		return err
+	}
+	defer procCloseClipboard.Call()
+
+	return writeClipboardData(cfUnicodeText, data)
+}
+
+// ReadClipboardImage returns the clipboard image from CF_DIB, which
+// Windows also synthesizes from bitmaps and CF_DIBV5.
+func (p *windowsPlatform) ReadClipboardImage() (*image.NRGBA, error) {
+	if err := p.openClipboard(); err != nil {
+		return nil, err
+	}
+	data := readClipboardData(cfDIB)
+	procCloseClipboard.Call()
+
+	if data == nil {
+		return nil, nil
+	}
+	return decodeDIB(data)
+}
+
+// WriteClipboardImage places an image on the clipboard as a 32-bit CF_DIB.
+func (p *windowsPlatform) WriteClipboardImage(img *image.NRGBA) error {
+	data := encodeDIB(img)
+
+	if err := p.openClipboard(); err != nil {
+		return err
+	}
+	defer procCloseClipboard.Call()
+
+	return writeClipboardData(cfDIB, data)
+}
+
+// decodeDIB converts a packed device-independent bitmap with 24 or 32
+// bits per pixel. A 32-bit bitmap whose alpha is zero everywhere is taken
+// as opaque, since most applications leave the alpha byte unused.
+func decodeDIB(data []byte) (*image.NRGBA, error) {
+	if len(data) < bitmapInfoHeaderSize {
+		return nil, fmt.Errorf("platform: clipboard bitmap too short")
+	}
+
+	headerSize := int(binary.LittleEndian.Uint32(data[0:]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:])))
+	bitCount := int(binary.LittleEndian.Uint16(data[14:]))
+	compression := binary.LittleEndian.Uint32(data[16:])
+
+	topDown := height < 0
+	if topDown {
+		height = -height
+	}
+	if width <= 0 || height <= 0 || headerSize < bitmapInfoHeaderSize {
+		return nil, fmt.Errorf("platform: invalid clipboard bitmap")
+	}
+	if (bitCount != 24 && bitCount != 32) ||
+		(compression != biRGB && !(compression == biBitfields && bitCount == 32)) {
+		return nil, fmt.Errorf("platform: unsupported clipboard bitmap format (%d bpp, compression %d)",
+			bitCount, compression)
+	}
+
+	// Pixels follow the header, and the color masks of BI_BITFIELDS,
+	// which are stored after a plain BITMAPINFOHEADER
+	offset := headerSize
+	if compression == biBitfields && headerSize == bitmapInfoHeaderSize {
+		offset += 12
+	}
+
+	stride := (width*bitCount + 31) / 32 * 4
+	if len(data) < offset+stride*height {
+		return nil, fmt.Errorf("platform: clipboard bitmap too short")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	bytesPerPixel := bitCount / 8
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := y
+		if !topDown {
+			row = height - 1 - y
+		}
+		src := data[offset+row*stride:]
+		dst := img.Pix[y*img.Stride:]
+		for x := 0; x < width; x++ {
+			s := src[x*bytesPerPixel:]
+			dst[x*4+0] = s[2]
+			dst[x*4+1] = s[1]
+			dst[x*4+2] = s[0]
+			dst[x*4+3] = 0xFF
+			if bytesPerPixel == 4 {
+				dst[x*4+3] = s[3]
+				hasAlpha = hasAlpha || s[3] != 0
+			}
+		}
+	}
+
+	if bytesPerPixel == 4 && !hasAlpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xFF
+		}
+	}
+	return img, nil
+}
+
+// encodeDIB converts an image to a packed bottom-up 32-bit BI_RGB bitmap,
+// the layout most applications read reliably.
+func encodeDIB(img *image.NRGBA) []byte {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	stride := width * 4
+
+	data := make([]byte, bitmapInfoHeaderSize+stride*height)
+	binary.LittleEndian.PutUint32(data[0:], bitmapInfoHeaderSize)
+	binary.LittleEndian.PutUint32(data[4:], uint32(width))
+	binary.LittleEndian.PutUint32(data[8:], uint32(height))
+	binary.LittleEndian.PutUint16(data[12:], 1)  // planes
+	binary.LittleEndian.PutUint16(data[14:], 32) // bits per pixel
+	binary.LittleEndian.PutUint32(data[16:], biRGB)
+	binary.LittleEndian.PutUint32(data[20:], uint32(stride*height))
+
+	pixels := data[bitmapInfoHeaderSize:]
+	for y := 0; y < height; y++ {
+		src := img.Pix[y*img.Stride:]
+		dst := pixels[(height-1-y)*stride:]
+		for x := 0; x < width; x++ {
+			dst[x*4+0] = src[x*4+2]
+			dst[x*4+1] = src[x*4+1]
+			dst[x*4+2] = src[x*4+0]
+			dst[x*4+3] = src[x*4+3]
+		}
+	}
+	return data
+}
@@ -0,0 +1,77 @@
+//go:build darwin
+
+package platform
+
+import (
+	"image"
+
+	"github.com/gogpu/gogpu/internal/platform/darwin"
+)
+
+// SetCursor shows a standard cursor over a window's content view.
+func (p *darwinPlatform) SetCursor(id WindowID, shape CursorShape) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	if shape == CursorDefault {
+		w.window.SetCursor(nil)
+		return nil
+	}
+	w.window.SetCursor(darwin.StandardCursor(darwinCursorShape(shape)))
+	return nil
+}
+
+// SetCustomCursor shows a cursor made from pixels over a window's
+// content view, at one point per pixel.
+func (p *darwinPlatform) SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+
+	img := &image.RGBA{Pix: pixels, Stride: width * 4, Rect: image.Rect(0, 0, width, height)}
+	cursor, err := darwin.NewCursor(img, hotX, hotY)
+	if err != nil {
+		return err
+	}
+	// The window keeps its own reference
+	w.window.SetCursor(cursor)
+	cursor.Release()
+	return nil
+}
+
+// SetCursorVisible hides or shows the cursor over a window's content view.
+func (p *darwinPlatform) SetCursorVisible(id WindowID, visible bool) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	w.window.SetCursorVisible(visible)
+	return nil
+}
+
+// darwinCursorShape returns the NSCursor of a cursor shape. AppKit has no
+// public diagonal, four-way or busy cursors; those show the arrow.
+func darwinCursorShape(shape CursorShape) darwin.CursorShape {
+	switch shape {
+	case CursorText:
+		return darwin.CursorIBeam
+	case CursorCrosshair:
+		return darwin.CursorCrosshair
+	case CursorPointer:
+		return darwin.CursorPointingHand
+	case CursorGrab:
+		return darwin.CursorOpenHand
+	case CursorGrabbing:
+		return darwin.CursorClosedHand
+	case CursorResizeEW:
+		return darwin.CursorResizeLeftRight
+	case CursorResizeNS:
+		return darwin.CursorResizeUpDown
+	case CursorNotAllowed:
+		return darwin.CursorNotAllowed
+	default:
+		return darwin.CursorArrow
+	}
+}
@@ -0,0 +1,87 @@
+//go:build js && wasm
+
+package platform
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strconv"
+)
+
+// cssCursors maps cursor shapes to CSS cursor values.
+var cssCursors = [...]string{
+	CursorDefault:    "default",
+	CursorText:       "text",
+	CursorCrosshair:  "crosshair",
+	CursorPointer:    "pointer",
+	CursorGrab:       "grab",
+	CursorGrabbing:   "grabbing",
+	CursorResizeEW:   "ew-resize",
+	CursorResizeNS:   "ns-resize",
+	CursorResizeNWSE: "nwse-resize",
+	CursorResizeNESW: "nesw-resize",
+	CursorResizeAll:  "move",
+	CursorNotAllowed: "not-allowed",
+	CursorWait:       "wait",
+}
+
+// SetCursor sets the CSS cursor of the canvas.
+func (p *jsPlatform) SetCursor(id WindowID, shape CursorShape) error {
+	cursor := "default"
+	if int(shape) < len(cssCursors) {
+		cursor = cssCursors[shape]
+	}
+	return p.setCursor(id, cursor)
+}
+
+// SetCustomCursor sets the canvas cursor to a PNG data URL of the pixels.
+// Browsers ignore cursor images larger than 128x128.
+func (p *jsPlatform) SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error {
+	img := &image.RGBA{Pix: pixels, Stride: width * 4, Rect: image.Rect(0, 0, width, height)}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	url := "url(data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()) + ") " +
+		strconv.Itoa(hotX) + " " + strconv.Itoa(hotY) + ", default"
+	return p.setCursor(id, url)
+}
+
+// SetCursorVisible hides the cursor over the canvas, or shows the cursor
+// set last.
+func (p *jsPlatform) SetCursorVisible(id WindowID, visible bool) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cursorHidden = !visible
+	p.applyCursor()
+	return nil
+}
+
+// setCursor stores and applies the CSS cursor of the canvas.
+func (p *jsPlatform) setCursor(id WindowID, cursor string) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cursor = cursor
+	p.applyCursor()
+	return nil
+}
+
+// applyCursor sets the canvas style for the cursor state.
+func (p *jsPlatform) applyCursor() {
+	cursor := p.cursor
+	if p.cursorHidden {
+		cursor = "none"
+	}
+	p.canvas.Get("style").Set("cursor", cursor)
+}
@@ -0,0 +1,257 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+	"github.com/gogpu/gogpu/internal/platform/x11"
+)
+
+// linuxCursor is the cursor state of a window.
+type linuxCursor struct {
+	shape  CursorShape
+	hidden bool
+}
+
+// x11CursorGlyphs maps cursor shapes to glyphs of the X11 cursor font.
+// The font has no hand cursors for dragging; the four-way arrows stand in.
+var x11CursorGlyphs = [...]uint16{
+	CursorDefault:    x11.CursorGlyphLeftPtr,
+	CursorText:       x11.CursorGlyphXterm,
+	CursorCrosshair:  x11.CursorGlyphCrosshair,
+	CursorPointer:    x11.CursorGlyphHand2,
+	CursorGrab:       x11.CursorGlyphFleur,
+	CursorGrabbing:   x11.CursorGlyphFleur,
+	CursorResizeEW:   x11.CursorGlyphHDoubleArrow,
+	CursorResizeNS:   x11.CursorGlyphVDoubleArrow,
+	CursorResizeNWSE: x11.CursorGlyphBottomRightCorner,
+	CursorResizeNESW: x11.CursorGlyphBottomLeftCorner,
+	CursorResizeAll:  x11.CursorGlyphFleur,
+	CursorNotAllowed: x11.CursorGlyphX,
+	CursorWait:       x11.CursorGlyphWatch,
+}
+
+// SetCursor shows a cursor of the X11 cursor font over a window.
+func (p *x11Platform) SetCursor(id WindowID, shape CursorShape) error {
+	c, err := p.windowCursor(id)
+	if err != nil {
+		return err
+	}
+	c.shape = shape
+	return p.applyCursor(id, c)
+}
+
+// SetCustomCursor is not supported on X11: the core protocol only has
+// two-color cursors, and color cursors need the RENDER extension.
+func (p *x11Platform) SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error {
+	return ErrUnsupported
+}
+
+// SetCursorVisible hides the cursor over a window with a blank cursor,
+// or shows the window's cursor again.
+func (p *x11Platform) SetCursorVisible(id WindowID, visible bool) error {
+	c, err := p.windowCursor(id)
+	if err != nil {
+		return err
+	}
+	c.hidden = !visible
+	return p.applyCursor(id, c)
+}
+
+// windowCursor returns the cursor state of a window.
+func (p *x11Platform) windowCursor(id WindowID) (*linuxCursor, error) {
+	if _, ok := p.windows[id]; id != MainWindow && !ok {
+		return nil, fmt.Errorf("platform: unknown window %d", id)
+	}
+	if p.cursors == nil {
+		p.cursors = make(map[WindowID]*linuxCursor)
+	}
+	c := p.cursors[id]
+	if c == nil {
+		c = &linuxCursor{}
+		p.cursors[id] = c
+	}
+	return c, nil
+}
+
+// applyCursor defines the X11 cursor of a window from its cursor state.
+// The default shape uses the cursor of the root window.
+func (p *x11Platform) applyCursor(id WindowID, c *linuxCursor) error {
+	var cursor x11.ResourceID
+	var err error
+	switch {
+	case c.hidden:
+		cursor, err = p.inner.BlankCursor()
+	case c.shape != CursorDefault && int(c.shape) < len(x11CursorGlyphs):
+		cursor, err = p.inner.GlyphCursor(x11CursorGlyphs[c.shape])
+	}
+	if err != nil {
+		return err
+	}
+	return p.inner.SetWindowCursor(p.windows[id], cursor)
+}
+
+// waylandCursorShapes maps cursor shapes to cursor-shape-v1 shapes.
+var waylandCursorShapes = [...]wayland.CursorShape{
+	CursorDefault:    wayland.CursorShapeDefault,
+	CursorText:       wayland.CursorShapeText,
+	CursorCrosshair:  wayland.CursorShapeCrosshair,
+	CursorPointer:    wayland.CursorShapePointer,
+	CursorGrab:       wayland.CursorShapeGrab,
+	CursorGrabbing:   wayland.CursorShapeGrabbing,
+	CursorResizeEW:   wayland.CursorShapeEWResize,
+	CursorResizeNS:   wayland.CursorShapeNSResize,
+	CursorResizeNWSE: wayland.CursorShapeNWSEResize,
+	CursorResizeNESW: wayland.CursorShapeNESWResize,
+	CursorResizeAll:  wayland.CursorShapeMove,
+	CursorNotAllowed: wayland.CursorShapeNotAllowed,
+	CursorWait:       wayland.CursorShapeWait,
+}
+
+// bindCursorShape binds wp_cursor_shape_manager_v1 and creates the
+// cursor shape device of the pointer.
+func (p *waylandPlatform) bindCursorShape() error {
+	managerID, err := p.registry.BindCursorShapeManager(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind cursor shape manager: %w", err)
+	}
+	p.cursorShapeManager = wayland.NewWpCursorShapeManagerV1(p.display, managerID)
+
+	device, err := p.cursorShapeManager.GetPointer(p.pointer)
+	if err != nil {
+		return fmt.Errorf("failed to get cursor shape device: %w", err)
+	}
+	p.cursorShapeDevice = device
+	return nil
+}
+
+// SetCursor shows a cursor from the compositor's theme over the window.
+// Without cursor-shape-v1 there is no way to return to a theme cursor,
+// so SetCursor and SetCursorVisible report ErrUnsupported; custom
+// cursors still work.
+func (p *waylandPlatform) SetCursor(id WindowID, shape CursorShape) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cursorShapeDevice == nil {
+		return ErrUnsupported
+	}
+	p.releaseCursorImage()
+	p.cursor.shape = shape
+	return p.updateCursor()
+}
+
+// SetCustomCursor shows a cursor made from pixels over the window, from
+// a surface with a wl_shm buffer.
+func (p *waylandPlatform) SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pointer == nil {
+		return ErrUnsupported
+	}
+	if p.shm == nil {
+		shmID, err := p.registry.BindShm(1)
+		if err != nil {
+			return fmt.Errorf("failed to bind shm: %w", err)
+		}
+		p.shm = wayland.NewWlShm(p.display, shmID)
+	}
+
+	buffer, err := p.shm.CreateImageBuffer(width, height, pixels)
+	if err != nil {
+		return err
+	}
+	surface, err := p.compositor.CreateSurface()
+	if err != nil {
+		_ = buffer.Destroy()
+		return err
+	}
+	_ = surface.Attach(buffer.ID(), 0, 0)
+	_ = surface.DamageBuffer(0, 0, int32(width), int32(height)) //nolint:gosec // G115: cursor sizes are small
+	_ = surface.Commit()
+
+	p.releaseCursorImage()
+	p.cursorSurface, p.cursorBuffer = surface, buffer
+	p.cursorHotX, p.cursorHotY = int32(hotX), int32(hotY) //nolint:gosec // G115: inside the image
+	return p.updateCursor()
+}
+
+// SetCursorVisible hides the cursor over the window, or shows the
+// window's cursor again.
+func (p *waylandPlatform) SetCursorVisible(id WindowID, visible bool) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cursorShapeDevice == nil {
+		return ErrUnsupported
+	}
+	p.cursor.hidden = !visible
+	return p.updateCursor()
+}
+
+// updateCursor applies a changed cursor at once if the pointer is over
+// the window. Otherwise it is applied by the next pointer enter.
+func (p *waylandPlatform) updateCursor() error {
+	if p.pointer == nil || p.surface == nil || p.pointer.EnteredSurface() != p.surface.ID() {
+		return nil
+	}
+	return p.applyCursor(p.enterSerial)
+}
+
+// applyCursor sets the pointer cursor for the serial of the pointer
+// enter event. The caller holds p.mu.
+func (p *waylandPlatform) applyCursor(serial uint32) error {
+	switch {
+	case p.cursor.hidden:
+		return p.pointer.SetCursor(serial, nil, 0, 0)
+	case p.cursorSurface != nil:
+		return p.pointer.SetCursor(serial, p.cursorSurface, p.cursorHotX, p.cursorHotY)
+	case p.cursorShapeDevice != nil:
+		shape := wayland.CursorShapeDefault
+		if int(p.cursor.shape) < len(waylandCursorShapes) {
+			shape = waylandCursorShapes[p.cursor.shape]
+		}
+		return p.cursorShapeDevice.SetShape(serial, shape)
+	}
+	return nil
+}
+
+// releaseCursorImage destroys the surface and buffer of a custom cursor.
+func (p *waylandPlatform) releaseCursorImage() {
+	if p.cursorSurface != nil {
+		_ = p.cursorSurface.Destroy()
+		p.cursorSurface = nil
+	}
+	if p.cursorBuffer != nil {
+		_ = p.cursorBuffer.Destroy()
+		p.cursorBuffer = nil
+	}
+}
+
+// releaseCursor destroys the cursor objects. The caller holds p.mu.
+func (p *waylandPlatform) releaseCursor() {
+	p.releaseCursorImage()
+	if p.cursorShapeDevice != nil {
+		_ = p.cursorShapeDevice.Destroy()
+		p.cursorShapeDevice = nil
+	}
+	if p.cursorShapeManager != nil {
+		_ = p.cursorShapeManager.Destroy()
+		p.cursorShapeManager = nil
+	}
+}
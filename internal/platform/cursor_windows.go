@@ -0,0 +1,178 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Cursor constants
+const (
+	wmSetCursor = 0x0020
+	htClient    = 1
+
+	idcIBeam    = 32513
+	idcWait     = 32514
+	idcCross    = 32515
+	idcSizeNWSE = 32642
+	idcSizeNESW = 32643
+	idcSizeWE   = 32644
+	idcSizeNS   = 32645
+	idcSizeAll  = 32646
+	idcNo       = 32648
+	idcHand     = 32649
+)
+
+var (
+	procSetCursor    = user32.NewProc("SetCursor")
+	procGetCursorPos = user32.NewProc("GetCursorPos")
+)
+
+// win32Cursor is the cursor of a window, shown by WM_SETCURSOR while the
+// pointer is over the client area.
+type win32Cursor struct {
+	handle uintptr // HCURSOR, or 0 for the class cursor
+	custom bool    // handle was created by SetCustomCursor
+	hidden bool
+}
+
+// set replaces the cursor handle, destroying a custom one.
+func (c *win32Cursor) set(handle uintptr, custom bool) {
+	c.release()
+	c.handle, c.custom = handle, custom
+}
+
+// release destroys a custom cursor.
+func (c *win32Cursor) release() {
+	if c.custom && c.handle != 0 {
+		procDestroyIcon.Call(c.handle)
+	}
+	c.handle, c.custom = 0, false
+}
+
+// handleSetCursor answers WM_SETCURSOR for the client area, reporting
+// whether the cursor was set. Elsewhere, DefWindowProc shows the resize
+// and caption cursors.
+func (c *win32Cursor) handleSetCursor(lParam uintptr) bool {
+	if lParam&0xFFFF != htClient || (c.handle == 0 && !c.hidden) {
+		return false
+	}
+	if c.hidden {
+		procSetCursor.Call(0)
+	} else {
+		procSetCursor.Call(c.handle)
+	}
+	return true
+}
+
+// SetCursor shows a standard cursor over a window. Must be called on the
+// window's thread.
+func (p *windowsPlatform) SetCursor(id WindowID, shape CursorShape) error {
+	c, hwnd := p.windowCursor(id)
+	if c == nil {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	var cursor uintptr
+	if shape != CursorDefault {
+		cursor, _, _ = procLoadCursorW.Call(0, win32CursorID(shape))
+	}
+	c.set(cursor, false)
+	p.updateCursor(hwnd, c)
+	return nil
+}
+
+// SetCustomCursor shows a cursor made from pixels over a window. Must be
+// called on the window's thread.
+func (p *windowsPlatform) SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error {
+	c, hwnd := p.windowCursor(id)
+	if c == nil {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	cursor, err := newIconHandle(width, height, pixels, iconInfo{
+		xHotspot: uint32(hotX), //nolint:gosec // G115: inside the image
+		yHotspot: uint32(hotY), //nolint:gosec // G115: inside the image
+	})
+	if err != nil {
+		return err
+	}
+	c.set(cursor, true)
+	p.updateCursor(hwnd, c)
+	return nil
+}
+
+// SetCursorVisible hides or shows the cursor over a window. Must be
+// called on the window's thread.
+func (p *windowsPlatform) SetCursorVisible(id WindowID, visible bool) error {
+	c, hwnd := p.windowCursor(id)
+	if c == nil {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+	c.hidden = !visible
+	p.updateCursor(hwnd, c)
+	return nil
+}
+
+// windowCursor returns the cursor state and HWND of a window, or nil.
+func (p *windowsPlatform) windowCursor(id WindowID) (*win32Cursor, windows.HWND) {
+	if id == MainWindow {
+		return &p.cursor, p.hwnd
+	}
+	if w := p.windowByID(id); w != nil {
+		return &w.cursor, w.hwnd
+	}
+	return nil, 0
+}
+
+// updateCursor applies a changed cursor at once if the pointer is over
+// the window's client area, instead of on the next mouse move.
+func (p *windowsPlatform) updateCursor(hwnd windows.HWND, c *win32Cursor) {
+	var pt point
+	if ok, _, _ := procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt))); ok == 0 {
+		return
+	}
+	procScreenToClient.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&pt)))
+	var r rect
+	procGetClientRect.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&r)))
+	if pt.x < r.left || pt.y < r.top || pt.x >= r.right || pt.y >= r.bottom {
+		return
+	}
+
+	if !c.handleSetCursor(htClient) {
+		cursor, _, _ := procLoadCursorW.Call(0, uintptr(idcArrow))
+		procSetCursor.Call(cursor)
+	}
+}
+
+// win32CursorID returns the IDC_* resource of a cursor shape. Windows
+// has no hand cursors for dragging; the four-way arrows stand in.
+func win32CursorID(shape CursorShape) uintptr {
+	switch shape {
+	case CursorText:
+		return idcIBeam
+	case CursorCrosshair:
+		return idcCross
+	case CursorPointer:
+		return idcHand
+	case CursorGrab, CursorGrabbing, CursorResizeAll:
+		return idcSizeAll
+	case CursorResizeEW:
+		return idcSizeWE
+	case CursorResizeNS:
+		return idcSizeNS
+	case CursorResizeNWSE:
+		return idcSizeNWSE
+	case CursorResizeNESW:
+		return idcSizeNESW
+	case CursorNotAllowed:
+		return idcNo
+	case CursorWait:
+		return idcWait
+	default:
+		return idcArrow
+	}
+}
@@ -0,0 +1,114 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"strings"
+	"sync"
+)
+
+// appearanceObserverClassName is the name of the runtime-registered class
+// that observes the application's effective appearance.
+const appearanceObserverClassName = "GoGPUAppearanceObserver"
+
+// appearanceObserver holds the shared key-value observer.
+var appearanceObserver struct {
+	once     sync.Once
+	observer ID
+}
+
+// IsDarkMode reports whether the application uses a dark appearance,
+// following the system setting unless the app overrides it.
+func (a *Application) IsDarkMode() bool {
+	a.mu.Lock()
+	nsApp := a.nsApp
+	a.mu.Unlock()
+
+	return isDarkAppearance(nsApp)
+}
+
+// isDarkAppearance reports whether the effective appearance of NSApp is dark.
+// All dark appearances (DarkAqua, VibrantDark and their high contrast
+// variants) have "Dark" in their name.
+func isDarkAppearance(nsApp ID) bool {
+	if nsApp.IsNil() {
+		return false
+	}
+	appearance := nsApp.Send(selectors.effectiveAppearance)
+	if appearance.IsNil() {
+		return false
+	}
+	return strings.Contains(goStringFromNSString(appearance.Send(selectors.name)), "Dark")
+}
+
+// observeAppearance observes NSApp.effectiveAppearance with key-value
+// observing. AppKit updates it on the main thread when the user switches
+// between light and dark mode, including automatic switching.
+func observeAppearance(nsApp ID) {
+	appearanceObserver.once.Do(func() {
+		class := allocateClass(appearanceObserverClassName, classes.NSObject, nil, []classMethod{
+			// - (void)observeValueForKeyPath:(NSString *)keyPath ofObject:(id)object
+			//     change:(NSDictionary *)change context:(void *)context
+			{selectors.observeValueForKeyPath, appearanceObserverValueChanged, "v@:@@@^v"},
+		})
+		if class == 0 {
+			return
+		}
+
+		keyPath := NewNSString("effectiveAppearance")
+		if keyPath == nil {
+			return
+		}
+		defer keyPath.Release() // Copied by the observation
+
+		// Never released or removed: observes for the life of the process
+		appearanceObserver.observer = class.Send(selectors.new)
+		msgSend(nsApp, selectors.addObserverForKeyPath,
+			appearanceObserver.observer.Ptr(),
+			keyPath.ID().Ptr(),
+			0, // options
+			0, // context
+		)
+	})
+}
+
+// appearanceObserverValueChanged implements
+// -observeValueForKeyPath:ofObject:change:context:.
+func appearanceObserverValueChanged(_, _, _, object uintptr) uintptr {
+	GetApplication().handleAppearanceChanged(isDarkAppearance(ID(object)))
+	return 0
+}
+
+// SetAppearanceHandler sets a callback for switches between light and dark
+// appearance. The handler receives the new state and runs on the main
+// thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetAppearanceHandler(handler func(dark bool)) error {
+	a.mu.Lock()
+	if !a.initialized {
+		a.mu.Unlock()
+		return ErrApplicationNotInitialized
+	}
+	a.onAppearance = handler
+	a.darkMode = isDarkAppearance(a.nsApp)
+	nsApp := a.nsApp
+	a.mu.Unlock()
+
+	if handler != nil {
+		observeAppearance(nsApp)
+	}
+	return nil
+}
+
+// handleAppearanceChanged calls the appearance handler if the state changed.
+// KVO also fires for changes between two light or two dark appearances.
+func (a *Application) handleAppearanceChanged(dark bool) {
+	a.mu.Lock()
+	onAppearance := a.onAppearance
+	changed := dark != a.darkMode
+	a.darkMode = dark
+	a.mu.Unlock()
+
+	if changed && onAppearance != nil {
+		onAppearance(dark)
+	}
+}
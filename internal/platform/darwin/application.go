@@ -5,6 +5,7 @@ package darwin
 import (
 	"errors"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -19,10 +20,47 @@ var (
 type Application struct {
 	mu              sync.Mutex
 	nsApp           ID
-	pool            ID
+	pool            *AutoreleasePool
 	initialized     bool
 	running         bool
 	shouldTerminate bool
+
+	// Open windows keyed by NSWindow windowNumber, for event routing
+	windows map[int64]*Window
+
+	// Main menu bar, set by SetupMainMenu
+	menuBar ID
+
+	// Input method marked text of content views that are composing
+	markedText map[ID]string
+
+	// Event handlers
+	onGesture     func(*Window, GestureEvent)
+	onKey         func(*Window, KeyEvent)
+	onMouse       func(*Window, MouseEvent)
+	onDrop        func(*Window, DropEvent) bool
+	onMenu        func(tag int)
+	onTextInput   func(*Window, TextInputEvent)
+	onWindowEvent func(*Window, WindowEvent)
+	onResize      func(*Window, ResizePhase)
+
+	onScreensChanged      func()
+	onAppearance          func(dark bool)
+	onPower               func(PowerEvent)
+	onDisplayReconfigured func(displayID uint32, flags DisplayChangeFlags)
+
+	// Last reported appearance, to filter light-to-light changes
+	darkMode bool
+
+	// Mouse capture, engaged while its window is key, and the mouse
+	// movement accumulated for MouseDelta
+	captureWindow    *Window
+	captureEngaged   bool
+	mouseDX, mouseDY float64
+
+	// Left mouse down event (retained) while the button is held, for
+	// BeginWindowDrag
+	mouseDown ID
 }
 
 // global application instance
@@ -55,8 +93,8 @@ func (a *Application) Init() error {
 	initClasses()
 
 	// Create autorelease pool for initialization
-	a.pool = classes.NSAutoreleasePool.Send(selectors.new)
-	if a.pool.IsNil() {
+	a.pool = NewAutoreleasePool()
+	if a.pool.ID().IsNil() {
 		return errors.New("darwin: failed to create NSAutoreleasePool")
 	}
 
@@ -79,6 +117,18 @@ func (a *Application) Init() error {
 	return nil
 }
 
+// Activate makes the application active, bringing its windows to the
+// front of other applications' windows.
+func (a *Application) Activate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized {
+		return
+	}
+	a.nsApp.SendBool(selectors.activateIgnoringOtherApps, true)
+}
+
 // Terminate requests application termination.
 // This sets a flag that can be checked with ShouldTerminate().
 func (a *Application) Terminate() {
@@ -101,9 +151,15 @@ func (a *Application) Destroy() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	if a.pool != 0 {
-		a.pool.Send(selectors.drain)
-		a.pool = 0
+	if a.menuBar != 0 {
+		a.nsApp.SendPtr(selectors.setMainMenu, 0)
+		a.menuBar.Send(selectors.release)
+		a.menuBar = 0
+	}
+
+	if a.pool != nil {
+		a.pool.Drain()
+		a.pool = nil
 	}
 
 	a.initialized = false
@@ -120,8 +176,8 @@ func (a *Application) PollEvents() bool {
 	processed := false
 
 	// Create local autorelease pool for event processing
-	pool := classes.NSAutoreleasePool.Send(selectors.new)
-	defer pool.Send(selectors.drain)
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
 
 	// Get distant past date for non-blocking poll
 	distantPast := classes.NSDate.Send(selectors.distantPast)
@@ -136,7 +192,7 @@ func (a *Application) PollEvents() bool {
 		if event.IsNil() {
 			break
 		}
-		a.nsApp.SendPtr(selectors.sendEvent, event.Ptr())
+		a.handleEvent(event)
 		processed = true
 	}
 
@@ -151,8 +207,8 @@ func (a *Application) WaitEvents() {
 	}
 
 	// Create local autorelease pool
-	pool := classes.NSAutoreleasePool.Send(selectors.new)
-	defer pool.Send(selectors.drain)
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
 
 	// Get distant future date for blocking wait
 	distantFuture := classes.NSDate.Send(selectors.distantFuture)
@@ -164,13 +220,173 @@ func (a *Application) WaitEvents() {
 	// Wait for first event
 	event := a.nextEvent(distantFuture, modeStr.ID())
 	if !event.IsNil() {
-		a.nsApp.SendPtr(selectors.sendEvent, event.Ptr())
+		a.handleEvent(event)
 	}
 
 	// Process any remaining events
 	a.PollEvents()
 }
 
+// WaitEventsTimeout is like WaitEvents, but returns after timeout if no
+// event arrives, so the caller can check state changed by other goroutines.
+func (a *Application) WaitEventsTimeout(timeout time.Duration) {
+	if !a.initialized {
+		return
+	}
+
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	until := ID(classes.NSDate).SendDouble(selectors.dateWithTimeIntervalSinceNow, timeout.Seconds())
+
+	modeStr := NewNSString("kCFRunLoopDefaultMode")
+	defer modeStr.Release()
+
+	event := a.nextEvent(until, modeStr.ID())
+	if !event.IsNil() {
+		a.handleEvent(event)
+	}
+
+	a.PollEvents()
+}
+
+// SetGestureHandler sets a callback for trackpad gesture events.
+// The handler receives the window the gesture targets, or nil if the
+// event does not belong to a window created by this package.
+// The handler runs on the main thread from PollEvents/WaitEvents.
+func (a *Application) SetGestureHandler(handler func(*Window, GestureEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onGesture = handler
+}
+
+// handleEvent inspects an event for the registered handlers and
+// forwards it to NSApp so AppKit keeps its default behavior.
+func (a *Application) handleEvent(event ID) {
+	a.mu.Lock()
+	onGesture := a.onGesture
+	onKey := a.onKey
+	onMouse := a.onMouse
+	a.mu.Unlock()
+
+	if onGesture != nil {
+		if g, ok := gestureFromEvent(event); ok {
+			onGesture(a.windowForEvent(event), g)
+		}
+	}
+	if onKey != nil {
+		if k, ok := keyFromEvent(event); ok {
+			onKey(a.windowForEvent(event), k)
+		}
+	}
+	if onMouse != nil {
+		if m, ok := mouseFromEvent(event); ok {
+			onMouse(a.windowForEvent(event), m)
+		}
+	}
+	a.accumulateMouseDelta(event)
+	a.trackMouseDown(event)
+
+	a.nsApp.SendPtr(selectors.sendEvent, event.Ptr())
+}
+
+// SetDropHandler sets a callback for file drag-and-drop onto window
+// content views. The handler returns whether the window accepts the drag;
+// for DropPerform this is reported back to the drag source. The handler
+// runs on the main thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetDropHandler(handler func(*Window, DropEvent) bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onDrop = handler
+}
+
+// handleDrop is called by the GoGPUContentView dragging destination methods.
+func (a *Application) handleDrop(view, sender ID, phase DropPhase) bool {
+	a.mu.Lock()
+	onDrop := a.onDrop
+	a.mu.Unlock()
+
+	if onDrop == nil {
+		return false
+	}
+
+	event := DropEvent{Phase: phase}
+	if phase != DropLeave {
+		event.Location = sender.GetPoint(selectors.draggingLocation)
+	}
+	if phase == DropPerform {
+		event.Paths = draggedFilePaths(sender)
+		if len(event.Paths) == 0 {
+			return false
+		}
+	}
+
+	return onDrop(a.windowForView(view), event)
+}
+
+// addWindow registers a window for event routing.
+func (a *Application) addWindow(w *Window) {
+	number := w.Number()
+	if number <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.windows == nil {
+		a.windows = make(map[int64]*Window)
+	}
+	a.windows[number] = w
+}
+
+// removeWindow unregisters a window.
+func (a *Application) removeWindow(w *Window) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for number, win := range a.windows {
+		if win == w {
+			delete(a.windows, number)
+		}
+	}
+}
+
+// Windows returns the open windows in no particular order.
+func (a *Application) Windows() []*Window {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]*Window, 0, len(a.windows))
+	for _, w := range a.windows {
+		result = append(result, w)
+	}
+	return result
+}
+
+// windowForEvent returns the window an event was sent to, using
+// -[NSEvent windowNumber]. Returns nil for events without a window.
+func (a *Application) windowForEvent(event ID) *Window {
+	return a.windowForNumber(int64(event.Send(selectors.windowNumber)))
+}
+
+// windowForView returns the registered window containing a view.
+func (a *Application) windowForView(view ID) *Window {
+	return a.windowForNSWindow(view.Send(selectors.window))
+}
+
+// windowForNumber returns the registered window with the given windowNumber.
+func (a *Application) windowForNumber(number int64) *Window {
+	if number <= 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.windows[number]
+}
+
 // nextEvent retrieves the next event from the event queue.
 // date controls blocking behavior: distantPast for non-blocking, distantFuture for blocking.
 func (a *Application) nextEvent(date ID, mode ID) ID {
@@ -255,12 +471,37 @@ func (s *NSString) Release() {
 }
 
 // String returns the Go string representation.
-// Note: This requires reading from the NSString's UTF8String pointer,
-// which is more complex than shown here.
 func (s *NSString) String() string {
-	// Simplified: return empty string
-	// A full implementation would call UTF8String and read the C string
-	return ""
+	if s == nil {
+		return ""
+	}
+	return goStringFromNSString(s.id)
+}
+
+// goStringFromNSString copies the contents of an NSString object into a Go string.
+// The UTF8String buffer is autoreleased, so it is copied immediately.
+func goStringFromNSString(str ID) string {
+	if str.IsNil() {
+		return ""
+	}
+
+	cstr := uintptr(str.Send(selectors.UTF8String))
+	if cstr == 0 {
+		return ""
+	}
+
+	n := 0
+	for *(*byte)(ptrAt(cstr, n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice((*byte)(ptrAt(cstr, 0)), n))
+}
+
+// ptrAt converts a C address plus offset to an unsafe.Pointer.
+// The memory must be owned by the Objective-C runtime, not the Go heap.
+func ptrAt(base uintptr, offset int) unsafe.Pointer {
+	addr := base + uintptr(offset)
+	return *(*unsafe.Pointer)(unsafe.Pointer(&addr))
 }
 
 // bytesPtr returns a uintptr to the first element of the byte slice.
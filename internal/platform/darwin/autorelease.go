@@ -0,0 +1,83 @@
+//go:build darwin
+
+package darwin
+
+import "sync"
+
+// AutoreleasePool is an NSAutoreleasePool scope. Objects autoreleased on
+// a thread go to its innermost pool and are released when it is drained.
+//
+// Pools nest per thread and must be drained in the reverse order of their
+// creation, on the thread that created them. Draining an outer pool would
+// also drain the pools nested in it and leave their owners with dangling
+// pointers, so Drain panics on an out-of-order or repeated drain.
+type AutoreleasePool struct {
+	id     ID
+	thread ID // NSThread that created the pool
+}
+
+// autoreleasePools holds the open pools of each thread, innermost last.
+var autoreleasePools struct {
+	mu     sync.Mutex
+	stacks map[ID][]ID
+}
+
+// NewAutoreleasePool creates a pool on the calling thread, nested in the
+// thread's current pool.
+func NewAutoreleasePool() *AutoreleasePool {
+	initSelectors()
+	initClasses()
+
+	p := &AutoreleasePool{
+		id:     classes.NSAutoreleasePool.Send(selectors.new),
+		thread: classes.NSThread.Send(selectors.currentThread),
+	}
+	if p.id.IsNil() {
+		return p
+	}
+
+	autoreleasePools.mu.Lock()
+	defer autoreleasePools.mu.Unlock()
+
+	if autoreleasePools.stacks == nil {
+		autoreleasePools.stacks = make(map[ID][]ID)
+	}
+	autoreleasePools.stacks[p.thread] = append(autoreleasePools.stacks[p.thread], p.id)
+	return p
+}
+
+// ID returns the underlying NSAutoreleasePool, or 0 if creation failed.
+func (p *AutoreleasePool) ID() ID {
+	if p == nil {
+		return 0
+	}
+	return p.id
+}
+
+// Drain releases the objects autoreleased into the pool and the pool itself.
+// It panics if the pool is not the innermost open pool of its thread.
+func (p *AutoreleasePool) Drain() {
+	if p == nil || p.id.IsNil() {
+		return
+	}
+
+	autoreleasePools.mu.Lock()
+	stack := autoreleasePools.stacks[p.thread]
+	if len(stack) == 0 || stack[len(stack)-1] != p.id {
+		autoreleasePools.mu.Unlock()
+		panic("darwin: autorelease pool drained out of order")
+	}
+	if thread := classes.NSThread.Send(selectors.currentThread); thread != p.thread {
+		autoreleasePools.mu.Unlock()
+		panic("darwin: autorelease pool drained on another thread")
+	}
+	if len(stack) == 1 {
+		delete(autoreleasePools.stacks, p.thread)
+	} else {
+		autoreleasePools.stacks[p.thread] = stack[:len(stack)-1]
+	}
+	autoreleasePools.mu.Unlock()
+
+	p.id.Send(selectors.drain)
+	p.id = 0
+}
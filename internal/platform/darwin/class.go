@@ -0,0 +1,480 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// Errors returned by runtime class registration.
+var (
+	ErrClassExists         = errors.New("darwin: class already exists")
+	ErrProtocolNotFound    = errors.New("darwin: protocol not found")
+	ErrInvalidTypeEncoding = errors.New("darwin: unsupported method type encoding")
+	ErrNilMethodHandler    = errors.New("darwin: nil method handler")
+)
+
+// maxMethodArgs is the number of register arguments after self and _cmd
+// a Go method can receive. Together they fill the argument registers
+// of x86-64; arm64 has two more.
+const maxMethodArgs = 4
+
+// MethodHandler implements an Objective-C method in Go.
+//
+// args holds the arguments after self and _cmd that are passed in integer
+// registers, in order: objects, selectors, pointers, integers and BOOLs,
+// plus one entry per member of small integer structs such as NSRange.
+// Floating-point arguments, including NSPoint, NSSize and NSRect, travel in
+// other registers and are not delivered. The return value is the integer
+// result register; floating-point and struct results cannot be returned
+// beyond their first integer register.
+type MethodHandler func(self ID, cmd SEL, args []uintptr) uintptr
+
+// Method describes a Go-implemented method for RegisterClass.
+type Method struct {
+	Selector SEL
+	Types    string // Objective-C type encoding, e.g. "v@:@" for - (void)m:(id)arg
+	Handler  MethodHandler
+}
+
+// ClassBuilder creates an Objective-C class at runtime whose methods are
+// implemented in Go, e.g. delegates, notification observers and views.
+//
+// All methods added with AddMethod share a small set of trampolines, one
+// per argument count, that look up the handler by selector and receiver
+// class. Callback trampolines cannot be freed, so classes should be
+// created once per process, not per object.
+type ClassBuilder struct {
+	class Class
+}
+
+// NewClass starts a subclass of super. Add protocols and methods, then call
+// Register before creating instances. Returns ErrClassExists if a class with
+// that name is already known to the runtime.
+func NewClass(name string, super Class) (*ClassBuilder, error) {
+	if err := initRuntime(); err != nil {
+		return nil, err
+	}
+
+	cname := append([]byte(name), 0)
+	class := Class(callC(objcRT.objcAllocateClassPair, super.ClassPtr(), bytesPtr(cname), 0))
+	if class == 0 {
+		return nil, ErrClassExists
+	}
+	return &ClassBuilder{class: class}, nil
+}
+
+// Class returns the class being built.
+func (b *ClassBuilder) Class() Class {
+	return b.class
+}
+
+// AddProtocol declares that the class conforms to a protocol. Some AppKit
+// behavior depends on conformsToProtocol:, e.g. NSView only creates an
+// input context for NSTextInputClient views.
+func (b *ClassBuilder) AddProtocol(name string) error {
+	pname := append([]byte(name), 0)
+	proto := callC(objcRT.objcGetProtocol, bytesPtr(pname))
+	if proto == 0 {
+		return ErrProtocolNotFound
+	}
+	callC(objcRT.classAddProtocol, b.class.ClassPtr(), proto)
+	return nil
+}
+
+// AddMethod adds a Go-implemented instance method. types is the method's
+// Objective-C type encoding; it determines how many arguments the handler
+// receives. Methods inherited from the superclass are overridden.
+func (b *ClassBuilder) AddMethod(sel SEL, types string, handler MethodHandler) error {
+	if handler == nil {
+		return ErrNilMethodHandler
+	}
+	nargs, err := methodArgCount(types)
+	if err != nil {
+		return err
+	}
+
+	imp := dispatchTrampoline(nargs)
+	if imp == 0 {
+		return ErrSendFailed
+	}
+	addDispatchEntry(b.class, sel, handler)
+
+	ctypes := append([]byte(types), 0)
+	callC(objcRT.classAddMethod, b.class.ClassPtr(), sel.SELPtr(), imp, bytesPtr(ctypes))
+	return nil
+}
+
+// addIMP adds a method implemented by a Go function taking (self, _cmd,
+// args...) as uintptr and returning uintptr, called directly without the
+// dispatch table. fn must be a top-level function: its trampoline is
+// cached by code address and shared with every class using it.
+func (b *ClassBuilder) addIMP(sel SEL, types string, fn any) {
+	ctypes := append([]byte(types), 0)
+	callC(objcRT.classAddMethod, b.class.ClassPtr(), sel.SELPtr(), impCallback(fn), bytesPtr(ctypes))
+}
+
+// Register registers the class with the runtime and returns it.
+// Instance variables cannot be added afterwards, methods still can.
+func (b *ClassBuilder) Register() Class {
+	callC(objcRT.objcRegisterClassPair, b.class.ClassPtr())
+	return b.class
+}
+
+// RegisterClass creates and registers a subclass of super with the given
+// protocols and Go-implemented methods in one call.
+func RegisterClass(name string, super Class, protocols []string, methods []Method) (Class, error) {
+	b, err := NewClass(name, super)
+	if err != nil {
+		return 0, err
+	}
+	for _, proto := range protocols {
+		if err := b.AddProtocol(proto); err != nil {
+			return 0, fmt.Errorf("%w: %s", err, proto)
+		}
+	}
+	for _, m := range methods {
+		if err := b.AddMethod(m.Selector, m.Types, m.Handler); err != nil {
+			return 0, err
+		}
+	}
+	return b.Register(), nil
+}
+
+// classMethod describes a method of a package-internal runtime class.
+type classMethod struct {
+	sel   SEL
+	imp   any    // Top-level Go func taking (self, _cmd, args...) as uintptr
+	types string // Objective-C type encoding, e.g. "v@:@"
+}
+
+// allocateClass creates and registers a package-internal class whose
+// methods call Go functions directly. Unknown protocols are skipped. If a
+// class with the same name already exists it is returned unchanged.
+// Returns 0 on failure.
+func allocateClass(name string, super Class, protocols []string, methods []classMethod) Class {
+	b, err := NewClass(name, super)
+	if errors.Is(err, ErrClassExists) {
+		// Registered by an earlier call
+		return GetClass(name)
+	}
+	if err != nil {
+		return 0
+	}
+
+	for _, proto := range protocols {
+		_ = b.AddProtocol(proto)
+	}
+	for _, m := range methods {
+		b.addIMP(m.sel, m.types, m.imp)
+	}
+	return b.Register()
+}
+
+// impCallbacks caches the trampolines of directly called IMP functions.
+var impCallbacks struct {
+	mu        sync.Mutex
+	callbacks map[uintptr]uintptr // function code address -> trampoline
+}
+
+// impCallback returns the trampoline for a top-level Go function.
+func impCallback(fn any) uintptr {
+	key := reflect.ValueOf(fn).Pointer()
+
+	impCallbacks.mu.Lock()
+	defer impCallbacks.mu.Unlock()
+
+	if cb, ok := impCallbacks.callbacks[key]; ok {
+		return cb
+	}
+	if impCallbacks.callbacks == nil {
+		impCallbacks.callbacks = make(map[uintptr]uintptr)
+	}
+	cb := ffi.NewCallback(fn)
+	impCallbacks.callbacks[key] = cb
+	return cb
+}
+
+// dispatchEntry is a Go method handler of one class.
+type dispatchEntry struct {
+	class   Class
+	handler MethodHandler
+}
+
+// dispatch maps selectors to the Go handlers of classes built with
+// ClassBuilder.AddMethod.
+var dispatch struct {
+	mu      sync.RWMutex
+	methods map[SEL][]dispatchEntry
+
+	once        [maxMethodArgs + 1]sync.Once
+	trampolines [maxMethodArgs + 1]uintptr
+}
+
+// dispatchFuncs are the shared IMPs, indexed by argument count.
+var dispatchFuncs = [maxMethodArgs + 1]any{
+	func(self, cmd uintptr) uintptr {
+		return dispatchMethod(self, cmd)
+	},
+	func(self, cmd, a0 uintptr) uintptr {
+		return dispatchMethod(self, cmd, a0)
+	},
+	func(self, cmd, a0, a1 uintptr) uintptr {
+		return dispatchMethod(self, cmd, a0, a1)
+	},
+	func(self, cmd, a0, a1, a2 uintptr) uintptr {
+		return dispatchMethod(self, cmd, a0, a1, a2)
+	},
+	func(self, cmd, a0, a1, a2, a3 uintptr) uintptr {
+		return dispatchMethod(self, cmd, a0, a1, a2, a3)
+	},
+}
+
+// dispatchTrampoline returns the shared IMP for methods with nargs
+// register arguments, creating it on first use.
+func dispatchTrampoline(nargs int) uintptr {
+	if nargs < 0 || nargs > maxMethodArgs {
+		return 0
+	}
+	dispatch.once[nargs].Do(func() {
+		dispatch.trampolines[nargs] = ffi.NewCallback(dispatchFuncs[nargs])
+	})
+	return dispatch.trampolines[nargs]
+}
+
+// addDispatchEntry sets the handler of sel for class.
+func addDispatchEntry(class Class, sel SEL, handler MethodHandler) {
+	dispatch.mu.Lock()
+	defer dispatch.mu.Unlock()
+
+	if dispatch.methods == nil {
+		dispatch.methods = make(map[SEL][]dispatchEntry)
+	}
+	entries := dispatch.methods[sel]
+	for i := range entries {
+		if entries[i].class == class {
+			entries[i].handler = handler
+			return
+		}
+	}
+	dispatch.methods[sel] = append(entries, dispatchEntry{class: class, handler: handler})
+}
+
+// dispatchMethod calls the Go handler for a message. When several classes
+// implement the selector, the receiver's class hierarchy is walked to find
+// the nearest one, so subclasses inherit handlers like regular methods.
+func dispatchMethod(self, cmd uintptr, args ...uintptr) uintptr {
+	dispatch.mu.RLock()
+	entries := dispatch.methods[SEL(cmd)]
+	dispatch.mu.RUnlock()
+
+	var handler MethodHandler
+	if len(entries) == 1 {
+		handler = entries[0].handler
+	} else {
+		handler = findHandler(entries, Class(callC(objcRT.objectGetClass, self)))
+	}
+	if handler == nil {
+		return 0
+	}
+	return handler(ID(self), SEL(cmd), args)
+}
+
+// findHandler returns the handler of the nearest class in the hierarchy.
+func findHandler(entries []dispatchEntry, class Class) MethodHandler {
+	for ; class != 0; class = Class(callC(objcRT.classGetSuperclass, class.ClassPtr())) {
+		for _, e := range entries {
+			if e.class == class {
+				return e.handler
+			}
+		}
+	}
+	return nil
+}
+
+// methodArgCount returns the number of integer register arguments after
+// self and _cmd for a method type encoding such as "v@:@{_NSRange=QQ}".
+// Stack offsets in encodings like "v24@0:8@16" are ignored.
+func methodArgCount(types string) (int, error) {
+	rest := types
+	count := 0
+	for i := 0; rest != ""; i++ {
+		var n int
+		var err error
+		n, rest, err = parseTypeEncoding(rest)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case i == 0: // return type
+		case i == 1 && n != 1, i == 2 && n != 1:
+			return 0, ErrInvalidTypeEncoding // self and _cmd
+		case i > 2:
+			count += n
+		}
+		rest = strings.TrimLeft(rest, "0123456789")
+		if i < 2 && rest == "" {
+			return 0, ErrInvalidTypeEncoding
+		}
+	}
+	if count > maxMethodArgs {
+		return 0, ErrInvalidTypeEncoding
+	}
+	return count, nil
+}
+
+// parseTypeEncoding parses one type of an encoding. It returns the number
+// of integer registers a value of the type occupies and the remainder.
+func parseTypeEncoding(s string) (registers int, rest string, err error) {
+	// Method qualifiers: const, in, inout, out, bycopy, byref, oneway
+	s = strings.TrimLeft(s, "rnNoORV")
+	if s == "" {
+		return 0, "", ErrInvalidTypeEncoding
+	}
+
+	switch c := s[0]; c {
+	case 'v', 'f', 'd':
+		return 0, s[1:], nil
+	case 'c', 'i', 's', 'l', 'q', 'C', 'I', 'S', 'L', 'Q', 'B', '*', '#', ':':
+		return 1, s[1:], nil
+	case '@':
+		s = s[1:]
+		if strings.HasPrefix(s, "?") { // block
+			s = s[1:]
+		} else if strings.HasPrefix(s, `"`) { // class name
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				return 0, "", ErrInvalidTypeEncoding
+			}
+			s = s[end+2:]
+		}
+		return 1, s, nil
+	case '^':
+		if strings.HasPrefix(s[1:], "?") { // function pointer
+			return 1, s[2:], nil
+		}
+		_, rest, err := skipTypeEncoding(s[1:])
+		return 1, rest, err
+	case '{':
+		return parseStructEncoding(s)
+	default:
+		// Arrays, unions, bit fields and long double are not
+		// passed in integer registers
+		return 0, "", ErrInvalidTypeEncoding
+	}
+}
+
+// parseStructEncoding parses a struct passed by value. Supported are
+// structs of up to two integer members (e.g. NSRange), passed in integer
+// registers, and structs of only floating-point members (e.g. NSPoint),
+// passed in floating-point registers.
+func parseStructEncoding(s string) (registers int, rest string, err error) {
+	body, rest, err := skipTypeEncoding(s)
+	if err != nil {
+		return 0, "", err
+	}
+	eq := strings.IndexByte(body, '=')
+	if eq < 0 {
+		return 0, "", ErrInvalidTypeEncoding // opaque struct
+	}
+
+	members := body[eq+1 : len(body)-1]
+	ints, floats := 0, 0
+	for members != "" {
+		var n int
+		if members[0] == 'f' || members[0] == 'd' {
+			floats++
+			members = members[1:]
+			continue
+		}
+		if members[0] == '{' {
+			// Nested struct, e.g. NSRect: count its members as floats only
+			// if it has no integer registers
+			n, members, err = parseStructEncoding(members)
+			if err != nil {
+				return 0, "", err
+			}
+			if n == 0 {
+				floats++
+			}
+			ints += n
+			continue
+		}
+		n, members, err = parseTypeEncoding(members)
+		if err != nil {
+			return 0, "", err
+		}
+		ints += n
+	}
+
+	switch {
+	case ints == 0:
+		return 0, rest, nil
+	case floats == 0 && ints <= 2:
+		return ints, rest, nil
+	default:
+		return 0, "", ErrInvalidTypeEncoding
+	}
+}
+
+// skipTypeEncoding returns the first type of s and the remainder, matching
+// brackets of structs, arrays and unions.
+func skipTypeEncoding(s string) (typ, rest string, err error) {
+	s = strings.TrimLeft(s, "rnNoORV")
+	if s == "" {
+		return "", "", ErrInvalidTypeEncoding
+	}
+
+	switch s[0] {
+	case '{', '[', '(':
+		depth := 0
+		for i := 0; i < len(s); i++ {
+			switch s[i] {
+			case '{', '[', '(':
+				depth++
+			case '}', ']', ')':
+				depth--
+				if depth == 0 {
+					return s[:i+1], s[i+1:], nil
+				}
+			}
+		}
+		return "", "", ErrInvalidTypeEncoding
+	case '^':
+		typ, rest, err := skipTypeEncoding(s[1:])
+		return "^" + typ, rest, err
+	case '@':
+		_, rest, err := parseTypeEncoding(s)
+		return s[:len(s)-len(rest)], rest, err
+	default:
+		return s[:1], s[1:], nil
+	}
+}
+
+// objcSuper mirrors struct objc_super.
+type objcSuper struct {
+	receiver   ID
+	superClass Class
+}
+
+// sendSuper calls the implementation of sel in super for self, like
+// [super sel] in a method of a class created at runtime.
+// Overrides use it when AppKit requires the inherited behavior.
+func sendSuper(self ID, super Class, sel SEL, args ...uintptr) ID {
+	sup := objcSuper{receiver: self, superClass: super}
+	callArgs := append([]uintptr{uintptr(unsafe.Pointer(&sup)), sel.SELPtr()}, args...)
+	return ID(callC(objcRT.objcMsgSendSuper, callArgs...))
+}
+
+// SendSuper calls the superclass implementation of sel for self, from a
+// MethodHandler of a class whose superclass is super.
+func SendSuper(self ID, super Class, sel SEL, args ...uintptr) ID {
+	return sendSuper(self, super, sel, args...)
+}
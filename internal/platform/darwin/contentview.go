@@ -0,0 +1,53 @@
+//go:build darwin
+
+package darwin
+
+import "sync"
+
+// contentViewClassName is the name of the runtime-registered content view class.
+const contentViewClassName = "GoGPUContentView"
+
+// contentView holds the lazily registered NSView subclass used as the
+// content view of every window. It is a file drop destination
+// (NSDraggingDestination) and a text input client (NSTextInputClient),
+// reports live resizes and keeps the window's cursor via cursor rects.
+var contentView struct {
+	once  sync.Once
+	class Class
+}
+
+// contentViewClass registers GoGPUContentView.
+// Returns 0 if the class could not be created.
+func contentViewClass() Class {
+	contentView.once.Do(func() {
+		if err := initRuntime(); err != nil {
+			return
+		}
+		initSelectors()
+		initClasses()
+
+		methods := append(dropMethods(), textInputMethods()...)
+		methods = append(methods, liveResizeMethods()...)
+		methods = append(methods, cursorMethods()...)
+		contentView.class = allocateClass(contentViewClassName, classes.NSView,
+			[]string{"NSTextInputClient"}, methods)
+	})
+	return contentView.class
+}
+
+// newContentView creates a GoGPUContentView registered for file drops.
+// Returns 0 if the class is unavailable; the caller owns the view.
+func newContentView(frame NSRect) ID {
+	class := contentViewClass()
+	if class == 0 {
+		return 0
+	}
+
+	view := class.Send(selectors.alloc).SendRect(selectors.initWithFrame, frame)
+	if view.IsNil() {
+		return 0
+	}
+
+	registerForFileDrops(view)
+	return view
+}
@@ -0,0 +1,197 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// coreGraphics holds the lazily loaded CoreGraphics symbols.
+var coreGraphics struct {
+	once sync.Once
+	err  error
+
+	lib         unsafe.Pointer
+	copyMode    unsafe.Pointer // CGDisplayCopyDisplayMode
+	refreshRate unsafe.Pointer // CGDisplayModeGetRefreshRate
+	releaseMode unsafe.Pointer // CGDisplayModeRelease
+	associate   unsafe.Pointer // CGAssociateMouseAndMouseCursorPosition
+	warp        unsafe.Pointer // CGWarpMouseCursorPosition
+	register    unsafe.Pointer // CGDisplayRegisterReconfigurationCallback
+
+	cifCopy      *types.CallInterface
+	cifRate      *types.CallInterface
+	cifRelease   *types.CallInterface
+	cifAssociate *types.CallInterface
+	cifWarp      *types.CallInterface
+	cifRegister  *types.CallInterface
+}
+
+// initCoreGraphics loads CoreGraphics and prepares the call interfaces.
+func initCoreGraphics() error {
+	coreGraphics.once.Do(func() {
+		coreGraphics.err = loadCoreGraphics()
+	})
+	return coreGraphics.err
+}
+
+// loadCoreGraphics resolves the display mode and mouse cursor functions.
+func loadCoreGraphics() error {
+	if err := initRuntime(); err != nil {
+		return err
+	}
+
+	var err error
+	coreGraphics.lib, err = ffi.LoadLibrary(
+		"/System/Library/Frameworks/CoreGraphics.framework/CoreGraphics")
+	if err != nil {
+		return errors.Join(ErrLibraryNotLoaded, err)
+	}
+
+	symbols := []struct {
+		name string
+		ptr  *unsafe.Pointer
+	}{
+		{"CGDisplayCopyDisplayMode", &coreGraphics.copyMode},
+		{"CGDisplayModeGetRefreshRate", &coreGraphics.refreshRate},
+		{"CGDisplayModeRelease", &coreGraphics.releaseMode},
+		{"CGAssociateMouseAndMouseCursorPosition", &coreGraphics.associate},
+		{"CGWarpMouseCursorPosition", &coreGraphics.warp},
+		{"CGDisplayRegisterReconfigurationCallback", &coreGraphics.register},
+	}
+	for _, sym := range symbols {
+		*sym.ptr, err = ffi.GetSymbol(coreGraphics.lib, sym.name)
+		if err != nil {
+			return errors.Join(ErrSymbolNotFound, err)
+		}
+	}
+
+	// CGDisplayModeRef CGDisplayCopyDisplayMode(CGDirectDisplayID)
+	coreGraphics.cifCopy = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreGraphics.cifCopy,
+		types.DefaultCall,
+		types.PointerTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // display
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// double CGDisplayModeGetRefreshRate(CGDisplayModeRef)
+	coreGraphics.cifRate = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreGraphics.cifRate,
+		types.DefaultCall,
+		types.DoubleTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // mode
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// void CGDisplayModeRelease(CGDisplayModeRef)
+	coreGraphics.cifRelease = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreGraphics.cifRelease,
+		types.DefaultCall,
+		types.PointerTypeDescriptor, // void, result ignored
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // mode
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// CGError CGAssociateMouseAndMouseCursorPosition(boolean_t connected)
+	coreGraphics.cifAssociate = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreGraphics.cifAssociate,
+		types.DefaultCall,
+		types.UInt32TypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // connected
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// CGError CGWarpMouseCursorPosition(CGPoint newCursorPosition)
+	// The CGPoint is passed as its two CGFloat members, which is how
+	// the C ABI passes a struct of two doubles.
+	coreGraphics.cifWarp = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreGraphics.cifWarp,
+		types.DefaultCall,
+		types.UInt32TypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.DoubleTypeDescriptor, // x
+			types.DoubleTypeDescriptor, // y
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// CGError CGDisplayRegisterReconfigurationCallback(
+	//     CGDisplayReconfigurationCallBack callback, void *userInfo)
+	coreGraphics.cifRegister = &types.CallInterface{}
+	return ffi.PrepareCallInterface(
+		coreGraphics.cifRegister,
+		types.DefaultCall,
+		types.UInt32TypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // callback
+			types.PointerTypeDescriptor, // userInfo
+		},
+	)
+}
+
+// associateMouse connects or disconnects mouse movement from the cursor
+// position. While disconnected, the cursor stays put and mouse events
+// still report movement through their deltas.
+func associateMouse(connected bool) {
+	if initCoreGraphics() != nil {
+		return
+	}
+
+	var arg, result uint32
+	if connected {
+		arg = 1
+	}
+	_ = ffi.CallFunction(
+		coreGraphics.cifAssociate,
+		coreGraphics.associate,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{unsafe.Pointer(&arg)},
+	)
+}
+
+// warpMouse moves the cursor to a point in global display coordinates
+// (points, top-left origin of the primary display, y down). It does not
+// generate mouse events.
+func warpMouse(point NSPoint) {
+	if initCoreGraphics() != nil {
+		return
+	}
+
+	x, y := float64(point.X), float64(point.Y)
+	var result uint32
+	_ = ffi.CallFunction(
+		coreGraphics.cifWarp,
+		coreGraphics.warp,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{unsafe.Pointer(&x), unsafe.Pointer(&y)},
+	)
+}
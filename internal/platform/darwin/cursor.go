@@ -0,0 +1,296 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"sync"
+	"unsafe"
+)
+
+// Errors returned by cursor operations.
+var (
+	ErrCursorCreationFailed = errors.New("darwin: cursor creation failed")
+)
+
+// CursorShape identifies a standard system cursor.
+type CursorShape uint8
+
+// Standard cursor shapes.
+const (
+	CursorArrow CursorShape = iota
+	CursorIBeam
+	CursorCrosshair
+	CursorPointingHand
+	CursorResizeLeftRight
+	CursorResizeUpDown
+	CursorOpenHand
+	CursorClosedHand
+	CursorNotAllowed
+)
+
+// Cursor wraps an NSCursor.
+type Cursor struct {
+	id    ID
+	owned bool // created by NewCursor and released by Release
+}
+
+// StandardCursor returns the system cursor for a shape.
+// Standard cursors are shared; Release is a no-op for them.
+func StandardCursor(shape CursorShape) *Cursor {
+	initSelectors()
+	initClasses()
+
+	var sel SEL
+	switch shape {
+	case CursorIBeam:
+		sel = selectors.IBeamCursor
+	case CursorCrosshair:
+		sel = selectors.crosshairCursor
+	case CursorPointingHand:
+		sel = selectors.pointingHandCursor
+	case CursorResizeLeftRight:
+		sel = selectors.resizeLeftRightCursor
+	case CursorResizeUpDown:
+		sel = selectors.resizeUpDownCursor
+	case CursorOpenHand:
+		sel = selectors.openHandCursor
+	case CursorClosedHand:
+		sel = selectors.closedHandCursor
+	case CursorNotAllowed:
+		sel = selectors.operationNotAllowedCursor
+	default:
+		sel = selectors.arrowCursor
+	}
+
+	return &Cursor{id: classes.NSCursor.Send(sel)}
+}
+
+// NewCursor creates a cursor from an image. (hotX, hotY) is the click
+// point in image pixels from the top-left corner. The image is shown at
+// one point per pixel. Call Release when the cursor is no longer used.
+func NewCursor(img image.Image, hotX, hotY int) (*Cursor, error) {
+	if err := initRuntime(); err != nil {
+		return nil, err
+	}
+	initSelectors()
+	initClasses()
+
+	bounds := img.Bounds()
+	rgba, ok := img.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+
+	nsImage := newImageFromRGBA(rgba)
+	if nsImage.IsNil() {
+		return nil, ErrCursorCreationFailed
+	}
+	defer nsImage.Send(selectors.release) // Retained by the cursor
+
+	hotSpot := NSPoint{X: CGFloat(hotX), Y: CGFloat(hotY)}
+	cursor := classes.NSCursor.Send(selectors.alloc).SendPtrPoint(
+		selectors.initWithImageHotSpot, nsImage.Ptr(), hotSpot)
+	if cursor.IsNil() {
+		return nil, ErrCursorCreationFailed
+	}
+
+	return &Cursor{id: cursor, owned: true}, nil
+}
+
+// ID returns the underlying NSCursor.
+func (c *Cursor) ID() ID {
+	if c == nil {
+		return 0
+	}
+	return c.id
+}
+
+// Release releases a cursor created by NewCursor.
+// Windows using the cursor keep their own reference.
+func (c *Cursor) Release() {
+	if c == nil || !c.owned || c.id.IsNil() {
+		return
+	}
+	c.id.Send(selectors.release)
+	c.id = 0
+}
+
+// hiddenCursor holds a fully transparent cursor. Hiding the cursor with
+// a cursor rect, rather than +[NSCursor hide], limits it to the content
+// view and survives the pointer leaving and re-entering the window.
+var hiddenCursor struct {
+	once   sync.Once
+	cursor *Cursor
+}
+
+// transparentCursor returns the shared transparent cursor, or nil.
+func transparentCursor() *Cursor {
+	hiddenCursor.once.Do(func() {
+		cursor, err := NewCursor(image.NewRGBA(image.Rect(0, 0, 16, 16)), 0, 0)
+		if err == nil {
+			hiddenCursor.cursor = cursor
+		}
+	})
+	return hiddenCursor.cursor
+}
+
+// newImageFromRGBA creates an NSImage with a copy of premultiplied RGBA
+// pixels. The caller owns the image.
+func newImageFromRGBA(rgba *image.RGBA) ID {
+	width, height := rgba.Rect.Dx(), rgba.Rect.Dy()
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+
+	colorSpace := NewNSString("NSDeviceRGBColorSpace")
+	if colorSpace == nil {
+		return 0
+	}
+	defer colorSpace.Release()
+
+	// A nil planes pointer makes the rep allocate its own pixel buffer,
+	// so no Go memory is referenced after this function returns
+	rep := msgSend(classes.NSBitmapImageRep.Send(selectors.alloc), selectors.initWithBitmapDataPlanes,
+		0,                     // planes
+		uintptr(width),        // pixelsWide
+		uintptr(height),       // pixelsHigh
+		8,                     // bitsPerSample
+		4,                     // samplesPerPixel
+		uintptr(YES),          // hasAlpha
+		uintptr(NO),           // isPlanar
+		colorSpace.ID().Ptr(), // colorSpaceName
+		uintptr(width*4),      // bytesPerRow
+		32,                    // bitsPerPixel
+	)
+	if rep.IsNil() {
+		return 0
+	}
+	defer rep.Send(selectors.release) // Retained by the image
+
+	data := uintptr(rep.Send(selectors.bitmapData))
+	if data == 0 {
+		return 0
+	}
+	dst := unsafe.Slice((*byte)(ptrAt(data, 0)), width*height*4)
+	for y := 0; y < height; y++ {
+		copy(dst[y*width*4:(y+1)*width*4], rgba.Pix[y*rgba.Stride:y*rgba.Stride+width*4])
+	}
+
+	size := NSSize{Width: CGFloat(width), Height: CGFloat(height)}
+	nsImage := classes.NSImage.Send(selectors.alloc).SendSize(selectors.initWithSize, size)
+	if nsImage.IsNil() {
+		return 0
+	}
+	nsImage.SendPtr(selectors.addRepresentation, rep.Ptr())
+	return nsImage
+}
+
+// cursorMethods returns the cursor rect override of the content view.
+func cursorMethods() []classMethod {
+	return []classMethod{
+		// - (void)resetCursorRects
+		{selectors.resetCursorRects, contentViewResetCursorRects, "v@:"},
+	}
+}
+
+// contentViewResetCursorRects implements -resetCursorRects. AppKit calls it
+// whenever cursor rects are invalidated, e.g. after a resize, and sets the
+// cursor each time the pointer enters the view.
+func contentViewResetCursorRects(self, _ uintptr) uintptr {
+	w := GetApplication().windowForView(ID(self))
+	if w == nil {
+		return 0
+	}
+
+	if cursor := w.effectiveCursor(); !cursor.IsNil() {
+		view := ID(self)
+		view.SendRectPtr(selectors.addCursorRect, view.GetRect(selectors.bounds), cursor.Ptr())
+	}
+	return 0
+}
+
+// SetCursor sets the cursor shown over the window's content view.
+// A nil cursor restores the default arrow. The window keeps its own
+// reference, so the caller may release a custom cursor afterwards.
+func (w *Window) SetCursor(cursor *Cursor) {
+	w.mu.Lock()
+	if !w.cursor.IsNil() {
+		w.cursor.Send(selectors.release)
+	}
+	w.cursor = cursor.ID()
+	if !w.cursor.IsNil() {
+		w.cursor.Send(selectors.retain)
+	}
+	w.mu.Unlock()
+
+	w.updateCursor()
+}
+
+// SetCursorVisible shows or hides the cursor while it is over the
+// window's content view.
+func (w *Window) SetCursorVisible(visible bool) {
+	w.mu.Lock()
+	w.cursorHidden = !visible
+	w.mu.Unlock()
+
+	w.updateCursor()
+}
+
+// effectiveCursor returns the NSCursor for the content view,
+// or 0 for the default.
+func (w *Window) effectiveCursor() ID {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cursorHidden {
+		return transparentCursor().ID()
+	}
+	return w.cursor
+}
+
+// updateCursor rebuilds the cursor rects and, if the pointer is over the
+// content view, applies the cursor now instead of on the next mouse move.
+func (w *Window) updateCursor() {
+	w.mu.Lock()
+	nsWindow, view := w.nsWindow, w.contentView
+	w.mu.Unlock()
+
+	if nsWindow.IsNil() || view.IsNil() {
+		return
+	}
+
+	nsWindow.SendPtr(selectors.invalidateCursorRectsForView, view.Ptr())
+
+	// The content view fills the window's content area, so window and
+	// view coordinates share the origin
+	mouse := nsWindow.GetPoint(selectors.mouseLocationOutsideOfEventStream)
+	bounds := view.GetRect(selectors.bounds)
+	if mouse.X < 0 || mouse.Y < 0 || mouse.X >= bounds.Size.Width || mouse.Y >= bounds.Size.Height {
+		return
+	}
+
+	cursor := w.effectiveCursor()
+	if cursor.IsNil() {
+		cursor = classes.NSCursor.Send(selectors.arrowCursor)
+	}
+	cursor.Send(selectors.set)
+}
+
+// HideCursor hides the cursor everywhere until UnhideCursor, including
+// outside the application's windows. Calls must be balanced.
+func HideCursor() {
+	initSelectors()
+	initClasses()
+	classes.NSCursor.Send(selectors.cursorHide)
+}
+
+// UnhideCursor balances a previous HideCursor.
+func UnhideCursor() {
+	initSelectors()
+	initClasses()
+	classes.NSCursor.Send(selectors.cursorUnhide)
+}
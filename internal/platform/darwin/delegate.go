@@ -0,0 +1,231 @@
+//go:build darwin
+
+package darwin
+
+import "sync"
+
+// WindowEvent is a window state change reported by the window delegate.
+type WindowEvent uint8
+
+// Window events.
+const (
+	// WindowDidMiniaturize is sent when the window is minimized to the Dock.
+	WindowDidMiniaturize WindowEvent = iota + 1
+
+	// WindowDidDeminiaturize is sent when the window is restored from the Dock.
+	WindowDidDeminiaturize
+
+	// WindowDidZoom is sent when the window enters the zoomed state.
+	WindowDidZoom
+
+	// WindowDidUnzoom is sent when the window leaves the zoomed state.
+	WindowDidUnzoom
+
+	// WindowDidBecomeKey is sent when the window receives keyboard focus.
+	WindowDidBecomeKey
+
+	// WindowDidResignKey is sent when the window loses keyboard focus.
+	WindowDidResignKey
+
+	// WindowDidBecomeOccluded is sent when no part of the window is visible,
+	// e.g. it is covered by other windows or on another Space.
+	WindowDidBecomeOccluded
+
+	// WindowDidBecomeVisible is sent when part of the window becomes visible again.
+	WindowDidBecomeVisible
+
+	// WindowDidChangeScreen is sent when the window moves to another screen.
+	WindowDidChangeScreen
+
+	// WindowDidEnterFullScreen is sent when the fullscreen transition has finished.
+	WindowDidEnterFullScreen
+
+	// WindowDidExitFullScreen is sent when the window is back from fullscreen.
+	WindowDidExitFullScreen
+)
+
+// nsWindowOcclusionStateVisible is the NSWindowOcclusionStateVisible bit.
+const nsWindowOcclusionStateVisible = 1 << 1
+
+// windowDelegateClassName is the name of the runtime-registered delegate class.
+const windowDelegateClassName = "GoGPUWindowDelegate"
+
+// windowDelegate holds the shared NSWindowDelegate instance. One object
+// serves all windows: notifications carry the window they refer to.
+var windowDelegate struct {
+	once     sync.Once
+	delegate ID
+}
+
+// windowDelegateObject returns the shared GoGPUWindowDelegate instance,
+// creating it on first use. Returns 0 if the class could not be created.
+func windowDelegateObject() ID {
+	windowDelegate.once.Do(func() {
+		class := allocateClass(windowDelegateClassName, classes.NSObject,
+			[]string{"NSWindowDelegate"}, []classMethod{
+				// - (BOOL)windowShouldClose:(NSWindow *)sender
+				{selectors.windowShouldClose, windowDelegateShouldClose, "c@:@"},
+				// - (void)windowWillClose:(NSNotification *)notification
+				{selectors.windowWillClose, windowDelegateWillClose, "v@:@"},
+				// - (void)windowDidMiniaturize:(NSNotification *)notification
+				{selectors.windowDidMiniaturize, windowDelegateDidMiniaturize, "v@:@"},
+				// - (void)windowDidDeminiaturize:(NSNotification *)notification
+				{selectors.windowDidDeminiaturize, windowDelegateDidDeminiaturize, "v@:@"},
+				// - (void)windowDidResize:(NSNotification *)notification
+				{selectors.windowDidResize, windowDelegateDidResize, "v@:@"},
+				// - (void)windowDidBecomeKey:(NSNotification *)notification
+				{selectors.windowDidBecomeKey, windowDelegateDidBecomeKey, "v@:@"},
+				// - (void)windowDidResignKey:(NSNotification *)notification
+				{selectors.windowDidResignKey, windowDelegateDidResignKey, "v@:@"},
+				// - (void)windowDidChangeOcclusionState:(NSNotification *)notification
+				{selectors.windowDidChangeOcclusionState, windowDelegateDidChangeOcclusionState, "v@:@"},
+				// - (void)windowDidChangeScreen:(NSNotification *)notification
+				{selectors.windowDidChangeScreen, windowDelegateDidChangeScreen, "v@:@"},
+				// - (void)windowDidEnterFullScreen:(NSNotification *)notification
+				{selectors.windowDidEnterFullScreen, windowDelegateDidEnterFullScreen, "v@:@"},
+				// - (void)windowDidExitFullScreen:(NSNotification *)notification
+				{selectors.windowDidExitFullScreen, windowDelegateDidExitFullScreen, "v@:@"},
+			})
+		if class != 0 {
+			// Never released: NSWindow does not retain its delegate,
+			// so the object must outlive every window
+			windowDelegate.delegate = class.Send(selectors.new)
+		}
+	})
+	return windowDelegate.delegate
+}
+
+// windowDelegateShouldClose implements -windowShouldClose:.
+// The close button only marks the window; the owner decides when to
+// destroy it, after reading ShouldClose.
+func windowDelegateShouldClose(_, _, sender uintptr) uintptr {
+	if w := GetApplication().windowForNSWindow(ID(sender)); w != nil {
+		w.SetShouldClose(true)
+	}
+	return uintptr(NO)
+}
+
+// windowDelegateWillClose implements -windowWillClose:, which is sent
+// when the window is closed without asking the delegate first.
+func windowDelegateWillClose(_, _, notification uintptr) uintptr {
+	if w := GetApplication().windowForNSWindow(ID(notification).Send(selectors.object)); w != nil {
+		w.SetShouldClose(true)
+	}
+	return 0
+}
+
+// windowDelegateDidMiniaturize implements -windowDidMiniaturize:.
+func windowDelegateDidMiniaturize(_, _, notification uintptr) uintptr {
+	GetApplication().handleWindowEvent(ID(notification), WindowDidMiniaturize)
+	return 0
+}
+
+// windowDelegateDidDeminiaturize implements -windowDidDeminiaturize:.
+func windowDelegateDidDeminiaturize(_, _, notification uintptr) uintptr {
+	GetApplication().handleWindowEvent(ID(notification), WindowDidDeminiaturize)
+	return 0
+}
+
+// windowDelegateDidResize implements -windowDidResize:. AppKit has no
+// zoom notification, so the zoomed state is compared after every resize.
+func windowDelegateDidResize(_, _, notification uintptr) uintptr {
+	a := GetApplication()
+	window := ID(notification).Send(selectors.object)
+	w := a.windowForNSWindow(window)
+	if w == nil {
+		return 0
+	}
+
+	a.handleResize(w, ResizeUpdate)
+
+	if zoomed, changed := w.updateZoomed(window); changed {
+		event := WindowDidUnzoom
+		if zoomed {
+			event = WindowDidZoom
+		}
+		a.handleWindowEvent(ID(notification), event)
+	}
+	return 0
+}
+
+// windowDelegateDidBecomeKey implements -windowDidBecomeKey:.
+func windowDelegateDidBecomeKey(_, _, notification uintptr) uintptr {
+	a := GetApplication()
+	if w := a.windowForNSWindow(ID(notification).Send(selectors.object)); w != nil {
+		a.resumeMouseCapture(w)
+	}
+	a.handleWindowEvent(ID(notification), WindowDidBecomeKey)
+	return 0
+}
+
+// windowDelegateDidResignKey implements -windowDidResignKey:.
+func windowDelegateDidResignKey(_, _, notification uintptr) uintptr {
+	a := GetApplication()
+	if w := a.windowForNSWindow(ID(notification).Send(selectors.object)); w != nil {
+		a.suspendMouseCapture(w)
+	}
+	a.handleWindowEvent(ID(notification), WindowDidResignKey)
+	return 0
+}
+
+// windowDelegateDidChangeOcclusionState implements -windowDidChangeOcclusionState:.
+func windowDelegateDidChangeOcclusionState(_, _, notification uintptr) uintptr {
+	window := ID(notification).Send(selectors.object)
+
+	event := WindowDidBecomeOccluded
+	if uint64(window.Send(selectors.occlusionState))&nsWindowOcclusionStateVisible != 0 {
+		event = WindowDidBecomeVisible
+	}
+	GetApplication().handleWindowEvent(ID(notification), event)
+	return 0
+}
+
+// windowDelegateDidChangeScreen implements -windowDidChangeScreen:.
+func windowDelegateDidChangeScreen(_, _, notification uintptr) uintptr {
+	GetApplication().handleWindowEvent(ID(notification), WindowDidChangeScreen)
+	return 0
+}
+
+// windowDelegateDidEnterFullScreen implements -windowDidEnterFullScreen:.
+func windowDelegateDidEnterFullScreen(_, _, notification uintptr) uintptr {
+	GetApplication().handleWindowEvent(ID(notification), WindowDidEnterFullScreen)
+	return 0
+}
+
+// windowDelegateDidExitFullScreen implements -windowDidExitFullScreen:.
+func windowDelegateDidExitFullScreen(_, _, notification uintptr) uintptr {
+	GetApplication().handleWindowEvent(ID(notification), WindowDidExitFullScreen)
+	return 0
+}
+
+// SetWindowEventHandler sets a callback for window state changes such as
+// minimize, focus and occlusion. The handler runs on the main thread,
+// usually from within PollEvents/WaitEvents.
+func (a *Application) SetWindowEventHandler(handler func(*Window, WindowEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onWindowEvent = handler
+}
+
+// handleWindowEvent is called by the delegate with the NSNotification
+// whose object is the window.
+func (a *Application) handleWindowEvent(notification ID, event WindowEvent) {
+	a.mu.Lock()
+	onWindowEvent := a.onWindowEvent
+	a.mu.Unlock()
+
+	if onWindowEvent == nil {
+		return
+	}
+	if w := a.windowForNSWindow(notification.Send(selectors.object)); w != nil {
+		onWindowEvent(w, event)
+	}
+}
+
+// windowForNSWindow returns the registered window for an NSWindow.
+func (a *Application) windowForNSWindow(window ID) *Window {
+	if window.IsNil() {
+		return nil
+	}
+	return a.windowForNumber(int64(window.Send(selectors.windowNumber)))
+}
@@ -0,0 +1,160 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors returned by file dialogs.
+var (
+	ErrDialogCreationFailed = errors.New("darwin: failed to create file dialog")
+)
+
+// nsModalResponseOK is the NSModalResponseOK result of -runModal.
+const nsModalResponseOK = 1
+
+// OpenPanelOptions configures an NSOpenPanel.
+type OpenPanelOptions struct {
+	// Title is shown in the panel's title bar, if it has one.
+	Title string
+
+	// Message is shown above the file browser.
+	Message string
+
+	// Directory is the initial directory. Empty uses the last one.
+	Directory string
+
+	// Extensions restricts selectable files to these extensions,
+	// without the leading dot (e.g. "png"). Empty allows all files.
+	Extensions []string
+
+	// Multiple allows selecting more than one item.
+	Multiple bool
+
+	// Directories selects directories instead of files.
+	Directories bool
+}
+
+// SavePanelOptions configures an NSSavePanel.
+type SavePanelOptions struct {
+	// Title is shown in the panel's title bar, if it has one.
+	Title string
+
+	// Message is shown above the file browser.
+	Message string
+
+	// Directory is the initial directory. Empty uses the last one.
+	Directory string
+
+	// Filename is the initial name in the name field.
+	Filename string
+
+	// Extensions are the allowed extensions, without the leading dot.
+	// The first one is appended to names typed without an extension.
+	Extensions []string
+}
+
+// RunOpenPanel shows a modal open panel and returns the chosen paths,
+// or nil if the user cancelled. Events keep being processed by AppKit
+// while the panel is open; the handlers run as usual.
+func (a *Application) RunOpenPanel(opts OpenPanelOptions) ([]string, error) {
+	if !a.IsInitialized() {
+		return nil, ErrApplicationNotInitialized
+	}
+
+	panel := classes.NSOpenPanel.Send(selectors.openPanel)
+	if panel.IsNil() {
+		return nil, ErrDialogCreationFailed
+	}
+
+	configurePanel(panel, opts.Title, opts.Message, opts.Directory, opts.Extensions)
+	panel.SendBool(selectors.setCanChooseFiles, !opts.Directories)
+	panel.SendBool(selectors.setCanChooseDirectories, opts.Directories)
+	panel.SendBool(selectors.setCanCreateDirectories, opts.Directories)
+	panel.SendBool(selectors.setAllowsMultipleSelection, opts.Multiple)
+
+	if int64(panel.Send(selectors.runModal)) != nsModalResponseOK {
+		return nil, nil
+	}
+
+	urls := panel.Send(selectors.URLs)
+	count := int(urls.Send(selectors.count))
+	paths := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		url := urls.SendUint(selectors.objectAtIndex, uint64(i))
+		if path := goStringFromNSString(url.Send(selectors.path)); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// RunSavePanel shows a modal save panel and returns the chosen path,
+// or "" if the user cancelled. The panel asks before replacing an
+// existing file.
+func (a *Application) RunSavePanel(opts SavePanelOptions) (string, error) {
+	if !a.IsInitialized() {
+		return "", ErrApplicationNotInitialized
+	}
+
+	panel := classes.NSSavePanel.Send(selectors.savePanel)
+	if panel.IsNil() {
+		return "", ErrDialogCreationFailed
+	}
+
+	configurePanel(panel, opts.Title, opts.Message, opts.Directory, opts.Extensions)
+	panel.SendBool(selectors.setCanCreateDirectories, true)
+	if opts.Filename != "" {
+		if name := NewNSString(opts.Filename); name != nil {
+			panel.SendPtr(selectors.setNameFieldStringValue, name.ID().Ptr())
+			name.Release()
+		}
+	}
+
+	if int64(panel.Send(selectors.runModal)) != nsModalResponseOK {
+		return "", nil
+	}
+	return goStringFromNSString(panel.Send(selectors.URL).Send(selectors.path)), nil
+}
+
+// configurePanel applies the options shared by open and save panels.
+func configurePanel(panel ID, title, message, directory string, extensions []string) {
+	if title != "" {
+		if s := NewNSString(title); s != nil {
+			panel.SendPtr(selectors.setTitle, s.ID().Ptr())
+			s.Release()
+		}
+	}
+	if message != "" {
+		if s := NewNSString(message); s != nil {
+			panel.SendPtr(selectors.setMessage, s.ID().Ptr())
+			s.Release()
+		}
+	}
+
+	if directory != "" {
+		if s := NewNSString(directory); s != nil {
+			url := msgSend(ID(classes.NSURL), selectors.fileURLWithPath, s.ID().Ptr())
+			panel.SendPtr(selectors.setDirectoryURL, url.Ptr())
+			s.Release()
+		}
+	}
+
+	if len(extensions) > 0 {
+		types := classes.NSMutableArray.Send(selectors.new)
+		for _, ext := range extensions {
+			s := NewNSString(strings.TrimPrefix(ext, "."))
+			if s == nil {
+				continue
+			}
+			types.SendPtr(selectors.addObject, s.ID().Ptr())
+			s.Release() // Retained by the array
+		}
+		// Deprecated in favor of UTType content types on macOS 12,
+		// but still honored and available on every supported version
+		panel.SendPtr(selectors.setAllowedFileTypes, types.Ptr())
+		types.Send(selectors.release)
+	}
+}
@@ -0,0 +1,284 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// Errors returned by DisplayLink operations.
+var (
+	ErrDisplayLinkCreationFailed = errors.New("darwin: failed to create CVDisplayLink")
+)
+
+// cvTimeIsIndefinite is the kCVTimeIsIndefinite flag of CVTime.
+const cvTimeIsIndefinite = 1 << 0
+
+// cvTime mirrors the CoreVideo CVTime struct.
+type cvTime struct {
+	timeValue int64
+	timeScale int32
+	flags     int32
+}
+
+// coreVideo holds the lazily loaded CoreVideo symbols.
+var coreVideo struct {
+	once sync.Once
+	err  error
+
+	lib           unsafe.Pointer
+	createWithCG  unsafe.Pointer // CVDisplayLinkCreateWithCGDisplay
+	nominalPeriod unsafe.Pointer // CVDisplayLinkGetNominalOutputVideoRefreshPeriod
+	release       unsafe.Pointer // CVDisplayLinkRelease
+
+	cifCreate  *types.CallInterface
+	cifPeriod  *types.CallInterface
+	cifRelease *types.CallInterface
+}
+
+// initCoreVideo loads CoreVideo and prepares the call interfaces.
+func initCoreVideo() error {
+	coreVideo.once.Do(func() {
+		coreVideo.err = loadCoreVideo()
+	})
+	return coreVideo.err
+}
+
+// loadCoreVideo resolves the CVDisplayLink functions.
+func loadCoreVideo() error {
+	if err := initRuntime(); err != nil {
+		return err
+	}
+
+	var err error
+	coreVideo.lib, err = ffi.LoadLibrary(
+		"/System/Library/Frameworks/CoreVideo.framework/CoreVideo")
+	if err != nil {
+		return errors.Join(ErrLibraryNotLoaded, err)
+	}
+
+	symbols := []struct {
+		name string
+		ptr  *unsafe.Pointer
+	}{
+		{"CVDisplayLinkCreateWithCGDisplay", &coreVideo.createWithCG},
+		{"CVDisplayLinkGetNominalOutputVideoRefreshPeriod", &coreVideo.nominalPeriod},
+		{"CVDisplayLinkRelease", &coreVideo.release},
+	}
+	for _, sym := range symbols {
+		*sym.ptr, err = ffi.GetSymbol(coreVideo.lib, sym.name)
+		if err != nil {
+			return errors.Join(ErrSymbolNotFound, err)
+		}
+	}
+
+	// CVReturn CVDisplayLinkCreateWithCGDisplay(CGDirectDisplayID, CVDisplayLinkRef*)
+	coreVideo.cifCreate = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreVideo.cifCreate,
+		types.DefaultCall,
+		types.UInt32TypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor,  // displayID
+			types.PointerTypeDescriptor, // displayLinkOut
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// CVTime CVDisplayLinkGetNominalOutputVideoRefreshPeriod(CVDisplayLinkRef)
+	coreVideo.cifPeriod = &types.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		coreVideo.cifPeriod,
+		types.DefaultCall,
+		&types.TypeDescriptor{
+			Size:      16, // int64 + int32 + int32
+			Alignment: 8,
+			Kind:      types.StructType,
+			Members: []*types.TypeDescriptor{
+				types.UInt64TypeDescriptor,
+				types.UInt32TypeDescriptor,
+				types.UInt32TypeDescriptor,
+			},
+		},
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // displayLink
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// void CVDisplayLinkRelease(CVDisplayLinkRef)
+	coreVideo.cifRelease = &types.CallInterface{}
+	return ffi.PrepareCallInterface(
+		coreVideo.cifRelease,
+		types.DefaultCall,
+		types.PointerTypeDescriptor, // void, result ignored
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // displayLink
+		},
+	)
+}
+
+// DisplayLink wraps a CVDisplayLink bound to one display.
+// It is used to query the display refresh period for frame pacing.
+type DisplayLink struct {
+	link      uintptr // CVDisplayLinkRef
+	displayID uint32
+	period    time.Duration
+}
+
+// NewDisplayLink creates a display link for the given CGDirectDisplayID.
+func NewDisplayLink(displayID uint32) (*DisplayLink, error) {
+	if err := initCoreVideo(); err != nil {
+		return nil, err
+	}
+
+	var link uintptr
+	linkPtr := unsafe.Pointer(&link)
+	id := displayID
+	var status uint32
+
+	err := ffi.CallFunction(
+		coreVideo.cifCreate,
+		coreVideo.createWithCG,
+		unsafe.Pointer(&status),
+		[]unsafe.Pointer{
+			unsafe.Pointer(&id),
+			unsafe.Pointer(&linkPtr),
+		},
+	)
+	if err != nil {
+		return nil, errors.Join(ErrDisplayLinkCreationFailed, err)
+	}
+	if status != 0 || link == 0 {
+		return nil, ErrDisplayLinkCreationFailed
+	}
+
+	d := &DisplayLink{
+		link:      link,
+		displayID: displayID,
+	}
+	d.period = d.nominalPeriod()
+	return d, nil
+}
+
+// nominalPeriod queries the nominal refresh period of the display.
+// Returns 0 if the display does not report one (e.g. some external monitors).
+func (d *DisplayLink) nominalPeriod() time.Duration {
+	var t cvTime
+	link := d.link
+
+	err := ffi.CallFunction(
+		coreVideo.cifPeriod,
+		coreVideo.nominalPeriod,
+		unsafe.Pointer(&t),
+		[]unsafe.Pointer{unsafe.Pointer(&link)},
+	)
+	if err != nil || t.flags&cvTimeIsIndefinite != 0 || t.timeScale <= 0 || t.timeValue <= 0 {
+		return 0
+	}
+
+	return time.Duration(t.timeValue * int64(time.Second) / int64(t.timeScale))
+}
+
+// DisplayID returns the CGDirectDisplayID the link is bound to.
+func (d *DisplayLink) DisplayID() uint32 {
+	if d == nil {
+		return 0
+	}
+	return d.displayID
+}
+
+// RefreshPeriod returns the time between display refreshes,
+// or 0 if the display does not report a refresh rate.
+func (d *DisplayLink) RefreshPeriod() time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.period
+}
+
+// Release releases the CVDisplayLink.
+func (d *DisplayLink) Release() {
+	if d == nil || d.link == 0 {
+		return
+	}
+
+	link := d.link
+	var result uintptr
+	_ = ffi.CallFunction(
+		coreVideo.cifRelease,
+		coreVideo.release,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{unsafe.Pointer(&link)},
+	)
+	d.link = 0
+}
+
+// defaultRefreshPeriod is used when the display does not report a refresh rate.
+const defaultRefreshPeriod = time.Second / 60
+
+// FramePacer schedules frames at the refresh rate of the display a window is on.
+//
+// CVDisplayLink normally drives rendering through an output callback on a
+// CoreVideo thread. The pacer instead uses the link only as the source of the
+// refresh period and sleeps on the main thread, which keeps all AppKit calls
+// on the main thread. When the window moves to another display the link is
+// recreated, so pacing adapts to the new refresh rate.
+type FramePacer struct {
+	link *DisplayLink
+	next time.Time
+}
+
+// Wait blocks until the next frame is due on the window's current display.
+func (p *FramePacer) Wait(window *Window) {
+	period := p.refreshPeriod(window)
+
+	now := time.Now()
+	if p.next.IsZero() || now.Sub(p.next) > period {
+		// First frame, or we fell more than a frame behind:
+		// resynchronize instead of rendering a burst of catch-up frames.
+		p.next = now.Add(period)
+		return
+	}
+
+	if wait := p.next.Sub(now); wait > 0 {
+		time.Sleep(wait)
+	}
+	p.next = p.next.Add(period)
+}
+
+// refreshPeriod returns the refresh period, recreating the display link
+// if the window moved to a different display.
+func (p *FramePacer) refreshPeriod(window *Window) time.Duration {
+	displayID := window.DisplayID()
+	if displayID != 0 && (p.link == nil || p.link.DisplayID() != displayID) {
+		p.link.Release()
+		p.link = nil
+
+		if link, err := NewDisplayLink(displayID); err == nil {
+			p.link = link
+		}
+	}
+
+	if period := p.link.RefreshPeriod(); period > 0 {
+		return period
+	}
+	return defaultRefreshPeriod
+}
+
+// Release releases the underlying display link.
+func (p *FramePacer) Release() {
+	p.link.Release()
+	p.link = nil
+	p.next = time.Time{}
+}
@@ -40,7 +40,8 @@
 // Cocoa uses reference counting for memory management:
 //   - Objects from alloc/init/copy must be released
 //   - Objects from other methods are autoreleased
-//   - Use NSAutoreleasePool for temporary objects
+//   - Use NewAutoreleasePool for temporary objects; pools nest per thread
+//     and are drained innermost first
 //
 // # Metal Integration
 //
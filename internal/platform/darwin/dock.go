@@ -0,0 +1,101 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"image"
+)
+
+// Errors returned by the Dock functions.
+var (
+	ErrImageCreationFailed = errors.New("darwin: failed to create image")
+	ErrDockBadgeFailed     = errors.New("darwin: failed to set dock badge")
+)
+
+// NSRequestUserAttentionType values for -requestUserAttention:.
+const (
+	nsCriticalRequest      = 0
+	nsInformationalRequest = 10
+)
+
+// SetDockIcon replaces the application icon in the Dock, and in the
+// application switcher, while the app runs. A nil image restores the
+// icon of the application bundle.
+func (a *Application) SetDockIcon(img *image.RGBA) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized {
+		return ErrApplicationNotInitialized
+	}
+
+	if img == nil {
+		a.nsApp.SendPtr(selectors.setApplicationIconImage, 0)
+		return nil
+	}
+
+	nsImage := newImageFromRGBA(img)
+	if nsImage.IsNil() {
+		return ErrImageCreationFailed
+	}
+	a.nsApp.SendPtr(selectors.setApplicationIconImage, nsImage.Ptr())
+	nsImage.Send(selectors.release) // Retained by NSApp
+	return nil
+}
+
+// SetDockBadge shows a short label, such as an unread count, on the Dock
+// icon. An empty label removes the badge.
+func (a *Application) SetDockBadge(label string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized {
+		return ErrApplicationNotInitialized
+	}
+
+	tile := a.nsApp.Send(selectors.dockTile)
+	if label == "" {
+		tile.SendPtr(selectors.setBadgeLabel, 0)
+		return nil
+	}
+
+	str := NewNSString(label)
+	if str == nil {
+		return ErrDockBadgeFailed
+	}
+	defer str.Release() // Copied by the dock tile
+	tile.SendPtr(selectors.setBadgeLabel, str.ID().Ptr())
+	return nil
+}
+
+// RequestUserAttention bounces the Dock icon while the application is
+// inactive: once, or until the app is activated if critical is set.
+// It returns the request identifier for CancelUserAttentionRequest, or 0
+// if the application is already active and nothing happened.
+func (a *Application) RequestUserAttention(critical bool) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized {
+		return 0
+	}
+
+	kind := int64(nsInformationalRequest)
+	if critical {
+		kind = nsCriticalRequest
+	}
+	return int(a.nsApp.SendInt(selectors.requestUserAttention, kind))
+}
+
+// CancelUserAttentionRequest stops a request made by RequestUserAttention.
+// Activating the application cancels pending requests as well.
+func (a *Application) CancelUserAttentionRequest(request int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized || request == 0 {
+		return
+	}
+	a.nsApp.SendInt(selectors.cancelUserAttentionRequest, int64(request))
+}
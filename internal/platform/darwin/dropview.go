@@ -0,0 +1,131 @@
+//go:build darwin
+
+package darwin
+
+// DropPhase describes the stage of a drag-and-drop session.
+type DropPhase uint8
+
+// Drop phases.
+const (
+	// DropEnter is sent when a drag enters the window.
+	DropEnter DropPhase = iota + 1
+
+	// DropOver is sent while the drag moves over the window.
+	DropOver
+
+	// DropLeave is sent when the drag leaves the window or is cancelled.
+	DropLeave
+
+	// DropPerform is sent when the files are dropped on the window.
+	DropPerform
+)
+
+// DropEvent is a drag-and-drop notification for a window's content view.
+type DropEvent struct {
+	Phase DropPhase
+
+	// Paths holds the dropped file paths (DropPerform only).
+	Paths []string
+
+	// Location is in window coordinates (points, bottom-left origin).
+	Location NSPoint
+}
+
+// NSDragOperation values returned from dragging destination methods.
+const (
+	nsDragOperationNone NSUInteger = 0
+	nsDragOperationCopy NSUInteger = 1
+)
+
+// pasteboardTypeFileURL is the UTI of file URLs (NSPasteboardTypeFileURL).
+const pasteboardTypeFileURL = "public.file-url"
+
+// dropMethods returns the NSDraggingDestination methods of the content view.
+func dropMethods() []classMethod {
+	return []classMethod{
+		// - (NSDragOperation)draggingEntered:(id<NSDraggingInfo>)sender
+		{selectors.draggingEntered, dropViewDraggingEntered, "Q@:@"},
+		// - (NSDragOperation)draggingUpdated:(id<NSDraggingInfo>)sender
+		{selectors.draggingUpdated, dropViewDraggingUpdated, "Q@:@"},
+		// - (void)draggingExited:(id<NSDraggingInfo>)sender
+		{selectors.draggingExited, dropViewDraggingExited, "v@:@"},
+		// - (BOOL)prepareForDragOperation:(id<NSDraggingInfo>)sender
+		{selectors.prepareForDragOperation, dropViewPrepareForDragOperation, "c@:@"},
+		// - (BOOL)performDragOperation:(id<NSDraggingInfo>)sender
+		{selectors.performDragOperation, dropViewPerformDragOperation, "c@:@"},
+	}
+}
+
+// registerForFileDrops registers a view as a destination for file URLs.
+func registerForFileDrops(view ID) {
+	uti := NewNSString(pasteboardTypeFileURL)
+	if uti == nil {
+		return
+	}
+	defer uti.Release()
+
+	array := msgSend(ID(classes.NSArray), selectors.arrayWithObject, uti.ID().Ptr())
+	view.SendPtr(selectors.registerForDraggedTypes, array.Ptr())
+}
+
+// dropViewDraggingEntered implements -draggingEntered:.
+func dropViewDraggingEntered(self, _, sender uintptr) uintptr {
+	if !GetApplication().handleDrop(ID(self), ID(sender), DropEnter) {
+		return uintptr(nsDragOperationNone)
+	}
+	return uintptr(nsDragOperationCopy)
+}
+
+// dropViewDraggingUpdated implements -draggingUpdated:.
+func dropViewDraggingUpdated(self, _, sender uintptr) uintptr {
+	if !GetApplication().handleDrop(ID(self), ID(sender), DropOver) {
+		return uintptr(nsDragOperationNone)
+	}
+	return uintptr(nsDragOperationCopy)
+}
+
+// dropViewDraggingExited implements -draggingExited:.
+func dropViewDraggingExited(self, _, sender uintptr) uintptr {
+	GetApplication().handleDrop(ID(self), ID(sender), DropLeave)
+	return 0
+}
+
+// dropViewPrepareForDragOperation implements -prepareForDragOperation:.
+func dropViewPrepareForDragOperation(_, _, _ uintptr) uintptr {
+	return uintptr(YES)
+}
+
+// dropViewPerformDragOperation implements -performDragOperation:.
+func dropViewPerformDragOperation(self, _, sender uintptr) uintptr {
+	if !GetApplication().handleDrop(ID(self), ID(sender), DropPerform) {
+		return uintptr(NO)
+	}
+	return uintptr(YES)
+}
+
+// draggedFilePaths reads the file paths from an NSDraggingInfo pasteboard.
+func draggedFilePaths(sender ID) []string {
+	pasteboard := sender.Send(selectors.draggingPasteboard)
+	if pasteboard.IsNil() {
+		return nil
+	}
+
+	urlClasses := msgSend(ID(classes.NSArray), selectors.arrayWithObject, classes.NSURL.ClassPtr())
+	urls := msgSend(pasteboard, selectors.readObjectsForClassesOpt, urlClasses.Ptr(), 0)
+	if urls.IsNil() {
+		return nil
+	}
+
+	count := int(urls.Send(selectors.count))
+	paths := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		url := urls.SendUint(selectors.objectAtIndex, uint64(i))
+		if url.IsNil() || !isYes(url.Send(selectors.isFileURL)) {
+			continue
+		}
+		if path := goStringFromNSString(url.Send(selectors.path)); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
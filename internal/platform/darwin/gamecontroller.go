@@ -0,0 +1,201 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// GameController is a snapshot of a connected controller with the
+// extended gamepad profile, which Xbox, PlayStation and MFi gamepads
+// provide. The GameController framework applies dead zones. Stick y axes
+// point up.
+type GameController struct {
+	ID   ID // The GCController, the same object while connected
+	Name string
+
+	A, B, X, Y                  bool
+	LeftShoulder, RightShoulder bool
+	Options, Menu, Home         bool // Back, Start and Guide
+	LeftThumb, RightThumb       bool
+	Up, Down, Left, Right       bool
+
+	LeftX, LeftY, RightX, RightY float32 // -1 to 1
+	LeftTrigger, RightTrigger    float32 // 0 to 1
+}
+
+// gameController holds the lazily loaded GameController framework and
+// the selectors of its classes.
+var gameController struct {
+	once sync.Once
+	err  error
+
+	GCController Class
+
+	controllers        SEL
+	extendedGamepad    SEL
+	vendorName         SEL
+	respondsToSelector SEL
+	buttonA            SEL
+	buttonB            SEL
+	buttonX            SEL
+	buttonY            SEL
+	leftShoulder       SEL
+	rightShoulder      SEL
+	buttonOptions      SEL // macOS 10.15
+	buttonMenu         SEL // macOS 10.15
+	buttonHome         SEL // macOS 11
+	leftThumbstickBtn  SEL // macOS 10.14.1
+	rightThumbstickBtn SEL // macOS 10.14.1
+	dpad               SEL
+	up                 SEL
+	down               SEL
+	left               SEL
+	right              SEL
+	leftThumbstick     SEL
+	rightThumbstick    SEL
+	xAxis              SEL
+	yAxis              SEL
+	leftTrigger        SEL
+	rightTrigger       SEL
+	isPressed          SEL
+	value              SEL
+}
+
+// initGameController loads the GameController framework.
+func initGameController() error {
+	gameController.once.Do(func() {
+		gameController.err = loadGameController()
+	})
+	return gameController.err
+}
+
+// loadGameController loads the framework and registers its selectors.
+func loadGameController() error {
+	if err := initRuntime(); err != nil {
+		return err
+	}
+	initSelectors()
+
+	if _, err := ffi.LoadLibrary(
+		"/System/Library/Frameworks/GameController.framework/GameController"); err != nil {
+		return errors.Join(ErrLibraryNotLoaded, err)
+	}
+	gameController.GCController = GetClass("GCController")
+	if gameController.GCController == 0 {
+		return ErrClassNotFound
+	}
+
+	gc := &gameController
+	gc.controllers = RegisterSelector("controllers")
+	gc.extendedGamepad = RegisterSelector("extendedGamepad")
+	gc.vendorName = RegisterSelector("vendorName")
+	gc.respondsToSelector = RegisterSelector("respondsToSelector:")
+	gc.buttonA = RegisterSelector("buttonA")
+	gc.buttonB = RegisterSelector("buttonB")
+	gc.buttonX = RegisterSelector("buttonX")
+	gc.buttonY = RegisterSelector("buttonY")
+	gc.leftShoulder = RegisterSelector("leftShoulder")
+	gc.rightShoulder = RegisterSelector("rightShoulder")
+	gc.buttonOptions = RegisterSelector("buttonOptions")
+	gc.buttonMenu = RegisterSelector("buttonMenu")
+	gc.buttonHome = RegisterSelector("buttonHome")
+	gc.leftThumbstickBtn = RegisterSelector("leftThumbstickButton")
+	gc.rightThumbstickBtn = RegisterSelector("rightThumbstickButton")
+	gc.dpad = RegisterSelector("dpad")
+	gc.up = RegisterSelector("up")
+	gc.down = RegisterSelector("down")
+	gc.left = RegisterSelector("left")
+	gc.right = RegisterSelector("right")
+	gc.leftThumbstick = RegisterSelector("leftThumbstick")
+	gc.rightThumbstick = RegisterSelector("rightThumbstick")
+	gc.xAxis = RegisterSelector("xAxis")
+	gc.yAxis = RegisterSelector("yAxis")
+	gc.leftTrigger = RegisterSelector("leftTrigger")
+	gc.rightTrigger = RegisterSelector("rightTrigger")
+	gc.isPressed = RegisterSelector("isPressed")
+	gc.value = RegisterSelector("value")
+	return nil
+}
+
+// GameControllers returns the connected controllers with the extended
+// gamepad profile, in the order GameController lists them. Controllers
+// are discovered by the application's run loop, so the list fills in
+// after the first events were processed.
+func GameControllers() ([]GameController, error) {
+	if err := initGameController(); err != nil {
+		return nil, err
+	}
+
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	gc := &gameController
+	list := ID(gc.GCController).Send(gc.controllers)
+	count := int(list.Send(selectors.count))
+
+	var controllers []GameController
+	for i := range count {
+		controller := list.SendUint(selectors.objectAtIndex, uint64(i))
+		pad := controller.Send(gc.extendedGamepad)
+		if pad.IsNil() {
+			continue
+		}
+
+		c := GameController{
+			ID:            controller,
+			Name:          goStringFromNSString(controller.Send(gc.vendorName)),
+			A:             pressed(pad.Send(gc.buttonA)),
+			B:             pressed(pad.Send(gc.buttonB)),
+			X:             pressed(pad.Send(gc.buttonX)),
+			Y:             pressed(pad.Send(gc.buttonY)),
+			LeftShoulder:  pressed(pad.Send(gc.leftShoulder)),
+			RightShoulder: pressed(pad.Send(gc.rightShoulder)),
+			Options:       pressed(optionalElement(pad, gc.buttonOptions)),
+			Menu:          pressed(optionalElement(pad, gc.buttonMenu)),
+			Home:          pressed(optionalElement(pad, gc.buttonHome)),
+			LeftThumb:     pressed(optionalElement(pad, gc.leftThumbstickBtn)),
+			RightThumb:    pressed(optionalElement(pad, gc.rightThumbstickBtn)),
+			LeftTrigger:   pad.Send(gc.leftTrigger).GetFloat(gc.value),
+			RightTrigger:  pad.Send(gc.rightTrigger).GetFloat(gc.value),
+		}
+
+		dpad := pad.Send(gc.dpad)
+		c.Up = pressed(dpad.Send(gc.up))
+		c.Down = pressed(dpad.Send(gc.down))
+		c.Left = pressed(dpad.Send(gc.left))
+		c.Right = pressed(dpad.Send(gc.right))
+
+		left := pad.Send(gc.leftThumbstick)
+		c.LeftX = left.Send(gc.xAxis).GetFloat(gc.value)
+		c.LeftY = left.Send(gc.yAxis).GetFloat(gc.value)
+		right := pad.Send(gc.rightThumbstick)
+		c.RightX = right.Send(gc.xAxis).GetFloat(gc.value)
+		c.RightY = right.Send(gc.yAxis).GetFloat(gc.value)
+
+		controllers = append(controllers, c)
+	}
+	return controllers, nil
+}
+
+// optionalElement returns an element of the extended gamepad profile
+// added in a later macOS version, or nil where it does not exist.
+// Sending an unknown selector would raise an exception.
+func optionalElement(pad ID, sel SEL) ID {
+	if pad.SendPtr(gameController.respondsToSelector, uintptr(sel))&0xff == 0 {
+		return 0
+	}
+	return pad.Send(sel)
+}
+
+// pressed reports whether a GCControllerButtonInput is pressed. A nil
+// button, which controllers without it return, is not.
+func pressed(button ID) bool {
+	if button.IsNil() {
+		return false
+	}
+	return button.Send(gameController.isPressed)&0xff != 0
+}
@@ -0,0 +1,73 @@
+//go:build darwin
+
+package darwin
+
+// GestureKind identifies a trackpad gesture.
+type GestureKind uint8
+
+// Gesture kinds.
+const (
+	// GestureMagnify is a two-finger pinch.
+	GestureMagnify GestureKind = iota + 1
+
+	// GestureRotate is a two-finger rotation.
+	GestureRotate
+
+	// GestureSwipe is a three-finger swipe (when enabled in System Settings).
+	GestureSwipe
+
+	// GestureSmartMagnify is a two-finger double tap.
+	GestureSmartMagnify
+)
+
+// GestureEvent is a trackpad gesture decoded from an NSEvent.
+type GestureEvent struct {
+	Kind GestureKind
+
+	// Phase is NSEventPhaseNone for discrete gestures (swipe, smart magnify).
+	Phase NSEventPhase
+
+	// Location is in window coordinates (points, bottom-left origin).
+	Location NSPoint
+
+	// Magnification is the scale delta since the previous event (GestureMagnify).
+	Magnification float64
+
+	// Rotation is the angle delta in degrees, counter-clockwise positive (GestureRotate).
+	Rotation float64
+
+	// DeltaX and DeltaY are the swipe direction, -1, 0 or 1 (GestureSwipe).
+	DeltaX float64
+	DeltaY float64
+}
+
+// gestureFromEvent decodes a gesture NSEvent.
+// Returns false if the event is not a gesture.
+func gestureFromEvent(event ID) (GestureEvent, bool) {
+	var g GestureEvent
+
+	switch NSEventType(event.Send(selectors.eventType)) {
+	case NSEventTypeMagnify:
+		g.Kind = GestureMagnify
+		g.Magnification = event.GetDouble(selectors.magnification)
+	case NSEventTypeRotate:
+		g.Kind = GestureRotate
+		g.Rotation = float64(event.GetFloat(selectors.rotation))
+	case NSEventTypeSwipe:
+		g.Kind = GestureSwipe
+		g.DeltaX = event.GetDouble(selectors.deltaX)
+		g.DeltaY = event.GetDouble(selectors.deltaY)
+	case NSEventTypeSmartMagnify:
+		g.Kind = GestureSmartMagnify
+	default:
+		return GestureEvent{}, false
+	}
+
+	// Swipe and smart magnify are discrete; only pinch and rotate carry phases.
+	if g.Kind == GestureMagnify || g.Kind == GestureRotate {
+		g.Phase = NSEventPhase(event.Send(selectors.phase))
+	}
+	g.Location = event.GetPoint(selectors.locationInWindow)
+
+	return g, true
+}
@@ -23,7 +23,8 @@ import "runtime"
 // - go-gl/glfw: https://github.com/go-gl/glfw
 //
 // Note: This lock is permanent for the lifetime of the program.
-// User callbacks (OnDraw, OnUpdate) will also execute on the main thread.
+// User callbacks (OnDraw, OnUpdate) will also execute on the main thread,
+// unless the app renders on a separate thread (gogpu Config.RenderThread).
 // Long-running operations should be offloaded to separate goroutines.
 func init() {
 	// Pin main goroutine to main OS thread for Cocoa compatibility.
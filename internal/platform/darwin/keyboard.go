@@ -0,0 +1,93 @@
+//go:build darwin
+
+package darwin
+
+// NSEventModifierFlagCapsLock is set while Caps Lock is on.
+const NSEventModifierFlagCapsLock NSEventModifierFlags = 1 << 16
+
+// KeyEvent is a key press or release.
+//
+// KeyCode identifies the physical key (a kVK_* virtual key code) and does
+// not depend on the keyboard layout, so it suits positional bindings such
+// as WASD. Characters is what the key types in the current layout with
+// no modifiers except Shift, so the Q key reports "a" on AZERTY.
+type KeyEvent struct {
+	KeyCode    uint16
+	Characters string
+	Down       bool
+	Repeat     bool
+	Modifiers  NSEventModifierFlags
+}
+
+// Virtual key codes of the modifier keys, reported by NSEventTypeFlagsChanged.
+const (
+	kVKCommand      = 0x37
+	kVKShift        = 0x38
+	kVKCapsLock     = 0x39
+	kVKOption       = 0x3A
+	kVKControl      = 0x3B
+	kVKRightCommand = 0x36
+	kVKRightShift   = 0x3C
+	kVKRightOption  = 0x3D
+	kVKRightControl = 0x3E
+)
+
+// modifierFlagForKey returns the modifier flag a modifier key sets, or 0.
+func modifierFlagForKey(keyCode uint16) NSEventModifierFlags {
+	switch keyCode {
+	case kVKShift, kVKRightShift:
+		return NSEventModifierFlagShift
+	case kVKControl, kVKRightControl:
+		return NSEventModifierFlagControl
+	case kVKOption, kVKRightOption:
+		return NSEventModifierFlagOption
+	case kVKCommand, kVKRightCommand:
+		return NSEventModifierFlagCommand
+	case kVKCapsLock:
+		return NSEventModifierFlagCapsLock
+	}
+	return 0
+}
+
+// keyFromEvent builds a KeyEvent from a key down, key up or flags changed
+// event. Modifier keys only produce flags changed events; whether one went
+// down is derived from its flag, which stays set while either the left or
+// the right key is held.
+func keyFromEvent(event ID) (KeyEvent, bool) {
+	eventType := NSEventType(event.Send(selectors.eventType))
+	switch eventType {
+	case NSEventTypeKeyDown, NSEventTypeKeyUp, NSEventTypeFlagsChanged:
+	default:
+		return KeyEvent{}, false
+	}
+
+	k := KeyEvent{
+		KeyCode:   uint16(event.Send(selectors.keyCode)),
+		Modifiers: NSEventModifierFlags(event.Send(selectors.modifierFlags)),
+	}
+
+	if eventType == NSEventTypeFlagsChanged {
+		flag := modifierFlagForKey(k.KeyCode)
+		if flag == 0 {
+			return KeyEvent{}, false
+		}
+		k.Down = k.Modifiers&flag != 0
+		return k, true
+	}
+
+	// Only valid for key events: AppKit raises for other event types
+	k.Characters = goStringFromNSString(event.Send(selectors.charactersIgnoringModifiers))
+	k.Down = eventType == NSEventTypeKeyDown
+	k.Repeat = k.Down && event.Send(selectors.isARepeat) != 0
+	return k, true
+}
+
+// SetKeyHandler sets a callback for key presses and releases in any
+// window. Key events are still delivered to the window afterwards, so
+// text input keeps working. The handler runs on the main thread, usually
+// from within PollEvents/WaitEvents.
+func (a *Application) SetKeyHandler(handler func(*Window, KeyEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onKey = handler
+}
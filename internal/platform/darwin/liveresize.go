@@ -0,0 +1,86 @@
+//go:build darwin
+
+package darwin
+
+// ResizePhase describes where a window size change happens in
+// an interactive (live) resize.
+type ResizePhase uint8
+
+// Resize phases.
+const (
+	// ResizeStart is sent when the user starts dragging a window edge.
+	ResizeStart ResizePhase = iota + 1
+
+	// ResizeUpdate is sent for every size change, during a live resize
+	// as well as for programmatic resizes and zoom.
+	ResizeUpdate
+
+	// ResizeEnd is sent when the user releases the window edge.
+	ResizeEnd
+)
+
+// liveResizeMethods returns the NSView live resize overrides of the content view.
+//
+// While the user drags a window edge, AppKit runs its own event tracking
+// loop inside -[NSApplication sendEvent:], so PollEvents does not return
+// until the mouse is released. These notifications, together with the
+// delegate's windowDidResize:, let the owner resize the drawable and render
+// synchronously from inside that loop.
+func liveResizeMethods() []classMethod {
+	return []classMethod{
+		// - (void)viewWillStartLiveResize
+		{selectors.viewWillStartLiveResize, contentViewWillStartLiveResize, "v@:"},
+		// - (void)viewDidEndLiveResize
+		{selectors.viewDidEndLiveResize, contentViewDidEndLiveResize, "v@:"},
+	}
+}
+
+// contentViewWillStartLiveResize implements -viewWillStartLiveResize.
+func contentViewWillStartLiveResize(self, _ uintptr) uintptr {
+	sendSuper(ID(self), classes.NSView, selectors.viewWillStartLiveResize)
+
+	a := GetApplication()
+	if w := a.windowForView(ID(self)); w != nil {
+		w.liveResize.Store(true)
+		a.handleResize(w, ResizeStart)
+	}
+	return 0
+}
+
+// contentViewDidEndLiveResize implements -viewDidEndLiveResize.
+func contentViewDidEndLiveResize(self, _ uintptr) uintptr {
+	sendSuper(ID(self), classes.NSView, selectors.viewDidEndLiveResize)
+
+	a := GetApplication()
+	if w := a.windowForView(ID(self)); w != nil {
+		w.liveResize.Store(false)
+		a.handleResize(w, ResizeEnd)
+	}
+	return 0
+}
+
+// InLiveResize reports whether the user is currently resizing the window.
+func (w *Window) InLiveResize() bool {
+	return w.liveResize.Load()
+}
+
+// SetResizeHandler sets a callback for window size changes. It is called
+// synchronously, also from inside AppKit's live resize tracking loop,
+// so it can resize the drawable and draw a frame at the new size.
+// It runs on the main thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetResizeHandler(handler func(*Window, ResizePhase)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onResize = handler
+}
+
+// handleResize calls the resize handler.
+func (a *Application) handleResize(w *Window, phase ResizePhase) {
+	a.mu.Lock()
+	onResize := a.onResize
+	a.mu.Unlock()
+
+	if onResize != nil {
+		onResize(w, phase)
+	}
+}
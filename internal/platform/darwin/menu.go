@@ -0,0 +1,272 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"sync"
+)
+
+// Errors returned by menu operations.
+var (
+	ErrMenuCreationFailed = errors.New("darwin: menu creation failed")
+)
+
+// MenuItem describes an item of a custom menu.
+type MenuItem struct {
+	// Title is the item label. Ignored for separators.
+	Title string
+
+	// Key is the key equivalent pressed together with Command,
+	// e.g. "s" for Cmd+S. An uppercase letter adds Shift. Empty for none.
+	Key string
+
+	// Modifiers are added to Command for the key equivalent, e.g.
+	// NSEventModifierFlagOption for Cmd+Opt+Key.
+	Modifiers NSEventModifierFlags
+
+	// Tag is passed to the menu handler when the item is selected.
+	Tag int
+
+	// Separator makes this item a separator line.
+	Separator bool
+}
+
+// menuTargetClassName is the name of the runtime-registered menu action target.
+const menuTargetClassName = "GoGPUMenuTarget"
+
+// menuTarget holds the shared target object for Go-handled menu items.
+var menuTarget struct {
+	once   sync.Once
+	target ID
+}
+
+// menuTargetObject returns the shared GoGPUMenuTarget instance,
+// creating it on first use. Returns 0 if the class could not be created.
+func menuTargetObject() ID {
+	menuTarget.once.Do(func() {
+		class := allocateClass(menuTargetClassName, classes.NSObject, nil, []classMethod{
+			// - (void)menuItemSelected:(NSMenuItem *)sender
+			{selectors.menuItemSelected, menuTargetItemSelected, "v@:@"},
+			// - (void)terminateRequested:(NSMenuItem *)sender
+			{selectors.terminateRequested, menuTargetTerminateRequested, "v@:@"},
+		})
+		if class != 0 {
+			// Never released: the target lives as long as the menu bar
+			menuTarget.target = class.Send(selectors.new)
+		}
+	})
+	return menuTarget.target
+}
+
+// menuTargetItemSelected implements -menuItemSelected:.
+func menuTargetItemSelected(_, _, sender uintptr) uintptr {
+	tag := int(int64(ID(sender).Send(selectors.tag)))
+	GetApplication().handleMenu(tag)
+	return 0
+}
+
+// menuTargetTerminateRequested implements -terminateRequested:.
+// Unlike -[NSApplication terminate:], it does not exit the process, so the
+// Go run loop can shut down cleanly.
+func menuTargetTerminateRequested(_, _, _ uintptr) uintptr {
+	GetApplication().Terminate()
+	return 0
+}
+
+// newMenu creates an NSMenu with the given title. The caller owns it.
+func newMenu(title string) ID {
+	nsTitle := NewNSString(title)
+	if nsTitle == nil {
+		return 0
+	}
+	defer nsTitle.Release()
+
+	return classes.NSMenu.Send(selectors.alloc).SendPtr(selectors.initWithTitle, nsTitle.ID().Ptr())
+}
+
+// addMenuItem appends an item to a menu. A zero target sends the action
+// along the responder chain. key uses the MenuItem.Key conventions.
+func addMenuItem(menu ID, title string, action SEL, key string, modifiers NSEventModifierFlags, target ID) ID {
+	nsTitle := NewNSString(title)
+	nsKey := NewNSString(key)
+	if nsTitle == nil || nsKey == nil {
+		nsTitle.Release()
+		nsKey.Release()
+		return 0
+	}
+	defer nsTitle.Release()
+	defer nsKey.Release()
+
+	item := msgSend(classes.NSMenuItem.Send(selectors.alloc), selectors.initWithTitleActionKeyEquivalent,
+		nsTitle.ID().Ptr(), action.SELPtr(), nsKey.ID().Ptr())
+	if item.IsNil() {
+		return 0
+	}
+
+	if modifiers != 0 {
+		item.SendUint(selectors.setKeyEquivalentModifierMask, uint64(NSEventModifierFlagCommand|modifiers))
+	}
+	if !target.IsNil() {
+		item.SendPtr(selectors.setTarget, target.Ptr())
+	}
+
+	menu.SendPtr(selectors.addItem, item.Ptr())
+	item.Send(selectors.release) // Retained by the menu
+	return item
+}
+
+// addSeparator appends a separator line to a menu.
+func addSeparator(menu ID) {
+	menu.SendPtr(selectors.addItem, classes.NSMenuItem.Send(selectors.separatorItem).Ptr())
+}
+
+// attachSubmenu wraps submenu in a top-level item of the menu bar.
+// If index is negative the item is appended, otherwise inserted at index.
+func attachSubmenu(menuBar, submenu ID, index int) {
+	item := classes.NSMenuItem.Send(selectors.alloc).Send(selectors.init)
+	if item.IsNil() {
+		return
+	}
+	item.SendPtr(selectors.setSubmenu, submenu.Ptr())
+
+	if index < 0 {
+		menuBar.SendPtr(selectors.addItem, item.Ptr())
+	} else {
+		msgSend(menuBar, selectors.insertItemAtIndex, item.Ptr(), uintptr(index))
+	}
+	item.Send(selectors.release) // Retained by the menu bar
+}
+
+// processName returns the name of the running process, used for
+// the application menu like AppKit does for bundled apps.
+func processName() string {
+	info := classes.NSProcessInfo.Send(selectors.processInfo)
+	return goStringFromNSString(info.Send(selectors.processName))
+}
+
+// SetupMainMenu installs the standard menu bar: an application menu with
+// About, Hide, Hide Others, Show All and Quit, an Edit menu so text
+// shortcuts (Cmd+C/V/X/Z/A) reach the responder chain, and a Window menu.
+// Quit calls Terminate instead of exiting the process.
+func (a *Application) SetupMainMenu() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.initialized {
+		return ErrApplicationNotInitialized
+	}
+	if !a.menuBar.IsNil() {
+		return nil
+	}
+
+	target := menuTargetObject()
+	if target.IsNil() {
+		return ErrMenuCreationFailed
+	}
+
+	menuBar := newMenu("")
+	if menuBar.IsNil() {
+		return ErrMenuCreationFailed
+	}
+
+	name := processName()
+
+	// Application menu; AppKit always titles it with the app name
+	appMenu := newMenu(name)
+	addMenuItem(appMenu, "About "+name, selectors.orderFrontStandardAboutPanel, "", 0, 0)
+	addSeparator(appMenu)
+	addMenuItem(appMenu, "Hide "+name, selectors.hide, "h", 0, 0)
+	addMenuItem(appMenu, "Hide Others", selectors.hideOtherApplications, "h", NSEventModifierFlagOption, 0)
+	addMenuItem(appMenu, "Show All", selectors.unhideAllApplications, "", 0, 0)
+	addSeparator(appMenu)
+	addMenuItem(appMenu, "Quit "+name, selectors.terminateRequested, "q", 0, target)
+	attachSubmenu(menuBar, appMenu, -1)
+	appMenu.Send(selectors.release)
+
+	// Edit menu
+	editMenu := newMenu("Edit")
+	addMenuItem(editMenu, "Undo", selectors.undo, "z", 0, 0)
+	addMenuItem(editMenu, "Redo", selectors.redo, "Z", 0, 0)
+	addSeparator(editMenu)
+	addMenuItem(editMenu, "Cut", selectors.cut, "x", 0, 0)
+	addMenuItem(editMenu, "Copy", selectors.copy, "c", 0, 0)
+	addMenuItem(editMenu, "Paste", selectors.paste, "v", 0, 0)
+	addMenuItem(editMenu, "Select All", selectors.selectAll, "a", 0, 0)
+	attachSubmenu(menuBar, editMenu, -1)
+	editMenu.Send(selectors.release)
+
+	// Window menu; AppKit appends the list of open windows to it
+	windowMenu := newMenu("Window")
+	addMenuItem(windowMenu, "Minimize", selectors.performMiniaturize, "m", 0, 0)
+	addMenuItem(windowMenu, "Zoom", selectors.performZoom, "", 0, 0)
+	addSeparator(windowMenu)
+	addMenuItem(windowMenu, "Bring All to Front", selectors.arrangeInFront, "", 0, 0)
+	attachSubmenu(menuBar, windowMenu, -1)
+	a.nsApp.SendPtr(selectors.setWindowsMenu, windowMenu.Ptr())
+	windowMenu.Send(selectors.release)
+
+	a.nsApp.SendPtr(selectors.setMainMenu, menuBar.Ptr())
+	a.menuBar = menuBar
+
+	return nil
+}
+
+// AddMenu adds a custom top-level menu before the Window menu.
+// Selecting an item calls the menu handler with the item's Tag.
+func (a *Application) AddMenu(title string, items []MenuItem) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.menuBar.IsNil() {
+		return ErrApplicationNotInitialized
+	}
+
+	target := menuTargetObject()
+	menu := newMenu(title)
+	if menu.IsNil() || target.IsNil() {
+		return ErrMenuCreationFailed
+	}
+	defer menu.Send(selectors.release)
+
+	for _, it := range items {
+		if it.Separator {
+			addSeparator(menu)
+			continue
+		}
+		item := addMenuItem(menu, it.Title, selectors.menuItemSelected, it.Key, it.Modifiers, target)
+		if item.IsNil() {
+			return ErrMenuCreationFailed
+		}
+		item.SendInt(selectors.setTag, int64(it.Tag))
+	}
+
+	// Keep the Window menu last, as in standard macOS apps
+	index := int(int64(a.menuBar.Send(selectors.numberOfItems))) - 1
+	if index < 1 {
+		index = -1
+	}
+	attachSubmenu(a.menuBar, menu, index)
+
+	return nil
+}
+
+// SetMenuHandler sets a callback for custom menu items added with AddMenu.
+// The handler receives the item's Tag and runs on the main thread,
+// usually from within PollEvents/WaitEvents.
+func (a *Application) SetMenuHandler(handler func(tag int)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onMenu = handler
+}
+
+// handleMenu is called by the menu target when a custom item is selected.
+func (a *Application) handleMenu(tag int) {
+	a.mu.Lock()
+	onMenu := a.onMenu
+	a.mu.Unlock()
+
+	if onMenu != nil {
+		onMenu(tag)
+	}
+}
@@ -0,0 +1,79 @@
+//go:build darwin
+
+package darwin
+
+// Mouse button event types not covered by the NSEventType constants.
+const (
+	nsEventTypeOtherMouseDown NSEventType = 25
+	nsEventTypeOtherMouseUp   NSEventType = 26
+)
+
+// MouseEventKind identifies what a MouseEvent reports.
+type MouseEventKind uint8
+
+// Mouse event kinds.
+const (
+	MouseDown MouseEventKind = iota + 1
+	MouseUp
+	MouseMove
+	MouseScroll
+)
+
+// MouseEvent is a mouse button, move or scroll wheel event.
+type MouseEvent struct {
+	Kind MouseEventKind
+
+	// Button is the button number of MouseDown and MouseUp: 0 for the
+	// left button, 1 for the right, 2 for the middle and so on.
+	Button int
+
+	// Location is in window coordinates (points, bottom-left origin).
+	Location NSPoint
+
+	// ScrollX and ScrollY are the MouseScroll deltas, positive to the
+	// left and up. They are in lines for wheels and in points if Precise
+	// is set, as for touchpads and Magic Mouse.
+	ScrollX, ScrollY float64
+	Precise          bool
+
+	Modifiers NSEventModifierFlags
+}
+
+// mouseFromEvent decodes a mouse NSEvent. Dragged events are reported as
+// MouseMove. Returns false if the event is not a mouse event.
+func mouseFromEvent(event ID) (MouseEvent, bool) {
+	var m MouseEvent
+
+	switch NSEventType(event.Send(selectors.eventType)) {
+	case NSEventTypeLeftMouseDown, NSEventTypeRightMouseDown, nsEventTypeOtherMouseDown:
+		m.Kind = MouseDown
+		m.Button = int(event.Send(selectors.buttonNumber))
+	case NSEventTypeLeftMouseUp, NSEventTypeRightMouseUp, nsEventTypeOtherMouseUp:
+		m.Kind = MouseUp
+		m.Button = int(event.Send(selectors.buttonNumber))
+	case NSEventTypeMouseMoved, nsEventTypeLeftMouseDragged,
+		nsEventTypeRightMouseDragged, nsEventTypeOtherMouseDragged:
+		m.Kind = MouseMove
+	case NSEventTypeScrollWheel:
+		m.Kind = MouseScroll
+		m.ScrollX = event.GetDouble(selectors.scrollingDeltaX)
+		m.ScrollY = event.GetDouble(selectors.scrollingDeltaY)
+		m.Precise = event.Send(selectors.hasPreciseScrollingDeltas) != 0
+	default:
+		return MouseEvent{}, false
+	}
+
+	m.Location = event.GetPoint(selectors.locationInWindow)
+	m.Modifiers = NSEventModifierFlags(event.Send(selectors.modifierFlags))
+	return m, true
+}
+
+// SetMouseHandler sets a callback for mouse buttons, movement and scroll
+// wheels in any window. Mouse events are still delivered to the window
+// afterwards. The handler runs on the main thread, usually from within
+// PollEvents/WaitEvents.
+func (a *Application) SetMouseHandler(handler func(*Window, MouseEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onMouse = handler
+}
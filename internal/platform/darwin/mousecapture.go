@@ -0,0 +1,158 @@
+//go:build darwin
+
+package darwin
+
+// Mouse dragged event types, which carry deltas like NSEventTypeMouseMoved.
+const (
+	nsEventTypeLeftMouseDragged  NSEventType = 6
+	nsEventTypeRightMouseDragged NSEventType = 7
+	nsEventTypeOtherMouseDragged NSEventType = 27
+)
+
+// SetMouseCapture locks the mouse to a window for relative motion, as used
+// by first-person cameras: the cursor is hidden and stays at the window
+// center while mouse movement is reported only through MouseDelta.
+// A nil window releases the capture.
+//
+// The capture is suspended while the window is not the key window, so the
+// user can switch applications, and resumes when it becomes key again.
+func (a *Application) SetMouseCapture(w *Window) {
+	key := w != nil && w.IsKeyWindow()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.captureWindow == w {
+		return
+	}
+
+	a.disengageMouseCapture()
+	a.captureWindow = w
+	if key {
+		a.engageMouseCapture()
+	}
+}
+
+// MouseCapture returns the window holding the mouse capture, or nil.
+func (a *Application) MouseCapture() *Window {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.captureWindow
+}
+
+// MouseDelta returns the mouse movement accumulated since the previous call,
+// in points with y down, and resets it. The values come from the NSEvent
+// deltas, so they include the user's pointer acceleration and keep
+// reporting motion while the cursor is captured and cannot move.
+func (a *Application) MouseDelta() (dx, dy float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dx, dy = a.mouseDX, a.mouseDY
+	a.mouseDX, a.mouseDY = 0, 0
+	return dx, dy
+}
+
+// accumulateMouseDelta adds the delta of a mouse moved or dragged event.
+func (a *Application) accumulateMouseDelta(event ID) {
+	switch NSEventType(event.Send(selectors.eventType)) {
+	case NSEventTypeMouseMoved, nsEventTypeLeftMouseDragged,
+		nsEventTypeRightMouseDragged, nsEventTypeOtherMouseDragged:
+	default:
+		return
+	}
+
+	dx := event.GetDouble(selectors.deltaX)
+	dy := event.GetDouble(selectors.deltaY)
+
+	a.mu.Lock()
+	a.mouseDX += dx
+	a.mouseDY += dy
+	a.mu.Unlock()
+}
+
+// resumeMouseCapture re-engages the capture when its window becomes key.
+func (a *Application) resumeMouseCapture(w *Window) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.captureWindow == w {
+		a.engageMouseCapture()
+	}
+}
+
+// suspendMouseCapture gives the cursor back while the capture window is
+// not key. The capture stays requested and resumes on resumeMouseCapture.
+func (a *Application) suspendMouseCapture(w *Window) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.captureWindow == w {
+		a.disengageMouseCapture()
+	}
+}
+
+// releaseMouseCapture drops the capture if w holds it, e.g. when w closes.
+func (a *Application) releaseMouseCapture(w *Window) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.captureWindow == w {
+		a.disengageMouseCapture()
+		a.captureWindow = nil
+	}
+}
+
+// engageMouseCapture centers and freezes the cursor over the capture
+// window and hides it. Caller must hold a.mu.
+func (a *Application) engageMouseCapture() {
+	if a.captureEngaged || a.captureWindow == nil {
+		return
+	}
+
+	// Center first, so clicks while captured land inside the window
+	if center, ok := a.captureWindow.screenCenter(); ok {
+		warpMouse(center)
+	}
+	associateMouse(false)
+	HideCursor()
+
+	a.captureEngaged = true
+	a.mouseDX, a.mouseDY = 0, 0
+}
+
+// disengageMouseCapture restores the cursor. Caller must hold a.mu.
+func (a *Application) disengageMouseCapture() {
+	if !a.captureEngaged {
+		return
+	}
+
+	associateMouse(true)
+	UnhideCursor()
+	a.captureEngaged = false
+}
+
+// screenCenter returns the center of the window in global display
+// coordinates (points, top-left origin of the primary display, y down),
+// as expected by CGWarpMouseCursorPosition.
+func (w *Window) screenCenter() (NSPoint, bool) {
+	w.mu.Lock()
+	nsWindow := w.nsWindow
+	w.mu.Unlock()
+
+	if nsWindow.IsNil() {
+		return NSPoint{}, false
+	}
+
+	primary := classes.NSScreen.Send(selectors.screens).SendUint(selectors.objectAtIndex, 0)
+	if primary.IsNil() {
+		return NSPoint{}, false
+	}
+
+	frame := nsWindow.GetRect(selectors.frame)
+	primaryHeight := primary.GetRect(selectors.frame).Size.Height
+	return NSPoint{
+		X: frame.Origin.X + frame.Size.Width/2,
+		Y: primaryHeight - (frame.Origin.Y + frame.Size.Height/2),
+	}, true
+}
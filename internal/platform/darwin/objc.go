@@ -48,6 +48,16 @@ type objcRuntime struct {
 	objcMsgSendStret unsafe.Pointer
 	selRegisterName  unsafe.Pointer
 
+	// Class creation (used for delegates and custom views)
+	objcAllocateClassPair unsafe.Pointer
+	objcRegisterClassPair unsafe.Pointer
+	classAddMethod        unsafe.Pointer
+	classAddProtocol      unsafe.Pointer
+	objcGetProtocol       unsafe.Pointer
+	objcMsgSendSuper      unsafe.Pointer
+	objectGetClass        unsafe.Pointer
+	classGetSuperclass    unsafe.Pointer
+
 	// Call interfaces (reusable)
 	cifVoidPtr  *types.CallInterface // Returns void*, takes variadic args
 	cifFpret    *types.CallInterface // Returns floating point
@@ -137,6 +147,40 @@ func loadRuntime() error {
 		return errors.Join(ErrSymbolNotFound, err)
 	}
 
+	// Resolve class creation functions
+	objcRT.objcAllocateClassPair, err = ffi.GetSymbol(objcRT.libobjc, "objc_allocateClassPair")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.objcRegisterClassPair, err = ffi.GetSymbol(objcRT.libobjc, "objc_registerClassPair")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.classAddMethod, err = ffi.GetSymbol(objcRT.libobjc, "class_addMethod")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.classAddProtocol, err = ffi.GetSymbol(objcRT.libobjc, "class_addProtocol")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.objcGetProtocol, err = ffi.GetSymbol(objcRT.libobjc, "objc_getProtocol")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.objcMsgSendSuper, err = ffi.GetSymbol(objcRT.libobjc, "objc_msgSendSuper")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.objectGetClass, err = ffi.GetSymbol(objcRT.libobjc, "object_getClass")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+	objcRT.classGetSuperclass, err = ffi.GetSymbol(objcRT.libobjc, "class_getSuperclass")
+	if err != nil {
+		return errors.Join(ErrSymbolNotFound, err)
+	}
+
 	// Prepare reusable call interfaces
 	objcRT.cifVoidPtr = &types.CallInterface{}
 	objcRT.cifFpret = &types.CallInterface{}
@@ -156,6 +200,20 @@ func loadRuntime() error {
 		return err
 	}
 
+	// CIF for double-returning calls (2 args: self, _cmd)
+	err = ffi.PrepareCallInterface(
+		objcRT.cifFpret,
+		types.DefaultCall,
+		types.DoubleTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // self (ID)
+			types.PointerTypeDescriptor, // _cmd (SEL)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
 	// CIF for sel_registerName (1 arg: const char*)
 	err = ffi.PrepareCallInterface(
 		objcRT.cifSelector,
@@ -348,6 +406,40 @@ func msgSend(self ID, sel SEL, args ...uintptr) ID {
 	return ID(result)
 }
 
+// callC calls a C function taking and returning pointer-sized integers.
+// Like msgSend, it prepares a new CIF per call and is not meant for hot paths.
+func callC(fn unsafe.Pointer, args ...uintptr) uintptr {
+	if fn == nil {
+		return 0
+	}
+
+	argTypes := make([]*types.TypeDescriptor, len(args))
+	argPtrs := make([]unsafe.Pointer, len(args))
+	for i := range args {
+		argTypes[i] = types.PointerTypeDescriptor
+		argPtrs[i] = unsafe.Pointer(&args[i])
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		types.PointerTypeDescriptor,
+		argTypes,
+	)
+	if err != nil {
+		return 0
+	}
+
+	var result uintptr
+	err = ffi.CallFunction(cif, fn, unsafe.Pointer(&result), argPtrs)
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
 // SendPtr sends a message with one pointer argument.
 func (id ID) SendPtr(sel SEL, arg uintptr) ID {
 	return msgSend(id, sel, arg)
@@ -436,6 +528,58 @@ func (id ID) SendRect(sel SEL, rect NSRect) ID {
 	return ID(result)
 }
 
+// SendDouble sends a message with one CGFloat (double) argument.
+// Floating-point arguments are passed in FP registers, so SendUint cannot be used.
+func (id ID) SendDouble(sel SEL, arg float64) ID {
+	if id == 0 || sel == 0 {
+		return 0
+	}
+
+	if err := initRuntime(); err != nil {
+		return 0
+	}
+
+	argTypes := []*types.TypeDescriptor{
+		types.PointerTypeDescriptor, // self
+		types.PointerTypeDescriptor, // _cmd
+		types.DoubleTypeDescriptor,  // value
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		types.PointerTypeDescriptor,
+		argTypes,
+	)
+	if err != nil {
+		return 0
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+	value := arg
+
+	argPtrs := []unsafe.Pointer{
+		unsafe.Pointer(&selfPtr),
+		unsafe.Pointer(&selPtr),
+		unsafe.Pointer(&value),
+	}
+
+	var result uintptr
+	err = ffi.CallFunction(
+		cif,
+		objcRT.objcMsgSend,
+		unsafe.Pointer(&result),
+		argPtrs,
+	)
+	if err != nil {
+		return 0
+	}
+
+	return ID(result)
+}
+
 // SendRectUintUintBool sends a message for initWithContentRect:styleMask:backing:defer:
 // This is the standard NSWindow initialization method.
 func (id ID) SendRectUintUintBool(sel SEL, rect NSRect, style NSUInteger, backing NSBackingStoreType, deferFlag bool) ID {
@@ -583,6 +727,12 @@ func (id ID) GetRect(sel SEL) NSRect {
 	}
 }
 
+// SendPoint sends a message with an NSPoint argument.
+func (id ID) SendPoint(sel SEL, point NSPoint) ID {
+	// NSPoint and NSSize are both two CGFloats, passed the same way
+	return id.SendSize(sel, NSSize{Width: point.X, Height: point.Y})
+}
+
 // SendSize sends a message with an NSSize argument.
 func (id ID) SendSize(sel SEL, size NSSize) ID {
 	if id == 0 || sel == 0 {
@@ -636,3 +786,257 @@ func (id ID) SendSize(sel SEL, size NSSize) ID {
 
 	return ID(result)
 }
+
+// GetDouble receives a CGFloat (double) return value from a method like magnification.
+func (id ID) GetDouble(sel SEL) float64 {
+	if id == 0 || sel == 0 {
+		return 0
+	}
+
+	if err := initRuntime(); err != nil {
+		return 0
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+
+	var result float64
+	err := ffi.CallFunction(
+		objcRT.cifFpret,
+		objcRT.objcMsgSendFpret,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{
+			unsafe.Pointer(&selfPtr),
+			unsafe.Pointer(&selPtr),
+		},
+	)
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
+// GetFloat receives a float return value from a method like rotation.
+// Unlike CGFloat, Objective-C float is 32 bits wide on all architectures.
+func (id ID) GetFloat(sel SEL) float32 {
+	if id == 0 || sel == 0 {
+		return 0
+	}
+
+	if err := initRuntime(); err != nil {
+		return 0
+	}
+
+	argTypes := []*types.TypeDescriptor{
+		types.PointerTypeDescriptor, // self
+		types.PointerTypeDescriptor, // _cmd
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		types.FloatTypeDescriptor,
+		argTypes,
+	)
+	if err != nil {
+		return 0
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+
+	var result float32
+	err = ffi.CallFunction(
+		cif,
+		objcRT.objcMsgSendFpret,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{
+			unsafe.Pointer(&selfPtr),
+			unsafe.Pointer(&selPtr),
+		},
+	)
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
+// GetPoint receives an NSPoint return value from a method like locationInWindow.
+// NSPoint is two doubles, which is returned in registers on both x86_64 and ARM64.
+func (id ID) GetPoint(sel SEL) NSPoint {
+	if id == 0 || sel == 0 {
+		return NSPoint{}
+	}
+
+	if err := initRuntime(); err != nil {
+		return NSPoint{}
+	}
+
+	pointType := &types.TypeDescriptor{
+		Size:      16, // 2 * 8 bytes (2 doubles)
+		Alignment: 8,  // double alignment
+		Kind:      types.StructType,
+		Members: []*types.TypeDescriptor{
+			types.DoubleTypeDescriptor,
+			types.DoubleTypeDescriptor,
+		},
+	}
+
+	argTypes := []*types.TypeDescriptor{
+		types.PointerTypeDescriptor, // self
+		types.PointerTypeDescriptor, // _cmd
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		pointType,
+		argTypes,
+	)
+	if err != nil {
+		return NSPoint{}
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+
+	var result [2]float64
+	err = ffi.CallFunction(
+		cif,
+		objcRT.objcMsgSend,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{
+			unsafe.Pointer(&selfPtr),
+			unsafe.Pointer(&selPtr),
+		},
+	)
+	if err != nil {
+		return NSPoint{}
+	}
+
+	return NSPoint{X: result[0], Y: result[1]}
+}
+
+// SendRectPtr sends a message with an NSRect and a pointer argument,
+// e.g. -[NSView addCursorRect:cursor:].
+func (id ID) SendRectPtr(sel SEL, rect NSRect, arg uintptr) ID {
+	if id == 0 || sel == 0 {
+		return 0
+	}
+
+	if err := initRuntime(); err != nil {
+		return 0
+	}
+
+	argTypes := []*types.TypeDescriptor{
+		types.PointerTypeDescriptor, // self
+		types.PointerTypeDescriptor, // _cmd
+		types.DoubleTypeDescriptor,  // x
+		types.DoubleTypeDescriptor,  // y
+		types.DoubleTypeDescriptor,  // width
+		types.DoubleTypeDescriptor,  // height
+		types.PointerTypeDescriptor, // arg
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		types.PointerTypeDescriptor,
+		argTypes,
+	)
+	if err != nil {
+		return 0
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+	x := rect.Origin.X
+	y := rect.Origin.Y
+	w := rect.Size.Width
+	h := rect.Size.Height
+
+	argPtrs := []unsafe.Pointer{
+		unsafe.Pointer(&selfPtr),
+		unsafe.Pointer(&selPtr),
+		unsafe.Pointer(&x),
+		unsafe.Pointer(&y),
+		unsafe.Pointer(&w),
+		unsafe.Pointer(&h),
+		unsafe.Pointer(&arg),
+	}
+
+	var result uintptr
+	err = ffi.CallFunction(
+		cif,
+		objcRT.objcMsgSend,
+		unsafe.Pointer(&result),
+		argPtrs,
+	)
+	if err != nil {
+		return 0
+	}
+
+	return ID(result)
+}
+
+// SendPtrPoint sends a message with a pointer and an NSPoint argument,
+// e.g. -[NSCursor initWithImage:hotSpot:].
+func (id ID) SendPtrPoint(sel SEL, arg uintptr, point NSPoint) ID {
+	if id == 0 || sel == 0 {
+		return 0
+	}
+
+	if err := initRuntime(); err != nil {
+		return 0
+	}
+
+	argTypes := []*types.TypeDescriptor{
+		types.PointerTypeDescriptor, // self
+		types.PointerTypeDescriptor, // _cmd
+		types.PointerTypeDescriptor, // arg
+		types.DoubleTypeDescriptor,  // x
+		types.DoubleTypeDescriptor,  // y
+	}
+
+	cif := &types.CallInterface{}
+	err := ffi.PrepareCallInterface(
+		cif,
+		types.DefaultCall,
+		types.PointerTypeDescriptor,
+		argTypes,
+	)
+	if err != nil {
+		return 0
+	}
+
+	selfPtr := uintptr(id)
+	selPtr := uintptr(sel)
+	x := point.X
+	y := point.Y
+
+	argPtrs := []unsafe.Pointer{
+		unsafe.Pointer(&selfPtr),
+		unsafe.Pointer(&selPtr),
+		unsafe.Pointer(&arg),
+		unsafe.Pointer(&x),
+		unsafe.Pointer(&y),
+	}
+
+	var result uintptr
+	err = ffi.CallFunction(
+		cif,
+		objcRT.objcMsgSend,
+		unsafe.Pointer(&result),
+		argPtrs,
+	)
+	if err != nil {
+		return 0
+	}
+
+	return ID(result)
+}
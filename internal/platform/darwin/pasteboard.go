@@ -0,0 +1,163 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Errors returned by Pasteboard operations.
+var (
+	ErrPasteboardUnavailable = errors.New("darwin: general pasteboard unavailable")
+	ErrPasteboardWriteFailed = errors.New("darwin: failed to write to pasteboard")
+)
+
+// Pasteboard types (UTIs). These are the values of the AppKit
+// NSPasteboardTypeString and NSPasteboardTypePNG constants.
+const (
+	PasteboardTypeString = "public.utf8-plain-text"
+	PasteboardTypePNG    = "public.png"
+)
+
+// Pasteboard wraps an NSPasteboard.
+type Pasteboard struct {
+	id ID
+}
+
+// GeneralPasteboard returns the system clipboard.
+func GeneralPasteboard() (*Pasteboard, error) {
+	if err := initRuntime(); err != nil {
+		return nil, err
+	}
+	initSelectors()
+	initClasses()
+
+	pb := classes.NSPasteboard.Send(selectors.generalPasteboard)
+	if pb.IsNil() {
+		return nil, ErrPasteboardUnavailable
+	}
+	return &Pasteboard{id: pb}, nil
+}
+
+// ChangeCount returns a counter that increases every time the
+// pasteboard contents change, including changes by other applications.
+func (p *Pasteboard) ChangeCount() int64 {
+	return int64(p.id.Send(selectors.changeCount))
+}
+
+// ReadText returns the plain text on the pasteboard.
+// The second result is false if the pasteboard holds no text.
+func (p *Pasteboard) ReadText() (string, bool) {
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	uti := NewNSString(PasteboardTypeString)
+	if uti == nil {
+		return "", false
+	}
+	defer uti.Release()
+
+	str := p.id.SendPtr(selectors.stringForType, uti.ID().Ptr())
+	if str.IsNil() {
+		return "", false
+	}
+	return goStringFromNSString(str), true
+}
+
+// WriteText replaces the pasteboard contents with plain text.
+func (p *Pasteboard) WriteText(text string) error {
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	str := NewNSString(text)
+	uti := NewNSString(PasteboardTypeString)
+	if str == nil || uti == nil {
+		str.Release()
+		uti.Release()
+		return ErrPasteboardWriteFailed
+	}
+	defer str.Release()
+	defer uti.Release()
+
+	// The pasteboard must be cleared before it accepts new data
+	p.id.Send(selectors.clearContents)
+	if !isYes(msgSend(p.id, selectors.setStringForType, str.ID().Ptr(), uti.ID().Ptr())) {
+		return ErrPasteboardWriteFailed
+	}
+	return nil
+}
+
+// ReadData returns a copy of the pasteboard data of the given type (UTI).
+// The second result is false if no data of that type is available.
+func (p *Pasteboard) ReadData(uti string) ([]byte, bool) {
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	nsUTI := NewNSString(uti)
+	if nsUTI == nil {
+		return nil, false
+	}
+	defer nsUTI.Release()
+
+	data := p.id.SendPtr(selectors.dataForType, nsUTI.ID().Ptr())
+	if data.IsNil() {
+		return nil, false
+	}
+
+	length := int(data.Send(selectors.length))
+	if length == 0 {
+		return []byte{}, true
+	}
+
+	src := uintptr(data.Send(selectors.bytes))
+	if src == 0 {
+		return nil, false
+	}
+
+	result := make([]byte, length)
+	copy(result, unsafe.Slice((*byte)(ptrAt(src, 0)), length))
+	return result, true
+}
+
+// WriteData replaces the pasteboard contents with data of the given type (UTI).
+func (p *Pasteboard) WriteData(uti string, data []byte) error {
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	nsUTI := NewNSString(uti)
+	if nsUTI == nil {
+		return ErrPasteboardWriteFailed
+	}
+	defer nsUTI.Release()
+
+	// dataWithBytes:length: copies the bytes into an autoreleased NSData
+	nsData := msgSend(ID(classes.NSData), selectors.dataWithBytesLength,
+		bytesPtr(data), uintptr(len(data)))
+	if nsData.IsNil() {
+		return ErrPasteboardWriteFailed
+	}
+
+	p.id.Send(selectors.clearContents)
+	if !isYes(msgSend(p.id, selectors.setDataForType, nsData.Ptr(), nsUTI.ID().Ptr())) {
+		return ErrPasteboardWriteFailed
+	}
+	return nil
+}
+
+// ReadImage returns the pasteboard image as PNG-encoded data.
+// Only PNG content is returned; other image types are not converted.
+func (p *Pasteboard) ReadImage() ([]byte, bool) {
+	return p.ReadData(PasteboardTypePNG)
+}
+
+// WriteImage replaces the pasteboard contents with a PNG-encoded image.
+func (p *Pasteboard) WriteImage(png []byte) error {
+	return p.WriteData(PasteboardTypePNG, png)
+}
+
+// isYes reports whether a BOOL returned through objc_msgSend is YES.
+// Only the low byte of the return register is defined.
+func isYes(result ID) bool {
+	return result&0xff != 0
+}
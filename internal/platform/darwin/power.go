@@ -0,0 +1,197 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// PowerEvent is a system sleep or wake transition.
+type PowerEvent uint8
+
+const (
+	// PowerWillSleep is sent before the system sleeps. Drawables and GPU
+	// work in flight may not survive the sleep.
+	PowerWillSleep PowerEvent = iota
+
+	// PowerDidWake is sent after the system wakes up.
+	PowerDidWake
+)
+
+// DisplayChangeFlags describes a display reconfiguration
+// (CGDisplayChangeSummaryFlags).
+type DisplayChangeFlags uint32
+
+const (
+	DisplayChangeMoved        DisplayChangeFlags = 1 << 1  // Display moved in the arrangement
+	DisplayChangeSetMain      DisplayChangeFlags = 1 << 2  // Display became the main display
+	DisplayChangeSetMode      DisplayChangeFlags = 1 << 3  // Resolution or refresh rate changed
+	DisplayChangeAdd          DisplayChangeFlags = 1 << 4  // Display was connected
+	DisplayChangeRemove       DisplayChangeFlags = 1 << 5  // Display was disconnected
+	DisplayChangeEnabled      DisplayChangeFlags = 1 << 8  // Display was enabled
+	DisplayChangeDisabled     DisplayChangeFlags = 1 << 9  // Display was disabled
+	DisplayChangeMirror       DisplayChangeFlags = 1 << 10 // Display started mirroring
+	DisplayChangeUnMirror     DisplayChangeFlags = 1 << 11 // Display stopped mirroring
+	DisplayChangeDesktopShape DisplayChangeFlags = 1 << 12 // Shape of the desktop changed
+)
+
+// displayChangeBegin is kCGDisplayBeginConfigurationFlag, set on the
+// notification sent before a reconfiguration starts.
+const displayChangeBegin DisplayChangeFlags = 1 << 0
+
+// powerObserverClassName is the name of the runtime-registered class that
+// receives NSWorkspace sleep and wake notifications.
+const powerObserverClassName = "GoGPUPowerObserver"
+
+// powerObserver holds the shared workspace notification observer.
+var powerObserver struct {
+	once     sync.Once
+	observer ID
+}
+
+// observePower registers for NSWorkspaceWillSleepNotification and
+// NSWorkspaceDidWakeNotification. They are posted on the workspace
+// notification center, not the default one.
+func observePower() {
+	powerObserver.once.Do(func() {
+		class := allocateClass(powerObserverClassName, classes.NSObject, nil, []classMethod{
+			// - (void)workspaceWillSleep:(NSNotification *)notification
+			{selectors.workspaceWillSleep, powerObserverWillSleep, "v@:@"},
+			// - (void)workspaceDidWake:(NSNotification *)notification
+			{selectors.workspaceDidWake, powerObserverDidWake, "v@:@"},
+		})
+		if class == 0 {
+			return
+		}
+
+		// Never released or removed: observes for the life of the process
+		powerObserver.observer = class.Send(selectors.new)
+		center := classes.NSWorkspace.Send(selectors.sharedWorkspace).Send(selectors.notificationCenter)
+
+		for _, n := range []struct {
+			name string
+			sel  SEL
+		}{
+			{"NSWorkspaceWillSleepNotification", selectors.workspaceWillSleep},
+			{"NSWorkspaceDidWakeNotification", selectors.workspaceDidWake},
+		} {
+			name := NewNSString(n.name)
+			if name == nil {
+				continue
+			}
+			msgSend(center, selectors.addObserverSelectorNameObject,
+				powerObserver.observer.Ptr(),
+				n.sel.SELPtr(),
+				name.ID().Ptr(),
+				0, // any sender
+			)
+			name.Release() // Copied by the notification center
+		}
+	})
+}
+
+// powerObserverWillSleep implements -workspaceWillSleep:.
+func powerObserverWillSleep(_, _, _ uintptr) uintptr {
+	GetApplication().handlePower(PowerWillSleep)
+	return 0
+}
+
+// powerObserverDidWake implements -workspaceDidWake:.
+func powerObserverDidWake(_, _, _ uintptr) uintptr {
+	GetApplication().handlePower(PowerDidWake)
+	return 0
+}
+
+// SetPowerHandler sets a callback for system sleep and wake. The handler
+// runs on the main thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetPowerHandler(handler func(PowerEvent)) {
+	a.mu.Lock()
+	a.onPower = handler
+	a.mu.Unlock()
+
+	if handler != nil {
+		observePower()
+	}
+}
+
+// handlePower calls the power handler.
+func (a *Application) handlePower(event PowerEvent) {
+	a.mu.Lock()
+	onPower := a.onPower
+	a.mu.Unlock()
+
+	if onPower != nil {
+		onPower(event)
+	}
+}
+
+// displayReconfiguration holds the CoreGraphics reconfiguration callback.
+var displayReconfiguration struct {
+	once     sync.Once
+	callback uintptr
+}
+
+// observeDisplayReconfiguration registers a CoreGraphics display
+// reconfiguration callback. Unlike the screen parameters notification,
+// it also fires when a MacBook with two GPUs switches between the
+// integrated and the discrete one.
+func observeDisplayReconfiguration() {
+	displayReconfiguration.once.Do(func() {
+		if err := initCoreGraphics(); err != nil {
+			return
+		}
+
+		// Never unregistered: the trampoline cannot be freed anyway
+		displayReconfiguration.callback = ffi.NewCallback(displayReconfigured)
+
+		callback := displayReconfiguration.callback
+		var userInfo uintptr
+		var result uint32
+		_ = ffi.CallFunction(
+			coreGraphics.cifRegister,
+			coreGraphics.register,
+			unsafe.Pointer(&result),
+			[]unsafe.Pointer{unsafe.Pointer(&callback), unsafe.Pointer(&userInfo)},
+		)
+	})
+}
+
+// displayReconfigured implements CGDisplayReconfigurationCallBack.
+// CoreGraphics calls it on the main thread from the run loop, once per
+// affected display before and once after the reconfiguration.
+func displayReconfigured(display, flags, _ uintptr) uintptr {
+	change := DisplayChangeFlags(uint32(flags))
+	if change&displayChangeBegin == 0 {
+		GetApplication().handleDisplayReconfigured(uint32(display), change)
+	}
+	return 0
+}
+
+// SetDisplayReconfigurationHandler sets a callback for completed display
+// reconfigurations, called once per affected display. After a GPU switch
+// or a mode change the drawables of existing layers may be stale, so the
+// renderer should reconfigure its surface. The handler runs on the main
+// thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetDisplayReconfigurationHandler(handler func(displayID uint32, flags DisplayChangeFlags)) {
+	a.mu.Lock()
+	a.onDisplayReconfigured = handler
+	a.mu.Unlock()
+
+	if handler != nil {
+		observeDisplayReconfiguration()
+	}
+}
+
+// handleDisplayReconfigured calls the display reconfiguration handler.
+func (a *Application) handleDisplayReconfigured(displayID uint32, flags DisplayChangeFlags) {
+	a.mu.Lock()
+	onDisplayReconfigured := a.onDisplayReconfigured
+	a.mu.Unlock()
+
+	if onDisplayReconfigured != nil {
+		onDisplayReconfigured(displayID, flags)
+	}
+}
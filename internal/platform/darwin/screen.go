@@ -0,0 +1,224 @@
+//go:build darwin
+
+package darwin
+
+import (
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// Screen describes a display attached to the system.
+type Screen struct {
+	// DisplayID is the CGDirectDisplayID of the display.
+	DisplayID uint32
+
+	// Name is the localized display name, e.g. "Built-in Retina Display".
+	Name string
+
+	// Frame is the screen rectangle in points, in Cocoa global coordinates:
+	// the origin is the bottom-left corner of the primary screen, y up.
+	Frame NSRect
+
+	// VisibleFrame is Frame without the menu bar and the Dock.
+	VisibleFrame NSRect
+
+	// ScaleFactor is the ratio of backing pixels to points.
+	ScaleFactor float64
+
+	// RefreshRate is the refresh rate in Hz, or 0 if unknown.
+	RefreshRate float64
+
+	// ColorSpace is the localized name of the screen's color space,
+	// e.g. "Display P3", or "" if unknown.
+	ColorSpace string
+}
+
+// displayRefreshRate returns the refresh rate of the display's current
+// mode in Hz. Built-in panels often report 0 through CGDisplayMode; the
+// CVDisplayLink nominal period is used for them instead.
+func displayRefreshRate(displayID uint32) float64 {
+	if rate := displayModeRefreshRate(displayID); rate > 0 {
+		return rate
+	}
+
+	link, err := NewDisplayLink(displayID)
+	if err != nil {
+		return 0
+	}
+	defer link.Release()
+
+	if period := link.RefreshPeriod(); period > 0 {
+		return float64(time.Second) / float64(period)
+	}
+	return 0
+}
+
+// displayModeRefreshRate queries CGDisplayModeGetRefreshRate, or returns 0.
+func displayModeRefreshRate(displayID uint32) float64 {
+	if err := initCoreGraphics(); err != nil {
+		return 0
+	}
+
+	var mode uintptr
+	id := displayID
+	err := ffi.CallFunction(
+		coreGraphics.cifCopy,
+		coreGraphics.copyMode,
+		unsafe.Pointer(&mode),
+		[]unsafe.Pointer{unsafe.Pointer(&id)},
+	)
+	if err != nil || mode == 0 {
+		return 0
+	}
+
+	var rate float64
+	_ = ffi.CallFunction(
+		coreGraphics.cifRate,
+		coreGraphics.refreshRate,
+		unsafe.Pointer(&rate),
+		[]unsafe.Pointer{unsafe.Pointer(&mode)},
+	)
+
+	var result uintptr
+	_ = ffi.CallFunction(
+		coreGraphics.cifRelease,
+		coreGraphics.releaseMode,
+		unsafe.Pointer(&result),
+		[]unsafe.Pointer{unsafe.Pointer(&mode)},
+	)
+
+	return rate
+}
+
+// Screens returns the attached displays. The first screen is the primary
+// one, which holds the menu bar and defines the coordinate origin.
+func Screens() []Screen {
+	if err := initRuntime(); err != nil {
+		return nil
+	}
+	initSelectors()
+	initClasses()
+
+	array := classes.NSScreen.Send(selectors.screens)
+	count := int(uint64(array.Send(selectors.count)))
+
+	screens := make([]Screen, 0, count)
+	for i := 0; i < count; i++ {
+		screen := array.SendUint(selectors.objectAtIndex, uint64(i))
+		if screen.IsNil() {
+			continue
+		}
+		screens = append(screens, screenInfo(screen))
+	}
+	return screens
+}
+
+// screenInfo collects the properties of an NSScreen.
+func screenInfo(screen ID) Screen {
+	s := Screen{
+		DisplayID:    screenDisplayID(screen),
+		Name:         goStringFromNSString(screen.Send(selectors.localizedName)),
+		Frame:        screen.GetRect(selectors.frame),
+		VisibleFrame: screen.GetRect(selectors.visibleFrame),
+		ScaleFactor:  screen.GetDouble(selectors.backingScaleFactor),
+	}
+	if s.ScaleFactor <= 0 {
+		s.ScaleFactor = 1.0
+	}
+	if s.DisplayID != 0 {
+		s.RefreshRate = displayRefreshRate(s.DisplayID)
+	}
+	if colorSpace := screen.Send(selectors.colorSpace); !colorSpace.IsNil() {
+		s.ColorSpace = goStringFromNSString(colorSpace.Send(selectors.localizedName))
+	}
+	return s
+}
+
+// screenDisplayID returns the CGDirectDisplayID of an NSScreen, or 0.
+func screenDisplayID(screen ID) uint32 {
+	key := NewNSString("NSScreenNumber")
+	if key == nil {
+		return 0
+	}
+	defer key.Release()
+
+	description := screen.Send(selectors.deviceDescription)
+	number := description.SendPtr(selectors.objectForKey, key.ID().Ptr())
+	return uint32(number.Send(selectors.unsignedIntValue))
+}
+
+// screenObserverClassName is the name of the runtime-registered class that
+// receives screen configuration notifications.
+const screenObserverClassName = "GoGPUScreenObserver"
+
+// screenObserver holds the shared notification observer.
+var screenObserver struct {
+	once     sync.Once
+	observer ID
+}
+
+// observeScreenChanges registers for
+// NSApplicationDidChangeScreenParametersNotification, which AppKit posts
+// when a display is connected or disconnected, or its resolution,
+// arrangement or scale changes.
+func observeScreenChanges() {
+	screenObserver.once.Do(func() {
+		class := allocateClass(screenObserverClassName, classes.NSObject, nil, []classMethod{
+			// - (void)screenParametersChanged:(NSNotification *)notification
+			{selectors.screenParametersChanged, screenObserverParametersChanged, "v@:@"},
+		})
+		if class == 0 {
+			return
+		}
+
+		name := NewNSString("NSApplicationDidChangeScreenParametersNotification")
+		if name == nil {
+			return
+		}
+		defer name.Release() // Copied by the notification center
+
+		// Never released or removed: observes for the life of the process
+		screenObserver.observer = class.Send(selectors.new)
+		center := classes.NSNotificationCenter.Send(selectors.defaultCenter)
+		msgSend(center, selectors.addObserverSelectorNameObject,
+			screenObserver.observer.Ptr(),
+			selectors.screenParametersChanged.SELPtr(),
+			name.ID().Ptr(),
+			0, // any sender
+		)
+	})
+}
+
+// screenObserverParametersChanged implements -screenParametersChanged:.
+func screenObserverParametersChanged(_, _, _ uintptr) uintptr {
+	GetApplication().handleScreensChanged()
+	return 0
+}
+
+// SetScreenChangeHandler sets a callback for display configuration changes:
+// displays connected or disconnected, and changes of resolution, arrangement
+// or scale. Call Screens from the handler for the new configuration.
+// The handler runs on the main thread, usually from within PollEvents/WaitEvents.
+func (a *Application) SetScreenChangeHandler(handler func()) {
+	a.mu.Lock()
+	a.onScreensChanged = handler
+	a.mu.Unlock()
+
+	if handler != nil {
+		observeScreenChanges()
+	}
+}
+
+// handleScreensChanged calls the screen change handler.
+func (a *Application) handleScreensChanged() {
+	a.mu.Lock()
+	onScreensChanged := a.onScreensChanged
+	a.mu.Unlock()
+
+	if onScreensChanged != nil {
+		onScreensChanged()
+	}
+}
@@ -30,6 +30,54 @@ var selectors struct {
 	// NSApplication delegate
 	setDelegate SEL
 
+	// NSApplication standard actions
+	setMainMenu                  SEL
+	setWindowsMenu               SEL
+	hide                         SEL
+	hideOtherApplications        SEL
+	unhideAllApplications        SEL
+	orderFrontStandardAboutPanel SEL
+	arrangeInFront               SEL
+
+	// NSApplication Dock tile
+	setApplicationIconImage    SEL
+	dockTile                   SEL
+	setBadgeLabel              SEL
+	requestUserAttention       SEL
+	cancelUserAttentionRequest SEL
+
+	// NSMenu / NSMenuItem
+	initWithTitle                    SEL
+	initWithTitleActionKeyEquivalent SEL
+	addItem                          SEL
+	insertItemAtIndex                SEL
+	numberOfItems                    SEL
+	setSubmenu                       SEL
+	separatorItem                    SEL
+	setTarget                        SEL
+	setTag                           SEL
+	tag                              SEL
+	setKeyEquivalentModifierMask     SEL
+	menuItemSelected                 SEL
+	terminateRequested               SEL
+
+	// Responder chain edit/window actions
+	undo               SEL
+	redo               SEL
+	cut                SEL
+	copy               SEL
+	paste              SEL
+	selectAll          SEL
+	performMiniaturize SEL
+	performZoom        SEL
+
+	// NSProcessInfo
+	processInfo SEL
+	processName SEL
+
+	// NSThread
+	currentThread SEL
+
 	// NSWindow - Window management
 	initWithContentRectStyleMaskBackingDefer SEL
 	setTitle                                 SEL
@@ -43,6 +91,8 @@ var selectors struct {
 	deminiaturize                            SEL
 	zoom                                     SEL
 	setFrame                                 SEL
+	setContentSize                           SEL
+	setFrameTopLeftPoint                     SEL
 	frame                                    SEL
 	contentRectForFrameRect                  SEL
 	frameRectForContentRect                  SEL
@@ -56,6 +106,35 @@ var selectors struct {
 	isZoomed                                 SEL
 	setReleasedWhenClosed                    SEL
 	center                                   SEL
+	backingScaleFactor                       SEL
+	screen                                   SEL
+	windowNumber                             SEL
+	occlusionState                           SEL
+	toggleFullScreen                         SEL
+	setCollectionBehavior                    SEL
+	setTitlebarAppearsTransparent            SEL
+	setTitleVisibility                       SEL
+	setOpaque                                SEL
+	setBackgroundColor                       SEL
+	performWindowDragWithEvent               SEL
+
+	// NSColor
+	clearColor              SEL
+	colorWithAlphaComponent SEL
+
+	// NSWindowDelegate
+	windowShouldClose             SEL
+	windowWillClose               SEL
+	windowDidMiniaturize          SEL
+	windowDidDeminiaturize        SEL
+	windowDidResize               SEL
+	windowDidBecomeKey            SEL
+	windowDidResignKey            SEL
+	windowDidChangeOcclusionState SEL
+	windowDidChangeScreen         SEL
+	windowDidEnterFullScreen      SEL
+	windowDidExitFullScreen       SEL
+	object                        SEL
 
 	// NSView - View management
 	setWantsLayer   SEL
@@ -65,16 +144,128 @@ var selectors struct {
 	bounds          SEL
 	setBounds       SEL
 	setNeedsDisplay SEL
+	initWithFrame   SEL
+	window          SEL
+
+	// NSView live resize
+	viewWillStartLiveResize SEL
+	viewDidEndLiveResize    SEL
+
+	// NSDraggingDestination / NSDraggingInfo
+	registerForDraggedTypes  SEL
+	draggingEntered          SEL
+	draggingUpdated          SEL
+	draggingExited           SEL
+	prepareForDragOperation  SEL
+	performDragOperation     SEL
+	draggingPasteboard       SEL
+	draggingLocation         SEL
+	readObjectsForClassesOpt SEL
+
+	// NSResponder
+	keyDown               SEL
+	acceptsFirstResponder SEL
+	interpretKeyEvents    SEL
+
+	// NSTextInputClient
+	insertText                   SEL
+	doCommandBySelector          SEL
+	setMarkedText                SEL
+	unmarkText                   SEL
+	selectedRange                SEL
+	markedRange                  SEL
+	hasMarkedText                SEL
+	attributedSubstringForRange  SEL
+	validAttributesForMarkedText SEL
+	firstRectForCharacterRange   SEL
+	characterIndexForPoint       SEL
+	isKindOfClass                SEL
+	string                       SEL
+
+	// NSCursor
+	arrowCursor                       SEL
+	IBeamCursor                       SEL
+	crosshairCursor                   SEL
+	pointingHandCursor                SEL
+	resizeLeftRightCursor             SEL
+	resizeUpDownCursor                SEL
+	openHandCursor                    SEL
+	closedHandCursor                  SEL
+	operationNotAllowedCursor         SEL
+	initWithImageHotSpot              SEL
+	set                               SEL
+	cursorHide                        SEL
+	cursorUnhide                      SEL
+	resetCursorRects                  SEL
+	addCursorRect                     SEL
+	invalidateCursorRectsForView      SEL
+	mouseLocationOutsideOfEventStream SEL
+
+	// NSImage / NSBitmapImageRep
+	initWithSize             SEL
+	addRepresentation        SEL
+	initWithBitmapDataPlanes SEL
+	bitmapData               SEL
+
+	// NSArray / NSURL
+	array           SEL
+	arrayWithObject SEL
+	count           SEL
+	objectAtIndex   SEL
+	isFileURL       SEL
+	path            SEL
+	class           SEL
+	addObject       SEL
+	fileURLWithPath SEL
+
+	// NSOpenPanel / NSSavePanel
+	openPanel                  SEL
+	savePanel                  SEL
+	setMessage                 SEL
+	setCanChooseFiles          SEL
+	setCanChooseDirectories    SEL
+	setAllowsMultipleSelection SEL
+	setCanCreateDirectories    SEL
+	setAllowedFileTypes        SEL
+	setDirectoryURL            SEL
+	setNameFieldStringValue    SEL
+	runModal                   SEL
+	URLs                       SEL
+	URL                        SEL
+
+	// NSAppearance (key-value observed on NSApplication)
+	effectiveAppearance    SEL
+	name                   SEL
+	addObserverForKeyPath  SEL
+	observeValueForKeyPath SEL
 
 	// NSScreen
-	mainScreen   SEL
-	screens      SEL
-	visibleFrame SEL
+	mainScreen        SEL
+	screens           SEL
+	visibleFrame      SEL
+	deviceDescription SEL
+	localizedName     SEL
+	colorSpace        SEL
+
+	// Screen change observer
+	screenParametersChanged SEL
+
+	// NSWorkspace sleep/wake observer
+	sharedWorkspace    SEL
+	notificationCenter SEL
+	workspaceWillSleep SEL
+	workspaceDidWake   SEL
+
+	// NSDictionary / NSNumber
+	objectForKey     SEL
+	unsignedIntValue SEL
 
 	// NSDate
 	distantPast   SEL
 	distantFuture SEL
 
+	dateWithTimeIntervalSinceNow SEL
+
 	// NSString
 	initWithUTF8String SEL
 	UTF8String         SEL
@@ -83,8 +274,22 @@ var selectors struct {
 	// NSAutoreleasePool
 	drain SEL
 
+	// NSPasteboard
+	generalPasteboard SEL
+	clearContents     SEL
+	setStringForType  SEL
+	stringForType     SEL
+	setDataForType    SEL
+	dataForType       SEL
+	changeCount       SEL
+
+	// NSData
+	dataWithBytesLength SEL
+	bytes               SEL
+
 	// CALayer / CAMetalLayer
 	setContentsScale        SEL
+	setContentsGravity      SEL
 	contentsScale           SEL
 	setDrawableSize         SEL
 	drawableSize            SEL
@@ -109,6 +314,11 @@ var selectors struct {
 	scrollingDeltaX             SEL
 	scrollingDeltaY             SEL
 	hasPreciseScrollingDeltas   SEL
+	magnification               SEL
+	rotation                    SEL
+	phase                       SEL
+	deltaX                      SEL
+	deltaY                      SEL
 
 	// NSNotificationCenter
 	defaultCenter                 SEL
@@ -134,7 +344,24 @@ var classes struct {
 	NSAutoreleasePool    Class
 	NSEvent              Class
 	NSNotificationCenter Class
+	NSWorkspace          Class
 	NSRunLoop            Class
+	NSPasteboard         Class
+	NSData               Class
+	NSArray              Class
+	NSURL                Class
+	NSMutableArray       Class
+	NSColor              Class
+	NSOpenPanel          Class
+	NSSavePanel          Class
+	NSAttributedString   Class
+	NSCursor             Class
+	NSImage              Class
+	NSBitmapImageRep     Class
+	NSMenu               Class
+	NSMenuItem           Class
+	NSProcessInfo        Class
+	NSThread             Class
 	CALayer              Class
 	CAMetalLayer         Class
 }
@@ -164,6 +391,54 @@ func initSelectors() {
 		// NSApplication delegate
 		selectors.setDelegate = RegisterSelector("setDelegate:")
 
+		// NSApplication standard actions
+		selectors.setMainMenu = RegisterSelector("setMainMenu:")
+		selectors.setWindowsMenu = RegisterSelector("setWindowsMenu:")
+		selectors.hide = RegisterSelector("hide:")
+		selectors.hideOtherApplications = RegisterSelector("hideOtherApplications:")
+		selectors.unhideAllApplications = RegisterSelector("unhideAllApplications:")
+		selectors.orderFrontStandardAboutPanel = RegisterSelector("orderFrontStandardAboutPanel:")
+		selectors.arrangeInFront = RegisterSelector("arrangeInFront:")
+
+		// NSApplication Dock tile
+		selectors.setApplicationIconImage = RegisterSelector("setApplicationIconImage:")
+		selectors.dockTile = RegisterSelector("dockTile")
+		selectors.setBadgeLabel = RegisterSelector("setBadgeLabel:")
+		selectors.requestUserAttention = RegisterSelector("requestUserAttention:")
+		selectors.cancelUserAttentionRequest = RegisterSelector("cancelUserAttentionRequest:")
+
+		// NSMenu / NSMenuItem
+		selectors.initWithTitle = RegisterSelector("initWithTitle:")
+		selectors.initWithTitleActionKeyEquivalent = RegisterSelector("initWithTitle:action:keyEquivalent:")
+		selectors.addItem = RegisterSelector("addItem:")
+		selectors.insertItemAtIndex = RegisterSelector("insertItem:atIndex:")
+		selectors.numberOfItems = RegisterSelector("numberOfItems")
+		selectors.setSubmenu = RegisterSelector("setSubmenu:")
+		selectors.separatorItem = RegisterSelector("separatorItem")
+		selectors.setTarget = RegisterSelector("setTarget:")
+		selectors.setTag = RegisterSelector("setTag:")
+		selectors.tag = RegisterSelector("tag")
+		selectors.setKeyEquivalentModifierMask = RegisterSelector("setKeyEquivalentModifierMask:")
+		selectors.menuItemSelected = RegisterSelector("menuItemSelected:")
+		selectors.terminateRequested = RegisterSelector("terminateRequested:")
+
+		// Responder chain edit/window actions
+		selectors.undo = RegisterSelector("undo:")
+		selectors.redo = RegisterSelector("redo:")
+		selectors.cut = RegisterSelector("cut:")
+		selectors.copy = RegisterSelector("copy:")
+		selectors.paste = RegisterSelector("paste:")
+		selectors.selectAll = RegisterSelector("selectAll:")
+		selectors.performMiniaturize = RegisterSelector("performMiniaturize:")
+		selectors.performZoom = RegisterSelector("performZoom:")
+
+		// NSProcessInfo
+		selectors.processInfo = RegisterSelector("processInfo")
+		selectors.processName = RegisterSelector("processName")
+
+		// NSThread
+		selectors.currentThread = RegisterSelector("currentThread")
+
 		// NSWindow
 		selectors.initWithContentRectStyleMaskBackingDefer = RegisterSelector(
 			"initWithContentRect:styleMask:backing:defer:")
@@ -178,6 +453,8 @@ func initSelectors() {
 		selectors.deminiaturize = RegisterSelector("deminiaturize:")
 		selectors.zoom = RegisterSelector("zoom:")
 		selectors.setFrame = RegisterSelector("setFrame:display:")
+		selectors.setContentSize = RegisterSelector("setContentSize:")
+		selectors.setFrameTopLeftPoint = RegisterSelector("setFrameTopLeftPoint:")
 		selectors.frame = RegisterSelector("frame")
 		selectors.contentRectForFrameRect = RegisterSelector("contentRectForFrameRect:")
 		selectors.frameRectForContentRect = RegisterSelector("frameRectForContentRect:")
@@ -191,6 +468,35 @@ func initSelectors() {
 		selectors.isZoomed = RegisterSelector("isZoomed")
 		selectors.setReleasedWhenClosed = RegisterSelector("setReleasedWhenClosed:")
 		selectors.center = RegisterSelector("center")
+		selectors.backingScaleFactor = RegisterSelector("backingScaleFactor")
+		selectors.screen = RegisterSelector("screen")
+		selectors.windowNumber = RegisterSelector("windowNumber")
+		selectors.occlusionState = RegisterSelector("occlusionState")
+		selectors.toggleFullScreen = RegisterSelector("toggleFullScreen:")
+		selectors.setCollectionBehavior = RegisterSelector("setCollectionBehavior:")
+		selectors.setTitlebarAppearsTransparent = RegisterSelector("setTitlebarAppearsTransparent:")
+		selectors.setTitleVisibility = RegisterSelector("setTitleVisibility:")
+		selectors.setOpaque = RegisterSelector("setOpaque:")
+		selectors.setBackgroundColor = RegisterSelector("setBackgroundColor:")
+		selectors.performWindowDragWithEvent = RegisterSelector("performWindowDragWithEvent:")
+
+		// NSColor
+		selectors.clearColor = RegisterSelector("clearColor")
+		selectors.colorWithAlphaComponent = RegisterSelector("colorWithAlphaComponent:")
+
+		// NSWindowDelegate
+		selectors.windowShouldClose = RegisterSelector("windowShouldClose:")
+		selectors.windowWillClose = RegisterSelector("windowWillClose:")
+		selectors.windowDidMiniaturize = RegisterSelector("windowDidMiniaturize:")
+		selectors.windowDidDeminiaturize = RegisterSelector("windowDidDeminiaturize:")
+		selectors.windowDidResize = RegisterSelector("windowDidResize:")
+		selectors.windowDidBecomeKey = RegisterSelector("windowDidBecomeKey:")
+		selectors.windowDidResignKey = RegisterSelector("windowDidResignKey:")
+		selectors.windowDidChangeOcclusionState = RegisterSelector("windowDidChangeOcclusionState:")
+		selectors.windowDidChangeScreen = RegisterSelector("windowDidChangeScreen:")
+		selectors.windowDidEnterFullScreen = RegisterSelector("windowDidEnterFullScreen:")
+		selectors.windowDidExitFullScreen = RegisterSelector("windowDidExitFullScreen:")
+		selectors.object = RegisterSelector("object")
 
 		// NSView
 		selectors.setWantsLayer = RegisterSelector("setWantsLayer:")
@@ -200,15 +506,128 @@ func initSelectors() {
 		selectors.bounds = RegisterSelector("bounds")
 		selectors.setBounds = RegisterSelector("setBounds:")
 		selectors.setNeedsDisplay = RegisterSelector("setNeedsDisplay:")
+		selectors.initWithFrame = RegisterSelector("initWithFrame:")
+		selectors.window = RegisterSelector("window")
+
+		// NSView live resize
+		selectors.viewWillStartLiveResize = RegisterSelector("viewWillStartLiveResize")
+		selectors.viewDidEndLiveResize = RegisterSelector("viewDidEndLiveResize")
+
+		// NSDraggingDestination / NSDraggingInfo
+		selectors.registerForDraggedTypes = RegisterSelector("registerForDraggedTypes:")
+		selectors.draggingEntered = RegisterSelector("draggingEntered:")
+		selectors.draggingUpdated = RegisterSelector("draggingUpdated:")
+		selectors.draggingExited = RegisterSelector("draggingExited:")
+		selectors.prepareForDragOperation = RegisterSelector("prepareForDragOperation:")
+		selectors.performDragOperation = RegisterSelector("performDragOperation:")
+		selectors.draggingPasteboard = RegisterSelector("draggingPasteboard")
+		selectors.draggingLocation = RegisterSelector("draggingLocation")
+		selectors.readObjectsForClassesOpt = RegisterSelector("readObjectsForClasses:options:")
+
+		// NSResponder
+		selectors.keyDown = RegisterSelector("keyDown:")
+		selectors.acceptsFirstResponder = RegisterSelector("acceptsFirstResponder")
+		selectors.interpretKeyEvents = RegisterSelector("interpretKeyEvents:")
+
+		// NSTextInputClient
+		selectors.insertText = RegisterSelector("insertText:replacementRange:")
+		selectors.doCommandBySelector = RegisterSelector("doCommandBySelector:")
+		selectors.setMarkedText = RegisterSelector("setMarkedText:selectedRange:replacementRange:")
+		selectors.unmarkText = RegisterSelector("unmarkText")
+		selectors.selectedRange = RegisterSelector("selectedRange")
+		selectors.markedRange = RegisterSelector("markedRange")
+		selectors.hasMarkedText = RegisterSelector("hasMarkedText")
+		selectors.attributedSubstringForRange = RegisterSelector("attributedSubstringForProposedRange:actualRange:")
+		selectors.validAttributesForMarkedText = RegisterSelector("validAttributesForMarkedText")
+		selectors.firstRectForCharacterRange = RegisterSelector("firstRectForCharacterRange:actualRange:")
+		selectors.characterIndexForPoint = RegisterSelector("characterIndexForPoint:")
+		selectors.isKindOfClass = RegisterSelector("isKindOfClass:")
+		selectors.string = RegisterSelector("string")
+
+		// NSCursor
+		selectors.arrowCursor = RegisterSelector("arrowCursor")
+		selectors.IBeamCursor = RegisterSelector("IBeamCursor")
+		selectors.crosshairCursor = RegisterSelector("crosshairCursor")
+		selectors.pointingHandCursor = RegisterSelector("pointingHandCursor")
+		selectors.resizeLeftRightCursor = RegisterSelector("resizeLeftRightCursor")
+		selectors.resizeUpDownCursor = RegisterSelector("resizeUpDownCursor")
+		selectors.openHandCursor = RegisterSelector("openHandCursor")
+		selectors.closedHandCursor = RegisterSelector("closedHandCursor")
+		selectors.operationNotAllowedCursor = RegisterSelector("operationNotAllowedCursor")
+		selectors.initWithImageHotSpot = RegisterSelector("initWithImage:hotSpot:")
+		selectors.set = RegisterSelector("set")
+		selectors.cursorHide = RegisterSelector("hide")
+		selectors.cursorUnhide = RegisterSelector("unhide")
+		selectors.resetCursorRects = RegisterSelector("resetCursorRects")
+		selectors.addCursorRect = RegisterSelector("addCursorRect:cursor:")
+		selectors.invalidateCursorRectsForView = RegisterSelector("invalidateCursorRectsForView:")
+		selectors.mouseLocationOutsideOfEventStream = RegisterSelector("mouseLocationOutsideOfEventStream")
+
+		// NSImage / NSBitmapImageRep
+		selectors.initWithSize = RegisterSelector("initWithSize:")
+		selectors.addRepresentation = RegisterSelector("addRepresentation:")
+		selectors.initWithBitmapDataPlanes = RegisterSelector(
+			"initWithBitmapDataPlanes:pixelsWide:pixelsHigh:bitsPerSample:samplesPerPixel:" +
+				"hasAlpha:isPlanar:colorSpaceName:bytesPerRow:bitsPerPixel:")
+		selectors.bitmapData = RegisterSelector("bitmapData")
+
+		// NSArray / NSURL
+		selectors.array = RegisterSelector("array")
+		selectors.arrayWithObject = RegisterSelector("arrayWithObject:")
+		selectors.count = RegisterSelector("count")
+		selectors.objectAtIndex = RegisterSelector("objectAtIndex:")
+		selectors.isFileURL = RegisterSelector("isFileURL")
+		selectors.path = RegisterSelector("path")
+		selectors.class = RegisterSelector("class")
+		selectors.addObject = RegisterSelector("addObject:")
+		selectors.fileURLWithPath = RegisterSelector("fileURLWithPath:")
+
+		// NSOpenPanel / NSSavePanel
+		selectors.openPanel = RegisterSelector("openPanel")
+		selectors.savePanel = RegisterSelector("savePanel")
+		selectors.setMessage = RegisterSelector("setMessage:")
+		selectors.setCanChooseFiles = RegisterSelector("setCanChooseFiles:")
+		selectors.setCanChooseDirectories = RegisterSelector("setCanChooseDirectories:")
+		selectors.setAllowsMultipleSelection = RegisterSelector("setAllowsMultipleSelection:")
+		selectors.setCanCreateDirectories = RegisterSelector("setCanCreateDirectories:")
+		selectors.setAllowedFileTypes = RegisterSelector("setAllowedFileTypes:")
+		selectors.setDirectoryURL = RegisterSelector("setDirectoryURL:")
+		selectors.setNameFieldStringValue = RegisterSelector("setNameFieldStringValue:")
+		selectors.runModal = RegisterSelector("runModal")
+		selectors.URLs = RegisterSelector("URLs")
+		selectors.URL = RegisterSelector("URL")
+
+		// NSAppearance (key-value observed on NSApplication)
+		selectors.effectiveAppearance = RegisterSelector("effectiveAppearance")
+		selectors.name = RegisterSelector("name")
+		selectors.addObserverForKeyPath = RegisterSelector("addObserver:forKeyPath:options:context:")
+		selectors.observeValueForKeyPath = RegisterSelector("observeValueForKeyPath:ofObject:change:context:")
 
 		// NSScreen
 		selectors.mainScreen = RegisterSelector("mainScreen")
 		selectors.screens = RegisterSelector("screens")
 		selectors.visibleFrame = RegisterSelector("visibleFrame")
+		selectors.deviceDescription = RegisterSelector("deviceDescription")
+		selectors.localizedName = RegisterSelector("localizedName")
+		selectors.colorSpace = RegisterSelector("colorSpace")
+
+		// Screen change observer
+		selectors.screenParametersChanged = RegisterSelector("screenParametersChanged:")
+
+		// NSWorkspace sleep/wake observer
+		selectors.sharedWorkspace = RegisterSelector("sharedWorkspace")
+		selectors.notificationCenter = RegisterSelector("notificationCenter")
+		selectors.workspaceWillSleep = RegisterSelector("workspaceWillSleep:")
+		selectors.workspaceDidWake = RegisterSelector("workspaceDidWake:")
+
+		// NSDictionary / NSNumber
+		selectors.objectForKey = RegisterSelector("objectForKey:")
+		selectors.unsignedIntValue = RegisterSelector("unsignedIntValue")
 
 		// NSDate
 		selectors.distantPast = RegisterSelector("distantPast")
 		selectors.distantFuture = RegisterSelector("distantFuture")
+		selectors.dateWithTimeIntervalSinceNow = RegisterSelector("dateWithTimeIntervalSinceNow:")
 
 		// NSString
 		selectors.initWithUTF8String = RegisterSelector("initWithUTF8String:")
@@ -218,8 +637,22 @@ func initSelectors() {
 		// NSAutoreleasePool
 		selectors.drain = RegisterSelector("drain")
 
+		// NSPasteboard
+		selectors.generalPasteboard = RegisterSelector("generalPasteboard")
+		selectors.clearContents = RegisterSelector("clearContents")
+		selectors.setStringForType = RegisterSelector("setString:forType:")
+		selectors.stringForType = RegisterSelector("stringForType:")
+		selectors.setDataForType = RegisterSelector("setData:forType:")
+		selectors.dataForType = RegisterSelector("dataForType:")
+		selectors.changeCount = RegisterSelector("changeCount")
+
+		// NSData
+		selectors.dataWithBytesLength = RegisterSelector("dataWithBytes:length:")
+		selectors.bytes = RegisterSelector("bytes")
+
 		// CALayer / CAMetalLayer
 		selectors.setContentsScale = RegisterSelector("setContentsScale:")
+		selectors.setContentsGravity = RegisterSelector("setContentsGravity:")
 		selectors.contentsScale = RegisterSelector("contentsScale")
 		selectors.setDrawableSize = RegisterSelector("setDrawableSize:")
 		selectors.drawableSize = RegisterSelector("drawableSize")
@@ -244,6 +677,11 @@ func initSelectors() {
 		selectors.scrollingDeltaX = RegisterSelector("scrollingDeltaX")
 		selectors.scrollingDeltaY = RegisterSelector("scrollingDeltaY")
 		selectors.hasPreciseScrollingDeltas = RegisterSelector("hasPreciseScrollingDeltas")
+		selectors.magnification = RegisterSelector("magnification")
+		selectors.rotation = RegisterSelector("rotation")
+		selectors.phase = RegisterSelector("phase")
+		selectors.deltaX = RegisterSelector("deltaX")
+		selectors.deltaY = RegisterSelector("deltaY")
 
 		// NSNotificationCenter
 		selectors.defaultCenter = RegisterSelector("defaultCenter")
@@ -270,7 +708,24 @@ func initClasses() {
 		classes.NSAutoreleasePool = GetClass("NSAutoreleasePool")
 		classes.NSEvent = GetClass("NSEvent")
 		classes.NSNotificationCenter = GetClass("NSNotificationCenter")
+		classes.NSWorkspace = GetClass("NSWorkspace")
 		classes.NSRunLoop = GetClass("NSRunLoop")
+		classes.NSPasteboard = GetClass("NSPasteboard")
+		classes.NSData = GetClass("NSData")
+		classes.NSArray = GetClass("NSArray")
+		classes.NSURL = GetClass("NSURL")
+		classes.NSMutableArray = GetClass("NSMutableArray")
+		classes.NSColor = GetClass("NSColor")
+		classes.NSOpenPanel = GetClass("NSOpenPanel")
+		classes.NSSavePanel = GetClass("NSSavePanel")
+		classes.NSAttributedString = GetClass("NSAttributedString")
+		classes.NSCursor = GetClass("NSCursor")
+		classes.NSImage = GetClass("NSImage")
+		classes.NSBitmapImageRep = GetClass("NSBitmapImageRep")
+		classes.NSMenu = GetClass("NSMenu")
+		classes.NSMenuItem = GetClass("NSMenuItem")
+		classes.NSProcessInfo = GetClass("NSProcessInfo")
+		classes.NSThread = GetClass("NSThread")
 		classes.CALayer = GetClass("CALayer")
 		classes.CAMetalLayer = GetClass("CAMetalLayer")
 	})
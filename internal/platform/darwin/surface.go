@@ -128,6 +128,33 @@ func (l *MetalLayer) DrawableSize() (width, height int) {
 	return 0, 0
 }
 
+// SetContentsGravity sets how the drawable is positioned when its size
+// differs from the layer bounds, e.g. "topLeft" (kCAGravityTopLeft)
+// or "resize" (kCAGravityResize, the default, which stretches).
+func (l *MetalLayer) SetContentsGravity(gravity string) {
+	if l == nil || l.id.IsNil() {
+		return
+	}
+
+	str := NewNSString(gravity)
+	if str == nil {
+		return
+	}
+	defer str.Release()
+
+	l.id.SendPtr(selectors.setContentsGravity, str.ID().Ptr())
+}
+
+// SetOpaque sets whether the layer ignores the alpha of its contents.
+// CAMetalLayer is opaque by default; transparent windows need false.
+func (l *MetalLayer) SetOpaque(opaque bool) {
+	if l == nil || l.id.IsNil() {
+		return
+	}
+
+	l.id.SendBool(selectors.setOpaque, opaque)
+}
+
 // SetFramebufferOnly sets whether textures are used only for rendering.
 // Setting this to true may improve performance.
 func (l *MetalLayer) SetFramebufferOnly(framebufferOnly bool) {
@@ -172,8 +199,7 @@ func (l *MetalLayer) SetContentsScale(scale float64) {
 		return
 	}
 
-	// Send CGFloat (double) argument
-	l.id.SendUint(selectors.setContentsScale, uint64(scale))
+	l.id.SendDouble(selectors.setContentsScale, scale)
 }
 
 // NextDrawable returns the next available drawable.
@@ -249,6 +275,7 @@ func (d *MetalDrawable) Present() {
 type Surface struct {
 	layer  *MetalLayer
 	window *Window
+	scale  float64
 }
 
 // NewSurface creates a new Metal surface for the given window.
@@ -272,16 +299,29 @@ func NewSurface(window *Window) (*Surface, error) {
 	layer.SetFramebufferOnly(true)
 	layer.SetMaximumDrawableCount(3) // Triple buffering
 
+	// Pin the last frame to the top-left corner instead of stretching it
+	// while a live resize is ahead of the renderer; the window title bar
+	// stays put, so this matches how the content moves.
+	layer.SetContentsGravity("topLeft")
+
+	// A transparent window composites the frame's alpha with the desktop
+	layer.SetOpaque(!window.IsTransparent())
+
 	// Attach layer to window FIRST (before setting drawable size).
 	// This is the correct order for macOS - the layer must be attached
 	// to a view before setting drawable size, otherwise CAMetalLayer
 	// may report warnings about invalid dimensions.
 	window.SetMetalLayer(layer.ID())
 
-	// Now set drawable size. Get actual size from window.
+	// Match the layer to the window's backing scale so Retina
+	// displays render at full resolution instead of being upscaled.
+	scale := window.BackingScaleFactor()
+	layer.SetContentsScale(scale)
+
+	// Now set drawable size in pixels. Get actual size from window.
 	// If dimensions are still 0 (window not yet visible), skip -
 	// the size will be set correctly on first resize event.
-	width, height := window.Size()
+	width, height := window.FramebufferSize()
 	if width > 0 && height > 0 {
 		layer.SetDrawableSize(width, height)
 	}
@@ -289,6 +329,7 @@ func NewSurface(window *Window) (*Surface, error) {
 	return &Surface{
 		layer:  layer,
 		window: window,
+		scale:  scale,
 	}, nil
 }
 
@@ -305,7 +346,7 @@ func (s *Surface) LayerPtr() uintptr {
 	return s.layer.Ptr()
 }
 
-// Resize updates the surface size.
+// Resize updates the surface size in backing pixels.
 // Call this when the window is resized.
 func (s *Surface) Resize(width, height int) {
 	if s == nil || s.layer == nil {
@@ -328,12 +369,39 @@ func (s *Surface) UpdateSize() {
 
 	// Get actual window size and update layer
 	s.window.UpdateSize()
-	width, height := s.window.Size()
+	s.UpdateScale()
+	width, height := s.window.FramebufferSize()
 	if width > 0 && height > 0 {
 		s.layer.SetDrawableSize(width, height)
 	}
 }
 
+// Scale returns the contents scale currently applied to the layer.
+func (s *Surface) Scale() float64 {
+	if s == nil || s.scale <= 0 {
+		return 1.0
+	}
+	return s.scale
+}
+
+// UpdateScale applies the window's current backing scale factor to the layer.
+// Returns true if the scale changed, e.g. after the window moved between a
+// Retina and a non-Retina display. The caller should then resize the surface.
+func (s *Surface) UpdateScale() bool {
+	if s == nil || s.window == nil || s.layer == nil {
+		return false
+	}
+
+	scale := s.window.BackingScaleFactor()
+	if scale == s.scale {
+		return false
+	}
+
+	s.scale = scale
+	s.layer.SetContentsScale(scale)
+	return true
+}
+
 // AcquireDrawable acquires the next drawable for rendering.
 func (s *Surface) AcquireDrawable() (*MetalDrawable, error) {
 	if s == nil || s.layer == nil {
@@ -0,0 +1,235 @@
+//go:build darwin
+
+package darwin
+
+import "unicode/utf16"
+
+// TextInputEvent is text produced by the keyboard or an input method.
+//
+// While an input method or a dead key is composing, events have Composing
+// set and Text holds the whole marked (preedit) string, replacing the
+// previous one; an empty Text ends the composition without input.
+// Committed text arrives with Composing unset.
+type TextInputEvent struct {
+	Text      string
+	Composing bool
+
+	// Cursor is the caret position within a composing Text, in runes.
+	Cursor int
+}
+
+// nsNotFound is the NSNotFound constant (NSIntegerMax).
+const nsNotFound = 1<<63 - 1
+
+// textInputMethods returns the NSTextInputClient methods of the content
+// view, plus the NSResponder overrides that route key events through
+// -interpretKeyEvents: so that input methods and dead keys see them.
+//
+// NSRange arguments are passed as two integer registers, so they appear as
+// two uintptr parameters; trailing arguments a Go implementation does not
+// need are omitted, which is safe in the C calling convention.
+func textInputMethods() []classMethod {
+	return []classMethod{
+		// - (BOOL)acceptsFirstResponder
+		{selectors.acceptsFirstResponder, textViewAcceptsFirstResponder, "c@:"},
+		// - (void)keyDown:(NSEvent *)event
+		{selectors.keyDown, textViewKeyDown, "v@:@"},
+		// - (void)insertText:(id)string replacementRange:(NSRange)range
+		{selectors.insertText, textViewInsertText, "v@:@{_NSRange=QQ}"},
+		// - (void)doCommandBySelector:(SEL)selector
+		{selectors.doCommandBySelector, textViewDoCommandBySelector, "v@::"},
+		// - (void)setMarkedText:(id)string selectedRange:(NSRange)selected replacementRange:(NSRange)range
+		{selectors.setMarkedText, textViewSetMarkedText, "v@:@{_NSRange=QQ}{_NSRange=QQ}"},
+		// - (void)unmarkText
+		{selectors.unmarkText, textViewUnmarkText, "v@:"},
+		// - (BOOL)hasMarkedText
+		{selectors.hasMarkedText, textViewHasMarkedText, "c@:"},
+		// - (NSRange)markedRange
+		{selectors.markedRange, textViewMarkedRange, "{_NSRange=QQ}@:"},
+		// - (NSRange)selectedRange
+		{selectors.selectedRange, textViewSelectedRange, "{_NSRange=QQ}@:"},
+		// - (NSAttributedString *)attributedSubstringForProposedRange:(NSRange)range actualRange:(NSRangePointer)actual
+		{selectors.attributedSubstringForRange, textViewAttributedSubstring, "@@:{_NSRange=QQ}^{_NSRange=QQ}"},
+		// - (NSArray *)validAttributesForMarkedText
+		{selectors.validAttributesForMarkedText, textViewValidAttributes, "@@:"},
+		// - (NSRect)firstRectForCharacterRange:(NSRange)range actualRange:(NSRangePointer)actual
+		{selectors.firstRectForCharacterRange, textViewFirstRect, "{CGRect={CGPoint=dd}{CGSize=dd}}@:{_NSRange=QQ}^{_NSRange=QQ}"},
+		// - (NSUInteger)characterIndexForPoint:(NSPoint)point
+		{selectors.characterIndexForPoint, textViewCharacterIndex, "Q@:{CGPoint=dd}"},
+	}
+}
+
+// textViewAcceptsFirstResponder implements -acceptsFirstResponder.
+func textViewAcceptsFirstResponder(_, _ uintptr) uintptr {
+	return uintptr(YES)
+}
+
+// textViewKeyDown implements -keyDown: by handing the event to the
+// input context, which calls back insertText: or setMarkedText:.
+func textViewKeyDown(self, _, event uintptr) uintptr {
+	events := msgSend(ID(classes.NSArray), selectors.arrayWithObject, event)
+	ID(self).SendPtr(selectors.interpretKeyEvents, events.Ptr())
+	return 0
+}
+
+// textViewInsertText implements -insertText:replacementRange:.
+func textViewInsertText(self, _, text uintptr) uintptr {
+	a := GetApplication()
+	a.setMarkedText(ID(self), "")
+	a.handleTextInput(ID(self), TextInputEvent{Text: stringFromTextArg(ID(text))})
+	return 0
+}
+
+// textViewDoCommandBySelector implements -doCommandBySelector:.
+// Editing commands (arrows, delete, return) are left to key events;
+// handling it here stops NSResponder from beeping.
+func textViewDoCommandBySelector(_, _, _ uintptr) uintptr {
+	return 0
+}
+
+// textViewSetMarkedText implements -setMarkedText:selectedRange:replacementRange:.
+func textViewSetMarkedText(self, _, text, selectedLocation uintptr) uintptr {
+	str := stringFromTextArg(ID(text))
+
+	a := GetApplication()
+	a.setMarkedText(ID(self), str)
+	a.handleTextInput(ID(self), TextInputEvent{
+		Text:      str,
+		Composing: true,
+		Cursor:    runeIndex(str, int(selectedLocation)),
+	})
+	return 0
+}
+
+// textViewUnmarkText implements -unmarkText by committing the marked text.
+func textViewUnmarkText(self, _ uintptr) uintptr {
+	a := GetApplication()
+	str := a.markedTextOf(ID(self))
+	if str == "" {
+		return 0
+	}
+	a.setMarkedText(ID(self), "")
+	a.handleTextInput(ID(self), TextInputEvent{Text: str})
+	return 0
+}
+
+// textViewHasMarkedText implements -hasMarkedText.
+func textViewHasMarkedText(self, _ uintptr) uintptr {
+	if GetApplication().markedTextOf(ID(self)) != "" {
+		return uintptr(YES)
+	}
+	return uintptr(NO)
+}
+
+// textViewMarkedRange implements -markedRange.
+//
+// Go callbacks return a single register, so only the location of the
+// NSRange is set. The view holds no text besides the marked text, which
+// therefore always starts at 0; {NSNotFound, ...} means none.
+func textViewMarkedRange(self, _ uintptr) uintptr {
+	if GetApplication().markedTextOf(ID(self)) != "" {
+		return 0
+	}
+	return nsNotFound
+}
+
+// textViewSelectedRange implements -selectedRange. See textViewMarkedRange.
+func textViewSelectedRange(_, _ uintptr) uintptr {
+	return 0
+}
+
+// textViewAttributedSubstring implements
+// -attributedSubstringForProposedRange:actualRange:. The view has no
+// document text to offer the input method.
+func textViewAttributedSubstring(_, _ uintptr) uintptr {
+	return 0
+}
+
+// textViewValidAttributes implements -validAttributesForMarkedText.
+func textViewValidAttributes(_, _ uintptr) uintptr {
+	return uintptr(classes.NSArray.Send(selectors.array))
+}
+
+// textViewFirstRect implements -firstRectForCharacterRange:actualRange:.
+// Go callbacks cannot return structs, so the candidate window position is
+// not reported and input methods place it on their own.
+func textViewFirstRect(_, _ uintptr) uintptr {
+	return 0
+}
+
+// textViewCharacterIndex implements -characterIndexForPoint:.
+func textViewCharacterIndex(_, _ uintptr) uintptr {
+	return nsNotFound
+}
+
+// stringFromTextArg converts the NSString or NSAttributedString passed to
+// the text input methods.
+func stringFromTextArg(text ID) string {
+	if text.IsNil() {
+		return ""
+	}
+	if isYes(text.SendPtr(selectors.isKindOfClass, classes.NSAttributedString.ClassPtr())) {
+		text = text.Send(selectors.string)
+	}
+	return goStringFromNSString(text)
+}
+
+// runeIndex converts a UTF-16 offset into str to a rune index.
+func runeIndex(str string, utf16Offset int) int {
+	if utf16Offset <= 0 {
+		return 0
+	}
+
+	index, units := 0, 0
+	for _, r := range str {
+		if units >= utf16Offset {
+			break
+		}
+		units += utf16.RuneLen(r)
+		index++
+	}
+	return index
+}
+
+// SetTextInputHandler sets a callback for text typed into a window,
+// including input method composition. The handler receives the window,
+// or nil if the view does not belong to a window created by this package.
+// It runs on the main thread from PollEvents/WaitEvents.
+func (a *Application) SetTextInputHandler(handler func(*Window, TextInputEvent)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onTextInput = handler
+}
+
+// handleTextInput is called by the GoGPUContentView text input methods.
+func (a *Application) handleTextInput(view ID, event TextInputEvent) {
+	a.mu.Lock()
+	onTextInput := a.onTextInput
+	a.mu.Unlock()
+
+	if onTextInput != nil {
+		onTextInput(a.windowForView(view), event)
+	}
+}
+
+// markedTextOf returns the current marked text of a content view.
+func (a *Application) markedTextOf(view ID) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.markedText[view]
+}
+
+// setMarkedText stores the marked text of a content view; "" clears it.
+func (a *Application) setMarkedText(view ID, text string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if text == "" {
+		delete(a.markedText, view)
+		return
+	}
+	if a.markedText == nil {
+		a.markedText = make(map[ID]string)
+	}
+	a.markedText[view] = text
+}
@@ -79,6 +79,36 @@ const (
 	NSWindowStyleMaskFullSizeContentView NSWindowStyleMask = 1 << 15
 )
 
+// NSWindowTitleVisibility specifies whether the window title text is shown.
+type NSWindowTitleVisibility NSInteger
+
+// Title visibility values.
+const (
+	// NSWindowTitleVisible shows the title text (the default).
+	NSWindowTitleVisible NSWindowTitleVisibility = 0
+
+	// NSWindowTitleHidden hides the title text but keeps the title bar.
+	NSWindowTitleHidden NSWindowTitleVisibility = 1
+)
+
+// NSWindowCollectionBehavior specifies how a window takes part in
+// Spaces, Exposé and fullscreen.
+type NSWindowCollectionBehavior NSUInteger
+
+// Window collection behavior values.
+const (
+	// NSWindowCollectionBehaviorManaged makes the window take part in Spaces and Exposé.
+	NSWindowCollectionBehaviorManaged NSWindowCollectionBehavior = 1 << 2
+
+	// NSWindowCollectionBehaviorFullScreenPrimary lets the window enter
+	// fullscreen in its own Space.
+	NSWindowCollectionBehaviorFullScreenPrimary NSWindowCollectionBehavior = 1 << 7
+
+	// NSWindowCollectionBehaviorFullScreenAuxiliary lets the window appear
+	// in the Space of another window's fullscreen.
+	NSWindowCollectionBehaviorFullScreenAuxiliary NSWindowCollectionBehavior = 1 << 8
+)
+
 // NSBackingStoreType specifies how the window buffer is stored.
 type NSBackingStoreType NSUInteger
 
@@ -112,6 +142,38 @@ const (
 	NSEventTypeKeyUp          NSEventType = 11
 	NSEventTypeFlagsChanged   NSEventType = 12
 	NSEventTypeScrollWheel    NSEventType = 22
+	NSEventTypeRotate         NSEventType = 18
+	NSEventTypeBeginGesture   NSEventType = 19
+	NSEventTypeEndGesture     NSEventType = 20
+	NSEventTypeMagnify        NSEventType = 30
+	NSEventTypeSwipe          NSEventType = 31
+	NSEventTypeSmartMagnify   NSEventType = 32
+)
+
+// NSEventPhase describes the phase of a continuous gesture or scroll.
+// The values are bit flags, but AppKit reports exactly one per event.
+type NSEventPhase NSUInteger
+
+// Event phases.
+const (
+	NSEventPhaseNone       NSEventPhase = 0
+	NSEventPhaseBegan      NSEventPhase = 1 << 0
+	NSEventPhaseStationary NSEventPhase = 1 << 1
+	NSEventPhaseChanged    NSEventPhase = 1 << 2
+	NSEventPhaseEnded      NSEventPhase = 1 << 3
+	NSEventPhaseCancelled  NSEventPhase = 1 << 4
+	NSEventPhaseMayBegin   NSEventPhase = 1 << 5
+)
+
+// NSEventModifierFlags is a bitmask of modifier keys.
+type NSEventModifierFlags NSUInteger
+
+// Modifier flags.
+const (
+	NSEventModifierFlagShift   NSEventModifierFlags = 1 << 17
+	NSEventModifierFlagControl NSEventModifierFlags = 1 << 18
+	NSEventModifierFlagOption  NSEventModifierFlags = 1 << 19
+	NSEventModifierFlagCommand NSEventModifierFlags = 1 << 20
 )
 
 // NSApplicationActivationPolicy specifies how an app is activated.
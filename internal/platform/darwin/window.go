@@ -5,6 +5,7 @@ package darwin
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
 )
 
 // Errors returned by Window operations.
@@ -20,6 +21,26 @@ type WindowConfig struct {
 	Height     int
 	Resizable  bool
 	Fullscreen bool
+
+	// TransparentTitlebar draws the title bar without its background and
+	// separator. Combine with FullSizeContentView for custom chrome.
+	TransparentTitlebar bool
+
+	// FullSizeContentView extends the content view, and the rendered
+	// frame, under the title bar. The window buttons stay on top.
+	FullSizeContentView bool
+
+	// HideTitle hides the title text; the window keeps its title for
+	// the Window menu and Mission Control.
+	HideTitle bool
+
+	// Transparent makes the window non-opaque, so the desktop shows
+	// through wherever the rendered frame has alpha below 1.
+	Transparent bool
+
+	// BackgroundAlpha is the alpha of the window background behind the
+	// content of a Transparent window. The default 0 is fully clear.
+	BackgroundAlpha float64
 }
 
 // Window represents an NSWindow with its content view.
@@ -32,6 +53,16 @@ type Window struct {
 	height      int
 	shouldClose bool
 	visible     bool
+	transparent bool
+
+	// Cursor over the content view (retained), applied via cursor rects
+	cursor       ID
+	cursorHidden bool
+
+	// Updated from the delegate and the content view, which may run
+	// inside calls that hold mu
+	zoomed     atomic.Bool
+	liveResize atomic.Bool
 }
 
 // NewWindow creates a new window with the given configuration.
@@ -40,8 +71,9 @@ func NewWindow(config WindowConfig) (*Window, error) {
 	initClasses()
 
 	w := &Window{
-		width:  config.Width,
-		height: config.Height,
+		width:       config.Width,
+		height:      config.Height,
+		transparent: config.Transparent,
 	}
 
 	// Calculate style mask
@@ -49,6 +81,9 @@ func NewWindow(config WindowConfig) (*Window, error) {
 	if config.Resizable {
 		styleMask |= NSWindowStyleMaskResizable
 	}
+	if config.FullSizeContentView {
+		styleMask |= NSWindowStyleMaskFullSizeContentView
+	}
 
 	// Create content rect
 	rect := MakeRect(0, 0, CGFloat(config.Width), CGFloat(config.Height))
@@ -82,6 +117,17 @@ func NewWindow(config WindowConfig) (*Window, error) {
 		}
 	}
 
+	applyWindowStyle(nsWindow, config)
+
+	// Replace the default content view with one that accepts file drops
+	// and text input, and make it first responder so it receives key events.
+	// The window retains its content view, so our reference is released.
+	if view := newContentView(rect); !view.IsNil() {
+		nsWindow.SendPtr(selectors.setContentView, view.Ptr())
+		nsWindow.SendPtr(selectors.makeFirstResponder, view.Ptr())
+		view.Send(selectors.release)
+	}
+
 	// Get content view
 	w.contentView = nsWindow.Send(selectors.contentView)
 	if w.contentView.IsNil() {
@@ -91,15 +137,55 @@ func NewWindow(config WindowConfig) (*Window, error) {
 	// Enable mouse events
 	nsWindow.SendBool(selectors.setAcceptsMouseMovedEvents, true)
 
+	// Allow native fullscreen in its own Space (the green title bar button).
+	// A fixed-size window can still be toggled with SetFullscreen.
+	nsWindow.SendUint(selectors.setCollectionBehavior,
+		uint64(NSWindowCollectionBehaviorManaged|NSWindowCollectionBehaviorFullScreenPrimary))
+
+	// Receive close, minimize, focus and occlusion changes
+	if delegate := windowDelegateObject(); !delegate.IsNil() {
+		nsWindow.SendPtr(selectors.setDelegate, delegate.Ptr())
+	}
+
 	// Don't release when closed (we manage lifecycle)
 	nsWindow.SendBool(selectors.setReleasedWhenClosed, false)
 
 	// Center window on screen
 	nsWindow.Send(selectors.center)
 
+	// Register for event routing
+	GetApplication().addWindow(w)
+
 	return w, nil
 }
 
+// applyWindowStyle applies the title bar and transparency options.
+func applyWindowStyle(nsWindow ID, config WindowConfig) {
+	if config.TransparentTitlebar {
+		nsWindow.SendBool(selectors.setTitlebarAppearsTransparent, true)
+	}
+	if config.HideTitle {
+		nsWindow.SendInt(selectors.setTitleVisibility, int64(NSWindowTitleHidden))
+	}
+
+	if config.Transparent {
+		nsWindow.SendBool(selectors.setOpaque, false)
+
+		alpha := min(max(config.BackgroundAlpha, 0), 1)
+		color := classes.NSColor.Send(selectors.clearColor).
+			SendDouble(selectors.colorWithAlphaComponent, alpha)
+		nsWindow.SendPtr(selectors.setBackgroundColor, color.Ptr())
+	}
+}
+
+// IsTransparent reports whether the window was created non-opaque.
+func (w *Window) IsTransparent() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.transparent
+}
+
 // Show makes the window visible and brings it to front.
 func (w *Window) Show() {
 	w.mu.Lock()
@@ -131,14 +217,17 @@ func (w *Window) Hide() {
 // Close closes the window.
 func (w *Window) Close() {
 	w.mu.Lock()
-	defer w.mu.Unlock()
+	nsWindow := w.nsWindow
+	w.shouldClose = true
+	w.mu.Unlock()
 
-	if w.nsWindow.IsNil() {
+	if nsWindow.IsNil() {
 		return
 	}
 
-	w.nsWindow.Send(selectors.close)
-	w.shouldClose = true
+	// Sent without w.mu held: the delegate's windowWillClose: sets
+	// the close flag again through SetShouldClose
+	nsWindow.Send(selectors.close)
 }
 
 // SetTitle sets the window title.
@@ -157,7 +246,7 @@ func (w *Window) SetTitle(title string) {
 	}
 }
 
-// Size returns the current content size of the window.
+// Size returns the current content size of the window in points.
 func (w *Window) Size() (width, height int) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -165,31 +254,96 @@ func (w *Window) Size() (width, height int) {
 	return w.width, w.height
 }
 
-// SetSize sets the window content size.
-func (w *Window) SetSize(width, height int) {
+// BackingScaleFactor returns the ratio of backing pixels to points
+// for the screen the window is currently on (2.0 on Retina displays).
+// Returns 1.0 if the window is not available.
+func (w *Window) BackingScaleFactor() float64 {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	return w.backingScaleFactor()
+}
+
+// backingScaleFactor queries the scale factor. Caller must hold w.mu.
+func (w *Window) backingScaleFactor() float64 {
 	if w.nsWindow.IsNil() {
+		return 1.0
+	}
+
+	scale := w.nsWindow.GetDouble(selectors.backingScaleFactor)
+	if scale <= 0 {
+		return 1.0
+	}
+	return scale
+}
+
+// FramebufferSize returns the content size in backing pixels.
+// This is the size the Metal drawable should have.
+func (w *Window) FramebufferSize() (width, height int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	scale := w.backingScaleFactor()
+	return int(float64(w.width)*scale + 0.5), int(float64(w.height)*scale + 0.5)
+}
+
+// DisplayID returns the CGDirectDisplayID of the screen the window is on,
+// or 0 if the window is not on any screen (e.g. fully offscreen).
+func (w *Window) DisplayID() uint32 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.nsWindow.IsNil() {
+		return 0
+	}
+
+	screen := w.nsWindow.Send(selectors.screen)
+	if screen.IsNil() {
+		return 0
+	}
+	return screenDisplayID(screen)
+}
+
+// SetSize sets the window content size in points, keeping the top-left
+// corner of the window in place.
+func (w *Window) SetSize(width, height int) {
+	w.mu.Lock()
+	nsWindow := w.nsWindow
+	w.mu.Unlock()
+
+	if nsWindow.IsNil() {
 		return
 	}
 
-	w.width = width
-	w.height = height
+	// The frame origin is its bottom-left corner
+	frame := nsWindow.GetRect(selectors.frame)
+	topLeft := NSPoint{X: frame.Origin.X, Y: frame.Origin.Y + frame.Size.Height}
 
-	// Get current frame
-	frame := w.nsWindow.GetRect(selectors.frame)
+	// Sent without w.mu held: the resize handlers run synchronously and
+	// query the window. The cached size is refreshed by UpdateSize.
+	nsWindow.SendSize(selectors.setContentSize, NSSize{Width: CGFloat(width), Height: CGFloat(height)})
+	nsWindow.SendPoint(selectors.setFrameTopLeftPoint, topLeft)
+}
 
-	// Create new frame with updated size
-	newFrame := MakeRect(
-		frame.Origin.X,
-		frame.Origin.Y,
-		CGFloat(width),
-		CGFloat(height),
-	)
+// SetPosition moves the top-left corner of the window frame to x, y in
+// points from the top-left corner of the primary display, y down.
+func (w *Window) SetPosition(x, y int) {
+	w.mu.Lock()
+	nsWindow := w.nsWindow
+	w.mu.Unlock()
 
-	// Set frame with display
-	w.nsWindow.SendRect(selectors.setFrame, newFrame)
+	if nsWindow.IsNil() {
+		return
+	}
+
+	// Cocoa screen coordinates start at the bottom-left corner of the
+	// primary display, y up
+	primary := classes.NSScreen.Send(selectors.screens).SendUint(selectors.objectAtIndex, 0)
+	if primary.IsNil() {
+		return
+	}
+	primaryHeight := primary.GetRect(selectors.frame).Size.Height
+	nsWindow.SendPoint(selectors.setFrameTopLeftPoint, NSPoint{X: CGFloat(x), Y: primaryHeight - CGFloat(y)})
 }
 
 // ShouldClose returns true if the window should close.
@@ -248,8 +402,32 @@ func (w *Window) ViewHandle() uintptr {
 	return w.contentView.Ptr()
 }
 
+// updateZoomed refreshes the cached zoomed state of nsWindow and reports
+// whether it changed. It does not lock w.mu, so the delegate may call it
+// from inside any window call.
+func (w *Window) updateZoomed(nsWindow ID) (zoomed, changed bool) {
+	zoomed = isYes(nsWindow.Send(selectors.isZoomed))
+	return zoomed, w.zoomed.Swap(zoomed) != zoomed
+}
+
+// Number returns the window server number of the window, which
+// identifies it in NSEvent.windowNumber. Returns 0 if not available.
+func (w *Window) Number() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.nsWindow.IsNil() {
+		return 0
+	}
+
+	return int64(w.nsWindow.Send(selectors.windowNumber))
+}
+
 // Destroy releases window resources.
 func (w *Window) Destroy() {
+	GetApplication().releaseMouseCapture(w)
+	GetApplication().removeWindow(w)
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
@@ -258,7 +436,13 @@ func (w *Window) Destroy() {
 		w.metalLayer = 0
 	}
 
+	if w.cursor != 0 {
+		w.cursor.Send(selectors.release)
+		w.cursor = 0
+	}
+
 	if w.nsWindow != 0 {
+		w.nsWindow.SendPtr(selectors.setDelegate, 0)
 		w.nsWindow.Send(selectors.close)
 		w.nsWindow.Send(selectors.release)
 		w.nsWindow = 0
@@ -372,14 +556,50 @@ func (w *Window) Deminiaturize() {
 
 // Zoom toggles the window zoom state.
 func (w *Window) Zoom() {
+	w.mu.Lock()
+	nsWindow := w.nsWindow
+	w.mu.Unlock()
+
+	if nsWindow.IsNil() {
+		return
+	}
+
+	// Resizes synchronously; see SetSize
+	nsWindow.SendPtr(selectors.zoom, 0)
+}
+
+// SetFullscreen enters or leaves native fullscreen, which moves the window
+// to its own Space with an animation. The transition is asynchronous:
+// WindowDidEnterFullScreen or WindowDidExitFullScreen is sent when it ends,
+// and the window is resized along the way.
+func (w *Window) SetFullscreen(fullscreen bool) {
+	if w.IsFullscreen() == fullscreen {
+		return
+	}
+
+	w.mu.Lock()
+	nsWindow := w.nsWindow
+	w.mu.Unlock()
+
+	if nsWindow.IsNil() {
+		return
+	}
+
+	// Sent without w.mu held; see SetSize
+	nsWindow.SendPtr(selectors.toggleFullScreen, 0)
+}
+
+// IsFullscreen reports whether the window is in native fullscreen.
+func (w *Window) IsFullscreen() bool {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
 	if w.nsWindow.IsNil() {
-		return
+		return false
 	}
 
-	w.nsWindow.SendPtr(selectors.zoom, 0)
+	styleMask := NSWindowStyleMask(w.nsWindow.Send(selectors.styleMask))
+	return styleMask&NSWindowStyleMaskFullScreen != 0
 }
 
 // IsMiniaturized returns true if the window is minimized.
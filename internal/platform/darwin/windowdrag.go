@@ -0,0 +1,47 @@
+//go:build darwin
+
+package darwin
+
+// trackMouseDown keeps the left mouse down event while its button is held.
+func (a *Application) trackMouseDown(event ID) {
+	switch NSEventType(event.Send(selectors.eventType)) {
+	case NSEventTypeLeftMouseDown:
+		event.Send(selectors.retain)
+	case NSEventTypeLeftMouseUp:
+		event = 0
+	default:
+		return
+	}
+
+	a.mu.Lock()
+	prev := a.mouseDown
+	a.mouseDown = event
+	a.mu.Unlock()
+
+	if !prev.IsNil() {
+		prev.Send(selectors.release)
+	}
+}
+
+// BeginWindowDrag lets the user move the window that received the held
+// left mouse button, as if its title bar had been pressed, with
+// -[NSWindow performWindowDragWithEvent:]. AppKit tracks the mouse until
+// the button is released. Returns false if the left button is not held.
+func (a *Application) BeginWindowDrag() bool {
+	a.mu.Lock()
+	event := a.mouseDown
+	a.mouseDown = 0
+	a.mu.Unlock()
+
+	if event.IsNil() {
+		return false
+	}
+	defer event.Send(selectors.release)
+
+	window := event.Send(selectors.window)
+	if window.IsNil() {
+		return false
+	}
+	window.SendPtr(selectors.performWindowDragWithEvent, event.Ptr())
+	return true
+}
@@ -0,0 +1,128 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// DPI constants
+const (
+	wmDpiChanged = 0x02E0
+
+	// USER_DEFAULT_SCREEN_DPI: the DPI at a scale factor of 1
+	defaultDPI = 96
+
+	// DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2, a pseudo handle of -4
+	dpiAwarenessContextPerMonitorAwareV2 = ^uintptr(3)
+
+	// PROCESS_PER_MONITOR_DPI_AWARE for SetProcessDpiAwareness
+	processPerMonitorDPIAware = 2
+
+	swpNoMove     = 0x0002
+	swpNoZOrder   = 0x0004
+	swpNoActivate = 0x0010
+)
+
+var (
+	shcore = windows.NewLazyDLL("shcore.dll")
+
+	procSetProcessDpiAwarenessContext = user32.NewProc("SetProcessDpiAwarenessContext")
+	procSetProcessDpiAwareness        = shcore.NewProc("SetProcessDpiAwareness")
+	procSetProcessDPIAware            = user32.NewProc("SetProcessDPIAware")
+	procGetDpiForWindow               = user32.NewProc("GetDpiForWindow")
+	procAdjustWindowRectExForDpi      = user32.NewProc("AdjustWindowRectExForDpi")
+	procAdjustWindowRectEx            = user32.NewProc("AdjustWindowRectEx")
+	procSetWindowPos                  = user32.NewProc("SetWindowPos")
+)
+
+// enableDPIAwareness makes the process per-monitor DPI aware, so Windows
+// does not bitmap-stretch the window on high DPI monitors, and sends
+// WM_DPICHANGED when the window moves to a monitor with another DPI.
+// Per-monitor v2 (Windows 10 1703) also scales the non-client area; older
+// systems fall back to per-monitor v1 (8.1) or system awareness (Vista).
+// The application manifest, if it declares an awareness, takes precedence.
+func enableDPIAwareness() {
+	if procSetProcessDpiAwarenessContext.Find() == nil {
+		ret, _, _ := procSetProcessDpiAwarenessContext.Call(dpiAwarenessContextPerMonitorAwareV2)
+		if ret != 0 {
+			return
+		}
+	}
+	if procSetProcessDpiAwareness.Find() == nil {
+		ret, _, _ := procSetProcessDpiAwareness.Call(processPerMonitorDPIAware)
+		if ret == 0 { // S_OK
+			return
+		}
+	}
+	if procSetProcessDPIAware.Find() == nil {
+		procSetProcessDPIAware.Call()
+	}
+}
+
+// windowDPI returns the DPI of the monitor the window is on.
+func windowDPI(hwnd windows.HWND) uint32 {
+	if procGetDpiForWindow.Find() != nil {
+		return defaultDPI
+	}
+	dpi, _, _ := procGetDpiForWindow.Call(uintptr(hwnd))
+	if dpi == 0 {
+		return defaultDPI
+	}
+	return uint32(dpi)
+}
+
+// scaleToDPI scales a size at 96 DPI to dpi.
+func scaleToDPI(size int, dpi uint32) int {
+	return (size*int(dpi) + defaultDPI/2) / defaultDPI
+}
+
+// setClientSize resizes a window so its client area has the given size
+// in pixels, keeping its position.
+func setClientSize(hwnd windows.HWND, width, height int, style uintptr, dpi uint32) {
+	r := rect{right: int32(width), bottom: int32(height)}
+	if procAdjustWindowRectExForDpi.Find() == nil {
+		procAdjustWindowRectExForDpi.Call(uintptr(unsafe.Pointer(&r)), style, 0, 0, uintptr(dpi))
+	} else {
+		procAdjustWindowRectEx.Call(uintptr(unsafe.Pointer(&r)), style, 0, 0)
+	}
+
+	procSetWindowPos.Call(uintptr(hwnd), 0, 0, 0,
+		uintptr(r.right-r.left), uintptr(r.bottom-r.top),
+		swpNoMove|swpNoZOrder|swpNoActivate)
+}
+
+// handleDPIChanged applies the window rectangle Windows suggests for the
+// new DPI, which keeps the window's logical size, and reports the new
+// scale. The size change itself arrives as WM_SIZE.
+func (p *windowsPlatform) handleDPIChanged(wParam, lParam uintptr) {
+	dpi := uint32(wParam & 0xFFFF)
+	// lParam points to the suggested RECT, owned by the system
+	suggested := *(**rect)(unsafe.Pointer(&lParam))
+	procSetWindowPos.Call(uintptr(p.hwnd), 0,
+		uintptr(suggested.left), uintptr(suggested.top),
+		uintptr(suggested.right-suggested.left), uintptr(suggested.bottom-suggested.top),
+		swpNoZOrder|swpNoActivate)
+
+	p.eventMu.Lock()
+	changed := dpi != p.dpi
+	p.dpi = dpi
+	p.eventMu.Unlock()
+
+	if changed {
+		p.queueEvent(Event{Type: EventScale, Scale: float64(dpi) / defaultDPI})
+	}
+}
+
+// ScaleFactor returns the DPI of the window's monitor relative to 96 DPI.
+func (p *windowsPlatform) ScaleFactor() float64 {
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+
+	if p.dpi == 0 {
+		return 1
+	}
+	return float64(p.dpi) / defaultDPI
+}
@@ -0,0 +1,121 @@
+//go:build linux
+
+package platform
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+)
+
+// dropTimeout bounds how long reading dropped file names waits for the
+// client dragging them.
+const dropTimeout = time.Second
+
+// setupDrop makes the window a drop target for files, offered by other
+// clients as text/uri-list.
+func (p *waylandPlatform) setupDrop(dataDevice *wayland.WlDataDevice) {
+	dataDevice.SetEnterHandler(func(event *wayland.DataDeviceEnterEvent) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		offer := event.Offer
+		if offer == nil {
+			return
+		}
+		if p.surface == nil || event.Surface != p.surface.ID() || !offer.HasMimeType(wayland.MimeTypeURIList) {
+			_ = offer.Accept(event.Serial, "")
+			return
+		}
+		_ = offer.Accept(event.Serial, wayland.MimeTypeURIList)
+		if p.dataDeviceVersion >= 3 {
+			_ = offer.SetActions(wayland.DndActionCopy, wayland.DndActionCopy)
+		}
+		p.dragging = true
+		p.dragX, p.dragY = event.X, event.Y
+		p.events = append(p.events, Event{Type: EventDrop, Drop: DropEvent{
+			Phase: DropPhaseEnter,
+			X:     event.X,
+			Y:     event.Y,
+		}})
+	})
+	dataDevice.SetMotionHandler(func(x, y float64) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.dragging {
+			p.dragX, p.dragY = x, y
+			p.events = append(p.events, Event{Type: EventDrop, Drop: DropEvent{Phase: DropPhaseOver, X: x, Y: y}})
+		}
+	})
+	dataDevice.SetLeaveHandler(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.dragging {
+			p.dragging = false
+			p.events = append(p.events, Event{Type: EventDrop, Drop: DropEvent{Phase: DropPhaseLeave}})
+		}
+	})
+	dataDevice.SetDropHandler(func(offer *wayland.WlDataOffer) {
+		p.mu.Lock()
+		dragging, x, y := p.dragging, p.dragX, p.dragY
+		p.dragging = false
+		p.mu.Unlock()
+
+		if !dragging || offer == nil {
+			if offer != nil {
+				_ = offer.Destroy()
+			}
+			return
+		}
+		// The dragging client writes the data only after this event is
+		// handled, so it is read without blocking the event loop
+		go p.receiveDrop(offer, x, y)
+	})
+}
+
+// receiveDrop reads the file names of a drop and queues the drop event.
+// A drop whose data cannot be read ends the drag like a leave.
+func (p *waylandPlatform) receiveDrop(offer *wayland.WlDataOffer, x, y float64) {
+	defer func() { _ = offer.Destroy() }()
+
+	paths, err := p.readDropPaths(offer)
+	if err == nil && p.dataDeviceVersion >= 3 {
+		_ = offer.Finish()
+	}
+
+	event := DropEvent{Phase: DropPhaseDrop, Paths: paths, X: x, Y: y}
+	if err != nil || len(paths) == 0 {
+		event = DropEvent{Phase: DropPhaseLeave}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, Event{Type: EventDrop, Drop: event})
+}
+
+// readDropPaths receives the text/uri-list of a drop offer and returns
+// its local file paths.
+func (p *waylandPlatform) readDropPaths(offer *wayland.WlDataOffer) ([]string, error) {
+	var fds [2]int
+	if err := unix.Pipe2(fds[:], unix.O_CLOEXEC|unix.O_NONBLOCK); err != nil {
+		return nil, err
+	}
+	r := os.NewFile(uintptr(fds[0]), "drop")
+	defer r.Close()
+	err := offer.Receive(wayland.MimeTypeURIList, fds[1])
+	_ = unix.Close(fds[1])
+	if err != nil {
+		return nil, err
+	}
+
+	_ = r.SetReadDeadline(time.Now().Add(dropTimeout))
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return wayland.FilePathsFromURIList(data), nil
+}
@@ -0,0 +1,89 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Drag-and-drop constants
+const (
+	wmDropFiles      = 0x0233
+	wmCopyData       = 0x004A
+	wmCopyGlobalData = 0x0049
+
+	msgfltAllow = 1
+)
+
+var (
+	shell32 = windows.NewLazyDLL("shell32.dll")
+
+	procDragAcceptFiles             = shell32.NewProc("DragAcceptFiles")
+	procDragQueryFileW              = shell32.NewProc("DragQueryFileW")
+	procDragQueryPoint              = shell32.NewProc("DragQueryPoint")
+	procDragFinish                  = shell32.NewProc("DragFinish")
+	procChangeWindowMessageFilterEx = user32.NewProc("ChangeWindowMessageFilterEx")
+)
+
+// point is the Win32 POINT structure.
+type point struct {
+	x, y int32
+}
+
+// acceptFileDrops lets Explorer and other applications drop files on the
+// window as WM_DROPFILES. An elevated process would not receive the drop
+// messages from a non-elevated Explorer, so they are let through the
+// message filter (Windows 7 and later).
+func (p *windowsPlatform) acceptFileDrops() {
+	procDragAcceptFiles.Call(uintptr(p.hwnd), 1)
+
+	if procChangeWindowMessageFilterEx.Find() == nil {
+		for _, message := range []uintptr{wmDropFiles, wmCopyData, wmCopyGlobalData} {
+			procChangeWindowMessageFilterEx.Call(uintptr(p.hwnd), message, msgfltAllow, 0)
+		}
+	}
+}
+
+// handleDropFiles queues the full paths of the files dropped on the window
+// and releases the drop handle.
+func (p *windowsPlatform) handleDropFiles(hdrop uintptr) {
+	defer procDragFinish.Call(hdrop)
+
+	paths := dropPaths(hdrop)
+	if len(paths) == 0 {
+		return
+	}
+
+	// Client coordinates of the drop
+	var pt point
+	procDragQueryPoint.Call(hdrop, uintptr(unsafe.Pointer(&pt)))
+
+	p.queueEvent(Event{
+		Type: EventDrop,
+		Drop: DropEvent{
+			Phase: DropPhaseDrop,
+			Paths: paths,
+			X:     float64(pt.x),
+			Y:     float64(pt.y),
+		},
+	})
+}
+
+// dropPaths returns the full paths of the files in a drop handle (HDROP).
+func dropPaths(hdrop uintptr) []string {
+	count, _, _ := procDragQueryFileW.Call(hdrop, 0xFFFFFFFF, 0, 0)
+	paths := make([]string, 0, count)
+	for i := uintptr(0); i < count; i++ {
+		// Length in characters, without the terminating NUL
+		length, _, _ := procDragQueryFileW.Call(hdrop, i, 0, 0)
+		if length == 0 {
+			continue
+		}
+		buf := make([]uint16, length+1)
+		procDragQueryFileW.Call(hdrop, i, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+		paths = append(paths, windows.UTF16ToString(buf))
+	}
+	return paths
+}
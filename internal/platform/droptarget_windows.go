@@ -0,0 +1,223 @@
+//go:build windows
+
+package platform
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// OLE drag-and-drop constants
+const (
+	cfHDrop         = 15 // CF_HDROP clipboard format
+	dvaspectContent = 1
+	tymedHGlobal    = 1
+
+	dropEffectNone = 0
+	dropEffectCopy = 1
+
+	eNoInterface = 0x80004002
+)
+
+var (
+	procOleInitialize    = ole32.NewProc("OleInitialize")
+	procOleUninitialize  = ole32.NewProc("OleUninitialize")
+	procRegisterDragDrop = ole32.NewProc("RegisterDragDrop")
+	procRevokeDragDrop   = ole32.NewProc("RevokeDragDrop")
+	procReleaseStgMedium = ole32.NewProc("ReleaseStgMedium")
+
+	iidIUnknown    = windows.GUID{Data1: 0x00000000, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	iidIDropTarget = windows.GUID{Data1: 0x00000122, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+
+	// Method table shared by all drop targets. The callbacks are created
+	// once, as their number is limited.
+	dropTargetMethods = dropTargetVtbl{
+		QueryInterface: windows.NewCallback(dropTargetQueryInterface),
+		AddRef:         windows.NewCallback(dropTargetAddRef),
+		Release:        windows.NewCallback(dropTargetRelease),
+		DragEnter:      windows.NewCallback(dropTargetDragEnter),
+		DragOver:       windows.NewCallback(dropTargetDragOver),
+		DragLeave:      windows.NewCallback(dropTargetDragLeave),
+		Drop:           windows.NewCallback(dropTargetDrop),
+	}
+)
+
+// dropTarget is a COM IDropTarget implemented in Go. OLE calls it on the
+// window's thread while files are dragged over the window, which allows
+// reporting the drag before the drop, unlike WM_DROPFILES.
+type dropTarget struct {
+	vtbl     *dropTargetVtbl
+	platform *windowsPlatform
+	accepted bool // The dragged data has files
+}
+
+// dropTargetVtbl is the IDropTarget method table.
+type dropTargetVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	DragEnter      uintptr
+	DragOver       uintptr
+	DragLeave      uintptr
+	Drop           uintptr
+}
+
+// dataObjectVtbl is the IDataObject method table up to QueryGetData.
+type dataObjectVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	GetData        uintptr
+	GetDataHere    uintptr
+	QueryGetData   uintptr
+}
+
+// formatEtc is the Win32 FORMATETC structure.
+type formatEtc struct {
+	cfFormat uint16
+	ptd      uintptr
+	dwAspect uint32
+	lindex   int32
+	tymed    uint32
+}
+
+// stgMedium is the Win32 STGMEDIUM structure.
+type stgMedium struct {
+	tymed          uint32
+	handle         uintptr // hGlobal for TYMED_HGLOBAL
+	pUnkForRelease uintptr
+}
+
+// hdropFormat asks a data object for files as an HDROP.
+var hdropFormat = formatEtc{cfFormat: cfHDrop, dwAspect: dvaspectContent, lindex: -1, tymed: tymedHGlobal}
+
+// registerDropTarget registers an OLE drop target for the window, so
+// drags are reported as they enter, move over and leave it. WM_DROPFILES
+// stays in place where that fails: in processes whose thread was already
+// initialized for the multithreaded COM model, and in elevated processes,
+// which OLE drags from non-elevated Explorer cannot reach.
+//
+// The drag position is passed by value as a POINTL, which is a single
+// argument to the callbacks only on 64-bit Windows.
+func (p *windowsPlatform) registerDropTarget() {
+	if unsafe.Sizeof(uintptr(0)) != 8 || windows.GetCurrentProcessToken().IsElevated() {
+		return
+	}
+	// S_FALSE if OLE is already initialized on the thread
+	if hr, _, _ := procOleInitialize.Call(0); int32(hr) < 0 {
+		return
+	}
+
+	target := &dropTarget{vtbl: &dropTargetMethods, platform: p}
+	if hr, _, _ := procRegisterDragDrop.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(target))); hr != 0 {
+		procOleUninitialize.Call()
+		return
+	}
+	p.dropTarget = target
+}
+
+// revokeDropTarget unregisters the window's drop target.
+func (p *windowsPlatform) revokeDropTarget() {
+	if p.dropTarget == nil {
+		return
+	}
+	procRevokeDragDrop.Call(uintptr(p.hwnd))
+	procOleUninitialize.Call()
+	p.dropTarget = nil
+}
+
+// dropEffect returns the drop effect of a drop target.
+func (t *dropTarget) dropEffect() uint32 {
+	if t.accepted {
+		return dropEffectCopy
+	}
+	return dropEffectNone
+}
+
+// dropPoint returns the client position of a POINTL passed by value.
+func (t *dropTarget) dropPoint(pt uintptr) (x, y float64) {
+	return t.platform.screenToClient(point{x: int32(pt), y: int32(uint64(pt) >> 32)}) //nolint:gosec // G115: POINTL halves
+}
+
+func dropTargetQueryInterface(this, riid, object uintptr) uintptr {
+	iid := *(**windows.GUID)(unsafe.Pointer(&riid))
+	if *iid != iidIUnknown && *iid != iidIDropTarget {
+		**(**uintptr)(unsafe.Pointer(&object)) = 0
+		return eNoInterface
+	}
+	**(**uintptr)(unsafe.Pointer(&object)) = this
+	return 0
+}
+
+// The drop target lives as long as the platform, which revokes it before
+// the last reference goes, so it is not reference counted.
+
+func dropTargetAddRef(this uintptr) uintptr {
+	return 1
+}
+
+func dropTargetRelease(this uintptr) uintptr {
+	return 1
+}
+
+func dropTargetDragEnter(this, dataObject, keyState, pt, effect uintptr) uintptr {
+	t := *(**dropTarget)(unsafe.Pointer(&this))
+	obj := **(***dataObjectVtbl)(unsafe.Pointer(&dataObject))
+	hr, _, _ := syscall.SyscallN(obj.QueryGetData, dataObject, uintptr(unsafe.Pointer(&hdropFormat)))
+	t.accepted = hr == 0
+
+	**(**uint32)(unsafe.Pointer(&effect)) = t.dropEffect()
+	if t.accepted {
+		x, y := t.dropPoint(pt)
+		t.platform.queueEvent(Event{Type: EventDrop, Drop: DropEvent{Phase: DropPhaseEnter, X: x, Y: y}})
+	}
+	return 0
+}
+
+func dropTargetDragOver(this, keyState, pt, effect uintptr) uintptr {
+	t := *(**dropTarget)(unsafe.Pointer(&this))
+	**(**uint32)(unsafe.Pointer(&effect)) = t.dropEffect()
+	if t.accepted {
+		x, y := t.dropPoint(pt)
+		t.platform.queueEvent(Event{Type: EventDrop, Drop: DropEvent{Phase: DropPhaseOver, X: x, Y: y}})
+	}
+	return 0
+}
+
+func dropTargetDragLeave(this uintptr) uintptr {
+	t := *(**dropTarget)(unsafe.Pointer(&this))
+	if t.accepted {
+		t.accepted = false
+		t.platform.queueEvent(Event{Type: EventDrop, Drop: DropEvent{Phase: DropPhaseLeave}})
+	}
+	return 0
+}
+
+func dropTargetDrop(this, dataObject, keyState, pt, effect uintptr) uintptr {
+	t := *(**dropTarget)(unsafe.Pointer(&this))
+	**(**uint32)(unsafe.Pointer(&effect)) = t.dropEffect()
+	if !t.accepted {
+		return 0
+	}
+	t.accepted = false
+
+	var paths []string
+	var medium stgMedium
+	obj := **(***dataObjectVtbl)(unsafe.Pointer(&dataObject))
+	if hr, _, _ := syscall.SyscallN(obj.GetData, dataObject,
+		uintptr(unsafe.Pointer(&hdropFormat)), uintptr(unsafe.Pointer(&medium))); hr == 0 {
+		paths = dropPaths(medium.handle)
+		procReleaseStgMedium.Call(uintptr(unsafe.Pointer(&medium)))
+	}
+
+	// Every enter ends with a leave or a drop
+	event := DropEvent{Phase: DropPhaseLeave}
+	if len(paths) > 0 {
+		x, y := t.dropPoint(pt)
+		event = DropEvent{Phase: DropPhaseDrop, Paths: paths, X: x, Y: y}
+	}
+	t.platform.queueEvent(Event{Type: EventDrop, Drop: event})
+	return 0
+}
@@ -0,0 +1,138 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Fullscreen constants
+const (
+	// GWL_STYLE, -16
+	gwlStyle = ^uintptr(15)
+
+	monitorDefaultToNearest = 2
+
+	swpFrameChanged  = 0x0020
+	swpNoOwnerZOrder = 0x0200
+	swpNoSize        = 0x0001
+)
+
+var (
+	procGetWindowLongPtrW  = user32.NewProc("GetWindowLongPtrW")
+	procSetWindowLongPtrW  = user32.NewProc("SetWindowLongPtrW")
+	procGetWindowLongW     = user32.NewProc("GetWindowLongW")
+	procSetWindowLongW     = user32.NewProc("SetWindowLongW")
+	procGetWindowPlacement = user32.NewProc("GetWindowPlacement")
+	procSetWindowPlacement = user32.NewProc("SetWindowPlacement")
+	procMonitorFromWindow  = user32.NewProc("MonitorFromWindow")
+	procGetMonitorInfoW    = user32.NewProc("GetMonitorInfoW")
+)
+
+// windowPlacement is the Win32 WINDOWPLACEMENT structure.
+type windowPlacement struct {
+	length           uint32
+	flags            uint32
+	showCmd          uint32
+	ptMinPosition    point
+	ptMaxPosition    point
+	rcNormalPosition rect
+}
+
+// monitorInfo is the Win32 MONITORINFO structure.
+type monitorInfo struct {
+	cbSize    uint32
+	rcMonitor rect
+	rcWork    rect
+	dwFlags   uint32
+}
+
+// windowStyle returns the window's GWL_STYLE.
+func (p *windowsPlatform) windowStyle() uintptr {
+	return windowStyle(p.hwnd)
+}
+
+// windowStyle returns the GWL_STYLE of hwnd. 32-bit user32 exports only
+// the non-Ptr variants.
+func windowStyle(hwnd windows.HWND) uintptr {
+	if procGetWindowLongPtrW.Find() == nil {
+		style, _, _ := procGetWindowLongPtrW.Call(uintptr(hwnd), gwlStyle)
+		return style
+	}
+	style, _, _ := procGetWindowLongW.Call(uintptr(hwnd), gwlStyle)
+	return style
+}
+
+// setWindowStyle replaces the window's GWL_STYLE.
+func (p *windowsPlatform) setWindowStyle(style uintptr) {
+	if procSetWindowLongPtrW.Find() == nil {
+		procSetWindowLongPtrW.Call(uintptr(p.hwnd), gwlStyle, style)
+		return
+	}
+	procSetWindowLongW.Call(uintptr(p.hwnd), gwlStyle, style)
+}
+
+// SetFullscreen switches between windowed and borderless fullscreen.
+//
+// Entering saves the window style and placement, removes the frame and
+// covers the monitor the window is on, taskbar included. A window covering
+// its monitor is presented without composition like exclusive fullscreen,
+// without a display mode change. Leaving restores the saved style and
+// placement, which brings back a maximized state as well. The size change
+// arrives as WM_SIZE and is reported as EventResize.
+func (p *windowsPlatform) SetFullscreen(fullscreen bool) error {
+	if fullscreen == p.IsFullscreen() {
+		return nil
+	}
+
+	if fullscreen {
+		monitor, _, _ := procMonitorFromWindow.Call(uintptr(p.hwnd), monitorDefaultToNearest)
+		info := monitorInfo{cbSize: uint32(unsafe.Sizeof(monitorInfo{}))}
+		if ret, _, _ := procGetMonitorInfoW.Call(monitor, uintptr(unsafe.Pointer(&info))); ret == 0 {
+			return ErrUnsupported
+		}
+
+		placement := windowPlacement{length: uint32(unsafe.Sizeof(windowPlacement{}))}
+		procGetWindowPlacement.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&placement)))
+		style := p.windowStyle()
+
+		p.eventMu.Lock()
+		p.savedPlacement = placement
+		p.savedStyle = style
+		p.fullscreen = true
+		p.eventMu.Unlock()
+
+		p.setWindowStyle(style &^ wsOverlappedWindow)
+		m := info.rcMonitor
+		procSetWindowPos.Call(uintptr(p.hwnd), 0,
+			uintptr(m.left), uintptr(m.top),
+			uintptr(m.right-m.left), uintptr(m.bottom-m.top),
+			swpNoOwnerZOrder|swpFrameChanged)
+
+		p.queueEvent(Event{Type: EventWindowState, State: WindowEnteredFullscreen})
+		return nil
+	}
+
+	p.eventMu.Lock()
+	placement, style := p.savedPlacement, p.savedStyle
+	p.fullscreen = false
+	p.eventMu.Unlock()
+
+	p.setWindowStyle(style)
+	procSetWindowPlacement.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&placement)))
+	// Recompute the frame for the restored style
+	procSetWindowPos.Call(uintptr(p.hwnd), 0, 0, 0, 0, 0,
+		swpNoMove|swpNoSize|swpNoZOrder|swpNoOwnerZOrder|swpFrameChanged)
+
+	p.queueEvent(Event{Type: EventWindowState, State: WindowExitedFullscreen})
+	return nil
+}
+
+// IsFullscreen reports whether the window is in borderless fullscreen.
+func (p *windowsPlatform) IsFullscreen() bool {
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+	return p.fullscreen
+}
@@ -0,0 +1,137 @@
+//go:build darwin
+
+package platform
+
+import (
+	"time"
+
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform/darwin"
+)
+
+// gamepadPollInterval limits how often GameController is polled.
+const gamepadPollInterval = 4 * time.Millisecond
+
+// darwinGamepad is a GameController controller assigned to a slot.
+type darwinGamepad struct {
+	id    darwin.ID // The GCController; 0 for an empty slot
+	state Gamepad
+}
+
+// pollGamepads samples the connected controllers and queues connection
+// changes. A controller keeps its slot while connected; new ones take
+// the first empty slot. Called from PollEvents with p.mu held.
+func (p *darwinPlatform) pollGamepads() {
+	now := time.Now()
+	if now.Sub(p.gamepadsPolled) < gamepadPollInterval {
+		return
+	}
+	p.gamepadsPolled = now
+
+	controllers, err := darwin.GameControllers()
+	if err != nil {
+		return
+	}
+
+	// Disconnected controllers free their slots first
+	for i := range p.gamepads {
+		slot := &p.gamepads[i]
+		if slot.id != 0 && !hasController(controllers, slot.id) {
+			*slot = darwinGamepad{}
+			p.events = append(p.events, Event{Type: EventGamepad, Gamepad: GamepadEvent{Slot: i}})
+		}
+	}
+
+	for _, c := range controllers {
+		i := p.gamepadSlot(c.ID)
+		if i < 0 {
+			continue // More controllers than slots
+		}
+		if p.gamepads[i].id == 0 {
+			p.gamepads[i].id = c.ID
+			p.events = append(p.events, Event{
+				Type:    EventGamepad,
+				Gamepad: GamepadEvent{Slot: i, Connected: true},
+			})
+		}
+		p.gamepads[i].state = gamepadFromController(&c)
+	}
+}
+
+// gamepadSlot returns the slot of a controller, or the first empty slot
+// for a new one, or -1 if all are taken.
+func (p *darwinPlatform) gamepadSlot(id darwin.ID) int {
+	free := -1
+	for i, slot := range p.gamepads {
+		if slot.id == id {
+			return i
+		}
+		if slot.id == 0 && free < 0 {
+			free = i
+		}
+	}
+	return free
+}
+
+// hasController reports whether id is among the controllers.
+func hasController(controllers []darwin.GameController, id darwin.ID) bool {
+	for _, c := range controllers {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// gamepadFromController converts a controller snapshot, flipping the
+// stick y axes to point down.
+func gamepadFromController(c *darwin.GameController) Gamepad {
+	pad := Gamepad{Name: c.Name}
+	pad.Buttons = [input.GamepadButtonCount]bool{
+		input.GamepadA:           c.A,
+		input.GamepadB:           c.B,
+		input.GamepadX:           c.X,
+		input.GamepadY:           c.Y,
+		input.GamepadLeftBumper:  c.LeftShoulder,
+		input.GamepadRightBumper: c.RightShoulder,
+		input.GamepadBack:        c.Options,
+		input.GamepadStart:       c.Menu,
+		input.GamepadGuide:       c.Home,
+		input.GamepadLeftThumb:   c.LeftThumb,
+		input.GamepadRightThumb:  c.RightThumb,
+		input.GamepadDpadUp:      c.Up,
+		input.GamepadDpadRight:   c.Right,
+		input.GamepadDpadDown:    c.Down,
+		input.GamepadDpadLeft:    c.Left,
+	}
+
+	pad.Axes[input.GamepadLeftX] = c.LeftX
+	pad.Axes[input.GamepadLeftY] = -c.LeftY
+	pad.Axes[input.GamepadRightX] = c.RightX
+	pad.Axes[input.GamepadRightY] = -c.RightY
+	pad.Axes[input.GamepadLeftTrigger] = c.LeftTrigger
+	pad.Axes[input.GamepadRightTrigger] = c.RightTrigger
+	return pad
+}
+
+// Gamepad returns the gamepad in slot as of the last PollEvents.
+func (p *darwinPlatform) Gamepad(slot int) (Gamepad, bool) {
+	if slot < 0 || slot >= len(p.gamepads) {
+		return Gamepad{}, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &p.gamepads[slot]
+	if s.id == 0 {
+		return Gamepad{}, false
+	}
+	return s.state, true
+}
+
+// SetGamepadRumble is not supported: GameController rumbles through Core
+// Haptics patterns rather than motor speeds.
+func (p *darwinPlatform) SetGamepadRumble(slot int, low, high float64) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,546 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/gogpu/gogpu/input"
+	"golang.org/x/sys/unix"
+)
+
+// Linux input event types and codes, from linux/input-event-codes.h
+const (
+	evSyn = 0x00
+	evKey = 0x01
+	evAbs = 0x03
+	evFF  = 0x15
+
+	synDropped = 3
+
+	btnSouth = 0x130 // BTN_A
+	btnTL2   = 0x138
+	btnTR2   = 0x139
+
+	absX     = 0x00
+	absY     = 0x01
+	absZ     = 0x02
+	absRX    = 0x03
+	absRY    = 0x04
+	absRZ    = 0x05
+	absHat0X = 0x10
+	absHat0Y = 0x11
+
+	ffRumble = 0x50
+
+	keyMax = 0x2ff
+	absMax = 0x3f
+	ffMax  = 0x7f
+)
+
+// Gamepad polling intervals. Input devices are rescanned for new
+// gamepads rarely, as that opens every device not known yet.
+const (
+	evdevPollInterval = 4 * time.Millisecond
+	evdevScanInterval = time.Second
+)
+
+// Dead zones, matching the ones XInput recommends for Xbox controllers.
+// Drivers report raw positions.
+const (
+	evdevStickDeadzone   = 0.24
+	evdevTriggerDeadzone = 0.12
+)
+
+// evdevButtons maps the key codes of the Linux gamepad API to gamepad
+// buttons. BTN_X and BTN_Y follow the xpad driver, which reports the
+// left face button as BTN_X.
+var evdevButtons = map[uint16]input.GamepadButton{
+	0x130: input.GamepadA,           // BTN_SOUTH
+	0x131: input.GamepadB,           // BTN_EAST
+	0x133: input.GamepadX,           // BTN_X
+	0x134: input.GamepadY,           // BTN_Y
+	0x136: input.GamepadLeftBumper,  // BTN_TL
+	0x137: input.GamepadRightBumper, // BTN_TR
+	0x13a: input.GamepadBack,        // BTN_SELECT
+	0x13b: input.GamepadStart,       // BTN_START
+	0x13c: input.GamepadGuide,       // BTN_MODE
+	0x13d: input.GamepadLeftThumb,   // BTN_THUMBL
+	0x13e: input.GamepadRightThumb,  // BTN_THUMBR
+	0x220: input.GamepadDpadUp,      // BTN_DPAD_UP
+	0x221: input.GamepadDpadDown,    // BTN_DPAD_DOWN
+	0x222: input.GamepadDpadLeft,    // BTN_DPAD_LEFT
+	0x223: input.GamepadDpadRight,   // BTN_DPAD_RIGHT
+}
+
+// evdevInputEvent is the Linux struct input_event.
+type evdevInputEvent struct {
+	time  unix.Timeval
+	typ   uint16
+	code  uint16
+	value int32
+}
+
+// evdevAbsInfo is the Linux struct input_absinfo.
+type evdevAbsInfo struct {
+	value      int32
+	minimum    int32
+	maximum    int32
+	fuzz       int32
+	flat       int32
+	resolution int32
+}
+
+// ffEffect is the Linux struct ff_effect holding a rumble effect. The
+// union of effect parameters is laid out like its largest member,
+// struct ff_periodic_effect, which ends in a pointer.
+type ffEffect struct {
+	typ             uint16
+	id              int16
+	direction       uint16
+	triggerButton   uint16
+	triggerInterval uint16
+	replayLength    uint16
+	replayDelay     uint16
+	_               uint16 // Union alignment
+	strong          uint16 // ff_rumble_effect.strong_magnitude
+	weak            uint16 // ff_rumble_effect.weak_magnitude
+	_               [7]uint16
+	_               uint32
+	_               uintptr
+}
+
+// evdev ioctl requests, from linux/input.h
+var (
+	eviocgkey  = evdevIOC(2, 0x18, keyMax/8+1)
+	eviocgname = evdevIOC(2, 0x06, 256)
+	eviocsff   = evdevIOC(1, 0x80, unsafe.Sizeof(ffEffect{}))
+)
+
+// evdevIOC builds an ioctl request of the evdev ('E') type.
+func evdevIOC(dir, nr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | 'E'<<8 | nr
+}
+
+// eviocgbit is EVIOCGBIT, reading the codes of an event type.
+func eviocgbit(typ, size uintptr) uintptr {
+	return evdevIOC(2, 0x20+typ, size)
+}
+
+// eviocgabs is EVIOCGABS, reading the range and value of an axis.
+func eviocgabs(code uintptr) uintptr {
+	return evdevIOC(2, 0x40+code, unsafe.Sizeof(evdevAbsInfo{}))
+}
+
+// evdevIoctl calls ioctl with a pointer argument.
+func evdevIoctl(fd int, req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// testBit reports whether bit n is set in a bit array read by ioctl.
+func testBit(bits []byte, n int) bool {
+	return bits[n/8]&(1<<(n%8)) != 0
+}
+
+// evdevGamepads polls the gamepads among the evdev input devices, shared
+// by the X11 and Wayland platforms. Devices are opened directly, which
+// needs read access to /dev/input/event*; desktop systems grant it to the
+// logged-in user for gamepads.
+type evdevGamepads struct {
+	mu      sync.Mutex
+	slots   [input.MaxGamepads]*evdevGamepad
+	other   map[string]bool // Devices that are not gamepads
+	polled  time.Time
+	scanned time.Time
+	events  []GamepadEvent // Connection changes not yet reported
+}
+
+// poll reads the input of the open gamepads, at most every
+// evdevPollInterval, and looks for new ones every evdevScanInterval. It
+// returns the next connection change, if any.
+func (g *evdevGamepads) poll() (GamepadEvent, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.polled) >= evdevPollInterval {
+		g.polled = now
+		for i, pad := range g.slots {
+			if pad != nil && !pad.read() {
+				pad.close()
+				g.slots[i] = nil
+				g.events = append(g.events, GamepadEvent{Slot: i})
+			}
+		}
+		if now.Sub(g.scanned) >= evdevScanInterval {
+			g.scanned = now
+			g.scan()
+		}
+	}
+
+	if len(g.events) == 0 {
+		return GamepadEvent{}, false
+	}
+	event := g.events[0]
+	g.events = g.events[1:]
+	return event, true
+}
+
+// scan opens the gamepads connected since the last scan.
+func (g *evdevGamepads) scan() {
+	paths, _ := filepath.Glob("/dev/input/event*")
+
+	present := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		present[path] = true
+	}
+	for path := range g.other {
+		if !present[path] {
+			delete(g.other, path)
+		}
+	}
+
+	for _, path := range paths {
+		if g.other[path] || g.isOpen(path) {
+			continue
+		}
+		slot := g.freeSlot()
+		if slot < 0 {
+			return
+		}
+
+		pad, err := openEvdevGamepad(path)
+		if err != nil {
+			// Access may be granted a moment after the device appears
+			continue
+		}
+		if pad == nil {
+			if g.other == nil {
+				g.other = make(map[string]bool)
+			}
+			g.other[path] = true
+			continue
+		}
+		g.slots[slot] = pad
+		g.events = append(g.events, GamepadEvent{Slot: slot, Connected: true})
+	}
+}
+
+// isOpen reports whether the device at path is an open gamepad.
+func (g *evdevGamepads) isOpen(path string) bool {
+	for _, pad := range g.slots {
+		if pad != nil && pad.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// freeSlot returns the first empty slot, or -1.
+func (g *evdevGamepads) freeSlot() int {
+	for i, pad := range g.slots {
+		if pad == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// gamepad returns the gamepad in slot as of the last poll.
+func (g *evdevGamepads) gamepad(slot int) (Gamepad, bool) {
+	if slot < 0 || slot >= len(g.slots) {
+		return Gamepad{}, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pad := g.slots[slot]
+	if pad == nil {
+		return Gamepad{}, false
+	}
+	return pad.state(), true
+}
+
+// setRumble sets the rumble motor speeds of the gamepad in slot.
+func (g *evdevGamepads) setRumble(slot int, low, high float64) error {
+	if slot < 0 || slot >= len(g.slots) {
+		return ErrUnsupported
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pad := g.slots[slot]
+	if pad == nil {
+		return fmt.Errorf("platform: gamepad %d is not connected", slot)
+	}
+	return pad.setRumble(low, high)
+}
+
+// close closes the open gamepads.
+func (g *evdevGamepads) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i, pad := range g.slots {
+		if pad != nil {
+			pad.close()
+			g.slots[i] = nil
+		}
+	}
+	g.events = nil
+}
+
+// evdevGamepad is an open evdev gamepad.
+type evdevGamepad struct {
+	path string
+	fd   int
+	name string
+
+	hasAbs  [absHat0Y + 1]bool
+	abs     [absHat0Y + 1]evdevAbsInfo // Ranges and current values
+	buttons [input.GamepadButtonCount]bool
+	tl2     bool // Digital triggers, for gamepads without trigger axes
+	tr2     bool
+
+	rumble bool  // FF_RUMBLE supported and the device is writable
+	effect int16 // Uploaded rumble effect, or -1
+}
+
+// openEvdevGamepad opens the input device at path. It returns nil
+// without an error if the device is not a gamepad.
+func openEvdevGamepad(path string) (*evdevGamepad, error) {
+	// Rumble needs write access; input alone only read access
+	writable := true
+	fd, err := unix.Open(path, unix.O_RDWR|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		writable = false
+		fd, err = unix.Open(path, unix.O_RDONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var keys [keyMax/8 + 1]byte
+	if evdevIoctl(fd, eviocgbit(evKey, uintptr(len(keys))), unsafe.Pointer(&keys)) != nil ||
+		!testBit(keys[:], btnSouth) {
+		_ = unix.Close(fd)
+		return nil, nil
+	}
+
+	pad := &evdevGamepad{path: path, fd: fd, effect: -1}
+
+	var name [256]byte
+	if evdevIoctl(fd, eviocgname, unsafe.Pointer(&name)) == nil {
+		pad.name = unix.ByteSliceToString(name[:])
+	}
+
+	var axes [absMax/8 + 1]byte
+	if evdevIoctl(fd, eviocgbit(evAbs, uintptr(len(axes))), unsafe.Pointer(&axes)) == nil {
+		for code := range pad.hasAbs {
+			pad.hasAbs[code] = testBit(axes[:], code)
+		}
+	}
+
+	var effects [ffMax/8 + 1]byte
+	if writable && evdevIoctl(fd, eviocgbit(evFF, uintptr(len(effects))), unsafe.Pointer(&effects)) == nil {
+		pad.rumble = testBit(effects[:], ffRumble)
+	}
+
+	pad.sync()
+	return pad, nil
+}
+
+// sync reads the current buttons and axes, after opening the device or
+// when the kernel dropped events.
+func (d *evdevGamepad) sync() {
+	var keys [keyMax/8 + 1]byte
+	if evdevIoctl(d.fd, eviocgkey, unsafe.Pointer(&keys)) == nil {
+		for code, button := range evdevButtons {
+			d.buttons[button] = testBit(keys[:], int(code))
+		}
+		d.tl2 = testBit(keys[:], btnTL2)
+		d.tr2 = testBit(keys[:], btnTR2)
+	}
+
+	for code, has := range d.hasAbs {
+		if has {
+			_ = evdevIoctl(d.fd, eviocgabs(uintptr(code)), unsafe.Pointer(&d.abs[code]))
+		}
+	}
+}
+
+// read applies the pending input events. It returns false once the
+// gamepad was disconnected.
+func (d *evdevGamepad) read() bool {
+	var events [32]evdevInputEvent
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&events[0])), unsafe.Sizeof(events))
+	for {
+		n, err := unix.Read(d.fd, buf)
+		if err == unix.EAGAIN || err == unix.EINTR {
+			return true
+		}
+		if err != nil || n <= 0 {
+			// ENODEV once unplugged
+			return false
+		}
+
+		for _, e := range events[:n/int(unsafe.Sizeof(events[0]))] {
+			d.apply(e)
+		}
+		if n < len(buf) {
+			return true
+		}
+	}
+}
+
+// apply applies one input event.
+func (d *evdevGamepad) apply(e evdevInputEvent) {
+	switch e.typ {
+	case evKey:
+		if button, ok := evdevButtons[e.code]; ok {
+			d.buttons[button] = e.value != 0
+		}
+		switch e.code {
+		case btnTL2:
+			d.tl2 = e.value != 0
+		case btnTR2:
+			d.tr2 = e.value != 0
+		}
+	case evAbs:
+		if int(e.code) < len(d.abs) {
+			d.abs[e.code].value = e.value
+		}
+	case evSyn:
+		if e.code == synDropped {
+			d.sync()
+		}
+	}
+}
+
+// state converts the device state to a Gamepad. Hat switches, which
+// many drivers use for the d-pad, are mapped to the d-pad buttons.
+func (d *evdevGamepad) state() Gamepad {
+	pad := Gamepad{Name: d.name, Buttons: d.buttons}
+
+	lx, ly := input.RadialDeadzone(d.stickAxis(absX), d.stickAxis(absY), evdevStickDeadzone)
+	rx, ry := input.RadialDeadzone(d.stickAxis(absRX), d.stickAxis(absRY), evdevStickDeadzone)
+	pad.Axes[input.GamepadLeftX], pad.Axes[input.GamepadLeftY] = lx, ly
+	pad.Axes[input.GamepadRightX], pad.Axes[input.GamepadRightY] = rx, ry
+	pad.Axes[input.GamepadLeftTrigger] = d.triggerAxis(absZ, d.tl2)
+	pad.Axes[input.GamepadRightTrigger] = d.triggerAxis(absRZ, d.tr2)
+
+	if d.hasAbs[absHat0X] {
+		pad.Buttons[input.GamepadDpadLeft] = pad.Buttons[input.GamepadDpadLeft] || d.abs[absHat0X].value < 0
+		pad.Buttons[input.GamepadDpadRight] = pad.Buttons[input.GamepadDpadRight] || d.abs[absHat0X].value > 0
+	}
+	if d.hasAbs[absHat0Y] {
+		pad.Buttons[input.GamepadDpadUp] = pad.Buttons[input.GamepadDpadUp] || d.abs[absHat0Y].value < 0
+		pad.Buttons[input.GamepadDpadDown] = pad.Buttons[input.GamepadDpadDown] || d.abs[absHat0Y].value > 0
+	}
+	return pad
+}
+
+// stickAxis converts a stick axis to -1..1. Like window coordinates,
+// evdev stick axes point down.
+func (d *evdevGamepad) stickAxis(code int) float32 {
+	info := d.abs[code]
+	if !d.hasAbs[code] || info.maximum <= info.minimum {
+		return 0
+	}
+	v := 2*float32(info.value-info.minimum)/float32(info.maximum-info.minimum) - 1
+	return min(max(v, -1), 1)
+}
+
+// triggerAxis converts a trigger axis to 0..1, or the digital trigger
+// button if the gamepad has no trigger axis.
+func (d *evdevGamepad) triggerAxis(code int, pressed bool) float32 {
+	info := d.abs[code]
+	if !d.hasAbs[code] || info.maximum <= info.minimum {
+		if pressed {
+			return 1
+		}
+		return 0
+	}
+	v := float32(info.value-info.minimum) / float32(info.maximum-info.minimum)
+	return input.TriggerDeadzone(min(max(v, 0), 1), evdevTriggerDeadzone)
+}
+
+// setRumble uploads a rumble effect with the motor speeds and plays it
+// until changed. Both speeds 0 stop it.
+func (d *evdevGamepad) setRumble(low, high float64) error {
+	if !d.rumble {
+		return ErrUnsupported
+	}
+
+	if low <= 0 && high <= 0 {
+		if d.effect < 0 {
+			return nil
+		}
+		return d.play(0)
+	}
+
+	// A replay length of 0 plays the effect until it is stopped
+	effect := ffEffect{
+		typ:    ffRumble,
+		id:     d.effect,
+		strong: ffMagnitude(low),
+		weak:   ffMagnitude(high),
+	}
+	if err := evdevIoctl(d.fd, eviocsff, unsafe.Pointer(&effect)); err != nil {
+		return fmt.Errorf("platform: upload rumble effect: %w", err)
+	}
+	d.effect = effect.id
+	return d.play(1)
+}
+
+// play starts (1) or stops (0) the uploaded rumble effect.
+func (d *evdevGamepad) play(value int32) error {
+	event := evdevInputEvent{typ: evFF, code: uint16(d.effect), value: value}
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&event)), unsafe.Sizeof(event))
+	if _, err := unix.Write(d.fd, buf); err != nil {
+		return fmt.Errorf("platform: play rumble effect: %w", err)
+	}
+	return nil
+}
+
+// ffMagnitude converts a motor speed from 0 to 1 to an effect magnitude.
+func ffMagnitude(speed float64) uint16 {
+	return uint16(min(max(speed, 0), 1)*0xffff + 0.5)
+}
+
+// close closes the device, which also removes its uploaded effects.
+func (d *evdevGamepad) close() {
+	_ = unix.Close(d.fd)
+}
+
+// Gamepad returns the gamepad in slot as of the last PollEvents.
+func (p *x11Platform) Gamepad(slot int) (Gamepad, bool) {
+	return p.gamepads.gamepad(slot)
+}
+
+// SetGamepadRumble sets the motor speeds of a gamepad with force
+// feedback.
+func (p *x11Platform) SetGamepadRumble(slot int, low, high float64) error {
+	return p.gamepads.setRumble(slot, low, high)
+}
+
+// Gamepad returns the gamepad in slot as of the last PollEvents.
+func (p *waylandPlatform) Gamepad(slot int) (Gamepad, bool) {
+	return p.gamepads.gamepad(slot)
+}
+
+// SetGamepadRumble sets the motor speeds of a gamepad with force
+// feedback.
+func (p *waylandPlatform) SetGamepadRumble(slot int, low, high float64) error {
+	return p.gamepads.setRumble(slot, low, high)
+}
@@ -0,0 +1,232 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/gogpu/gogpu/input"
+	"golang.org/x/sys/windows"
+)
+
+// XInput constants
+const (
+	xinputGamepadDpadUp        = 0x0001
+	xinputGamepadDpadDown      = 0x0002
+	xinputGamepadDpadLeft      = 0x0004
+	xinputGamepadDpadRight     = 0x0008
+	xinputGamepadStart         = 0x0010
+	xinputGamepadBack          = 0x0020
+	xinputGamepadLeftThumb     = 0x0040
+	xinputGamepadRightThumb    = 0x0080
+	xinputGamepadLeftShoulder  = 0x0100
+	xinputGamepadRightShoulder = 0x0200
+	xinputGamepadA             = 0x1000
+	xinputGamepadB             = 0x2000
+	xinputGamepadX             = 0x4000
+	xinputGamepadY             = 0x8000
+
+	// Recommended dead zones from XInput.h
+	xinputLeftThumbDeadzone  = 7849
+	xinputRightThumbDeadzone = 8689
+	xinputTriggerThreshold   = 30
+
+	// XInput has four user slots, matching input.MaxGamepads
+	xinputUserCount = 4
+)
+
+// Gamepad polling intervals. XInputGetState on an empty slot can take
+// milliseconds, so empty slots are probed for new gamepads rarely.
+const (
+	gamepadPollInterval  = 4 * time.Millisecond
+	gamepadProbeInterval = time.Second
+)
+
+// xinputButtons maps XInput button bits to gamepad buttons. The Guide
+// button is not reported by the documented XInput API.
+var xinputButtons = [...]struct {
+	mask   uint16
+	button input.GamepadButton
+}{
+	{xinputGamepadA, input.GamepadA},
+	{xinputGamepadB, input.GamepadB},
+	{xinputGamepadX, input.GamepadX},
+	{xinputGamepadY, input.GamepadY},
+	{xinputGamepadLeftShoulder, input.GamepadLeftBumper},
+	{xinputGamepadRightShoulder, input.GamepadRightBumper},
+	{xinputGamepadBack, input.GamepadBack},
+	{xinputGamepadStart, input.GamepadStart},
+	{xinputGamepadLeftThumb, input.GamepadLeftThumb},
+	{xinputGamepadRightThumb, input.GamepadRightThumb},
+	{xinputGamepadDpadUp, input.GamepadDpadUp},
+	{xinputGamepadDpadRight, input.GamepadDpadRight},
+	{xinputGamepadDpadDown, input.GamepadDpadDown},
+	{xinputGamepadDpadLeft, input.GamepadDpadLeft},
+}
+
+// XInput entry points, from the newest available XInput DLL
+var (
+	xinputOnce         sync.Once
+	procXInputGetState *windows.LazyProc
+	procXInputSetState *windows.LazyProc
+)
+
+// xinputGamepad is the Win32 XINPUT_GAMEPAD structure.
+type xinputGamepad struct {
+	wButtons      uint16
+	bLeftTrigger  uint8
+	bRightTrigger uint8
+	sThumbLX      int16
+	sThumbLY      int16
+	sThumbRX      int16
+	sThumbRY      int16
+}
+
+// xinputState is the Win32 XINPUT_STATE structure.
+type xinputState struct {
+	dwPacketNumber uint32
+	gamepad        xinputGamepad
+}
+
+// xinputVibration is the Win32 XINPUT_VIBRATION structure.
+type xinputVibration struct {
+	wLeftMotorSpeed  uint16
+	wRightMotorSpeed uint16
+}
+
+// gamepadSlot is the polling state of one XInput slot.
+type gamepadSlot struct {
+	connected bool
+	packet    uint32    // dwPacketNumber of the last state read
+	probed    time.Time // Last time an empty slot was checked
+	state     Gamepad
+}
+
+// loadXInput loads XInput 1.4 (Windows 8 and later), or XInput 9.1.0,
+// which ships with every Windows since Vista. It reports whether either
+// is available. GameInput, which also covers non-Xbox controllers, is the
+// candidate for a future backend.
+func loadXInput() bool {
+	xinputOnce.Do(func() {
+		for _, name := range []string{"xinput1_4.dll", "xinput9_1_0.dll"} {
+			dll := windows.NewLazyDLL(name)
+			if dll.Load() != nil {
+				continue
+			}
+			procXInputGetState = dll.NewProc("XInputGetState")
+			procXInputSetState = dll.NewProc("XInputSetState")
+			return
+		}
+	})
+	return procXInputGetState != nil
+}
+
+// pollGamepads samples the connected gamepads and queues connection
+// changes. Called from PollEvents, at most every gamepadPollInterval.
+func (p *windowsPlatform) pollGamepads() {
+	now := time.Now()
+	if now.Sub(p.gamepadsPolled) < gamepadPollInterval || !loadXInput() {
+		return
+	}
+	p.gamepadsPolled = now
+
+	for i := range p.gamepads {
+		slot := &p.gamepads[i]
+		if !slot.connected {
+			if now.Sub(slot.probed) < gamepadProbeInterval {
+				continue
+			}
+			slot.probed = now
+		}
+
+		var state xinputState
+		ret, _, _ := procXInputGetState.Call(uintptr(i), uintptr(unsafe.Pointer(&state)))
+		connected := ret == 0 // ERROR_SUCCESS; otherwise ERROR_DEVICE_NOT_CONNECTED
+
+		p.eventMu.Lock()
+		changed := connected != slot.connected
+		slot.connected = connected
+		if connected && (changed || state.dwPacketNumber != slot.packet) {
+			slot.packet = state.dwPacketNumber
+			slot.state = gamepadFromXInput(&state.gamepad)
+		}
+		p.eventMu.Unlock()
+
+		if changed {
+			p.queueEvent(Event{
+				Type:    EventGamepad,
+				Gamepad: GamepadEvent{Slot: i, Connected: connected},
+			})
+		}
+	}
+}
+
+// gamepadFromXInput converts an XInput gamepad state, applying the
+// recommended dead zones and flipping the stick y axes to point down.
+func gamepadFromXInput(g *xinputGamepad) Gamepad {
+	// XInput does not report product names
+	pad := Gamepad{Name: "XInput Controller"}
+	for _, b := range xinputButtons {
+		pad.Buttons[b.button] = g.wButtons&b.mask != 0
+	}
+
+	lx, ly := input.RadialDeadzone(stickAxis(g.sThumbLX), -stickAxis(g.sThumbLY),
+		xinputLeftThumbDeadzone/32767.0)
+	rx, ry := input.RadialDeadzone(stickAxis(g.sThumbRX), -stickAxis(g.sThumbRY),
+		xinputRightThumbDeadzone/32767.0)
+	pad.Axes[input.GamepadLeftX], pad.Axes[input.GamepadLeftY] = lx, ly
+	pad.Axes[input.GamepadRightX], pad.Axes[input.GamepadRightY] = rx, ry
+
+	pad.Axes[input.GamepadLeftTrigger] = input.TriggerDeadzone(float32(g.bLeftTrigger)/255,
+		xinputTriggerThreshold/255.0)
+	pad.Axes[input.GamepadRightTrigger] = input.TriggerDeadzone(float32(g.bRightTrigger)/255,
+		xinputTriggerThreshold/255.0)
+	return pad
+}
+
+// stickAxis converts a stick axis to -1..1.
+func stickAxis(v int16) float32 {
+	return max(float32(v)/32767, -1)
+}
+
+// Gamepad returns the gamepad in slot as of the last PollEvents.
+func (p *windowsPlatform) Gamepad(slot int) (Gamepad, bool) {
+	if slot < 0 || slot >= len(p.gamepads) {
+		return Gamepad{}, false
+	}
+
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+
+	s := &p.gamepads[slot]
+	if !s.connected {
+		return Gamepad{}, false
+	}
+	return s.state, true
+}
+
+// SetGamepadRumble sets the motor speeds of an XInput gamepad. The left
+// motor of an Xbox controller is the low-frequency one.
+func (p *windowsPlatform) SetGamepadRumble(slot int, low, high float64) error {
+	if slot < 0 || slot >= len(p.gamepads) || !loadXInput() {
+		return ErrUnsupported
+	}
+
+	vibration := xinputVibration{
+		wLeftMotorSpeed:  motorSpeed(low),
+		wRightMotorSpeed: motorSpeed(high),
+	}
+	ret, _, _ := procXInputSetState.Call(uintptr(slot), uintptr(unsafe.Pointer(&vibration)))
+	if ret != 0 {
+		return fmt.Errorf("platform: gamepad %d is not connected", slot)
+	}
+	return nil
+}
+
+// motorSpeed converts a speed from 0 to 1 to an XInput motor speed.
+func motorSpeed(speed float64) uint16 {
+	return uint16(min(max(speed, 0), 1)*65535 + 0.5)
+}
@@ -0,0 +1,62 @@
+package platform
+
+// headlessPlatform is a Platform without a window, for apps that draw
+// offscreen. It has no events and its size is fixed.
+type headlessPlatform struct {
+	width, height int
+}
+
+// NewHeadless creates a platform without a window. Renderers for it are
+// created without a surface.
+func NewHeadless() Platform {
+	return &headlessPlatform{}
+}
+
+// Init records the size of the frames.
+func (p *headlessPlatform) Init(config Config) error {
+	p.width, p.height = config.Width, config.Height
+	return nil
+}
+
+// PollEvents returns EventNone: there is no window to produce events.
+func (p *headlessPlatform) PollEvents() Event {
+	return Event{Type: EventNone}
+}
+
+// ShouldClose returns false; headless apps end with Quit.
+func (p *headlessPlatform) ShouldClose() bool {
+	return false
+}
+
+// GetSize returns the size of the frames.
+func (p *headlessPlatform) GetSize() (width, height int) {
+	return p.width, p.height
+}
+
+// GetHandle returns no handles.
+func (p *headlessPlatform) GetHandle() (instance, window uintptr) {
+	return 0, 0
+}
+
+// SetIcon returns ErrUnsupported.
+func (p *headlessPlatform) SetIcon(int, int, []byte) error {
+	return ErrUnsupported
+}
+
+// BeginMove returns ErrUnsupported.
+func (p *headlessPlatform) BeginMove() error {
+	return ErrUnsupported
+}
+
+// BeginResize returns ErrUnsupported.
+func (p *headlessPlatform) BeginResize(ResizeEdge) error {
+	return ErrUnsupported
+}
+
+// ShowWindowMenu returns ErrUnsupported.
+func (p *headlessPlatform) ShowWindowMenu(int, int) error {
+	return ErrUnsupported
+}
+
+// Destroy does nothing.
+func (p *headlessPlatform) Destroy() {}
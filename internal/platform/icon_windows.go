@@ -0,0 +1,126 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Icon constants
+const (
+	wmSetIcon = 0x0080
+	iconSmall = 0
+	iconBig   = 1
+
+	dibRGBColors = 0
+)
+
+var (
+	gdi32 = windows.NewLazyDLL("gdi32.dll")
+
+	procCreateDIBSection   = gdi32.NewProc("CreateDIBSection")
+	procCreateBitmap       = gdi32.NewProc("CreateBitmap")
+	procDeleteObject       = gdi32.NewProc("DeleteObject")
+	procCreateIconIndirect = user32.NewProc("CreateIconIndirect")
+	procDestroyIcon        = user32.NewProc("DestroyIcon")
+)
+
+// bitmapInfoHeader is the Win32 BITMAPINFOHEADER structure.
+type bitmapInfoHeader struct {
+	biSize          uint32
+	biWidth         int32
+	biHeight        int32
+	biPlanes        uint16
+	biBitCount      uint16
+	biCompression   uint32
+	biSizeImage     uint32
+	biXPelsPerMeter int32
+	biYPelsPerMeter int32
+	biClrUsed       uint32
+	biClrImportant  uint32
+}
+
+// iconInfo is the Win32 ICONINFO structure.
+type iconInfo struct {
+	fIcon    int32 // BOOL
+	xHotspot uint32
+	yHotspot uint32
+	hbmMask  uintptr
+	hbmColor uintptr
+}
+
+// SetIcon sets the title bar and taskbar icon. Windows scales the image
+// to the small and large icon sizes.
+func (p *windowsPlatform) SetIcon(width, height int, pixels []byte) error {
+	icon, err := createIcon(width, height, pixels)
+	if err != nil {
+		return err
+	}
+
+	procSendMessageW.Call(uintptr(p.hwnd), wmSetIcon, iconBig, icon)
+	procSendMessageW.Call(uintptr(p.hwnd), wmSetIcon, iconSmall, icon)
+
+	// The window does not own its icons
+	if p.icon != 0 {
+		procDestroyIcon.Call(p.icon)
+	}
+	p.icon = icon
+	return nil
+}
+
+// createIcon creates an HICON from premultiplied RGBA pixels.
+func createIcon(width, height int, pixels []byte) (uintptr, error) {
+	return newIconHandle(width, height, pixels, iconInfo{fIcon: 1})
+}
+
+// newIconHandle creates an icon, or a cursor if info.fIcon is 0, from
+// premultiplied RGBA pixels. The bitmaps of info are filled in; icons
+// take straight alpha in BGRA order.
+func newIconHandle(width, height int, pixels []byte, info iconInfo) (uintptr, error) {
+	if width <= 0 || height <= 0 || len(pixels) < width*height*4 {
+		return 0, fmt.Errorf("platform: invalid icon size %dx%d", width, height)
+	}
+
+	header := bitmapInfoHeader{
+		biSize:     uint32(unsafe.Sizeof(bitmapInfoHeader{})),
+		biWidth:    int32(width),
+		biHeight:   -int32(height), // top-down
+		biPlanes:   1,
+		biBitCount: 32,
+	}
+	var bits uintptr
+	color, _, _ := procCreateDIBSection.Call(0, uintptr(unsafe.Pointer(&header)), dibRGBColors,
+		uintptr(unsafe.Pointer(&bits)), 0, 0)
+	if color == 0 {
+		return 0, fmt.Errorf("platform: CreateDIBSection failed")
+	}
+	defer procDeleteObject.Call(color)
+
+	dst := memoryBytes(bits, width*height*4)
+	for i := 0; i < width*height*4; i += 4 {
+		r, g, b, a := pixels[i], pixels[i+1], pixels[i+2], pixels[i+3]
+		if a != 0 && a != 0xFF {
+			r = uint8(uint32(r) * 0xFF / uint32(a))
+			g = uint8(uint32(g) * 0xFF / uint32(a))
+			b = uint8(uint32(b) * 0xFF / uint32(a))
+		}
+		dst[i], dst[i+1], dst[i+2], dst[i+3] = b, g, r, a
+	}
+
+	// Unused with an alpha channel, but required
+	mask, _, _ := procCreateBitmap.Call(uintptr(width), uintptr(height), 1, 1, 0)
+	if mask == 0 {
+		return 0, fmt.Errorf("platform: CreateBitmap failed")
+	}
+	defer procDeleteObject.Call(mask)
+
+	info.hbmMask, info.hbmColor = mask, color
+	icon, _, _ := procCreateIconIndirect.Call(uintptr(unsafe.Pointer(&info)))
+	if icon == 0 {
+		return 0, fmt.Errorf("platform: CreateIconIndirect failed")
+	}
+	return icon, nil
+}
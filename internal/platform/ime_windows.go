@@ -0,0 +1,203 @@
+//go:build windows
+
+package platform
+
+import (
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// IME and character message constants
+const (
+	wmChar                = 0x0102
+	wmImeStartComposition = 0x010D
+	wmImeEndComposition   = 0x010E
+	wmImeComposition      = 0x010F
+	wmImeSetContext       = 0x0281
+
+	gcsCompStr   = 0x0008
+	gcsCursorPos = 0x0080
+	gcsResultStr = 0x0800
+
+	cfsPoint        = 0x0002
+	cfsCandidatePos = 0x0040
+	cfsExclude      = 0x0080
+
+	// ISC_SHOWUICOMPOSITIONWINDOW
+	iscShowUICompositionWindow = 0x80000000
+)
+
+var (
+	imm32 = windows.NewLazyDLL("imm32.dll")
+
+	procImmGetContext            = imm32.NewProc("ImmGetContext")
+	procImmReleaseContext        = imm32.NewProc("ImmReleaseContext")
+	procImmGetCompositionStringW = imm32.NewProc("ImmGetCompositionStringW")
+	procImmSetCompositionWindow  = imm32.NewProc("ImmSetCompositionWindow")
+	procImmSetCandidateWindow    = imm32.NewProc("ImmSetCandidateWindow")
+)
+
+// compositionForm is the Win32 COMPOSITIONFORM structure.
+type compositionForm struct {
+	dwStyle      uint32
+	ptCurrentPos point
+	rcArea       rect
+}
+
+// candidateForm is the Win32 CANDIDATEFORM structure.
+type candidateForm struct {
+	dwIndex      uint32
+	dwStyle      uint32
+	ptCurrentPos point
+	rcArea       rect
+}
+
+// handleChar queues a WM_CHAR character as committed text. Characters
+// outside the Basic Multilingual Plane arrive as two messages, one per
+// UTF-16 surrogate. Control characters are left to key events.
+func (p *windowsPlatform) handleChar(wParam uintptr) {
+	unit := uint16(wParam)
+	switch {
+	case utf16.IsSurrogate(rune(unit)) && unit < 0xDC00:
+		p.highSurrogate = unit
+		return
+	case utf16.IsSurrogate(rune(unit)):
+		high := p.highSurrogate
+		p.highSurrogate = 0
+		if high == 0 {
+			return
+		}
+		p.queueText(string(utf16.DecodeRune(rune(high), rune(unit))), false, 0)
+		return
+	}
+	p.highSurrogate = 0
+
+	if unit < 0x20 || unit == 0x7F {
+		return
+	}
+	p.queueText(string(rune(unit)), false, 0)
+}
+
+// handleIMEComposition queues the committed and composing strings of a
+// WM_IME_COMPOSITION message. Both may change in one message, when the
+// IME commits part of the input and keeps composing the rest.
+func (p *windowsPlatform) handleIMEComposition(lParam uintptr) {
+	himc, _, _ := procImmGetContext.Call(uintptr(p.hwnd))
+	if himc == 0 {
+		return
+	}
+	defer procImmReleaseContext.Call(uintptr(p.hwnd), himc)
+
+	p.placeIMEWindows(himc)
+
+	if lParam&gcsResultStr != 0 {
+		if result := compositionString(himc, gcsResultStr); len(result) > 0 {
+			p.composing = false
+			p.queueText(string(utf16.Decode(result)), false, 0)
+		}
+	}
+
+	if lParam&gcsCompStr != 0 {
+		composition := compositionString(himc, gcsCompStr)
+		if len(composition) == 0 && !p.composing {
+			return
+		}
+
+		// Caret position in UTF-16 units
+		cursor := len(composition)
+		if lParam&gcsCursorPos != 0 {
+			ret, _, _ := procImmGetCompositionStringW.Call(himc, gcsCursorPos, 0, 0)
+			if pos := int(int32(ret)); pos >= 0 && pos <= len(composition) {
+				cursor = pos
+			}
+		}
+
+		p.composing = len(composition) > 0
+		p.queueText(string(utf16.Decode(composition)), true, len(utf16.Decode(composition[:cursor])))
+	}
+}
+
+// handleIMEEndComposition reports a composition that ended without being
+// committed, such as one cancelled with Escape.
+func (p *windowsPlatform) handleIMEEndComposition() {
+	if p.composing {
+		p.composing = false
+		p.queueText("", true, 0)
+	}
+}
+
+// handleIMEStartComposition places the IME windows for a new composition.
+func (p *windowsPlatform) handleIMEStartComposition() {
+	himc, _, _ := procImmGetContext.Call(uintptr(p.hwnd))
+	if himc == 0 {
+		return
+	}
+	defer procImmReleaseContext.Call(uintptr(p.hwnd), himc)
+
+	p.placeIMEWindows(himc)
+}
+
+// compositionString returns a composition string of the input context.
+func compositionString(himc uintptr, index uintptr) []uint16 {
+	// Size in bytes; negative values are IMM errors
+	ret, _, _ := procImmGetCompositionStringW.Call(himc, index, 0, 0)
+	size := int(int32(ret))
+	if size <= 0 {
+		return nil
+	}
+
+	buf := make([]uint16, size/2)
+	procImmGetCompositionStringW.Call(himc, index, uintptr(unsafe.Pointer(&buf[0])), uintptr(size))
+	return buf
+}
+
+// placeIMEWindows moves the composition and candidate windows to the text
+// input area set with SetTextInputRect, keeping the candidates from
+// covering it. IMM calls must come from the window's thread, so the area
+// is applied here rather than when it is set.
+func (p *windowsPlatform) placeIMEWindows(himc uintptr) {
+	p.eventMu.Lock()
+	area, ok := p.textInputRect, p.textInputRectSet
+	p.eventMu.Unlock()
+	if !ok {
+		return
+	}
+
+	composition := compositionForm{
+		dwStyle:      cfsPoint,
+		ptCurrentPos: point{x: area.left, y: area.top},
+	}
+	procImmSetCompositionWindow.Call(himc, uintptr(unsafe.Pointer(&composition)))
+
+	candidate := candidateForm{
+		dwStyle:      cfsExclude,
+		ptCurrentPos: point{x: area.left, y: area.bottom},
+		rcArea:       area,
+	}
+	procImmSetCandidateWindow.Call(himc, uintptr(unsafe.Pointer(&candidate)))
+}
+
+// queueText queues a text input event.
+func (p *windowsPlatform) queueText(text string, composing bool, cursor int) {
+	p.queueEvent(Event{
+		Type: EventText,
+		Text: TextEvent{Text: text, Composing: composing, Cursor: cursor},
+	})
+}
+
+// SetTextInputRect records the area of the text being edited, in client
+// pixels, for the IME windows of the next composition update.
+func (p *windowsPlatform) SetTextInputRect(x, y, width, height int) {
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+
+	p.textInputRect = rect{
+		left:   int32(x),
+		top:    int32(y),
+		right:  int32(x + width),
+		bottom: int32(y + height),
+	}
+	p.textInputRectSet = true
+}
@@ -0,0 +1,276 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"github.com/gogpu/gogpu/input"
+)
+
+// Key and mouse message constants
+const (
+	wmSysKeydown   = 0x0104
+	wmSysKeyup     = 0x0105
+	wmMouseMove    = 0x0200
+	wmLButtonDown  = 0x0201
+	wmLButtonUp    = 0x0202
+	wmRButtonDown  = 0x0204
+	wmRButtonUp    = 0x0205
+	wmMButtonDown  = 0x0207
+	wmMButtonUp    = 0x0208
+	wmMouseWheel   = 0x020A
+	wmXButtonDown  = 0x020B
+	wmXButtonUp    = 0x020C
+	wmMouseHWheel  = 0x020E
+	wheelDelta     = 120
+	xButton1       = 0x0001
+	mkLButton      = 0x0001
+	mkRButton      = 0x0002
+	mkMButton      = 0x0010
+	mkXButton1     = 0x0020
+	mkXButton2     = 0x0040
+	vkShift        = 0x10
+	vkControl      = 0x11
+	vkMenu         = 0x12
+	vkLWin         = 0x5B
+	vkRWin         = 0x5C
+	scanRightShift = 0x36
+)
+
+var (
+	procGetKeyState = user32.NewProc("GetKeyState")
+	procSetCapture  = user32.NewProc("SetCapture")
+)
+
+// windowsKeyCodes maps virtual-key codes to keys. Letter, digit and
+// punctuation codes follow the keyboard layout, so VK 'A' is the key
+// labeled A. The generic VK_SHIFT, VK_CONTROL and VK_MENU codes sent with
+// WM_KEYDOWN are resolved to a side by windowsKey.
+var windowsKeyCodes = map[uintptr]input.Key{
+	0x08: input.KeyBackspace,
+	0x09: input.KeyTab,
+	0x0D: input.KeyEnter,
+	0x13: input.KeyPause,
+	0x14: input.KeyCapsLock,
+	0x1B: input.KeyEscape,
+	0x20: input.KeySpace,
+	0x21: input.KeyPageUp,
+	0x22: input.KeyPageDown,
+	0x23: input.KeyEnd,
+	0x24: input.KeyHome,
+	0x25: input.KeyLeft,
+	0x26: input.KeyUp,
+	0x27: input.KeyRight,
+	0x28: input.KeyDown,
+	0x2C: input.KeyPrintScreen,
+	0x2D: input.KeyInsert,
+	0x2E: input.KeyDelete,
+	0x30: input.Key0,
+	0x31: input.Key1,
+	0x32: input.Key2,
+	0x33: input.Key3,
+	0x34: input.Key4,
+	0x35: input.Key5,
+	0x36: input.Key6,
+	0x37: input.Key7,
+	0x38: input.Key8,
+	0x39: input.Key9,
+	0x41: input.KeyA,
+	0x42: input.KeyB,
+	0x43: input.KeyC,
+	0x44: input.KeyD,
+	0x45: input.KeyE,
+	0x46: input.KeyF,
+	0x47: input.KeyG,
+	0x48: input.KeyH,
+	0x49: input.KeyI,
+	0x4A: input.KeyJ,
+	0x4B: input.KeyK,
+	0x4C: input.KeyL,
+	0x4D: input.KeyM,
+	0x4E: input.KeyN,
+	0x4F: input.KeyO,
+	0x50: input.KeyP,
+	0x51: input.KeyQ,
+	0x52: input.KeyR,
+	0x53: input.KeyS,
+	0x54: input.KeyT,
+	0x55: input.KeyU,
+	0x56: input.KeyV,
+	0x57: input.KeyW,
+	0x58: input.KeyX,
+	0x59: input.KeyY,
+	0x5A: input.KeyZ,
+	0x5B: input.KeySuperLeft,
+	0x5C: input.KeySuperRight,
+	0x60: input.KeyNumpad0,
+	0x61: input.KeyNumpad1,
+	0x62: input.KeyNumpad2,
+	0x63: input.KeyNumpad3,
+	0x64: input.KeyNumpad4,
+	0x65: input.KeyNumpad5,
+	0x66: input.KeyNumpad6,
+	0x67: input.KeyNumpad7,
+	0x68: input.KeyNumpad8,
+	0x69: input.KeyNumpad9,
+	0x6A: input.KeyNumpadMultiply,
+	0x6B: input.KeyNumpadAdd,
+	0x6D: input.KeyNumpadSubtract,
+	0x6E: input.KeyNumpadDecimal,
+	0x6F: input.KeyNumpadDivide,
+	0x70: input.KeyF1,
+	0x71: input.KeyF2,
+	0x72: input.KeyF3,
+	0x73: input.KeyF4,
+	0x74: input.KeyF5,
+	0x75: input.KeyF6,
+	0x76: input.KeyF7,
+	0x77: input.KeyF8,
+	0x78: input.KeyF9,
+	0x79: input.KeyF10,
+	0x7A: input.KeyF11,
+	0x7B: input.KeyF12,
+	0x90: input.KeyNumLock,
+	0x91: input.KeyScrollLock,
+	0xA0: input.KeyShiftLeft,
+	0xA1: input.KeyShiftRight,
+	0xA2: input.KeyControlLeft,
+	0xA3: input.KeyControlRight,
+	0xA4: input.KeyAltLeft,
+	0xA5: input.KeyAltRight,
+	0xBA: input.KeySemicolon,    // VK_OEM_1
+	0xBB: input.KeyEqual,        // VK_OEM_PLUS
+	0xBC: input.KeyComma,        // VK_OEM_COMMA
+	0xBD: input.KeyMinus,        // VK_OEM_MINUS
+	0xBE: input.KeyPeriod,       // VK_OEM_PERIOD
+	0xBF: input.KeySlash,        // VK_OEM_2
+	0xC0: input.KeyGrave,        // VK_OEM_3
+	0xDB: input.KeyLeftBracket,  // VK_OEM_4
+	0xDC: input.KeyBackslash,    // VK_OEM_5
+	0xDD: input.KeyRightBracket, // VK_OEM_6
+	0xDE: input.KeyApostrophe,   // VK_OEM_7
+}
+
+// windowsKey returns the key of a WM_KEYDOWN or WM_KEYUP message.
+// Bit 24 of lParam marks extended keys: the right Control and Alt keys
+// and the keypad Enter key.
+func windowsKey(vk, lParam uintptr) input.Key {
+	extended := lParam&(1<<24) != 0
+	switch vk {
+	case vkShift:
+		// Both Shift keys share the extended flag; tell them by scan code
+		if (lParam>>16)&0xFF == scanRightShift {
+			return input.KeyShiftRight
+		}
+		return input.KeyShiftLeft
+	case vkControl:
+		if extended {
+			return input.KeyControlRight
+		}
+		return input.KeyControlLeft
+	case vkMenu:
+		if extended {
+			return input.KeyAltRight
+		}
+		return input.KeyAltLeft
+	case 0x0D: // VK_RETURN
+		if extended {
+			return input.KeyNumpadEnter
+		}
+	}
+	return windowsKeyCodes[vk]
+}
+
+// windowsModifiers returns the modifiers held while the current message
+// was generated.
+func windowsModifiers() input.Modifier {
+	held := func(vk uintptr) bool {
+		state, _, _ := procGetKeyState.Call(vk)
+		return state&0x8000 != 0
+	}
+
+	var mods input.Modifier
+	if held(vkShift) {
+		mods |= input.ModShift
+	}
+	if held(vkControl) {
+		mods |= input.ModControl
+	}
+	if held(vkMenu) {
+		mods |= input.ModAlt
+	}
+	if held(vkLWin) || held(vkRWin) {
+		mods |= input.ModSuper
+	}
+	return mods
+}
+
+// handleKey queues a key event for a WM_KEYDOWN, WM_KEYUP, WM_SYSKEYDOWN
+// or WM_SYSKEYUP message. The scan code includes the extended flag in
+// bit 8, so the two Enter keys have different scan codes.
+func (p *windowsPlatform) handleKey(id WindowID, message uint32, wParam, lParam uintptr) {
+	down := message == wmKeydown || message == wmSysKeydown
+	p.queueEvent(Event{Type: EventKey, Window: id, Key: KeyEvent{
+		Key:       windowsKey(wParam, lParam),
+		Scancode:  uint32((lParam>>16)&0xFF | (lParam>>24&1)<<8),
+		Down:      down,
+		Repeat:    down && lParam&(1<<30) != 0,
+		Modifiers: windowsModifiers(),
+	}})
+}
+
+// handleMouse queues a mouse event for a WM_MOUSEMOVE, button or wheel
+// message. The mouse is captured while a button is held, so a drag that
+// leaves the window still reports its release.
+func (p *windowsPlatform) handleMouse(hwnd uintptr, id WindowID, message uint32, wParam, lParam uintptr) {
+	event := MouseEvent{
+		X:         float64(int16(lParam & 0xFFFF)),
+		Y:         float64(int16((lParam >> 16) & 0xFFFF)),
+		Modifiers: windowsModifiers(),
+	}
+
+	eventType := EventMouseButton
+	switch message {
+	case wmMouseMove:
+		eventType = EventMouseMove
+	case wmLButtonDown, wmLButtonUp:
+		event.Button = input.MouseButtonLeft
+	case wmRButtonDown, wmRButtonUp:
+		event.Button = input.MouseButtonRight
+	case wmMButtonDown, wmMButtonUp:
+		event.Button = input.MouseButtonMiddle
+	case wmXButtonDown, wmXButtonUp:
+		event.Button = input.MouseButton5
+		if (wParam>>16)&0xFFFF == xButton1 {
+			event.Button = input.MouseButton4
+		}
+	case wmMouseWheel, wmMouseHWheel:
+		eventType = EventScroll
+		delta := float64(int16((wParam>>16)&0xFFFF)) / wheelDelta
+		if message == wmMouseWheel {
+			event.ScrollY = delta
+		} else {
+			event.ScrollX = delta
+		}
+		// Wheel positions are in screen coordinates
+		pt := point{x: int32(event.X), y: int32(event.Y)}
+		procScreenToClient.Call(hwnd, uintptr(unsafe.Pointer(&pt)))
+		event.X, event.Y = float64(pt.x), float64(pt.y)
+	}
+
+	if eventType == EventMouseButton {
+		switch message {
+		case wmLButtonDown, wmRButtonDown, wmMButtonDown, wmXButtonDown:
+			event.Down = true
+			procSetCapture.Call(hwnd)
+		default:
+			// The low word of wParam holds the buttons still down
+			if wParam&(mkLButton|mkRButton|mkMButton|mkXButton1|mkXButton2) == 0 {
+				procReleaseCapture.Call()
+			}
+		}
+	}
+
+	p.queueEvent(Event{Type: eventType, Window: id, Mouse: event})
+}
@@ -0,0 +1,191 @@
+//go:build darwin
+
+package platform
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform/darwin"
+)
+
+// darwinKeyCodes maps macOS virtual key codes (kVK_*), which name the key
+// at that position on an ANSI US keyboard, to keys.
+var darwinKeyCodes = map[uint16]input.Key{
+	0x00: input.KeyA,
+	0x01: input.KeyS,
+	0x02: input.KeyD,
+	0x03: input.KeyF,
+	0x04: input.KeyH,
+	0x05: input.KeyG,
+	0x06: input.KeyZ,
+	0x07: input.KeyX,
+	0x08: input.KeyC,
+	0x09: input.KeyV,
+	0x0B: input.KeyB,
+	0x0C: input.KeyQ,
+	0x0D: input.KeyW,
+	0x0E: input.KeyE,
+	0x0F: input.KeyR,
+	0x10: input.KeyY,
+	0x11: input.KeyT,
+	0x12: input.Key1,
+	0x13: input.Key2,
+	0x14: input.Key3,
+	0x15: input.Key4,
+	0x16: input.Key6,
+	0x17: input.Key5,
+	0x18: input.KeyEqual,
+	0x19: input.Key9,
+	0x1A: input.Key7,
+	0x1B: input.KeyMinus,
+	0x1C: input.Key8,
+	0x1D: input.Key0,
+	0x1E: input.KeyRightBracket,
+	0x1F: input.KeyO,
+	0x20: input.KeyU,
+	0x21: input.KeyLeftBracket,
+	0x22: input.KeyI,
+	0x23: input.KeyP,
+	0x24: input.KeyEnter,
+	0x25: input.KeyL,
+	0x26: input.KeyJ,
+	0x27: input.KeyApostrophe,
+	0x28: input.KeyK,
+	0x29: input.KeySemicolon,
+	0x2A: input.KeyBackslash,
+	0x2B: input.KeyComma,
+	0x2C: input.KeySlash,
+	0x2D: input.KeyN,
+	0x2E: input.KeyM,
+	0x2F: input.KeyPeriod,
+	0x30: input.KeyTab,
+	0x31: input.KeySpace,
+	0x32: input.KeyGrave,
+	0x33: input.KeyBackspace,
+	0x35: input.KeyEscape,
+	0x36: input.KeySuperRight,
+	0x37: input.KeySuperLeft,
+	0x38: input.KeyShiftLeft,
+	0x39: input.KeyCapsLock,
+	0x3A: input.KeyAltLeft,
+	0x3B: input.KeyControlLeft,
+	0x3C: input.KeyShiftRight,
+	0x3D: input.KeyAltRight,
+	0x3E: input.KeyControlRight,
+	0x41: input.KeyNumpadDecimal,
+	0x43: input.KeyNumpadMultiply,
+	0x45: input.KeyNumpadAdd,
+	0x47: input.KeyNumLock, // Keypad Clear, in the Num Lock position
+	0x4B: input.KeyNumpadDivide,
+	0x4C: input.KeyNumpadEnter,
+	0x4E: input.KeyNumpadSubtract,
+	0x52: input.KeyNumpad0,
+	0x53: input.KeyNumpad1,
+	0x54: input.KeyNumpad2,
+	0x55: input.KeyNumpad3,
+	0x56: input.KeyNumpad4,
+	0x57: input.KeyNumpad5,
+	0x58: input.KeyNumpad6,
+	0x59: input.KeyNumpad7,
+	0x5B: input.KeyNumpad8,
+	0x5C: input.KeyNumpad9,
+	0x60: input.KeyF5,
+	0x61: input.KeyF6,
+	0x62: input.KeyF7,
+	0x63: input.KeyF3,
+	0x64: input.KeyF8,
+	0x65: input.KeyF9,
+	0x67: input.KeyF11,
+	0x69: input.KeyPrintScreen, // F13
+	0x6B: input.KeyScrollLock,  // F14
+	0x6D: input.KeyF10,
+	0x6F: input.KeyF12,
+	0x71: input.KeyPause,  // F15
+	0x72: input.KeyInsert, // Help, in the Insert position
+	0x73: input.KeyHome,
+	0x74: input.KeyPageUp,
+	0x75: input.KeyDelete,
+	0x76: input.KeyF4,
+	0x77: input.KeyEnd,
+	0x78: input.KeyF2,
+	0x79: input.KeyPageDown,
+	0x7A: input.KeyF1,
+	0x7B: input.KeyLeft,
+	0x7C: input.KeyRight,
+	0x7D: input.KeyDown,
+	0x7E: input.KeyUp,
+}
+
+// darwinPunctuation maps the characters of punctuation keys to keys.
+var darwinPunctuation = map[rune]input.Key{
+	'-':  input.KeyMinus,
+	'=':  input.KeyEqual,
+	'[':  input.KeyLeftBracket,
+	']':  input.KeyRightBracket,
+	'\\': input.KeyBackslash,
+	';':  input.KeySemicolon,
+	'\'': input.KeyApostrophe,
+	'`':  input.KeyGrave,
+	',':  input.KeyComma,
+	'.':  input.KeyPeriod,
+	'/':  input.KeySlash,
+}
+
+// darwinKey returns the key for a virtual key code in the current layout.
+// Keys in the character area of the keyboard are identified by the
+// character they type, so Dvorak and AZERTY report the labeled key.
+// Keys whose character has no Key (e.g. "é" or the shifted digits of
+// AZERTY) keep their positional mapping, as do all other keys.
+func darwinKey(keyCode uint16, characters string) input.Key {
+	key, ok := darwinKeyCodes[keyCode]
+	if !ok {
+		return input.KeyUnknown
+	}
+	if !isCharacterKey(key) {
+		return key
+	}
+
+	r, size := utf8.DecodeRuneInString(characters)
+	if size == 0 || size != len(characters) {
+		return key
+	}
+
+	// Characters ignore modifiers except Shift, so fold case
+	switch r = unicode.ToLower(r); {
+	case r >= 'a' && r <= 'z':
+		return input.KeyA + input.Key(r-'a')
+	case r >= '0' && r <= '9':
+		return input.Key0 + input.Key(r-'0')
+	}
+	if k, ok := darwinPunctuation[r]; ok {
+		return k
+	}
+	return key
+}
+
+// isCharacterKey reports whether the key types a letter, digit or
+// punctuation mark, so its meaning depends on the keyboard layout.
+func isCharacterKey(key input.Key) bool {
+	return (key >= input.Key0 && key <= input.KeyZ) ||
+		(key >= input.KeyMinus && key <= input.KeySlash)
+}
+
+// darwinModifiers converts AppKit modifier flags.
+func darwinModifiers(flags darwin.NSEventModifierFlags) input.Modifier {
+	var mods input.Modifier
+	if flags&darwin.NSEventModifierFlagShift != 0 {
+		mods |= input.ModShift
+	}
+	if flags&darwin.NSEventModifierFlagControl != 0 {
+		mods |= input.ModControl
+	}
+	if flags&darwin.NSEventModifierFlagOption != 0 {
+		mods |= input.ModAlt
+	}
+	if flags&darwin.NSEventModifierFlagCommand != 0 {
+		mods |= input.ModSuper
+	}
+	return mods
+}
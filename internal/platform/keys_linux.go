@@ -0,0 +1,297 @@
+//go:build linux
+
+package platform
+
+import (
+	"github.com/gogpu/gogpu/input"
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+	"github.com/gogpu/gogpu/internal/platform/x11"
+)
+
+// x11Keysyms maps unshifted X11 keysyms to keys. The keysyms come from
+// the server's keyboard mapping, so letters follow the keyboard layout.
+// Keypad keys report their navigation keysym while Num Lock is off.
+var x11Keysyms = map[x11.Keysym]input.Key{
+	x11.KeysymBackSpace:    input.KeyBackspace,
+	x11.KeysymTab:          input.KeyTab,
+	x11.KeysymReturn:       input.KeyEnter,
+	x11.KeysymPause:        input.KeyPause,
+	x11.KeysymScrollLock:   input.KeyScrollLock,
+	x11.KeysymEscape:       input.KeyEscape,
+	x11.KeysymDelete:       input.KeyDelete,
+	x11.KeysymHome:         input.KeyHome,
+	x11.KeysymLeft:         input.KeyLeft,
+	x11.KeysymUp:           input.KeyUp,
+	x11.KeysymRight:        input.KeyRight,
+	x11.KeysymDown:         input.KeyDown,
+	x11.KeysymPageUp:       input.KeyPageUp,
+	x11.KeysymPageDown:     input.KeyPageDown,
+	x11.KeysymEnd:          input.KeyEnd,
+	x11.KeysymPrint:        input.KeyPrintScreen,
+	x11.KeysymInsert:       input.KeyInsert,
+	x11.KeysymNumLock:      input.KeyNumLock,
+	x11.KeysymKPEnter:      input.KeyNumpadEnter,
+	x11.KeysymKPMultiply:   input.KeyNumpadMultiply,
+	x11.KeysymKPAdd:        input.KeyNumpadAdd,
+	x11.KeysymKPSubtract:   input.KeyNumpadSubtract,
+	x11.KeysymKPDecimal:    input.KeyNumpadDecimal,
+	x11.KeysymKPDivide:     input.KeyNumpadDivide,
+	x11.KeysymKP0:          input.KeyNumpad0,
+	x11.KeysymKP1:          input.KeyNumpad1,
+	x11.KeysymKP2:          input.KeyNumpad2,
+	x11.KeysymKP3:          input.KeyNumpad3,
+	x11.KeysymKP4:          input.KeyNumpad4,
+	x11.KeysymKP5:          input.KeyNumpad5,
+	x11.KeysymKP6:          input.KeyNumpad6,
+	x11.KeysymKP7:          input.KeyNumpad7,
+	x11.KeysymKP8:          input.KeyNumpad8,
+	x11.KeysymKP9:          input.KeyNumpad9,
+	x11.KeysymKPInsert:     input.KeyNumpad0, // Num Lock off
+	x11.KeysymKPEnd:        input.KeyNumpad1,
+	x11.KeysymKPDown:       input.KeyNumpad2,
+	x11.KeysymKPPageDown:   input.KeyNumpad3,
+	x11.KeysymKPLeft:       input.KeyNumpad4,
+	x11.KeysymKPBegin:      input.KeyNumpad5,
+	x11.KeysymKPRight:      input.KeyNumpad6,
+	x11.KeysymKPHome:       input.KeyNumpad7,
+	x11.KeysymKPUp:         input.KeyNumpad8,
+	x11.KeysymKPPageUp:     input.KeyNumpad9,
+	x11.KeysymKPDelete:     input.KeyNumpadDecimal,
+	x11.KeysymF1:           input.KeyF1,
+	x11.KeysymF2:           input.KeyF2,
+	x11.KeysymF3:           input.KeyF3,
+	x11.KeysymF4:           input.KeyF4,
+	x11.KeysymF5:           input.KeyF5,
+	x11.KeysymF6:           input.KeyF6,
+	x11.KeysymF7:           input.KeyF7,
+	x11.KeysymF8:           input.KeyF8,
+	x11.KeysymF9:           input.KeyF9,
+	x11.KeysymF10:          input.KeyF10,
+	x11.KeysymF11:          input.KeyF11,
+	x11.KeysymF12:          input.KeyF12,
+	x11.KeysymShiftL:       input.KeyShiftLeft,
+	x11.KeysymShiftR:       input.KeyShiftRight,
+	x11.KeysymControlL:     input.KeyControlLeft,
+	x11.KeysymControlR:     input.KeyControlRight,
+	x11.KeysymCapsLock:     input.KeyCapsLock,
+	x11.KeysymAltL:         input.KeyAltLeft,
+	x11.KeysymAltR:         input.KeyAltRight,
+	x11.KeysymSuperL:       input.KeySuperLeft,
+	x11.KeysymSuperR:       input.KeySuperRight,
+	x11.KeysymSpace:        input.KeySpace,
+	x11.KeysymApostrophe:   input.KeyApostrophe,
+	x11.KeysymComma:        input.KeyComma,
+	x11.KeysymMinus:        input.KeyMinus,
+	x11.KeysymPeriod:       input.KeyPeriod,
+	x11.KeysymSlash:        input.KeySlash,
+	x11.Keysym0:            input.Key0,
+	x11.Keysym1:            input.Key1,
+	x11.Keysym2:            input.Key2,
+	x11.Keysym3:            input.Key3,
+	x11.Keysym4:            input.Key4,
+	x11.Keysym5:            input.Key5,
+	x11.Keysym6:            input.Key6,
+	x11.Keysym7:            input.Key7,
+	x11.Keysym8:            input.Key8,
+	x11.Keysym9:            input.Key9,
+	x11.KeysymSemicolon:    input.KeySemicolon,
+	x11.KeysymEqual:        input.KeyEqual,
+	x11.KeysymBracketLeft:  input.KeyLeftBracket,
+	x11.KeysymBackslash:    input.KeyBackslash,
+	x11.KeysymBracketRight: input.KeyRightBracket,
+	x11.KeysymGrave:        input.KeyGrave,
+	x11.Keysyma:            input.KeyA,
+	x11.Keysymb:            input.KeyB,
+	x11.Keysymc:            input.KeyC,
+	x11.Keysymd:            input.KeyD,
+	x11.Keysyme:            input.KeyE,
+	x11.Keysymf:            input.KeyF,
+	x11.Keysymg:            input.KeyG,
+	x11.Keysymh:            input.KeyH,
+	x11.Keysymi:            input.KeyI,
+	x11.Keysymj:            input.KeyJ,
+	x11.Keysymk:            input.KeyK,
+	x11.Keysyml:            input.KeyL,
+	x11.Keysymm:            input.KeyM,
+	x11.Keysymn:            input.KeyN,
+	x11.Keysymo:            input.KeyO,
+	x11.Keysymp:            input.KeyP,
+	x11.Keysymq:            input.KeyQ,
+	x11.Keysymr:            input.KeyR,
+	x11.Keysyms:            input.KeyS,
+	x11.Keysymt:            input.KeyT,
+	x11.Keysymu:            input.KeyU,
+	x11.Keysymv:            input.KeyV,
+	x11.Keysymw:            input.KeyW,
+	x11.Keysymx:            input.KeyX,
+	x11.Keysymy:            input.KeyY,
+	x11.Keysymz:            input.KeyZ,
+}
+
+// evdevKeys maps Linux evdev key codes, as sent by wl_keyboard, to keys.
+// Evdev codes name physical positions on a US keyboard; without an XKB
+// keymap parser the layout is not applied.
+var evdevKeys = map[uint32]input.Key{
+	1:   input.KeyEscape,
+	2:   input.Key1,
+	3:   input.Key2,
+	4:   input.Key3,
+	5:   input.Key4,
+	6:   input.Key5,
+	7:   input.Key6,
+	8:   input.Key7,
+	9:   input.Key8,
+	10:  input.Key9,
+	11:  input.Key0,
+	12:  input.KeyMinus,
+	13:  input.KeyEqual,
+	14:  input.KeyBackspace,
+	15:  input.KeyTab,
+	16:  input.KeyQ,
+	17:  input.KeyW,
+	18:  input.KeyE,
+	19:  input.KeyR,
+	20:  input.KeyT,
+	21:  input.KeyY,
+	22:  input.KeyU,
+	23:  input.KeyI,
+	24:  input.KeyO,
+	25:  input.KeyP,
+	26:  input.KeyLeftBracket,
+	27:  input.KeyRightBracket,
+	28:  input.KeyEnter,
+	29:  input.KeyControlLeft,
+	30:  input.KeyA,
+	31:  input.KeyS,
+	32:  input.KeyD,
+	33:  input.KeyF,
+	34:  input.KeyG,
+	35:  input.KeyH,
+	36:  input.KeyJ,
+	37:  input.KeyK,
+	38:  input.KeyL,
+	39:  input.KeySemicolon,
+	40:  input.KeyApostrophe,
+	41:  input.KeyGrave,
+	42:  input.KeyShiftLeft,
+	43:  input.KeyBackslash,
+	44:  input.KeyZ,
+	45:  input.KeyX,
+	46:  input.KeyC,
+	47:  input.KeyV,
+	48:  input.KeyB,
+	49:  input.KeyN,
+	50:  input.KeyM,
+	51:  input.KeyComma,
+	52:  input.KeyPeriod,
+	53:  input.KeySlash,
+	54:  input.KeyShiftRight,
+	55:  input.KeyNumpadMultiply,
+	56:  input.KeyAltLeft,
+	57:  input.KeySpace,
+	58:  input.KeyCapsLock,
+	59:  input.KeyF1,
+	60:  input.KeyF2,
+	61:  input.KeyF3,
+	62:  input.KeyF4,
+	63:  input.KeyF5,
+	64:  input.KeyF6,
+	65:  input.KeyF7,
+	66:  input.KeyF8,
+	67:  input.KeyF9,
+	68:  input.KeyF10,
+	69:  input.KeyNumLock,
+	70:  input.KeyScrollLock,
+	71:  input.KeyNumpad7,
+	72:  input.KeyNumpad8,
+	73:  input.KeyNumpad9,
+	74:  input.KeyNumpadSubtract,
+	75:  input.KeyNumpad4,
+	76:  input.KeyNumpad5,
+	77:  input.KeyNumpad6,
+	78:  input.KeyNumpadAdd,
+	79:  input.KeyNumpad1,
+	80:  input.KeyNumpad2,
+	81:  input.KeyNumpad3,
+	82:  input.KeyNumpad0,
+	83:  input.KeyNumpadDecimal,
+	87:  input.KeyF11,
+	88:  input.KeyF12,
+	96:  input.KeyNumpadEnter,
+	97:  input.KeyControlRight,
+	98:  input.KeyNumpadDivide,
+	99:  input.KeyPrintScreen,
+	100: input.KeyAltRight,
+	102: input.KeyHome,
+	103: input.KeyUp,
+	104: input.KeyPageUp,
+	105: input.KeyLeft,
+	106: input.KeyRight,
+	107: input.KeyEnd,
+	108: input.KeyDown,
+	109: input.KeyPageDown,
+	110: input.KeyInsert,
+	111: input.KeyDelete,
+	119: input.KeyPause,
+	125: input.KeySuperLeft,
+	126: input.KeySuperRight,
+}
+
+// x11Modifiers converts an X11 key and button mask to modifiers. Mod1
+// and Mod4 are Alt and Super in the usual modifier mapping.
+func x11Modifiers(state uint16) input.Modifier {
+	var mods input.Modifier
+	if state&x11.ModifierShift != 0 {
+		mods |= input.ModShift
+	}
+	if state&x11.ModifierControl != 0 {
+		mods |= input.ModControl
+	}
+	if state&x11.ModifierMod1 != 0 {
+		mods |= input.ModAlt
+	}
+	if state&x11.ModifierMod4 != 0 {
+		mods |= input.ModSuper
+	}
+	return mods
+}
+
+// waylandModifiers converts the depressed modifiers of a wl_keyboard
+// modifiers event, which use the X11 modifier bits of the standard keymap.
+func waylandModifiers(depressed uint32) input.Modifier {
+	return x11Modifiers(uint16(depressed))
+}
+
+// keyModifiers updates modifiers reported before a key event, as X11 and
+// Wayland do, to include a modifier key's own press or release.
+func keyModifiers(mods input.Modifier, key input.Key, down bool) input.Modifier {
+	var mod input.Modifier
+	switch key {
+	case input.KeyShiftLeft, input.KeyShiftRight:
+		mod = input.ModShift
+	case input.KeyControlLeft, input.KeyControlRight:
+		mod = input.ModControl
+	case input.KeyAltLeft, input.KeyAltRight:
+		mod = input.ModAlt
+	case input.KeySuperLeft, input.KeySuperRight:
+		mod = input.ModSuper
+	default:
+		return mods
+	}
+	if down {
+		return mods | mod
+	}
+	return mods &^ mod
+}
+
+// waylandScrollStep is the wl_pointer axis value of one wheel step.
+const waylandScrollStep = 10
+
+// waylandButtons maps evdev pointer button codes to mouse buttons.
+var waylandButtons = map[uint32]input.MouseButton{
+	wayland.ButtonLeft:   input.MouseButtonLeft,
+	wayland.ButtonRight:  input.MouseButtonRight,
+	wayland.ButtonMiddle: input.MouseButtonMiddle,
+	wayland.ButtonSide:   input.MouseButton4,
+	wayland.ButtonExtra:  input.MouseButton5,
+}
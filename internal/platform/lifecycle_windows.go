@@ -0,0 +1,171 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Window lifecycle constants
+const (
+	wmActivate = 0x0006
+	waInactive = 0
+
+	sizeRestored  = 0
+	sizeMinimized = 1
+	sizeMaximized = 2
+
+	wmWtsSessionChange = 0x02B1
+	wtsSessionLock     = 0x7
+	wtsSessionUnlock   = 0x8
+	notifyForThisSess  = 0
+
+	wmPowerBroadcast       = 0x0218
+	pbtApmSuspend          = 0x0004
+	pbtApmResumeAutomatic  = 0x0012
+	pbtPowerSettingChange  = 0x8013
+	deviceNotifyWindowHndl = 0
+
+	// GUID_CONSOLE_DISPLAY_STATE values
+	displayStateOff = 0
+)
+
+var (
+	wtsapi32 = windows.NewLazyDLL("wtsapi32.dll")
+
+	procWTSRegisterSessionNotification   = wtsapi32.NewProc("WTSRegisterSessionNotification")
+	procWTSUnRegisterSessionNotification = wtsapi32.NewProc("WTSUnRegisterSessionNotification")
+	procRegisterPowerSettingNotification = user32.NewProc("RegisterPowerSettingNotification")
+	procUnregisterPowerSettingNotif      = user32.NewProc("UnregisterPowerSettingNotification")
+
+	// GUID_CONSOLE_DISPLAY_STATE: the display is on, off or dimmed
+	guidConsoleDisplayState = windows.GUID{Data1: 0x6FE69556, Data2: 0x704A, Data3: 0x47A0, Data4: [8]byte{0x8F, 0x24, 0xC2, 0x8D, 0x93, 0x6F, 0xDA, 0x47}}
+)
+
+// powerBroadcastSetting is the header of the Win32 POWERBROADCAST_SETTING
+// structure, followed by DataLength bytes of data.
+type powerBroadcastSetting struct {
+	powerSetting windows.GUID
+	dataLength   uint32
+	data         [4]byte
+}
+
+// registerLifecycleNotifications asks for session lock and display power
+// notifications, which hide the window without minimizing it.
+func (p *windowsPlatform) registerLifecycleNotifications() {
+	if procWTSRegisterSessionNotification.Find() == nil {
+		procWTSRegisterSessionNotification.Call(uintptr(p.hwnd), notifyForThisSess)
+	}
+	if procRegisterPowerSettingNotification.Find() == nil {
+		p.displayNotify, _, _ = procRegisterPowerSettingNotification.Call(uintptr(p.hwnd),
+			uintptr(unsafe.Pointer(&guidConsoleDisplayState)), deviceNotifyWindowHndl)
+	}
+}
+
+// unregisterLifecycleNotifications undoes registerLifecycleNotifications.
+func (p *windowsPlatform) unregisterLifecycleNotifications() {
+	if procWTSUnRegisterSessionNotification.Find() == nil {
+		procWTSUnRegisterSessionNotification.Call(uintptr(p.hwnd))
+	}
+	if p.displayNotify != 0 {
+		procUnregisterPowerSettingNotif.Call(p.displayNotify)
+		p.displayNotify = 0
+	}
+}
+
+// handleActivate queues focus changes and suspends the mouse capture
+// while the window is inactive.
+func (p *windowsPlatform) handleActivate(wParam uintptr) {
+	active := wParam&0xFFFF != waInactive
+	if active == p.active {
+		return
+	}
+	p.active = active
+	p.updateMouseCapture()
+
+	if active {
+		p.queueWindowState(WindowFocused)
+	} else {
+		p.queueWindowState(WindowUnfocused)
+	}
+}
+
+// handleSizeState queues minimize, restore and maximize transitions from
+// the WM_SIZE request type.
+func (p *windowsPlatform) handleSizeState(sizeType uintptr) {
+	previous := p.sizeType
+	if sizeType > sizeMaximized || sizeType == previous {
+		return // WM_SIZE also reports other windows being maximized
+	}
+	p.sizeType = sizeType
+
+	switch {
+	case sizeType == sizeMinimized:
+		p.queueWindowState(WindowMinimized)
+	case previous == sizeMinimized:
+		p.queueWindowState(WindowRestored)
+		// Restored straight to maximized
+		if sizeType == sizeMaximized {
+			p.queueWindowState(WindowMaximized)
+		}
+	case sizeType == sizeMaximized:
+		p.queueWindowState(WindowMaximized)
+	default:
+		p.queueWindowState(WindowUnmaximized)
+	}
+}
+
+// handleSessionChange treats a locked session as hiding the window.
+func (p *windowsPlatform) handleSessionChange(wParam uintptr) {
+	switch wParam {
+	case wtsSessionLock:
+		p.sessionLocked = true
+	case wtsSessionUnlock:
+		p.sessionLocked = false
+	default:
+		return
+	}
+	p.updateOcclusion()
+}
+
+// handlePowerBroadcast queues sleep and wake, and treats a display turned
+// off as hiding the window.
+func (p *windowsPlatform) handlePowerBroadcast(wParam, lParam uintptr) {
+	switch wParam {
+	case pbtApmSuspend:
+		p.queueEvent(Event{Type: EventSleep})
+	case pbtApmResumeAutomatic:
+		p.queueEvent(Event{Type: EventWake})
+	case pbtPowerSettingChange:
+		setting := *(**powerBroadcastSetting)(unsafe.Pointer(&lParam))
+		if setting.powerSetting != guidConsoleDisplayState || setting.dataLength < 1 {
+			return
+		}
+		p.displayOff = setting.data[0] == displayStateOff
+		p.updateOcclusion()
+	}
+}
+
+// updateOcclusion queues WindowOccluded or WindowVisible when the window
+// becomes hidden by a locked session or a display turned off, or visible
+// again.
+func (p *windowsPlatform) updateOcclusion() {
+	occluded := p.sessionLocked || p.displayOff
+	if occluded == p.occluded {
+		return
+	}
+	p.occluded = occluded
+
+	if occluded {
+		p.queueWindowState(WindowOccluded)
+	} else {
+		p.queueWindowState(WindowVisible)
+	}
+}
+
+// queueWindowState queues a main window state change.
+func (p *windowsPlatform) queueWindowState(state WindowState) {
+	p.queueEvent(Event{Type: EventWindowState, State: state})
+}
@@ -0,0 +1,181 @@
+//go:build windows
+
+package platform
+
+import (
+	"sort"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Monitor constants
+const (
+	wmDisplayChange  = 0x007E
+	wmSettingChange  = 0x001A
+	spiSetWorkArea   = 0x002F
+	monitorfPrimary  = 0x00000001
+	mdtEffectiveDPI  = 0
+	enumCurrentMode  = 0xFFFFFFFF // ENUM_CURRENT_SETTINGS
+	cchDeviceName    = 32
+	cchFormName      = 32
+	defaultFrequency = 1 // dmDisplayFrequency of 0 or 1: hardware default
+)
+
+var (
+	procEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+	procEnumDisplaySettings = user32.NewProc("EnumDisplaySettingsW")
+	procEnumDisplayDevices  = user32.NewProc("EnumDisplayDevicesW")
+	procGetDpiForMonitor    = shcore.NewProc("GetDpiForMonitor")
+
+	// Created once: Windows callbacks are never freed
+	enumMonitorsCallback = windows.NewCallback(enumMonitorsProc)
+)
+
+// monitorInfoEx is the Win32 MONITORINFOEXW structure.
+type monitorInfoEx struct {
+	monitorInfo
+	szDevice [cchDeviceName]uint16
+}
+
+// devMode is the display part of the Win32 DEVMODEW structure.
+type devMode struct {
+	dmDeviceName         [cchDeviceName]uint16
+	dmSpecVersion        uint16
+	dmDriverVersion      uint16
+	dmSize               uint16
+	dmDriverExtra        uint16
+	dmFields             uint32
+	dmPositionX          int32
+	dmPositionY          int32
+	dmDisplayOrientation uint32
+	dmDisplayFixedOutput uint32
+	dmColor              int16
+	dmDuplex             int16
+	dmYResolution        int16
+	dmTTOption           int16
+	dmCollate            int16
+	dmFormName           [cchFormName]uint16
+	dmLogPixels          uint16
+	dmBitsPerPel         uint32
+	dmPelsWidth          uint32
+	dmPelsHeight         uint32
+	dmDisplayFlags       uint32
+	dmDisplayFrequency   uint32
+	dmICMMethod          uint32
+	dmICMIntent          uint32
+	dmMediaType          uint32
+	dmDitherType         uint32
+	dmReserved1          uint32
+	dmReserved2          uint32
+	dmPanningWidth       uint32
+	dmPanningHeight      uint32
+}
+
+// displayDevice is the Win32 DISPLAY_DEVICEW structure.
+type displayDevice struct {
+	cb           uint32
+	deviceName   [32]uint16
+	deviceString [128]uint16
+	stateFlags   uint32
+	deviceID     [128]uint16
+	deviceKey    [128]uint16
+}
+
+// enumMonitorsProc is the MONITORENUMPROC for EnumDisplayMonitors. lParam
+// points to the slice collecting the monitor handles.
+func enumMonitorsProc(hmonitor, _, _, lParam uintptr) uintptr {
+	handles := *(**[]uintptr)(unsafe.Pointer(&lParam))
+	*handles = append(*handles, hmonitor)
+	return 1
+}
+
+// Monitors returns the attached monitors, primary first. Positions and
+// sizes are in pixels, as the process is DPI aware.
+func (p *windowsPlatform) Monitors() []Monitor {
+	var handles []uintptr
+	procEnumDisplayMonitors.Call(0, 0, enumMonitorsCallback, uintptr(unsafe.Pointer(&handles)))
+
+	monitors := make([]Monitor, 0, len(handles))
+	for _, handle := range handles {
+		if m, ok := monitorFromHandle(handle); ok {
+			monitors = append(monitors, m)
+		}
+	}
+
+	sort.SliceStable(monitors, func(i, j int) bool {
+		return monitors[i].Primary && !monitors[j].Primary
+	})
+	return monitors
+}
+
+// monitorFromHandle describes the monitor with the given HMONITOR.
+func monitorFromHandle(handle uintptr) (Monitor, bool) {
+	info := monitorInfoEx{}
+	info.cbSize = uint32(unsafe.Sizeof(info))
+	if ret, _, _ := procGetMonitorInfoW.Call(handle, uintptr(unsafe.Pointer(&info))); ret == 0 {
+		return Monitor{}, false
+	}
+
+	r, work := info.rcMonitor, info.rcWork
+	m := Monitor{
+		Name:       monitorName(info.szDevice[:]),
+		X:          int(r.left),
+		Y:          int(r.top),
+		Width:      int(r.right - r.left),
+		Height:     int(r.bottom - r.top),
+		WorkX:      int(work.left),
+		WorkY:      int(work.top),
+		WorkWidth:  int(work.right - work.left),
+		WorkHeight: int(work.bottom - work.top),
+		Scale:      float64(monitorDPI(handle)) / defaultDPI,
+		Primary:    info.dwFlags&monitorfPrimary != 0,
+	}
+
+	mode := devMode{}
+	mode.dmSize = uint16(unsafe.Sizeof(mode))
+	ret, _, _ := procEnumDisplaySettings.Call(uintptr(unsafe.Pointer(&info.szDevice[0])), enumCurrentMode,
+		uintptr(unsafe.Pointer(&mode)))
+	if ret != 0 && mode.dmDisplayFrequency > defaultFrequency {
+		m.RefreshRate = float64(mode.dmDisplayFrequency)
+	}
+	return m, true
+}
+
+// monitorName returns the name of the monitor attached to a display
+// adapter output such as \\.\DISPLAY1, or the output name if the monitor
+// is unknown.
+func monitorName(device []uint16) string {
+	dd := displayDevice{}
+	dd.cb = uint32(unsafe.Sizeof(dd))
+	ret, _, _ := procEnumDisplayDevices.Call(uintptr(unsafe.Pointer(&device[0])), 0, uintptr(unsafe.Pointer(&dd)), 0)
+	if ret != 0 {
+		if name := windows.UTF16ToString(dd.deviceString[:]); name != "" {
+			return name
+		}
+	}
+	return windows.UTF16ToString(device)
+}
+
+// monitorDPI returns the effective DPI of a monitor (Windows 8.1 and
+// later), or the default DPI.
+func monitorDPI(handle uintptr) uint32 {
+	if procGetDpiForMonitor.Find() != nil {
+		return defaultDPI
+	}
+	var dpiX, dpiY uint32
+	ret, _, _ := procGetDpiForMonitor.Call(handle, mdtEffectiveDPI,
+		uintptr(unsafe.Pointer(&dpiX)), uintptr(unsafe.Pointer(&dpiY)))
+	if ret != 0 || dpiX == 0 { // not S_OK
+		return defaultDPI
+	}
+	return dpiX
+}
+
+// handleDisplayChange reports a change of the display configuration:
+// resolution or refresh rate, or monitors attached, removed or rearranged.
+// The surface is reset, as presentation may have been tied to the old mode.
+func (p *windowsPlatform) handleDisplayChange() {
+	p.queueEvent(Event{Type: EventMonitors})
+	p.queueEvent(Event{Type: EventDisplayReconfigured})
+}
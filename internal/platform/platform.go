@@ -1,6 +1,18 @@
 // Package platform provides OS-specific windowing abstraction.
 package platform
 
+import (
+	"errors"
+	"image"
+	"time"
+
+	"github.com/gogpu/gogpu/input"
+)
+
+// ErrUnsupported is returned when an operation is not supported
+// by the current platform or compositor.
+var ErrUnsupported = errors.New("platform: operation not supported")
+
 // Config holds platform-agnostic window configuration.
 type Config struct {
 	Title      string
@@ -8,13 +20,67 @@ type Config struct {
 	Height     int
 	Resizable  bool
 	Fullscreen bool
+
+	// Transparent makes the window background transparent where the
+	// rendered frame has alpha below 1.
+	Transparent bool
+
+	// Titlebar selects the title bar style.
+	Titlebar TitlebarStyle
+
+	// PaceToDisplay asks platforms implementing FramePacer to
+	// pace frames to the refresh rate of the window's display.
+	PaceToDisplay bool
+
+	// ContentType hints the compositor at what the window shows.
+	ContentType ContentType
 }
 
+// ContentType describes the kind of content a window shows, so the
+// compositor can favor latency, smoothness or color accuracy. The values
+// match those of the Wayland content-type-v1 protocol.
+type ContentType uint8
+
+const (
+	ContentTypeNone  ContentType = iota // No hint (default)
+	ContentTypePhoto                    // Still pictures
+	ContentTypeVideo                    // Video or animation
+	ContentTypeGame                     // Interactive content
+)
+
+// TitlebarStyle selects how the window title bar is drawn.
+type TitlebarStyle uint8
+
+const (
+	TitlebarDefault     TitlebarStyle = iota // Standard title bar
+	TitlebarTransparent                      // Content extends under a transparent title bar
+	TitlebarHidden                           // Like TitlebarTransparent without the title text
+)
+
+// WindowID identifies a window of a Platform.
+type WindowID uint32
+
+// MainWindow is the window created by Platform.Init.
+const MainWindow WindowID = 0
+
 // Event represents a platform event.
 type Event struct {
-	Type   EventType
-	Width  int // for resize events
-	Height int // for resize events
+	Type    EventType
+	Window  WindowID     // window the event belongs to
+	Width   int          // for resize events
+	Height  int          // for resize events
+	Pen     PenEvent     // for pen events
+	Gesture GestureEvent // for gesture events
+	Drop    DropEvent    // for file drop events
+	MenuID  int          // for menu events: MenuItem.ID of the selected item
+	Text    TextEvent    // for text input events
+	State   WindowState  // for window state events
+	Dark    bool         // for theme events: the new appearance is dark
+	Key     KeyEvent     // for key events
+	Scale   float64      // for scale events: the new content scale factor
+	Gamepad GamepadEvent // for gamepad events
+	Touch   TouchEvent   // for touch events
+	Mouse   MouseEvent   // for mouse button, move and scroll events
 }
 
 // EventType represents the type of platform event.
@@ -24,6 +90,303 @@ const (
 	EventNone EventType = iota
 	EventClose
 	EventResize
+	EventPen
+	EventGesture
+	EventDrop
+	EventMenu
+	EventText
+	EventWindowState
+	EventMonitors // Monitors were connected, disconnected or reconfigured
+	EventTheme    // System switched between light and dark appearance
+
+	// EventSleep is sent before the system sleeps and EventWake after it
+	// wakes up. Surfaces may hold stale drawables after waking.
+	EventSleep
+	EventWake
+
+	// EventDisplayReconfigured is sent after a display reconfiguration
+	// that can invalidate drawables, such as a mode change or a switch
+	// between integrated and discrete GPU.
+	EventDisplayReconfigured
+
+	EventKey     // Key pressed or released
+	EventScale   // Content scale of the main window changed, e.g. moved to a monitor with another DPI
+	EventGamepad // Gamepad connected or disconnected
+	EventTouch   // Touchscreen contact began, moved or ended
+
+	EventMouseButton // Mouse button pressed or released
+	EventMouseMove   // Mouse moved over the window
+	EventScroll      // Mouse wheel or touchpad scrolled
+)
+
+// PenPhase describes what changed in a PenEvent.
+type PenPhase uint8
+
+const (
+	PenPhaseMove         PenPhase = iota // Position/pressure/tilt changed
+	PenPhaseProximityIn                  // Tool entered detection range
+	PenPhaseProximityOut                 // Tool left detection range
+	PenPhaseDown                         // Tip touched the surface
+	PenPhaseUp                           // Tip lifted from the surface
+)
+
+// PenTool identifies the physical stylus tool.
+type PenTool uint8
+
+const (
+	PenToolPen PenTool = iota
+	PenToolEraser
+	PenToolBrush
+	PenToolPencil
+	PenToolAirbrush
+	PenToolOther
+)
+
+// PenButtons is a bitmask of stylus barrel buttons.
+type PenButtons uint8
+
+const (
+	PenButtonPrimary   PenButtons = 1 << iota // First barrel button
+	PenButtonSecondary                        // Second barrel button
+	PenButtonTertiary                         // Third barrel button
+)
+
+// PenEvent describes stylus input from a graphics tablet or pen display.
+type PenEvent struct {
+	Phase    PenPhase
+	Tool     PenTool
+	X, Y     float64    // Window coordinates in pixels
+	Pressure float64    // Tip pressure [0, 1]; 0 when not touching
+	Distance float64    // Hover distance [0, 1], if supported
+	TiltX    float64    // Tilt in degrees, positive toward the right
+	TiltY    float64    // Tilt in degrees, positive toward the user
+	Rotation float64    // Barrel rotation in degrees, if supported
+	Contact  bool       // Tip touches the surface
+	Buttons  PenButtons // Held barrel buttons
+}
+
+// TouchPhase describes what changed in a TouchEvent.
+type TouchPhase uint8
+
+const (
+	TouchPhaseBegin  TouchPhase = iota // Contact touched the screen
+	TouchPhaseMove                     // Contact moved or its pressure changed
+	TouchPhaseEnd                      // Contact lifted from the screen
+	TouchPhaseCancel                   // Contact was taken over by the system
+)
+
+// TouchEvent describes one contact on a touchscreen. Each finger is a
+// separate contact with its own ID, valid from TouchPhaseBegin until
+// TouchPhaseEnd or TouchPhaseCancel; IDs may be reused afterwards.
+type TouchEvent struct {
+	Phase    TouchPhase
+	ID       uint32
+	X, Y     float64 // Window coordinates in pixels
+	Pressure float64 // Contact pressure [0, 1]; 1 while touching if not supported
+}
+
+// GestureKind identifies a touchpad gesture.
+type GestureKind uint8
+
+const (
+	GestureMagnify      GestureKind = iota // Two-finger pinch
+	GestureRotate                          // Two-finger rotation
+	GestureSwipe                           // Multi-finger swipe
+	GestureSmartMagnify                    // Two-finger double tap
+)
+
+// GesturePhase describes the lifecycle stage of a GestureEvent.
+type GesturePhase uint8
+
+const (
+	GesturePhaseNone   GesturePhase = iota // Discrete gesture (swipe, smart magnify)
+	GesturePhaseBegin                      // Continuous gesture started
+	GesturePhaseChange                     // Continuous gesture updated
+	GesturePhaseEnd                        // Continuous gesture finished
+	GesturePhaseCancel                     // Continuous gesture was cancelled
+)
+
+// GestureEvent describes a high-level touchpad gesture.
+// Magnification and Rotation are deltas since the previous event of the gesture.
+type GestureEvent struct {
+	Kind          GestureKind
+	Phase         GesturePhase
+	X, Y          float64 // Window coordinates in pixels
+	Magnification float64 // Scale delta, e.g. 0.1 for 10% larger (GestureMagnify)
+	Rotation      float64 // Angle delta in degrees, clockwise positive (GestureRotate)
+	DeltaX        float64 // Swipe direction, -1, 0 or 1 (GestureSwipe)
+	DeltaY        float64 // Swipe direction, -1, 0 or 1 (GestureSwipe)
+}
+
+// KeyEvent describes a key press or release.
+//
+// Key follows the current keyboard layout for keys that type a letter,
+// digit or punctuation mark, so the key labeled A is KeyA on QWERTY and
+// AZERTY alike. Scancode identifies the physical key regardless of the
+// layout, for positional bindings such as WASD; its values are
+// platform-specific.
+type KeyEvent struct {
+	Key       input.Key
+	Scancode  uint32
+	Down      bool           // Pressed; false for a release
+	Repeat    bool           // Auto-repeat of a held key
+	Modifiers input.Modifier // Modifiers held, including the key itself
+}
+
+// MouseEvent describes a mouse button press or release, a move, or a
+// scroll. X and Y are the pointer position in window pixels for all three.
+//
+// Scroll deltas are in wheel steps, or fractions of one for touchpads
+// and high-resolution wheels; positive ScrollY scrolls up (content moves
+// down) and positive ScrollX scrolls right.
+type MouseEvent struct {
+	X, Y      float64
+	Button    input.MouseButton // for button events
+	Down      bool              // for button events: pressed; false for a release
+	ScrollX   float64           // for scroll events
+	ScrollY   float64           // for scroll events
+	Modifiers input.Modifier    // Modifiers held
+}
+
+// DropPhase describes the stage of a file drag-and-drop.
+type DropPhase uint8
+
+const (
+	DropPhaseDrop  DropPhase = iota // Files were dropped on the window
+	DropPhaseEnter                  // Drag entered the window
+	DropPhaseOver                   // Drag moved over the window
+	DropPhaseLeave                  // Drag left the window or was cancelled
+)
+
+// DropEvent describes files being dragged onto or dropped on a window.
+type DropEvent struct {
+	Phase DropPhase
+	Paths []string // Dropped file paths (DropPhaseDrop only)
+	X, Y  float64  // Window coordinates in pixels
+}
+
+// GamepadEvent describes a gamepad connection change.
+type GamepadEvent struct {
+	Slot      int  // Gamepad slot, 0 to input.MaxGamepads-1
+	Connected bool // Connected, or disconnected
+}
+
+// Gamepad is a snapshot of a gamepad's buttons and axes, with dead zones
+// applied. Axis ranges are described at input.GamepadAxis.
+type Gamepad struct {
+	Name    string // Product name, as reported by the driver
+	Buttons [input.GamepadButtonCount]bool
+	Axes    [input.GamepadAxisCount]float32
+}
+
+// TextEvent describes text input, including input method composition.
+// While composing (IME preedit or a pending dead key), Text is the whole
+// composition string and replaces the previous one; an empty Text ends the
+// composition without input. Committed text has Composing unset.
+type TextEvent struct {
+	Text      string
+	Composing bool
+	Cursor    int // Caret position within a composing Text, in runes
+}
+
+// WindowState describes a change of a window's state.
+type WindowState uint8
+
+const (
+	WindowStateNone         WindowState = iota
+	WindowMinimized                     // Minimized (iconified)
+	WindowRestored                      // Restored from minimized
+	WindowMaximized                     // Maximized (zoomed on macOS)
+	WindowUnmaximized                   // Left the maximized state
+	WindowFocused                       // Gained keyboard focus
+	WindowUnfocused                     // Lost keyboard focus
+	WindowOccluded                      // Completely hidden by other windows or on another desktop
+	WindowVisible                       // Partly visible again after being occluded
+	WindowScreenChanged                 // Moved to another monitor
+	WindowEnteredFullscreen             // Fullscreen transition finished
+	WindowExitedFullscreen              // Back from fullscreen
+)
+
+// Menu describes a custom top-level menu in the application menu bar.
+type Menu struct {
+	Title string
+	Items []MenuItem
+}
+
+// MenuItem describes an entry of a Menu.
+type MenuItem struct {
+	ID        int    // Reported in Event.MenuID when selected
+	Title     string // Ignored for separators
+	Shortcut  string // Key pressed with the platform command modifier, e.g. "s"; empty for none
+	Separator bool   // Draws a separator line instead of an item
+}
+
+// Monitor describes a display attached to the system.
+// Positions and sizes are in the platform's desktop coordinate space, with
+// the origin at the top-left corner of the primary monitor and y down:
+// points on macOS, pixels elsewhere.
+type Monitor struct {
+	Name          string
+	X, Y          int // Top-left corner
+	Width, Height int
+
+	// Work area: the monitor without the menu bar, Dock or taskbar
+	WorkX, WorkY, WorkWidth, WorkHeight int
+
+	Scale       float64 // Pixels per desktop unit (backing scale factor or DPI / 96)
+	RefreshRate float64 // Current refresh rate in Hz; 0 if unknown
+	ColorSpace  string  // Color space name, e.g. "Display P3"; empty if unknown
+	Primary     bool    // Holds the menu bar or taskbar
+}
+
+// FileFilter restricts a file dialog to files with the given extensions.
+type FileFilter struct {
+	Name       string   // Description shown where the platform supports it, e.g. "Images"
+	Extensions []string // Without the leading dot, e.g. "png"
+}
+
+// FileDialog configures a native open or save dialog.
+type FileDialog struct {
+	Title       string
+	Directory   string       // Initial directory; empty for the platform default
+	Filename    string       // Initial file name (save dialogs)
+	Filters     []FileFilter // Empty allows all files
+	Multiple    bool         // Allow selecting several files (open dialogs)
+	Directories bool         // Pick directories instead of files (open dialogs)
+}
+
+// ResizeEdge identifies the window edge or corner grabbed for an interactive resize.
+type ResizeEdge uint8
+
+const (
+	ResizeEdgeNone ResizeEdge = iota
+	ResizeEdgeTop
+	ResizeEdgeBottom
+	ResizeEdgeLeft
+	ResizeEdgeRight
+	ResizeEdgeTopLeft
+	ResizeEdgeTopRight
+	ResizeEdgeBottomLeft
+	ResizeEdgeBottomRight
+)
+
+// CursorShape identifies a standard system cursor.
+type CursorShape uint8
+
+const (
+	CursorDefault    CursorShape = iota // Arrow
+	CursorText                          // I-beam, over editable text
+	CursorCrosshair                     // Precise selection
+	CursorPointer                       // Pointing hand, over links
+	CursorGrab                          // Open hand, over draggable content
+	CursorGrabbing                      // Closed hand, while dragging
+	CursorResizeEW                      // Left-right arrows
+	CursorResizeNS                      // Up-down arrows
+	CursorResizeNWSE                    // Diagonal arrows, top-left to bottom-right
+	CursorResizeNESW                    // Diagonal arrows, top-right to bottom-left
+	CursorResizeAll                     // Four-way arrows, for moving
+	CursorNotAllowed                    // The action is not possible
+	CursorWait                          // Busy
 )
 
 // Platform abstracts OS-specific windowing.
@@ -47,10 +410,294 @@ type Platform interface {
 	// On Linux: (display, window)
 	GetHandle() (instance, window uintptr)
 
+	// SetIcon sets the window icon from tightly packed RGBA pixels
+	// (premultiplied alpha, image.RGBA layout). Returns ErrUnsupported
+	// if the platform cannot change the icon.
+	SetIcon(width, height int, pixels []byte) error
+
+	// BeginMove starts an interactive, system-driven window move.
+	// It uses the most recent pointer button press and must be called
+	// while the button is still held (e.g. from a custom title bar).
+	BeginMove() error
+
+	// BeginResize starts an interactive, system-driven window resize
+	// from the given edge. Same requirements as BeginMove.
+	BeginResize(edge ResizeEdge) error
+
+	// ShowWindowMenu shows the system window menu at (x, y) in
+	// window coordinates, as triggered by the last pointer button press.
+	ShowWindowMenu(x, y int) error
+
 	// Destroy closes the window and releases resources.
 	Destroy()
 }
 
+// WaylandHandles is implemented by platforms whose GetHandle and
+// WindowHandle may return the handles of Wayland windows rather than
+// X11 ones.
+type WaylandHandles interface {
+	// WaylandHandles reports whether the handles are of Wayland windows.
+	WaylandHandles() bool
+}
+
+// FramePacer is implemented by platforms that can pace rendering
+// to the refresh rate of the display the window is on.
+type FramePacer interface {
+	// WaitFrame blocks until the next frame is due.
+	// It returns immediately if Config.PaceToDisplay is false.
+	WaitFrame()
+}
+
+// ScaleReporter is implemented by platforms that report the content
+// scale of the main window: the ratio of pixels to the desktop units
+// used for window sizes. Changes are reported through EventScale.
+type ScaleReporter interface {
+	// ScaleFactor returns the content scale, e.g. 2 on a Retina display
+	// or 1.5 on a Windows monitor at 144 DPI.
+	ScaleFactor() float64
+}
+
+// EventWaiter is implemented by platforms that can block until events
+// arrive, so a main thread that only processes events need not spin.
+type EventWaiter interface {
+	// WaitEvents blocks until at least one OS event has been processed
+	// or the timeout expires. Events are then read with PollEvents.
+	WaitEvents(timeout time.Duration)
+}
+
+// EventWaker is implemented by platforms whose WaitEvents can be ended
+// from another goroutine.
+type EventWaker interface {
+	// WakeEvents ends a WaitEvents in progress, or makes the next one
+	// return at once. Safe to call from any goroutine.
+	WakeEvents()
+}
+
+// FrameScoper is implemented by platforms that scope per-frame OS
+// resources, such as the objects AppKit and Metal autorelease on macOS.
+type FrameScoper interface {
+	// BeginFrameScope opens a scope on the calling thread and returns
+	// the function that closes it, releasing what the frame left behind.
+	// Scopes nest, and must be closed in reverse order on the same thread.
+	BeginFrameScope() (end func())
+}
+
+// WindowManager is implemented by platforms that can open additional
+// windows besides the main window at runtime. Events for these windows
+// carry their WindowID; closing one does not close the application.
+type WindowManager interface {
+	// CreateWindow opens a new window with its own rendering surface.
+	CreateWindow(config Config) (WindowID, error)
+
+	// DestroyWindow closes a window created by CreateWindow.
+	DestroyWindow(id WindowID)
+
+	// WindowSize returns the size of a window in pixels.
+	WindowSize(id WindowID) (width, height int)
+
+	// WindowHandle returns the surface handles of a window, like GetHandle.
+	WindowHandle(id WindowID) (instance, window uintptr)
+}
+
+// WindowController is implemented by platforms that can change windows
+// after creating them. Sizes are in the units of Config.Width and
+// Config.Height, positions in the desktop coordinates of Monitor. A
+// method the window system does not offer returns ErrUnsupported.
+type WindowController interface {
+	// SetWindowTitle changes the title of a window.
+	SetWindowTitle(id WindowID, title string) error
+
+	// SetWindowSize resizes the content area of a window. The new size
+	// is reported through EventResize.
+	SetWindowSize(id WindowID, width, height int) error
+
+	// SetWindowPosition moves the top-left corner of a window's frame.
+	SetWindowPosition(id WindowID, x, y int) error
+
+	// MinimizeWindow minimizes (iconifies) a window.
+	MinimizeWindow(id WindowID) error
+
+	// MaximizeWindow maximizes a window.
+	MaximizeWindow(id WindowID) error
+
+	// RestoreWindow restores a minimized or maximized window to its
+	// normal size.
+	RestoreWindow(id WindowID) error
+
+	// FocusWindow brings a window to the front and gives it keyboard
+	// focus. Systems that prevent focus stealing may only highlight it.
+	FocusWindow(id WindowID) error
+
+	// RequestWindowAttention highlights a window that is not active, e.g.
+	// by flashing its taskbar button, until the user switches to it if
+	// critical is set.
+	RequestWindowAttention(id WindowID, critical bool) error
+}
+
+// Clipboard is implemented by platforms with access to the system clipboard.
+type Clipboard interface {
+	// ReadClipboardText returns the clipboard text, or "" if it holds no text.
+	ReadClipboardText() (string, error)
+
+	// WriteClipboardText replaces the clipboard contents with text.
+	WriteClipboardText(text string) error
+}
+
+// ImageClipboard is implemented by platforms that can exchange images
+// with the system clipboard.
+type ImageClipboard interface {
+	// ReadClipboardImage returns the clipboard image, or nil if the
+	// clipboard holds none.
+	ReadClipboardImage() (*image.NRGBA, error)
+
+	// WriteClipboardImage replaces the clipboard contents with img.
+	WriteClipboardImage(img *image.NRGBA) error
+}
+
+// ProgressState is the state of a progress indicator.
+type ProgressState uint8
+
+const (
+	ProgressNone          ProgressState = iota // No progress shown
+	ProgressNormal                             // Progress at the given value
+	ProgressIndeterminate                      // Busy, without a known value
+	ProgressPaused                             // Progress at the given value, paused
+	ProgressError                              // Progress at the given value, failed
+)
+
+// ProgressIndicator is implemented by platforms that can show progress
+// on the application's taskbar button.
+type ProgressIndicator interface {
+	// SetProgress shows progress from 0 to 1 in the given state, or
+	// removes it for ProgressNone.
+	SetProgress(state ProgressState, progress float64) error
+}
+
+// GamepadReader is implemented by platforms with gamepad support.
+// Gamepads are sampled by PollEvents, which reports connection changes
+// through EventGamepad.
+type GamepadReader interface {
+	// Gamepad returns the gamepad in slot as of the last PollEvents, or
+	// false if the slot is empty.
+	Gamepad(slot int) (Gamepad, bool)
+
+	// SetGamepadRumble sets the speeds of the low- and high-frequency
+	// rumble motors, from 0 to 1. Zero for both stops the rumble.
+	SetGamepadRumble(slot int, low, high float64) error
+}
+
+// TextInputPositioner is implemented by platforms whose input methods
+// show windows next to the text being edited.
+type TextInputPositioner interface {
+	// SetTextInputRect sets the area of the text being edited, in window
+	// pixels. Candidate windows are placed below it without covering it.
+	SetTextInputRect(x, y, width, height int)
+}
+
+// LiveResizer is implemented by platforms where an interactive window
+// resize blocks PollEvents until the user releases the window edge (macOS).
+type LiveResizer interface {
+	// SetLiveResizeHandler sets a function called synchronously for every
+	// size change of the main window during an interactive resize, after
+	// the surface has been resized, with the new size in pixels. The
+	// resize is also reported as a regular EventResize afterwards.
+	// The handler runs while the platform is busy and must not call
+	// Platform methods.
+	SetLiveResizeHandler(fn func(width, height int))
+}
+
+// Fullscreener is implemented by platforms that can toggle the main
+// window between windowed and fullscreen at runtime. The size change is
+// reported through EventResize, and the transition through
+// WindowEnteredFullscreen and WindowExitedFullscreen.
+type Fullscreener interface {
+	// SetFullscreen enters or leaves fullscreen. It may return before
+	// an animated transition has finished.
+	SetFullscreen(fullscreen bool) error
+
+	// IsFullscreen reports whether the main window is fullscreen.
+	IsFullscreen() bool
+}
+
+// MonitorLister is implemented by platforms that can enumerate monitors.
+// Configuration changes are reported through EventMonitors.
+type MonitorLister interface {
+	// Monitors returns the attached monitors, primary first.
+	Monitors() []Monitor
+}
+
+// ThemeDetector is implemented by platforms that report the system
+// light or dark appearance. Switches are reported through EventTheme.
+type ThemeDetector interface {
+	// DarkMode reports whether the system uses a dark appearance.
+	DarkMode() bool
+}
+
+// MouseCapturer is implemented by platforms that can lock the mouse to the
+// main window for relative motion, e.g. for first-person cameras.
+type MouseCapturer interface {
+	// SetMouseCaptured hides the cursor and keeps it inside the main
+	// window, or releases it. While captured, movement is only reported
+	// through MouseDelta. The platform suspends the capture while the
+	// window does not have focus.
+	SetMouseCaptured(captured bool) error
+
+	// MouseDelta returns the mouse movement in pixels, y down,
+	// accumulated since the previous call, and resets it.
+	MouseDelta() (dx, dy float64)
+}
+
+// CursorSetter is implemented by platforms that can change the mouse
+// cursor shown over their windows. Each window keeps its own cursor,
+// which the platform shows while the pointer is over the window's
+// content area.
+type CursorSetter interface {
+	// SetCursor shows a standard cursor over the window. Shapes the
+	// system lacks fall back to a similar one or the arrow.
+	SetCursor(id WindowID, shape CursorShape) error
+
+	// SetCustomCursor shows a cursor made from premultiplied RGBA
+	// pixels over the window, clicking at (hotX, hotY) in the image.
+	SetCustomCursor(id WindowID, width, height, hotX, hotY int, pixels []byte) error
+
+	// SetCursorVisible hides the cursor while it is over the window, or
+	// shows the window's cursor again.
+	SetCursorVisible(id WindowID, visible bool) error
+}
+
+// Dialogs is implemented by platforms with native file dialogs.
+// The dialogs are modal and return once the user has made a choice;
+// a cancelled dialog returns an empty result and no error.
+type Dialogs interface {
+	// OpenFileDialog returns the chosen files or directories.
+	OpenFileDialog(dialog FileDialog) ([]string, error)
+
+	// SaveFileDialog returns the chosen destination path.
+	SaveFileDialog(dialog FileDialog) (string, error)
+}
+
+// Dock is implemented by platforms with an application icon in a dock
+// or taskbar that the app can update while it runs.
+type Dock interface {
+	// SetDockIcon replaces the application icon with premultiplied RGBA
+	// pixels, or restores the default icon if pixels is nil.
+	SetDockIcon(width, height int, pixels []byte) error
+
+	// SetDockBadge shows a short label on the icon; "" removes it.
+	SetDockBadge(label string) error
+
+	// RequestAttention draws the user's attention to an inactive
+	// application, persistently until it is activated if critical is set.
+	RequestAttention(critical bool) error
+}
+
+// MenuBar is implemented by platforms with an application menu bar.
+// Selecting an item of a custom menu produces an EventMenu.
+type MenuBar interface {
+	// AddMenu appends a custom menu to the menu bar.
+	AddMenu(menu Menu) error
+}
+
 // New creates a platform-specific implementation.
 // This is implemented in platform-specific files.
 func New() Platform {
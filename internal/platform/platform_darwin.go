@@ -3,8 +3,15 @@
 package platform
 
 import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
 	"sync"
+	"time"
 
+	"github.com/gogpu/gogpu/input"
 	"github.com/gogpu/gogpu/internal/platform/darwin"
 )
 
@@ -12,11 +19,34 @@ import (
 type darwinPlatform struct {
 	mu          sync.Mutex
 	app         *darwin.Application
-	window      *darwin.Window
-	surface     *darwin.Surface
+	main        *darwinWindow
+	pacer       darwin.FramePacer
 	config      Config
 	shouldClose bool
 	events      []Event
+
+	// Called for main window size changes during a live resize
+	liveResize func(width, height int)
+
+	// Additional windows opened with CreateWindow
+	windows      map[WindowID]*darwinWindow
+	nextWindowID WindowID
+
+	// Game controllers in their slots, sampled by PollEvents
+	gamepads       [input.MaxGamepads]darwinGamepad
+	gamepadsPolled time.Time
+}
+
+// darwinWindow is an NSWindow with its Metal surface.
+type darwinWindow struct {
+	id      WindowID
+	window  *darwin.Window
+	surface *darwin.Surface
+	closed  bool
+
+	// Framebuffer size in backing pixels, as reported by GetSize
+	width  int
+	height int
 }
 
 func newPlatform() Platform {
@@ -34,21 +64,56 @@ func (p *darwinPlatform) Init(config Config) error {
 	if err := p.app.Init(); err != nil {
 		return err
 	}
+	p.app.SetGestureHandler(p.handleGesture)
+	p.app.SetKeyHandler(p.handleKey)
+	p.app.SetMouseHandler(p.handleMouse)
+	p.app.SetDropHandler(p.handleDrop)
+	p.app.SetMenuHandler(p.handleMenu)
+	p.app.SetTextInputHandler(p.handleTextInput)
+	p.app.SetWindowEventHandler(p.handleWindowEvent)
+	p.app.SetResizeHandler(p.handleResize)
+	p.app.SetScreenChangeHandler(p.handleScreensChanged)
+	p.app.SetPowerHandler(p.handlePower)
+	p.app.SetDisplayReconfigurationHandler(p.handleDisplayReconfigured)
+
+	// Non-fatal: without a menu bar the app still runs, but Cmd+Q and the
+	// standard text shortcuts are unavailable
+	_ = p.app.SetupMainMenu()
+
+	// Cannot fail: the application was initialized above
+	_ = p.app.SetAppearanceHandler(p.handleAppearance)
+
+	main, err := newDarwinWindow(MainWindow, config)
+	if err != nil {
+		return err
+	}
+	p.main = main
 
+	return nil
+}
+
+// newDarwinWindow creates and shows a window with a Metal surface.
+func newDarwinWindow(id WindowID, config Config) (*darwinWindow, error) {
 	// Create window
 	windowConfig := darwin.WindowConfig{
-		Title:      config.Title,
-		Width:      config.Width,
-		Height:     config.Height,
-		Resizable:  config.Resizable,
-		Fullscreen: config.Fullscreen,
+		Title:       config.Title,
+		Width:       config.Width,
+		Height:      config.Height,
+		Resizable:   config.Resizable,
+		Fullscreen:  config.Fullscreen,
+		Transparent: config.Transparent,
+	}
+	if config.Titlebar != TitlebarDefault {
+		windowConfig.TransparentTitlebar = true
+		windowConfig.FullSizeContentView = true
+		windowConfig.HideTitle = config.Titlebar == TitlebarHidden
 	}
 
 	window, err := darwin.NewWindow(windowConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	p.window = window
+	w := &darwinWindow{id: id, window: window}
 
 	// Create Metal surface for GPU rendering.
 	// Note: Surface is created before window is shown, but drawable size
@@ -57,22 +122,85 @@ func (p *darwinPlatform) Init(config Config) error {
 	if err != nil {
 		// Non-fatal: window works without Metal surface
 		// This allows the window to still be used with software rendering
-		p.surface = nil
+		w.surface = nil
 	} else {
-		p.surface = surface
+		w.surface = surface
 	}
 
 	// Show window - this makes the window visible and gives it valid dimensions
-	p.window.Show()
+	w.window.Show()
 
 	// Update surface size now that window is visible.
 	// This ensures CAMetalLayer has correct drawable dimensions
 	// and avoids "ignoring invalid setDrawableSize" warnings.
-	if p.surface != nil {
-		p.surface.UpdateSize()
+	if w.surface != nil {
+		w.surface.UpdateSize()
 	}
+	w.window.UpdateSize()
+	w.width, w.height = w.window.FramebufferSize()
 
-	return nil
+	// Native fullscreen animates into its own Space once the window is on
+	// screen; the size change arrives through the regular resize path
+	if config.Fullscreen {
+		w.window.SetFullscreen(true)
+	}
+
+	return w, nil
+}
+
+// pollResize updates the cached size and returns a resize event if it changed.
+// AppKit posts NSWindowDidChangeBackingPropertiesNotification when the
+// window moves between Retina and non-Retina displays; polling the scale
+// factor here catches the same transition without a delegate object.
+func (w *darwinWindow) pollResize() (Event, bool) {
+	w.window.UpdateSize()
+	scaleChanged := w.surface != nil && w.surface.UpdateScale()
+	newWidth, newHeight := w.window.FramebufferSize()
+
+	if newWidth == w.width && newHeight == w.height && !scaleChanged {
+		return Event{}, false
+	}
+
+	w.width = newWidth
+	w.height = newHeight
+
+	// Update surface size
+	if w.surface != nil {
+		w.surface.Resize(newWidth, newHeight)
+	}
+
+	return Event{
+		Type:   EventResize,
+		Window: w.id,
+		Width:  newWidth,
+		Height: newHeight,
+	}, true
+}
+
+// handle returns the surface handles of the window.
+func (w *darwinWindow) handle() (instance, window uintptr) {
+	// On macOS:
+	// - instance: 0 (not used)
+	// - window: CAMetalLayer pointer for surface creation
+	if w.surface != nil {
+		return 0, w.surface.LayerPtr()
+	}
+
+	// Fallback to content view if no surface
+	return 0, w.window.ViewHandle()
+}
+
+// destroy releases the surface and the window.
+func (w *darwinWindow) destroy() {
+	if w.surface != nil {
+		w.surface.Destroy()
+		w.surface = nil
+	}
+
+	if w.window != nil {
+		w.window.Destroy()
+		w.window = nil
+	}
 }
 
 func (p *darwinPlatform) PollEvents() Event {
@@ -84,35 +212,39 @@ func (p *darwinPlatform) PollEvents() Event {
 		p.app.PollEvents()
 	}
 
-	// Check if window should close
-	if p.window != nil && p.window.ShouldClose() {
+	// Check if window should close, or Quit was chosen from the menu
+	if p.app != nil && p.app.ShouldTerminate() && !p.shouldClose {
+		p.shouldClose = true
+		return Event{Type: EventClose}
+	}
+	if p.main != nil && p.main.window.ShouldClose() {
 		p.shouldClose = true
 		return Event{Type: EventClose}
 	}
 
 	// Update window size and check for resize
-	if p.window != nil {
-		oldWidth, oldHeight := p.config.Width, p.config.Height
-		p.window.UpdateSize()
-		newWidth, newHeight := p.window.Size()
-
-		if newWidth != oldWidth || newHeight != oldHeight {
-			p.config.Width = newWidth
-			p.config.Height = newHeight
-
-			// Update surface size
-			if p.surface != nil {
-				p.surface.Resize(newWidth, newHeight)
-			}
-
-			return Event{
-				Type:   EventResize,
-				Width:  newWidth,
-				Height: newHeight,
-			}
+	if p.main != nil {
+		if event, ok := p.main.pollResize(); ok {
+			return event
+		}
+	}
+
+	for _, w := range p.windows {
+		if w.closed {
+			continue
+		}
+		if w.window.ShouldClose() {
+			// Reported once; the window stays valid until DestroyWindow
+			w.closed = true
+			return Event{Type: EventClose, Window: w.id}
+		}
+		if event, ok := w.pollResize(); ok {
+			return event
 		}
 	}
 
+	p.pollGamepads()
+
 	// Return queued event if any
 	if len(p.events) > 0 {
 		event := p.events[0]
@@ -127,8 +259,8 @@ func (p *darwinPlatform) ShouldClose() bool {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.window != nil {
-		return p.window.ShouldClose() || p.shouldClose
+	if p.main != nil {
+		return p.main.window.ShouldClose() || p.shouldClose
 	}
 	return p.shouldClose
 }
@@ -137,8 +269,8 @@ func (p *darwinPlatform) GetSize() (width, height int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.window != nil {
-		return p.window.Size()
+	if p.main != nil {
+		return p.main.width, p.main.height
 	}
 	return p.config.Width, p.config.Height
 }
@@ -147,33 +279,420 @@ func (p *darwinPlatform) GetHandle() (instance, window uintptr) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	// On macOS:
-	// - instance: 0 (not used)
-	// - window: CAMetalLayer pointer for surface creation
-	if p.surface != nil {
-		return 0, p.surface.LayerPtr()
+	if p.main != nil {
+		return p.main.handle()
 	}
 
-	// Fallback to content view if no surface
-	if p.window != nil {
-		return 0, p.window.ViewHandle()
+	return 0, 0
+}
+
+// CreateWindow opens an additional window with its own Metal surface.
+func (p *darwinPlatform) CreateWindow(config Config) (WindowID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil {
+		return 0, darwin.ErrApplicationNotInitialized
+	}
+
+	p.nextWindowID++
+	id := p.nextWindowID
+
+	w, err := newDarwinWindow(id, config)
+	if err != nil {
+		return 0, err
+	}
+
+	if p.windows == nil {
+		p.windows = make(map[WindowID]*darwinWindow)
+	}
+	p.windows[id] = w
+
+	return id, nil
+}
+
+// DestroyWindow closes a window opened with CreateWindow.
+func (p *darwinPlatform) DestroyWindow(id WindowID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w, ok := p.windows[id]; ok {
+		w.destroy()
+		delete(p.windows, id)
+	}
+}
+
+// WindowSize returns the framebuffer size of a window in pixels.
+func (p *darwinPlatform) WindowSize(id WindowID) (width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w := p.windowByID(id); w != nil {
+		return w.width, w.height
 	}
+	return 0, 0
+}
 
+// WindowHandle returns the surface handles of a window.
+func (p *darwinPlatform) WindowHandle(id WindowID) (instance, window uintptr) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w := p.windowByID(id); w != nil {
+		return w.handle()
+	}
 	return 0, 0
 }
 
+// windowByID returns the main or an additional window. Caller must hold p.mu.
+func (p *darwinPlatform) windowByID(id WindowID) *darwinWindow {
+	if id == MainWindow {
+		return p.main
+	}
+	return p.windows[id]
+}
+
+// openWindow returns the open window with id.
+func (p *darwinPlatform) openWindow(id WindowID) (*darwinWindow, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil {
+		return nil, darwin.ErrApplicationNotInitialized
+	}
+	w := p.windowByID(id)
+	if w == nil || w.closed {
+		return nil, fmt.Errorf("platform: unknown window %d", id)
+	}
+	return w, nil
+}
+
+// ReadClipboardText reads plain text from the general NSPasteboard.
+func (p *darwinPlatform) ReadClipboardText() (string, error) {
+	pb, err := darwin.GeneralPasteboard()
+	if err != nil {
+		return "", err
+	}
+	text, _ := pb.ReadText()
+	return text, nil
+}
+
+// WriteClipboardText writes plain text to the general NSPasteboard.
+func (p *darwinPlatform) WriteClipboardText(text string) error {
+	pb, err := darwin.GeneralPasteboard()
+	if err != nil {
+		return err
+	}
+	return pb.WriteText(text)
+}
+
+// ReadClipboardImage reads a PNG image from the general NSPasteboard.
+// Images in other formats are not converted and read as none.
+func (p *darwinPlatform) ReadClipboardImage() (*image.NRGBA, error) {
+	pb, err := darwin.GeneralPasteboard()
+	if err != nil {
+		return nil, err
+	}
+	data, ok := pb.ReadImage()
+	if !ok {
+		return nil, nil
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("platform: clipboard image: %w", err)
+	}
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Rect.Min == (image.Point{}) {
+		return nrgba, nil
+	}
+	bounds := img.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	return nrgba, nil
+}
+
+// WriteClipboardImage writes img to the general NSPasteboard as PNG.
+func (p *darwinPlatform) WriteClipboardImage(img *image.NRGBA) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return fmt.Errorf("platform: clipboard image: %w", err)
+	}
+
+	pb, err := darwin.GeneralPasteboard()
+	if err != nil {
+		return err
+	}
+	return pb.WriteImage(buf.Bytes())
+}
+
+// WaitFrame paces the render loop to the refresh rate of the window's
+// current display, as reported by CVDisplayLink.
+//
+// It does not take p.mu: config and main are set by Init and not changed
+// until Destroy, and the pacer is only used from the render loop. Sleeping
+// with p.mu held would stall WaitEvents on the main thread when the render
+// loop runs on its own thread.
+func (p *darwinPlatform) WaitFrame() {
+	if !p.config.PaceToDisplay || p.main == nil {
+		return
+	}
+	p.pacer.Wait(p.main.window)
+}
+
+// BeginFrameScope implements FrameScoper with an autorelease pool, so
+// objects autoreleased while processing events and rendering a frame are
+// released at the end of the frame rather than accumulating.
+func (p *darwinPlatform) BeginFrameScope() func() {
+	return darwin.NewAutoreleasePool().Drain
+}
+
+// WaitEvents blocks until an OS event has been processed or the timeout
+// expires. It returns at once if events are already queued.
+func (p *darwinPlatform) WaitEvents(timeout time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil || len(p.events) > 0 {
+		return
+	}
+	p.app.WaitEventsTimeout(timeout)
+}
+
+// SetLiveResizeHandler sets the function that draws frames during a live resize.
+func (p *darwinPlatform) SetLiveResizeHandler(fn func(width, height int)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.liveResize = fn
+}
+
+// SetFullscreen toggles native fullscreen of the main window.
+func (p *darwinPlatform) SetFullscreen(fullscreen bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.main == nil {
+		return darwin.ErrApplicationNotInitialized
+	}
+	p.main.window.SetFullscreen(fullscreen)
+	return nil
+}
+
+// IsFullscreen reports whether the main window is in native fullscreen.
+func (p *darwinPlatform) IsFullscreen() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.main != nil && p.main.window.IsFullscreen()
+}
+
+// Monitors returns the attached screens, converted from Cocoa's bottom-left
+// origin to top-left desktop coordinates in points.
+func (p *darwinPlatform) Monitors() []Monitor {
+	screens := darwin.Screens()
+	if len(screens) == 0 {
+		return nil
+	}
+
+	// Cocoa's global coordinates start at the bottom-left corner of the
+	// primary screen with y up
+	primaryHeight := screens[0].Frame.Size.Height
+
+	monitors := make([]Monitor, 0, len(screens))
+	for i, s := range screens {
+		m := Monitor{
+			Name:        s.Name,
+			Scale:       s.ScaleFactor,
+			RefreshRate: s.RefreshRate,
+			ColorSpace:  s.ColorSpace,
+			Primary:     i == 0,
+		}
+		m.X, m.Y, m.Width, m.Height = flipRect(s.Frame, primaryHeight)
+		m.WorkX, m.WorkY, m.WorkWidth, m.WorkHeight = flipRect(s.VisibleFrame, primaryHeight)
+		monitors = append(monitors, m)
+	}
+	return monitors
+}
+
+// flipRect converts a Cocoa screen rectangle to a top-left origin.
+func flipRect(r darwin.NSRect, primaryHeight darwin.CGFloat) (x, y, width, height int) {
+	top := primaryHeight - (r.Origin.Y + r.Size.Height)
+	return int(r.Origin.X), int(top), int(r.Size.Width), int(r.Size.Height)
+}
+
+// SetMouseCaptured locks the mouse to the main window for relative motion.
+func (p *darwinPlatform) SetMouseCaptured(captured bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil || p.main == nil {
+		return darwin.ErrApplicationNotInitialized
+	}
+	if captured {
+		p.app.SetMouseCapture(p.main.window)
+	} else {
+		p.app.SetMouseCapture(nil)
+	}
+	return nil
+}
+
+// ScaleFactor returns the backing scale factor of the main window.
+func (p *darwinPlatform) ScaleFactor() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.main == nil {
+		return 1
+	}
+	return p.main.window.BackingScaleFactor()
+}
+
+// MouseDelta returns the accumulated mouse movement converted to pixels.
+func (p *darwinPlatform) MouseDelta() (dx, dy float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil || p.main == nil {
+		return 0, 0
+	}
+	dx, dy = p.app.MouseDelta()
+	scale := p.main.window.BackingScaleFactor()
+	return dx * scale, dy * scale
+}
+
+// OpenFileDialog runs an NSOpenPanel. The filters are merged, since the
+// panel only restricts the allowed extensions.
+func (p *darwinPlatform) OpenFileDialog(dialog FileDialog) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil {
+		return nil, darwin.ErrApplicationNotInitialized
+	}
+	return p.app.RunOpenPanel(darwin.OpenPanelOptions{
+		Title:       dialog.Title,
+		Directory:   dialog.Directory,
+		Extensions:  filterExtensions(dialog.Filters),
+		Multiple:    dialog.Multiple,
+		Directories: dialog.Directories,
+	})
+}
+
+// SaveFileDialog runs an NSSavePanel.
+func (p *darwinPlatform) SaveFileDialog(dialog FileDialog) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil {
+		return "", darwin.ErrApplicationNotInitialized
+	}
+	return p.app.RunSavePanel(darwin.SavePanelOptions{
+		Title:      dialog.Title,
+		Directory:  dialog.Directory,
+		Filename:   dialog.Filename,
+		Extensions: filterExtensions(dialog.Filters),
+	})
+}
+
+// filterExtensions returns the extensions of all filters.
+func filterExtensions(filters []FileFilter) []string {
+	var extensions []string
+	for _, f := range filters {
+		extensions = append(extensions, f.Extensions...)
+	}
+	return extensions
+}
+
+// DarkMode reports whether the application appearance is dark.
+func (p *darwinPlatform) DarkMode() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.app != nil && p.app.IsDarkMode()
+}
+
+// AddMenu adds a custom menu to the menu bar, before the Window menu.
+func (p *darwinPlatform) AddMenu(menu Menu) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.app == nil {
+		return darwin.ErrApplicationNotInitialized
+	}
+
+	items := make([]darwin.MenuItem, 0, len(menu.Items))
+	for _, item := range menu.Items {
+		items = append(items, darwin.MenuItem{
+			Title:     item.Title,
+			Key:       item.Shortcut,
+			Tag:       item.ID,
+			Separator: item.Separator,
+		})
+	}
+	return p.app.AddMenu(menu.Title, items)
+}
+
+func (p *darwinPlatform) SetIcon(width, height int, pixels []byte) error {
+	// Per-window icons are not a macOS concept; the dock icon is set per application.
+	return ErrUnsupported
+}
+
+// SetDockIcon implements Dock.
+func (p *darwinPlatform) SetDockIcon(width, height int, pixels []byte) error {
+	if pixels == nil {
+		return p.app.SetDockIcon(nil)
+	}
+	return p.app.SetDockIcon(&image.RGBA{
+		Pix:    pixels,
+		Stride: width * 4,
+		Rect:   image.Rect(0, 0, width, height),
+	})
+}
+
+// SetDockBadge implements Dock.
+func (p *darwinPlatform) SetDockBadge(label string) error {
+	return p.app.SetDockBadge(label)
+}
+
+// RequestAttention implements Dock.
+func (p *darwinPlatform) RequestAttention(critical bool) error {
+	p.app.RequestUserAttention(critical)
+	return nil
+}
+
+// BeginMove drags the window with the held left mouse button via
+// -[NSWindow performWindowDragWithEvent:].
+func (p *darwinPlatform) BeginMove() error {
+	if p.app == nil || !p.app.BeginWindowDrag() {
+		return ErrUnsupported
+	}
+	return nil
+}
+
+func (p *darwinPlatform) BeginResize(edge ResizeEdge) error {
+	// AppKit has no API to start a system resize from an arbitrary event
+	return ErrUnsupported
+}
+
+func (p *darwinPlatform) ShowWindowMenu(x, y int) error {
+	// macOS has no per-window system menu
+	return ErrUnsupported
+}
+
 func (p *darwinPlatform) Destroy() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if p.surface != nil {
-		p.surface.Destroy()
-		p.surface = nil
+	p.pacer.Release()
+
+	for id, w := range p.windows {
+		w.destroy()
+		delete(p.windows, id)
 	}
 
-	if p.window != nil {
-		p.window.Destroy()
-		p.window = nil
+	if p.main != nil {
+		p.main.destroy()
+		p.main = nil
 	}
 
 	if p.app != nil {
@@ -182,7 +701,296 @@ func (p *darwinPlatform) Destroy() {
 	}
 }
 
+// windowForNative returns the platform window wrapping a darwin window.
+// Caller must hold p.mu.
+func (p *darwinPlatform) windowForNative(window *darwin.Window) *darwinWindow {
+	if window == nil {
+		return nil
+	}
+	if p.main != nil && p.main.window == window {
+		return p.main
+	}
+	for _, w := range p.windows {
+		if w.window == window {
+			return w
+		}
+	}
+	return nil
+}
+
+// handleGesture converts an AppKit gesture into a platform event.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleGesture(window *darwin.Window, g darwin.GestureEvent) {
+	w := p.windowForNative(window)
+	if w == nil {
+		w = p.main
+	}
+
+	event := GestureEvent{
+		Magnification: g.Magnification,
+		// AppKit rotation is counter-clockwise positive
+		Rotation: -g.Rotation,
+		DeltaX:   g.DeltaX,
+		DeltaY:   g.DeltaY,
+	}
+
+	switch g.Kind {
+	case darwin.GestureMagnify:
+		event.Kind = GestureMagnify
+	case darwin.GestureRotate:
+		event.Kind = GestureRotate
+	case darwin.GestureSwipe:
+		event.Kind = GestureSwipe
+	case darwin.GestureSmartMagnify:
+		event.Kind = GestureSmartMagnify
+	default:
+		return
+	}
+
+	switch g.Phase {
+	case darwin.NSEventPhaseBegan, darwin.NSEventPhaseMayBegin:
+		event.Phase = GesturePhaseBegin
+	case darwin.NSEventPhaseChanged, darwin.NSEventPhaseStationary:
+		event.Phase = GesturePhaseChange
+	case darwin.NSEventPhaseEnded:
+		event.Phase = GesturePhaseEnd
+	case darwin.NSEventPhaseCancelled:
+		event.Phase = GesturePhaseCancel
+	default:
+		event.Phase = GesturePhaseNone
+	}
+
+	var id WindowID
+	event.X, event.Y = g.Location.X, g.Location.Y
+	if w != nil {
+		id = w.id
+		event.X, event.Y = w.toPixels(g.Location)
+	}
+
+	p.queueEvent(Event{Type: EventGesture, Window: id, Gesture: event})
+}
+
+// handleDrop converts an AppKit drag-and-drop notification into a platform event.
+// Called from the dragging destination methods on the main thread, usually
+// from within app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleDrop(window *darwin.Window, d darwin.DropEvent) bool {
+	w := p.windowForNative(window)
+	if w == nil {
+		return false
+	}
+
+	event := DropEvent{Paths: d.Paths}
+	switch d.Phase {
+	case darwin.DropEnter:
+		event.Phase = DropPhaseEnter
+	case darwin.DropOver:
+		event.Phase = DropPhaseOver
+	case darwin.DropLeave:
+		event.Phase = DropPhaseLeave
+	case darwin.DropPerform:
+		event.Phase = DropPhaseDrop
+	default:
+		return false
+	}
+	event.X, event.Y = w.toPixels(d.Location)
+
+	p.queueEvent(Event{Type: EventDrop, Window: w.id, Drop: event})
+	return true
+}
+
+// toPixels converts a location in window coordinates (points, bottom-left
+// origin) to pixels with a top-left origin like other platforms.
+func (w *darwinWindow) toPixels(location darwin.NSPoint) (x, y float64) {
+	_, height := w.window.Size()
+	scale := w.window.BackingScaleFactor()
+	return location.X * scale, (float64(height) - location.Y) * scale
+}
+
 // queueEvent adds an event to the event queue.
 func (p *darwinPlatform) queueEvent(event Event) {
 	p.events = append(p.events, event)
 }
+
+// handleKey queues a key press or release.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleKey(window *darwin.Window, k darwin.KeyEvent) {
+	w := p.windowForNative(window)
+	if w == nil {
+		w = p.main
+	}
+
+	p.queueEvent(Event{Type: EventKey, Window: w.id, Key: KeyEvent{
+		Key:       darwinKey(k.KeyCode, k.Characters),
+		Scancode:  uint32(k.KeyCode),
+		Down:      k.Down,
+		Repeat:    k.Repeat,
+		Modifiers: darwinModifiers(k.Modifiers),
+	}})
+}
+
+// darwinScrollStep converts precise scroll deltas in points to wheel
+// steps, which AppKit reports as lines.
+const darwinScrollStep = 10
+
+// handleMouse queues a mouse button, move or scroll event for a window.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleMouse(window *darwin.Window, m darwin.MouseEvent) {
+	w := p.windowForNative(window)
+	if w == nil {
+		// Moves outside our windows, e.g. over the menu bar
+		return
+	}
+
+	mouse := MouseEvent{Modifiers: darwinModifiers(m.Modifiers)}
+	mouse.X, mouse.Y = w.toPixels(m.Location)
+
+	var eventType EventType
+	switch m.Kind {
+	case darwin.MouseDown, darwin.MouseUp:
+		eventType = EventMouseButton
+		mouse.Down = m.Kind == darwin.MouseDown
+		switch m.Button {
+		case 0:
+			mouse.Button = input.MouseButtonLeft
+		case 1:
+			mouse.Button = input.MouseButtonRight
+		case 2:
+			mouse.Button = input.MouseButtonMiddle
+		case 3:
+			mouse.Button = input.MouseButton4
+		case 4:
+			mouse.Button = input.MouseButton5
+		default:
+			return
+		}
+	case darwin.MouseMove:
+		eventType = EventMouseMove
+	case darwin.MouseScroll:
+		eventType = EventScroll
+		mouse.ScrollX, mouse.ScrollY = -m.ScrollX, m.ScrollY
+		if m.Precise {
+			mouse.ScrollX /= darwinScrollStep
+			mouse.ScrollY /= darwinScrollStep
+		}
+	default:
+		return
+	}
+
+	p.queueEvent(Event{Type: eventType, Window: w.id, Mouse: mouse})
+}
+
+// handleMenu queues the selection of a custom menu item.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleMenu(tag int) {
+	p.queueEvent(Event{Type: EventMenu, MenuID: tag})
+}
+
+// handleTextInput queues typed or composed text.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleTextInput(window *darwin.Window, t darwin.TextInputEvent) {
+	var id WindowID
+	if w := p.windowForNative(window); w != nil {
+		id = w.id
+	}
+
+	p.queueEvent(Event{
+		Type:   EventText,
+		Window: id,
+		Text: TextEvent{
+			Text:      t.Text,
+			Composing: t.Composing,
+			Cursor:    t.Cursor,
+		},
+	})
+}
+
+// handleWindowEvent converts a window delegate notification into a platform event.
+// Called on the main thread with p.mu already held, from app.PollEvents or
+// from window calls such as Show that trigger synchronous notifications.
+func (p *darwinPlatform) handleWindowEvent(window *darwin.Window, e darwin.WindowEvent) {
+	w := p.windowForNative(window)
+	if w == nil {
+		return
+	}
+
+	var state WindowState
+	switch e {
+	case darwin.WindowDidMiniaturize:
+		state = WindowMinimized
+	case darwin.WindowDidDeminiaturize:
+		state = WindowRestored
+	case darwin.WindowDidZoom:
+		state = WindowMaximized
+	case darwin.WindowDidUnzoom:
+		state = WindowUnmaximized
+	case darwin.WindowDidBecomeKey:
+		state = WindowFocused
+	case darwin.WindowDidResignKey:
+		state = WindowUnfocused
+	case darwin.WindowDidBecomeOccluded:
+		state = WindowOccluded
+	case darwin.WindowDidBecomeVisible:
+		state = WindowVisible
+	case darwin.WindowDidChangeScreen:
+		state = WindowScreenChanged
+	case darwin.WindowDidEnterFullScreen:
+		state = WindowEnteredFullscreen
+	case darwin.WindowDidExitFullScreen:
+		state = WindowExitedFullscreen
+	default:
+		return
+	}
+
+	p.queueEvent(Event{Type: EventWindowState, Window: w.id, State: state})
+}
+
+// handleScreensChanged queues a monitor configuration change.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleScreensChanged() {
+	p.queueEvent(Event{Type: EventMonitors})
+}
+
+// handlePower queues system sleep and wake.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handlePower(event darwin.PowerEvent) {
+	switch event {
+	case darwin.PowerWillSleep:
+		p.queueEvent(Event{Type: EventSleep})
+	case darwin.PowerDidWake:
+		p.queueEvent(Event{Type: EventWake})
+	}
+}
+
+// handleDisplayReconfigured queues a display reconfiguration. CoreGraphics
+// reports each affected display; the app coalesces the events.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleDisplayReconfigured(_ uint32, _ darwin.DisplayChangeFlags) {
+	p.queueEvent(Event{Type: EventDisplayReconfigured})
+}
+
+// handleAppearance queues a switch between light and dark appearance.
+// Called from app.PollEvents with p.mu already held.
+func (p *darwinPlatform) handleAppearance(dark bool) {
+	p.queueEvent(Event{Type: EventTheme, Dark: dark})
+}
+
+// handleResize resizes the surface as soon as AppKit resizes the window,
+// so the drawable keeps matching the window during a live resize, when
+// PollEvents is blocked in AppKit's tracking loop. Called on the main
+// thread with p.mu already held.
+func (p *darwinPlatform) handleResize(window *darwin.Window, phase darwin.ResizePhase) {
+	w := p.windowForNative(window)
+	if w == nil || phase == darwin.ResizeStart {
+		return
+	}
+
+	event, ok := w.pollResize()
+	if !ok {
+		return
+	}
+	p.queueEvent(event)
+
+	if w.id == MainWindow && p.liveResize != nil && window.InLiveResize() {
+		p.liveResize(event.Width, event.Height)
+	}
+}
@@ -0,0 +1,510 @@
+//go:build js && wasm
+
+package platform
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"syscall/js"
+
+	"github.com/gogpu/gogpu/input"
+)
+
+// canvasWindow is the window handle of the main canvas. The browser
+// backend looks the canvas up by the id "gogpu-canvas-<window>".
+const canvasWindow = 1
+
+// canvasID is the element id of the main canvas. A page may provide a
+// canvas with this id; otherwise one filling the page is created.
+const canvasID = "gogpu-canvas-1"
+
+// jsPlatform implements the Platform interface with an HTML canvas.
+type jsPlatform struct {
+	mu sync.Mutex
+
+	canvas  js.Value
+	created bool // The canvas was created by Init and is removed by Destroy
+
+	// Fixed CSS size from the config; 0 follows the page size
+	cssWidth  int
+	cssHeight int
+
+	// Event listeners, removed by Destroy
+	listeners []listener
+
+	// Events waiting for PollEvents
+	events []Event
+
+	// Canvases of the additional windows opened with CreateWindow
+	windows      map[WindowID]*jsCanvas
+	nextWindowID WindowID
+
+	width       int
+	height      int
+	scale       float64
+	shouldClose bool
+
+	// CSS cursor of the canvas, replaced by "none" while hidden
+	cursor       string
+	cursorHidden bool
+
+	// Animation frame callback and the channel it signals
+	onFrame js.Func
+	frame   chan struct{}
+}
+
+// listener is an event listener added to a JavaScript object.
+type listener struct {
+	target js.Value
+	event  string
+	fn     js.Func
+}
+
+// newPlatform creates the platform-specific implementation.
+// In the browser, windows are canvas elements of the page.
+func newPlatform() Platform {
+	return &jsPlatform{}
+}
+
+// Init sets up the canvas and its event listeners.
+func (p *jsPlatform) Init(config Config) error {
+	document := js.Global().Get("document")
+	if config.Title != "" {
+		document.Set("title", config.Title)
+	}
+
+	p.canvas = document.Call("getElementById", canvasID)
+	if !p.canvas.Truthy() {
+		p.canvas = document.Call("createElement", "canvas")
+		p.canvas.Set("id", canvasID)
+		style := p.canvas.Get("style")
+		style.Set("display", "block")
+		if config.Fullscreen || config.Width <= 0 || config.Height <= 0 {
+			style.Set("width", "100vw")
+			style.Set("height", "100vh")
+		}
+		document.Get("body").Call("appendChild", p.canvas)
+		p.created = true
+	}
+	if !config.Fullscreen && config.Width > 0 && config.Height > 0 {
+		p.cssWidth, p.cssHeight = config.Width, config.Height
+		style := p.canvas.Get("style")
+		style.Set("width", strconv.Itoa(config.Width)+"px")
+		style.Set("height", strconv.Itoa(config.Height)+"px")
+	}
+
+	// Key events only reach focusable elements
+	p.canvas.Set("tabIndex", 0)
+	p.canvas.Call("focus")
+
+	p.scale = devicePixelRatio()
+	p.width, p.height = p.pixelSize()
+
+	window := js.Global()
+	p.listen(window, "resize", p.handleResize)
+	p.listen(window, "pagehide", func(js.Value) {
+		p.mu.Lock()
+		p.shouldClose = true
+		p.events = append(p.events, Event{Type: EventClose})
+		p.mu.Unlock()
+	})
+	p.listeners = append(p.listeners, p.listenInput(p.canvas, MainWindow)...)
+	p.listen(document, "visibilitychange", func(js.Value) {
+		// Hidden tabs and minimized browser windows
+		state := WindowVisible
+		if document.Get("hidden").Bool() {
+			state = WindowOccluded
+		}
+		p.push(Event{Type: EventWindowState, State: state})
+	})
+
+	p.frame = make(chan struct{}, 1)
+	p.onFrame = js.FuncOf(func(js.Value, []js.Value) any {
+		select {
+		case p.frame <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// listen adds an event listener to target.
+func (p *jsPlatform) listen(target js.Value, event string, handler func(e js.Value)) {
+	p.listeners = append(p.listeners, addListener(target, event, handler))
+}
+
+// addListener adds an event listener to target and returns it.
+func addListener(target js.Value, event string, handler func(e js.Value)) listener {
+	fn := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		handler(args[0])
+		return nil
+	})
+	target.Call("addEventListener", event, fn)
+	return listener{target: target, event: event, fn: fn}
+}
+
+// listenInput adds the listeners for input on the canvas of a window
+// and returns them.
+func (p *jsPlatform) listenInput(canvas js.Value, window WindowID) []listener {
+	return []listener{
+		addListener(canvas, "keydown", func(e js.Value) { p.handleKey(window, e, true) }),
+		addListener(canvas, "keyup", func(e js.Value) { p.handleKey(window, e, false) }),
+		addListener(canvas, "mousedown", func(e js.Value) { p.handleMouseButton(window, e, true) }),
+		addListener(canvas, "mouseup", func(e js.Value) { p.handleMouseButton(window, e, false) }),
+		addListener(canvas, "mousemove", func(e js.Value) { p.handleMouseMove(window, e) }),
+		addListener(canvas, "wheel", func(e js.Value) { p.handleWheel(window, e) }),
+		addListener(canvas, "contextmenu", func(e js.Value) { e.Call("preventDefault") }),
+		addListener(canvas, "focus", func(js.Value) {
+			p.push(Event{Type: EventWindowState, Window: window, State: WindowFocused})
+		}),
+		addListener(canvas, "blur", func(js.Value) {
+			p.push(Event{Type: EventWindowState, Window: window, State: WindowUnfocused})
+		}),
+	}
+}
+
+// push queues an event for PollEvents.
+func (p *jsPlatform) push(event Event) {
+	p.mu.Lock()
+	p.events = append(p.events, event)
+	p.mu.Unlock()
+}
+
+// devicePixelRatio returns the pixels per CSS pixel of the page.
+func devicePixelRatio() float64 {
+	if ratio := js.Global().Get("devicePixelRatio"); ratio.Truthy() {
+		return ratio.Float()
+	}
+	return 1
+}
+
+// pixelSize returns the size of the canvas in device pixels.
+func (p *jsPlatform) pixelSize() (width, height int) {
+	return canvasPixelSize(p.canvas, p.cssWidth, p.cssHeight, p.scale)
+}
+
+// canvasPixelSize returns the size in device pixels of a canvas with a
+// fixed CSS size, or of its layout size for a CSS size of 0.
+func canvasPixelSize(canvas js.Value, cssWidth, cssHeight int, scale float64) (width, height int) {
+	if cssWidth == 0 || cssHeight == 0 {
+		cssWidth = canvas.Get("clientWidth").Int()
+		cssHeight = canvas.Get("clientHeight").Int()
+	}
+	return int(float64(cssWidth)*scale + 0.5), int(float64(cssHeight)*scale + 0.5)
+}
+
+// handleResize reports size and scale changes of the canvas.
+func (p *jsPlatform) handleResize(js.Value) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if scale := devicePixelRatio(); scale != p.scale {
+		p.scale = scale
+		p.events = append(p.events, Event{Type: EventScale, Scale: scale})
+	}
+
+	if width, height := p.pixelSize(); width != p.width || height != p.height {
+		p.width, p.height = width, height
+		p.events = append(p.events, Event{Type: EventResize, Width: width, Height: height})
+	}
+	for id, c := range p.windows {
+		if width, height := canvasPixelSize(c.canvas, c.cssWidth, c.cssHeight, p.scale); width != c.width || height != c.height {
+			c.width, c.height = width, height
+			p.events = append(p.events, Event{Type: EventResize, Window: id, Width: width, Height: height})
+		}
+	}
+}
+
+// handleKey reports a keydown or keyup event.
+func (p *jsPlatform) handleKey(window WindowID, e js.Value, down bool) {
+	code := e.Get("code").String()
+	key := keyFromCode(code)
+	// Keys that type a letter or digit follow the keyboard layout
+	if name := e.Get("key").String(); len(name) == 1 && !strings.HasPrefix(code, "Numpad") {
+		if k := keyFromChar(name[0]); k != input.KeyUnknown {
+			key = k
+		}
+	}
+
+	// Keep the browser from scrolling or navigating on game keys
+	if key != input.KeyUnknown && !e.Get("metaKey").Bool() && !e.Get("ctrlKey").Bool() {
+		e.Call("preventDefault")
+	}
+
+	p.push(Event{Type: EventKey, Window: window, Key: KeyEvent{
+		Key:       key,
+		Scancode:  uint32(e.Get("keyCode").Int()), //nolint:gosec // G115: key codes are small
+		Down:      down,
+		Repeat:    e.Get("repeat").Bool(),
+		Modifiers: jsModifiers(e),
+	}})
+}
+
+// jsModifiers returns the modifiers held during a keyboard, mouse or
+// wheel event.
+func jsModifiers(e js.Value) input.Modifier {
+	var mods input.Modifier
+	if e.Get("shiftKey").Bool() {
+		mods |= input.ModShift
+	}
+	if e.Get("ctrlKey").Bool() {
+		mods |= input.ModControl
+	}
+	if e.Get("altKey").Bool() {
+		mods |= input.ModAlt
+	}
+	if e.Get("metaKey").Bool() {
+		mods |= input.ModSuper
+	}
+	return mods
+}
+
+// mouseEvent returns the position and modifiers of a mouse or wheel
+// event, converting the CSS pixel offset on the canvas to pixels.
+func (p *jsPlatform) mouseEvent(e js.Value) MouseEvent {
+	p.mu.Lock()
+	scale := p.scale
+	p.mu.Unlock()
+
+	return MouseEvent{
+		X:         e.Get("offsetX").Float() * scale,
+		Y:         e.Get("offsetY").Float() * scale,
+		Modifiers: jsModifiers(e),
+	}
+}
+
+// handleMouseButton reports a mousedown or mouseup event. MouseEvent.button
+// numbers the middle button 1 and the right button 2.
+func (p *jsPlatform) handleMouseButton(window WindowID, e js.Value, down bool) {
+	mouse := p.mouseEvent(e)
+	mouse.Down = down
+	switch e.Get("button").Int() {
+	case 0:
+		mouse.Button = input.MouseButtonLeft
+	case 1:
+		mouse.Button = input.MouseButtonMiddle
+	case 2:
+		mouse.Button = input.MouseButtonRight
+	case 3:
+		mouse.Button = input.MouseButton4
+	case 4:
+		mouse.Button = input.MouseButton5
+	default:
+		return
+	}
+	p.push(Event{Type: EventMouseButton, Window: window, Mouse: mouse})
+}
+
+// handleMouseMove reports a mousemove event.
+func (p *jsPlatform) handleMouseMove(window WindowID, e js.Value) {
+	p.push(Event{Type: EventMouseMove, Window: window, Mouse: p.mouseEvent(e)})
+}
+
+// Wheel step sizes per WheelEvent.deltaMode, as reported by browsers for
+// one notch of a mouse wheel.
+const (
+	wheelPixelsPerStep = 100
+	wheelLinesPerStep  = 3
+)
+
+// handleWheel reports a wheel event and keeps the page from scrolling.
+// WheelEvent deltas are positive down and right.
+func (p *jsPlatform) handleWheel(window WindowID, e js.Value) {
+	e.Call("preventDefault")
+
+	step := 1.0
+	switch e.Get("deltaMode").Int() {
+	case 0: // DOM_DELTA_PIXEL
+		step = wheelPixelsPerStep
+	case 1: // DOM_DELTA_LINE
+		step = wheelLinesPerStep
+	}
+
+	mouse := p.mouseEvent(e)
+	mouse.ScrollX = e.Get("deltaX").Float() / step
+	mouse.ScrollY = -e.Get("deltaY").Float() / step
+	p.push(Event{Type: EventScroll, Window: window, Mouse: mouse})
+}
+
+// keyFromChar returns the key typing the letter or digit c.
+func keyFromChar(c byte) input.Key {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return input.KeyA + input.Key(c-'a')
+	case c >= 'A' && c <= 'Z':
+		return input.KeyA + input.Key(c-'A')
+	case c >= '0' && c <= '9':
+		return input.Key0 + input.Key(c-'0')
+	}
+	return input.KeyUnknown
+}
+
+// codeKeys maps KeyboardEvent.code values, which name physical keys, to
+// keys. Letters, digits and function keys are handled by keyFromCode.
+var codeKeys = map[string]input.Key{
+	"Space":          input.KeySpace,
+	"Enter":          input.KeyEnter,
+	"Escape":         input.KeyEscape,
+	"Backspace":      input.KeyBackspace,
+	"Tab":            input.KeyTab,
+	"CapsLock":       input.KeyCapsLock,
+	"ShiftLeft":      input.KeyShiftLeft,
+	"ShiftRight":     input.KeyShiftRight,
+	"ControlLeft":    input.KeyControlLeft,
+	"ControlRight":   input.KeyControlRight,
+	"AltLeft":        input.KeyAltLeft,
+	"AltRight":       input.KeyAltRight,
+	"MetaLeft":       input.KeySuperLeft,
+	"MetaRight":      input.KeySuperRight,
+	"ArrowUp":        input.KeyUp,
+	"ArrowDown":      input.KeyDown,
+	"ArrowLeft":      input.KeyLeft,
+	"ArrowRight":     input.KeyRight,
+	"Insert":         input.KeyInsert,
+	"Delete":         input.KeyDelete,
+	"Home":           input.KeyHome,
+	"End":            input.KeyEnd,
+	"PageUp":         input.KeyPageUp,
+	"PageDown":       input.KeyPageDown,
+	"Minus":          input.KeyMinus,
+	"Equal":          input.KeyEqual,
+	"BracketLeft":    input.KeyLeftBracket,
+	"BracketRight":   input.KeyRightBracket,
+	"Backslash":      input.KeyBackslash,
+	"Semicolon":      input.KeySemicolon,
+	"Quote":          input.KeyApostrophe,
+	"Backquote":      input.KeyGrave,
+	"Comma":          input.KeyComma,
+	"Period":         input.KeyPeriod,
+	"Slash":          input.KeySlash,
+	"NumpadAdd":      input.KeyNumpadAdd,
+	"NumpadSubtract": input.KeyNumpadSubtract,
+	"NumpadMultiply": input.KeyNumpadMultiply,
+	"NumpadDivide":   input.KeyNumpadDivide,
+	"NumpadEnter":    input.KeyNumpadEnter,
+	"NumpadDecimal":  input.KeyNumpadDecimal,
+	"NumLock":        input.KeyNumLock,
+	"PrintScreen":    input.KeyPrintScreen,
+	"ScrollLock":     input.KeyScrollLock,
+	"Pause":          input.KeyPause,
+}
+
+// keyFromCode converts a KeyboardEvent.code value to a key.
+func keyFromCode(code string) input.Key {
+	if key, ok := codeKeys[code]; ok {
+		return key
+	}
+
+	switch {
+	case strings.HasPrefix(code, "Key") && len(code) == 4:
+		return keyFromChar(code[3])
+	case strings.HasPrefix(code, "Digit") && len(code) == 6:
+		return keyFromChar(code[5])
+	case strings.HasPrefix(code, "Numpad") && len(code) == 7 && code[6] >= '0' && code[6] <= '9':
+		return input.KeyNumpad0 + input.Key(code[6]-'0')
+	case strings.HasPrefix(code, "F") && len(code) <= 3:
+		n := 0
+		for _, c := range code[1:] {
+			if c < '0' || c > '9' {
+				return input.KeyUnknown
+			}
+			n = n*10 + int(c-'0')
+		}
+		if n >= 1 && n <= 12 {
+			return input.KeyF1 + input.Key(n-1)
+		}
+	}
+	return input.KeyUnknown
+}
+
+// PollEvents returns the next queued event.
+func (p *jsPlatform) PollEvents() Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.events) == 0 {
+		return Event{Type: EventNone}
+	}
+	event := p.events[0]
+	p.events = p.events[1:]
+	return event
+}
+
+// ShouldClose returns true once the page is being left.
+func (p *jsPlatform) ShouldClose() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.shouldClose
+}
+
+// GetSize returns the canvas size in device pixels.
+func (p *jsPlatform) GetSize() (width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.width, p.height
+}
+
+// GetHandle returns the handle of the main canvas: (0, 1).
+func (p *jsPlatform) GetHandle() (instance, window uintptr) {
+	return 0, canvasWindow
+}
+
+// WaitFrame blocks until the browser's next animation frame. It always
+// waits, regardless of Config.PaceToDisplay: the browser only presents
+// the canvas and runs event listeners while Go code is blocked.
+func (p *jsPlatform) WaitFrame() {
+	js.Global().Call("requestAnimationFrame", p.onFrame)
+	<-p.frame
+}
+
+// ScaleFactor returns the device pixel ratio of the page.
+func (p *jsPlatform) ScaleFactor() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.scale
+}
+
+// SetIcon is not supported in the browser; pages set their favicon.
+func (p *jsPlatform) SetIcon(width, height int, pixels []byte) error {
+	return ErrUnsupported
+}
+
+// BeginMove is not supported in the browser.
+func (p *jsPlatform) BeginMove() error {
+	return ErrUnsupported
+}
+
+// BeginResize is not supported in the browser.
+func (p *jsPlatform) BeginResize(edge ResizeEdge) error {
+	return ErrUnsupported
+}
+
+// ShowWindowMenu is not supported in the browser.
+func (p *jsPlatform) ShowWindowMenu(x, y int) error {
+	return ErrUnsupported
+}
+
+// Destroy removes the event listeners and the canvases created by Init
+// and CreateWindow.
+func (p *jsPlatform) Destroy() {
+	for id := range p.windows {
+		p.DestroyWindow(id)
+	}
+
+	for _, l := range p.listeners {
+		l.target.Call("removeEventListener", l.event, l.fn)
+		l.fn.Release()
+	}
+	p.listeners = nil
+
+	if p.onFrame.Truthy() {
+		p.onFrame.Release()
+	}
+	if p.created {
+		p.canvas.Call("remove")
+		p.created = false
+	}
+}
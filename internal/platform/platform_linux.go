@@ -3,10 +3,12 @@
 package platform
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sync"
 
+	"github.com/gogpu/gogpu/input"
 	"github.com/gogpu/gogpu/internal/platform/wayland"
 	"github.com/gogpu/gogpu/internal/platform/x11"
 )
@@ -24,26 +26,94 @@ type waylandPlatform struct {
 	xdgSurface *wayland.XdgSurface
 	toplevel   *wayland.XdgToplevel
 
+	// Optional protocols
+	contentTypeManager *wayland.WpContentTypeManagerV1
+	contentType        *wayland.WpContentTypeV1
+	shm                *wayland.WlShm
+	iconManager        *wayland.XdgToplevelIconManagerV1
+	icon               *wayland.XdgToplevelIconV1
+	iconBuffer         *wayland.WlBuffer
+	activation         *wayland.XdgActivationV1
+
 	// Input devices
 	seat     *wayland.WlSeat
 	keyboard *wayland.WlKeyboard
 	pointer  *wayland.WlPointer
 
+	// Cursor of the window, applied on pointer enter with its serial. A
+	// custom cursor is shown from its own surface.
+	cursorShapeManager *wayland.WpCursorShapeManagerV1
+	cursorShapeDevice  *wayland.WpCursorShapeDeviceV1
+	cursor             linuxCursor
+	cursorSurface      *wayland.WlSurface
+	cursorBuffer       *wayland.WlBuffer
+	cursorHotX         int32
+	cursorHotY         int32
+	enterSerial        uint32
+
+	// Tablet (stylus) input
+	tabletManager *wayland.ZwpTabletManagerV2
+	tabletSeat    *wayland.ZwpTabletSeatV2
+
+	// Clipboard, and the source serving our text while it is the selection
+	dataDeviceManager *wayland.WlDataDeviceManager
+	dataDevice        *wayland.WlDataDevice
+	dataDeviceVersion uint32
+	clipboardSource   *wayland.WlDataSource
+	clipboardText     string
+
+	// Whether files are being dragged over the window, and where
+	dragging bool
+	dragX    float64
+	dragY    float64
+
+	// Input events waiting for PollEvents
+	events []Event
+
+	// Keyboard modifiers held, from the last wl_keyboard modifiers event
+	modifiers input.Modifier
+
+	// Serial of the last pointer button press, required by
+	// xdg_toplevel move/resize/show_window_menu
+	buttonSerial uint32
+
+	// Additional windows opened with CreateWindow
+	windows      map[WindowID]*waylandWindow
+	nextWindowID WindowID
+
+	// Windows with the pointer and keyboard focus; input events carry
+	// their IDs
+	pointerWindow  WindowID
+	keyboardWindow WindowID
+
 	// Window state
 	width       int
 	height      int
 	shouldClose bool
 	configured  bool
+	activated   bool // Toplevel has the activated state
+	suspended   bool // Toplevel has the suspended state
 
 	// Pending resize from configure event
 	pendingWidth  int
 	pendingHeight int
 	hasResize     bool
+
+	gamepads evdevGamepads
 }
 
 // x11Platform wraps x11.Platform to implement the Platform interface.
 type x11Platform struct {
 	inner *x11.Platform
+
+	// X11 windows of the additional windows opened with CreateWindow
+	windows      map[WindowID]x11.ResourceID
+	nextWindowID WindowID
+
+	// Cursor state of the windows whose cursor was changed
+	cursors map[WindowID]*linuxCursor
+
+	gamepads evdevGamepads
 }
 
 // newPlatform creates the platform-specific implementation.
@@ -75,17 +145,107 @@ func (p *x11Platform) Init(config Config) error {
 
 // PollEvents processes pending X11 events.
 func (p *x11Platform) PollEvents() Event {
+	if gamepad, ok := p.gamepads.poll(); ok {
+		return Event{Type: EventGamepad, Gamepad: gamepad}
+	}
+
 	event := p.inner.PollEvents()
+	window := MainWindow
+	if event.Window != 0 {
+		var ok bool
+		if window, ok = p.windowID(event.Window); !ok {
+			// Late event of a destroyed window
+			return p.PollEvents()
+		}
+	}
 	switch event.Type {
 	case x11.EventTypeClose:
-		return Event{Type: EventClose}
+		return Event{Type: EventClose, Window: window}
 	case x11.EventTypeResize:
-		return Event{Type: EventResize, Width: event.Width, Height: event.Height}
+		return Event{Type: EventResize, Window: window, Width: event.Width, Height: event.Height}
+	case x11.EventTypeKey:
+		key := x11Keysyms[event.Keysym]
+		return Event{Type: EventKey, Window: window, Key: KeyEvent{
+			Key:       key,
+			Scancode:  uint32(event.Keycode),
+			Down:      event.Down,
+			Repeat:    event.Repeat,
+			Modifiers: keyModifiers(x11Modifiers(event.State), key, event.Down),
+		}}
+	case x11.EventTypeButton:
+		if e, ok := x11ButtonEvent(window, event); ok {
+			return e
+		}
+		// Wheel step release or unknown button
+		return p.PollEvents()
+	case x11.EventTypeMotion:
+		return Event{Type: EventMouseMove, Window: window, Mouse: MouseEvent{
+			X:         float64(event.X),
+			Y:         float64(event.Y),
+			Modifiers: x11Modifiers(event.State),
+		}}
+	case x11.EventTypeFocus:
+		state := WindowUnfocused
+		if event.Active {
+			state = WindowFocused
+		}
+		return Event{Type: EventWindowState, Window: window, State: state}
+	case x11.EventTypeMap:
+		// Window managers unmap iconified windows
+		state := WindowMinimized
+		if event.Active {
+			state = WindowRestored
+		}
+		return Event{Type: EventWindowState, Window: window, State: state}
 	default:
 		return Event{Type: EventNone}
 	}
 }
 
+// x11ButtonEvent converts a core protocol button event. Buttons 4 to 7
+// are wheel steps, reported as a press and a release; the press becomes
+// a scroll of one line and the release is dropped.
+func x11ButtonEvent(window WindowID, event x11.PlatformEvent) (Event, bool) {
+	mouse := MouseEvent{
+		X:         float64(event.X),
+		Y:         float64(event.Y),
+		Down:      event.Down,
+		Modifiers: x11Modifiers(event.State),
+	}
+
+	switch event.Button {
+	case 1:
+		mouse.Button = input.MouseButtonLeft
+	case 2:
+		mouse.Button = input.MouseButtonMiddle
+	case 3:
+		mouse.Button = input.MouseButtonRight
+	case 4, 5, 6, 7:
+		if !event.Down {
+			return Event{}, false
+		}
+		mouse.Down = false
+		switch event.Button {
+		case 4:
+			mouse.ScrollY = 1
+		case 5:
+			mouse.ScrollY = -1
+		case 6:
+			mouse.ScrollX = -1
+		case 7:
+			mouse.ScrollX = 1
+		}
+		return Event{Type: EventScroll, Window: window, Mouse: mouse}, true
+	case 8:
+		mouse.Button = input.MouseButton4
+	case 9:
+		mouse.Button = input.MouseButton5
+	default:
+		return Event{}, false
+	}
+	return Event{Type: EventMouseButton, Window: window, Mouse: mouse}, true
+}
+
 // ShouldClose returns true if window close was requested.
 func (p *x11Platform) ShouldClose() bool {
 	return p.inner.ShouldClose()
@@ -101,9 +261,118 @@ func (p *x11Platform) GetHandle() (instance, window uintptr) {
 	return p.inner.GetHandle()
 }
 
+// BeginMove starts an interactive move via _NET_WM_MOVERESIZE.
+func (p *x11Platform) BeginMove() error {
+	return p.beginMoveResize(x11.MoveResizeMove)
+}
+
+// BeginResize starts an interactive resize via _NET_WM_MOVERESIZE.
+func (p *x11Platform) BeginResize(edge ResizeEdge) error {
+	direction, ok := x11MoveResizeEdges[edge]
+	if !ok {
+		return fmt.Errorf("x11: invalid resize edge %d", edge)
+	}
+	return p.beginMoveResize(direction)
+}
+
+// beginMoveResize hands the held pointer button to the window manager.
+func (p *x11Platform) beginMoveResize(direction uint32) error {
+	err := p.inner.BeginMoveResize(direction)
+	if errors.Is(err, x11.ErrNoButtonHeld) {
+		return ErrUnsupported
+	}
+	return err
+}
+
+// x11MoveResizeEdges maps ResizeEdge to _NET_WM_MOVERESIZE directions.
+var x11MoveResizeEdges = map[ResizeEdge]uint32{
+	ResizeEdgeTop:         x11.MoveResizeTop,
+	ResizeEdgeBottom:      x11.MoveResizeBottom,
+	ResizeEdgeLeft:        x11.MoveResizeLeft,
+	ResizeEdgeRight:       x11.MoveResizeRight,
+	ResizeEdgeTopLeft:     x11.MoveResizeTopLeft,
+	ResizeEdgeTopRight:    x11.MoveResizeTopRight,
+	ResizeEdgeBottomLeft:  x11.MoveResizeBottomLeft,
+	ResizeEdgeBottomRight: x11.MoveResizeBottomRight,
+}
+
+// ShowWindowMenu is not supported on X11; window menus belong to the window manager.
+func (p *x11Platform) ShowWindowMenu(x, y int) error {
+	return ErrUnsupported
+}
+
+// SetIcon sets the window icon.
+// Not yet implemented for X11 (_NET_WM_ICON needs BIG-REQUESTS for large icons).
+func (p *x11Platform) SetIcon(width, height int, pixels []byte) error {
+	return ErrUnsupported
+}
+
 // Destroy closes the window and releases resources.
 func (p *x11Platform) Destroy() {
+	p.gamepads.close()
 	p.inner.Destroy()
+	clear(p.windows)
+	clear(p.cursors)
+}
+
+// CreateWindow opens an additional X11 window.
+func (p *x11Platform) CreateWindow(config Config) (WindowID, error) {
+	window, err := p.inner.CreateWindow(x11.Config{
+		Title:      config.Title,
+		Width:      config.Width,
+		Height:     config.Height,
+		Resizable:  config.Resizable,
+		Fullscreen: config.Fullscreen,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	p.nextWindowID++
+	if p.windows == nil {
+		p.windows = make(map[WindowID]x11.ResourceID)
+	}
+	p.windows[p.nextWindowID] = window
+	return p.nextWindowID, nil
+}
+
+// DestroyWindow closes a window opened with CreateWindow.
+func (p *x11Platform) DestroyWindow(id WindowID) {
+	if window, ok := p.windows[id]; ok {
+		p.inner.DestroyWindow(window)
+		delete(p.windows, id)
+		delete(p.cursors, id)
+	}
+}
+
+// WindowSize returns the size of a window in pixels.
+func (p *x11Platform) WindowSize(id WindowID) (width, height int) {
+	if id == MainWindow {
+		return p.inner.GetSize()
+	}
+	return p.inner.WindowSize(p.windows[id])
+}
+
+// WindowHandle returns the surface handles of a window, like GetHandle.
+func (p *x11Platform) WindowHandle(id WindowID) (instance, window uintptr) {
+	instance, main := p.inner.GetHandle()
+	if id == MainWindow {
+		return instance, main
+	}
+	if w, ok := p.windows[id]; ok {
+		return instance, uintptr(w)
+	}
+	return 0, 0
+}
+
+// windowID returns the ID of an additional X11 window.
+func (p *x11Platform) windowID(window x11.ResourceID) (WindowID, bool) {
+	for id, w := range p.windows {
+		if w == window {
+			return id, true
+		}
+	}
+	return 0, false
 }
 
 // Init creates the Wayland window.
@@ -146,8 +415,8 @@ func (p *waylandPlatform) Init(config Config) error {
 	}
 	p.compositor = wayland.NewWlCompositor(display, compositorID)
 
-	// Bind to xdg_wm_base
-	xdgWmBaseID, err := registry.BindXdgWmBase(2)
+	// Bind to xdg_wm_base, version 6 for the suspended toplevel state
+	xdgWmBaseID, err := registry.BindXdgWmBase(min(registry.GlobalVersion(wayland.InterfaceXdgWmBase), 6))
 	if err != nil {
 		_ = display.Close()
 		return fmt.Errorf("wayland: failed to bind xdg_wm_base: %w", err)
@@ -204,6 +473,16 @@ func (p *waylandPlatform) Init(config Config) error {
 		}
 	}
 
+	// Hint at the kind of content shown (applied on commit)
+	if config.ContentType != ContentTypeNone && registry.HasGlobal(wayland.InterfaceWpContentTypeManager) {
+		_ = p.setupContentType(wayland.ContentType(config.ContentType)) // Non-fatal: hint only
+	}
+
+	// Bind icon support if the compositor provides it
+	if registry.HasGlobal(wayland.InterfaceXdgToplevelIconManager) && registry.HasGlobal(wayland.InterfaceWlShm) {
+		_ = p.bindIconManager() // Non-fatal: SetIcon reports ErrUnsupported
+	}
+
 	// Set up event handlers
 	p.setupEventHandlers()
 
@@ -224,6 +503,26 @@ func (p *waylandPlatform) Init(config Config) error {
 		_ = p.bindSeat() // Non-fatal: we can run without input devices
 	}
 
+	// Optionally bind tablet support for stylus input
+	if p.seat != nil && registry.HasGlobal(wayland.InterfaceZwpTabletManagerV2) {
+		_ = p.bindTablet() // Non-fatal: pen input is optional
+	}
+
+	// Optionally bind cursor shapes for SetCursor
+	if p.pointer != nil && registry.HasGlobal(wayland.InterfaceWpCursorShapeManager) {
+		_ = p.bindCursorShape() // Non-fatal: SetCursor reports ErrUnsupported
+	}
+
+	// Optionally bind the data device for the clipboard and file drops
+	if p.seat != nil && registry.HasGlobal(wayland.InterfaceWlDataDeviceManager) {
+		_ = p.bindDataDevice() // Non-fatal: the clipboard reports ErrUnsupported
+	}
+
+	// Optionally bind activation for FocusWindow and RequestWindowAttention
+	if registry.HasGlobal(wayland.InterfaceXdgActivation) {
+		_ = p.bindActivation() // Non-fatal: both report ErrUnsupported
+	}
+
 	// Set fullscreen if requested
 	if config.Fullscreen {
 		_ = toplevel.SetFullscreen(0) // Non-fatal, continue
@@ -270,6 +569,25 @@ func (p *waylandPlatform) setupEventHandlers() {
 				p.hasResize = true
 			}
 		}
+
+		// Compositors hide minimized windows and those on other
+		// workspaces behind the suspended state
+		if config.Activated != p.activated {
+			p.activated = config.Activated
+			state := WindowUnfocused
+			if config.Activated {
+				state = WindowFocused
+			}
+			p.events = append(p.events, Event{Type: EventWindowState, State: state})
+		}
+		if config.Suspended != p.suspended {
+			p.suspended = config.Suspended
+			state := WindowVisible
+			if config.Suspended {
+				state = WindowOccluded
+			}
+			p.events = append(p.events, Event{Type: EventWindowState, State: state})
+		}
 	})
 
 	// Handle toplevel close
@@ -300,6 +618,40 @@ func (p *waylandPlatform) waitForConfigure() error {
 	return fmt.Errorf("timeout waiting for configure")
 }
 
+// setupContentType binds wp_content_type_manager_v1 and sets the surface content type.
+func (p *waylandPlatform) setupContentType(contentType wayland.ContentType) error {
+	managerID, err := p.registry.BindContentTypeManager(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind content type manager: %w", err)
+	}
+	p.contentTypeManager = wayland.NewWpContentTypeManagerV1(p.display, managerID)
+
+	ct, err := p.contentTypeManager.GetSurfaceContentType(p.surface)
+	if err != nil {
+		return fmt.Errorf("failed to get surface content type: %w", err)
+	}
+	p.contentType = ct
+
+	return ct.SetContentType(contentType)
+}
+
+// bindIconManager binds wl_shm and xdg_toplevel_icon_manager_v1 for window icons.
+func (p *waylandPlatform) bindIconManager() error {
+	shmID, err := p.registry.BindShm(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind shm: %w", err)
+	}
+	p.shm = wayland.NewWlShm(p.display, shmID)
+
+	managerID, err := p.registry.BindToplevelIconManager(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind toplevel icon manager: %w", err)
+	}
+	p.iconManager = wayland.NewXdgToplevelIconManagerV1(p.display, managerID)
+
+	return nil
+}
+
 // bindSeat binds to the wl_seat for input devices.
 func (p *waylandPlatform) bindSeat() error {
 	seatVersion := p.registry.GlobalVersion(wayland.InterfaceWlSeat)
@@ -328,6 +680,7 @@ func (p *waylandPlatform) bindSeat() error {
 		keyboard, err := p.seat.GetKeyboard()
 		if err == nil {
 			p.keyboard = keyboard
+			p.setupKeyboard(keyboard)
 		}
 	}
 
@@ -336,16 +689,214 @@ func (p *waylandPlatform) bindSeat() error {
 		pointer, err := p.seat.GetPointer()
 		if err == nil {
 			p.pointer = pointer
+			p.setupPointer(pointer)
 		}
 	}
 
 	return nil
 }
 
+// setupKeyboard queues key events. Keys held when the window gains
+// focus are reported as presses, and keys still held when it loses focus
+// as releases. The compositor leaves key repeat to clients; held keys
+// are not repeated.
+func (p *waylandPlatform) setupKeyboard(keyboard *wayland.WlKeyboard) {
+	keyboard.SetModifiersHandler(func(event *wayland.KeyboardModifiersEvent) {
+		p.mu.Lock()
+		p.modifiers = waylandModifiers(event.ModsDepressed)
+		p.mu.Unlock()
+	})
+	keyboard.SetEnterHandler(func(event *wayland.KeyboardEnterEvent) {
+		p.mu.Lock()
+		p.keyboardWindow = p.surfaceWindow(event.Surface)
+		p.mu.Unlock()
+	})
+	keyboard.SetKeyHandler(func(event *wayland.KeyboardKeyEvent) {
+		key := evdevKeys[event.Key]
+		down := event.State == wayland.KeyStatePressed
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.events = append(p.events, Event{Type: EventKey, Window: p.keyboardWindow, Key: KeyEvent{
+			Key:       key,
+			Scancode:  event.Key,
+			Down:      down,
+			Modifiers: keyModifiers(p.modifiers, key, down),
+		}})
+	})
+}
+
+// setupPointer queues mouse events and keeps the serial of the last
+// button press for interactive moves and resizes.
+func (p *waylandPlatform) setupPointer(pointer *wayland.WlPointer) {
+	pointer.SetEnterHandler(func(event *wayland.PointerEnterEvent) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.enterSerial = event.Serial
+		p.pointerWindow = p.surfaceWindow(event.Surface)
+		_ = p.applyCursor(event.Serial)
+	})
+	pointer.SetMotionHandler(func(event *wayland.PointerMotionEvent) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.events = append(p.events, Event{Type: EventMouseMove, Window: p.pointerWindow, Mouse: MouseEvent{
+			X:         event.SurfaceX,
+			Y:         event.SurfaceY,
+			Modifiers: p.modifiers,
+		}})
+	})
+	pointer.SetButtonHandler(func(event *wayland.PointerButtonEvent) {
+		down := event.State == wayland.PointerButtonStatePressed
+		x, y := pointer.Position()
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if down {
+			p.buttonSerial = event.Serial
+		}
+		button, ok := waylandButtons[event.Button]
+		if !ok {
+			return
+		}
+		p.events = append(p.events, Event{Type: EventMouseButton, Window: p.pointerWindow, Mouse: MouseEvent{
+			X:         x,
+			Y:         y,
+			Button:    button,
+			Down:      down,
+			Modifiers: p.modifiers,
+		}})
+	})
+	pointer.SetAxisHandler(func(event *wayland.PointerAxisEvent) {
+		// Axis values are in surface units, about 10 per wheel step,
+		// with positive values scrolling down or right
+		var mouse MouseEvent
+		mouse.X, mouse.Y = pointer.Position()
+		if event.Axis == wayland.PointerAxisHorizontalScroll {
+			mouse.ScrollX = event.Value / waylandScrollStep
+		} else {
+			mouse.ScrollY = -event.Value / waylandScrollStep
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		mouse.Modifiers = p.modifiers
+		p.events = append(p.events, Event{Type: EventScroll, Window: p.pointerWindow, Mouse: mouse})
+	})
+}
+
+// bindTablet binds zwp_tablet_manager_v2 and forwards tool frames as pen events.
+func (p *waylandPlatform) bindTablet() error {
+	managerID, err := p.registry.BindTabletManager(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind tablet manager: %w", err)
+	}
+	p.tabletManager = wayland.NewZwpTabletManagerV2(p.display, managerID)
+
+	tabletSeat, err := p.tabletManager.GetTabletSeat(p.seat)
+	if err != nil {
+		return fmt.Errorf("failed to get tablet seat: %w", err)
+	}
+	p.tabletSeat = tabletSeat
+
+	tabletSeat.SetToolAddedHandler(func(tool *wayland.ZwpTabletToolV2) {
+		tool.SetFrameHandler(func(frame *wayland.TabletToolFrame) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			for _, pen := range penEventsFromFrame(frame) {
+				p.events = append(p.events, Event{Type: EventPen, Pen: pen})
+			}
+		})
+	})
+
+	return nil
+}
+
+// penEventsFromFrame converts a tablet tool frame into pen events,
+// one per transition, or a single move event if nothing else changed.
+func penEventsFromFrame(frame *wayland.TabletToolFrame) []PenEvent {
+	base := PenEvent{
+		Tool:     penToolFromType(frame.Type),
+		X:        frame.X,
+		Y:        frame.Y,
+		Pressure: frame.Pressure,
+		Distance: frame.Distance,
+		TiltX:    frame.TiltX,
+		TiltY:    frame.TiltY,
+		Rotation: frame.Rotation,
+		Contact:  frame.Contact,
+	}
+	if frame.Buttons[wayland.TabletButtonStylus] {
+		base.Buttons |= PenButtonPrimary
+	}
+	if frame.Buttons[wayland.TabletButtonStylus2] {
+		base.Buttons |= PenButtonSecondary
+	}
+	if frame.Buttons[wayland.TabletButtonStylus3] {
+		base.Buttons |= PenButtonTertiary
+	}
+	if !frame.Contact {
+		base.Pressure = 0
+	}
+
+	var phases []PenPhase
+	if frame.ProximityIn {
+		phases = append(phases, PenPhaseProximityIn)
+	}
+	if frame.Down {
+		phases = append(phases, PenPhaseDown)
+	}
+	if frame.Up {
+		phases = append(phases, PenPhaseUp)
+	}
+	if frame.ProximityOut {
+		phases = append(phases, PenPhaseProximityOut)
+	}
+	if len(phases) == 0 {
+		phases = append(phases, PenPhaseMove)
+	}
+
+	events := make([]PenEvent, len(phases))
+	for i, phase := range phases {
+		events[i] = base
+		events[i].Phase = phase
+	}
+	return events
+}
+
+// penToolFromType maps a zwp_tablet_tool_v2 type to a PenTool.
+func penToolFromType(toolType wayland.TabletToolType) PenTool {
+	switch toolType {
+	case wayland.TabletToolTypePen:
+		return PenToolPen
+	case wayland.TabletToolTypeEraser:
+		return PenToolEraser
+	case wayland.TabletToolTypeBrush:
+		return PenToolBrush
+	case wayland.TabletToolTypePencil:
+		return PenToolPencil
+	case wayland.TabletToolTypeAirbrush:
+		return PenToolAirbrush
+	default:
+		return PenToolOther
+	}
+}
+
 // PollEvents processes pending Wayland events.
 func (p *waylandPlatform) PollEvents() Event {
+	if gamepad, ok := p.gamepads.poll(); ok {
+		return Event{Type: EventGamepad, Gamepad: gamepad}
+	}
+
 	p.mu.Lock()
 
+	// Return queued input events first
+	if len(p.events) > 0 {
+		event := p.events[0]
+		p.events = p.events[1:]
+		p.mu.Unlock()
+		return event
+	}
+
 	// Check for pending resize
 	if p.hasResize {
 		p.width = p.pendingWidth
@@ -396,6 +947,12 @@ func (p *waylandPlatform) PollEvents() Event {
 		return Event{Type: EventClose}
 	}
 
+	if len(p.events) > 0 {
+		event := p.events[0]
+		p.events = p.events[1:]
+		return event
+	}
+
 	return Event{Type: EventNone}
 }
 
@@ -428,13 +985,154 @@ func (p *waylandPlatform) GetHandle() (instance, window uintptr) {
 	return p.display.Ptr(), p.surface.Ptr()
 }
 
+// WaylandHandles reports that the handles are of Wayland windows.
+func (p *waylandPlatform) WaylandHandles() bool {
+	return true
+}
+
+// SetIcon sets the window icon via xdg_toplevel_icon_v1.
+// Icons must be square; the compositor scales them as needed.
+func (p *waylandPlatform) SetIcon(width, height int, pixels []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.iconManager == nil || p.shm == nil || p.toplevel == nil {
+		return ErrUnsupported
+	}
+	if width != height {
+		return fmt.Errorf("wayland: icon must be square, got %dx%d", width, height)
+	}
+
+	buffer, err := p.shm.CreateImageBuffer(width, height, pixels)
+	if err != nil {
+		return err
+	}
+
+	icon, err := p.iconManager.CreateIcon()
+	if err != nil {
+		_ = buffer.Destroy()
+		return fmt.Errorf("wayland: failed to create icon: %w", err)
+	}
+	if err := icon.AddBuffer(buffer, 1); err != nil {
+		_ = icon.Destroy()
+		_ = buffer.Destroy()
+		return fmt.Errorf("wayland: failed to add icon buffer: %w", err)
+	}
+	if err := p.iconManager.SetIcon(p.toplevel, icon); err != nil {
+		_ = icon.Destroy()
+		_ = buffer.Destroy()
+		return fmt.Errorf("wayland: failed to set icon: %w", err)
+	}
+
+	// The previous icon is no longer referenced by the toplevel
+	p.releaseIcon()
+	p.icon = icon
+	p.iconBuffer = buffer
+
+	return p.surface.Commit()
+}
+
+// BeginMove starts an interactive move via xdg_toplevel.move.
+func (p *waylandPlatform) BeginMove() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.seat == nil || p.toplevel == nil || p.buttonSerial == 0 {
+		return ErrUnsupported
+	}
+	return p.toplevel.Move(p.seat.ID(), p.buttonSerial)
+}
+
+// BeginResize starts an interactive resize via xdg_toplevel.resize.
+func (p *waylandPlatform) BeginResize(edge ResizeEdge) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.seat == nil || p.toplevel == nil || p.buttonSerial == 0 {
+		return ErrUnsupported
+	}
+
+	edges, ok := xdgResizeEdges[edge]
+	if !ok {
+		return fmt.Errorf("wayland: invalid resize edge %d", edge)
+	}
+	return p.toplevel.Resize(p.seat.ID(), p.buttonSerial, edges)
+}
+
+// ShowWindowMenu asks the compositor to show its window menu via xdg_toplevel.show_window_menu.
+func (p *waylandPlatform) ShowWindowMenu(x, y int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.seat == nil || p.toplevel == nil || p.buttonSerial == 0 {
+		return ErrUnsupported
+	}
+	return p.toplevel.ShowWindowMenu(p.seat.ID(), p.buttonSerial, int32(x), int32(y)) //nolint:gosec // G115: window coordinates fit int32
+}
+
+// xdgResizeEdges maps ResizeEdge to xdg_toplevel.resize_edge values.
+var xdgResizeEdges = map[ResizeEdge]uint32{
+	ResizeEdgeTop:         wayland.XdgToplevelResizeEdgeTop,
+	ResizeEdgeBottom:      wayland.XdgToplevelResizeEdgeBottom,
+	ResizeEdgeLeft:        wayland.XdgToplevelResizeEdgeLeft,
+	ResizeEdgeRight:       wayland.XdgToplevelResizeEdgeRight,
+	ResizeEdgeTopLeft:     wayland.XdgToplevelResizeEdgeTopLeft,
+	ResizeEdgeTopRight:    wayland.XdgToplevelResizeEdgeTopRight,
+	ResizeEdgeBottomLeft:  wayland.XdgToplevelResizeEdgeBottomLeft,
+	ResizeEdgeBottomRight: wayland.XdgToplevelResizeEdgeBottomRight,
+}
+
+// releaseIcon destroys the current icon and its buffer.
+// Must be called with p.mu held.
+func (p *waylandPlatform) releaseIcon() {
+	if p.icon != nil {
+		_ = p.icon.Destroy()
+		p.icon = nil
+	}
+	if p.iconBuffer != nil {
+		_ = p.iconBuffer.Destroy()
+		p.iconBuffer = nil
+	}
+}
+
 // Destroy closes the window and releases resources.
 func (p *waylandPlatform) Destroy() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	p.gamepads.close()
+
 	// Destroy in reverse order of creation
 
+	for _, w := range p.windows {
+		p.destroyToplevel(w)
+	}
+	clear(p.windows)
+
+	if p.clipboardSource != nil {
+		_ = p.clipboardSource.Destroy()
+		p.clipboardSource = nil
+	}
+	p.dataDevice = nil
+	p.dataDeviceManager = nil
+
+	p.releaseCursor()
+
+	if p.activation != nil {
+		_ = p.activation.Destroy()
+		p.activation = nil
+	}
+
+	if p.tabletSeat != nil {
+		_ = p.tabletSeat.Destroy()
+		p.tabletSeat = nil
+	}
+
+	if p.tabletManager != nil {
+		_ = p.tabletManager.Destroy()
+		p.tabletManager = nil
+	}
+
 	if p.pointer != nil {
 		_ = p.pointer.Release()
 		p.pointer = nil
@@ -450,6 +1148,23 @@ func (p *waylandPlatform) Destroy() {
 		p.seat = nil
 	}
 
+	p.releaseIcon()
+
+	if p.iconManager != nil {
+		_ = p.iconManager.Destroy()
+		p.iconManager = nil
+	}
+
+	if p.contentType != nil {
+		_ = p.contentType.Destroy()
+		p.contentType = nil
+	}
+
+	if p.contentTypeManager != nil {
+		_ = p.contentTypeManager.Destroy()
+		p.contentTypeManager = nil
+	}
+
 	if p.toplevel != nil {
 		_ = p.toplevel.Destroy()
 		p.toplevel = nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -24,9 +25,21 @@ const (
 	swShowNormal       = 1
 	pmRemove           = 0x0001
 	wsOverlappedWindow = 0x00CF0000
-	wsVisible          = 0x10000000
 	cwUseDefault       = 0x80000000
 	vkEscape           = 0x1B
+	wmSysCommand       = 0x0112
+	wmNCLButtonDown    = 0x00A1
+	htCaption          = 2
+	htLeft             = 10
+	htRight            = 11
+	htTop              = 12
+	htTopLeft          = 13
+	htTopRight         = 14
+	htBottom           = 15
+	htBottomLeft       = 16
+	htBottomRight      = 17
+	tpmRightButton     = 0x0002
+	tpmReturnCmd       = 0x0100
 )
 
 var (
@@ -45,6 +58,12 @@ var (
 	procGetModuleHandleW = kernel32.NewProc("GetModuleHandleW")
 	procDestroyWindow    = user32.NewProc("DestroyWindow")
 	procGetClientRect    = user32.NewProc("GetClientRect")
+	procReleaseCapture   = user32.NewProc("ReleaseCapture")
+	procSendMessageW     = user32.NewProc("SendMessageW")
+	procPostMessageW     = user32.NewProc("PostMessageW")
+	procGetSystemMenu    = user32.NewProc("GetSystemMenu")
+	procTrackPopupMenu   = user32.NewProc("TrackPopupMenu")
+	procClientToScreen   = user32.NewProc("ClientToScreen")
 )
 
 // WNDCLASSEXW is the Win32 WNDCLASSEXW structure.
@@ -87,6 +106,67 @@ type windowsPlatform struct {
 	shouldClose bool
 	events      []Event
 	eventMu     sync.Mutex
+
+	// Raw mouse movement accumulated for MouseDelta, guarded by eventMu,
+	// and the last absolute raw position for devices that report one
+	mouseDX, mouseDY           float64
+	rawAbsoluteX, rawAbsoluteY float64
+	rawAbsoluteValid           bool
+
+	// DPI of the window's monitor, guarded by eventMu
+	dpi uint32
+
+	// Mouse capture state, used on the window's thread
+	active        bool // Window is the active window
+	mouseCaptured bool // Capture requested with SetMouseCaptured
+	cursorHides   int  // ShowCursor(FALSE) calls to undo
+
+	// Cursor of the main window, used on the window's thread
+	cursor win32Cursor
+
+	// Window lifecycle state, used on the window's thread
+	sizeType      uintptr // Last WM_SIZE request type
+	sessionLocked bool
+	displayOff    bool
+	occluded      bool
+	displayNotify uintptr // HPOWERNOTIFY for the display state
+
+	// System dark mode for apps, guarded by eventMu
+	dark bool
+
+	// Text input state, used on the window's thread. The text input
+	// area is guarded by eventMu.
+	highSurrogate    uint16 // Pending WM_CHAR high surrogate
+	composing        bool   // A non-empty IME composition was reported
+	textInputRect    rect
+	textInputRectSet bool
+
+	// Borderless fullscreen state and the windowed style and placement to
+	// restore, guarded by eventMu
+	fullscreen     bool
+	savedStyle     uintptr
+	savedPlacement windowPlacement
+
+	// XInput slots, polled on the window's thread. Connection and state
+	// are guarded by eventMu.
+	gamepads       [xinputUserCount]gamepadSlot
+	gamepadsPolled time.Time
+
+	// Window icon and taskbar button progress
+	icon          uintptr // HICON set with WM_SETICON
+	taskbar       *taskbarList3
+	taskbarReady  bool // Taskbar button exists
+	progressState ProgressState
+	progress      float64
+
+	// OLE drop target reporting drags over the main window, or nil where
+	// only WM_DROPFILES is used
+	dropTarget *dropTarget
+
+	// Additional windows opened with CreateWindow, used on the window's
+	// thread
+	windows      map[windows.HWND]*win32Window
+	nextWindowID WindowID
 }
 
 // Global instance for window procedure callback
@@ -105,7 +185,7 @@ func (p *windowsPlatform) Init(config Config) error {
 	p.hinstance = windows.Handle(ret)
 
 	// Register window class
-	className, err := windows.UTF16PtrFromString("GoGPUWindow")
+	className, err := windows.UTF16PtrFromString(windowClassName)
 	if err != nil {
 		return fmt.Errorf("utf16 class name: %w", err)
 	}
@@ -133,7 +213,11 @@ func (p *windowsPlatform) Init(config Config) error {
 		return fmt.Errorf("utf16 title: %w", err)
 	}
 
-	style := uintptr(wsOverlappedWindow | wsVisible)
+	// Must precede window creation
+	enableDPIAwareness()
+
+	// Created hidden, so it can be sized for its monitor's DPI first
+	style := uintptr(wsOverlappedWindow)
 
 	hwnd, _, _ := procCreateWindowExW.Call(
 		0,
@@ -153,8 +237,19 @@ func (p *windowsPlatform) Init(config Config) error {
 	}
 
 	p.hwnd = windows.HWND(hwnd)
-	p.width = config.Width
-	p.height = config.Height
+
+	// Config sizes are client sizes at 96 DPI, like points on macOS
+	p.dpi = windowDPI(p.hwnd)
+	setClientSize(p.hwnd, scaleToDPI(config.Width, p.dpi), scaleToDPI(config.Height, p.dpi), style, p.dpi)
+
+	// Title bar matching the system theme from the first frame
+	p.dark = systemDarkMode()
+	p.applyTheme(p.dark)
+
+	// Entered while hidden, so the windowed placement is restored on exit
+	if config.Fullscreen {
+		_ = p.SetFullscreen(true)
+	}
 
 	// Show window
 	procShowWindow.Call(uintptr(p.hwnd), swShowNormal)
@@ -163,6 +258,15 @@ func (p *windowsPlatform) Init(config Config) error {
 	// Get actual client size
 	p.updateSize()
 
+	// Non-fatal: without raw input MouseDelta stays zero
+	_ = p.registerRawMouse()
+
+	p.acceptFileDrops()
+	p.registerDropTarget()
+	p.registerTouch()
+	p.registerTaskbarCreated()
+	p.registerLifecycleNotifications()
+
 	return nil
 }
 
@@ -189,6 +293,8 @@ func (p *windowsPlatform) PollEvents() Event {
 		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
 	}
 
+	p.pollGamepads()
+
 	// Return queued event if any
 	p.eventMu.Lock()
 	defer p.eventMu.Unlock()
@@ -214,11 +320,88 @@ func (p *windowsPlatform) GetHandle() (instance, window uintptr) {
 	return uintptr(p.hinstance), uintptr(p.hwnd)
 }
 
+// BeginMove hands the current left-button drag to the system as a caption drag.
+func (p *windowsPlatform) BeginMove() error {
+	return p.beginNCDrag(htCaption)
+}
+
+// BeginResize hands the current left-button drag to the system as a border drag.
+func (p *windowsPlatform) BeginResize(edge ResizeEdge) error {
+	var hit uintptr
+	switch edge {
+	case ResizeEdgeTop:
+		hit = htTop
+	case ResizeEdgeBottom:
+		hit = htBottom
+	case ResizeEdgeLeft:
+		hit = htLeft
+	case ResizeEdgeRight:
+		hit = htRight
+	case ResizeEdgeTopLeft:
+		hit = htTopLeft
+	case ResizeEdgeTopRight:
+		hit = htTopRight
+	case ResizeEdgeBottomLeft:
+		hit = htBottomLeft
+	case ResizeEdgeBottomRight:
+		hit = htBottomRight
+	default:
+		return fmt.Errorf("platform: invalid resize edge %d", edge)
+	}
+	return p.beginNCDrag(hit)
+}
+
+// beginNCDrag releases mouse capture and simulates a non-client button press,
+// letting DefWindowProc run its modal move/size loop.
+func (p *windowsPlatform) beginNCDrag(hit uintptr) error {
+	if p.hwnd == 0 {
+		return fmt.Errorf("platform: window not created")
+	}
+	procReleaseCapture.Call()
+	procSendMessageW.Call(uintptr(p.hwnd), wmNCLButtonDown, hit, 0)
+	return nil
+}
+
+// ShowWindowMenu shows the system menu and executes the chosen command.
+func (p *windowsPlatform) ShowWindowMenu(x, y int) error {
+	if p.hwnd == 0 {
+		return fmt.Errorf("platform: window not created")
+	}
+
+	menu, _, _ := procGetSystemMenu.Call(uintptr(p.hwnd), 0)
+	if menu == 0 {
+		return ErrUnsupported
+	}
+
+	pt := struct{ x, y int32 }{int32(x), int32(y)}
+	procClientToScreen.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&pt)))
+
+	cmd, _, _ := procTrackPopupMenu.Call(menu, tpmReturnCmd|tpmRightButton,
+		uintptr(pt.x), uintptr(pt.y), 0, uintptr(p.hwnd), 0)
+	if cmd != 0 {
+		procPostMessageW.Call(uintptr(p.hwnd), wmSysCommand, cmd, 0)
+	}
+	return nil
+}
+
 func (p *windowsPlatform) Destroy() {
+	// The cursor clip is system-wide and outlives the window
+	if p.mouseCaptured {
+		p.applyMouseCapture(false)
+	}
+	p.releaseTaskbar()
+	p.destroyWindows()
 	if p.hwnd != 0 {
+		p.revokeDropTarget()
+		p.unregisterLifecycleNotifications()
 		procDestroyWindow.Call(uintptr(p.hwnd))
 		p.hwnd = 0
 	}
+	if p.icon != 0 {
+		procDestroyIcon.Call(p.icon)
+		p.icon = 0
+	}
+	p.cursor.release()
 	globalPlatform = nil
 }
 
@@ -236,6 +419,17 @@ func wndProc(hwnd windows.HWND, message uint32, wParam, lParam uintptr) uintptr
 		return ret
 	}
 
+	// The main window's handle is set once CreateWindowExW returns
+	if p.hwnd != 0 && hwnd != p.hwnd {
+		return p.windowProc(hwnd, message, wParam, lParam)
+	}
+
+	// Registered message, so not a case constant
+	if wmTaskbarCreated != 0 && message == wmTaskbarCreated {
+		p.handleTaskbarCreated()
+		return 0
+	}
+
 	switch message {
 	case wmClose:
 		p.shouldClose = true
@@ -247,6 +441,8 @@ func wndProc(hwnd windows.HWND, message uint32, wParam, lParam uintptr) uintptr
 		return 0
 
 	case wmSize:
+		p.handleMove()
+		p.handleSizeState(wParam)
 		newWidth := int(lParam & 0xFFFF)
 		newHeight := int((lParam >> 16) & 0xFFFF)
 		if newWidth > 0 && newHeight > 0 && (newWidth != p.width || newHeight != p.height) {
@@ -260,13 +456,97 @@ func wndProc(hwnd windows.HWND, message uint32, wParam, lParam uintptr) uintptr
 		}
 		return 0
 
+	case wmSetCursor:
+		if p.cursor.handleSetCursor(lParam) {
+			return 1
+		}
+
+	case wmActivate:
+		p.handleActivate(wParam)
+
+	case wmMove:
+		p.handleMove()
+
+	case wmWtsSessionChange:
+		p.handleSessionChange(wParam)
+
+	case wmPowerBroadcast:
+		p.handlePowerBroadcast(wParam, lParam)
+
+	case wmDpiChanged:
+		p.handleDPIChanged(wParam, lParam)
+		return 0
+
+	case wmDisplayChange:
+		p.handleDisplayChange()
+
+	case wmSettingChange:
+		// The taskbar moved or changed size
+		if wParam == spiSetWorkArea {
+			p.queueEvent(Event{Type: EventMonitors})
+		}
+		p.handleSettingChange(lParam)
+
+	case wmPointerDown, wmPointerUp, wmPointerUpdate,
+		wmPointerEnter, wmPointerLeave, wmPointerCaptureChanged:
+		p.handlePointer(message, wParam)
+
+	case wmTouch:
+		p.handleTouchInput(wParam, lParam)
+		return 0
+
+	case wmDropFiles:
+		p.handleDropFiles(wParam)
+		return 0
+
+	case wmInput:
+		p.handleRawInput(lParam)
+		// DefWindowProc performs the raw input cleanup
+
+	case wmChar:
+		p.handleChar(wParam)
+		return 0
+
+	case wmImeSetContext:
+		// The application draws the composition string itself; the IME
+		// still shows its candidate window
+		lParam &^= iscShowUICompositionWindow
+
+	case wmImeStartComposition:
+		p.handleIMEStartComposition()
+		return 0
+
+	case wmImeComposition:
+		// Not passed on, so DefWindowProc does not also send the result
+		// as WM_IME_CHAR
+		p.handleIMEComposition(lParam)
+		return 0
+
+	case wmImeEndComposition:
+		p.handleIMEEndComposition()
+		return 0
+
 	case wmKeydown:
+		p.handleKey(MainWindow, message, wParam, lParam)
 		// ESC to close (convenience)
 		if wParam == vkEscape {
 			p.shouldClose = true
 			p.queueEvent(Event{Type: EventClose})
 		}
 		return 0
+
+	case wmKeyup:
+		p.handleKey(MainWindow, message, wParam, lParam)
+		return 0
+
+	case wmSysKeydown, wmSysKeyup:
+		// Passed on for Alt+F4 and the window menu
+		p.handleKey(MainWindow, message, wParam, lParam)
+
+	case wmMouseMove, wmLButtonDown, wmLButtonUp, wmRButtonDown, wmRButtonUp,
+		wmMButtonDown, wmMButtonUp, wmXButtonDown, wmXButtonUp,
+		wmMouseWheel, wmMouseHWheel:
+		p.handleMouse(uintptr(hwnd), MainWindow, message, wParam, lParam)
 	}
 
 	ret, _, _ := procDefWindowProcW.Call(uintptr(hwnd), uintptr(message), wParam, lParam)
@@ -0,0 +1,270 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+)
+
+// Pointer and touch message constants
+const (
+	wmTouch                 = 0x0240
+	wmPointerUpdate         = 0x0245
+	wmPointerDown           = 0x0246
+	wmPointerUp             = 0x0247
+	wmPointerEnter          = 0x0249
+	wmPointerLeave          = 0x024A
+	wmPointerCaptureChanged = 0x024C
+
+	ptTouch = 2
+	ptPen   = 3
+
+	pointerFlagInContact = 0x00000004
+	pointerFlagCanceled  = 0x00008000
+
+	penFlagBarrel   = 0x00000001
+	penFlagInverted = 0x00000002
+	penFlagEraser   = 0x00000004
+
+	penMaskPressure = 0x00000001
+	penMaskRotation = 0x00000002
+	penMaskTiltX    = 0x00000004
+	penMaskTiltY    = 0x00000008
+
+	touchMaskPressure = 0x00000004
+
+	// Pen and touch pressure range
+	maxPointerPressure = 1024
+
+	touchEventFMove = 0x0001
+	touchEventFDown = 0x0002
+	touchEventFUp   = 0x0004
+)
+
+var (
+	procGetPointerType        = user32.NewProc("GetPointerType")
+	procGetPointerPenInfo     = user32.NewProc("GetPointerPenInfo")
+	procGetPointerTouchInfo   = user32.NewProc("GetPointerTouchInfo")
+	procRegisterTouchWindow   = user32.NewProc("RegisterTouchWindow")
+	procGetTouchInputInfo     = user32.NewProc("GetTouchInputInfo")
+	procCloseTouchInputHandle = user32.NewProc("CloseTouchInputHandle")
+	procScreenToClient        = user32.NewProc("ScreenToClient")
+)
+
+// pointerInfo is the Win32 POINTER_INFO structure.
+type pointerInfo struct {
+	pointerType           uint32
+	pointerID             uint32
+	frameID               uint32
+	pointerFlags          uint32
+	sourceDevice          uintptr
+	hwndTarget            uintptr
+	ptPixelLocation       point
+	ptHimetricLocation    point
+	ptPixelLocationRaw    point
+	ptHimetricLocationRaw point
+	dwTime                uint32
+	historyCount          uint32
+	inputData             int32
+	dwKeyStates           uint32
+	performanceCount      uint64
+	buttonChangeType      int32
+}
+
+// pointerPenInfo is the Win32 POINTER_PEN_INFO structure.
+type pointerPenInfo struct {
+	pointerInfo pointerInfo
+	penFlags    uint32
+	penMask     uint32
+	pressure    uint32
+	rotation    uint32
+	tiltX       int32
+	tiltY       int32
+}
+
+// pointerTouchInfo is the Win32 POINTER_TOUCH_INFO structure.
+type pointerTouchInfo struct {
+	pointerInfo  pointerInfo
+	touchFlags   uint32
+	touchMask    uint32
+	rcContact    rect
+	rcContactRaw rect
+	orientation  uint32
+	pressure     uint32
+}
+
+// touchInput is the Win32 TOUCHINPUT structure.
+type touchInput struct {
+	x, y        int32 // Hundredths of a pixel, screen coordinates
+	hSource     uintptr
+	dwID        uint32
+	dwFlags     uint32
+	dwMask      uint32
+	dwTime      uint32
+	dwExtraInfo uintptr
+	cxContact   uint32
+	cyContact   uint32
+}
+
+// registerTouch enables WM_TOUCH where pointer messages are unavailable.
+// Windows 8 and later send WM_POINTER messages for touch and pen without
+// registration; Windows 7 only reports touch, as WM_TOUCH.
+func (p *windowsPlatform) registerTouch() {
+	if procGetPointerType.Find() == nil || procRegisterTouchWindow.Find() != nil {
+		return
+	}
+	procRegisterTouchWindow.Call(uintptr(p.hwnd), 0)
+}
+
+// handlePointer queues pen and touch events for a WM_POINTER message.
+// Mouse and touchpad pointers are ignored. The message is still passed to
+// DefWindowProc, so applications reading only mouse input keep working
+// with the mouse messages Windows derives from pen and touch.
+func (p *windowsPlatform) handlePointer(message uint32, wParam uintptr) {
+	id := uint32(wParam & 0xFFFF) // GET_POINTERID_WPARAM
+
+	var pointerType uint32
+	if ret, _, _ := procGetPointerType.Call(uintptr(id), uintptr(unsafe.Pointer(&pointerType))); ret == 0 {
+		return
+	}
+
+	switch pointerType {
+	case ptPen:
+		var info pointerPenInfo
+		if ret, _, _ := procGetPointerPenInfo.Call(uintptr(id), uintptr(unsafe.Pointer(&info))); ret == 0 {
+			return
+		}
+		p.handlePen(message, &info)
+
+	case ptTouch:
+		var info pointerTouchInfo
+		if ret, _, _ := procGetPointerTouchInfo.Call(uintptr(id), uintptr(unsafe.Pointer(&info))); ret == 0 {
+			return
+		}
+		p.handleTouch(message, &info)
+	}
+}
+
+// handlePen queues a PenEvent for a pen pointer message.
+func (p *windowsPlatform) handlePen(message uint32, info *pointerPenInfo) {
+	event := PenEvent{
+		Tool:    PenToolPen,
+		Contact: info.pointerInfo.pointerFlags&pointerFlagInContact != 0,
+	}
+
+	switch message {
+	case wmPointerEnter:
+		event.Phase = PenPhaseProximityIn
+	case wmPointerLeave:
+		event.Phase = PenPhaseProximityOut
+	case wmPointerDown:
+		event.Phase = PenPhaseDown
+	case wmPointerUp:
+		event.Phase = PenPhaseUp
+	default:
+		event.Phase = PenPhaseMove
+	}
+
+	// Inverted: the eraser end is toward the screen
+	if info.penFlags&(penFlagEraser|penFlagInverted) != 0 {
+		event.Tool = PenToolEraser
+	}
+	if info.penFlags&penFlagBarrel != 0 {
+		event.Buttons |= PenButtonPrimary
+	}
+
+	if event.Contact {
+		event.Pressure = 1
+		if info.penMask&penMaskPressure != 0 {
+			event.Pressure = float64(info.pressure) / maxPointerPressure
+		}
+	}
+	if info.penMask&penMaskRotation != 0 {
+		event.Rotation = float64(info.rotation)
+	}
+	if info.penMask&penMaskTiltX != 0 {
+		event.TiltX = float64(info.tiltX)
+	}
+	if info.penMask&penMaskTiltY != 0 {
+		event.TiltY = float64(info.tiltY)
+	}
+
+	event.X, event.Y = p.screenToClient(info.pointerInfo.ptPixelLocation)
+	p.queueEvent(Event{Type: EventPen, Pen: event})
+}
+
+// handleTouch queues a TouchEvent for a touch pointer message.
+func (p *windowsPlatform) handleTouch(message uint32, info *pointerTouchInfo) {
+	event := TouchEvent{
+		ID:       info.pointerInfo.pointerID,
+		Pressure: 1,
+	}
+
+	switch message {
+	case wmPointerDown:
+		event.Phase = TouchPhaseBegin
+	case wmPointerUpdate:
+		event.Phase = TouchPhaseMove
+	case wmPointerUp:
+		event.Phase = TouchPhaseEnd
+		event.Pressure = 0
+	case wmPointerCaptureChanged:
+		event.Phase = TouchPhaseCancel
+		event.Pressure = 0
+	default:
+		return
+	}
+	if info.pointerInfo.pointerFlags&pointerFlagCanceled != 0 {
+		event.Phase = TouchPhaseCancel
+		event.Pressure = 0
+	}
+	if event.Pressure > 0 && info.touchMask&touchMaskPressure != 0 {
+		event.Pressure = float64(info.pressure) / maxPointerPressure
+	}
+
+	event.X, event.Y = p.screenToClient(info.pointerInfo.ptPixelLocation)
+	p.queueEvent(Event{Type: EventTouch, Touch: event})
+}
+
+// handleTouchInput queues TouchEvents for a Windows 7 WM_TOUCH message,
+// which carries all contacts that changed, and closes its handle.
+func (p *windowsPlatform) handleTouchInput(wParam, lParam uintptr) {
+	defer procCloseTouchInputHandle.Call(lParam)
+
+	count := int(wParam & 0xFFFF)
+	if count == 0 {
+		return
+	}
+	inputs := make([]touchInput, count)
+	ret, _, _ := procGetTouchInputInfo.Call(lParam, uintptr(count),
+		uintptr(unsafe.Pointer(&inputs[0])), unsafe.Sizeof(inputs[0]))
+	if ret == 0 {
+		return
+	}
+
+	for _, in := range inputs {
+		event := TouchEvent{ID: in.dwID, Pressure: 1}
+		switch {
+		case in.dwFlags&touchEventFDown != 0:
+			event.Phase = TouchPhaseBegin
+		case in.dwFlags&touchEventFUp != 0:
+			event.Phase = TouchPhaseEnd
+			event.Pressure = 0
+		case in.dwFlags&touchEventFMove != 0:
+			event.Phase = TouchPhaseMove
+		default:
+			continue
+		}
+
+		// Round hundredths of a pixel to the containing pixel
+		event.X, event.Y = p.screenToClient(point{x: in.x / 100, y: in.y / 100})
+		p.queueEvent(Event{Type: EventTouch, Touch: event})
+	}
+}
+
+// screenToClient converts a screen position in pixels to the window's
+// client area.
+func (p *windowsPlatform) screenToClient(pt point) (x, y float64) {
+	procScreenToClient.Call(uintptr(p.hwnd), uintptr(unsafe.Pointer(&pt)))
+	return float64(pt.x), float64(pt.y)
+}
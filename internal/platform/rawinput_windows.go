@@ -0,0 +1,148 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+)
+
+// Raw input constants
+const (
+	wmInput = 0x00FF
+
+	ridInput     = 0x10000003
+	rimTypeMouse = 0
+
+	hidUsagePageGeneric = 0x01
+	hidUsageMouse       = 0x02
+
+	mouseMoveAbsolute   = 0x01
+	mouseVirtualDesktop = 0x02
+
+	smCxScreen        = 0
+	smCyScreen        = 1
+	smCxVirtualScreen = 78
+	smCyVirtualScreen = 79
+)
+
+var (
+	procRegisterRawInputDevices = user32.NewProc("RegisterRawInputDevices")
+	procGetRawInputData         = user32.NewProc("GetRawInputData")
+	procGetSystemMetrics        = user32.NewProc("GetSystemMetrics")
+)
+
+// rawInputDevice is the Win32 RAWINPUTDEVICE structure.
+type rawInputDevice struct {
+	usUsagePage uint16
+	usUsage     uint16
+	dwFlags     uint32
+	hwndTarget  uintptr
+}
+
+// rawInputHeader is the Win32 RAWINPUTHEADER structure.
+type rawInputHeader struct {
+	dwType  uint32
+	dwSize  uint32
+	hDevice uintptr
+	wParam  uintptr
+}
+
+// rawMouse is the Win32 RAWMOUSE structure.
+type rawMouse struct {
+	usFlags            uint16
+	_                  uint16
+	usButtonFlags      uint16
+	usButtonData       uint16
+	ulRawButtons       uint32
+	lLastX             int32
+	lLastY             int32
+	ulExtraInformation uint32
+}
+
+// rawInputMouse is the Win32 RAWINPUT structure for mouse input.
+type rawInputMouse struct {
+	header rawInputHeader
+	mouse  rawMouse
+}
+
+// registerRawMouse asks for WM_INPUT mouse messages while the window is in
+// the foreground. Raw input reports the device's own counts, without the
+// pointer acceleration ("Enhance pointer precision") applied to the cursor.
+func (p *windowsPlatform) registerRawMouse() error {
+	device := rawInputDevice{
+		usUsagePage: hidUsagePageGeneric,
+		usUsage:     hidUsageMouse,
+		hwndTarget:  uintptr(p.hwnd),
+	}
+	ret, _, err := procRegisterRawInputDevices.Call(
+		uintptr(unsafe.Pointer(&device)),
+		1,
+		unsafe.Sizeof(device),
+	)
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+// handleRawInput adds the movement of a WM_INPUT mouse message to the
+// accumulated delta.
+func (p *windowsPlatform) handleRawInput(lParam uintptr) {
+	var input rawInputMouse
+	size := uint32(unsafe.Sizeof(input))
+	ret, _, _ := procGetRawInputData.Call(
+		lParam,
+		ridInput,
+		uintptr(unsafe.Pointer(&input)),
+		uintptr(unsafe.Pointer(&size)),
+		unsafe.Sizeof(input.header),
+	)
+	if int32(ret) <= 0 || input.header.dwType != rimTypeMouse {
+		return
+	}
+
+	m := &input.mouse
+	var dx, dy float64
+	if m.usFlags&mouseMoveAbsolute != 0 {
+		// Remote desktop sessions, virtual machines and pen tablets report
+		// absolute positions normalized to 0..65535 across the screen
+		width, height := screenMetrics(smCxScreen, smCyScreen)
+		if m.usFlags&mouseVirtualDesktop != 0 {
+			width, height = screenMetrics(smCxVirtualScreen, smCyVirtualScreen)
+		}
+		x := float64(m.lLastX) / 65535 * width
+		y := float64(m.lLastY) / 65535 * height
+
+		if p.rawAbsoluteValid {
+			dx, dy = x-p.rawAbsoluteX, y-p.rawAbsoluteY
+		}
+		p.rawAbsoluteX, p.rawAbsoluteY = x, y
+		p.rawAbsoluteValid = true
+	} else {
+		dx, dy = float64(m.lLastX), float64(m.lLastY)
+	}
+
+	p.eventMu.Lock()
+	p.mouseDX += dx
+	p.mouseDY += dy
+	p.eventMu.Unlock()
+}
+
+// screenMetrics returns two GetSystemMetrics values.
+func screenMetrics(xIndex, yIndex uintptr) (x, y float64) {
+	cx, _, _ := procGetSystemMetrics.Call(xIndex)
+	cy, _, _ := procGetSystemMetrics.Call(yIndex)
+	return float64(int32(cx)), float64(int32(cy))
+}
+
+// MouseDelta returns the raw mouse movement accumulated since the previous
+// call, y down, and resets it. Raw deltas are in mouse counts, which match
+// pixels for cursor movement without acceleration.
+func (p *windowsPlatform) MouseDelta() (dx, dy float64) {
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+
+	dx, dy = p.mouseDX, p.mouseDY
+	p.mouseDX, p.mouseDY = 0, 0
+	return dx, dy
+}
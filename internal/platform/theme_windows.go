@@ -0,0 +1,89 @@
+//go:build windows
+
+package platform
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Theme constants
+const (
+	// DWMWA_USE_IMMERSIVE_DARK_MODE; 19 before Windows 10 20H1
+	dwmwaUseImmersiveDarkMode       = 20
+	dwmwaUseImmersiveDarkModeBefore = 19
+
+	personalizeKey = `Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`
+)
+
+var (
+	dwmapi = windows.NewLazyDLL("dwmapi.dll")
+
+	procDwmSetWindowAttribute = dwmapi.NewProc("DwmSetWindowAttribute")
+)
+
+// systemDarkMode reports whether the user chose the dark mode for apps.
+// Windows before 10 1809 have no such setting and are light.
+func systemDarkMode() bool {
+	key, err := registry.OpenKey(registry.CURRENT_USER, personalizeKey, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+
+	light, _, err := key.GetIntegerValue("AppsUseLightTheme")
+	return err == nil && light == 0
+}
+
+// applyTheme switches the title bar between the light and dark frame.
+// The frame of a window not yet shown is updated when it is drawn; for a
+// visible window DWM updates it on its next activation change at the
+// latest.
+func (p *windowsPlatform) applyTheme(dark bool) {
+	if procDwmSetWindowAttribute.Find() != nil {
+		return
+	}
+
+	var value int32 // BOOL
+	if dark {
+		value = 1
+	}
+	ret, _, _ := procDwmSetWindowAttribute.Call(uintptr(p.hwnd), dwmwaUseImmersiveDarkMode,
+		uintptr(unsafe.Pointer(&value)), unsafe.Sizeof(value))
+	if ret != 0 { // not S_OK
+		procDwmSetWindowAttribute.Call(uintptr(p.hwnd), dwmwaUseImmersiveDarkModeBefore,
+			uintptr(unsafe.Pointer(&value)), unsafe.Sizeof(value))
+	}
+}
+
+// handleSettingChange follows switches between the light and dark app
+// mode, which are broadcast as WM_SETTINGCHANGE for "ImmersiveColorSet".
+func (p *windowsPlatform) handleSettingChange(lParam uintptr) {
+	if lParam == 0 {
+		return
+	}
+	area := windows.UTF16PtrToString(*(**uint16)(unsafe.Pointer(&lParam)))
+	if area != "ImmersiveColorSet" {
+		return
+	}
+
+	dark := systemDarkMode()
+	p.eventMu.Lock()
+	changed := dark != p.dark
+	p.dark = dark
+	p.eventMu.Unlock()
+
+	if changed {
+		p.applyTheme(dark)
+		p.queueEvent(Event{Type: EventTheme, Dark: dark})
+	}
+}
+
+// DarkMode reports whether the user chose the dark mode for apps.
+func (p *windowsPlatform) DarkMode() bool {
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+	return p.dark
+}
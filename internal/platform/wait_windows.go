@@ -0,0 +1,39 @@
+//go:build windows
+
+package platform
+
+import (
+	"time"
+)
+
+// Message wait constants
+const (
+	wmNull = 0x0000
+
+	qsAllInput         = 0x04FF
+	mwmoInputAvailable = 0x0004
+)
+
+var procMsgWaitForMultipleObjectsEx = user32.NewProc("MsgWaitForMultipleObjectsEx")
+
+// WaitEvents sleeps until a message arrives in the thread's queue or the
+// timeout expires. MWMO_INPUTAVAILABLE also returns for messages that
+// arrived before the call but were not removed yet. Gamepads are not
+// messages; they are sampled when PollEvents runs after the wait.
+func (p *windowsPlatform) WaitEvents(timeout time.Duration) {
+	p.eventMu.Lock()
+	queued := len(p.events) > 0
+	p.eventMu.Unlock()
+	if queued {
+		return
+	}
+
+	procMsgWaitForMultipleObjectsEx.Call(0, 0, uintptr(max(timeout.Milliseconds(), 0)),
+		qsAllInput, mwmoInputAvailable)
+}
+
+// WakeEvents posts an empty message, which ends a WaitEvents in progress
+// or makes the next one return at once. Safe from any goroutine.
+func (p *windowsPlatform) WakeEvents() {
+	procPostMessageW.Call(uintptr(p.hwnd), wmNull, 0, 0)
+}
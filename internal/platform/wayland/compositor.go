@@ -90,10 +90,12 @@ type WlSurface struct {
 
 // NewWlSurface creates a WlSurface from an object ID.
 func NewWlSurface(display *Display, objectID ObjectID) *WlSurface {
-	return &WlSurface{
+	s := &WlSurface{
 		display: display,
 		id:      objectID,
 	}
+	display.registerObject(objectID, s)
+	return s
 }
 
 // ID returns the object ID of the surface.
@@ -303,11 +305,13 @@ type WlCallback struct {
 
 // NewWlCallback creates a WlCallback from an object ID.
 func NewWlCallback(display *Display, objectID ObjectID) *WlCallback {
-	return &WlCallback{
+	c := &WlCallback{
 		display: display,
 		id:      objectID,
 		done:    make(chan uint32, 1),
 	}
+	display.registerObject(objectID, c)
+	return c
 }
 
 // ID returns the object ID of the callback.
@@ -558,3 +558,36 @@ func TestSetBufferTransformMessage(t *testing.T) {
 		t.Errorf("transform = %d, want %d", gotTransform, transform)
 	}
 }
+
+// TestDisplayDispatchRegisteredObject verifies events are routed to registered objects.
+func TestDisplayDispatchRegisteredObject(t *testing.T) {
+	display := &Display{
+		callbacks: make(map[ObjectID]chan uint32),
+		objects:   make(map[ObjectID]eventDispatcher),
+	}
+	surface := NewWlSurface(display, ObjectID(12))
+
+	var gotOutputID ObjectID
+	surface.SetEnterHandler(func(outputID ObjectID) {
+		gotOutputID = outputID
+	})
+
+	builder := NewMessageBuilder()
+	builder.PutObject(ObjectID(60))
+	if err := display.dispatch(builder.BuildMessage(surface.ID(), surfaceEventEnter)); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if gotOutputID != ObjectID(60) {
+		t.Errorf("enter output ID = %d, want 60", gotOutputID)
+	}
+
+	// delete_id must stop routing to the object
+	builder = NewMessageBuilder()
+	builder.PutUint32(uint32(surface.ID()))
+	if err := display.dispatch(builder.BuildMessage(1, displayEventDeleteID)); err != nil {
+		t.Fatalf("dispatch delete_id failed: %v", err)
+	}
+	if _, ok := display.objects[surface.ID()]; ok {
+		t.Error("object still registered after delete_id")
+	}
+}
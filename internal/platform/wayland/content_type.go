@@ -0,0 +1,137 @@
+//go:build linux
+
+package wayland
+
+// wp_content_type_manager_v1 opcodes (requests)
+const (
+	contentTypeManagerDestroy               Opcode = 0 // destroy()
+	contentTypeManagerGetSurfaceContentType Opcode = 1 // get_surface_content_type(id: new_id<wp_content_type_v1>, surface: object<wl_surface>)
+)
+
+// wp_content_type_v1 opcodes (requests)
+const (
+	contentTypeDestroy        Opcode = 0 // destroy()
+	contentTypeSetContentType Opcode = 1 // set_content_type(content_type: uint)
+)
+
+// ContentType describes the kind of content shown on a surface.
+// These match the wp_content_type_v1.type enum from content-type-v1.xml.
+type ContentType uint32
+
+// Content type values.
+const (
+	// ContentTypeNone means no content type is known (the default).
+	ContentTypeNone ContentType = 0
+
+	// ContentTypePhoto is still pictures; compositors may favor color accuracy.
+	ContentTypePhoto ContentType = 1
+
+	// ContentTypeVideo is video or animation; compositors may favor smooth playback.
+	ContentTypeVideo ContentType = 2
+
+	// ContentTypeGame is interactive content; compositors may favor low latency.
+	ContentTypeGame ContentType = 3
+)
+
+// String returns the protocol name of the content type.
+func (t ContentType) String() string {
+	switch t {
+	case ContentTypeNone:
+		return "none"
+	case ContentTypePhoto:
+		return "photo"
+	case ContentTypeVideo:
+		return "video"
+	case ContentTypeGame:
+		return "game"
+	default:
+		return "unknown"
+	}
+}
+
+// WpContentTypeManagerV1 represents the wp_content_type_manager_v1 interface.
+// It creates content type objects that describe what a surface is showing,
+// allowing the compositor to choose latency- or quality-optimized scheduling.
+type WpContentTypeManagerV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewWpContentTypeManagerV1 creates a WpContentTypeManagerV1 from a bound object ID.
+// The objectID should be obtained from Registry.BindContentTypeManager().
+func NewWpContentTypeManagerV1(display *Display, objectID ObjectID) *WpContentTypeManagerV1 {
+	return &WpContentTypeManagerV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the content type manager.
+func (m *WpContentTypeManagerV1) ID() ObjectID {
+	return m.id
+}
+
+// GetSurfaceContentType creates a content type object for the given surface.
+// Only one content type object may exist per surface at a time.
+func (m *WpContentTypeManagerV1) GetSurfaceContentType(surface *WlSurface) (*WpContentTypeV1, error) {
+	contentTypeID := m.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(contentTypeID)
+	builder.PutObject(surface.ID())
+	msg := builder.BuildMessage(m.id, contentTypeManagerGetSurfaceContentType)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewWpContentTypeV1(m.display, contentTypeID), nil
+}
+
+// Destroy destroys the content type manager.
+// Existing content type objects are not affected.
+func (m *WpContentTypeManagerV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(m.id, contentTypeManagerDestroy)
+
+	return m.display.SendMessage(msg)
+}
+
+// WpContentTypeV1 represents the wp_content_type_v1 interface.
+// It carries the content type hint for a single wl_surface.
+type WpContentTypeV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewWpContentTypeV1 creates a WpContentTypeV1 from an object ID.
+func NewWpContentTypeV1(display *Display, objectID ObjectID) *WpContentTypeV1 {
+	return &WpContentTypeV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the content type object.
+func (c *WpContentTypeV1) ID() ObjectID {
+	return c.id
+}
+
+// SetContentType sets the content type of the surface.
+// The new value is double-buffered and applied on the next wl_surface.commit.
+func (c *WpContentTypeV1) SetContentType(contentType ContentType) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(uint32(contentType))
+	msg := builder.BuildMessage(c.id, contentTypeSetContentType)
+
+	return c.display.SendMessage(msg)
+}
+
+// Destroy destroys the content type object.
+// The surface content type is reset to none on the next commit.
+func (c *WpContentTypeV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(c.id, contentTypeDestroy)
+
+	return c.display.SendMessage(msg)
+}
@@ -0,0 +1,118 @@
+//go:build linux
+
+package wayland
+
+import (
+	"testing"
+)
+
+// TestContentTypeOpcodes verifies content-type-v1 opcode constants match the protocol spec.
+func TestContentTypeOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"manager.destroy", contentTypeManagerDestroy, 0},
+		{"manager.get_surface_content_type", contentTypeManagerGetSurfaceContentType, 1},
+		{"destroy", contentTypeDestroy, 0},
+		{"set_content_type", contentTypeSetContentType, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestContentTypeConstants verifies content type enum values and names.
+func TestContentTypeConstants(t *testing.T) {
+	tests := []struct {
+		contentType ContentType
+		value       uint32
+		name        string
+	}{
+		{ContentTypeNone, 0, "none"},
+		{ContentTypePhoto, 1, "photo"},
+		{ContentTypeVideo, 2, "video"},
+		{ContentTypeGame, 3, "game"},
+		{ContentType(42), 42, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if uint32(tt.contentType) != tt.value {
+				t.Errorf("ContentType %s = %d, want %d", tt.name, tt.contentType, tt.value)
+			}
+			if got := tt.contentType.String(); got != tt.name {
+				t.Errorf("ContentType(%d).String() = %q, want %q", tt.value, got, tt.name)
+			}
+		})
+	}
+}
+
+// TestContentTypeCreation verifies content type object initialization.
+func TestContentTypeCreation(t *testing.T) {
+	manager := NewWpContentTypeManagerV1(nil, ObjectID(20))
+	if manager.ID() != ObjectID(20) {
+		t.Errorf("WpContentTypeManagerV1.ID() = %d, want 20", manager.ID())
+	}
+
+	ct := NewWpContentTypeV1(nil, ObjectID(21))
+	if ct.ID() != ObjectID(21) {
+		t.Errorf("WpContentTypeV1.ID() = %d, want 21", ct.ID())
+	}
+}
+
+// TestGetSurfaceContentTypeMessage verifies the message format for get_surface_content_type.
+func TestGetSurfaceContentTypeMessage(t *testing.T) {
+	builder := NewMessageBuilder()
+	builder.PutNewID(ObjectID(30))
+	builder.PutObject(ObjectID(3))
+	msg := builder.BuildMessage(ObjectID(20), contentTypeManagerGetSurfaceContentType)
+
+	if msg.Opcode != contentTypeManagerGetSurfaceContentType {
+		t.Errorf("Opcode = %d, want %d", msg.Opcode, contentTypeManagerGetSurfaceContentType)
+	}
+
+	dec := NewDecoder(msg.Args)
+
+	id, err := dec.NewID()
+	if err != nil {
+		t.Fatalf("failed to decode id: %v", err)
+	}
+	if id != ObjectID(30) {
+		t.Errorf("id = %d, want 30", id)
+	}
+
+	surface, err := dec.Object()
+	if err != nil {
+		t.Fatalf("failed to decode surface: %v", err)
+	}
+	if surface != ObjectID(3) {
+		t.Errorf("surface = %d, want 3", surface)
+	}
+}
+
+// TestSetContentTypeMessage verifies the message format for set_content_type.
+func TestSetContentTypeMessage(t *testing.T) {
+	builder := NewMessageBuilder()
+	builder.PutUint32(uint32(ContentTypeGame))
+	msg := builder.BuildMessage(ObjectID(30), contentTypeSetContentType)
+
+	if msg.Opcode != contentTypeSetContentType {
+		t.Errorf("Opcode = %d, want %d", msg.Opcode, contentTypeSetContentType)
+	}
+
+	dec := NewDecoder(msg.Args)
+	value, err := dec.Uint32()
+	if err != nil {
+		t.Fatalf("failed to decode content_type: %v", err)
+	}
+	if ContentType(value) != ContentTypeGame {
+		t.Errorf("content_type = %d, want %d", value, ContentTypeGame)
+	}
+}
@@ -0,0 +1,123 @@
+//go:build linux
+
+package wayland
+
+// wp_cursor_shape_manager_v1 opcodes (requests)
+const (
+	cursorShapeManagerDestroy    Opcode = 0 // destroy()
+	cursorShapeManagerGetPointer Opcode = 1 // get_pointer(cursor_shape_device: new_id<wp_cursor_shape_device_v1>, pointer: object<wl_pointer>)
+)
+
+// wp_cursor_shape_device_v1 opcodes (requests)
+const (
+	cursorShapeDeviceDestroy  Opcode = 0 // destroy()
+	cursorShapeDeviceSetShape Opcode = 1 // set_shape(serial: uint, shape: uint)
+)
+
+// CursorShape is a cursor from the compositor's cursor theme.
+// These match the wp_cursor_shape_device_v1.shape enum from
+// cursor-shape-v1.xml, whose names follow the CSS cursor property.
+type CursorShape uint32
+
+// Cursor shape values.
+const (
+	CursorShapeDefault    CursorShape = 1
+	CursorShapePointer    CursorShape = 4
+	CursorShapeWait       CursorShape = 6
+	CursorShapeCrosshair  CursorShape = 8
+	CursorShapeText       CursorShape = 9
+	CursorShapeMove       CursorShape = 13
+	CursorShapeNotAllowed CursorShape = 15
+	CursorShapeGrab       CursorShape = 16
+	CursorShapeGrabbing   CursorShape = 17
+	CursorShapeEWResize   CursorShape = 26
+	CursorShapeNSResize   CursorShape = 27
+	CursorShapeNESWResize CursorShape = 28
+	CursorShapeNWSEResize CursorShape = 29
+)
+
+// WpCursorShapeManagerV1 represents the wp_cursor_shape_manager_v1 interface.
+// It lets clients pick cursors from the compositor's theme by name,
+// instead of loading cursor images themselves.
+type WpCursorShapeManagerV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewWpCursorShapeManagerV1 creates a WpCursorShapeManagerV1 from a bound object ID.
+// The objectID should be obtained from Registry.BindCursorShapeManager().
+func NewWpCursorShapeManagerV1(display *Display, objectID ObjectID) *WpCursorShapeManagerV1 {
+	return &WpCursorShapeManagerV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the cursor shape manager.
+func (m *WpCursorShapeManagerV1) ID() ObjectID {
+	return m.id
+}
+
+// GetPointer creates the cursor shape device of a pointer.
+func (m *WpCursorShapeManagerV1) GetPointer(pointer *WlPointer) (*WpCursorShapeDeviceV1, error) {
+	deviceID := m.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(deviceID)
+	builder.PutObject(pointer.ID())
+	msg := builder.BuildMessage(m.id, cursorShapeManagerGetPointer)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewWpCursorShapeDeviceV1(m.display, deviceID), nil
+}
+
+// Destroy destroys the cursor shape manager.
+// Existing cursor shape devices are not affected.
+func (m *WpCursorShapeManagerV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(m.id, cursorShapeManagerDestroy)
+
+	return m.display.SendMessage(msg)
+}
+
+// WpCursorShapeDeviceV1 represents the wp_cursor_shape_device_v1 interface.
+// It sets the cursor of a single wl_pointer.
+type WpCursorShapeDeviceV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewWpCursorShapeDeviceV1 creates a WpCursorShapeDeviceV1 from an object ID.
+func NewWpCursorShapeDeviceV1(display *Display, objectID ObjectID) *WpCursorShapeDeviceV1 {
+	return &WpCursorShapeDeviceV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the cursor shape device.
+func (d *WpCursorShapeDeviceV1) ID() ObjectID {
+	return d.id
+}
+
+// SetShape sets the pointer cursor, like WlPointer.SetCursor. The serial
+// must be that of the latest wl_pointer.enter event.
+func (d *WpCursorShapeDeviceV1) SetShape(serial uint32, shape CursorShape) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(serial)
+	builder.PutUint32(uint32(shape))
+	msg := builder.BuildMessage(d.id, cursorShapeDeviceSetShape)
+
+	return d.display.SendMessage(msg)
+}
+
+// Destroy destroys the cursor shape device.
+func (d *WpCursorShapeDeviceV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(d.id, cursorShapeDeviceDestroy)
+
+	return d.display.SendMessage(msg)
+}
@@ -0,0 +1,60 @@
+//go:build linux
+
+package wayland
+
+import (
+	"testing"
+)
+
+// TestCursorShapeOpcodes verifies cursor-shape-v1 opcode constants match the protocol spec.
+func TestCursorShapeOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"manager.destroy", cursorShapeManagerDestroy, 0},
+		{"manager.get_pointer", cursorShapeManagerGetPointer, 1},
+		{"device.destroy", cursorShapeDeviceDestroy, 0},
+		{"device.set_shape", cursorShapeDeviceSetShape, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCursorShapeConstants verifies cursor shape enum values.
+func TestCursorShapeConstants(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape CursorShape
+		value uint32
+	}{
+		{"default", CursorShapeDefault, 1},
+		{"pointer", CursorShapePointer, 4},
+		{"wait", CursorShapeWait, 6},
+		{"crosshair", CursorShapeCrosshair, 8},
+		{"text", CursorShapeText, 9},
+		{"move", CursorShapeMove, 13},
+		{"not_allowed", CursorShapeNotAllowed, 15},
+		{"grab", CursorShapeGrab, 16},
+		{"grabbing", CursorShapeGrabbing, 17},
+		{"ew_resize", CursorShapeEWResize, 26},
+		{"ns_resize", CursorShapeNSResize, 27},
+		{"nesw_resize", CursorShapeNESWResize, 28},
+		{"nwse_resize", CursorShapeNWSEResize, 29},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if uint32(tt.shape) != tt.value {
+				t.Errorf("CursorShape %s = %d, want %d", tt.name, tt.shape, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,621 @@
+//go:build linux
+
+package wayland
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// wl_data_device_manager opcodes (requests)
+const (
+	dataDeviceManagerCreateDataSource Opcode = 0 // create_data_source(id: new_id<wl_data_source>)
+	dataDeviceManagerGetDataDevice    Opcode = 1 // get_data_device(id: new_id<wl_data_device>, seat: object<wl_seat>)
+)
+
+// wl_data_source opcodes (requests)
+const (
+	dataSourceOffer   Opcode = 0 // offer(mime_type: string)
+	dataSourceDestroy Opcode = 1 // destroy()
+)
+
+// wl_data_source event opcodes
+const (
+	dataSourceEventTarget    Opcode = 0 // target(mime_type: string)
+	dataSourceEventSend      Opcode = 1 // send(mime_type: string, fd: fd)
+	dataSourceEventCancelled Opcode = 2 // cancelled()
+)
+
+// wl_data_device opcodes (requests)
+const (
+	dataDeviceStartDrag    Opcode = 0 // start_drag(source, origin, icon, serial)
+	dataDeviceSetSelection Opcode = 1 // set_selection(source: object<wl_data_source>, serial: uint)
+)
+
+// wl_data_device event opcodes
+const (
+	dataDeviceEventDataOffer Opcode = 0 // data_offer(id: new_id<wl_data_offer>)
+	dataDeviceEventEnter     Opcode = 1 // enter(serial, surface, x, y, id)
+	dataDeviceEventLeave     Opcode = 2 // leave()
+	dataDeviceEventMotion    Opcode = 3 // motion(time, x, y)
+	dataDeviceEventDrop      Opcode = 4 // drop()
+	dataDeviceEventSelection Opcode = 5 // selection(id: object<wl_data_offer>)
+)
+
+// wl_data_offer opcodes (requests)
+const (
+	dataOfferAccept     Opcode = 0 // accept(serial: uint, mime_type: string)
+	dataOfferReceive    Opcode = 1 // receive(mime_type: string, fd: fd)
+	dataOfferDestroy    Opcode = 2 // destroy()
+	dataOfferFinish     Opcode = 3 // finish() (v3)
+	dataOfferSetActions Opcode = 4 // set_actions(dnd_actions: uint, preferred_action: uint) (v3)
+)
+
+// wl_data_offer event opcodes
+const (
+	dataOfferEventOffer Opcode = 0 // offer(mime_type: string)
+)
+
+// Drag-and-drop actions (wl_data_device_manager.dnd_action).
+const (
+	DndActionNone uint32 = 0
+	DndActionCopy uint32 = 1
+	DndActionMove uint32 = 2
+	DndActionAsk  uint32 = 4
+)
+
+// MimeTypeURIList is the MIME type of dragged files, a list of file URIs.
+const MimeTypeURIList = "text/uri-list"
+
+// DataDeviceEnterEvent is sent when a drag enters a surface.
+type DataDeviceEnterEvent struct {
+	Serial  uint32       // Serial for WlDataOffer.Accept
+	Surface ObjectID     // Surface entered
+	X, Y    float64      // Position in surface coordinates
+	Offer   *WlDataOffer // Dragged data, nil if it has none
+}
+
+// WlDataDeviceManager represents the wl_data_device_manager interface,
+// which creates data sources and the data device of a seat for the
+// clipboard and drag and drop.
+type WlDataDeviceManager struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewWlDataDeviceManager creates a WlDataDeviceManager from a bound object ID.
+// The objectID should be obtained from Registry.BindDataDeviceManager().
+func NewWlDataDeviceManager(display *Display, objectID ObjectID) *WlDataDeviceManager {
+	return &WlDataDeviceManager{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the data device manager.
+func (m *WlDataDeviceManager) ID() ObjectID {
+	return m.id
+}
+
+// CreateDataSource creates a data source, offering data to other clients.
+func (m *WlDataDeviceManager) CreateDataSource() (*WlDataSource, error) {
+	sourceID := m.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(sourceID)
+	msg := builder.BuildMessage(m.id, dataDeviceManagerCreateDataSource)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewWlDataSource(m.display, sourceID), nil
+}
+
+// GetDataDevice creates the data device of seat.
+func (m *WlDataDeviceManager) GetDataDevice(seat *WlSeat) (*WlDataDevice, error) {
+	deviceID := m.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(deviceID)
+	builder.PutObject(seat.ID())
+	msg := builder.BuildMessage(m.id, dataDeviceManagerGetDataDevice)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewWlDataDevice(m.display, deviceID), nil
+}
+
+// WlDataSource represents the wl_data_source interface: data this client
+// offers, in the MIME types announced with Offer.
+type WlDataSource struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	onSend      func(mimeType string, fd int)
+	onCancelled func()
+}
+
+// NewWlDataSource creates a WlDataSource from an object ID.
+func NewWlDataSource(display *Display, objectID ObjectID) *WlDataSource {
+	s := &WlDataSource{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, s)
+	return s
+}
+
+// ID returns the object ID of the data source.
+func (s *WlDataSource) ID() ObjectID {
+	return s.id
+}
+
+// Offer announces a MIME type the data is available in.
+func (s *WlDataSource) Offer(mimeType string) error {
+	builder := NewMessageBuilder()
+	builder.PutString(mimeType)
+	msg := builder.BuildMessage(s.id, dataSourceOffer)
+
+	return s.display.SendMessage(msg)
+}
+
+// Destroy destroys the data source.
+func (s *WlDataSource) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(s.id, dataSourceDestroy)
+
+	return s.display.SendMessage(msg)
+}
+
+// SetSendHandler sets a callback for the send event, which asks for the
+// data in mimeType to be written to fd. The handler must close fd.
+func (s *WlDataSource) SetSendHandler(handler func(mimeType string, fd int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSend = handler
+}
+
+// SetCancelledHandler sets a callback for the cancelled event, sent when
+// another source replaced this one. The source should be destroyed.
+func (s *WlDataSource) SetCancelledHandler(handler func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onCancelled = handler
+}
+
+// dispatch handles wl_data_source events.
+func (s *WlDataSource) dispatch(msg *Message) error {
+	switch msg.Opcode {
+	case dataSourceEventSend:
+		return s.handleSend(msg)
+	case dataSourceEventCancelled:
+		s.mu.Lock()
+		handler := s.onCancelled
+		s.mu.Unlock()
+		if handler != nil {
+			handler()
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// handleSend handles the wl_data_source.send event.
+func (s *WlDataSource) handleSend(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	mimeType, err := decoder.String()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_source.send: failed to decode mime type: %w", err)
+	}
+	if len(msg.FDs) == 0 {
+		return fmt.Errorf("wayland: wl_data_source.send: no file descriptor")
+	}
+
+	s.mu.Lock()
+	handler := s.onSend
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler(mimeType, msg.FDs[0])
+	}
+	return nil
+}
+
+// WlDataDevice represents the wl_data_device interface of a seat. It
+// tracks the data offers the compositor introduces, which one holds the
+// current selection (clipboard), and which one is being dragged over
+// the client's surfaces.
+type WlDataDevice struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	offers    map[ObjectID]*WlDataOffer // Offers introduced by data_offer
+	selection *WlDataOffer              // Current selection, or nil
+	drag      *WlDataOffer              // Offer of the drag over a surface, or nil
+
+	// Drag-and-drop event handlers
+	onEnter  func(event *DataDeviceEnterEvent)
+	onLeave  func()
+	onMotion func(x, y float64)
+	onDrop   func(offer *WlDataOffer)
+}
+
+// NewWlDataDevice creates a WlDataDevice from an object ID.
+func NewWlDataDevice(display *Display, objectID ObjectID) *WlDataDevice {
+	d := &WlDataDevice{
+		display: display,
+		id:      objectID,
+		offers:  make(map[ObjectID]*WlDataOffer),
+	}
+	display.registerObject(objectID, d)
+	return d
+}
+
+// ID returns the object ID of the data device.
+func (d *WlDataDevice) ID() ObjectID {
+	return d.id
+}
+
+// SetSelection makes source the selection, or clears it with a nil
+// source. The serial must be that of a recent input event of the seat.
+func (d *WlDataDevice) SetSelection(source *WlDataSource, serial uint32) error {
+	var sourceID ObjectID
+	if source != nil {
+		sourceID = source.ID()
+	}
+
+	builder := NewMessageBuilder()
+	builder.PutObject(sourceID)
+	builder.PutUint32(serial)
+	msg := builder.BuildMessage(d.id, dataDeviceSetSelection)
+
+	return d.display.SendMessage(msg)
+}
+
+// Selection returns the offer holding the current selection, or nil if
+// there is none. Clients only receive the selection while they have
+// keyboard focus.
+func (d *WlDataDevice) Selection() *WlDataOffer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.selection
+}
+
+// SetEnterHandler sets a callback for a drag entering a surface.
+func (d *WlDataDevice) SetEnterHandler(handler func(event *DataDeviceEnterEvent)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onEnter = handler
+}
+
+// SetLeaveHandler sets a callback for a drag leaving the surface it
+// entered, or being cancelled. The offer of the drag is destroyed after
+// the handler returns.
+func (d *WlDataDevice) SetLeaveHandler(handler func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onLeave = handler
+}
+
+// SetMotionHandler sets a callback for a drag moving over the surface it
+// entered, with the position in surface coordinates.
+func (d *WlDataDevice) SetMotionHandler(handler func(x, y float64)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onMotion = handler
+}
+
+// SetDropHandler sets a callback for a drag dropped on the surface it
+// entered. The handler takes over the offer of the drag, nil if it has
+// no data: it receives the data, then calls Finish and Destroy.
+func (d *WlDataDevice) SetDropHandler(handler func(offer *WlDataOffer)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onDrop = handler
+}
+
+// dispatch handles wl_data_device events.
+func (d *WlDataDevice) dispatch(msg *Message) error {
+	switch msg.Opcode {
+	case dataDeviceEventDataOffer:
+		return d.handleDataOffer(msg)
+	case dataDeviceEventEnter:
+		return d.handleEnter(msg)
+	case dataDeviceEventLeave:
+		return d.handleLeave()
+	case dataDeviceEventMotion:
+		return d.handleMotion(msg)
+	case dataDeviceEventDrop:
+		return d.handleDrop()
+	case dataDeviceEventSelection:
+		return d.handleSelection(msg)
+	default:
+		return nil
+	}
+}
+
+// handleEnter handles the wl_data_device.enter event.
+func (d *WlDataDevice) handleEnter(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	serial, err := decoder.Uint32()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.enter: failed to decode serial: %w", err)
+	}
+	surface, err := decoder.Object()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.enter: failed to decode surface: %w", err)
+	}
+	x, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.enter: failed to decode x: %w", err)
+	}
+	y, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.enter: failed to decode y: %w", err)
+	}
+	offerID, err := decoder.Object()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.enter: failed to decode offer: %w", err)
+	}
+
+	d.mu.Lock()
+	d.drag = d.offers[offerID]
+	delete(d.offers, offerID)
+	event := &DataDeviceEnterEvent{Serial: serial, Surface: surface, X: x.Float(), Y: y.Float(), Offer: d.drag}
+	handler := d.onEnter
+	d.mu.Unlock()
+
+	if handler != nil {
+		handler(event)
+	}
+	return nil
+}
+
+// handleLeave handles the wl_data_device.leave event.
+func (d *WlDataDevice) handleLeave() error {
+	d.mu.Lock()
+	offer := d.drag
+	d.drag = nil
+	handler := d.onLeave
+	d.mu.Unlock()
+
+	if handler != nil {
+		handler()
+	}
+	if offer != nil {
+		_ = offer.Destroy()
+	}
+	return nil
+}
+
+// handleMotion handles the wl_data_device.motion event.
+func (d *WlDataDevice) handleMotion(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	_, _ = decoder.Uint32() // time
+	x, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.motion: failed to decode x: %w", err)
+	}
+	y, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.motion: failed to decode y: %w", err)
+	}
+
+	d.mu.Lock()
+	handler := d.onMotion
+	d.mu.Unlock()
+
+	if handler != nil {
+		handler(x.Float(), y.Float())
+	}
+	return nil
+}
+
+// handleDrop handles the wl_data_device.drop event. No leave event
+// follows for the dropped offer.
+func (d *WlDataDevice) handleDrop() error {
+	d.mu.Lock()
+	offer := d.drag
+	d.drag = nil
+	handler := d.onDrop
+	d.mu.Unlock()
+
+	if handler != nil {
+		handler(offer)
+	} else if offer != nil {
+		_ = offer.Destroy()
+	}
+	return nil
+}
+
+// handleDataOffer handles the wl_data_device.data_offer event, which
+// introduces an offer whose MIME types follow.
+func (d *WlDataDevice) handleDataOffer(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	offerID, err := decoder.NewID()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.data_offer: failed to decode: %w", err)
+	}
+
+	offer := NewWlDataOffer(d.display, offerID)
+	d.mu.Lock()
+	d.offers[offerID] = offer
+	d.mu.Unlock()
+	return nil
+}
+
+// handleSelection handles the wl_data_device.selection event. Offers
+// other than the new selection are destroyed.
+func (d *WlDataDevice) handleSelection(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	offerID, err := decoder.Object()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_device.selection: failed to decode: %w", err)
+	}
+
+	d.mu.Lock()
+	d.selection = d.offers[offerID]
+	var stale []*WlDataOffer
+	for id, offer := range d.offers {
+		if id != offerID {
+			stale = append(stale, offer)
+			delete(d.offers, id)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, offer := range stale {
+		_ = offer.Destroy()
+	}
+	return nil
+}
+
+// WlDataOffer represents the wl_data_offer interface: data another
+// client offers, in the MIME types it announced.
+type WlDataOffer struct {
+	display *Display
+	id      ObjectID
+
+	mu        sync.Mutex
+	mimeTypes []string
+}
+
+// NewWlDataOffer creates a WlDataOffer from an object ID.
+func NewWlDataOffer(display *Display, objectID ObjectID) *WlDataOffer {
+	o := &WlDataOffer{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, o)
+	return o
+}
+
+// ID returns the object ID of the data offer.
+func (o *WlDataOffer) ID() ObjectID {
+	return o.id
+}
+
+// MimeTypes returns the MIME types the data is offered in.
+func (o *WlDataOffer) MimeTypes() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.mimeTypes...)
+}
+
+// HasMimeType reports whether the data is offered in mimeType.
+func (o *WlDataOffer) HasMimeType(mimeType string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return slices.Contains(o.mimeTypes, mimeType)
+}
+
+// Accept tells the dragging client whether the data can be dropped, in
+// mimeType, or not with an empty mimeType. The serial is that of the
+// enter event.
+func (o *WlDataOffer) Accept(serial uint32, mimeType string) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(serial)
+	if mimeType == "" {
+		builder.PutUint32(0) // null string
+	} else {
+		builder.PutString(mimeType)
+	}
+	msg := builder.BuildMessage(o.id, dataOfferAccept)
+
+	return o.display.SendMessage(msg)
+}
+
+// SetActions sets the drag-and-drop actions the client supports and the
+// one it prefers (v3).
+func (o *WlDataOffer) SetActions(actions, preferred uint32) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(actions)
+	builder.PutUint32(preferred)
+	msg := builder.BuildMessage(o.id, dataOfferSetActions)
+
+	return o.display.SendMessage(msg)
+}
+
+// Finish tells the dragging client that the dropped data was received
+// (v3). The offer is then destroyed.
+func (o *WlDataOffer) Finish() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(o.id, dataOfferFinish)
+
+	return o.display.SendMessage(msg)
+}
+
+// Receive asks the offering client to write the data in mimeType to fd,
+// the write end of a pipe. The caller closes its copy of fd after the
+// request was sent and reads the data until end of file.
+func (o *WlDataOffer) Receive(mimeType string, fd int) error {
+	builder := NewMessageBuilder()
+	builder.PutString(mimeType)
+	builder.PutFD(fd)
+	msg := builder.BuildMessage(o.id, dataOfferReceive)
+
+	return o.display.SendMessage(msg)
+}
+
+// Destroy destroys the data offer.
+func (o *WlDataOffer) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(o.id, dataOfferDestroy)
+
+	return o.display.SendMessage(msg)
+}
+
+// dispatch handles wl_data_offer events.
+func (o *WlDataOffer) dispatch(msg *Message) error {
+	if msg.Opcode != dataOfferEventOffer {
+		return nil
+	}
+
+	decoder := NewDecoder(msg.Args)
+	mimeType, err := decoder.String()
+	if err != nil {
+		return fmt.Errorf("wayland: wl_data_offer.offer: failed to decode: %w", err)
+	}
+
+	o.mu.Lock()
+	o.mimeTypes = append(o.mimeTypes, mimeType)
+	o.mu.Unlock()
+	return nil
+}
+
+// FilePathsFromURIList returns the local file paths of a text/uri-list,
+// skipping comments and URIs of other schemes.
+func FilePathsFromURIList(data []byte) []string {
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		u, err := url.Parse(line)
+		if err != nil || u.Scheme != "file" || u.Path == "" {
+			continue
+		}
+		if u.Host != "" && u.Host != "localhost" {
+			continue // File on another host
+		}
+		paths = append(paths, u.Path)
+	}
+	return paths
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package wayland
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDataDeviceOpcodes verifies wl_data_device opcode constants match the protocol spec.
+func TestDataDeviceOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"manager.create_data_source", dataDeviceManagerCreateDataSource, 0},
+		{"manager.get_data_device", dataDeviceManagerGetDataDevice, 1},
+		{"source.offer", dataSourceOffer, 0},
+		{"source.destroy", dataSourceDestroy, 1},
+		{"source.send", dataSourceEventSend, 1},
+		{"source.cancelled", dataSourceEventCancelled, 2},
+		{"device.set_selection", dataDeviceSetSelection, 1},
+		{"device.data_offer", dataDeviceEventDataOffer, 0},
+		{"device.enter", dataDeviceEventEnter, 1},
+		{"device.leave", dataDeviceEventLeave, 2},
+		{"device.motion", dataDeviceEventMotion, 3},
+		{"device.drop", dataDeviceEventDrop, 4},
+		{"device.selection", dataDeviceEventSelection, 5},
+		{"offer.accept", dataOfferAccept, 0},
+		{"offer.receive", dataOfferReceive, 1},
+		{"offer.destroy", dataOfferDestroy, 2},
+		{"offer.finish", dataOfferFinish, 3},
+		{"offer.set_actions", dataOfferSetActions, 4},
+		{"offer.offer", dataOfferEventOffer, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDataDeviceSelection verifies that the selection offer and its MIME
+// types are tracked from data_offer, offer and selection events.
+func TestDataDeviceSelection(t *testing.T) {
+	device := NewWlDataDevice(nil, ObjectID(40))
+	offerID := ObjectID(0xff000001)
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(offerID)
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventDataOffer)); err != nil {
+		t.Fatal(err)
+	}
+
+	offer := device.offers[offerID]
+	if offer == nil {
+		t.Fatal("data_offer did not create an offer")
+	}
+	for _, mimeType := range []string{"text/plain;charset=utf-8", "text/html"} {
+		builder.Reset()
+		builder.PutString(mimeType)
+		if err := offer.dispatch(builder.BuildMessage(offerID, dataOfferEventOffer)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if device.Selection() != nil {
+		t.Error("selection set before the selection event")
+	}
+	builder.Reset()
+	builder.PutObject(offerID)
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventSelection)); err != nil {
+		t.Fatal(err)
+	}
+
+	if device.Selection() != offer {
+		t.Fatal("selection event did not select the offer")
+	}
+	if got := offer.MimeTypes(); !slices.Equal(got, []string{"text/plain;charset=utf-8", "text/html"}) {
+		t.Errorf("MimeTypes = %v", got)
+	}
+	if !offer.HasMimeType("text/html") || offer.HasMimeType("image/png") {
+		t.Error("HasMimeType does not match the offered types")
+	}
+}
+
+// TestDataDeviceDrag verifies that enter, motion and drop events reach
+// their handlers, and that the dragged offer is handed to the drop
+// handler instead of being destroyed by a new selection.
+func TestDataDeviceDrag(t *testing.T) {
+	device := NewWlDataDevice(nil, ObjectID(40))
+	offerID := ObjectID(0xff000002)
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(offerID)
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventDataOffer)); err != nil {
+		t.Fatal(err)
+	}
+	offer := device.offers[offerID]
+
+	var entered *DataDeviceEnterEvent
+	var moved [2]float64
+	var dropped *WlDataOffer
+	device.SetEnterHandler(func(event *DataDeviceEnterEvent) { entered = event })
+	device.SetMotionHandler(func(x, y float64) { moved = [2]float64{x, y} })
+	device.SetDropHandler(func(offer *WlDataOffer) { dropped = offer })
+
+	builder.Reset()
+	builder.PutUint32(7)
+	builder.PutObject(ObjectID(3))
+	builder.PutFixed(FixedFromFloat(10.5))
+	builder.PutFixed(FixedFromFloat(20))
+	builder.PutObject(offerID)
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventEnter)); err != nil {
+		t.Fatal(err)
+	}
+	want := DataDeviceEnterEvent{Serial: 7, Surface: 3, X: 10.5, Y: 20, Offer: offer}
+	if entered == nil || *entered != want {
+		t.Fatalf("enter event = %+v, want %+v", entered, want)
+	}
+
+	// A selection change keeps the dragged offer
+	builder.Reset()
+	builder.PutObject(0)
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventSelection)); err != nil {
+		t.Fatal(err)
+	}
+
+	builder.Reset()
+	builder.PutUint32(1000)
+	builder.PutFixed(FixedFromFloat(12))
+	builder.PutFixed(FixedFromFloat(24.25))
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventMotion)); err != nil {
+		t.Fatal(err)
+	}
+	if moved != [2]float64{12, 24.25} {
+		t.Errorf("motion = %v, want [12 24.25]", moved)
+	}
+
+	builder.Reset()
+	if err := device.dispatch(builder.BuildMessage(device.ID(), dataDeviceEventDrop)); err != nil {
+		t.Fatal(err)
+	}
+	if dropped != offer {
+		t.Errorf("drop handler got offer %p, want %p", dropped, offer)
+	}
+}
+
+// TestFilePathsFromURIList verifies that local file paths are extracted
+// from a text/uri-list, skipping comments and non-file URIs.
+func TestFilePathsFromURIList(t *testing.T) {
+	data := "# dragged from a file manager\r\n" +
+		"file:///home/user/a.png\r\n" +
+		"file://localhost/tmp/with%20space.txt\r\n" +
+		"https://example.com/b.png\r\n" +
+		"file://otherhost/c.png\r\n"
+
+	got := FilePathsFromURIList([]byte(data))
+	want := []string{"/home/user/a.png", "/tmp/with space.txt"}
+	if !slices.Equal(got, want) {
+		t.Errorf("FilePathsFromURIList = %q, want %q", got, want)
+	}
+}
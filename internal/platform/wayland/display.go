@@ -63,6 +63,7 @@ type Display struct {
 	writeBuf  []byte
 	fdBuf     []int
 	callbacks map[ObjectID]chan uint32
+	objects   map[ObjectID]eventDispatcher
 	closed    bool
 
 	// Protocol error state
@@ -116,6 +117,7 @@ func ConnectTo(socketPath string) (*Display, error) {
 		writeBuf:  make([]byte, 0, 4096),
 		fdBuf:     make([]int, 0, 16),
 		callbacks: make(map[ObjectID]chan uint32),
+		objects:   make(map[ObjectID]eventDispatcher),
 	}
 
 	// wl_display is always object ID 1, so start allocating from 2
@@ -402,6 +404,15 @@ func (d *Display) dispatch(msg *Message) error {
 			return d.registry.dispatch(msg)
 		}
 
+		// Check if it's a registered protocol object
+		d.mu.Lock()
+		obj, ok := d.objects[msg.ObjectID]
+		d.mu.Unlock()
+
+		if ok {
+			return obj.dispatch(msg)
+		}
+
 		// Unknown object - this is not necessarily an error
 		// The object might have been created by application code
 		return nil
@@ -456,6 +467,26 @@ func (d *Display) handleError(msg *Message) error {
 	return d.protocolError
 }
 
+// eventDispatcher is implemented by protocol objects that receive events.
+type eventDispatcher interface {
+	dispatch(msg *Message) error
+}
+
+// registerObject routes events for the given object ID to obj.
+// It is a no-op for a nil display, so objects can be constructed in tests.
+func (d *Display) registerObject(id ObjectID, obj eventDispatcher) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.objects != nil {
+		d.objects[id] = obj
+	}
+}
+
 // handleDeleteID handles the wl_display.delete_id event.
 func (d *Display) handleDeleteID(msg *Message) error {
 	decoder := NewDecoder(msg.Args)
@@ -467,6 +498,7 @@ func (d *Display) handleDeleteID(msg *Message) error {
 
 	d.mu.Lock()
 	d.deletedIDs = append(d.deletedIDs, ObjectID(id))
+	delete(d.objects, ObjectID(id))
 	d.mu.Unlock()
 
 	// Note: In a full implementation, you would recycle these IDs
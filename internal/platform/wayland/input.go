@@ -50,11 +50,13 @@ type WlSeat struct {
 // NewWlSeat creates a WlSeat from a bound object ID.
 // The objectID should be obtained from Registry.BindSeat().
 func NewWlSeat(display *Display, objectID ObjectID, version uint32) *WlSeat {
-	return &WlSeat{
+	s := &WlSeat{
 		display: display,
 		id:      objectID,
 		version: version,
 	}
+	display.registerObject(objectID, s)
+	return s
 }
 
 // ID returns the object ID of the seat.
@@ -331,10 +333,12 @@ type WlPointer struct {
 
 // NewWlPointer creates a WlPointer from an object ID.
 func NewWlPointer(display *Display, objectID ObjectID) *WlPointer {
-	return &WlPointer{
+	p := &WlPointer{
 		display: display,
 		id:      objectID,
 	}
+	display.registerObject(objectID, p)
+	return p
 }
 
 // ID returns the object ID of the pointer.
@@ -803,6 +807,11 @@ type KeyboardKeyEvent struct {
 	Time   uint32 // Timestamp in milliseconds.
 	Key    uint32 // Key code (Linux evdev key code).
 	State  uint32 // Key state (pressed/released).
+
+	// Synthetic is true for events generated from the key set of an
+	// enter event (presses) or on leave (releases), rather than reported
+	// by the compositor as a physical key transition.
+	Synthetic bool
 }
 
 // KeyboardModifiersEvent contains data for the modifiers event.
@@ -831,6 +840,8 @@ type WlKeyboard struct {
 	// Current state
 	focusedSurface ObjectID
 	lastSerial     uint32
+	pressed        map[uint32]bool
+	modifiers      KeyboardModifiersEvent
 
 	// Keymap file descriptor (needs to be closed by the application)
 	keymapFD   int
@@ -851,13 +862,16 @@ type WlKeyboard struct {
 
 // NewWlKeyboard creates a WlKeyboard from an object ID.
 func NewWlKeyboard(display *Display, objectID ObjectID) *WlKeyboard {
-	return &WlKeyboard{
+	k := &WlKeyboard{
 		display:     display,
 		id:          objectID,
 		keymapFD:    -1,
+		pressed:     make(map[uint32]bool),
 		repeatRate:  25,  // Default: 25 chars/sec
 		repeatDelay: 400, // Default: 400ms
 	}
+	display.registerObject(objectID, k)
+	return k
 }
 
 // ID returns the object ID of the keyboard.
@@ -880,6 +894,35 @@ func (k *WlKeyboard) LastSerial() uint32 {
 	return k.lastSerial
 }
 
+// IsKeyPressed returns true if the key is currently held down.
+// The state includes keys reported as already pressed on enter.
+func (k *WlKeyboard) IsKeyPressed(key uint32) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.pressed[key]
+}
+
+// PressedKeys returns the keys currently held down.
+func (k *WlKeyboard) PressedKeys() []uint32 {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keys := make([]uint32, 0, len(k.pressed))
+	for key := range k.pressed {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Modifiers returns the last modifier state received from the compositor.
+// Compositors send a modifiers event right after enter, so this is an
+// accurate snapshot even if modifiers were pressed before focus was gained.
+func (k *WlKeyboard) Modifiers() KeyboardModifiersEvent {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.modifiers
+}
+
 // KeymapFD returns the file descriptor for the keymap.
 // Returns -1 if no keymap has been received.
 // The caller is responsible for closing this FD when done.
@@ -1038,10 +1081,20 @@ func (k *WlKeyboard) handleEnter(msg *Message) error {
 			uint32(keysData[i*4+3])<<24
 	}
 
+	// Reconcile key state: keys held while another window had focus
+	// are reported here rather than as key events.
 	k.mu.Lock()
 	k.focusedSurface = surface
 	k.lastSerial = serial
+	newlyPressed := make([]uint32, 0, len(keys))
+	for _, key := range keys {
+		if !k.pressed[key] {
+			k.pressed[key] = true
+			newlyPressed = append(newlyPressed, key)
+		}
+	}
 	handler := k.onEnter
+	keyHandler := k.onKey
 	k.mu.Unlock()
 
 	if handler != nil {
@@ -1052,6 +1105,17 @@ func (k *WlKeyboard) handleEnter(msg *Message) error {
 		})
 	}
 
+	if keyHandler != nil {
+		for _, key := range newlyPressed {
+			keyHandler(&KeyboardKeyEvent{
+				Serial:    serial,
+				Key:       key,
+				State:     KeyStatePressed,
+				Synthetic: true,
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -1068,12 +1132,32 @@ func (k *WlKeyboard) handleLeave(msg *Message) error {
 		return fmt.Errorf("wayland: wl_keyboard.leave: failed to decode surface: %w", err)
 	}
 
+	// No key events arrive while unfocused, so release everything now
+	// to avoid keys (especially modifiers) getting stuck.
 	k.mu.Lock()
 	k.focusedSurface = 0
 	k.lastSerial = serial
+	released := make([]uint32, 0, len(k.pressed))
+	for key := range k.pressed {
+		released = append(released, key)
+	}
+	k.pressed = make(map[uint32]bool)
+	k.modifiers = KeyboardModifiersEvent{}
 	handler := k.onLeave
+	keyHandler := k.onKey
 	k.mu.Unlock()
 
+	if keyHandler != nil {
+		for _, key := range released {
+			keyHandler(&KeyboardKeyEvent{
+				Serial:    serial,
+				Key:       key,
+				State:     KeyStateReleased,
+				Synthetic: true,
+			})
+		}
+	}
+
 	if handler != nil {
 		handler(&KeyboardLeaveEvent{
 			Serial:  serial,
@@ -1109,6 +1193,11 @@ func (k *WlKeyboard) handleKey(msg *Message) error {
 
 	k.mu.Lock()
 	k.lastSerial = serial
+	if state == KeyStatePressed {
+		k.pressed[key] = true
+	} else {
+		delete(k.pressed, key)
+	}
 	handler := k.onKey
 	k.mu.Unlock()
 
@@ -1152,19 +1241,22 @@ func (k *WlKeyboard) handleModifiers(msg *Message) error {
 		return fmt.Errorf("wayland: wl_keyboard.modifiers: failed to decode group: %w", err)
 	}
 
+	event := KeyboardModifiersEvent{
+		Serial:        serial,
+		ModsDepressed: modsDepressed,
+		ModsLatched:   modsLatched,
+		ModsLocked:    modsLocked,
+		Group:         group,
+	}
+
 	k.mu.Lock()
 	k.lastSerial = serial
+	k.modifiers = event
 	handler := k.onModifiers
 	k.mu.Unlock()
 
 	if handler != nil {
-		handler(&KeyboardModifiersEvent{
-			Serial:        serial,
-			ModsDepressed: modsDepressed,
-			ModsLatched:   modsLatched,
-			ModsLocked:    modsLocked,
-			Group:         group,
-		})
+		handler(&event)
 	}
 
 	return nil
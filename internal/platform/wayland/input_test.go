@@ -1012,6 +1012,74 @@ func TestKeyboardLeaveDispatch(t *testing.T) {
 	}
 }
 
+// TestKeyboardEnterKeyReconciliation verifies keys held on enter are synthesized
+// as presses and released again on leave.
+func TestKeyboardEnterKeyReconciliation(t *testing.T) {
+	keyboard := NewWlKeyboard(nil, ObjectID(960))
+
+	var events []KeyboardKeyEvent
+	keyboard.SetKeyHandler(func(event *KeyboardKeyEvent) {
+		events = append(events, *event)
+	})
+
+	// Enter with left shift (42) and A (30) already held
+	keysData := make([]byte, 8)
+	binary.LittleEndian.PutUint32(keysData[0:], 42)
+	binary.LittleEndian.PutUint32(keysData[4:], 30)
+
+	builder := NewMessageBuilder()
+	builder.PutUint32(100)
+	builder.PutObject(ObjectID(3))
+	builder.PutArray(keysData)
+	if err := keyboard.dispatch(builder.BuildMessage(keyboard.id, keyboardEventEnter)); err != nil {
+		t.Fatalf("dispatch enter failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("synthesized %d key events on enter, want 2", len(events))
+	}
+	for _, e := range events {
+		if !e.Synthetic || e.State != KeyStatePressed || e.Serial != 100 {
+			t.Errorf("enter event = %+v, want synthetic press with serial 100", e)
+		}
+	}
+	if !keyboard.IsKeyPressed(42) || !keyboard.IsKeyPressed(30) {
+		t.Error("keys from enter not tracked as pressed")
+	}
+
+	// Physical release of A
+	builder = NewMessageBuilder()
+	builder.PutUint32(101)
+	builder.PutUint32(5000)
+	builder.PutUint32(30)
+	builder.PutUint32(KeyStateReleased)
+	if err := keyboard.dispatch(builder.BuildMessage(keyboard.id, keyboardEventKey)); err != nil {
+		t.Fatalf("dispatch key failed: %v", err)
+	}
+	if keyboard.IsKeyPressed(30) {
+		t.Error("key 30 still pressed after release")
+	}
+	if events[2].Synthetic {
+		t.Error("physical key event marked synthetic")
+	}
+
+	// Leave releases the remaining shift
+	events = nil
+	builder = NewMessageBuilder()
+	builder.PutUint32(102)
+	builder.PutObject(ObjectID(3))
+	if err := keyboard.dispatch(builder.BuildMessage(keyboard.id, keyboardEventLeave)); err != nil {
+		t.Fatalf("dispatch leave failed: %v", err)
+	}
+
+	if len(events) != 1 || events[0].Key != 42 || events[0].State != KeyStateReleased || !events[0].Synthetic {
+		t.Errorf("leave events = %+v, want synthetic release of key 42", events)
+	}
+	if len(keyboard.PressedKeys()) != 0 {
+		t.Errorf("PressedKeys() = %v after leave, want empty", keyboard.PressedKeys())
+	}
+}
+
 // TestReleaseMessages verifies release message formats.
 func TestReleaseMessages(t *testing.T) {
 	tests := []struct {
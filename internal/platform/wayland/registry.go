@@ -20,14 +20,19 @@ const (
 
 // Well-known Wayland interface names.
 const (
-	InterfaceWlCompositor        = "wl_compositor"
-	InterfaceWlShm               = "wl_shm"
-	InterfaceWlSeat              = "wl_seat"
-	InterfaceWlOutput            = "wl_output"
-	InterfaceXdgWmBase           = "xdg_wm_base"
-	InterfaceWlSubcompositor     = "wl_subcompositor"
-	InterfaceWlDataDeviceManager = "wl_data_device_manager"
-	InterfaceZwpLinuxDmabuf      = "zwp_linux_dmabuf_v1"
+	InterfaceWlCompositor           = "wl_compositor"
+	InterfaceWlShm                  = "wl_shm"
+	InterfaceWlSeat                 = "wl_seat"
+	InterfaceWlOutput               = "wl_output"
+	InterfaceXdgWmBase              = "xdg_wm_base"
+	InterfaceWlSubcompositor        = "wl_subcompositor"
+	InterfaceWlDataDeviceManager    = "wl_data_device_manager"
+	InterfaceZwpLinuxDmabuf         = "zwp_linux_dmabuf_v1"
+	InterfaceWpContentTypeManager   = "wp_content_type_manager_v1"
+	InterfaceXdgToplevelIconManager = "xdg_toplevel_icon_manager_v1"
+	InterfaceZwpTabletManagerV2     = "zwp_tablet_manager_v2"
+	InterfaceWpCursorShapeManager   = "wp_cursor_shape_manager_v1"
+	InterfaceXdgActivation          = "xdg_activation_v1"
 )
 
 // Global represents a Wayland global interface advertised by the compositor.
@@ -150,6 +155,60 @@ func (r *Registry) BindXdgWmBase(version uint32) (ObjectID, error) {
 	return r.Bind(name, InterfaceXdgWmBase, version)
 }
 
+// BindDataDeviceManager binds to the wl_data_device_manager global.
+func (r *Registry) BindDataDeviceManager(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceWlDataDeviceManager)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceWlDataDeviceManager, version)
+}
+
+// BindContentTypeManager binds to the wp_content_type_manager_v1 global.
+func (r *Registry) BindContentTypeManager(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceWpContentTypeManager)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceWpContentTypeManager, version)
+}
+
+// BindToplevelIconManager binds to the xdg_toplevel_icon_manager_v1 global.
+func (r *Registry) BindToplevelIconManager(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceXdgToplevelIconManager)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceXdgToplevelIconManager, version)
+}
+
+// BindTabletManager binds to the zwp_tablet_manager_v2 global.
+func (r *Registry) BindTabletManager(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceZwpTabletManagerV2)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceZwpTabletManagerV2, version)
+}
+
+// BindCursorShapeManager binds to the wp_cursor_shape_manager_v1 global.
+func (r *Registry) BindCursorShapeManager(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceWpCursorShapeManager)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceWpCursorShapeManager, version)
+}
+
+// BindXdgActivation binds to the xdg_activation_v1 global.
+func (r *Registry) BindXdgActivation(version uint32) (ObjectID, error) {
+	name, err := r.FindGlobal(InterfaceXdgActivation)
+	if err != nil {
+		return 0, err
+	}
+	return r.Bind(name, InterfaceXdgActivation, version)
+}
+
 // FindGlobal finds a global by interface name and returns its name.
 // Returns an error if the global is not found.
 func (r *Registry) FindGlobal(iface string) (uint32, error) {
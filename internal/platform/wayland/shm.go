@@ -181,11 +181,13 @@ type WlShm struct {
 // NewWlShm creates a WlShm from a bound object ID.
 // The objectID should be obtained from Registry.BindShm().
 func NewWlShm(display *Display, objectID ObjectID) *WlShm {
-	return &WlShm{
+	s := &WlShm{
 		display: display,
 		id:      objectID,
 		formats: make([]ShmFormat, 0, 16),
 	}
+	display.registerObject(objectID, s)
+	return s
 }
 
 // ID returns the object ID of the shm.
@@ -367,10 +369,12 @@ type WlBuffer struct {
 
 // NewWlBuffer creates a WlBuffer from an object ID.
 func NewWlBuffer(display *Display, objectID ObjectID) *WlBuffer {
-	return &WlBuffer{
+	b := &WlBuffer{
 		display: display,
 		id:      objectID,
 	}
+	display.registerObject(objectID, b)
+	return b
 }
 
 // ID returns the object ID of the buffer.
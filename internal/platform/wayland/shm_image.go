@@ -0,0 +1,77 @@
+//go:build linux
+
+package wayland
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// CreateImageBuffer creates a wl_buffer containing a copy of an RGBA image.
+//
+// The pixels must be tightly packed RGBA with premultiplied alpha (the
+// layout of image.RGBA.Pix), width*height*4 bytes long. The data is copied
+// into a memfd-backed pool in ShmFormatARGB8888, so the caller may reuse
+// pixels immediately. The intermediate pool is destroyed before returning;
+// the buffer keeps the underlying memory alive until it is destroyed.
+func (s *WlShm) CreateImageBuffer(width, height int, pixels []byte) (*WlBuffer, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("wayland: invalid image size %dx%d", width, height)
+	}
+
+	stride := width * 4
+	size := stride * height
+	if len(pixels) != size {
+		return nil, fmt.Errorf("wayland: invalid image data size: expected %d bytes, got %d", size, len(pixels))
+	}
+
+	fd, err := unix.MemfdCreate("gogpu-shm", unix.MFD_CLOEXEC)
+	if err != nil {
+		return nil, fmt.Errorf("wayland: memfd_create failed: %w", err)
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	if err := unix.Ftruncate(fd, int64(size)); err != nil {
+		return nil, fmt.Errorf("wayland: ftruncate failed: %w", err)
+	}
+
+	data, err := unix.Mmap(fd, 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("wayland: mmap failed: %w", err)
+	}
+	rgbaToARGB8888(data, pixels)
+	if err := unix.Munmap(data); err != nil {
+		return nil, fmt.Errorf("wayland: munmap failed: %w", err)
+	}
+
+	pool, err := s.CreatePool(fd, int32(size)) //nolint:gosec // G115: size bounded by len(pixels)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer, err := pool.CreateBuffer(0, int32(width), int32(height), int32(stride), ShmFormatARGB8888) //nolint:gosec // G115: dimensions validated above
+	if err != nil {
+		_ = pool.Destroy()
+		return nil, err
+	}
+
+	// The buffer holds its own reference to the pool memory.
+	if err := pool.Destroy(); err != nil {
+		_ = buffer.Destroy()
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+// rgbaToARGB8888 converts RGBA bytes to little-endian ARGB8888 (B, G, R, A).
+// Both formats use premultiplied alpha, so only the channel order changes.
+func rgbaToARGB8888(dst, src []byte) {
+	for i := 0; i+3 < len(src) && i+3 < len(dst); i += 4 {
+		dst[i+0] = src[i+2]
+		dst[i+1] = src[i+1]
+		dst[i+2] = src[i+0]
+		dst[i+3] = src[i+3]
+	}
+}
@@ -0,0 +1,661 @@
+//go:build linux
+
+package wayland
+
+import (
+	"fmt"
+	"sync"
+)
+
+// zwp_tablet_manager_v2 opcodes (requests)
+const (
+	tabletManagerGetTabletSeat Opcode = 0 // get_tablet_seat(tablet_seat: new_id<zwp_tablet_seat_v2>, seat: object<wl_seat>)
+	tabletManagerDestroy       Opcode = 1 // destroy()
+)
+
+// zwp_tablet_seat_v2 opcodes (requests)
+const (
+	tabletSeatDestroy Opcode = 0 // destroy()
+)
+
+// zwp_tablet_seat_v2 event opcodes
+const (
+	tabletSeatEventTabletAdded Opcode = 0 // tablet_added(id: new_id<zwp_tablet_v2>)
+	tabletSeatEventToolAdded   Opcode = 1 // tool_added(id: new_id<zwp_tablet_tool_v2>)
+	tabletSeatEventPadAdded    Opcode = 2 // pad_added(id: new_id<zwp_tablet_pad_v2>)
+)
+
+// zwp_tablet_v2 opcodes (requests)
+const (
+	tabletDestroy Opcode = 0 // destroy()
+)
+
+// zwp_tablet_v2 event opcodes
+const (
+	tabletEventName    Opcode = 0 // name(name: string)
+	tabletEventID      Opcode = 1 // id(vid: uint, pid: uint)
+	tabletEventPath    Opcode = 2 // path(path: string)
+	tabletEventDone    Opcode = 3 // done()
+	tabletEventRemoved Opcode = 4 // removed()
+)
+
+// zwp_tablet_tool_v2 opcodes (requests)
+const (
+	tabletToolSetCursor Opcode = 0 // set_cursor(serial: uint, surface: object<wl_surface>, hotspot_x: int, hotspot_y: int)
+	tabletToolDestroy   Opcode = 1 // destroy()
+)
+
+// zwp_tablet_tool_v2 event opcodes
+const (
+	tabletToolEventType            Opcode = 0  // type(tool_type: uint)
+	tabletToolEventHardwareSerial  Opcode = 1  // hardware_serial(hardware_serial_hi: uint, hardware_serial_lo: uint)
+	tabletToolEventHardwareIDWacom Opcode = 2  // hardware_id_wacom(hardware_id_hi: uint, hardware_id_lo: uint)
+	tabletToolEventCapability      Opcode = 3  // capability(capability: uint)
+	tabletToolEventDone            Opcode = 4  // done()
+	tabletToolEventRemoved         Opcode = 5  // removed()
+	tabletToolEventProximityIn     Opcode = 6  // proximity_in(serial: uint, tablet: object<zwp_tablet_v2>, surface: object<wl_surface>)
+	tabletToolEventProximityOut    Opcode = 7  // proximity_out()
+	tabletToolEventDown            Opcode = 8  // down(serial: uint)
+	tabletToolEventUp              Opcode = 9  // up()
+	tabletToolEventMotion          Opcode = 10 // motion(x: fixed, y: fixed)
+	tabletToolEventPressure        Opcode = 11 // pressure(pressure: uint)
+	tabletToolEventDistance        Opcode = 12 // distance(distance: uint)
+	tabletToolEventTilt            Opcode = 13 // tilt(tilt_x: fixed, tilt_y: fixed)
+	tabletToolEventRotation        Opcode = 14 // rotation(degrees: fixed)
+	tabletToolEventSlider          Opcode = 15 // slider(position: int)
+	tabletToolEventWheel           Opcode = 16 // wheel(degrees: fixed, clicks: int)
+	tabletToolEventButton          Opcode = 17 // button(serial: uint, button: uint, state: uint)
+	tabletToolEventFrame           Opcode = 18 // frame(time: uint)
+)
+
+// TabletToolType is the physical type of a tablet tool.
+// These match the zwp_tablet_tool_v2.type enum (BTN_TOOL_* codes).
+type TabletToolType uint32
+
+// Tablet tool types.
+const (
+	TabletToolTypePen      TabletToolType = 0x140
+	TabletToolTypeEraser   TabletToolType = 0x141
+	TabletToolTypeBrush    TabletToolType = 0x142
+	TabletToolTypePencil   TabletToolType = 0x143
+	TabletToolTypeAirbrush TabletToolType = 0x144
+	TabletToolTypeFinger   TabletToolType = 0x145
+	TabletToolTypeMouse    TabletToolType = 0x146
+	TabletToolTypeLens     TabletToolType = 0x147
+)
+
+// Tablet tool capabilities (zwp_tablet_tool_v2.capability enum).
+const (
+	TabletToolCapabilityTilt     uint32 = 1
+	TabletToolCapabilityPressure uint32 = 2
+	TabletToolCapabilityDistance uint32 = 3
+	TabletToolCapabilityRotation uint32 = 4
+	TabletToolCapabilitySlider   uint32 = 5
+	TabletToolCapabilityWheel    uint32 = 6
+)
+
+// Tablet tool button codes (from linux/input-event-codes.h).
+const (
+	TabletButtonStylus  uint32 = 0x14b // First stylus button (BTN_STYLUS).
+	TabletButtonStylus2 uint32 = 0x14c // Second stylus button (BTN_STYLUS2).
+	TabletButtonStylus3 uint32 = 0x149 // Third stylus button (BTN_STYLUS3).
+)
+
+// TabletToolFrame is the accumulated tool state delivered on each frame event.
+// The protocol sends a group of events (proximity, motion, pressure, ...)
+// terminated by frame; the change flags report what happened in that group.
+type TabletToolFrame struct {
+	Time    uint32         // Timestamp in milliseconds.
+	Type    TabletToolType // Physical tool type.
+	Surface ObjectID       // Surface the tool is over (0 when out of proximity).
+
+	// Transitions within this frame.
+	ProximityIn  bool
+	ProximityOut bool
+	Down         bool
+	Up           bool
+
+	// Current state.
+	InProximity bool
+	Contact     bool            // Tool tip touches the tablet.
+	X           float64         // Surface-local X.
+	Y           float64         // Surface-local Y.
+	Pressure    float64         // Normalized tip pressure [0, 1].
+	Distance    float64         // Normalized hover distance [0, 1].
+	TiltX       float64         // Tilt in degrees.
+	TiltY       float64         // Tilt in degrees.
+	Rotation    float64         // Rotation in degrees.
+	Buttons     map[uint32]bool // Pressed tool buttons by code.
+}
+
+// ZwpTabletManagerV2 represents the zwp_tablet_manager_v2 interface.
+// It provides per-seat access to graphics tablets and their tools.
+type ZwpTabletManagerV2 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewZwpTabletManagerV2 creates a ZwpTabletManagerV2 from a bound object ID.
+// The objectID should be obtained from Registry.BindTabletManager().
+func NewZwpTabletManagerV2(display *Display, objectID ObjectID) *ZwpTabletManagerV2 {
+	return &ZwpTabletManagerV2{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the tablet manager.
+func (m *ZwpTabletManagerV2) ID() ObjectID {
+	return m.id
+}
+
+// GetTabletSeat creates the tablet seat for a wl_seat.
+// The compositor announces tablets and tools on the returned object.
+func (m *ZwpTabletManagerV2) GetTabletSeat(seat *WlSeat) (*ZwpTabletSeatV2, error) {
+	tabletSeatID := m.display.AllocID()
+
+	// Register before sending so tool_added events are not lost.
+	tabletSeat := NewZwpTabletSeatV2(m.display, tabletSeatID)
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(tabletSeatID)
+	builder.PutObject(seat.ID())
+	msg := builder.BuildMessage(m.id, tabletManagerGetTabletSeat)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return tabletSeat, nil
+}
+
+// Destroy destroys the tablet manager.
+func (m *ZwpTabletManagerV2) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(m.id, tabletManagerDestroy)
+
+	return m.display.SendMessage(msg)
+}
+
+// ZwpTabletSeatV2 represents the zwp_tablet_seat_v2 interface.
+// It announces tablets and tools as they are plugged in or first used.
+type ZwpTabletSeatV2 struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	tablets []*ZwpTabletV2
+	tools   []*ZwpTabletToolV2
+
+	// Event handlers
+	onToolAdded func(tool *ZwpTabletToolV2)
+}
+
+// NewZwpTabletSeatV2 creates a ZwpTabletSeatV2 from an object ID.
+func NewZwpTabletSeatV2(display *Display, objectID ObjectID) *ZwpTabletSeatV2 {
+	s := &ZwpTabletSeatV2{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, s)
+	return s
+}
+
+// ID returns the object ID of the tablet seat.
+func (s *ZwpTabletSeatV2) ID() ObjectID {
+	return s.id
+}
+
+// Tools returns the tools announced so far.
+func (s *ZwpTabletSeatV2) Tools() []*ZwpTabletToolV2 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*ZwpTabletToolV2, len(s.tools))
+	copy(result, s.tools)
+	return result
+}
+
+// SetToolAddedHandler sets a callback for the tool_added event.
+// Install frame handlers on the tool from within this callback.
+func (s *ZwpTabletSeatV2) SetToolAddedHandler(handler func(tool *ZwpTabletToolV2)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onToolAdded = handler
+}
+
+// Destroy destroys the tablet seat.
+func (s *ZwpTabletSeatV2) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(s.id, tabletSeatDestroy)
+
+	return s.display.SendMessage(msg)
+}
+
+// dispatch handles zwp_tablet_seat_v2 events.
+func (s *ZwpTabletSeatV2) dispatch(msg *Message) error {
+	switch msg.Opcode {
+	case tabletSeatEventTabletAdded:
+		return s.handleTabletAdded(msg)
+	case tabletSeatEventToolAdded:
+		return s.handleToolAdded(msg)
+	default:
+		// Pads (buttons/rings/strips on the tablet itself) are not supported.
+		return nil
+	}
+}
+
+// handleTabletAdded handles the zwp_tablet_seat_v2.tablet_added event.
+func (s *ZwpTabletSeatV2) handleTabletAdded(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	id, err := decoder.NewID()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_seat_v2.tablet_added: failed to decode id: %w", err)
+	}
+
+	tablet := NewZwpTabletV2(s.display, id)
+
+	s.mu.Lock()
+	s.tablets = append(s.tablets, tablet)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// handleToolAdded handles the zwp_tablet_seat_v2.tool_added event.
+func (s *ZwpTabletSeatV2) handleToolAdded(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	id, err := decoder.NewID()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_seat_v2.tool_added: failed to decode id: %w", err)
+	}
+
+	tool := NewZwpTabletToolV2(s.display, id)
+
+	s.mu.Lock()
+	s.tools = append(s.tools, tool)
+	handler := s.onToolAdded
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler(tool)
+	}
+
+	return nil
+}
+
+// ZwpTabletV2 represents the zwp_tablet_v2 interface (a physical tablet).
+type ZwpTabletV2 struct {
+	display *Display
+	id      ObjectID
+
+	mu      sync.Mutex
+	name    string
+	removed bool
+}
+
+// NewZwpTabletV2 creates a ZwpTabletV2 from an object ID.
+func NewZwpTabletV2(display *Display, objectID ObjectID) *ZwpTabletV2 {
+	t := &ZwpTabletV2{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, t)
+	return t
+}
+
+// ID returns the object ID of the tablet.
+func (t *ZwpTabletV2) ID() ObjectID {
+	return t.id
+}
+
+// Name returns the tablet's descriptive name.
+func (t *ZwpTabletV2) Name() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.name
+}
+
+// Destroy destroys the tablet object.
+func (t *ZwpTabletV2) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(t.id, tabletDestroy)
+
+	return t.display.SendMessage(msg)
+}
+
+// dispatch handles zwp_tablet_v2 events.
+func (t *ZwpTabletV2) dispatch(msg *Message) error {
+	switch msg.Opcode {
+	case tabletEventName:
+		decoder := NewDecoder(msg.Args)
+		name, err := decoder.String()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_v2.name: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.name = name
+		t.mu.Unlock()
+		return nil
+	case tabletEventRemoved:
+		t.mu.Lock()
+		t.removed = true
+		t.mu.Unlock()
+		return t.Destroy()
+	default:
+		return nil
+	}
+}
+
+// ZwpTabletToolV2 represents the zwp_tablet_tool_v2 interface.
+// A tool is a physical stylus, eraser, or puck used on a tablet.
+type ZwpTabletToolV2 struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	// Static description (sent before done)
+	toolType     TabletToolType
+	capabilities []uint32
+
+	// Accumulated state for the current frame
+	frame TabletToolFrame
+
+	// Event handlers
+	onFrame   func(frame *TabletToolFrame)
+	onRemoved func()
+}
+
+// NewZwpTabletToolV2 creates a ZwpTabletToolV2 from an object ID.
+func NewZwpTabletToolV2(display *Display, objectID ObjectID) *ZwpTabletToolV2 {
+	t := &ZwpTabletToolV2{
+		display: display,
+		id:      objectID,
+	}
+	t.frame.Buttons = make(map[uint32]bool)
+	display.registerObject(objectID, t)
+	return t
+}
+
+// ID returns the object ID of the tool.
+func (t *ZwpTabletToolV2) ID() ObjectID {
+	return t.id
+}
+
+// Type returns the physical tool type.
+func (t *ZwpTabletToolV2) Type() TabletToolType {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.toolType
+}
+
+// HasCapability returns true if the tool reports the given capability.
+func (t *ZwpTabletToolV2) HasCapability(capability uint32) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, c := range t.capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFrameHandler sets a callback invoked with the accumulated state on each frame event.
+// The frame is only valid for the duration of the callback.
+func (t *ZwpTabletToolV2) SetFrameHandler(handler func(frame *TabletToolFrame)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onFrame = handler
+}
+
+// SetRemovedHandler sets a callback for the removed event.
+func (t *ZwpTabletToolV2) SetRemovedHandler(handler func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onRemoved = handler
+}
+
+// SetCursor sets the cursor image shown while the tool is in proximity.
+// The serial must be from the proximity_in event. Pass nil to hide the cursor.
+func (t *ZwpTabletToolV2) SetCursor(serial uint32, surface *WlSurface, hotspotX, hotspotY int32) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(serial)
+	if surface != nil {
+		builder.PutObject(surface.ID())
+	} else {
+		builder.PutObject(0)
+	}
+	builder.PutInt32(hotspotX)
+	builder.PutInt32(hotspotY)
+	msg := builder.BuildMessage(t.id, tabletToolSetCursor)
+
+	return t.display.SendMessage(msg)
+}
+
+// Destroy destroys the tool object.
+func (t *ZwpTabletToolV2) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(t.id, tabletToolDestroy)
+
+	return t.display.SendMessage(msg)
+}
+
+// dispatch handles zwp_tablet_tool_v2 events.
+func (t *ZwpTabletToolV2) dispatch(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	switch msg.Opcode {
+	case tabletToolEventType:
+		toolType, err := decoder.Uint32()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_tool_v2.type: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.toolType = TabletToolType(toolType)
+		t.frame.Type = t.toolType
+		t.mu.Unlock()
+
+	case tabletToolEventCapability:
+		capability, err := decoder.Uint32()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_tool_v2.capability: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.capabilities = append(t.capabilities, capability)
+		t.mu.Unlock()
+
+	case tabletToolEventRemoved:
+		t.mu.Lock()
+		handler := t.onRemoved
+		t.mu.Unlock()
+		if handler != nil {
+			handler()
+		}
+		return t.Destroy()
+
+	case tabletToolEventProximityIn:
+		return t.handleProximityIn(decoder)
+
+	case tabletToolEventProximityOut:
+		t.mu.Lock()
+		t.frame.ProximityOut = true
+		t.frame.InProximity = false
+		t.frame.Contact = false
+		t.frame.Surface = 0
+		t.mu.Unlock()
+
+	case tabletToolEventDown:
+		t.mu.Lock()
+		t.frame.Down = true
+		t.frame.Contact = true
+		t.mu.Unlock()
+
+	case tabletToolEventUp:
+		t.mu.Lock()
+		t.frame.Up = true
+		t.frame.Contact = false
+		t.mu.Unlock()
+
+	case tabletToolEventMotion:
+		return t.handleMotion(decoder)
+
+	case tabletToolEventPressure:
+		pressure, err := decoder.Uint32()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_tool_v2.pressure: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.frame.Pressure = float64(pressure) / 65535
+		t.mu.Unlock()
+
+	case tabletToolEventDistance:
+		distance, err := decoder.Uint32()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_tool_v2.distance: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.frame.Distance = float64(distance) / 65535
+		t.mu.Unlock()
+
+	case tabletToolEventTilt:
+		return t.handleTilt(decoder)
+
+	case tabletToolEventRotation:
+		degrees, err := decoder.Fixed()
+		if err != nil {
+			return fmt.Errorf("wayland: zwp_tablet_tool_v2.rotation: failed to decode: %w", err)
+		}
+		t.mu.Lock()
+		t.frame.Rotation = degrees.Float()
+		t.mu.Unlock()
+
+	case tabletToolEventButton:
+		return t.handleButton(decoder)
+
+	case tabletToolEventFrame:
+		return t.handleFrame(decoder)
+	}
+
+	return nil
+}
+
+// handleProximityIn handles the zwp_tablet_tool_v2.proximity_in event.
+func (t *ZwpTabletToolV2) handleProximityIn(decoder *Decoder) error {
+	if _, err := decoder.Uint32(); err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.proximity_in: failed to decode serial: %w", err)
+	}
+	if _, err := decoder.Object(); err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.proximity_in: failed to decode tablet: %w", err)
+	}
+	surface, err := decoder.Object()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.proximity_in: failed to decode surface: %w", err)
+	}
+
+	t.mu.Lock()
+	t.frame.ProximityIn = true
+	t.frame.InProximity = true
+	t.frame.Surface = surface
+	t.mu.Unlock()
+
+	return nil
+}
+
+// handleMotion handles the zwp_tablet_tool_v2.motion event.
+func (t *ZwpTabletToolV2) handleMotion(decoder *Decoder) error {
+	x, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.motion: failed to decode x: %w", err)
+	}
+	y, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.motion: failed to decode y: %w", err)
+	}
+
+	t.mu.Lock()
+	t.frame.X = x.Float()
+	t.frame.Y = y.Float()
+	t.mu.Unlock()
+
+	return nil
+}
+
+// handleTilt handles the zwp_tablet_tool_v2.tilt event.
+func (t *ZwpTabletToolV2) handleTilt(decoder *Decoder) error {
+	tiltX, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.tilt: failed to decode tilt_x: %w", err)
+	}
+	tiltY, err := decoder.Fixed()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.tilt: failed to decode tilt_y: %w", err)
+	}
+
+	t.mu.Lock()
+	t.frame.TiltX = tiltX.Float()
+	t.frame.TiltY = tiltY.Float()
+	t.mu.Unlock()
+
+	return nil
+}
+
+// handleButton handles the zwp_tablet_tool_v2.button event.
+func (t *ZwpTabletToolV2) handleButton(decoder *Decoder) error {
+	if _, err := decoder.Uint32(); err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.button: failed to decode serial: %w", err)
+	}
+	button, err := decoder.Uint32()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.button: failed to decode button: %w", err)
+	}
+	state, err := decoder.Uint32()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.button: failed to decode state: %w", err)
+	}
+
+	t.mu.Lock()
+	if state == PointerButtonStatePressed {
+		t.frame.Buttons[button] = true
+	} else {
+		delete(t.frame.Buttons, button)
+	}
+	t.mu.Unlock()
+
+	return nil
+}
+
+// handleFrame handles the zwp_tablet_tool_v2.frame event.
+func (t *ZwpTabletToolV2) handleFrame(decoder *Decoder) error {
+	time, err := decoder.Uint32()
+	if err != nil {
+		return fmt.Errorf("wayland: zwp_tablet_tool_v2.frame: failed to decode time: %w", err)
+	}
+
+	t.mu.Lock()
+	t.frame.Time = time
+	frame := t.frame
+	frame.Buttons = make(map[uint32]bool, len(t.frame.Buttons))
+	for b := range t.frame.Buttons {
+		frame.Buttons[b] = true
+	}
+	handler := t.onFrame
+
+	// Transitions are per-frame; state carries over.
+	t.frame.ProximityIn = false
+	t.frame.ProximityOut = false
+	t.frame.Down = false
+	t.frame.Up = false
+	t.mu.Unlock()
+
+	if handler != nil {
+		handler(&frame)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+//go:build linux
+
+package wayland
+
+import (
+	"testing"
+)
+
+// TestTabletToolEventOpcodes verifies zwp_tablet_tool_v2 event opcodes match the protocol spec.
+func TestTabletToolEventOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"type", tabletToolEventType, 0},
+		{"capability", tabletToolEventCapability, 3},
+		{"done", tabletToolEventDone, 4},
+		{"removed", tabletToolEventRemoved, 5},
+		{"proximity_in", tabletToolEventProximityIn, 6},
+		{"proximity_out", tabletToolEventProximityOut, 7},
+		{"down", tabletToolEventDown, 8},
+		{"up", tabletToolEventUp, 9},
+		{"motion", tabletToolEventMotion, 10},
+		{"pressure", tabletToolEventPressure, 11},
+		{"distance", tabletToolEventDistance, 12},
+		{"tilt", tabletToolEventTilt, 13},
+		{"rotation", tabletToolEventRotation, 14},
+		{"button", tabletToolEventButton, 17},
+		{"frame", tabletToolEventFrame, 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("event opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTabletSeatToolAdded verifies tool_added creates a tool and calls the handler.
+func TestTabletSeatToolAdded(t *testing.T) {
+	seat := NewZwpTabletSeatV2(nil, ObjectID(70))
+
+	var added *ZwpTabletToolV2
+	seat.SetToolAddedHandler(func(tool *ZwpTabletToolV2) {
+		added = tool
+	})
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(ObjectID(0xff000001))
+	if err := seat.dispatch(builder.BuildMessage(seat.ID(), tabletSeatEventToolAdded)); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+
+	if added == nil {
+		t.Fatal("tool_added handler not called")
+	}
+	if added.ID() != ObjectID(0xff000001) {
+		t.Errorf("tool ID = %#x, want 0xff000001", added.ID())
+	}
+	if len(seat.Tools()) != 1 {
+		t.Errorf("Tools() length = %d, want 1", len(seat.Tools()))
+	}
+}
+
+// TestTabletToolFrame verifies events are accumulated and delivered on frame.
+func TestTabletToolFrame(t *testing.T) {
+	tool := NewZwpTabletToolV2(nil, ObjectID(71))
+
+	var frames []TabletToolFrame
+	tool.SetFrameHandler(func(frame *TabletToolFrame) {
+		frames = append(frames, *frame)
+	})
+
+	send := func(opcode Opcode, build func(b *MessageBuilder)) {
+		t.Helper()
+		builder := NewMessageBuilder()
+		if build != nil {
+			build(builder)
+		}
+		if err := tool.dispatch(builder.BuildMessage(tool.ID(), opcode)); err != nil {
+			t.Fatalf("dispatch opcode %d failed: %v", opcode, err)
+		}
+	}
+
+	send(tabletToolEventType, func(b *MessageBuilder) { b.PutUint32(uint32(TabletToolTypePen)) })
+	send(tabletToolEventProximityIn, func(b *MessageBuilder) {
+		b.PutUint32(1)
+		b.PutObject(ObjectID(72))
+		b.PutObject(ObjectID(3))
+	})
+	send(tabletToolEventDown, func(b *MessageBuilder) { b.PutUint32(2) })
+	send(tabletToolEventMotion, func(b *MessageBuilder) {
+		b.PutFixed(FixedFromFloat(10.5))
+		b.PutFixed(FixedFromFloat(20.25))
+	})
+	send(tabletToolEventPressure, func(b *MessageBuilder) { b.PutUint32(65535) })
+	send(tabletToolEventTilt, func(b *MessageBuilder) {
+		b.PutFixed(FixedFromFloat(-30))
+		b.PutFixed(FixedFromFloat(15))
+	})
+	send(tabletToolEventButton, func(b *MessageBuilder) {
+		b.PutUint32(3)
+		b.PutUint32(TabletButtonStylus)
+		b.PutUint32(PointerButtonStatePressed)
+	})
+	send(tabletToolEventFrame, func(b *MessageBuilder) { b.PutUint32(1000) })
+
+	if len(frames) != 1 {
+		t.Fatalf("got %d frames, want 1", len(frames))
+	}
+
+	f := frames[0]
+	if !f.ProximityIn || !f.Down || !f.InProximity || !f.Contact {
+		t.Errorf("transitions = %+v, want proximity in + down", f)
+	}
+	if f.Type != TabletToolTypePen || f.Surface != ObjectID(3) || f.Time != 1000 {
+		t.Errorf("type/surface/time = %#x/%d/%d, want pen/3/1000", f.Type, f.Surface, f.Time)
+	}
+	if f.X != 10.5 || f.Y != 20.25 {
+		t.Errorf("position = (%v, %v), want (10.5, 20.25)", f.X, f.Y)
+	}
+	if f.Pressure != 1 {
+		t.Errorf("pressure = %v, want 1", f.Pressure)
+	}
+	if f.TiltX != -30 || f.TiltY != 15 {
+		t.Errorf("tilt = (%v, %v), want (-30, 15)", f.TiltX, f.TiltY)
+	}
+	if !f.Buttons[TabletButtonStylus] {
+		t.Error("stylus button not reported as pressed")
+	}
+
+	// Transitions reset after a frame, state persists
+	send(tabletToolEventUp, nil)
+	send(tabletToolEventFrame, func(b *MessageBuilder) { b.PutUint32(1016) })
+
+	f = frames[1]
+	if f.ProximityIn || f.Down || !f.Up || f.Contact || !f.InProximity {
+		t.Errorf("second frame = %+v, want only up with proximity kept", f)
+	}
+}
@@ -0,0 +1,182 @@
+//go:build linux
+
+package wayland
+
+import (
+	"fmt"
+	"sync"
+)
+
+// xdg_activation_v1 opcodes (requests)
+const (
+	activationDestroy            Opcode = 0 // destroy()
+	activationGetActivationToken Opcode = 1 // get_activation_token(id: new_id<xdg_activation_token_v1>)
+	activationActivate           Opcode = 2 // activate(token: string, surface: object<wl_surface>)
+)
+
+// xdg_activation_token_v1 opcodes (requests)
+const (
+	activationTokenSetSerial  Opcode = 0 // set_serial(serial: uint, seat: object<wl_seat>)
+	activationTokenSetAppID   Opcode = 1 // set_app_id(app_id: string)
+	activationTokenSetSurface Opcode = 2 // set_surface(surface: object<wl_surface>)
+	activationTokenCommit     Opcode = 3 // commit()
+	activationTokenDestroy    Opcode = 4 // destroy()
+)
+
+// xdg_activation_token_v1 opcodes (events)
+const (
+	activationTokenEventDone Opcode = 0 // done(token: string)
+)
+
+// XdgActivationV1 represents the xdg_activation_v1 interface. It lets a
+// client ask for one of its surfaces to be activated (focused). The
+// compositor decides from the token whether to activate the surface or,
+// e.g. for a token without a recent input serial, only to mark it as
+// demanding attention.
+type XdgActivationV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewXdgActivationV1 creates an XdgActivationV1 from a bound object ID.
+// The objectID should be obtained from Registry.BindXdgActivation().
+func NewXdgActivationV1(display *Display, objectID ObjectID) *XdgActivationV1 {
+	return &XdgActivationV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the activation global.
+func (a *XdgActivationV1) ID() ObjectID {
+	return a.id
+}
+
+// GetActivationToken creates an activation token object. The token is
+// described with its setters, then requested with Commit.
+func (a *XdgActivationV1) GetActivationToken() (*XdgActivationTokenV1, error) {
+	tokenID := a.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(tokenID)
+	msg := builder.BuildMessage(a.id, activationGetActivationToken)
+
+	if err := a.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewXdgActivationTokenV1(a.display, tokenID), nil
+}
+
+// Activate asks for surface to be activated with a token from the done
+// event of an activation token.
+func (a *XdgActivationV1) Activate(token string, surface *WlSurface) error {
+	builder := NewMessageBuilder()
+	builder.PutString(token)
+	builder.PutObject(surface.ID())
+	msg := builder.BuildMessage(a.id, activationActivate)
+
+	return a.display.SendMessage(msg)
+}
+
+// Destroy destroys the activation global object.
+// Existing activation tokens are not affected.
+func (a *XdgActivationV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(a.id, activationDestroy)
+
+	return a.display.SendMessage(msg)
+}
+
+// XdgActivationTokenV1 represents the xdg_activation_token_v1 interface,
+// a request for an activation token.
+type XdgActivationTokenV1 struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	onDone func(token string)
+}
+
+// NewXdgActivationTokenV1 creates an XdgActivationTokenV1 from an object ID.
+func NewXdgActivationTokenV1(display *Display, objectID ObjectID) *XdgActivationTokenV1 {
+	t := &XdgActivationTokenV1{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, t)
+	return t
+}
+
+// ID returns the object ID of the activation token.
+func (t *XdgActivationTokenV1) ID() ObjectID {
+	return t.id
+}
+
+// SetSerial sets the serial of the input event that caused the request,
+// and the seat it came from.
+func (t *XdgActivationTokenV1) SetSerial(serial uint32, seat *WlSeat) error {
+	builder := NewMessageBuilder()
+	builder.PutUint32(serial)
+	builder.PutObject(seat.ID())
+	msg := builder.BuildMessage(t.id, activationTokenSetSerial)
+
+	return t.display.SendMessage(msg)
+}
+
+// SetSurface sets the surface requesting the activation.
+func (t *XdgActivationTokenV1) SetSurface(surface *WlSurface) error {
+	builder := NewMessageBuilder()
+	builder.PutObject(surface.ID())
+	msg := builder.BuildMessage(t.id, activationTokenSetSurface)
+
+	return t.display.SendMessage(msg)
+}
+
+// Commit requests the token. It arrives with the done event.
+func (t *XdgActivationTokenV1) Commit() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(t.id, activationTokenCommit)
+
+	return t.display.SendMessage(msg)
+}
+
+// Destroy destroys the activation token object. A token already
+// received stays valid.
+func (t *XdgActivationTokenV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(t.id, activationTokenDestroy)
+
+	return t.display.SendMessage(msg)
+}
+
+// SetDoneHandler sets a callback for the done event, which carries the
+// token.
+func (t *XdgActivationTokenV1) SetDoneHandler(handler func(token string)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onDone = handler
+}
+
+// dispatch handles xdg_activation_token_v1 events.
+func (t *XdgActivationTokenV1) dispatch(msg *Message) error {
+	if msg.Opcode != activationTokenEventDone {
+		return nil
+	}
+
+	decoder := NewDecoder(msg.Args)
+	token, err := decoder.String()
+	if err != nil {
+		return fmt.Errorf("wayland: xdg_activation_token_v1.done: failed to decode token: %w", err)
+	}
+
+	t.mu.Lock()
+	handler := t.onDone
+	t.mu.Unlock()
+
+	if handler != nil {
+		handler(token)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build linux
+
+package wayland
+
+import (
+	"testing"
+)
+
+// TestXdgActivationOpcodes verifies xdg-activation-v1 opcode constants match the protocol spec.
+func TestXdgActivationOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"activation.destroy", activationDestroy, 0},
+		{"activation.get_activation_token", activationGetActivationToken, 1},
+		{"activation.activate", activationActivate, 2},
+		{"token.set_serial", activationTokenSetSerial, 0},
+		{"token.set_app_id", activationTokenSetAppID, 1},
+		{"token.set_surface", activationTokenSetSurface, 2},
+		{"token.commit", activationTokenCommit, 3},
+		{"token.destroy", activationTokenDestroy, 4},
+		{"token.done", activationTokenEventDone, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestXdgActivationTokenDone verifies that the done event delivers the token.
+func TestXdgActivationTokenDone(t *testing.T) {
+	token := NewXdgActivationTokenV1(nil, ObjectID(50))
+
+	var got string
+	token.SetDoneHandler(func(value string) { got = value })
+
+	builder := NewMessageBuilder()
+	builder.PutString("token-1234")
+	if err := token.dispatch(builder.BuildMessage(token.ID(), activationTokenEventDone)); err != nil {
+		t.Fatal(err)
+	}
+	if got != "token-1234" {
+		t.Errorf("token = %q, want %q", got, "token-1234")
+	}
+}
@@ -70,6 +70,7 @@ const (
 	XdgToplevelStateTiledRight  uint32 = 6 // Window is tiled on right edge
 	XdgToplevelStateTiledTop    uint32 = 7 // Window is tiled on top edge
 	XdgToplevelStateTiledBottom uint32 = 8 // Window is tiled on bottom edge
+	XdgToplevelStateSuspended   uint32 = 9 // Window is not visible, since version 6
 )
 
 // xdg_positioner opcodes (requests)
@@ -102,10 +103,12 @@ type XdgWmBase struct {
 // NewXdgWmBase creates an XdgWmBase from a bound object ID.
 // The objectID should be obtained from Registry.BindXdgWmBase().
 func NewXdgWmBase(display *Display, objectID ObjectID) *XdgWmBase {
-	return &XdgWmBase{
+	w := &XdgWmBase{
 		display: display,
 		id:      objectID,
 	}
+	display.registerObject(objectID, w)
+	return w
 }
 
 // ID returns the object ID of the xdg_wm_base.
@@ -231,11 +234,13 @@ type XdgSurface struct {
 
 // NewXdgSurface creates an XdgSurface from an object ID.
 func NewXdgSurface(display *Display, objectID ObjectID, surface *WlSurface) *XdgSurface {
-	return &XdgSurface{
+	s := &XdgSurface{
 		display: display,
 		id:      objectID,
 		surface: surface,
 	}
+	display.registerObject(objectID, s)
+	return s
 }
 
 // ID returns the object ID of the xdg_surface.
@@ -390,6 +395,7 @@ type XdgToplevelConfig struct {
 	TiledRight  bool
 	TiledTop    bool
 	TiledBottom bool
+	Suspended   bool
 }
 
 // parseStates parses the states array and sets helper booleans.
@@ -412,6 +418,8 @@ func (c *XdgToplevelConfig) parseStates() {
 			c.TiledTop = true
 		case XdgToplevelStateTiledBottom:
 			c.TiledBottom = true
+		case XdgToplevelStateSuspended:
+			c.Suspended = true
 		}
 	}
 }
@@ -436,11 +444,13 @@ type XdgToplevel struct {
 
 // NewXdgToplevel creates an XdgToplevel from an object ID.
 func NewXdgToplevel(display *Display, objectID ObjectID, xdgSurface *XdgSurface) *XdgToplevel {
-	return &XdgToplevel{
+	t := &XdgToplevel{
 		display:    display,
 		id:         objectID,
 		xdgSurface: xdgSurface,
 	}
+	display.registerObject(objectID, t)
+	return t
 }
 
 // ID returns the object ID of the xdg_toplevel.
@@ -775,11 +785,13 @@ const (
 
 // NewXdgPopup creates an XdgPopup from an object ID.
 func NewXdgPopup(display *Display, objectID ObjectID, xdgSurface *XdgSurface) *XdgPopup {
-	return &XdgPopup{
+	p := &XdgPopup{
 		display:    display,
 		id:         objectID,
 		xdgSurface: xdgSurface,
 	}
+	display.registerObject(objectID, p)
+	return p
 }
 
 // ID returns the object ID of the xdg_popup.
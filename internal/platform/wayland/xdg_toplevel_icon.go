@@ -0,0 +1,217 @@
+//go:build linux
+
+package wayland
+
+import (
+	"fmt"
+	"sync"
+)
+
+// xdg_toplevel_icon_manager_v1 opcodes (requests)
+const (
+	toplevelIconManagerDestroy    Opcode = 0 // destroy()
+	toplevelIconManagerCreateIcon Opcode = 1 // create_icon(id: new_id<xdg_toplevel_icon_v1>)
+	toplevelIconManagerSetIcon    Opcode = 2 // set_icon(toplevel: object<xdg_toplevel>, icon: object<xdg_toplevel_icon_v1, allow-null>)
+)
+
+// xdg_toplevel_icon_manager_v1 event opcodes
+const (
+	toplevelIconManagerEventIconSize Opcode = 0 // icon_size(size: int)
+	toplevelIconManagerEventDone     Opcode = 1 // done()
+)
+
+// xdg_toplevel_icon_v1 opcodes (requests)
+const (
+	toplevelIconDestroy   Opcode = 0 // destroy()
+	toplevelIconSetName   Opcode = 1 // set_name(icon_name: string)
+	toplevelIconAddBuffer Opcode = 2 // add_buffer(buffer: object<wl_buffer>, scale: int)
+)
+
+// XdgToplevelIconManagerV1 represents the xdg_toplevel_icon_manager_v1 interface.
+// It creates icon objects and assigns them to toplevel windows.
+type XdgToplevelIconManagerV1 struct {
+	display *Display
+	id      ObjectID
+
+	mu sync.Mutex
+
+	// Preferred icon sizes advertised by the compositor
+	sizes        []int32
+	pendingSizes []int32
+
+	// Event handlers
+	onDone func(sizes []int32)
+}
+
+// NewXdgToplevelIconManagerV1 creates an XdgToplevelIconManagerV1 from a bound object ID.
+// The objectID should be obtained from Registry.BindToplevelIconManager().
+func NewXdgToplevelIconManagerV1(display *Display, objectID ObjectID) *XdgToplevelIconManagerV1 {
+	m := &XdgToplevelIconManagerV1{
+		display: display,
+		id:      objectID,
+	}
+	display.registerObject(objectID, m)
+	return m
+}
+
+// ID returns the object ID of the icon manager.
+func (m *XdgToplevelIconManagerV1) ID() ObjectID {
+	return m.id
+}
+
+// CreateIcon creates a new, empty icon object.
+// Populate it with SetName and/or AddBuffer before passing it to SetIcon.
+func (m *XdgToplevelIconManagerV1) CreateIcon() (*XdgToplevelIconV1, error) {
+	iconID := m.display.AllocID()
+
+	builder := NewMessageBuilder()
+	builder.PutNewID(iconID)
+	msg := builder.BuildMessage(m.id, toplevelIconManagerCreateIcon)
+
+	if err := m.display.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return NewXdgToplevelIconV1(m.display, iconID), nil
+}
+
+// SetIcon assigns an icon to a toplevel window.
+// The icon becomes immutable once set. Pass nil to reset to the default icon.
+// The change is applied on the next wl_surface.commit of the toplevel.
+func (m *XdgToplevelIconManagerV1) SetIcon(toplevel *XdgToplevel, icon *XdgToplevelIconV1) error {
+	var iconID ObjectID
+	if icon != nil {
+		iconID = icon.ID()
+	}
+
+	builder := NewMessageBuilder()
+	builder.PutObject(toplevel.ID())
+	builder.PutObject(iconID)
+	msg := builder.BuildMessage(m.id, toplevelIconManagerSetIcon)
+
+	return m.display.SendMessage(msg)
+}
+
+// Destroy destroys the icon manager.
+// Icons already assigned to toplevels are not affected.
+func (m *XdgToplevelIconManagerV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(m.id, toplevelIconManagerDestroy)
+
+	return m.display.SendMessage(msg)
+}
+
+// Sizes returns a copy of the preferred icon sizes advertised by the compositor.
+// An empty result means the compositor has no preference.
+func (m *XdgToplevelIconManagerV1) Sizes() []int32 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]int32, len(m.sizes))
+	copy(result, m.sizes)
+	return result
+}
+
+// SetDoneHandler sets a callback for the done event.
+// The handler receives the complete list of preferred icon sizes.
+func (m *XdgToplevelIconManagerV1) SetDoneHandler(handler func(sizes []int32)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onDone = handler
+}
+
+// dispatch handles xdg_toplevel_icon_manager_v1 events.
+func (m *XdgToplevelIconManagerV1) dispatch(msg *Message) error {
+	switch msg.Opcode {
+	case toplevelIconManagerEventIconSize:
+		return m.handleIconSize(msg)
+	case toplevelIconManagerEventDone:
+		return m.handleDone()
+	default:
+		return nil
+	}
+}
+
+// handleIconSize handles the xdg_toplevel_icon_manager_v1.icon_size event.
+func (m *XdgToplevelIconManagerV1) handleIconSize(msg *Message) error {
+	decoder := NewDecoder(msg.Args)
+
+	size, err := decoder.Int32()
+	if err != nil {
+		return fmt.Errorf("wayland: xdg_toplevel_icon_manager_v1.icon_size: failed to decode size: %w", err)
+	}
+
+	m.mu.Lock()
+	m.pendingSizes = append(m.pendingSizes, size)
+	m.mu.Unlock()
+
+	return nil
+}
+
+// handleDone handles the xdg_toplevel_icon_manager_v1.done event.
+func (m *XdgToplevelIconManagerV1) handleDone() error {
+	m.mu.Lock()
+	m.sizes = m.pendingSizes
+	m.pendingSizes = nil
+	sizes := make([]int32, len(m.sizes))
+	copy(sizes, m.sizes)
+	handler := m.onDone
+	m.mu.Unlock()
+
+	if handler != nil {
+		handler(sizes)
+	}
+
+	return nil
+}
+
+// XdgToplevelIconV1 represents the xdg_toplevel_icon_v1 interface.
+// An icon is built from a themed icon name and/or square pixel buffers.
+type XdgToplevelIconV1 struct {
+	display *Display
+	id      ObjectID
+}
+
+// NewXdgToplevelIconV1 creates an XdgToplevelIconV1 from an object ID.
+func NewXdgToplevelIconV1(display *Display, objectID ObjectID) *XdgToplevelIconV1 {
+	return &XdgToplevelIconV1{
+		display: display,
+		id:      objectID,
+	}
+}
+
+// ID returns the object ID of the icon.
+func (i *XdgToplevelIconV1) ID() ObjectID {
+	return i.id
+}
+
+// SetName sets a themed icon name (freedesktop icon theme specification).
+// Compositors prefer the named icon over buffers when both are provided.
+func (i *XdgToplevelIconV1) SetName(name string) error {
+	builder := NewMessageBuilder()
+	builder.PutString(name)
+	msg := builder.BuildMessage(i.id, toplevelIconSetName)
+
+	return i.display.SendMessage(msg)
+}
+
+// AddBuffer adds a pixel buffer to the icon.
+// The buffer must be a square wl_shm buffer in ARGB8888 format.
+// The scale is the buffer scale factor the image was rendered for.
+func (i *XdgToplevelIconV1) AddBuffer(buffer *WlBuffer, scale int32) error {
+	builder := NewMessageBuilder()
+	builder.PutObject(buffer.ID())
+	builder.PutInt32(scale)
+	msg := builder.BuildMessage(i.id, toplevelIconAddBuffer)
+
+	return i.display.SendMessage(msg)
+}
+
+// Destroy destroys the icon object.
+// Toplevels the icon was assigned to keep showing it.
+func (i *XdgToplevelIconV1) Destroy() error {
+	builder := NewMessageBuilder()
+	msg := builder.BuildMessage(i.id, toplevelIconDestroy)
+
+	return i.display.SendMessage(msg)
+}
@@ -0,0 +1,112 @@
+//go:build linux
+
+package wayland
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestToplevelIconOpcodes verifies xdg-toplevel-icon-v1 opcode constants match the protocol spec.
+func TestToplevelIconOpcodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opcode   Opcode
+		expected Opcode
+	}{
+		{"manager.destroy", toplevelIconManagerDestroy, 0},
+		{"manager.create_icon", toplevelIconManagerCreateIcon, 1},
+		{"manager.set_icon", toplevelIconManagerSetIcon, 2},
+		{"manager.icon_size", toplevelIconManagerEventIconSize, 0},
+		{"manager.done", toplevelIconManagerEventDone, 1},
+		{"destroy", toplevelIconDestroy, 0},
+		{"set_name", toplevelIconSetName, 1},
+		{"add_buffer", toplevelIconAddBuffer, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opcode != tt.expected {
+				t.Errorf("opcode %s = %d, want %d", tt.name, tt.opcode, tt.expected)
+			}
+		})
+	}
+}
+
+// TestToplevelIconManagerDispatch verifies icon_size events are collected until done.
+func TestToplevelIconManagerDispatch(t *testing.T) {
+	manager := NewXdgToplevelIconManagerV1(nil, ObjectID(40))
+
+	var got []int32
+	manager.SetDoneHandler(func(sizes []int32) {
+		got = sizes
+	})
+
+	for _, size := range []int32{32, 64} {
+		builder := NewMessageBuilder()
+		builder.PutInt32(size)
+		if err := manager.dispatch(builder.BuildMessage(manager.ID(), toplevelIconManagerEventIconSize)); err != nil {
+			t.Fatalf("dispatch icon_size failed: %v", err)
+		}
+	}
+
+	if len(manager.Sizes()) != 0 {
+		t.Errorf("Sizes() before done = %v, want empty", manager.Sizes())
+	}
+
+	if err := manager.dispatch(NewMessageBuilder().BuildMessage(manager.ID(), toplevelIconManagerEventDone)); err != nil {
+		t.Fatalf("dispatch done failed: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != 32 || got[1] != 64 {
+		t.Errorf("done handler sizes = %v, want [32 64]", got)
+	}
+	if sizes := manager.Sizes(); len(sizes) != 2 {
+		t.Errorf("Sizes() = %v, want [32 64]", sizes)
+	}
+}
+
+// TestToplevelIconAddBufferMessage verifies the message format for add_buffer.
+func TestToplevelIconAddBufferMessage(t *testing.T) {
+	builder := NewMessageBuilder()
+	builder.PutObject(ObjectID(50))
+	builder.PutInt32(2)
+	msg := builder.BuildMessage(ObjectID(41), toplevelIconAddBuffer)
+
+	dec := NewDecoder(msg.Args)
+
+	buffer, err := dec.Object()
+	if err != nil {
+		t.Fatalf("failed to decode buffer: %v", err)
+	}
+	if buffer != ObjectID(50) {
+		t.Errorf("buffer = %d, want 50", buffer)
+	}
+
+	scale, err := dec.Int32()
+	if err != nil {
+		t.Fatalf("failed to decode scale: %v", err)
+	}
+	if scale != 2 {
+		t.Errorf("scale = %d, want 2", scale)
+	}
+}
+
+// TestRGBAToARGB8888 verifies channel reordering for wl_shm image buffers.
+func TestRGBAToARGB8888(t *testing.T) {
+	src := []byte{
+		0x11, 0x22, 0x33, 0xFF, // opaque pixel
+		0x08, 0x10, 0x18, 0x80, // half-transparent premultiplied pixel
+	}
+	want := []byte{
+		0x33, 0x22, 0x11, 0xFF,
+		0x18, 0x10, 0x08, 0x80,
+	}
+
+	dst := make([]byte, len(src))
+	rgbaToARGB8888(dst, src)
+
+	if !bytes.Equal(dst, want) {
+		t.Errorf("rgbaToARGB8888() = % x, want % x", dst, want)
+	}
+}
@@ -0,0 +1,131 @@
+//go:build js && wasm
+
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+// jsCanvas is the canvas of an additional window opened with
+// CreateWindow.
+type jsCanvas struct {
+	canvas  js.Value
+	created bool // The canvas was created by CreateWindow and is removed by DestroyWindow
+
+	// Fixed CSS size from the config; 0 follows the page layout
+	cssWidth  int
+	cssHeight int
+
+	width  int
+	height int
+
+	// Event listeners, removed by DestroyWindow
+	listeners []listener
+}
+
+// windowCanvasID returns the element id of the canvas of a window. The
+// browser backend looks it up by the window handle, see GetHandle.
+func windowCanvasID(id WindowID) string {
+	return "gogpu-canvas-" + strconv.FormatUint(uint64(windowHandle(id)), 10)
+}
+
+// windowHandle returns the window handle of a window: canvasWindow for
+// the main window, and the ones following it for additional windows.
+func windowHandle(id WindowID) uintptr {
+	return canvasWindow + uintptr(id)
+}
+
+// CreateWindow adds a canvas to the page for an additional window. A
+// page may provide the canvas itself with the id "gogpu-canvas-<n>",
+// where n is the WindowID plus one; otherwise one of the config's size
+// is appended to the page. The title is the canvas's tooltip.
+func (p *jsPlatform) CreateWindow(config Config) (WindowID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := p.nextWindowID + 1
+	document := js.Global().Get("document")
+	c := &jsCanvas{canvas: document.Call("getElementById", windowCanvasID(id))}
+	if !c.canvas.Truthy() {
+		if config.Width <= 0 || config.Height <= 0 {
+			return 0, fmt.Errorf("platform: invalid window size %dx%d", config.Width, config.Height)
+		}
+		c.canvas = document.Call("createElement", "canvas")
+		c.canvas.Set("id", windowCanvasID(id))
+		c.canvas.Get("style").Set("display", "block")
+		document.Get("body").Call("appendChild", c.canvas)
+		c.created = true
+	}
+	if config.Fullscreen {
+		style := c.canvas.Get("style")
+		style.Set("width", "100vw")
+		style.Set("height", "100vh")
+	} else if config.Width > 0 && config.Height > 0 {
+		c.cssWidth, c.cssHeight = config.Width, config.Height
+		style := c.canvas.Get("style")
+		style.Set("width", strconv.Itoa(config.Width)+"px")
+		style.Set("height", strconv.Itoa(config.Height)+"px")
+	}
+	if config.Title != "" {
+		c.canvas.Set("title", config.Title)
+	}
+
+	// Key events only reach focusable elements
+	c.canvas.Set("tabIndex", 0)
+	c.width, c.height = canvasPixelSize(c.canvas, c.cssWidth, c.cssHeight, p.scale)
+	c.listeners = p.listenInput(c.canvas, id)
+
+	p.nextWindowID = id
+	if p.windows == nil {
+		p.windows = make(map[WindowID]*jsCanvas)
+	}
+	p.windows[id] = c
+	return id, nil
+}
+
+// DestroyWindow removes the event listeners of a window opened with
+// CreateWindow, and its canvas if CreateWindow added it.
+func (p *jsPlatform) DestroyWindow(id WindowID) {
+	p.mu.Lock()
+	c, ok := p.windows[id]
+	delete(p.windows, id)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	for _, l := range c.listeners {
+		l.target.Call("removeEventListener", l.event, l.fn)
+		l.fn.Release()
+	}
+	if c.created {
+		c.canvas.Call("remove")
+	}
+}
+
+// WindowSize returns the canvas size of a window in device pixels.
+func (p *jsPlatform) WindowSize(id WindowID) (width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id == MainWindow {
+		return p.width, p.height
+	}
+	if c, ok := p.windows[id]; ok {
+		return c.width, c.height
+	}
+	return 0, 0
+}
+
+// WindowHandle returns the surface handles of a window, like GetHandle.
+func (p *jsPlatform) WindowHandle(id WindowID) (instance, window uintptr) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.windows[id]; !ok && id != MainWindow {
+		return 0, 0
+	}
+	return 0, windowHandle(id)
+}
@@ -0,0 +1,227 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+)
+
+// waylandWindow is an additional toplevel opened with CreateWindow.
+type waylandWindow struct {
+	surface    *wayland.WlSurface
+	xdgSurface *wayland.XdgSurface
+	toplevel   *wayland.XdgToplevel
+
+	width      int
+	height     int
+	configured bool
+}
+
+// CreateWindow opens an additional toplevel with a wl_surface of its
+// own. Its resizes and close requests are queued with its WindowID, as
+// are input events while it has the pointer or keyboard focus.
+func (p *waylandPlatform) CreateWindow(config Config) (WindowID, error) {
+	p.mu.Lock()
+	if p.compositor == nil || p.xdgWmBase == nil {
+		p.mu.Unlock()
+		return 0, ErrUnsupported
+	}
+
+	w := &waylandWindow{width: config.Width, height: config.Height}
+	if err := p.createToplevel(w, config); err != nil {
+		p.destroyToplevel(w)
+		p.mu.Unlock()
+		return 0, err
+	}
+
+	p.nextWindowID++
+	id := p.nextWindowID
+	if p.windows == nil {
+		p.windows = make(map[WindowID]*waylandWindow)
+	}
+	p.windows[id] = w
+	p.setupWindowHandlers(id, w)
+
+	// Commit to signal we're ready for configure
+	err := w.surface.Commit()
+	p.mu.Unlock()
+	if err == nil {
+		err = p.waitForWindowConfigure(w)
+	}
+	if err != nil {
+		p.DestroyWindow(id)
+		return 0, fmt.Errorf("wayland: failed to configure window: %w", err)
+	}
+
+	if config.Fullscreen {
+		_ = w.toplevel.SetFullscreen(0) // Non-fatal, continue
+	}
+	return id, nil
+}
+
+// createToplevel creates the surface and toplevel of w.
+// Must be called with p.mu held.
+func (p *waylandPlatform) createToplevel(w *waylandWindow, config Config) error {
+	surface, err := p.compositor.CreateSurface()
+	if err != nil {
+		return fmt.Errorf("wayland: failed to create surface: %w", err)
+	}
+	w.surface = surface
+
+	xdgSurface, err := p.xdgWmBase.GetXdgSurface(surface)
+	if err != nil {
+		return fmt.Errorf("wayland: failed to create xdg_surface: %w", err)
+	}
+	w.xdgSurface = xdgSurface
+
+	toplevel, err := xdgSurface.GetToplevel()
+	if err != nil {
+		return fmt.Errorf("wayland: failed to create toplevel: %w", err)
+	}
+	w.toplevel = toplevel
+
+	if err := toplevel.SetTitle(config.Title); err != nil {
+		return fmt.Errorf("wayland: failed to set title: %w", err)
+	}
+	if err := toplevel.SetAppID("gogpu"); err != nil {
+		return fmt.Errorf("wayland: failed to set app_id: %w", err)
+	}
+	if !config.Resizable {
+		if err := toplevel.SetMinSize(int32(config.Width), int32(config.Height)); err != nil {
+			return fmt.Errorf("wayland: failed to set min size: %w", err)
+		}
+		if err := toplevel.SetMaxSize(int32(config.Width), int32(config.Height)); err != nil {
+			return fmt.Errorf("wayland: failed to set max size: %w", err)
+		}
+	}
+	return nil
+}
+
+// setupWindowHandlers queues the resizes and close requests of window id.
+func (p *waylandPlatform) setupWindowHandlers(id WindowID, w *waylandWindow) {
+	w.xdgSurface.SetConfigureHandler(func(serial uint32) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if err := w.xdgSurface.AckConfigure(serial); err != nil {
+			return
+		}
+		if err := w.surface.Commit(); err != nil {
+			return
+		}
+		w.configured = true
+	})
+
+	w.toplevel.SetConfigureHandler(func(config *wayland.XdgToplevelConfig) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// Width/height of 0 means client can choose
+		width, height := int(config.Width), int(config.Height)
+		if width > 0 && height > 0 && (width != w.width || height != w.height) {
+			w.width, w.height = width, height
+			p.events = append(p.events, Event{Type: EventResize, Window: id, Width: width, Height: height})
+		}
+	})
+
+	w.toplevel.SetCloseHandler(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.events = append(p.events, Event{Type: EventClose, Window: id})
+	})
+}
+
+// waitForWindowConfigure waits for the initial configure event of w.
+func (p *waylandPlatform) waitForWindowConfigure(w *waylandWindow) error {
+	for i := 0; i < 10; i++ {
+		if err := p.display.Roundtrip(); err != nil {
+			return fmt.Errorf("roundtrip failed: %w", err)
+		}
+
+		p.mu.Lock()
+		configured := w.configured
+		p.mu.Unlock()
+
+		if configured {
+			return nil
+		}
+	}
+	return fmt.Errorf("timeout waiting for configure")
+}
+
+// DestroyWindow closes a window opened with CreateWindow.
+func (p *waylandPlatform) DestroyWindow(id WindowID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w, ok := p.windows[id]; ok {
+		p.destroyToplevel(w)
+		delete(p.windows, id)
+	}
+	if p.pointerWindow == id {
+		p.pointerWindow = MainWindow
+	}
+	if p.keyboardWindow == id {
+		p.keyboardWindow = MainWindow
+	}
+}
+
+// destroyToplevel destroys the objects of w in reverse order of creation.
+// Must be called with p.mu held.
+func (p *waylandPlatform) destroyToplevel(w *waylandWindow) {
+	if w.toplevel != nil {
+		_ = w.toplevel.Destroy()
+		w.toplevel = nil
+	}
+	if w.xdgSurface != nil {
+		_ = w.xdgSurface.Destroy()
+		w.xdgSurface = nil
+	}
+	if w.surface != nil {
+		_ = w.surface.Destroy()
+		w.surface = nil
+	}
+}
+
+// WindowSize returns the size of a window in pixels.
+func (p *waylandPlatform) WindowSize(id WindowID) (width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id == MainWindow {
+		return p.width, p.height
+	}
+	if w, ok := p.windows[id]; ok {
+		return w.width, w.height
+	}
+	return 0, 0
+}
+
+// WindowHandle returns the surface handles of a window, like GetHandle.
+func (p *waylandPlatform) WindowHandle(id WindowID) (instance, window uintptr) {
+	if id == MainWindow {
+		return p.GetHandle()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.windows[id]
+	if !ok || p.display == nil {
+		return 0, 0
+	}
+	return p.display.Ptr(), w.surface.Ptr()
+}
+
+// surfaceWindow returns the window of a wl_surface, or MainWindow for
+// surfaces of no additional window. Must be called with p.mu held.
+func (p *waylandPlatform) surfaceWindow(surface wayland.ObjectID) WindowID {
+	for id, w := range p.windows {
+		if w.surface != nil && w.surface.ID() == surface {
+			return id
+		}
+	}
+	return MainWindow
+}
@@ -0,0 +1,187 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// windowClassName is the window class of the main and additional windows,
+// registered by Init.
+const windowClassName = "GoGPUWindow"
+
+// win32Window is an additional window opened with CreateWindow. Its
+// state is used on the window's thread.
+type win32Window struct {
+	id            WindowID
+	hwnd          windows.HWND
+	width, height int
+	cursor        win32Cursor
+}
+
+// CreateWindow opens an additional window with the window class of the
+// main window. Like the main window, its size is scaled to the DPI of
+// its monitor.
+func (p *windowsPlatform) CreateWindow(config Config) (WindowID, error) {
+	if p.hwnd == 0 {
+		return 0, fmt.Errorf("platform: window not created")
+	}
+
+	className, err := windows.UTF16PtrFromString(windowClassName)
+	if err != nil {
+		return 0, fmt.Errorf("utf16 class name: %w", err)
+	}
+	titlePtr, err := windows.UTF16PtrFromString(config.Title)
+	if err != nil {
+		return 0, fmt.Errorf("utf16 title: %w", err)
+	}
+
+	style := uintptr(wsOverlappedWindow)
+	hwnd, _, _ := procCreateWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(className)),
+		uintptr(unsafe.Pointer(titlePtr)),
+		style,
+		uintptr(cwUseDefault),
+		uintptr(cwUseDefault),
+		uintptr(config.Width),
+		uintptr(config.Height),
+		0, 0,
+		uintptr(p.hinstance),
+		0,
+	)
+	if hwnd == 0 {
+		return 0, fmt.Errorf("CreateWindowExW failed")
+	}
+
+	p.nextWindowID++
+	w := &win32Window{id: p.nextWindowID, hwnd: windows.HWND(hwnd)}
+	if p.windows == nil {
+		p.windows = make(map[windows.HWND]*win32Window)
+	}
+	p.windows[w.hwnd] = w
+
+	dpi := windowDPI(w.hwnd)
+	setClientSize(w.hwnd, scaleToDPI(config.Width, dpi), scaleToDPI(config.Height, dpi), style, dpi)
+	procShowWindow.Call(hwnd, swShowNormal)
+	procUpdateWindow.Call(hwnd)
+	w.updateSize()
+
+	return w.id, nil
+}
+
+// DestroyWindow closes a window opened with CreateWindow.
+func (p *windowsPlatform) DestroyWindow(id WindowID) {
+	if w := p.windowByID(id); w != nil {
+		delete(p.windows, w.hwnd)
+		procDestroyWindow.Call(uintptr(w.hwnd))
+		w.cursor.release()
+	}
+}
+
+// WindowSize returns the client size of a window in pixels.
+func (p *windowsPlatform) WindowSize(id WindowID) (width, height int) {
+	if id == MainWindow {
+		return p.width, p.height
+	}
+	if w := p.windowByID(id); w != nil {
+		return w.width, w.height
+	}
+	return 0, 0
+}
+
+// WindowHandle returns the HINSTANCE and HWND of a window.
+func (p *windowsPlatform) WindowHandle(id WindowID) (instance, window uintptr) {
+	if id == MainWindow {
+		return p.GetHandle()
+	}
+	if w := p.windowByID(id); w != nil {
+		return uintptr(p.hinstance), uintptr(w.hwnd)
+	}
+	return 0, 0
+}
+
+// windowByID returns the additional window with id, or nil.
+func (p *windowsPlatform) windowByID(id WindowID) *win32Window {
+	for _, w := range p.windows {
+		if w.id == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// destroyWindows closes the additional windows still open.
+func (p *windowsPlatform) destroyWindows() {
+	for hwnd, w := range p.windows {
+		procDestroyWindow.Call(uintptr(hwnd))
+		w.cursor.release()
+	}
+	clear(p.windows)
+}
+
+// updateSize reads the client size of the window.
+func (w *win32Window) updateSize() {
+	var r rect
+	procGetClientRect.Call(uintptr(w.hwnd), uintptr(unsafe.Pointer(&r)))
+	w.width = int(r.right - r.left)
+	w.height = int(r.bottom - r.top)
+}
+
+// windowProc handles the messages of an additional window. Closing one
+// only reports the close; the window stays until DestroyWindow, and its
+// destruction does not end the message loop.
+func (p *windowsPlatform) windowProc(hwnd windows.HWND, message uint32, wParam, lParam uintptr) uintptr {
+	w := p.windows[hwnd]
+	if w == nil {
+		// Messages sent while CreateWindowExW runs
+		ret, _, _ := procDefWindowProcW.Call(uintptr(hwnd), uintptr(message), wParam, lParam)
+		return ret
+	}
+
+	switch message {
+	case wmClose:
+		p.queueEvent(Event{Type: EventClose, Window: w.id})
+		return 0
+
+	case wmDestroy:
+		return 0
+
+	case wmSize:
+		newWidth := int(lParam & 0xFFFF)
+		newHeight := int((lParam >> 16) & 0xFFFF)
+		if newWidth != w.width || newHeight != w.height {
+			w.width, w.height = newWidth, newHeight
+			p.queueEvent(Event{
+				Type:   EventResize,
+				Window: w.id,
+				Width:  newWidth,
+				Height: newHeight,
+			})
+		}
+		return 0
+
+	case wmKeydown, wmKeyup:
+		p.handleKey(w.id, message, wParam, lParam)
+		return 0
+
+	case wmSysKeydown, wmSysKeyup:
+		p.handleKey(w.id, message, wParam, lParam)
+
+	case wmSetCursor:
+		if w.cursor.handleSetCursor(lParam) {
+			return 1
+		}
+
+	case wmMouseMove, wmLButtonDown, wmLButtonUp, wmRButtonDown, wmRButtonUp,
+		wmMButtonDown, wmMButtonUp, wmXButtonDown, wmXButtonUp,
+		wmMouseWheel, wmMouseHWheel:
+		p.handleMouse(uintptr(hwnd), w.id, message, wParam, lParam)
+	}
+
+	ret, _, _ := procDefWindowProcW.Call(uintptr(hwnd), uintptr(message), wParam, lParam)
+	return ret
+}
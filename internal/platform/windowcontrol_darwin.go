@@ -0,0 +1,97 @@
+//go:build darwin
+
+package platform
+
+// SetWindowTitle implements WindowController.
+func (p *darwinPlatform) SetWindowTitle(id WindowID, title string) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	w.window.SetTitle(title)
+	return nil
+}
+
+// SetWindowSize sets the content size of a window in points.
+func (p *darwinPlatform) SetWindowSize(id WindowID, width, height int) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	w.window.SetSize(width, height)
+	return nil
+}
+
+// SetWindowPosition moves a window's frame, in points from the top-left
+// corner of the primary display.
+func (p *darwinPlatform) SetWindowPosition(id WindowID, x, y int) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	w.window.SetPosition(x, y)
+	return nil
+}
+
+// MinimizeWindow miniaturizes a window into the Dock.
+func (p *darwinPlatform) MinimizeWindow(id WindowID) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	w.window.Miniaturize()
+	return nil
+}
+
+// MaximizeWindow zooms a window, unless it is zoomed already.
+func (p *darwinPlatform) MaximizeWindow(id WindowID) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	if !w.window.IsZoomed() {
+		w.window.Zoom()
+	}
+	return nil
+}
+
+// RestoreWindow deminiaturizes a minimized window, or unzooms a zoomed
+// one.
+func (p *darwinPlatform) RestoreWindow(id WindowID) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case w.window.IsMiniaturized():
+		w.window.Deminiaturize()
+	case w.window.IsZoomed():
+		w.window.Zoom()
+	}
+	return nil
+}
+
+// FocusWindow activates the application and makes a window the key
+// window.
+func (p *darwinPlatform) FocusWindow(id WindowID) error {
+	w, err := p.openWindow(id)
+	if err != nil {
+		return err
+	}
+	if w.window.IsMiniaturized() {
+		w.window.Deminiaturize()
+	}
+	p.app.Activate()
+	w.window.Show()
+	return nil
+}
+
+// RequestWindowAttention bounces the Dock icon, as windows have no
+// attention state of their own on macOS.
+func (p *darwinPlatform) RequestWindowAttention(id WindowID, critical bool) error {
+	if _, err := p.openWindow(id); err != nil {
+		return err
+	}
+	p.app.RequestUserAttention(critical)
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build js && wasm
+
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+// SetWindowTitle sets the title of the page.
+func (p *jsPlatform) SetWindowTitle(id WindowID, title string) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+	js.Global().Get("document").Set("title", title)
+	return nil
+}
+
+// SetWindowSize sets the CSS size of the canvas.
+func (p *jsPlatform) SetWindowSize(id WindowID, width, height int) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("platform: invalid window size %dx%d", width, height)
+	}
+
+	p.mu.Lock()
+	p.cssWidth, p.cssHeight = width, height
+	style := p.canvas.Get("style")
+	style.Set("width", strconv.Itoa(width)+"px")
+	style.Set("height", strconv.Itoa(height)+"px")
+	p.mu.Unlock()
+
+	p.handleResize(js.Undefined())
+	return nil
+}
+
+// SetWindowPosition is not supported in the browser; the page lays out
+// the canvas.
+func (p *jsPlatform) SetWindowPosition(id WindowID, x, y int) error {
+	return ErrUnsupported
+}
+
+// MinimizeWindow is not supported in the browser.
+func (p *jsPlatform) MinimizeWindow(id WindowID) error {
+	return ErrUnsupported
+}
+
+// MaximizeWindow is not supported in the browser; see the Fullscreen API.
+func (p *jsPlatform) MaximizeWindow(id WindowID) error {
+	return ErrUnsupported
+}
+
+// RestoreWindow is not supported in the browser.
+func (p *jsPlatform) RestoreWindow(id WindowID) error {
+	return ErrUnsupported
+}
+
+// FocusWindow gives the canvas keyboard focus. Pages cannot bring their
+// browser window to the front.
+func (p *jsPlatform) FocusWindow(id WindowID) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+	p.canvas.Call("focus")
+	return nil
+}
+
+// RequestWindowAttention is not supported in the browser.
+func (p *jsPlatform) RequestWindowAttention(id WindowID, critical bool) error {
+	return ErrUnsupported
+}
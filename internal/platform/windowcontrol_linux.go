@@ -0,0 +1,244 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/internal/platform/wayland"
+	"github.com/gogpu/gogpu/internal/platform/x11"
+)
+
+// SetWindowTitle changes the title of an X11 window.
+func (p *x11Platform) SetWindowTitle(id WindowID, title string) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.SetWindowTitle(window, title)
+}
+
+// SetWindowSize resizes an X11 window.
+func (p *x11Platform) SetWindowSize(id WindowID, width, height int) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.SetWindowSize(window, width, height)
+}
+
+// SetWindowPosition moves an X11 window. The window manager places its
+// frame there.
+func (p *x11Platform) SetWindowPosition(id WindowID, x, y int) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.SetWindowPosition(window, x, y)
+}
+
+// MinimizeWindow iconifies an X11 window.
+func (p *x11Platform) MinimizeWindow(id WindowID) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.MinimizeWindow(window)
+}
+
+// MaximizeWindow maximizes an X11 window through _NET_WM_STATE.
+func (p *x11Platform) MaximizeWindow(id WindowID) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.MaximizeWindow(window)
+}
+
+// RestoreWindow deiconifies and unmaximizes an X11 window.
+func (p *x11Platform) RestoreWindow(id WindowID) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.RestoreWindow(window)
+}
+
+// FocusWindow asks the window manager to activate an X11 window through
+// _NET_ACTIVE_WINDOW.
+func (p *x11Platform) FocusWindow(id WindowID) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.FocusWindow(window)
+}
+
+// RequestWindowAttention marks an X11 window as demanding attention. The
+// window manager keeps the mark until the window is activated, so
+// critical makes no difference.
+func (p *x11Platform) RequestWindowAttention(id WindowID, critical bool) error {
+	window, err := p.x11Window(id)
+	if err != nil {
+		return err
+	}
+	return p.inner.RequestWindowAttention(window)
+}
+
+// x11Window returns the X11 window of a window ID, 0 for the main window.
+func (p *x11Platform) x11Window(id WindowID) (x11.ResourceID, error) {
+	if id == MainWindow {
+		return 0, nil
+	}
+	window, ok := p.windows[id]
+	if !ok {
+		return 0, fmt.Errorf("platform: unknown window %d", id)
+	}
+	return window, nil
+}
+
+// bindActivation binds xdg_activation_v1 for FocusWindow and
+// RequestWindowAttention.
+func (p *waylandPlatform) bindActivation() error {
+	activationID, err := p.registry.BindXdgActivation(1)
+	if err != nil {
+		return fmt.Errorf("failed to bind xdg activation: %w", err)
+	}
+	p.activation = wayland.NewXdgActivationV1(p.display, activationID)
+	return nil
+}
+
+// SetWindowTitle changes the title of the toplevel.
+func (p *waylandPlatform) SetWindowTitle(id WindowID, title string) error {
+	return p.toplevelRequest(id, func(toplevel *wayland.XdgToplevel) error {
+		return toplevel.SetTitle(title)
+	})
+}
+
+// SetWindowSize resizes the window. Wayland clients choose the size of
+// their windows themselves, so the new size is reported at once; the
+// compositor may still impose another one, e.g. on a maximized window.
+func (p *waylandPlatform) SetWindowSize(id WindowID, width, height int) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("wayland: invalid window size %dx%d", width, height)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.toplevel == nil {
+		return ErrUnsupported
+	}
+	if width != p.width || height != p.height {
+		p.pendingWidth = width
+		p.pendingHeight = height
+		p.hasResize = true
+	}
+	return nil
+}
+
+// SetWindowPosition is not supported on Wayland: clients do not know
+// where their windows are.
+func (p *waylandPlatform) SetWindowPosition(id WindowID, x, y int) error {
+	return ErrUnsupported
+}
+
+// MinimizeWindow asks the compositor to minimize the toplevel.
+func (p *waylandPlatform) MinimizeWindow(id WindowID) error {
+	return p.toplevelRequest(id, func(toplevel *wayland.XdgToplevel) error {
+		return toplevel.SetMinimized()
+	})
+}
+
+// MaximizeWindow asks the compositor to maximize the toplevel.
+func (p *waylandPlatform) MaximizeWindow(id WindowID) error {
+	return p.toplevelRequest(id, func(toplevel *wayland.XdgToplevel) error {
+		return toplevel.SetMaximized()
+	})
+}
+
+// RestoreWindow unmaximizes the toplevel. xdg-shell has no request to
+// bring back a minimized window; FocusWindow may do so.
+func (p *waylandPlatform) RestoreWindow(id WindowID) error {
+	return p.toplevelRequest(id, func(toplevel *wayland.XdgToplevel) error {
+		return toplevel.UnsetMaximized()
+	})
+}
+
+// FocusWindow asks the compositor to activate the window with a token
+// for the last input event. Compositors that see no recent input mark
+// the window as demanding attention instead.
+func (p *waylandPlatform) FocusWindow(id WindowID) error {
+	return p.activate(id, true)
+}
+
+// RequestWindowAttention asks the compositor to activate the window with
+// a token for no input event, which compositors show as a request for
+// attention. critical makes no difference.
+func (p *waylandPlatform) RequestWindowAttention(id WindowID, critical bool) error {
+	return p.activate(id, false)
+}
+
+// toplevelRequest sends the request of fn for the toplevel of a window.
+func (p *waylandPlatform) toplevelRequest(id WindowID, fn func(toplevel *wayland.XdgToplevel) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	toplevel := p.toplevel
+	if id != MainWindow {
+		w, ok := p.windows[id]
+		if !ok {
+			return fmt.Errorf("platform: unknown window %d", id)
+		}
+		toplevel = w.toplevel
+	}
+	if toplevel == nil {
+		return ErrUnsupported
+	}
+	return fn(toplevel)
+}
+
+// activate requests an activation token for the window and activates it
+// once the token arrives. With input, the token carries the serial of the
+// last pointer button press or key event.
+func (p *waylandPlatform) activate(id WindowID, input bool) error {
+	if id != MainWindow {
+		return fmt.Errorf("platform: unknown window %d", id)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.activation == nil || p.surface == nil {
+		return ErrUnsupported
+	}
+
+	token, err := p.activation.GetActivationToken()
+	if err != nil {
+		return fmt.Errorf("wayland: failed to get activation token: %w", err)
+	}
+	token.SetDoneHandler(func(value string) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		_ = token.Destroy()
+		if p.activation != nil && p.surface != nil {
+			_ = p.activation.Activate(value, p.surface)
+		}
+	})
+
+	if input && p.seat != nil {
+		serial := p.buttonSerial
+		if p.keyboard != nil && p.keyboard.LastSerial() > serial {
+			serial = p.keyboard.LastSerial()
+		}
+		if serial != 0 {
+			_ = token.SetSerial(serial, p.seat)
+		}
+	}
+	_ = token.SetSurface(p.surface)
+	return token.Commit()
+}
@@ -0,0 +1,151 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ShowWindow commands and FlashWindowEx flags
+const (
+	swMaximize = 3
+	swMinimize = 6
+	swRestore  = 9
+
+	flashwAll       = 0x3 // Caption and taskbar button
+	flashwTray      = 0x2 // Taskbar button
+	flashwTimerNoFG = 0xC // Until the window comes to the foreground
+)
+
+var (
+	procSetWindowTextW      = user32.NewProc("SetWindowTextW")
+	procSetForegroundWindow = user32.NewProc("SetForegroundWindow")
+	procGetForegroundWindow = user32.NewProc("GetForegroundWindow")
+	procIsIconic            = user32.NewProc("IsIconic")
+	procFlashWindowEx       = user32.NewProc("FlashWindowEx")
+)
+
+// flashWInfo is the Win32 FLASHWINFO structure.
+type flashWInfo struct {
+	cbSize    uint32
+	hwnd      windows.HWND
+	dwFlags   uint32
+	uCount    uint32
+	dwTimeout uint32
+}
+
+// SetWindowTitle implements WindowController.
+func (p *windowsPlatform) SetWindowTitle(id WindowID, title string) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	titlePtr, err := windows.UTF16PtrFromString(title)
+	if err != nil {
+		return fmt.Errorf("utf16 title: %w", err)
+	}
+	if ok, _, _ := procSetWindowTextW.Call(uintptr(hwnd), uintptr(unsafe.Pointer(titlePtr))); ok == 0 {
+		return fmt.Errorf("platform: SetWindowTextW failed")
+	}
+	return nil
+}
+
+// SetWindowSize resizes the client area, scaled to the DPI of the
+// window's monitor like the size at creation.
+func (p *windowsPlatform) SetWindowSize(id WindowID, width, height int) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	dpi := windowDPI(hwnd)
+	setClientSize(hwnd, scaleToDPI(width, dpi), scaleToDPI(height, dpi), windowStyle(hwnd), dpi)
+	return nil
+}
+
+// SetWindowPosition moves the window's frame, in pixels of the virtual
+// screen.
+func (p *windowsPlatform) SetWindowPosition(id WindowID, x, y int) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	procSetWindowPos.Call(uintptr(hwnd), 0, uintptr(x), uintptr(y), 0, 0,
+		swpNoSize|swpNoZOrder|swpNoActivate)
+	return nil
+}
+
+// MinimizeWindow implements WindowController.
+func (p *windowsPlatform) MinimizeWindow(id WindowID) error {
+	return p.showWindow(id, swMinimize)
+}
+
+// MaximizeWindow implements WindowController.
+func (p *windowsPlatform) MaximizeWindow(id WindowID) error {
+	return p.showWindow(id, swMaximize)
+}
+
+// RestoreWindow implements WindowController.
+func (p *windowsPlatform) RestoreWindow(id WindowID) error {
+	return p.showWindow(id, swRestore)
+}
+
+// FocusWindow restores a minimized window and brings it to the
+// foreground. Windows lets only the foreground process do so; otherwise
+// the taskbar button flashes instead.
+func (p *windowsPlatform) FocusWindow(id WindowID) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	if iconic, _, _ := procIsIconic.Call(uintptr(hwnd)); iconic != 0 {
+		procShowWindow.Call(uintptr(hwnd), swRestore)
+	}
+	procSetForegroundWindow.Call(uintptr(hwnd))
+	return nil
+}
+
+// RequestWindowAttention flashes the taskbar button of a background
+// window a few times and leaves it highlighted, or flashes the caption
+// and taskbar button until the window comes to the foreground if
+// critical is set.
+func (p *windowsPlatform) RequestWindowAttention(id WindowID, critical bool) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	if foreground, _, _ := procGetForegroundWindow.Call(); windows.HWND(foreground) == hwnd {
+		return nil
+	}
+
+	info := flashWInfo{cbSize: uint32(unsafe.Sizeof(flashWInfo{})), hwnd: hwnd, dwFlags: flashwTray, uCount: 3}
+	if critical {
+		info.dwFlags, info.uCount = flashwAll|flashwTimerNoFG, 0
+	}
+	procFlashWindowEx.Call(uintptr(unsafe.Pointer(&info)))
+	return nil
+}
+
+// showWindow sends a ShowWindow command to a window.
+func (p *windowsPlatform) showWindow(id WindowID, cmd uintptr) error {
+	hwnd, err := p.windowHandle(id)
+	if err != nil {
+		return err
+	}
+	procShowWindow.Call(uintptr(hwnd), cmd)
+	return nil
+}
+
+// windowHandle returns the HWND of the main window or an additional
+// window.
+func (p *windowsPlatform) windowHandle(id WindowID) (windows.HWND, error) {
+	if id == MainWindow && p.hwnd != 0 {
+		return p.hwnd, nil
+	}
+	if w := p.windowByID(id); w != nil {
+		return w.hwnd, nil
+	}
+	return 0, fmt.Errorf("platform: unknown window %d", id)
+}
@@ -12,17 +12,21 @@ const (
 	AtomNameWMDeleteWindow          = "WM_DELETE_WINDOW"
 	AtomNameWMTakeFocus             = "WM_TAKE_FOCUS"
 	AtomNameWMState                 = "WM_STATE"
+	AtomNameWMChangeState           = "WM_CHANGE_STATE"
+	AtomNameNetActiveWindow         = "_NET_ACTIVE_WINDOW"
 	AtomNameNetWMName               = "_NET_WM_NAME"
 	AtomNameNetWMState              = "_NET_WM_STATE"
 	AtomNameNetWMStateFullscreen    = "_NET_WM_STATE_FULLSCREEN"
 	AtomNameNetWMStateMaximizedVert = "_NET_WM_STATE_MAXIMIZED_VERT"
 	AtomNameNetWMStateMaximizedHorz = "_NET_WM_STATE_MAXIMIZED_HORZ"
 	AtomNameNetWMStateHidden        = "_NET_WM_STATE_HIDDEN"
+	AtomNameNetWMStateDemandsAttn   = "_NET_WM_STATE_DEMANDS_ATTENTION"
 	AtomNameNetWMWindowType         = "_NET_WM_WINDOW_TYPE"
 	AtomNameNetWMWindowTypeNormal   = "_NET_WM_WINDOW_TYPE_NORMAL"
 	AtomNameNetWMPID                = "_NET_WM_PID"
 	AtomNameNetWMIcon               = "_NET_WM_ICON"
 	AtomNameNetFrameExtents         = "_NET_FRAME_EXTENTS"
+	AtomNameNetWMMoveResize         = "_NET_WM_MOVERESIZE"
 	AtomNameUTF8String              = "UTF8_STRING"
 	AtomNameMotifWMHints            = "_MOTIF_WM_HINTS"
 )
@@ -171,14 +175,18 @@ type StandardAtoms struct {
 	WMDeleteWindow          Atom
 	WMTakeFocus             Atom
 	WMState                 Atom
+	WMChangeState           Atom
+	NetActiveWindow         Atom
 	NetWMName               Atom
 	NetWMState              Atom
 	NetWMStateFullscreen    Atom
 	NetWMStateMaximizedVert Atom
 	NetWMStateMaximizedHorz Atom
+	NetWMStateDemandsAttn   Atom
 	NetWMWindowType         Atom
 	NetWMWindowTypeNormal   Atom
 	NetWMPID                Atom
+	NetWMMoveResize         Atom
 	UTF8String              Atom
 	MotifWMHints            Atom
 }
@@ -209,6 +217,16 @@ func (c *Connection) InternStandardAtoms() (*StandardAtoms, error) {
 		return nil, err
 	}
 
+	atoms.WMChangeState, err = c.InternAtom(AtomNameWMChangeState, false)
+	if err != nil {
+		return nil, err
+	}
+
+	atoms.NetActiveWindow, err = c.InternAtom(AtomNameNetActiveWindow, false)
+	if err != nil {
+		return nil, err
+	}
+
 	atoms.NetWMName, err = c.InternAtom(AtomNameNetWMName, false)
 	if err != nil {
 		return nil, err
@@ -234,6 +252,11 @@ func (c *Connection) InternStandardAtoms() (*StandardAtoms, error) {
 		return nil, err
 	}
 
+	atoms.NetWMStateDemandsAttn, err = c.InternAtom(AtomNameNetWMStateDemandsAttn, false)
+	if err != nil {
+		return nil, err
+	}
+
 	atoms.NetWMWindowType, err = c.InternAtom(AtomNameNetWMWindowType, false)
 	if err != nil {
 		return nil, err
@@ -249,6 +272,11 @@ func (c *Connection) InternStandardAtoms() (*StandardAtoms, error) {
 		return nil, err
 	}
 
+	atoms.NetWMMoveResize, err = c.InternAtom(AtomNameNetWMMoveResize, false)
+	if err != nil {
+		return nil, err
+	}
+
 	atoms.UTF8String, err = c.InternAtom(AtomNameUTF8String, false)
 	if err != nil {
 		return nil, err
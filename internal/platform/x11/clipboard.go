@@ -0,0 +1,360 @@
+//go:build linux
+
+package x11
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// selectionTimeout bounds how long the clipboard waits for a reply of
+// the server or of the clipboard owner, such as the next part of an INCR
+// transfer.
+const selectionTimeout = time.Second
+
+// incrChunkSize is the most bytes of clipboard text sent in one property
+// change. Larger text is sent in parts with the INCR protocol.
+const incrChunkSize = 1 << 16
+
+// Atom names used for the clipboard.
+const (
+	AtomNameClipboard = "CLIPBOARD"
+	AtomNameTargets   = "TARGETS"
+	AtomNameIncr      = "INCR"
+
+	// atomNameSelection is the property of the main window that clipboard
+	// contents are transferred in.
+	atomNameSelection = "GOGPU_SELECTION"
+)
+
+// errClipboardOwner is returned when another client keeps the clipboard.
+var errClipboardOwner = errors.New("x11: failed to take clipboard ownership")
+
+// clipboardAtoms are the atoms of the clipboard protocol, interned on
+// first use.
+type clipboardAtoms struct {
+	clipboard Atom
+	targets   Atom
+	incr      Atom
+	property  Atom
+}
+
+// incrTransfer is clipboard text sent to a requestor in parts, see
+// startIncr.
+type incrTransfer struct {
+	requestor ResourceID
+	property  Atom
+	data      []byte // Not yet sent
+}
+
+// next cuts the next part of at most size bytes off the data, and
+// reports whether it is the empty part that ends the transfer.
+func (t *incrTransfer) next(size int) (part []byte, done bool) {
+	n := min(size, len(t.data))
+	part, t.data = t.data[:n], t.data[n:]
+	return part, n == 0
+}
+
+// clipboardAtoms interns the clipboard atoms.
+func (p *Platform) clipboardAtoms() (*clipboardAtoms, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.clipAtoms != nil {
+		return p.clipAtoms, nil
+	}
+	atoms, err := p.conn.InternAtoms([]string{AtomNameClipboard, AtomNameTargets, AtomNameIncr, atomNameSelection})
+	if err != nil {
+		return nil, err
+	}
+	p.clipAtoms = &clipboardAtoms{
+		clipboard: atoms[AtomNameClipboard],
+		targets:   atoms[AtomNameTargets],
+		incr:      atoms[AtomNameIncr],
+		property:  atoms[atomNameSelection],
+	}
+	return p.clipAtoms, nil
+}
+
+// ReadClipboardText returns the text of the CLIPBOARD selection, or "" if
+// it has no owner or the owner has no text. Text the owner sends in
+// parts, with the INCR protocol, is put together. Events read while
+// waiting for the owner are kept for PollEvents.
+func (p *Platform) ReadClipboardText() (string, error) {
+	atoms, err := p.clipboardAtoms()
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	owned, text := p.clipboardOwned, p.clipboardText
+	p.mu.Unlock()
+	if owned {
+		return text, nil
+	}
+
+	owner, err := p.conn.GetSelectionOwner(atoms.clipboard)
+	if err != nil || owner == 0 {
+		return "", err
+	}
+	stamp, err := p.eventTime(atoms)
+	if err != nil {
+		return "", err
+	}
+	if err := p.conn.ConvertSelection(p.window, atoms.clipboard, p.atoms.UTF8String, atoms.property, stamp); err != nil {
+		return "", err
+	}
+
+	var notify *SelectionNotifyEvent
+	err = p.waitEvent(func(event Event) bool {
+		e, ok := event.(*SelectionNotifyEvent)
+		if ok && e.Requestor == p.window && e.Selection == atoms.clipboard {
+			notify = e
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return "", fmt.Errorf("x11: clipboard owner did not respond: %w", err)
+	}
+
+	if notify.Property == AtomNone {
+		return "", nil // No text on the clipboard
+	}
+	propType, data, err := p.conn.GetProperty(p.window, notify.Property, true)
+	if err != nil {
+		return "", err
+	}
+	if propType == atoms.incr {
+		return p.readIncr(notify.Property)
+	}
+	return string(data), nil
+}
+
+// readIncr receives clipboard text the owner sends in parts. Deleting
+// the INCR property started the transfer; each part is a new value of
+// property, deleted once read, and an empty part ends it.
+func (p *Platform) readIncr(property Atom) (string, error) {
+	var text []byte
+	for {
+		err := p.waitEvent(func(event Event) bool {
+			e, ok := event.(*PropertyNotifyEvent)
+			return ok && e.Window == p.window && e.Atom == property && e.State == PropertyNewValue
+		})
+		if err != nil {
+			return "", fmt.Errorf("x11: clipboard owner stopped sending: %w", err)
+		}
+
+		_, data, err := p.conn.GetProperty(p.window, property, true)
+		if err != nil {
+			return "", err
+		}
+		if len(data) == 0 {
+			return string(text), nil
+		}
+		text = append(text, data...)
+	}
+}
+
+// waitEvent reads events until match reports true for one, or
+// selectionTimeout passes. Other events are handled, and kept for
+// PollEvents.
+func (p *Platform) waitEvent(match func(Event) bool) error {
+	deadline := time.Now().Add(selectionTimeout)
+	for {
+		event, err := p.conn.WaitForEventUntil(deadline)
+		if err != nil {
+			return err
+		}
+		if match(event) {
+			return nil
+		}
+		if e := p.handleEvent(event); e.Type != EventTypeNone {
+			p.mu.Lock()
+			p.queued = append(p.queued, e)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// eventTime returns the server time of the last key or button event,
+// which ICCCM asks selection requests to carry instead of CurrentTime.
+// Before any such event, it gets the current server time from the
+// PropertyNotify of an empty append to a property of the main window.
+func (p *Platform) eventTime(atoms *clipboardAtoms) (Timestamp, error) {
+	p.mu.Lock()
+	last := p.lastTime
+	p.mu.Unlock()
+	if last != CurrentTime {
+		return last, nil
+	}
+
+	if err := p.conn.ChangeProperty(p.window, atoms.property, p.atoms.UTF8String, 8, PropModeAppend, nil); err != nil {
+		return 0, err
+	}
+	var stamp Timestamp
+	err := p.waitEvent(func(event Event) bool {
+		e, ok := event.(*PropertyNotifyEvent)
+		if ok && e.Window == p.window && e.Atom == atoms.property {
+			stamp = e.Time
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return 0, fmt.Errorf("x11: failed to get server time: %w", err)
+	}
+	return stamp, nil
+}
+
+// WriteClipboardText makes the main window the owner of the CLIPBOARD
+// selection, serving text to other clients until another one takes it.
+// Ownership dates from the last key or button event, see eventTime.
+func (p *Platform) WriteClipboardText(text string) error {
+	atoms, err := p.clipboardAtoms()
+	if err != nil {
+		return err
+	}
+
+	stamp, err := p.eventTime(atoms)
+	if err != nil {
+		return err
+	}
+	if err := p.conn.SetSelectionOwner(p.window, atoms.clipboard, stamp); err != nil {
+		return err
+	}
+	owner, err := p.conn.GetSelectionOwner(atoms.clipboard)
+	if err != nil {
+		return err
+	}
+	if owner != p.window {
+		return errClipboardOwner
+	}
+
+	p.mu.Lock()
+	p.clipboardOwned = true
+	p.clipboardText = text
+	p.clipboardTime = stamp
+	p.mu.Unlock()
+	return nil
+}
+
+// serveSelection answers another client's request for the clipboard
+// text. Requests for other selections or formats, and those from before
+// the main window took the clipboard, are refused. Text too large for
+// one request is sent in parts, see startIncr.
+func (p *Platform) serveSelection(e *SelectionRequestEvent) {
+	p.mu.Lock()
+	atoms, owned, text, since := p.clipAtoms, p.clipboardOwned, p.clipboardText, p.clipboardTime
+	p.mu.Unlock()
+
+	// Obsolete clients leave the property unset
+	property := e.Property
+	if property == AtomNone {
+		property = e.Target
+	}
+
+	switch {
+	case atoms == nil || !owned || e.Selection != atoms.clipboard:
+		property = AtomNone
+	case e.Time != CurrentTime && e.Time < since:
+		property = AtomNone
+	case e.Target == atoms.targets:
+		targets := make([]byte, 8)
+		p.conn.putUint32LE(targets[0:4], uint32(atoms.targets))
+		p.conn.putUint32LE(targets[4:8], uint32(p.atoms.UTF8String))
+		if p.conn.ChangeProperty(e.Requestor, property, AtomAtom, 32, PropModeReplace, targets) != nil {
+			property = AtomNone
+		}
+	case e.Target == p.atoms.UTF8String && len(text) > p.selectionChunk():
+		if p.startIncr(e.Requestor, property, text) != nil {
+			property = AtomNone
+		}
+	case e.Target == p.atoms.UTF8String:
+		if p.conn.ChangeProperty(e.Requestor, property, p.atoms.UTF8String, 8, PropModeReplace, []byte(text)) != nil {
+			property = AtomNone
+		}
+	default:
+		property = AtomNone
+	}
+
+	_ = p.conn.SendSelectionNotify(e.Requestor, e.Selection, e.Target, property, e.Time)
+}
+
+// clearSelection forgets the clipboard text after another client took
+// the clipboard.
+func (p *Platform) clearSelection(e *SelectionClearEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.clipAtoms != nil && e.Selection == p.clipAtoms.clipboard {
+		p.clipboardOwned = false
+		p.clipboardText = ""
+	}
+}
+
+// selectionChunk returns the most bytes of clipboard text sent in one
+// property change: incrChunkSize, or less on servers with a smaller
+// maximum request size.
+func (p *Platform) selectionChunk() int {
+	const header = 24 // ChangeProperty request before the data
+	return min(incrChunkSize, int(p.conn.Setup().MaxRequestLength)*4-header)
+}
+
+// startIncr begins sending text to requestor in parts (ICCCM 2.7.2).
+// The INCR property gives its size, and each time the requestor deletes
+// the property, continueIncr sends the next part.
+func (p *Platform) startIncr(requestor ResourceID, property Atom, text string) error {
+	// Property changes for the parts, and destruction to drop the transfer
+	if err := p.conn.SelectInput(requestor, EventMaskPropertyChange|EventMaskStructureNotify); err != nil {
+		return err
+	}
+	size := make([]byte, 4)
+	p.conn.putUint32LE(size, uint32(len(text))) //nolint:gosec // G115: clipboard text is far below 4 GiB
+	if err := p.conn.ChangeProperty(requestor, property, p.clipAtoms.incr, 32, PropModeReplace, size); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.incr = append(p.incr, &incrTransfer{requestor: requestor, property: property, data: []byte(text)})
+	p.mu.Unlock()
+	return nil
+}
+
+// continueIncr sends the next part of an INCR transfer once its
+// requestor deleted the previous one. The empty part after the last one
+// ends the transfer.
+func (p *Platform) continueIncr(e *PropertyNotifyEvent) {
+	if e.State != PropertyDelete {
+		return
+	}
+
+	p.mu.Lock()
+	i := slices.IndexFunc(p.incr, func(t *incrTransfer) bool {
+		return t.requestor == e.Window && t.property == e.Atom
+	})
+	if i < 0 {
+		p.mu.Unlock()
+		return
+	}
+	t := p.incr[i]
+	part, done := t.next(p.selectionChunk())
+	if done {
+		p.incr = slices.Delete(p.incr, i, i+1)
+	}
+	p.mu.Unlock()
+
+	_ = p.conn.ChangeProperty(t.requestor, t.property, p.atoms.UTF8String, 8, PropModeReplace, part)
+	if done {
+		_ = p.conn.SelectInput(t.requestor, 0)
+	}
+}
+
+// dropIncr ends the INCR transfers to a destroyed requestor.
+func (p *Platform) dropIncr(requestor ResourceID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.incr = slices.DeleteFunc(p.incr, func(t *incrTransfer) bool { return t.requestor == requestor })
+}
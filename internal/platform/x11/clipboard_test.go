@@ -0,0 +1,36 @@
+//go:build linux
+
+package x11
+
+import (
+	"testing"
+)
+
+func TestIncrTransferNext(t *testing.T) {
+	transfer := &incrTransfer{data: []byte("abcdefg")}
+
+	var parts []string
+	for {
+		part, done := transfer.next(3)
+		if done {
+			if len(part) != 0 {
+				t.Errorf("final part = %q, want empty", part)
+			}
+			break
+		}
+		parts = append(parts, string(part))
+		if len(parts) > 3 {
+			t.Fatalf("parts = %q, transfer does not end", parts)
+		}
+	}
+
+	want := []string{"abc", "def", "g"}
+	if len(parts) != len(want) {
+		t.Fatalf("parts = %q, want %q", parts, want)
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Errorf("part %d = %q, want %q", i, parts[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+//go:build linux
+
+package x11
+
+import "fmt"
+
+// Glyphs of the standard "cursor" font, from X11/cursorfont.h. The mask
+// of each glyph is the following glyph.
+const (
+	CursorGlyphX                 uint16 = 0
+	CursorGlyphBottomLeftCorner  uint16 = 12
+	CursorGlyphBottomRightCorner uint16 = 14
+	CursorGlyphCrosshair         uint16 = 34
+	CursorGlyphFleur             uint16 = 52
+	CursorGlyphHand1             uint16 = 58
+	CursorGlyphHand2             uint16 = 60
+	CursorGlyphLeftPtr           uint16 = 68
+	CursorGlyphHDoubleArrow      uint16 = 108
+	CursorGlyphVDoubleArrow      uint16 = 116
+	CursorGlyphWatch             uint16 = 150
+	CursorGlyphXterm             uint16 = 152
+)
+
+// OpenFont loads a server font by name.
+func (c *Connection) OpenFont(name string) (ResourceID, error) {
+	fontID := c.GenerateID()
+
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeOpenFont)
+	e.PutUint8(0)                                         // unused
+	e.PutUint16(uint16(3 + (len(name)+pad(len(name)))/4)) //nolint:gosec // G115: font names are short
+	e.PutUint32(uint32(fontID))
+	e.PutUint16(uint16(len(name))) //nolint:gosec // G115: font names are short
+	e.PutUint16(0)                 // unused
+	e.PutString(name)
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: OpenFont failed: %w", err)
+	}
+	return fontID, nil
+}
+
+// CreateGlyphCursor creates a black on white cursor from a glyph of font
+// and its mask glyph.
+func (c *Connection) CreateGlyphCursor(font ResourceID, glyph, mask uint16) (ResourceID, error) {
+	cursorID := c.GenerateID()
+
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeCreateGlyphCursor)
+	e.PutUint8(0)  // unused
+	e.PutUint16(8) // length
+	e.PutUint32(uint32(cursorID))
+	e.PutUint32(uint32(font)) // source font
+	e.PutUint32(uint32(font)) // mask font
+	e.PutUint16(glyph)
+	e.PutUint16(mask)
+	e.PutUint16(0) // foreground red, green, blue
+	e.PutUint16(0)
+	e.PutUint16(0)
+	e.PutUint16(0xFFFF) // background red, green, blue
+	e.PutUint16(0xFFFF)
+	e.PutUint16(0xFFFF)
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: CreateGlyphCursor failed: %w", err)
+	}
+	return cursorID, nil
+}
+
+// CreateBlankCursor creates a fully transparent cursor, from a 1x1
+// bitmap that is cleared and used as its own mask.
+func (c *Connection) CreateBlankCursor() (ResourceID, error) {
+	pixmapID := c.GenerateID()
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeCreatePixmap)
+	e.PutUint8(1)  // depth
+	e.PutUint16(4) // length
+	e.PutUint32(uint32(pixmapID))
+	e.PutUint32(uint32(c.RootWindow()))
+	e.PutUint16(1) // width
+	e.PutUint16(1) // height
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: CreatePixmap failed: %w", err)
+	}
+
+	// The default GC foreground is 0
+	gcID := c.GenerateID()
+	e.Reset()
+	e.PutUint8(OpcodeCreateGC)
+	e.PutUint8(0)  // unused
+	e.PutUint16(4) // length
+	e.PutUint32(uint32(gcID))
+	e.PutUint32(uint32(pixmapID))
+	e.PutUint32(0) // value-mask
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: CreateGC failed: %w", err)
+	}
+
+	e.Reset()
+	e.PutUint8(OpcodePolyFillRectangle)
+	e.PutUint8(0)  // unused
+	e.PutUint16(5) // length
+	e.PutUint32(uint32(pixmapID))
+	e.PutUint32(uint32(gcID))
+	e.PutInt16(0)  // x
+	e.PutInt16(0)  // y
+	e.PutUint16(1) // width
+	e.PutUint16(1) // height
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: PolyFillRectangle failed: %w", err)
+	}
+
+	cursorID := c.GenerateID()
+	e.Reset()
+	e.PutUint8(OpcodeCreateCursor)
+	e.PutUint8(0)  // unused
+	e.PutUint16(8) // length
+	e.PutUint32(uint32(cursorID))
+	e.PutUint32(uint32(pixmapID)) // source
+	e.PutUint32(uint32(pixmapID)) // mask
+	e.PutPadN(12)                 // foreground and background colors
+	e.PutUint16(0)                // x
+	e.PutUint16(0)                // y
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return 0, fmt.Errorf("x11: CreateCursor failed: %w", err)
+	}
+
+	// The cursor keeps what it needs of the pixmap
+	c.freeResource(OpcodeFreeGC, gcID)
+	c.freeResource(OpcodeFreePixmap, pixmapID)
+	return cursorID, nil
+}
+
+// freeResource sends a request freeing a resource, such as FreeGC.
+func (c *Connection) freeResource(opcode uint8, id ResourceID) {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(opcode)
+	e.PutUint8(0)  // unused
+	e.PutUint16(2) // length
+	e.PutUint32(uint32(id))
+	_, _ = c.sendRequest(e.Bytes())
+}
+
+// DefineCursor sets the cursor shown over window, or the cursor of its
+// parent with cursor 0.
+func (c *Connection) DefineCursor(window, cursor ResourceID) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeChangeWindowAttrs)
+	e.PutUint8(0)  // unused
+	e.PutUint16(4) // length
+	e.PutUint32(uint32(window))
+	e.PutUint32(CWCursor)
+	e.PutUint32(uint32(cursor))
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: ChangeWindowAttributes failed: %w", err)
+	}
+	return nil
+}
+
+// GlyphCursor returns the cursor for a glyph of the "cursor" font,
+// created on first use.
+func (p *Platform) GlyphCursor(glyph uint16) (ResourceID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return 0, fmt.Errorf("x11: not initialized")
+	}
+	if cursor, ok := p.cursors[glyph]; ok {
+		return cursor, nil
+	}
+
+	if p.cursorFont == 0 {
+		font, err := p.conn.OpenFont("cursor")
+		if err != nil {
+			return 0, err
+		}
+		p.cursorFont = font
+	}
+	cursor, err := p.conn.CreateGlyphCursor(p.cursorFont, glyph, glyph+1)
+	if err != nil {
+		return 0, err
+	}
+	if p.cursors == nil {
+		p.cursors = make(map[uint16]ResourceID)
+	}
+	p.cursors[glyph] = cursor
+	return cursor, nil
+}
+
+// BlankCursor returns the transparent cursor, created on first use.
+func (p *Platform) BlankCursor() (ResourceID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return 0, fmt.Errorf("x11: not initialized")
+	}
+	if p.blankCursor == 0 {
+		cursor, err := p.conn.CreateBlankCursor()
+		if err != nil {
+			return 0, err
+		}
+		p.blankCursor = cursor
+	}
+	return p.blankCursor, nil
+}
+
+// SetWindowCursor shows cursor over window, the main window if window is
+// 0, or the default cursor if cursor is 0.
+func (p *Platform) SetWindowCursor(window, cursor ResourceID) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return fmt.Errorf("x11: not initialized")
+	}
+	if window == 0 {
+		window = p.window
+	}
+	if err := p.conn.DefineCursor(window, cursor); err != nil {
+		return err
+	}
+	return p.conn.Flush()
+}
@@ -39,7 +39,9 @@
 //   - Window: The X11 window ID (uint32)
 //
 // Note: This pure Go implementation returns the socket FD as the "display"
-// handle. This works with some Vulkan implementations that accept raw FDs.
+// handle, which is not an Xlib Display. The GPU backends open their own
+// Xlib display for the window instead, see package internal/xlib, since
+// window IDs are global to the X server.
 //
 // # Thread Safety
 //
@@ -134,6 +134,26 @@ type FocusOutEvent struct {
 	FocusEvent
 }
 
+// Mode values of focus and crossing events.
+const (
+	NotifyNormal       = 0
+	NotifyGrab         = 1
+	NotifyUngrab       = 2
+	NotifyWhileGrabbed = 3
+)
+
+// Detail values of focus events.
+const (
+	NotifyAncestor         = 0
+	NotifyVirtual          = 1
+	NotifyInferior         = 2
+	NotifyNonlinear        = 3
+	NotifyNonlinearVirtual = 4
+	NotifyPointer          = 5
+	NotifyPointerRoot      = 6
+	NotifyDetailNone       = 7
+)
+
 // ExposeEvent is generated when a window region needs redrawing.
 type ExposeEvent struct {
 	Sequence uint16     // Sequence number
@@ -308,6 +328,10 @@ func (c *Connection) parseEvent(buf []byte) (Event, error) {
 		return c.parseClientMessageEvent(buf)
 	case EventSelectionClear:
 		return c.parseSelectionClearEvent(buf)
+	case EventSelectionRequest:
+		return c.parseSelectionRequestEvent(buf)
+	case EventSelectionNotify:
+		return c.parseSelectionNotifyEvent(buf)
 	case EventMappingNotify:
 		return c.parseMappingNotifyEvent(buf)
 	default:
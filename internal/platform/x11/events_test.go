@@ -123,6 +123,8 @@ func TestEventMarkers(t *testing.T) {
 		&PropertyNotifyEvent{},
 		&ClientMessageEvent{},
 		&SelectionClearEvent{},
+		&SelectionRequestEvent{},
+		&SelectionNotifyEvent{},
 		&MappingNotifyEvent{},
 		&UnknownEvent{},
 	}
@@ -132,3 +134,28 @@ func TestEventMarkers(t *testing.T) {
 		e.eventMarker()
 	}
 }
+
+func TestParseSelectionRequestEvent(t *testing.T) {
+	c := &Connection{byteOrder: LSBFirst}
+
+	e := NewEncoder(LSBFirst)
+	e.PutUint8(EventSelectionRequest)
+	e.PutUint8(0)
+	e.PutUint16(7)  // sequence
+	e.PutUint32(0)  // time
+	e.PutUint32(10) // owner
+	e.PutUint32(20) // requestor
+	e.PutUint32(30) // selection
+	e.PutUint32(40) // target
+	e.PutUint32(50) // property
+	e.PutPadN(4)
+
+	event, err := c.parseEvent(e.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SelectionRequestEvent{Sequence: 7, Owner: 10, Requestor: 20, Selection: 30, Target: 40, Property: 50}
+	if got, ok := event.(*SelectionRequestEvent); !ok || *got != *want {
+		t.Errorf("parseEvent = %+v, want %+v", event, want)
+	}
+}
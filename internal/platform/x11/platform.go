@@ -24,14 +24,32 @@ const (
 	EventTypeNone EventType = iota
 	EventTypeClose
 	EventTypeResize
+	EventTypeKey    // Key pressed or released
+	EventTypeButton // Pointer button pressed or released, including wheel steps
+	EventTypeMotion // Pointer moved
+	EventTypeFocus  // Window gained (Active) or lost keyboard focus
+	EventTypeMap    // Main window mapped (Active) or unmapped, e.g. iconified
 )
 
 // PlatformEvent represents a platform event.
 // This mirrors platform.Event to avoid import cycles.
 type PlatformEvent struct {
 	Type   EventType
+	Window ResourceID // Additional window the event belongs to, 0 for the main window
 	Width  int
 	Height int
+
+	// Key and pointer events
+	Keysym  Keysym // Unshifted keysym of the key
+	Keycode uint8
+	Button  uint8 // Core protocol button: 1-3, 4-7 for wheel steps, 8-9 back/forward
+	Down    bool
+	Repeat  bool   // Press of a key that is already held
+	X, Y    int    // Pointer position in the window
+	State   uint16 // Modifier and button mask before the event
+
+	// Focus and map events
+	Active bool
 }
 
 // Platform implements X11 windowing support.
@@ -50,16 +68,60 @@ type Platform struct {
 	// Keyboard mapping
 	keymap *KeyboardMapping
 
+	// Keys held, by keycode, to tell repeats from new presses
+	keysDown [256]bool
+
 	// Window state
 	width       int
 	height      int
 	shouldClose bool
 	configured  bool
+	unmapped    bool // Main window unmapped since it was mapped
 
 	// Pending resize
 	pendingWidth  int
 	pendingHeight int
 	hasResize     bool
+
+	// Sizes of the additional windows opened with CreateWindow
+	windows map[ResourceID]*windowSize
+
+	// Pointer button held in a window, for BeginMoveResize
+	press buttonPress
+
+	// Clipboard text served while the main window owns CLIPBOARD
+	clipAtoms      *clipboardAtoms
+	clipboardOwned bool
+	clipboardText  string
+	clipboardTime  Timestamp // When the main window took CLIPBOARD
+
+	// Clipboard text sent to requestors in parts, see startIncr
+	incr []*incrTransfer
+
+	// Server time of the last key or button event, for selection
+	// requests, see eventTime
+	lastTime Timestamp
+
+	// Events read while waiting for the clipboard owner
+	queued []PlatformEvent
+
+	// Cursors created on first use, by glyph of the cursor font
+	cursorFont  ResourceID
+	cursors     map[uint16]ResourceID
+	blankCursor ResourceID
+}
+
+// buttonPress is a pointer button press whose button is still held.
+type buttonPress struct {
+	window       ResourceID // 0 while no button is held
+	rootX, rootY int16
+	button       uint8
+	time         Timestamp
+}
+
+// windowSize is the last known size of an additional window.
+type windowSize struct {
+	width, height int
 }
 
 // NewPlatform creates a new X11 platform instance.
@@ -84,6 +146,39 @@ func (p *Platform) Init(config Config) error {
 	}
 	p.atoms = atoms
 
+	window, err := p.newWindow(config)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	p.window = window
+
+	// Get keyboard mapping (non-fatal - keyboard input may not work correctly without it)
+	keymap, _ := conn.GetKeyboardMapping()
+	p.keymap = keymap
+
+	// Set fullscreen if requested (non-fatal, will fail if WM doesn't support EWMH)
+	if config.Fullscreen {
+		_ = conn.SetFullscreen(window, true, atoms)
+	}
+
+	// Store initial size
+	p.width = config.Width
+	p.height = config.Height
+	p.configured = true
+
+	// Flush to ensure all requests are sent
+	_ = conn.Flush()
+
+	// Sync to ensure window is created
+	_ = conn.Sync()
+
+	return nil
+}
+
+// newWindow creates, titles and maps a window with the properties of
+// config.
+func (p *Platform) newWindow(config Config) (ResourceID, error) {
 	// Create window
 	windowConfig := WindowConfig{
 		Title:      config.Title,
@@ -95,36 +190,34 @@ func (p *Platform) Init(config Config) error {
 		Fullscreen: config.Fullscreen,
 	}
 
-	window, err := conn.CreateWindow(windowConfig)
+	window, err := p.conn.CreateWindow(windowConfig)
 	if err != nil {
-		_ = conn.Close()
-		return fmt.Errorf("x11: failed to create window: %w", err)
+		return 0, fmt.Errorf("x11: failed to create window: %w", err)
 	}
-	p.window = window
 
 	// Set window properties
-	if err := conn.SetWindowTitle(window, config.Title, atoms); err != nil {
-		_ = conn.Close()
-		return fmt.Errorf("x11: failed to set title: %w", err)
+	if err := p.conn.SetWindowTitle(window, config.Title, p.atoms); err != nil {
+		_ = p.conn.DestroyWindow(window)
+		return 0, fmt.Errorf("x11: failed to set title: %w", err)
 	}
 
 	// Set WM protocols (for close button)
-	if err := conn.SetWMProtocols(window, atoms); err != nil {
-		_ = conn.Close()
-		return fmt.Errorf("x11: failed to set WM protocols: %w", err)
+	if err := p.conn.SetWMProtocols(window, p.atoms); err != nil {
+		_ = p.conn.DestroyWindow(window)
+		return 0, fmt.Errorf("x11: failed to set WM protocols: %w", err)
 	}
 
 	// Set WM class
-	if err := conn.SetWMClass(window, "gogpu", "GoGPU"); err != nil {
-		_ = conn.Close()
-		return fmt.Errorf("x11: failed to set WM class: %w", err)
+	if err := p.conn.SetWMClass(window, "gogpu", "GoGPU"); err != nil {
+		_ = p.conn.DestroyWindow(window)
+		return 0, fmt.Errorf("x11: failed to set WM class: %w", err)
 	}
 
 	// Set PID (non-fatal, some WMs don't support this)
-	_ = conn.SetWMPID(window, atoms)
+	_ = p.conn.SetWMPID(window, p.atoms)
 
 	// Set window type (non-fatal, some WMs don't support this)
-	_ = conn.SetNetWMWindowType(window, atoms.NetWMWindowTypeNormal, atoms)
+	_ = p.conn.SetNetWMWindowType(window, p.atoms.NetWMWindowTypeNormal, p.atoms)
 
 	// Handle non-resizable windows via Motif hints
 	if !config.Resizable {
@@ -134,42 +227,29 @@ func (p *Platform) Init(config Config) error {
 			Functions:   1 | 2 | 8, // Move | Minimize | Close (no Resize or Maximize)
 		}
 		// Non-fatal, some WMs don't support Motif hints
-		_ = conn.SetMotifWMHints(window, hints, atoms)
+		_ = p.conn.SetMotifWMHints(window, hints, p.atoms)
 	}
 
 	// Map (show) the window
-	if err := conn.MapWindow(window); err != nil {
-		_ = conn.Close()
-		return fmt.Errorf("x11: failed to map window: %w", err)
+	if err := p.conn.MapWindow(window); err != nil {
+		_ = p.conn.DestroyWindow(window)
+		return 0, fmt.Errorf("x11: failed to map window: %w", err)
 	}
 
-	// Get keyboard mapping (non-fatal - keyboard input may not work correctly without it)
-	keymap, _ := conn.GetKeyboardMapping()
-	p.keymap = keymap
-
-	// Set fullscreen if requested (non-fatal, will fail if WM doesn't support EWMH)
-	if config.Fullscreen {
-		_ = conn.SetFullscreen(window, true, atoms)
-	}
-
-	// Store initial size
-	p.width = config.Width
-	p.height = config.Height
-	p.configured = true
-
-	// Flush to ensure all requests are sent
-	_ = conn.Flush()
-
-	// Sync to ensure window is created
-	_ = conn.Sync()
-
-	return nil
+	return window, nil
 }
 
 // PollEvents processes pending X11 events.
 func (p *Platform) PollEvents() PlatformEvent {
 	p.mu.Lock()
 
+	if len(p.queued) > 0 {
+		event := p.queued[0]
+		p.queued = p.queued[1:]
+		p.mu.Unlock()
+		return event
+	}
+
 	// Check for pending resize
 	if p.hasResize {
 		p.width = p.pendingWidth
@@ -237,27 +317,32 @@ func (p *Platform) PollEvents() PlatformEvent {
 func (p *Platform) handleEvent(event Event) PlatformEvent {
 	switch e := event.(type) {
 	case *ConfigureNotifyEvent:
-		if e.Window == p.window {
-			p.mu.Lock()
-			newWidth := int(e.Width)
-			newHeight := int(e.Height)
-			if newWidth != p.width || newHeight != p.height {
-				p.pendingWidth = newWidth
-				p.pendingHeight = newHeight
-				p.hasResize = true
-			}
-			p.mu.Unlock()
+		if e.Window != p.window {
+			return p.handleWindowConfigure(e)
+		}
+		p.mu.Lock()
+		newWidth := int(e.Width)
+		newHeight := int(e.Height)
+		if newWidth != p.width || newHeight != p.height {
+			p.pendingWidth = newWidth
+			p.pendingHeight = newHeight
+			p.hasResize = true
+		}
+		p.mu.Unlock()
 
-			if p.hasResize {
-				return PlatformEvent{
-					Type:   EventTypeResize,
-					Width:  newWidth,
-					Height: newHeight,
-				}
+		if p.hasResize {
+			return PlatformEvent{
+				Type:   EventTypeResize,
+				Width:  newWidth,
+				Height: newHeight,
 			}
 		}
 
 	case *ClientMessageEvent:
+		if e.IsDeleteWindow(p.atoms) && e.Window != p.window {
+			// Closing an additional window is up to the application
+			return PlatformEvent{Type: EventTypeClose, Window: e.Window}
+		}
 		if e.IsDeleteWindow(p.atoms) {
 			p.mu.Lock()
 			p.shouldClose = true
@@ -272,20 +357,214 @@ func (p *Platform) handleEvent(event Event) PlatformEvent {
 			p.mu.Unlock()
 			return PlatformEvent{Type: EventTypeClose}
 		}
+		p.dropIncr(e.Window)
+
+	case *PropertyNotifyEvent:
+		p.continueIncr(e)
+
+	case *KeyPressEvent:
+		return p.keyEvent(&e.KeyEvent, true)
+
+	case *KeyReleaseEvent:
+		return p.keyEvent(&e.KeyEvent, false)
+
+	case *ButtonPressEvent:
+		return p.buttonEvent(&e.ButtonEvent, true)
+
+	case *ButtonReleaseEvent:
+		return p.buttonEvent(&e.ButtonEvent, false)
+
+	case *MotionNotifyEvent:
+		return PlatformEvent{
+			Type:   EventTypeMotion,
+			Window: p.eventWindow(e.Event),
+			X:      int(e.EventX),
+			Y:      int(e.EventY),
+			State:  e.State,
+		}
 
 	case *ExposeEvent:
 		// Could trigger redraw, but for now we just ignore
 		// The main render loop should handle this
 
 	case *MapNotifyEvent:
+		if e.Window != p.window {
+			break
+		}
 		p.mu.Lock()
+		remapped := p.unmapped
 		p.configured = true
+		p.unmapped = false
+		p.mu.Unlock()
+		// The first map, when the window opens, is not reported
+		if remapped {
+			return PlatformEvent{Type: EventTypeMap, Active: true}
+		}
+
+	case *UnmapNotifyEvent:
+		if e.Window != p.window {
+			break
+		}
+		p.mu.Lock()
+		p.unmapped = true
 		p.mu.Unlock()
+		return PlatformEvent{Type: EventTypeMap}
+
+	case *SelectionRequestEvent:
+		p.serveSelection(e)
+
+	case *SelectionClearEvent:
+		p.clearSelection(e)
+
+	case *FocusInEvent:
+		return p.focusEvent(&e.FocusEvent, true)
+
+	case *FocusOutEvent:
+		return p.focusEvent(&e.FocusEvent, false)
 	}
 
 	return PlatformEvent{Type: EventTypeNone}
 }
 
+// keyEvent reports a key press or release. The server repeats held keys
+// as further presses, or as release and press pairs unless detectable
+// auto-repeat is enabled.
+func (p *Platform) keyEvent(e *KeyEvent, down bool) PlatformEvent {
+	p.mu.Lock()
+	repeat := down && p.keysDown[e.Detail]
+	p.keysDown[e.Detail] = down
+	p.lastTime = e.Time
+	keymap := p.keymap
+	p.mu.Unlock()
+
+	keysym := Keysym(KeysymVoidSymbol)
+	if keymap != nil {
+		keysym = keymap.KeycodeToKeysym(e.Detail, false, false)
+	}
+	return PlatformEvent{
+		Type:    EventTypeKey,
+		Window:  p.eventWindow(e.Event),
+		Keysym:  keysym,
+		Keycode: e.Detail,
+		Down:    down,
+		Repeat:  repeat,
+		X:       int(e.EventX),
+		Y:       int(e.EventY),
+		State:   e.State,
+	}
+}
+
+// focusEvent reports a window gaining or losing keyboard focus. Focus
+// changes caused by keyboard grabs, or moving between the window and its
+// children, are not reported.
+func (p *Platform) focusEvent(e *FocusEvent, focused bool) PlatformEvent {
+	if e.Mode == NotifyGrab || e.Mode == NotifyUngrab || e.Detail == NotifyInferior {
+		return PlatformEvent{Type: EventTypeNone}
+	}
+	return PlatformEvent{
+		Type:   EventTypeFocus,
+		Window: p.eventWindow(e.Event),
+		Active: focused,
+	}
+}
+
+// buttonEvent reports a pointer button press or release.
+func (p *Platform) buttonEvent(e *ButtonEvent, down bool) PlatformEvent {
+	p.mu.Lock()
+	p.lastTime = e.Time
+	if down && e.Detail <= 3 { // Not the wheel, buttons 4 to 7
+		p.press = buttonPress{window: e.Event, rootX: e.RootX, rootY: e.RootY, button: e.Detail, time: e.Time}
+	} else if e.Detail == p.press.button {
+		p.press = buttonPress{}
+	}
+	p.mu.Unlock()
+
+	return PlatformEvent{
+		Type:   EventTypeButton,
+		Window: p.eventWindow(e.Event),
+		Button: e.Detail,
+		Down:   down,
+		X:      int(e.EventX),
+		Y:      int(e.EventY),
+		State:  e.State,
+	}
+}
+
+// eventWindow returns the PlatformEvent.Window of an event sent to window.
+func (p *Platform) eventWindow(window ResourceID) ResourceID {
+	if window == p.window {
+		return 0
+	}
+	return window
+}
+
+// handleWindowConfigure reports a size change of an additional window.
+func (p *Platform) handleWindowConfigure(e *ConfigureNotifyEvent) PlatformEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	size := p.windows[e.Window]
+	if size == nil || (int(e.Width) == size.width && int(e.Height) == size.height) {
+		return PlatformEvent{Type: EventTypeNone}
+	}
+	size.width, size.height = int(e.Width), int(e.Height)
+	return PlatformEvent{
+		Type:   EventTypeResize,
+		Window: e.Window,
+		Width:  size.width,
+		Height: size.height,
+	}
+}
+
+// CreateWindow opens an additional window. Its events carry its ID in
+// PlatformEvent.Window; closing it only reports EventTypeClose.
+func (p *Platform) CreateWindow(config Config) (ResourceID, error) {
+	if p.conn == nil {
+		return 0, fmt.Errorf("x11: not initialized")
+	}
+
+	window, err := p.newWindow(config)
+	if err != nil {
+		return 0, err
+	}
+	if config.Fullscreen {
+		_ = p.conn.SetFullscreen(window, true, p.atoms)
+	}
+	_ = p.conn.Flush()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.windows == nil {
+		p.windows = make(map[ResourceID]*windowSize)
+	}
+	p.windows[window] = &windowSize{width: config.Width, height: config.Height}
+	return window, nil
+}
+
+// DestroyWindow closes a window opened with CreateWindow.
+func (p *Platform) DestroyWindow(window ResourceID) {
+	p.mu.Lock()
+	_, ok := p.windows[window]
+	delete(p.windows, window)
+	p.mu.Unlock()
+
+	if ok && p.conn != nil {
+		_ = p.conn.DestroyWindow(window)
+		_ = p.conn.Flush()
+	}
+}
+
+// WindowSize returns the size of a window opened with CreateWindow.
+func (p *Platform) WindowSize(window ResourceID) (width, height int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if size := p.windows[window]; size != nil {
+		return size.width, size.height
+	}
+	return 0, 0
+}
+
 // ShouldClose returns true if window close was requested.
 func (p *Platform) ShouldClose() bool {
 	p.mu.Lock()
@@ -319,6 +598,10 @@ func (p *Platform) Destroy() {
 	defer p.mu.Unlock()
 
 	if p.conn != nil {
+		for window := range p.windows {
+			_ = p.conn.DestroyWindow(window)
+		}
+		clear(p.windows)
 		if p.window != 0 {
 			_ = p.conn.DestroyWindow(p.window)
 			p.window = 0
@@ -329,4 +612,5 @@ func (p *Platform) Destroy() {
 
 	p.atoms = nil
 	p.keymap = nil
+	p.cursorFont, p.cursors, p.blankCursor = 0, nil, 0
 }
@@ -0,0 +1,237 @@
+//go:build linux
+
+package x11
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnyPropertyType matches properties of any type in GetProperty.
+const AnyPropertyType Atom = 0
+
+// SelectionRequestEvent is sent to the owner of a selection when another
+// client asks for its contents.
+type SelectionRequestEvent struct {
+	Sequence  uint16     // Sequence number
+	Time      Timestamp  // Time of the request, or CurrentTime
+	Owner     ResourceID // Window owning the selection
+	Requestor ResourceID // Window to store the contents on
+	Selection Atom       // Selection atom, e.g. CLIPBOARD
+	Target    Atom       // Format requested, e.g. UTF8_STRING
+	Property  Atom       // Property to store the contents in, or AtomNone
+}
+
+func (*SelectionRequestEvent) eventMarker() {}
+
+// SelectionNotifyEvent reports that a ConvertSelection completed.
+type SelectionNotifyEvent struct {
+	Sequence  uint16     // Sequence number
+	Time      Timestamp  // Time of the request
+	Requestor ResourceID // Window passed to ConvertSelection
+	Selection Atom       // Selection atom
+	Target    Atom       // Format requested
+	Property  Atom       // Property holding the contents, AtomNone on failure
+}
+
+func (*SelectionNotifyEvent) eventMarker() {}
+
+func (c *Connection) parseSelectionRequestEvent(buf []byte) (Event, error) {
+	d := NewDecoder(c.byteOrder, buf)
+
+	_, _ = d.Uint8() // event type
+	_, _ = d.Uint8() // unused
+	seq, _ := d.Uint16()
+	time, _ := d.Uint32()
+	owner, _ := d.Uint32()
+	requestor, _ := d.Uint32()
+	selection, _ := d.Uint32()
+	target, _ := d.Uint32()
+	property, _ := d.Uint32()
+
+	return &SelectionRequestEvent{
+		Sequence:  seq,
+		Time:      Timestamp(time),
+		Owner:     ResourceID(owner),
+		Requestor: ResourceID(requestor),
+		Selection: Atom(selection),
+		Target:    Atom(target),
+		Property:  Atom(property),
+	}, nil
+}
+
+func (c *Connection) parseSelectionNotifyEvent(buf []byte) (Event, error) {
+	d := NewDecoder(c.byteOrder, buf)
+
+	_, _ = d.Uint8() // event type
+	_, _ = d.Uint8() // unused
+	seq, _ := d.Uint16()
+	time, _ := d.Uint32()
+	requestor, _ := d.Uint32()
+	selection, _ := d.Uint32()
+	target, _ := d.Uint32()
+	property, _ := d.Uint32()
+
+	return &SelectionNotifyEvent{
+		Sequence:  seq,
+		Time:      Timestamp(time),
+		Requestor: ResourceID(requestor),
+		Selection: Atom(selection),
+		Target:    Atom(target),
+		Property:  Atom(property),
+	}, nil
+}
+
+// SetSelectionOwner makes owner the owner of selection, or clears the
+// owner with owner 0.
+func (c *Connection) SetSelectionOwner(owner ResourceID, selection Atom, time Timestamp) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeSetSelectionOwner)
+	e.PutUint8(0)  // unused
+	e.PutUint16(4) // length
+	e.PutUint32(uint32(owner))
+	e.PutUint32(uint32(selection))
+	e.PutUint32(uint32(time))
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: SetSelectionOwner failed: %w", err)
+	}
+	return nil
+}
+
+// GetSelectionOwner returns the window owning selection, or 0 if it has
+// no owner.
+func (c *Connection) GetSelectionOwner(selection Atom) (ResourceID, error) {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeGetSelectionOwner)
+	e.PutUint8(0)  // unused
+	e.PutUint16(2) // length
+	e.PutUint32(uint32(selection))
+
+	reply, err := c.sendRequestWithReply(e.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("x11: GetSelectionOwner failed: %w", err)
+	}
+	if len(reply) < 12 {
+		return 0, fmt.Errorf("x11: GetSelectionOwner reply too short")
+	}
+
+	d := NewDecoder(c.byteOrder, reply[8:12])
+	owner, _ := d.Uint32()
+	return ResourceID(owner), nil
+}
+
+// ConvertSelection asks the owner of selection to store its contents in
+// format target in property of requestor. A SelectionNotifyEvent reports
+// the result.
+func (c *Connection) ConvertSelection(requestor ResourceID, selection, target, property Atom, time Timestamp) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeConvertSelection)
+	e.PutUint8(0)  // unused
+	e.PutUint16(6) // length
+	e.PutUint32(uint32(requestor))
+	e.PutUint32(uint32(selection))
+	e.PutUint32(uint32(target))
+	e.PutUint32(uint32(property))
+	e.PutUint32(uint32(time))
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: ConvertSelection failed: %w", err)
+	}
+	return nil
+}
+
+// GetProperty returns the type and value of a window property, deleting
+// it if del is set. A missing property has type AtomNone.
+func (c *Connection) GetProperty(window ResourceID, property Atom, del bool) (Atom, []byte, error) {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeGetProperty)
+	if del {
+		e.PutUint8(1)
+	} else {
+		e.PutUint8(0)
+	}
+	e.PutUint16(6) // length
+	e.PutUint32(uint32(window))
+	e.PutUint32(uint32(property))
+	e.PutUint32(uint32(AnyPropertyType))
+	e.PutUint32(0)       // long-offset
+	e.PutUint32(1 << 22) // long-length in 4-byte units, 16 MiB
+	reply, err := c.sendRequestWithReply(e.Bytes())
+	if err != nil {
+		return AtomNone, nil, fmt.Errorf("x11: GetProperty failed: %w", err)
+	}
+
+	// Reply format: [1][format][seq:2][length:4][type:4][bytes-after:4]
+	// [value-length:4][unused:12][value]
+	if len(reply) < 32 {
+		return AtomNone, nil, fmt.Errorf("x11: GetProperty reply too short")
+	}
+	d := NewDecoder(c.byteOrder, reply[8:20])
+	propType, _ := d.Uint32()
+	_, _ = d.Uint32() // bytes-after
+	length, _ := d.Uint32()
+
+	size := int(length) * int(reply[1]) / 8
+	if 32+size > len(reply) {
+		return AtomNone, nil, fmt.Errorf("x11: GetProperty reply too short")
+	}
+	return Atom(propType), reply[32 : 32+size], nil
+}
+
+// SendSelectionNotify tells requestor that a selection request was
+// handled, with property AtomNone if it was refused.
+func (c *Connection) SendSelectionNotify(requestor ResourceID, selection, target, property Atom, time Timestamp) error {
+	event := NewEncoder(c.byteOrder)
+	event.PutUint8(EventSelectionNotify)
+	event.PutUint8(0)  // unused
+	event.PutUint16(0) // sequence, set by the server
+	event.PutUint32(uint32(time))
+	event.PutUint32(uint32(requestor))
+	event.PutUint32(uint32(selection))
+	event.PutUint32(uint32(target))
+	event.PutUint32(uint32(property))
+	event.PutPadN(8)
+
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeSendEvent)
+	e.PutUint8(0)   // propagate = false
+	e.PutUint16(11) // length = 11 4-byte units
+	e.PutUint32(uint32(requestor))
+	e.PutUint32(0) // event mask: the requestor's client receives it
+	e.PutBytes(event.Bytes())
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: SendEvent failed: %w", err)
+	}
+	return nil
+}
+
+// SelectInput sets the events of window this client receives, e.g.
+// EventMaskPropertyChange on a selection requestor during an INCR
+// transfer.
+func (c *Connection) SelectInput(window ResourceID, mask uint32) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeChangeWindowAttrs)
+	e.PutUint8(0)  // unused
+	e.PutUint16(4) // length
+	e.PutUint32(uint32(window))
+	e.PutUint32(CWEventMask)
+	e.PutUint32(mask)
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: ChangeWindowAttributes failed: %w", err)
+	}
+	return nil
+}
+
+// WaitForEventUntil is WaitForEvent with a deadline, after which it
+// returns an error.
+func (c *Connection) WaitForEventUntil(deadline time.Time) (Event, error) {
+	if err := c.conn.SetReadDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("x11: failed to set read deadline: %w", err)
+	}
+	defer func() { _ = c.conn.SetReadDeadline(time.Time{}) }()
+
+	return c.WaitForEvent()
+}
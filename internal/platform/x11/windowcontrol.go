@@ -0,0 +1,256 @@
+//go:build linux
+
+package x11
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigureWindow value mask bits.
+const (
+	configX      = 1 << 0
+	configY      = 1 << 1
+	configWidth  = 1 << 2
+	configHeight = 1 << 3
+)
+
+// _NET_WM_STATE actions and the source indication of EWMH requests.
+const (
+	netWMStateRemove = 0
+	netWMStateAdd    = 1
+
+	sourceApplication = 1
+)
+
+// MoveResize directions of _NET_WM_MOVERESIZE: the edge or corner of a
+// window being resized, or a move.
+const (
+	MoveResizeTopLeft     = 0
+	MoveResizeTop         = 1
+	MoveResizeTopRight    = 2
+	MoveResizeRight       = 3
+	MoveResizeBottomRight = 4
+	MoveResizeBottom      = 5
+	MoveResizeBottomLeft  = 6
+	MoveResizeLeft        = 7
+	MoveResizeMove        = 8
+)
+
+// ErrNoButtonHeld is returned by BeginMoveResize when no pointer button
+// is held in a window of the platform.
+var ErrNoButtonHeld = errors.New("x11: no pointer button held")
+
+// iconicState is the ICCCM WM_STATE of an iconified window.
+const iconicState = 3
+
+// MoveWindow moves a window to x, y relative to its parent. Window
+// managers place the frame of a top-level window there.
+func (c *Connection) MoveWindow(window ResourceID, x, y int) error {
+	return c.configureWindow(window, configX|configY, uint32(int32(x)), uint32(int32(y))) //nolint:gosec // G115: screen coordinates
+}
+
+// ResizeWindow resizes a window.
+func (c *Connection) ResizeWindow(window ResourceID, width, height int) error {
+	return c.configureWindow(window, configWidth|configHeight, uint32(width), uint32(height)) //nolint:gosec // G115: window sizes are small
+}
+
+// configureWindow sends a ConfigureWindow request with the values of the
+// bits set in mask, in bit order.
+func (c *Connection) configureWindow(window ResourceID, mask uint16, values ...uint32) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeConfigureWindow)
+	e.PutUint8(0)                        // unused
+	e.PutUint16(uint16(3 + len(values))) //nolint:gosec // G115: at most 7 values
+	e.PutUint32(uint32(window))
+	e.PutUint16(mask)
+	e.PutUint16(0) // unused
+	for _, v := range values {
+		e.PutUint32(v)
+	}
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: ConfigureWindow failed: %w", err)
+	}
+	return nil
+}
+
+// SetMaximized asks the window manager to maximize a window in both
+// directions, or to restore it.
+func (c *Connection) SetMaximized(window ResourceID, maximized bool, atoms *StandardAtoms) error {
+	if atoms.NetWMState == AtomNone {
+		return nil
+	}
+
+	action := uint32(netWMStateRemove)
+	if maximized {
+		action = netWMStateAdd
+	}
+	return c.SendClientMessage(window, c.RootWindow(), atoms.NetWMState, action,
+		uint32(atoms.NetWMStateMaximizedHorz), uint32(atoms.NetWMStateMaximizedVert), sourceApplication, 0)
+}
+
+// IconifyWindow asks the window manager to iconify (minimize) a window,
+// as described by ICCCM. Mapping the window again restores it.
+func (c *Connection) IconifyWindow(window ResourceID, atoms *StandardAtoms) error {
+	if atoms.WMChangeState == AtomNone {
+		return nil
+	}
+	return c.SendClientMessage(window, c.RootWindow(), atoms.WMChangeState, iconicState, 0, 0, 0, 0)
+}
+
+// ActivateWindow asks the window manager to raise and focus a window,
+// switching to its desktop and deiconifying it as needed.
+func (c *Connection) ActivateWindow(window ResourceID, atoms *StandardAtoms) error {
+	if atoms.NetActiveWindow == AtomNone {
+		return nil
+	}
+	// Timestamp and currently active window are unknown
+	return c.SendClientMessage(window, c.RootWindow(), atoms.NetActiveWindow, sourceApplication, 0, 0, 0, 0)
+}
+
+// SetDemandsAttention asks the window manager to mark a window as
+// needing attention, e.g. by flashing its taskbar entry. Window managers
+// clear the state when the window is activated.
+func (c *Connection) SetDemandsAttention(window ResourceID, atoms *StandardAtoms) error {
+	if atoms.NetWMState == AtomNone || atoms.NetWMStateDemandsAttn == AtomNone {
+		return nil
+	}
+	return c.SendClientMessage(window, c.RootWindow(), atoms.NetWMState, netWMStateAdd,
+		uint32(atoms.NetWMStateDemandsAttn), 0, sourceApplication, 0)
+}
+
+// UngrabPointer releases a pointer grab of the client, such as the
+// implicit grab of a button press, made before time.
+func (c *Connection) UngrabPointer(time Timestamp) error {
+	e := NewEncoder(c.byteOrder)
+	e.PutUint8(OpcodeUngrabPointer)
+	e.PutUint8(0)  // unused
+	e.PutUint16(2) // length
+	e.PutUint32(uint32(time))
+
+	if _, err := c.sendRequest(e.Bytes()); err != nil {
+		return fmt.Errorf("x11: UngrabPointer failed: %w", err)
+	}
+	return nil
+}
+
+// MoveResizeWindow asks the window manager to move or resize a window
+// interactively in direction, a MoveResize constant, following the
+// pointer from rootX, rootY while button stays held.
+func (c *Connection) MoveResizeWindow(window ResourceID, rootX, rootY int16, direction uint32, button uint8, atoms *StandardAtoms) error {
+	if atoms.NetWMMoveResize == AtomNone {
+		return nil
+	}
+	return c.SendClientMessage(window, c.RootWindow(), atoms.NetWMMoveResize,
+		uint32(int32(rootX)), uint32(int32(rootY)), direction, uint32(button), sourceApplication) //nolint:gosec // G115: root coordinates
+}
+
+// SetWindowTitle changes the title of window, the main window if window
+// is 0.
+func (p *Platform) SetWindowTitle(window ResourceID, title string) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.SetWindowTitle(w, title, p.atoms)
+	})
+}
+
+// SetWindowSize resizes window, the main window if window is 0. The new
+// size arrives as a ConfigureNotify event.
+func (p *Platform) SetWindowSize(window ResourceID, width, height int) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.ResizeWindow(w, width, height)
+	})
+}
+
+// SetWindowPosition moves window, the main window if window is 0.
+func (p *Platform) SetWindowPosition(window ResourceID, x, y int) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.MoveWindow(w, x, y)
+	})
+}
+
+// MinimizeWindow iconifies window, the main window if window is 0.
+func (p *Platform) MinimizeWindow(window ResourceID) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.IconifyWindow(w, p.atoms)
+	})
+}
+
+// MaximizeWindow maximizes window, the main window if window is 0.
+func (p *Platform) MaximizeWindow(window ResourceID) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.SetMaximized(w, true, p.atoms)
+	})
+}
+
+// RestoreWindow deiconifies and unmaximizes window, the main window if
+// window is 0.
+func (p *Platform) RestoreWindow(window ResourceID) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		if err := p.conn.MapWindow(w); err != nil {
+			return err
+		}
+		return p.conn.SetMaximized(w, false, p.atoms)
+	})
+}
+
+// FocusWindow activates window, the main window if window is 0.
+func (p *Platform) FocusWindow(window ResourceID) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.ActivateWindow(w, p.atoms)
+	})
+}
+
+// RequestWindowAttention marks window, the main window if window is 0,
+// as demanding attention.
+func (p *Platform) RequestWindowAttention(window ResourceID) error {
+	return p.windowRequest(window, func(w ResourceID) error {
+		return p.conn.SetDemandsAttention(w, p.atoms)
+	})
+}
+
+// windowRequest sends the requests of fn for window, the main window if
+// window is 0, and flushes them.
+func (p *Platform) windowRequest(window ResourceID, fn func(window ResourceID) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return fmt.Errorf("x11: not initialized")
+	}
+	if window == 0 {
+		window = p.window
+	}
+	if err := fn(window); err != nil {
+		return err
+	}
+	return p.conn.Flush()
+}
+
+// BeginMoveResize hands the pointer button held since the last button
+// press to the window manager, which moves or resizes the pressed window
+// in direction, a MoveResize constant, until the button is released.
+// It returns ErrNoButtonHeld if no button is held.
+func (p *Platform) BeginMoveResize(direction uint32) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return fmt.Errorf("x11: not initialized")
+	}
+	press := p.press
+	if press.window == 0 {
+		return ErrNoButtonHeld
+	}
+	p.press = buttonPress{} // The window manager takes the release
+
+	// The window manager grabs the pointer itself, which fails while
+	// the implicit grab of the press is active
+	if err := p.conn.UngrabPointer(press.time); err != nil {
+		return err
+	}
+	if err := p.conn.MoveResizeWindow(press.window, press.rootX, press.rootY, direction, press.button, p.atoms); err != nil {
+		return err
+	}
+	return p.conn.Flush()
+}
@@ -348,6 +348,12 @@ const (
 	PropModeAppend  = 2
 )
 
+// PropertyNotify state values.
+const (
+	PropertyNewValue = 0
+	PropertyDelete   = 1
+)
+
 // Wire protocol errors.
 var (
 	ErrMessageTooLarge  = errors.New("x11: message exceeds maximum size")
@@ -0,0 +1,82 @@
+//go:build linux
+
+// Package xlib opens an Xlib display for GPU surface creation.
+//
+// The pure Go X11 platform speaks the wire protocol on its own socket and
+// returns that socket as the display handle, which VK_KHR_xlib_surface,
+// EGL and wgpu-native cannot use. Window IDs are global to the X server
+// though, so the window is handed to the driver together with a second
+// connection opened through libX11, loaded with dlopen to keep
+// CGO_ENABLED=0 builds.
+package xlib
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	goffitypes "github.com/go-webgpu/goffi/types"
+)
+
+// ErrWayland is returned for surface handles of Wayland windows, which
+// belong to the pure Go Wayland client. Its objects live on its own
+// socket connection, while a VK_KHR_wayland_surface, or a wgpu-native
+// Wayland surface, takes the wl_display and wl_surface of a
+// libwayland-client connection, which cannot share them.
+var ErrWayland = errors.New("xlib: Wayland windows of the pure Go client cannot get a GPU surface; " +
+	"unset WAYLAND_DISPLAY to run through XWayland")
+
+var display struct {
+	once sync.Once
+	err  error
+	ptr  unsafe.Pointer // Display*
+}
+
+// Display returns the Xlib display of $DISPLAY, opened on first use and
+// shared by all surfaces.
+func Display() (uintptr, error) {
+	display.once.Do(func() {
+		display.ptr, display.err = openDisplay()
+	})
+	return uintptr(display.ptr), display.err
+}
+
+// openDisplay loads libX11 and calls XOpenDisplay(NULL).
+func openDisplay() (unsafe.Pointer, error) {
+	lib, err := ffi.LoadLibrary("libX11.so.6")
+	if err != nil {
+		return nil, fmt.Errorf("xlib: failed to load libX11: %w", err)
+	}
+	openDisplay, err := ffi.GetSymbol(lib, "XOpenDisplay")
+	if err != nil {
+		return nil, fmt.Errorf("xlib: XOpenDisplay not found: %w", err)
+	}
+
+	// Display *XOpenDisplay(char *display_name)
+	cif := &goffitypes.CallInterface{}
+	err = ffi.PrepareCallInterface(
+		cif,
+		goffitypes.DefaultCall,
+		goffitypes.PointerTypeDescriptor,
+		[]*goffitypes.TypeDescriptor{
+			goffitypes.PointerTypeDescriptor, // display_name
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var name, display unsafe.Pointer // NULL: $DISPLAY
+	err = ffi.CallFunction(cif, openDisplay, unsafe.Pointer(&display),
+		[]unsafe.Pointer{unsafe.Pointer(&name)})
+	if err != nil {
+		return nil, fmt.Errorf("xlib: XOpenDisplay failed: %w", err)
+	}
+	if display == nil {
+		return nil, fmt.Errorf("xlib: cannot open X display %q", os.Getenv("DISPLAY"))
+	}
+	return display, nil
+}
@@ -0,0 +1,43 @@
+package gogpu
+
+// OnFocusChanged sets the callback for the main window gaining or losing
+// keyboard focus. Keys and mouse buttons held are released when the
+// window loses focus.
+func (a *App) OnFocusChanged(fn func(focused bool)) *App {
+	a.onFocusChanged = fn
+	return a
+}
+
+// OnMinimized sets the callback for the main window being minimized.
+// On X11 a window unmapped by the window manager counts as minimized.
+func (a *App) OnMinimized(fn func()) *App {
+	a.onMinimized = fn
+	return a
+}
+
+// OnRestored sets the callback for the main window being restored after
+// it was minimized.
+func (a *App) OnRestored(fn func()) *App {
+	a.onRestored = fn
+	return a
+}
+
+// OnVisibilityChanged sets the callback for the main window becoming
+// hidden or visible again. The window is hidden while it is minimized,
+// occluded, or the system is asleep; a Wayland window the compositor
+// suspended and a browser tab in the background count as occluded.
+// Drawing stops while the window is hidden; use
+// Config.WithBackground(BackgroundPause) to also stop OnUpdate and save
+// power.
+func (a *App) OnVisibilityChanged(fn func(visible bool)) *App {
+	a.onVisibility = fn
+	return a
+}
+
+// visibilityChanged calls the visibility callback if the main window's
+// visibility changed from visible.
+func (a *App) visibilityChanged(visible bool) {
+	if now := !a.hidden(); now != visible && a.onVisibility != nil {
+		a.onVisibility(now)
+	}
+}
@@ -0,0 +1,45 @@
+package gogpu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLifecycleCallbacks(t *testing.T) {
+	var calls []string
+	record := func(call string) { calls = append(calls, call) }
+	a := &App{}
+	a.OnFocusChanged(func(focused bool) {
+		if focused {
+			record("focused")
+		} else {
+			record("unfocused")
+		}
+	})
+	a.OnMinimized(func() { record("minimized") })
+	a.OnRestored(func() { record("restored") })
+	a.OnVisibilityChanged(func(visible bool) {
+		if visible {
+			record("visible")
+		} else {
+			record("hidden")
+		}
+	})
+
+	for _, state := range []WindowState{
+		WindowUnfocused,
+		WindowMinimized,
+		WindowMinimized, // Repeated states are not reported again
+		WindowOccluded,  // Still hidden
+		WindowRestored,
+		WindowVisible,
+		WindowFocused,
+	} {
+		a.handleWindowState(state)
+	}
+
+	want := []string{"unfocused", "minimized", "hidden", "restored", "visible", "focused"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
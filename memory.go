@@ -0,0 +1,44 @@
+package gogpu
+
+import (
+	"github.com/gogpu/gogpu/gpu"
+)
+
+// MemoryStats returns the GPU memory taken by the textures and buffers
+// of the renderer's device, and the budget the adapter reports. ok is
+// false if the backend does not account for memory; only the native
+// backend does.
+func (r *Renderer) MemoryStats() (stats gpu.MemoryStats, ok bool) {
+	mr, ok := r.backend.(gpu.MemoryReporter)
+	if !ok {
+		return gpu.MemoryStats{}, false
+	}
+	return mr.MemoryStats(r.device), true
+}
+
+// SetMemoryWarningHandler sets the function called when an allocation
+// brings the device near its memory budget, see gpu.MemoryStats.NearBudget.
+// Backends that do not account for memory never call it. App sets it to
+// the OnMemoryWarning handling.
+func (r *Renderer) SetMemoryWarningHandler(fn func(stats gpu.MemoryStats)) {
+	if mr, ok := r.backend.(gpu.MemoryReporter); ok {
+		mr.SetMemoryWarningCallback(r.device, fn)
+	}
+}
+
+// OnMemoryWarning sets the callback for allocations that bring the GPU
+// memory in use near the budget the adapter reports, see
+// Renderer.MemoryStats. It is called again only after the memory in use
+// fell below the threshold, on the goroutine that allocated.
+func (a *App) OnMemoryWarning(fn func(stats gpu.MemoryStats)) *App {
+	a.onMemoryWarning = fn
+	return a
+}
+
+// handleMemoryWarning passes a memory warning to the OnMemoryWarning
+// callback, if any.
+func (a *App) handleMemoryWarning(stats gpu.MemoryStats) {
+	if a.onMemoryWarning != nil {
+		a.onMemoryWarning(stats)
+	}
+}
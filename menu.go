@@ -0,0 +1,77 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// Menu is a custom top-level menu in the application menu bar.
+type Menu struct {
+	Title string
+	Items []MenuItem
+}
+
+// MenuItem is an entry of a Menu.
+type MenuItem struct {
+	// Title is the item label. Ignored for separators.
+	Title string
+
+	// Shortcut is the key pressed together with the platform command
+	// modifier (Cmd on macOS), e.g. "s" for Cmd+S. Empty for none.
+	Shortcut string
+
+	// Separator draws a separator line instead of an item.
+	Separator bool
+
+	// OnSelect is called on the main thread when the item is chosen.
+	OnSelect func()
+}
+
+// AddMenu adds a custom menu to the application menu bar.
+// It may be called before Run; the menu is added once the window exists,
+// and errors at that point are ignored.
+// Returns ErrPlatformNotSupported if the platform has no menu bar
+// (currently only macOS has one).
+func (a *App) AddMenu(menu Menu) error {
+	if a.platform == nil {
+		a.menus = append(a.menus, menu)
+		return nil
+	}
+	return a.applyMenu(menu)
+}
+
+// applyMenu assigns item IDs, registers the callbacks and passes
+// the menu to the platform.
+func (a *App) applyMenu(menu Menu) error {
+	menuBar, ok := a.platform.(platform.MenuBar)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+
+	if a.menuActions == nil {
+		a.menuActions = make(map[int]func())
+	}
+
+	items := make([]platform.MenuItem, 0, len(menu.Items))
+	for _, item := range menu.Items {
+		if item.Separator {
+			items = append(items, platform.MenuItem{Separator: true})
+			continue
+		}
+
+		// IDs start at 1 so that 0 never names a valid item
+		a.nextMenuID++
+		a.menuActions[a.nextMenuID] = item.OnSelect
+		items = append(items, platform.MenuItem{
+			ID:       a.nextMenuID,
+			Title:    item.Title,
+			Shortcut: item.Shortcut,
+		})
+	}
+
+	return platformError(menuBar.AddMenu(platform.Menu{Title: menu.Title, Items: items}))
+}
+
+// handleMenu calls the OnSelect callback of the chosen menu item.
+func (a *App) handleMenu(id int) {
+	if fn := a.menuActions[id]; fn != nil {
+		fn()
+	}
+}
@@ -0,0 +1,34 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// Monitor describes a display attached to the system.
+//
+// X, Y, Width and Height (and the Work area without the menu bar, Dock or
+// taskbar) are in desktop coordinates with the origin at the top-left
+// corner of the primary monitor: points on macOS, which multiplied by
+// Scale give pixels, and pixels elsewhere.
+type Monitor = platform.Monitor
+
+// Monitors returns the attached monitors, primary first.
+// Returns ErrNotInitialized before Run, and ErrPlatformNotSupported if the
+// platform cannot enumerate monitors (currently macOS and Windows).
+func (a *App) Monitors() ([]Monitor, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	lister, ok := a.platform.(platform.MonitorLister)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return lister.Monitors(), nil
+}
+
+// OnMonitorsChanged sets the callback for monitor configuration changes:
+// monitors connected or disconnected, and changes of resolution,
+// arrangement or scale. Call Monitors from the callback for the new
+// configuration.
+func (a *App) OnMonitorsChanged(fn func()) *App {
+	a.onMonitors = fn
+	return a
+}
@@ -0,0 +1,31 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// SetMouseCaptured locks the mouse to the main window for relative
+// motion, as needed by first-person cameras: the cursor is hidden and
+// cannot leave the window, and movement is read with MouseDelta.
+// The capture is suspended while the window does not have focus.
+// Returns ErrPlatformNotSupported if the platform cannot capture the
+// mouse (currently macOS and Windows).
+func (a *App) SetMouseCaptured(captured bool) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	c, ok := a.platform.(platform.MouseCapturer)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	return platformError(c.SetMouseCaptured(captured))
+}
+
+// MouseDelta returns the relative mouse movement in pixels since the
+// previous call, with y pointing down. It reports motion whether or not
+// the mouse is captured. On Windows it comes from raw input, without
+// pointer acceleration. Call it once per frame, e.g. from OnUpdate.
+func (a *App) MouseDelta() (dx, dy float64) {
+	if c, ok := a.platform.(platform.MouseCapturer); ok {
+		return c.MouseDelta()
+	}
+	return 0, 0
+}
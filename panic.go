@@ -0,0 +1,82 @@
+package gogpu
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError reports a panic in an application callback, see OnError.
+type PanicError struct {
+	Callback string // Name of the callback, e.g. "OnDraw"
+	Value    any    // Value passed to panic
+	Stack    []byte // Stack of the panicking goroutine
+}
+
+// Error returns the callback and the panic value, without the stack.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("gogpu: %s panicked: %v", e.Callback, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// OnError sets the callback for errors that end the main loop. A panic
+// in OnDraw, OnUpdate, OnFixedUpdate or OnResize, or the OnDraw of an
+// additional window, is recovered instead of unwinding through the
+// platform's message pump: no further callbacks are called, the loop
+// stops, the windows and renderer are released, and fn is called with a
+// *PanicError holding the stack of the panic. Run then returns the error.
+// In applications started with Start, Running reports false after the
+// panic, and Close calls fn and returns the error. Without a callback
+// the error is only returned; its Stack holds the stack of the panic.
+// fn is also called with the error of writing Config.TraceFile when the
+// app closes.
+func (a *App) OnError(fn func(err error)) *App {
+	a.onError = fn
+	return a
+}
+
+// guard calls the callback fn named callback and reports whether it
+// returned. A panic is recovered and stops the main loop. After a panic
+// no callbacks are called.
+func (a *App) guard(callback string, fn func()) (ok bool) {
+	if a.panicked != nil {
+		return false
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			a.panicked = &PanicError{Callback: callback, Value: v, Stack: debug.Stack()}
+			a.Quit()
+			ok = false
+		}
+	}()
+	fn()
+	return true
+}
+
+// reportError passes an error of tearing down the app, such as that of
+// writing the trace file, to the OnError callback, if any.
+func (a *App) reportError(err error) {
+	if a.onError != nil {
+		a.onError(err)
+	}
+}
+
+// reportPanic passes a recovered callback panic to the OnError callback,
+// if any, once the app has been torn down. It returns the panic as an
+// error, or nil.
+func (a *App) reportPanic() error {
+	p := a.panicked
+	if p == nil {
+		return nil
+	}
+	a.panicked = nil
+	if a.onError != nil {
+		a.onError(p)
+	}
+	return p
+}
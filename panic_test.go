@@ -0,0 +1,45 @@
+package gogpu
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGuard(t *testing.T) {
+	a := &App{running: true}
+	if !a.guard("OnUpdate", func() {}) {
+		t.Fatal("guard reported a panic for a callback that returned")
+	}
+
+	if a.guard("OnDraw", func() { panic(io.EOF) }) {
+		t.Fatal("guard did not report the panic")
+	}
+	if a.running {
+		t.Error("main loop still running after a panic")
+	}
+
+	// No callbacks are called after a panic
+	called := false
+	if a.guard("OnUpdate", func() { called = true }) || called {
+		t.Error("callback called after a panic")
+	}
+
+	var reported error
+	a.OnError(func(err error) { reported = err })
+	err := a.reportPanic()
+	if err == nil || reported != err {
+		t.Fatalf("reportPanic returned %v, OnError got %v", err, reported)
+	}
+	var p *PanicError
+	if !errors.As(err, &p) || p.Callback != "OnDraw" || !errors.Is(err, io.EOF) {
+		t.Errorf("error = %#v, want a PanicError of OnDraw wrapping io.EOF", err)
+	}
+	if !strings.Contains(string(p.Stack), "TestGuard") {
+		t.Errorf("stack does not show the panicking callback:\n%s", p.Stack)
+	}
+	if a.reportPanic() != nil {
+		t.Error("panic reported twice")
+	}
+}
@@ -0,0 +1,51 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// PenEvent describes stylus input from a graphics tablet or pen display.
+// Coordinates are in window pixels; pressure, distance and tilt are
+// only meaningful if the tool reports them.
+type PenEvent = platform.PenEvent
+
+// PenPhase describes what changed in a PenEvent.
+type PenPhase = platform.PenPhase
+
+// PenTool identifies the physical stylus tool.
+type PenTool = platform.PenTool
+
+// PenButtons is a bitmask of stylus barrel buttons.
+type PenButtons = platform.PenButtons
+
+// Pen phases.
+const (
+	PenPhaseMove         = platform.PenPhaseMove
+	PenPhaseProximityIn  = platform.PenPhaseProximityIn
+	PenPhaseProximityOut = platform.PenPhaseProximityOut
+	PenPhaseDown         = platform.PenPhaseDown
+	PenPhaseUp           = platform.PenPhaseUp
+)
+
+// Pen tools.
+const (
+	PenToolPen      = platform.PenToolPen
+	PenToolEraser   = platform.PenToolEraser
+	PenToolBrush    = platform.PenToolBrush
+	PenToolPencil   = platform.PenToolPencil
+	PenToolAirbrush = platform.PenToolAirbrush
+	PenToolOther    = platform.PenToolOther
+)
+
+// Pen barrel buttons.
+const (
+	PenButtonPrimary   = platform.PenButtonPrimary
+	PenButtonSecondary = platform.PenButtonSecondary
+	PenButtonTertiary  = platform.PenButtonTertiary
+)
+
+// OnPen sets the callback for stylus input.
+// Currently delivered on Wayland compositors supporting tablet-v2 and on
+// Windows 8 and later.
+func (a *App) OnPen(fn func(PenEvent)) *App {
+	a.onPen = fn
+	return a
+}
@@ -0,0 +1,32 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// ProgressState is the state of the taskbar progress indicator.
+type ProgressState = platform.ProgressState
+
+// Progress states for SetTaskbarProgress.
+const (
+	ProgressNone          = platform.ProgressNone
+	ProgressNormal        = platform.ProgressNormal
+	ProgressIndeterminate = platform.ProgressIndeterminate
+	ProgressPaused        = platform.ProgressPaused
+	ProgressError         = platform.ProgressError
+)
+
+// SetTaskbarProgress shows the progress of a long-running task, such as an
+// export, from 0 to 1 on the application's taskbar button, so it can be
+// followed while the window is in the background. ProgressIndeterminate
+// ignores progress, and ProgressNone removes the indicator.
+// Returns ErrPlatformNotSupported if the platform has no such indicator
+// (currently shown on Windows).
+func (a *App) SetTaskbarProgress(state ProgressState, progress float64) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	p, ok := a.platform.(platform.ProgressIndicator)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	return platformError(p.SetProgress(state, progress))
+}
@@ -0,0 +1,200 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// maxPushConstantSize is the push constant size requested from devices
+// that support them, the minimum every Vulkan driver provides.
+const maxPushConstantSize = 128
+
+// PushConstants passes a small block of per-draw data, like a transform,
+// to shaders without creating and binding a bind group for each draw.
+//
+// With types.FeaturePushConstants, which the renderer enables when the
+// adapter has it, the data is set as push constants. Otherwise it falls
+// back to a dynamic uniform buffer with one slot per draw, bound at a
+// fixed group index with a dynamic offset. Shaders declare the data with
+// Declaration and pipelines use the layout from PipelineLayout, so the
+// same code works with both.
+type PushConstants struct {
+	size   uint32
+	stages types.ShaderStage
+	group  uint32
+	native bool
+
+	// Dynamic uniform buffer fallback
+	layout    types.BindGroupLayout
+	buffer    *Buffer
+	bindGroup types.BindGroup
+	stride    uint32
+	capacity  uint32
+	next      uint32
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// NewPushConstants creates push constants of size bytes for the shader
+// stages. group is the bind group index the fallback uses, and capacity
+// the number of Set calls the fallback holds until Reset.
+func (r *Renderer) NewPushConstants(size uint32, stages types.ShaderStage, group, capacity uint32) (*PushConstants, error) {
+	p := &PushConstants{
+		size:     size,
+		stages:   stages,
+		group:    group,
+		renderer: r,
+	}
+	if r.Features().Has(types.FeaturePushConstants) && size <= r.Limits().MaxPushConstantSize {
+		p.native = true
+		return p, nil
+	}
+
+	if err := p.initFallback(capacity); err != nil {
+		p.Destroy()
+		return nil, err
+	}
+	return p, nil
+}
+
+// initFallback creates the dynamic uniform buffer and its bind group.
+func (p *PushConstants) initFallback(capacity uint32) error {
+	r := p.renderer
+	p.stride = alignUp(p.size, r.Limits().MinUniformBufferOffsetAlignment)
+	p.capacity = capacity
+
+	var err error
+	p.layout, err = r.backend.CreateBindGroupLayout(r.device, &types.BindGroupLayoutDescriptor{
+		Entries: []types.BindGroupLayoutEntry{{
+			Binding:    0,
+			Visibility: p.stages,
+			Buffer: &types.BufferBindingLayout{
+				Type:             types.BufferBindingTypeUniform,
+				HasDynamicOffset: true,
+				MinBindingSize:   uint64(p.size),
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("gogpu: failed to create push constant layout: %w", err)
+	}
+
+	p.buffer, err = r.NewBuffer(uint64(p.stride)*uint64(capacity), types.BufferUsageUniform|types.BufferUsageCopyDst)
+	if err != nil {
+		return err
+	}
+
+	p.bindGroup, err = r.backend.CreateBindGroup(r.device, &types.BindGroupDescriptor{
+		Layout: p.layout,
+		Entries: []types.BindGroupEntry{{
+			Binding: 0,
+			Buffer:  p.buffer.buffer,
+			Size:    uint64(p.size),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("gogpu: failed to create push constant bind group: %w", err)
+	}
+	return nil
+}
+
+// Native reports whether push constants are used rather than the
+// dynamic uniform buffer fallback.
+func (p *PushConstants) Native() bool {
+	return p.native
+}
+
+// Size returns the size of the data in bytes.
+func (p *PushConstants) Size() uint32 {
+	return p.size
+}
+
+// Declaration returns the WGSL declaration of the data as a variable
+// name of type typ, to be inserted into shader source.
+func (p *PushConstants) Declaration(name, typ string) string {
+	return pushConstantDeclaration(p.native, p.group, name, typ)
+}
+
+// pushConstantDeclaration returns the WGSL declaration of push constants
+// or of their fallback uniform at group.
+func pushConstantDeclaration(native bool, group uint32, name, typ string) string {
+	if native {
+		return fmt.Sprintf("var<push_constant> %s: %s;", name, typ)
+	}
+	return fmt.Sprintf("@group(%d) @binding(0) var<uniform> %s: %s;", group, name, typ)
+}
+
+// PipelineLayout creates a pipeline layout with the bind group layouts of
+// groups 0 to the push constant group, which may not be more, and the
+// push constants. Release it with the backend once the pipelines exist.
+func (p *PushConstants) PipelineLayout(groups ...types.BindGroupLayout) (types.PipelineLayout, error) {
+	if uint32(len(groups)) != p.group { //nolint:gosec // G115: few bind groups
+		return 0, fmt.Errorf("gogpu: push constants use group %d, got %d bind group layouts", p.group, len(groups))
+	}
+
+	desc := &types.PipelineLayoutDescriptor{}
+	if p.native {
+		desc.BindGroupLayouts = groups
+		desc.PushConstantRanges = []types.PushConstantRange{{Stages: p.stages, End: p.size}}
+	} else {
+		desc.BindGroupLayouts = append(append([]types.BindGroupLayout(nil), groups...), p.layout)
+	}
+
+	layout, err := p.renderer.backend.CreatePipelineLayout(p.renderer.device, desc)
+	if err != nil {
+		return 0, fmt.Errorf("gogpu: failed to create pipeline layout: %w", err)
+	}
+	return layout, nil
+}
+
+// Set sets the data for the following draws recorded with enc. data must
+// be Size bytes. The fallback uses a new slot each call, see Reset.
+func (p *PushConstants) Set(enc *RenderBundleEncoder, data []byte) error {
+	if uint32(len(data)) != p.size { //nolint:gosec // G115: checked against a uint32
+		return fmt.Errorf("gogpu: push constant data is %d bytes, want %d", len(data), p.size)
+	}
+
+	if p.native {
+		p.renderer.backend.SetBundlePushConstants(enc.encoder, p.stages, 0, data)
+		return nil
+	}
+
+	if p.next >= p.capacity {
+		return fmt.Errorf("gogpu: push constant capacity of %d exceeded", p.capacity)
+	}
+	offset := p.next * p.stride
+	p.next++
+
+	p.buffer.Write(uint64(offset), data)
+	p.renderer.backend.SetBundleBindGroup(enc.encoder, p.group, p.bindGroup, []uint32{offset})
+	return nil
+}
+
+// Reset makes all fallback slots available again. Bundles recorded with
+// the previous slots must not be executed afterwards.
+func (p *PushConstants) Reset() {
+	p.next = 0
+}
+
+// Destroy releases the fallback resources. After calling Destroy, the
+// push constants should not be used.
+func (p *PushConstants) Destroy() {
+	if p.renderer == nil || p.renderer.backend == nil {
+		return
+	}
+
+	if p.bindGroup != 0 {
+		p.renderer.backend.ReleaseBindGroup(p.bindGroup)
+		p.bindGroup = 0
+	}
+	if p.buffer != nil {
+		p.buffer.Destroy()
+		p.buffer = nil
+	}
+	if p.layout != 0 {
+		p.renderer.backend.ReleaseBindGroupLayout(p.layout)
+		p.layout = 0
+	}
+}
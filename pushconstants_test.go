@@ -0,0 +1,37 @@
+package gogpu
+
+import "testing"
+
+func TestPushConstantDeclaration(t *testing.T) {
+	tests := []struct {
+		native bool
+		group  uint32
+		want   string
+	}{
+		{true, 1, "var<push_constant> draw: DrawData;"},
+		{false, 1, "@group(1) @binding(0) var<uniform> draw: DrawData;"},
+	}
+
+	for _, tt := range tests {
+		if got := pushConstantDeclaration(tt.native, tt.group, "draw", "DrawData"); got != tt.want {
+			t.Errorf("pushConstantDeclaration(%v, %d) = %q, want %q", tt.native, tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestAlignUp(t *testing.T) {
+	tests := []struct {
+		n, align, want uint32
+	}{
+		{64, 256, 256},
+		{256, 256, 256},
+		{300, 256, 512},
+		{64, 0, 64},
+	}
+
+	for _, tt := range tests {
+		if got := alignUp(tt.n, tt.align); got != tt.want {
+			t.Errorf("alignUp(%d, %d) = %d, want %d", tt.n, tt.align, got, tt.want)
+		}
+	}
+}
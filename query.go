@@ -0,0 +1,106 @@
+package gogpu
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// QuerySet is a set of GPU timestamp or occlusion queries.
+// Create one with Renderer.NewQuerySet.
+type QuerySet struct {
+	querySet  types.QuerySet
+	queryType types.QueryType
+	count     uint32
+
+	// Reference to renderer for resource management
+	renderer *Renderer
+}
+
+// NewQuerySet creates a set of count queries. Timestamp queries need a
+// device with the timestamp query feature.
+func (r *Renderer) NewQuerySet(queryType types.QueryType, count uint32) (*QuerySet, error) {
+	querySet, err := r.backend.CreateQuerySet(r.device, &types.QuerySetDescriptor{
+		Type:  queryType,
+		Count: count,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create query set: %w", err)
+	}
+
+	return &QuerySet{
+		querySet:  querySet,
+		queryType: queryType,
+		count:     count,
+		renderer:  r,
+	}, nil
+}
+
+// Handle returns the underlying GPU query set handle.
+// For advanced use cases that need direct GPU access.
+func (q *QuerySet) Handle() types.QuerySet {
+	return q.querySet
+}
+
+// Type returns the kind of queries in the set.
+func (q *QuerySet) Type() types.QueryType {
+	return q.queryType
+}
+
+// Count returns the number of queries in the set.
+func (q *QuerySet) Count() uint32 {
+	return q.count
+}
+
+// Destroy releases the query set. After calling Destroy, the query set
+// should not be used.
+func (q *QuerySet) Destroy() {
+	if q.renderer == nil || q.renderer.backend == nil {
+		return
+	}
+
+	if q.querySet != 0 {
+		q.renderer.backend.ReleaseQuerySet(q.querySet)
+		q.querySet = 0
+	}
+}
+
+// WriteTimestamp records the GPU time into query index of a timestamp
+// query set once the work submitted before it is done. The difference
+// of two timestamps around a Compute or draw call measures its GPU time.
+func (r *Renderer) WriteTimestamp(querySet *QuerySet, index uint32) error {
+	return r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.WriteTimestamp(encoder, querySet.querySet, index)
+	})
+}
+
+// ReadQueryResults resolves count queries starting at first and waits
+// until their results are available: nanoseconds for timestamp queries,
+// passed samples for occlusion queries.
+func (r *Renderer) ReadQueryResults(querySet *QuerySet, first, count uint32) ([]uint64, error) {
+	size := uint64(count) * types.QueryResultSize
+	resolve, err := r.NewBuffer(size, types.BufferUsageQueryResolve|types.BufferUsageCopySrc)
+	if err != nil {
+		return nil, err
+	}
+	defer resolve.Destroy()
+
+	err = r.submitCommands(func(encoder types.CommandEncoder) {
+		r.backend.ResolveQuerySet(encoder, querySet.querySet, first, count, resolve.buffer, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.ReadBuffer(resolve, 0, size)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]uint64, count)
+	for i := range results {
+		results[i] = binary.LittleEndian.Uint64(data[i*types.QueryResultSize:])
+	}
+	return results, nil
+}
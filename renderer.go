@@ -2,10 +2,14 @@ package gogpu
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 
+	"github.com/gogpu/gogpu/gmath"
 	"github.com/gogpu/gogpu/gpu"
 	"github.com/gogpu/gogpu/gpu/backend/native"
 	"github.com/gogpu/gogpu/gpu/backend/rust"
+	"github.com/gogpu/gogpu/gpu/backend/web"
 	"github.com/gogpu/gogpu/gpu/types"
 	"github.com/gogpu/gogpu/internal/platform"
 )
@@ -21,73 +25,201 @@ type Renderer struct {
 	adapter  types.Adapter
 	device   types.Device
 	queue    types.Queue
-	surface  types.Surface
 
-	// Surface configuration
-	format            types.TextureFormat
-	width             uint32
-	height            uint32
-	surfaceConfigured bool // Whether surface has been configured with valid dimensions
+	// Surface configuration. Frames are drawn in format, through views
+	// of the surface textures when surfaceFormat differs, see SrgbPolicy.
+	format        types.TextureFormat
+	surfaceFormat types.TextureFormat
+	alphaMode     types.AlphaMode
+	presentMode   types.PresentMode
+	surfaceCaps   types.SurfaceCapabilities
 
-	// Current frame state
-	currentTexture types.Texture
-	currentView    types.TextureView
+	// Surface frames are drawn to: mainFrame, or that of an additional
+	// window while it is drawn, see Window
+	frame     *surfaceState
+	mainFrame surfaceState
+
+	// Color target frames are drawn into without a surface, see
+	// NewHeadlessRenderer
+	target *Texture
+
+	// Samples of the color target; frames are resolved from a
+	// multisampled target when sampleCount > 1
+	sampleCount uint32
 
 	// Built-in pipelines
 	trianglePipeline types.RenderPipeline
 	triangleShader   types.ShaderModule
 
+	// Shader modules and pipelines of the device, see PipelineCache
+	pipelines *gpu.PipelineCache
+
+	// Transfer queues, flushed by EndFrame, see NewTransferQueue
+	transfers []*TransferQueue
+
+	// Pending loss of the device, set from the device-lost callback,
+	// which may run on any goroutine
+	lostMu sync.Mutex
+	lost   *deviceLoss
+
+	// Submission tracking, see submission.go. Callbacks of the backend
+	// may run on any goroutine.
+	subMu        sync.Mutex
+	submitted    types.SubmissionIndex
+	completed    types.SubmissionIndex
+	subBase      types.SubmissionIndex // Added to backend indices; submissions of lost devices
+	subCallbacks []submissionCallback  // Sorted by index
+
+	// Staging ring for dynamic data, created on first use, see StagingRing
+	staging *StagingRing
+
+	// GPU time of frames, see FrameStats; created on first use
+	gpuTimer       *gpuFrameTimer
+	gpuTimerFailed bool // No timestamp queries, or creating the timer failed
+
 	// Platform reference
 	platform platform.Platform
-}
 
-// newRenderer creates and initializes a new renderer.
-func newRenderer(plat platform.Platform, backendType types.BackendType) (*Renderer, error) {
-	// Create backend based on type
-	backend, err := createBackend(backendType)
-	if err != nil {
-		return nil, err
-	}
+	// How the backend was chosen, see BackendReport
+	report *BackendReport
 
-	r := &Renderer{
-		backend:  backend,
-		platform: plat,
-	}
+	// Called with the resources not released, see Config.LeakDetection
+	onLeaks func(leaks []gpu.Leak)
 
-	if err := r.init(); err != nil {
-		backend.Destroy()
-		return nil, err
-	}
+	// Recording of the backend calls, see Config.TraceFile
+	recorder  *gpu.Recorder
+	traceFile string
+	traceErr  error
+}
 
-	return r, nil
+// surfaceState is a surface and the state of the frames drawn to it. The
+// format and modes of the surface are those of the Renderer.
+type surfaceState struct {
+	surface           types.Surface
+	width             uint32
+	height            uint32
+	surfaceConfigured bool // Whether surface has been configured with valid dimensions
+
+	// Current frame
+	currentTexture types.Texture
+	currentView    types.TextureView
+
+	// Multisampled color target, resolved into the surface texture.
+	// Only used when sampleCount > 1.
+	msaaTexture types.Texture
+	msaaView    types.TextureView
+	msaaSize    [2]uint32 // Size msaaTexture was created with
 }
 
-// createBackend creates a backend of the specified type.
-func createBackend(typ types.BackendType) (gpu.Backend, error) {
-	switch typ {
-	case types.BackendRust:
-		if !rust.IsAvailable() {
-			return nil, fmt.Errorf("rust backend not available on this platform")
+// newRenderer creates and initializes a new renderer with the backend,
+// sample count and device features of config. Backends are tried in
+// the order of backendCandidates until one initializes; the renderer's
+// BackendReport records why the others were rejected. A transparent
+// window gets a premultiplied-alpha surface so the frame's alpha
+// reaches the compositor.
+func newRenderer(plat platform.Platform, config Config) (*Renderer, error) {
+	report := &BackendReport{}
+	for _, c := range backendCandidates(config) {
+		if !c.available() {
+			report.reject(c.name, c.unavailable)
+			continue
+		}
+		backend := c.create()
+		if cv, ok := backend.(gpu.CommandValidation); ok && config.Validation {
+			cv.EnableValidation()
 		}
-		return rust.New(), nil
-	case types.BackendGo:
-		return native.New(), nil
-	case types.BackendAuto:
-		// Auto: prefer Rust backend if available, fallback to native
-		if rust.IsAvailable() {
-			return rust.New(), nil
+		if fp, ok := backend.(gpu.FramePacer); ok && config.FramesInFlight > 0 {
+			fp.SetMaxFramesInFlight(config.FramesInFlight)
+		}
+
+		r := &Renderer{
+			backend:   backend,
+			platform:  plat,
+			alphaMode: types.AlphaModeOpaque,
+			report:    report,
 		}
-		return native.New(), nil
-	default:
-		if rust.IsAvailable() {
-			return rust.New(), nil
+		r.frame = &r.mainFrame
+		if ld, ok := backend.(gpu.LeakDetector); ok && config.LeakDetection {
+			ld.EnableLeakDetection(r.reportLeaks)
 		}
-		return native.New(), nil
+		if config.TraceFile != "" {
+			r.recorder = gpu.NewRecorder(backend)
+			r.backend = r.recorder
+			r.traceFile = config.TraceFile
+		}
+		if config.Transparent {
+			r.alphaMode = types.AlphaModePremultiplied
+		}
+
+		err := r.init(config)
+		if rr, ok := backend.(gpu.RejectionReporter); ok {
+			report.Rejected = append(report.Rejected, rr.Rejections()...)
+		}
+		if err == nil {
+			report.Selected = backend.Name()
+			return r, nil
+		}
+		backend.Destroy()
+		report.reject(c.name, err)
+	}
+	return nil, &BackendError{Report: report}
+}
+
+// backendCandidate is a backend newRenderer may try.
+type backendCandidate struct {
+	typ         types.BackendType
+	name        string
+	available   func() bool
+	unavailable error // Rejection reason when !available()
+	create      func() gpu.Backend
+}
+
+// backendChain lists the backends in the order BackendAuto tries them:
+// the browser's WebGPU in js/wasm builds, then the Rust backend, then
+// the native backend, which itself falls back from Vulkan, Metal or
+// Direct3D 12 to OpenGL and, when built with it, software rendering.
+var backendChain = []backendCandidate{
+	{
+		typ:         types.BackendWeb,
+		name:        "web",
+		available:   web.IsAvailable,
+		unavailable: fmt.Errorf("web backend not available: requires a js/wasm build in a browser with WebGPU"),
+		create:      func() gpu.Backend { return web.New() },
+	},
+	{
+		typ:         types.BackendRust,
+		name:        "rust",
+		available:   rust.IsAvailable,
+		unavailable: fmt.Errorf("rust backend not available on this platform"),
+		create:      func() gpu.Backend { return rust.New() },
+	},
+	{
+		typ:       types.BackendGo,
+		name:      "native",
+		available: func() bool { return true },
+		create:    func() gpu.Backend { return native.New() },
+	},
+}
+
+// backendCandidates returns the backends to try for config, in order.
+// An explicit Config.Backend is tried alone unless BackendFallback is
+// set, in which case the rest of the chain follows it.
+func backendCandidates(config Config) []backendCandidate {
+	i := slices.IndexFunc(backendChain, func(c backendCandidate) bool {
+		return c.typ == config.Backend
+	})
+	if i < 0 {
+		// BackendAuto, or an unknown type
+		return backendChain
 	}
+	if !config.BackendFallback {
+		return backendChain[i : i+1]
+	}
+	return append(backendChain[i:i+1:i+1], slices.Delete(slices.Clone(backendChain), i, i+1)...)
 }
 
 // init initializes WebGPU and creates the rendering pipeline.
-func (r *Renderer) init() error {
+func (r *Renderer) init(config Config) error {
 	var err error
 
 	// Initialize backend
@@ -101,18 +233,39 @@ func (r *Renderer) init() error {
 		return fmt.Errorf("gogpu: failed to create instance: %w", err)
 	}
 
+	// Without a window the frames go to r.target instead of a surface
+	if r.platform == nil {
+		return r.createDevice(config)
+	}
+
 	// Get platform handles for surface creation
 	hinstance, hwnd := r.platform.GetHandle()
 
 	// Create surface
-	r.surface, err = r.backend.CreateSurface(r.instance, types.SurfaceHandle{
-		Instance: hinstance,
-		Window:   hwnd,
-	})
+	r.frame.surface, err = r.backend.CreateSurface(r.instance, r.surfaceHandle(hinstance, hwnd))
 	if err != nil {
 		return fmt.Errorf("gogpu: failed to create surface: %w", err)
 	}
 
+	return r.createDevice(config)
+}
+
+// surfaceHandle returns the surface handle of a window of the platform
+// from its platform handles, marking those of Wayland windows.
+func (r *Renderer) surfaceHandle(instance, window uintptr) types.SurfaceHandle {
+	wh, ok := r.platform.(platform.WaylandHandles)
+	return types.SurfaceHandle{
+		Instance: instance,
+		Window:   window,
+		Wayland:  ok && wh.WaylandHandles(),
+	}
+}
+
+// createDevice requests an adapter and device and configures the surface
+// for them. Called again to replace a lost device, see restore.
+func (r *Renderer) createDevice(config Config) error {
+	var err error
+
 	// Request adapter
 	r.adapter, err = r.backend.RequestAdapter(r.instance, &types.AdapterOptions{
 		PowerPreference: types.PowerPreferenceHighPerformance,
@@ -122,40 +275,63 @@ func (r *Renderer) init() error {
 	}
 
 	// Request device
-	r.device, err = r.backend.RequestDevice(r.adapter, nil)
+	adapterFeatures := r.backend.GetAdapterFeatures(r.adapter)
+	if missing := config.RequiredFeatures &^ adapterFeatures; missing != 0 {
+		return fmt.Errorf("gogpu: adapter does not support required features %#x", uint64(missing))
+	}
+	opts := &types.DeviceOptions{
+		RequiredFeatures: config.RequiredFeatures,
+	}
+	if adapterFeatures.Has(types.FeaturePushConstants) {
+		// Enabled when available, see NewPushConstants
+		limits := types.DefaultLimits()
+		limits.MaxPushConstantSize = min(r.backend.GetAdapterLimits(r.adapter).MaxPushConstantSize, maxPushConstantSize)
+		opts.RequiredFeatures |= types.FeaturePushConstants
+		opts.RequiredLimits = &limits
+	}
+	r.device, err = r.backend.RequestDevice(r.adapter, opts)
 	if err != nil {
 		return fmt.Errorf("gogpu: failed to request device: %w", err)
 	}
 
 	// Get queue
 	r.queue = r.backend.GetQueue(r.device)
+	r.pipelines = gpu.NewPipelineCache(r.backend, r.device)
+	r.backend.SetDeviceLostCallback(r.device, r.handleDeviceLost)
 
 	// Configure surface
 	// Get current window dimensions. On some platforms (especially macOS),
 	// the window may not have valid dimensions immediately after creation.
 	// In that case, we defer surface configuration until the first Resize event.
-	width, height := r.platform.GetSize()
-
-	// Use BGRA8Unorm which is common across platforms
-	r.format = types.TextureFormatBGRA8Unorm
+	width, height := config.Width, config.Height
+	if r.frame.surface != 0 {
+		width, height = r.platform.GetSize()
+
+		// Negotiate format and modes with what the surface supports
+		r.surfaceCaps = r.backend.GetSurfaceCapabilities(r.frame.surface, r.adapter)
+		r.surfaceFormat, r.format = pickSurfaceFormat(r.surfaceCaps.Formats, config.SrgbPolicy)
+		r.presentMode = pickPresentMode(r.surfaceCaps.PresentModes, config.VSync)
+		r.alphaMode = pickAlphaMode(r.surfaceCaps.AlphaModes, r.alphaMode)
+	} else {
+		// Headless frames can be read back with ReadFrame
+		r.format = types.TextureFormatRGBA8Unorm
+		if config.SrgbPolicy == types.SrgbPolicyEncode {
+			r.format = r.format.Srgb()
+		}
+		r.surfaceFormat = r.format
+	}
+	r.sampleCount = pickSampleCount(config.SampleCount, r.backend.SupportedSampleCounts(r.adapter, r.format))
 
 	// Only configure surface if dimensions are valid.
 	// If dimensions are zero (window not yet visible, minimized, or timing issue),
 	// defer configuration until Resize is called with valid dimensions.
 	// This matches wgpu-core behavior which returns ConfigureSurfaceError::ZeroArea.
 	if width > 0 && height > 0 {
-		r.width = uint32(width)   //nolint:gosec // G115: validated positive above
-		r.height = uint32(height) //nolint:gosec // G115: validated positive above
-
-		r.backend.ConfigureSurface(r.surface, r.device, &types.SurfaceConfig{
-			Format:      r.format,
-			Usage:       types.TextureUsageRenderAttachment,
-			Width:       r.width,
-			Height:      r.height,
-			AlphaMode:   types.AlphaModeOpaque,
-			PresentMode: types.PresentModeFifo, // VSync
-		})
-		r.surfaceConfigured = true
+		r.frame.width = uint32(width)   //nolint:gosec // G115: validated positive above
+		r.frame.height = uint32(height) //nolint:gosec // G115: validated positive above
+
+		r.configureSurface()
+		r.frame.surfaceConfigured = true
 	}
 	// If dimensions are zero, surfaceConfigured remains false.
 	// The surface will be configured on the first Resize event with valid dimensions.
@@ -172,18 +348,217 @@ func (r *Renderer) Resize(width, height int) {
 	}
 
 	// Note: width/height validated positive above
-	r.width = uint32(width)   //nolint:gosec // G115: validated positive above
-	r.height = uint32(height) //nolint:gosec // G115: validated positive above
+	r.frame.width = uint32(width)   //nolint:gosec // G115: validated positive above
+	r.frame.height = uint32(height) //nolint:gosec // G115: validated positive above
+
+	r.configureSurface()
+	r.frame.surfaceConfigured = true
+}
 
-	r.backend.ConfigureSurface(r.surface, r.device, &types.SurfaceConfig{
-		Format:      r.format,
+// configureSurface (re)configures the surface at the current size. A
+// headless renderer has no surface; its target follows the size on the
+// next BeginFrame.
+func (r *Renderer) configureSurface() {
+	if r.frame.surface == 0 {
+		return
+	}
+	config := &types.SurfaceConfig{
+		Format:      r.surfaceFormat,
 		Usage:       types.TextureUsageRenderAttachment,
-		Width:       r.width,
-		Height:      r.height,
-		AlphaMode:   types.AlphaModeOpaque,
-		PresentMode: types.PresentModeFifo,
+		Width:       r.frame.width,
+		Height:      r.frame.height,
+		AlphaMode:   r.alphaMode,
+		PresentMode: r.presentMode,
+	}
+	if r.format != r.surfaceFormat {
+		config.ViewFormats = []types.TextureFormat{r.format}
+	}
+	r.backend.ConfigureSurface(r.frame.surface, r.device, config)
+}
+
+// pickSampleCount returns the highest supported sample count that does
+// not exceed the requested one, or 1.
+func pickSampleCount(requested int, supported []uint32) uint32 {
+	best := uint32(1)
+	for _, count := range supported {
+		if int(count) <= requested && count > best {
+			best = count
+		}
+	}
+	return best
+}
+
+// pickSurfaceFormat returns the format to configure the surface with
+// and the format frames are drawn in. It prefers BGRA8Unorm, which is
+// common across platforms, then RGBA8Unorm, else the backend's preferred
+// format; without capabilities BGRA8Unorm is assumed. With
+// SrgbPolicyEncode the sRGB variants are drawn in, as surface formats
+// where supported, else as view formats.
+func pickSurfaceFormat(supported []types.TextureFormat, policy types.SrgbPolicy) (surface, view types.TextureFormat) {
+	candidates := []types.TextureFormat{types.TextureFormatBGRA8Unorm, types.TextureFormatRGBA8Unorm}
+	if policy == types.SrgbPolicyEncode {
+		for _, want := range candidates {
+			if slices.Contains(supported, want.Srgb()) {
+				return want.Srgb(), want.Srgb()
+			}
+		}
+	}
+
+	format := types.TextureFormatBGRA8Unorm
+	if i := slices.IndexFunc(candidates, func(f types.TextureFormat) bool { return slices.Contains(supported, f) }); i >= 0 {
+		format = candidates[i]
+	} else if len(supported) > 0 {
+		format = supported[0]
+	}
+	if policy == types.SrgbPolicyEncode {
+		return format, format.Srgb()
+	}
+	return format, format
+}
+
+// pickPresentMode returns Fifo with vsync. Without it, Mailbox avoids
+// tearing where available, then Immediate. Fifo is always supported.
+func pickPresentMode(supported []types.PresentMode, vsync bool) types.PresentMode {
+	if !vsync {
+		for _, want := range []types.PresentMode{types.PresentModeMailbox, types.PresentModeImmediate} {
+			if slices.Contains(supported, want) {
+				return want
+			}
+		}
+	}
+	return types.PresentModeFifo
+}
+
+// pickAlphaMode returns want if supported, else Opaque or the backend's
+// preferred mode. Without capabilities want is assumed to work.
+func pickAlphaMode(supported []types.AlphaMode, want types.AlphaMode) types.AlphaMode {
+	if len(supported) == 0 || slices.Contains(supported, want) {
+		return want
+	}
+	if slices.Contains(supported, types.AlphaModeOpaque) {
+		return types.AlphaModeOpaque
+	}
+	return supported[0]
+}
+
+// AdapterInfo returns information about the GPU in use.
+func (r *Renderer) AdapterInfo() types.AdapterInfo {
+	return r.backend.GetAdapterInfo(r.adapter)
+}
+
+// AdapterFeatures returns the optional features the GPU supports. Only
+// those in Config.RequiredFeatures are enabled, see Features.
+func (r *Renderer) AdapterFeatures() types.Features {
+	return r.backend.GetAdapterFeatures(r.adapter)
+}
+
+// Features returns the optional features enabled on the device.
+func (r *Renderer) Features() types.Features {
+	return r.backend.GetDeviceFeatures(r.device)
+}
+
+// Limits returns the resource limits of the device.
+func (r *Renderer) Limits() types.Limits {
+	return r.backend.GetDeviceLimits(r.device)
+}
+
+// PipelineCache returns the device's cache of shader modules and
+// pipelines. Creating pipelines through it compiles each distinct shader
+// and pipeline state once, so callers can request them every frame
+// without compile hitches. The cache is replaced with the device after a
+// device loss.
+func (r *Renderer) PipelineCache() *gpu.PipelineCache {
+	return r.pipelines
+}
+
+// SampleCount returns the number of samples per pixel frames are drawn
+// with: 1 without MSAA. Pipelines drawing into the frame must use it as
+// their multisample count.
+func (r *Renderer) SampleCount() uint32 {
+	return r.sampleCount
+}
+
+// ensureMSAATarget (re)creates the multisampled color target when MSAA is
+// enabled and the surface size changed.
+func (r *Renderer) ensureMSAATarget() error {
+	if r.sampleCount <= 1 || r.frame.msaaSize == [2]uint32{r.frame.width, r.frame.height} {
+		return nil
+	}
+	r.releaseMSAATarget()
+
+	texture, err := r.backend.CreateTexture(r.device, &types.TextureDescriptor{
+		Label: "msaa_color",
+		Size: types.Extent3D{
+			Width:              r.frame.width,
+			Height:             r.frame.height,
+			DepthOrArrayLayers: 1,
+		},
+		MipLevelCount: 1,
+		SampleCount:   r.sampleCount,
+		Dimension:     types.TextureDimension2D,
+		Format:        r.format,
+		Usage:         types.TextureUsageRenderAttachment,
 	})
-	r.surfaceConfigured = true
+	if err != nil {
+		return fmt.Errorf("gogpu: failed to create MSAA target: %w", err)
+	}
+
+	view := r.backend.CreateTextureView(texture, nil)
+	if view == 0 {
+		r.backend.ReleaseTexture(texture)
+		return fmt.Errorf("gogpu: failed to create MSAA target view")
+	}
+
+	r.frame.msaaTexture, r.frame.msaaView = texture, view
+	r.frame.msaaSize = [2]uint32{r.frame.width, r.frame.height}
+	return nil
+}
+
+// releaseMSAATarget releases the multisampled color target.
+func (r *Renderer) releaseMSAATarget() {
+	if r.frame.msaaView != 0 {
+		r.backend.ReleaseTextureView(r.frame.msaaView)
+		r.frame.msaaView = 0
+	}
+	if r.frame.msaaTexture != 0 {
+		r.backend.ReleaseTexture(r.frame.msaaTexture)
+		r.frame.msaaTexture = 0
+	}
+	r.frame.msaaSize = [2]uint32{}
+}
+
+// colorAttachment returns the frame's color attachment. With MSAA it
+// draws into the multisampled target and resolves into the surface
+// texture. The samples are stored so later passes of the frame that
+// load them, like ExecuteBundles, keep what was drawn before.
+func (r *Renderer) colorAttachment(loadOp types.LoadOp, clear types.Color) types.ColorAttachment {
+	if r.frame.msaaView != 0 {
+		return types.ColorAttachment{
+			View:          r.frame.msaaView,
+			ResolveTarget: r.frame.currentView,
+			LoadOp:        loadOp,
+			StoreOp:       types.StoreOpStore,
+			ClearValue:    clear,
+		}
+	}
+	return types.ColorAttachment{
+		View:       r.frame.currentView,
+		LoadOp:     loadOp,
+		StoreOp:    types.StoreOpStore,
+		ClearValue: clear,
+	}
+}
+
+// ResetSurface reconfigures the surface so it gets fresh drawables,
+// dropping any frame in progress. Used after the system wakes or the
+// displays are reconfigured (e.g. a GPU switch), when the old
+// drawables may be dead and presenting them shows nothing.
+func (r *Renderer) ResetSurface() {
+	r.releaseFrame()
+
+	if r.frame.surfaceConfigured && r.frame.width > 0 && r.frame.height > 0 {
+		r.configureSurface()
+	}
 }
 
 // BeginFrame prepares a new frame for rendering.
@@ -191,57 +566,95 @@ func (r *Renderer) Resize(width, height int) {
 func (r *Renderer) BeginFrame() bool {
 	// Skip if surface is not configured yet.
 	// This happens when the window has zero dimensions (minimized, not yet visible).
-	if !r.surfaceConfigured {
+	if !r.frame.surfaceConfigured {
 		return false
 	}
+	if r.frame.surface == 0 {
+		return r.beginHeadlessFrame()
+	}
 
-	surfTex, err := r.backend.GetCurrentTexture(r.surface)
+	surfTex, err := r.backend.GetCurrentTexture(r.frame.surface)
 	if err != nil || surfTex.Status != types.SurfaceStatusSuccess {
 		// Surface needs reconfiguration.
 		// Only attempt if we have valid dimensions.
-		if r.width > 0 && r.height > 0 {
-			r.backend.ConfigureSurface(r.surface, r.device, &types.SurfaceConfig{
-				Format:      r.format,
-				Usage:       types.TextureUsageRenderAttachment,
-				Width:       r.width,
-				Height:      r.height,
-				AlphaMode:   types.AlphaModeOpaque,
-				PresentMode: types.PresentModeFifo,
-			})
+		if r.frame.width > 0 && r.frame.height > 0 {
+			r.configureSurface()
 		}
 		return false
 	}
 
-	r.currentTexture = surfTex.Texture
+	r.frame.currentTexture = surfTex.Texture
 
 	// Create texture view for rendering
-	r.currentView = r.backend.CreateTextureView(r.currentTexture, nil)
-	return r.currentView != 0
+	var viewDesc *types.TextureViewDescriptor // nil = surface format
+	if r.format != r.surfaceFormat {
+		viewDesc = &types.TextureViewDescriptor{Format: r.format, Aspect: types.TextureAspectAll}
+	}
+	r.frame.currentView = r.backend.CreateTextureView(r.frame.currentTexture, viewDesc)
+	if r.frame.currentView == 0 {
+		return false
+	}
+
+	// Pipelines are built for the sample count, so without the
+	// multisampled target the frame cannot be drawn
+	if err := r.ensureMSAATarget(); err != nil {
+		r.ResetSurface()
+		return false
+	}
+	return true
 }
 
-// EndFrame presents the rendered frame.
+// EndFrame presents the rendered frame and flushes the transfer queues.
 func (r *Renderer) EndFrame() {
+	r.present()
+
+	// Uploads of the transfer queues go between frames
+	r.flushTransfers()
+}
+
+// present presents the current frame and releases its surface texture.
+// Unlike EndFrame it leaves the per-frame work to the main window, so
+// additional windows do not repeat it.
+func (r *Renderer) present() {
 	// Present first while texture is still valid.
 	// On Metal (macOS), releasing the texture view before present
 	// can invalidate the drawable, causing blank frames.
-	r.backend.Present(r.surface)
+	if r.frame.surface != 0 {
+		r.backend.Present(r.frame.surface)
+	}
 
 	// Release resources after presentation
-	if r.currentView != 0 {
-		r.backend.ReleaseTextureView(r.currentView)
-		r.currentView = 0
+	r.releaseFrame()
+}
+
+// releaseFrame releases the surface texture of the current frame. A
+// headless frame draws into r.target, which is kept.
+func (r *Renderer) releaseFrame() {
+	if r.frame.surface == 0 {
+		r.frame.currentView, r.frame.currentTexture = 0, 0
+		return
+	}
+	if r.frame.currentView != 0 {
+		r.backend.ReleaseTextureView(r.frame.currentView)
+		r.frame.currentView = 0
 	}
-	if r.currentTexture != 0 {
-		r.backend.ReleaseTexture(r.currentTexture)
-		r.currentTexture = 0
+	if r.frame.currentTexture != 0 {
+		r.backend.ReleaseTexture(r.frame.currentTexture)
+		r.frame.currentTexture = 0
 	}
 }
 
-// Clear submits a clear command with the specified color.
+// Clear submits a clear command with the specified color, given in
+// sRGB. It is converted for sRGB render targets, see SrgbEncoded.
 func (r *Renderer) Clear(red, green, blue, alpha float64) {
-	if r.currentView == 0 {
+	if r.frame.currentView == 0 {
 		return
 	}
+	if r.SrgbEncoded() {
+		red = float64(gmath.SrgbToLinear(float32(red)))
+		green = float64(gmath.SrgbToLinear(float32(green)))
+		blue = float64(gmath.SrgbToLinear(float32(blue)))
+	}
 
 	encoder := r.backend.CreateCommandEncoder(r.device)
 	if encoder == 0 {
@@ -250,12 +663,7 @@ func (r *Renderer) Clear(red, green, blue, alpha float64) {
 
 	renderPass := r.backend.BeginRenderPass(encoder, &types.RenderPassDescriptor{
 		ColorAttachments: []types.ColorAttachment{
-			{
-				View:       r.currentView,
-				LoadOp:     types.LoadOpClear,
-				StoreOp:    types.StoreOpStore,
-				ClearValue: types.Color{R: red, G: green, B: blue, A: alpha},
-			},
+			r.colorAttachment(types.LoadOpClear, types.Color{R: red, G: green, B: blue, A: alpha}),
 		},
 	})
 
@@ -265,13 +673,24 @@ func (r *Renderer) Clear(red, green, blue, alpha float64) {
 	commands := r.backend.FinishEncoder(encoder)
 	r.backend.ReleaseCommandEncoder(encoder)
 
-	r.backend.Submit(r.queue, commands)
+	r.submit(commands)
 	r.backend.ReleaseCommandBuffer(commands)
 }
 
 // Size returns the current render target size.
 func (r *Renderer) Size() (width, height int) {
-	return int(r.width), int(r.height)
+	return int(r.frame.width), int(r.frame.height)
+}
+
+// SurfaceCapabilities returns the formats and modes the window surface
+// supports with the GPU in use.
+func (r *Renderer) SurfaceCapabilities() types.SurfaceCapabilities {
+	return r.surfaceCaps
+}
+
+// PresentMode returns the present mode the surface is configured with.
+func (r *Renderer) PresentMode() types.PresentMode {
+	return r.presentMode
 }
 
 // Format returns the surface texture format.
@@ -279,11 +698,34 @@ func (r *Renderer) Format() types.TextureFormat {
 	return r.format
 }
 
+// SrgbEncoded reports whether frames are drawn in an sRGB format, whose
+// values the GPU encodes for display. Shaders then write linear colors;
+// see TargetColor and Config.SrgbPolicy.
+func (r *Renderer) SrgbEncoded() bool {
+	return r.format.Linear() != r.format
+}
+
+// TargetColor returns the value a shader writes into frames to show the
+// sRGB color c, the same on every backend: c converted to linear if
+// frames are sRGB-encoded, else c itself.
+func (r *Renderer) TargetColor(c gmath.Color) gmath.Color {
+	if r.SrgbEncoded() {
+		return c.ToLinear()
+	}
+	return c
+}
+
 // Backend returns the name of the active backend.
 func (r *Renderer) Backend() string {
 	return r.backend.Name()
 }
 
+// BackendReport describes how the active backend was chosen and why
+// the backends tried before it were rejected.
+func (r *Renderer) BackendReport() *BackendReport {
+	return r.report
+}
+
 // initTrianglePipeline creates the built-in triangle render pipeline.
 func (r *Renderer) initTrianglePipeline() error {
 	if r.trianglePipeline != 0 {
@@ -305,6 +747,7 @@ func (r *Renderer) initTrianglePipeline() error {
 		FragmentShader:   r.triangleShader,
 		FragmentEntry:    "fs_main",
 		TargetFormat:     r.format,
+		Multisample:      types.MultisampleState{Count: r.sampleCount},
 	})
 	if err != nil {
 		return fmt.Errorf("gogpu: failed to create render pipeline: %w", err)
@@ -315,7 +758,7 @@ func (r *Renderer) initTrianglePipeline() error {
 
 // DrawTriangle draws the built-in colored triangle.
 func (r *Renderer) DrawTriangle(clearR, clearG, clearB, clearA float64) error {
-	if r.currentView == 0 {
+	if r.frame.currentView == 0 {
 		return nil
 	}
 
@@ -333,12 +776,7 @@ func (r *Renderer) DrawTriangle(clearR, clearG, clearB, clearA float64) error {
 
 	renderPass := r.backend.BeginRenderPass(encoder, &types.RenderPassDescriptor{
 		ColorAttachments: []types.ColorAttachment{
-			{
-				View:       r.currentView,
-				LoadOp:     types.LoadOpClear,
-				StoreOp:    types.StoreOpStore,
-				ClearValue: types.Color{R: clearR, G: clearG, B: clearB, A: clearA},
-			},
+			r.colorAttachment(types.LoadOpClear, types.Color{R: clearR, G: clearG, B: clearB, A: clearA}),
 		},
 	})
 
@@ -351,7 +789,7 @@ func (r *Renderer) DrawTriangle(clearR, clearG, clearB, clearA float64) error {
 	commands := r.backend.FinishEncoder(encoder)
 	r.backend.ReleaseCommandEncoder(encoder)
 
-	r.backend.Submit(r.queue, commands)
+	r.submit(commands)
 	r.backend.ReleaseCommandBuffer(commands)
 
 	return nil
@@ -359,17 +797,29 @@ func (r *Renderer) DrawTriangle(clearR, clearG, clearB, clearA float64) error {
 
 // Destroy releases all GPU resources.
 func (r *Renderer) Destroy() {
-	if r.currentView != 0 {
-		r.backend.ReleaseTextureView(r.currentView)
-		r.currentView = 0
+	r.releaseMSAATarget()
+	r.releaseFrame()
+	r.releaseTarget()
+
+	if r.staging != nil {
+		r.staging.Destroy()
 	}
-	if r.currentTexture != 0 {
-		r.backend.ReleaseTexture(r.currentTexture)
-		r.currentTexture = 0
+	if r.gpuTimer != nil {
+		r.gpuTimer.destroy()
+	}
+
+	if r.pipelines != nil {
+		r.pipelines.Release()
+	}
+	for len(r.transfers) > 0 {
+		r.transfers[0].Destroy()
 	}
 
 	// Backend handles cleanup of all resources
 	if r.backend != nil {
 		r.backend.Destroy()
 	}
+	if r.recorder != nil {
+		r.writeTrace()
+	}
 }
@@ -0,0 +1,231 @@
+package gogpu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu/types"
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+func TestPickSampleCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int
+		supported []uint32
+		want      uint32
+	}{
+		{"disabled", 0, []uint32{1, 4}, 1},
+		{"one", 1, []uint32{1, 4}, 1},
+		{"exact", 4, []uint32{1, 4}, 4},
+		{"highest below", 8, []uint32{1, 2, 4}, 4},
+		{"between", 3, []uint32{1, 2, 4, 8}, 2},
+		{"none supported", 4, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickSampleCount(tt.requested, tt.supported); got != tt.want {
+				t.Errorf("pickSampleCount(%d, %v) = %d, want %d", tt.requested, tt.supported, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickSurfaceFormat(t *testing.T) {
+	bgra := []types.TextureFormat{types.TextureFormatBGRA8UnormSrgb, types.TextureFormatBGRA8Unorm}
+	tests := []struct {
+		name          string
+		supported     []types.TextureFormat
+		policy        types.SrgbPolicy
+		surface, view types.TextureFormat
+	}{
+		{"unknown", nil, types.SrgbPolicyPassthrough, types.TextureFormatBGRA8Unorm, types.TextureFormatBGRA8Unorm},
+		{"bgra", bgra, types.SrgbPolicyPassthrough, types.TextureFormatBGRA8Unorm, types.TextureFormatBGRA8Unorm},
+		{"rgba", []types.TextureFormat{types.TextureFormatRGBA8UnormSrgb, types.TextureFormatRGBA8Unorm}, types.SrgbPolicyPassthrough,
+			types.TextureFormatRGBA8Unorm, types.TextureFormatRGBA8Unorm},
+		{"preferred", []types.TextureFormat{types.TextureFormatRGBA16Float}, types.SrgbPolicyPassthrough,
+			types.TextureFormatRGBA16Float, types.TextureFormatRGBA16Float},
+		{"srgb surface", bgra, types.SrgbPolicyEncode, types.TextureFormatBGRA8UnormSrgb, types.TextureFormatBGRA8UnormSrgb},
+		{"srgb view", []types.TextureFormat{types.TextureFormatRGBA8Unorm}, types.SrgbPolicyEncode,
+			types.TextureFormatRGBA8Unorm, types.TextureFormatRGBA8UnormSrgb},
+		{"srgb unknown", nil, types.SrgbPolicyEncode, types.TextureFormatBGRA8Unorm, types.TextureFormatBGRA8UnormSrgb},
+		{"no srgb variant", []types.TextureFormat{types.TextureFormatRGBA16Float}, types.SrgbPolicyEncode,
+			types.TextureFormatRGBA16Float, types.TextureFormatRGBA16Float},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surface, view := pickSurfaceFormat(tt.supported, tt.policy)
+			if surface != tt.surface || view != tt.view {
+				t.Errorf("pickSurfaceFormat(%v, %v) = %v, %v, want %v, %v", tt.supported, tt.policy, surface, view, tt.surface, tt.view)
+			}
+		})
+	}
+}
+
+func TestPickPresentMode(t *testing.T) {
+	all := []types.PresentMode{types.PresentModeFifo, types.PresentModeImmediate, types.PresentModeMailbox}
+	tests := []struct {
+		name      string
+		supported []types.PresentMode
+		vsync     bool
+		want      types.PresentMode
+	}{
+		{"vsync", all, true, types.PresentModeFifo},
+		{"mailbox", all, false, types.PresentModeMailbox},
+		{"immediate", []types.PresentMode{types.PresentModeFifo, types.PresentModeImmediate}, false, types.PresentModeImmediate},
+		{"fifo only", []types.PresentMode{types.PresentModeFifo}, false, types.PresentModeFifo},
+		{"unknown", nil, false, types.PresentModeFifo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickPresentMode(tt.supported, tt.vsync); got != tt.want {
+				t.Errorf("pickPresentMode(%v, %v) = %v, want %v", tt.supported, tt.vsync, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickAlphaMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported []types.AlphaMode
+		want      types.AlphaMode
+		expect    types.AlphaMode
+	}{
+		{"unknown", nil, types.AlphaModePremultiplied, types.AlphaModePremultiplied},
+		{"supported", []types.AlphaMode{types.AlphaModeOpaque, types.AlphaModePremultiplied}, types.AlphaModePremultiplied, types.AlphaModePremultiplied},
+		{"opaque fallback", []types.AlphaMode{types.AlphaModePostmultiplied, types.AlphaModeOpaque}, types.AlphaModePremultiplied, types.AlphaModeOpaque},
+		{"preferred", []types.AlphaMode{types.AlphaModePostmultiplied}, types.AlphaModeOpaque, types.AlphaModePostmultiplied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickAlphaMode(tt.supported, tt.want); got != tt.expect {
+				t.Errorf("pickAlphaMode(%v, %v) = %v, want %v", tt.supported, tt.want, got, tt.expect)
+			}
+		})
+	}
+}
+
+// waylandPlatform is a platform whose windows are Wayland windows.
+type waylandPlatform struct {
+	platform.Platform
+}
+
+func (waylandPlatform) WaylandHandles() bool { return true }
+
+func TestSurfaceHandle(t *testing.T) {
+	r := &Renderer{platform: &windowControlPlatform{}}
+	if sh := r.surfaceHandle(1, 2); sh != (types.SurfaceHandle{Instance: 1, Window: 2}) {
+		t.Errorf("surfaceHandle of an X11 window = %+v", sh)
+	}
+	r.platform = waylandPlatform{}
+	if sh := r.surfaceHandle(1, 2); !sh.Wayland {
+		t.Errorf("surfaceHandle of a Wayland window = %+v, want Wayland set", sh)
+	}
+}
+
+func TestWithSurface(t *testing.T) {
+	r := &Renderer{mainFrame: surfaceState{surface: 1, width: 640}}
+	r.frame = &r.mainFrame
+	s := &surfaceState{surface: 2, width: 320}
+
+	r.withSurface(s, func() {
+		if r.frame != s {
+			t.Errorf("frame in withSurface = %+v, want the window's", r.frame)
+		}
+		r.frame.width = 200
+	})
+	if r.frame != &r.mainFrame || r.mainFrame != (surfaceState{surface: 1, width: 640}) {
+		t.Errorf("main frame after withSurface = %+v", r.frame)
+	}
+	if s.width != 200 {
+		t.Errorf("window width = %d, want 200", s.width)
+	}
+}
+
+func TestNewHeadlessRendererInvalidSize(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {64, 0}, {-1, 64}} {
+		if _, err := NewHeadlessRenderer(Config{Width: size[0], Height: size[1]}); err == nil {
+			t.Errorf("NewHeadlessRenderer(%dx%d) succeeded, want error", size[0], size[1])
+		}
+	}
+}
+
+func TestHeadlessApp(t *testing.T) {
+	app := NewHeadlessApp(64, 32)
+	if !app.config.Headless || app.config.Width != 64 || app.config.Height != 32 {
+		t.Errorf("config = %+v, want a headless 64x32 app", app.config)
+	}
+	if _, err := app.Frame(); err == nil {
+		t.Error("Frame succeeded before Start, want error")
+	}
+
+	// The size is checked before anything is opened
+	if err := NewHeadlessApp(0, 32).Start(); err == nil {
+		t.Error("Start of a 0x32 headless app succeeded, want error")
+	}
+}
+
+func TestBackendCandidates(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{"auto", Config{Backend: BackendAuto}, []string{"web", "rust", "native"}},
+		{"explicit", Config{Backend: BackendRust}, []string{"rust"}},
+		{"fallback", Config{Backend: BackendGo, BackendFallback: true}, []string{"native", "web", "rust"}},
+		{"fallback first", Config{Backend: BackendWeb, BackendFallback: true}, []string{"web", "rust", "native"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range backendCandidates(tt.config) {
+				got = append(got, c.name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("backendCandidates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Candidate lists must not alias the chain
+	_ = backendCandidates(Config{Backend: BackendGo, BackendFallback: true})
+	if backendChain[0].name != "web" || backendChain[2].name != "native" {
+		t.Errorf("backendCandidates modified backendChain")
+	}
+}
+
+func TestBackendError(t *testing.T) {
+	reason := errors.New("no adapters found")
+	one := &BackendError{Report: &BackendReport{}}
+	one.Report.reject("native", reason)
+	if got := one.Error(); got != "no adapters found" {
+		t.Errorf("Error() = %q", got)
+	}
+	if !errors.Is(one, reason) {
+		t.Errorf("errors.Is(err, reason) = false")
+	}
+
+	two := &BackendError{Report: &BackendReport{}}
+	two.Report.reject("rust", errors.New("not available"))
+	two.Report.reject("native", reason)
+	want := "gogpu: no usable backend (rust: not available; native: no adapters found)"
+	if got := two.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if backendReportOf(fmt.Errorf("start: %w", two)) != two.Report {
+		t.Errorf("backendReportOf did not find the report")
+	}
+	wantReport := "selected: none\nrejected rust: not available\nrejected native: no adapters found\n"
+	if got := two.Report.String(); got != wantReport {
+		t.Errorf("String() = %q, want %q", got, wantReport)
+	}
+}
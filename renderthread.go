@@ -0,0 +1,191 @@
+package gogpu
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// eventWaitTimeout bounds how long the main thread waits for events while
+// rendering runs on its own thread, so it notices Quit from the render
+// thread without an event to wake it.
+const eventWaitTimeout = 50 * time.Millisecond
+
+// renderThread hands main window state from the main thread, which
+// processes platform events, to the render goroutine.
+// See Config.RenderThread.
+type renderThread struct {
+	mu sync.Mutex
+
+	width, height int  // Latest main window size
+	resized       bool // Size changed since the last frame
+	paused        bool // Window cannot be seen or the system is asleep
+	surfaceStale  bool // Surface must be reset before the next frame
+	quit          bool // Quit was called
+	stop          bool // Main loop ended; the render goroutine must return
+
+	done chan struct{}
+}
+
+// frameState is the state the render goroutine uses for one frame.
+type frameState struct {
+	width, height int
+	resized       bool
+	paused        bool
+	surfaceStale  bool
+	stop          bool
+}
+
+// resize records a new main window size. It is also used as the live
+// resize handler, which runs while the main thread is blocked in an
+// interactive resize and the render goroutine keeps drawing.
+func (t *renderThread) resize(width, height int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.width, t.height = width, height
+	t.resized = true
+}
+
+// publish records the pause state after events have been processed.
+// A stale surface stays pending until the render goroutine resets it.
+func (t *renderThread) publish(paused, surfaceStale bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.paused = paused
+	t.surfaceStale = t.surfaceStale || surfaceStale
+}
+
+// take returns the state for the next frame and clears one-shot flags.
+func (t *renderThread) take() frameState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	frame := frameState{
+		width:        t.width,
+		height:       t.height,
+		resized:      t.resized,
+		paused:       t.paused,
+		surfaceStale: t.surfaceStale,
+		stop:         t.stop,
+	}
+	t.resized = false
+	t.surfaceStale = false
+	return frame
+}
+
+// requestQuit asks the main loop to end. Safe from any goroutine.
+func (t *renderThread) requestQuit() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quit = true
+}
+
+// quitRequested reports whether Quit was called.
+func (t *renderThread) quitRequested() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.quit
+}
+
+// stopAndWait stops the render goroutine and waits until it has returned,
+// so the renderer can be destroyed.
+func (t *renderThread) stopAndWait() {
+	t.mu.Lock()
+	t.stop = true
+	t.mu.Unlock()
+
+	<-t.done
+}
+
+// runThreaded runs the main loop with rendering on its own goroutine,
+// locked to an OS thread, while the main thread waits for and processes
+// platform events.
+func (a *App) runThreaded(waiter platform.EventWaiter, pacer platform.FramePacer) {
+	a.sizeMu.Lock()
+	t := &renderThread{
+		width:  a.width,
+		height: a.height,
+		done:   make(chan struct{}),
+	}
+	a.sizeMu.Unlock()
+	a.renderThread = t
+	defer func() { a.renderThread = nil }()
+
+	if resizer, ok := a.platform.(platform.LiveResizer); ok {
+		resizer.SetLiveResizeHandler(t.resize)
+		defer resizer.SetLiveResizeHandler(nil)
+	}
+
+	go a.renderLoop(t, pacer)
+
+	for a.running && !a.platform.ShouldClose() && !t.quitRequested() {
+		end := a.beginFrameScope()
+		waiter.WaitEvents(eventWaitTimeout)
+		a.processEvents()
+		end()
+
+		t.publish(a.minimized || a.occluded || a.asleep, a.surfaceStale)
+		a.surfaceStale = false
+	}
+
+	t.stopAndWait()
+}
+
+// renderLoop calls OnUpdate and draws frames until the main loop ends.
+// The renderer is only used from this goroutine while it runs.
+func (a *App) renderLoop(t *renderThread, pacer platform.FramePacer) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(t.done)
+
+	for {
+		if pacer != nil {
+			pacer.WaitFrame()
+		}
+
+		frame := t.take()
+		if frame.stop {
+			return
+		}
+
+		end := a.beginFrameScope()
+		a.renderThreadFrame(frame)
+		end()
+	}
+}
+
+// renderThreadFrame calls OnUpdate and draws one frame on the render goroutine.
+func (a *App) renderThreadFrame(frame frameState) {
+	if frame.resized {
+		a.resize(frame.width, frame.height)
+	}
+
+	if frame.paused && a.config.Background == BackgroundPause {
+		time.Sleep(hiddenWaitInterval)
+		a.lastFrame = time.Now()
+		return
+	}
+
+	a.limiter.wait()
+
+	now := time.Now()
+	elapsed := now.Sub(a.lastFrame)
+	a.lastFrame = now
+	a.input.beginFrame()
+
+	a.update(elapsed)
+
+	if frame.paused || frame.width <= 0 || frame.height <= 0 {
+		time.Sleep(pausedFrameInterval)
+		return
+	}
+	if frame.surfaceStale {
+		a.renderer.ResetSurface()
+	}
+
+	a.drawFrame(a.onDraw)
+}
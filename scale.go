@@ -0,0 +1,24 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// ScaleFactor returns the content scale of the main window: the number of
+// pixels per unit of Config.Width and Config.Height, e.g. 2 on a Retina
+// display or 1.5 on a Windows monitor set to 150%. Scale text and UI
+// drawn in pixels by it to keep their physical size. Returns 1 before Run
+// and on platforms that do not report a scale.
+func (a *App) ScaleFactor() float64 {
+	if s, ok := a.platform.(platform.ScaleReporter); ok {
+		return s.ScaleFactor()
+	}
+	return 1
+}
+
+// OnScaleChanged sets the callback for changes of the content scale, such
+// as when the window moves to a monitor with a different DPI. The window
+// keeps its logical size, so OnResize reports the new pixel size as well.
+// Currently delivered on Windows.
+func (a *App) OnScaleChanged(fn func(scale float64)) *App {
+	a.onScale = fn
+	return a
+}
@@ -0,0 +1,286 @@
+package gogpu
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// DefaultStagingRingSize is the size of the renderer's own staging ring,
+// see Renderer.StagingRing.
+const DefaultStagingRingSize = 4 << 20
+
+const (
+	// stagingAlign is the alignment of staged data, that of buffer copies
+	stagingAlign = 4
+
+	// stagingTextureAlign is the alignment of staged texture data, a
+	// multiple of the texel block size of every format
+	stagingTextureAlign = 16
+)
+
+// StagingRing uploads data through one persistent buffer instead of a
+// queue write per upload. Writes are packed into a ring, suballocated in
+// order and wrapping at its end, and copied to their destinations when
+// the ring is flushed, with a single queue write and command submission
+// for all of them. Space is reused once the GPU has completed the copies.
+//
+// Staged writes reach their destination with Flush. Those of the
+// renderer's own ring, see Renderer.StagingRing, are flushed
+// automatically before every submission, so they are in place for the
+// next frame, compute pass or copy.
+type StagingRing struct {
+	renderer *Renderer
+	buffer   *Buffer
+
+	// Guards the fields below; completion callbacks may run on any
+	// goroutine
+	mu    sync.Mutex
+	alloc *gpu.RingAllocator
+
+	// Data staged since the last flush, packed from batchStart on, and
+	// whole batches cut off by wrapping
+	batch      []byte
+	batchStart uint64
+	writes     []stagedWrite
+	copies     []stagedCopy
+}
+
+// stagedWrite is data waiting to be written into the ring.
+type stagedWrite struct {
+	offset uint64
+	data   []byte
+}
+
+// stagedCopy copies staged data from the ring to its destination.
+type stagedCopy struct {
+	src uint64
+	dst types.Buffer
+
+	dstOffset uint64
+	size      uint64
+
+	// Texture destinations
+	texture *types.ImageCopyTexture
+	layout  types.ImageDataLayout
+	extent  types.Extent3D
+}
+
+// NewStagingRing creates a staging ring of size bytes, a multiple of 4.
+// Writes to it must be flushed with Flush.
+func (r *Renderer) NewStagingRing(size uint64) (*StagingRing, error) {
+	if size == 0 || size%stagingAlign != 0 {
+		return nil, fmt.Errorf("gogpu: staging ring size %d is not a positive multiple of %d", size, stagingAlign)
+	}
+	buffer, err := r.NewBuffer(size, types.BufferUsageCopySrc|types.BufferUsageCopyDst)
+	if err != nil {
+		return nil, err
+	}
+	return &StagingRing{
+		renderer: r,
+		buffer:   buffer,
+		alloc:    gpu.NewRingAllocator(size),
+	}, nil
+}
+
+// StagingRing returns the renderer's staging ring of
+// DefaultStagingRingSize bytes, creating it on first use. It is flushed
+// before every submission of the renderer, and replaced after a device
+// loss.
+func (r *Renderer) StagingRing() (*StagingRing, error) {
+	if r.staging == nil {
+		s, err := r.NewStagingRing(DefaultStagingRingSize)
+		if err != nil {
+			return nil, err
+		}
+		r.staging = s
+	}
+	return r.staging, nil
+}
+
+// Size returns the size of the ring in bytes.
+func (s *StagingRing) Size() uint64 {
+	return s.buffer.Size()
+}
+
+// WriteBuffer stages data to be written to dst at offset by the next
+// flush. offset and len(data) must be multiples of 4, and dst needs
+// BufferUsageCopyDst. data may be reused once WriteBuffer returns.
+func (s *StagingRing) WriteBuffer(dst *Buffer, offset uint64, data []byte) error {
+	size := uint64(len(data))
+	if err := gpu.ValidateBufferCopy(0, offset, size); err != nil {
+		return err
+	}
+	if size == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, err := s.stage(data, stagingAlign)
+	if err != nil {
+		return err
+	}
+	s.copies = append(s.copies, stagedCopy{src: src, dst: dst.buffer, dstOffset: offset, size: size})
+	return nil
+}
+
+// WriteTexture stages data laid out as described by layout to be written
+// to size texels of dst at origin by the next flush, like
+// Renderer.CopyBufferToTexture: layout.BytesPerRow must be a multiple of
+// 256, and dst needs TextureUsageCopyDst. layout.Offset is ignored.
+func (s *StagingRing) WriteTexture(dst *Texture, origin types.Origin3D, data []byte, layout types.ImageDataLayout, size types.Extent3D) error {
+	layout.Offset = 0
+	if err := gpu.ValidateImageCopyBuffer(&layout, &size); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, err := s.stage(data, stagingTextureAlign)
+	if err != nil {
+		return err
+	}
+	layout.Offset = src
+	s.copies = append(s.copies, stagedCopy{
+		texture: &types.ImageCopyTexture{Texture: dst.texture, Origin: origin, Aspect: types.TextureAspectAll},
+		layout:  layout,
+		extent:  size,
+	})
+	return nil
+}
+
+// stage copies data into the ring at an offset aligned to align and
+// returns the offset. When the ring is full, pending writes are flushed
+// and completed ones released first.
+func (s *StagingRing) stage(data []byte, align uint64) (uint64, error) {
+	size := uint64(len(data))
+	aligned := alignUp64(size, stagingAlign)
+	if aligned > s.alloc.Size() {
+		return 0, fmt.Errorf("gogpu: %d bytes do not fit staging ring of %d bytes", size, s.alloc.Size())
+	}
+
+	offset, ok := s.alloc.Alloc(aligned, align)
+	if !ok {
+		index := s.flushLocked()
+		s.mu.Unlock()
+		s.releaseAfter(index)
+		s.renderer.Poll(false)
+		s.mu.Lock()
+		if offset, ok = s.alloc.Alloc(aligned, align); !ok {
+			return 0, fmt.Errorf("gogpu: staging ring is full")
+		}
+	}
+
+	if offset != s.batchStart+uint64(len(s.batch)) {
+		// Not contiguous with the batch, e.g. after wrapping: the batch
+		// so far is written on its own
+		s.cutBatch()
+		s.batchStart = offset
+	}
+	s.batch = append(s.batch, data...)
+	s.batch = append(s.batch, make([]byte, aligned-size)...)
+	return offset, nil
+}
+
+// cutBatch moves the batch to the pending writes.
+func (s *StagingRing) cutBatch() {
+	if len(s.batch) > 0 {
+		s.writes = append(s.writes, stagedWrite{offset: s.batchStart, data: s.batch})
+	}
+	s.batch = nil
+}
+
+// Flush writes the staged data into the ring and submits the copies to
+// their destinations. It returns the index of the submission, or 0 if
+// nothing was staged.
+func (s *StagingRing) Flush() types.SubmissionIndex {
+	s.mu.Lock()
+	index := s.flushLocked()
+	s.mu.Unlock()
+	s.releaseAfter(index)
+	return index
+}
+
+// flushLocked flushes with s.mu held. The caller passes the returned
+// index to releaseAfter once s.mu is unlocked.
+func (s *StagingRing) flushLocked() types.SubmissionIndex {
+	if len(s.copies) == 0 {
+		return 0
+	}
+	r := s.renderer
+
+	s.cutBatch()
+	for _, w := range s.writes {
+		s.buffer.Write(w.offset, w.data)
+	}
+	// The queue has copied the data, so the memory of the last batch
+	// can take the next one
+	s.batch = s.writes[len(s.writes)-1].data[:0]
+
+	var index types.SubmissionIndex
+	encoder := r.backend.CreateCommandEncoder(r.device)
+	if encoder != 0 {
+		for i := range s.copies {
+			c := &s.copies[i]
+			if c.texture != nil {
+				r.backend.CopyBufferToTexture(encoder,
+					&types.ImageCopyBuffer{Buffer: s.buffer.buffer, Layout: c.layout},
+					c.texture, &c.extent)
+				continue
+			}
+			r.backend.CopyBufferToBuffer(encoder, s.buffer.buffer, c.src, c.dst, c.dstOffset, c.size)
+		}
+		commands := r.backend.FinishEncoder(encoder)
+		r.backend.ReleaseCommandEncoder(encoder)
+		index = r.submitDirect(commands)
+		r.backend.ReleaseCommandBuffer(commands)
+	}
+
+	clear(s.writes)
+	s.writes = s.writes[:0]
+	clear(s.copies)
+	s.copies = s.copies[:0]
+
+	s.alloc.Fence(index)
+	return index
+}
+
+// releaseAfter frees the ring space fenced with index once that
+// submission has completed. Called without s.mu held, as the release may
+// happen right away.
+func (s *StagingRing) releaseAfter(index types.SubmissionIndex) {
+	s.renderer.OnSubmissionDone(index, func() {
+		s.mu.Lock()
+		s.alloc.Release(index)
+		s.mu.Unlock()
+	})
+}
+
+// Destroy releases the ring's buffer. Staged writes that were not
+// flushed are dropped.
+func (s *StagingRing) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffer.Destroy()
+	if s.renderer.staging == s {
+		s.renderer.staging = nil
+	}
+}
+
+// flushStaging flushes the renderer's staging ring, if it has one.
+func (r *Renderer) flushStaging() {
+	if r.staging != nil {
+		r.staging.Flush()
+	}
+}
+
+// alignUp64 rounds n up to a multiple of align.
+func alignUp64(n, align uint64) uint64 {
+	return (n + align - 1) / align * align
+}
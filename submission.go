@@ -0,0 +1,123 @@
+package gogpu
+
+import (
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// submissionCallback runs once a submission has completed.
+type submissionCallback struct {
+	index types.SubmissionIndex
+	fn    func()
+}
+
+// submit submits commands to the queue and tracks their completion.
+// Writes staged in the renderer's staging ring are submitted first.
+func (r *Renderer) submit(commands types.CommandBuffer) types.SubmissionIndex {
+	r.flushStaging()
+	return r.submitDirect(commands)
+}
+
+// submitDirect submits commands and tracks their completion, without
+// flushing the staging ring.
+func (r *Renderer) submitDirect(commands types.CommandBuffer) types.SubmissionIndex {
+	backendIndex := r.backend.Submit(r.queue, commands)
+	if backendIndex == 0 {
+		return 0
+	}
+
+	r.subMu.Lock()
+	index := r.subBase + backendIndex
+	r.submitted = max(r.submitted, index)
+	r.subMu.Unlock()
+
+	r.backend.OnSubmittedWorkDone(r.queue, func() {
+		r.completeSubmission(index)
+	})
+	return index
+}
+
+// completeSubmission records that index and the submissions before it
+// have completed and runs their callbacks.
+func (r *Renderer) completeSubmission(index types.SubmissionIndex) {
+	r.subMu.Lock()
+	r.completed = max(r.completed, index)
+	n := 0
+	for n < len(r.subCallbacks) && r.subCallbacks[n].index <= r.completed {
+		n++
+	}
+	ready := r.subCallbacks[:n:n]
+	r.subCallbacks = r.subCallbacks[n:]
+	r.subMu.Unlock()
+
+	for _, cb := range ready {
+		cb.fn()
+	}
+}
+
+// resetSubmissions completes the submissions of a lost device, which
+// never finish on the GPU, so that indices of the new device's queue,
+// which start over, continue after them.
+func (r *Renderer) resetSubmissions() {
+	last := r.LastSubmission()
+	r.completeSubmission(last)
+
+	r.subMu.Lock()
+	r.subBase = last
+	r.subMu.Unlock()
+}
+
+// LastSubmission returns the index of the last command submission, such
+// as a frame, compute pass or copy. Pass it to OnSubmissionDone or
+// WaitSubmission to learn when that work, and all work before it, has
+// completed on the GPU.
+func (r *Renderer) LastSubmission() types.SubmissionIndex {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	return r.submitted
+}
+
+// SubmissionDone reports whether submission index has completed. It
+// changes during Poll, which the App calls after each frame.
+func (r *Renderer) SubmissionDone(index types.SubmissionIndex) bool {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	return index <= r.completed
+}
+
+// OnSubmissionDone calls fn once submission index has completed, e.g.
+// to reuse a staging buffer or read back results. fn runs during a later
+// Poll, or right away if the submission has already completed.
+// Callbacks run in submission order.
+func (r *Renderer) OnSubmissionDone(index types.SubmissionIndex, fn func()) {
+	r.subMu.Lock()
+	if index <= r.completed {
+		r.subMu.Unlock()
+		fn()
+		return
+	}
+	// Keep the callbacks sorted by index, so completion runs a prefix
+	i := len(r.subCallbacks)
+	for i > 0 && r.subCallbacks[i-1].index > index {
+		i--
+	}
+	r.subCallbacks = append(r.subCallbacks, submissionCallback{})
+	copy(r.subCallbacks[i+1:], r.subCallbacks[i:])
+	r.subCallbacks[i] = submissionCallback{index: index, fn: fn}
+	r.subMu.Unlock()
+}
+
+// OnSubmittedWorkDone calls fn once all work submitted so far has
+// completed, like OnSubmissionDone with LastSubmission.
+func (r *Renderer) OnSubmittedWorkDone(fn func()) {
+	r.OnSubmissionDone(r.LastSubmission(), fn)
+}
+
+// WaitSubmission blocks until submission index has completed, running
+// completed callbacks.
+func (r *Renderer) WaitSubmission(index types.SubmissionIndex) {
+	for !r.SubmissionDone(index) {
+		if r.Poll(true) && !r.SubmissionDone(index) {
+			return // Nothing pending: the submission failed or was lost
+		}
+	}
+}
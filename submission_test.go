@@ -0,0 +1,36 @@
+package gogpu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOnSubmissionDone(t *testing.T) {
+	r := &Renderer{}
+	r.submitted = 3
+
+	var ran []int
+	r.OnSubmissionDone(3, func() { ran = append(ran, 3) })
+	r.OnSubmissionDone(1, func() { ran = append(ran, 1) })
+	r.OnSubmissionDone(2, func() { ran = append(ran, 2) })
+
+	r.completeSubmission(2)
+	if !slices.Equal(ran, []int{1, 2}) {
+		t.Errorf("after completing 2, ran %v, want [1 2]", ran)
+	}
+	if !r.SubmissionDone(2) || r.SubmissionDone(3) {
+		t.Errorf("SubmissionDone(2), SubmissionDone(3) = %v, %v, want true, false", r.SubmissionDone(2), r.SubmissionDone(3))
+	}
+
+	// Already completed: runs right away
+	r.OnSubmissionDone(1, func() { ran = append(ran, 10) })
+	if !slices.Equal(ran, []int{1, 2, 10}) {
+		t.Errorf("completed submission callback did not run right away: %v", ran)
+	}
+
+	// A lost device completes everything submitted
+	r.resetSubmissions()
+	if !slices.Equal(ran, []int{1, 2, 10, 3}) || r.subBase != 3 {
+		t.Errorf("after reset, ran %v and subBase %d", ran, r.subBase)
+	}
+}
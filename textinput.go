@@ -0,0 +1,37 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// TextInputEvent describes text typed into the main window.
+//
+// Input methods (CJK input, dead keys such as ´ followed by e) first send
+// events with Composing set: Text is the whole in-progress composition and
+// replaces the previously shown one, and Cursor is the caret position in
+// runes. An empty composing Text means the composition was cancelled.
+// The final text arrives in an event with Composing unset.
+type TextInputEvent = platform.TextEvent
+
+// OnTextInput sets the callback for text input, including composed
+// characters from input methods. Use it for text fields instead of raw
+// key events. Currently delivered on macOS and Windows.
+func (a *App) OnTextInput(fn func(TextInputEvent)) *App {
+	a.onTextInput = fn
+	return a
+}
+
+// SetTextInputRect tells input methods where the text being edited is, in
+// main window pixels, so candidate lists appear next to it instead of in a
+// corner of the window. Update it when the caret moves. Returns
+// ErrPlatformNotSupported if the platform places input method windows on
+// its own (currently used on Windows).
+func (a *App) SetTextInputRect(x, y, width, height int) error {
+	if a.platform == nil {
+		return ErrNotInitialized
+	}
+	positioner, ok := a.platform.(platform.TextInputPositioner)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	positioner.SetTextInputRect(x, y, width, height)
+	return nil
+}
@@ -8,7 +8,9 @@ import (
 	_ "image/png"  // Register PNG decoder
 	"io"
 	"os"
+	"slices"
 
+	"github.com/gogpu/gogpu/gpu"
 	"github.com/gogpu/gogpu/gpu/types"
 )
 
@@ -21,9 +23,12 @@ type Texture struct {
 	sampler types.Sampler
 
 	// Metadata
-	width  int
-	height int
-	format types.TextureFormat
+	width         int
+	height        int
+	layers        int // Array layers, cube faces or depth slices
+	format        types.TextureFormat
+	viewFormats   []types.TextureFormat // Other formats views may have
+	viewDimension types.TextureViewDimension
 
 	// Reference to renderer for resource management
 	renderer *Renderer
@@ -44,11 +49,28 @@ func (t *Texture) Size() (width, height int) {
 	return t.width, t.height
 }
 
+// Layers returns the number of array layers, cube faces, or depth slices
+// of a 3D texture; 1 for plain 2D textures.
+func (t *Texture) Layers() int {
+	if t.layers == 0 {
+		return 1
+	}
+	return t.layers
+}
+
 // Format returns the texture format.
 func (t *Texture) Format() types.TextureFormat {
 	return t.format
 }
 
+// ViewDimension returns the dimension of View, for bind group layouts.
+func (t *Texture) ViewDimension() types.TextureViewDimension {
+	if t.viewDimension == types.TextureViewDimensionUndefined {
+		return types.TextureViewDimension2D
+	}
+	return t.viewDimension
+}
+
 // Handle returns the underlying GPU texture handle.
 // For advanced use cases that need direct GPU access.
 func (t *Texture) Handle() types.Texture {
@@ -65,6 +87,56 @@ func (t *Texture) Sampler() types.Sampler {
 	return t.sampler
 }
 
+// NewView creates another view of the texture, e.g. of a single array
+// layer or mip level to render a shadow map cascade into, an sRGB view
+// of a linear texture created with that view format, or a depth-only view
+// to sample a depth-stencil texture. The caller releases it with the
+// backend.
+func (t *Texture) NewView(desc *types.TextureViewDescriptor) (types.TextureView, error) {
+	if desc != nil {
+		if err := gpu.ValidateTextureView(t.format, t.viewFormats, desc); err != nil {
+			return 0, fmt.Errorf("gogpu: %w", err)
+		}
+	}
+	view := t.renderer.backend.CreateTextureView(t.texture, desc)
+	if view == 0 {
+		return 0, fmt.Errorf("gogpu: failed to create texture view")
+	}
+	return view, nil
+}
+
+// WriteLayer uploads the pixels of one array layer, cube face, or depth
+// slice. Rows are bytesPerRow bytes apart, and data holds Height rows.
+// The texture needs TextureUsageCopyDst.
+func (t *Texture) WriteLayer(layer, bytesPerRow int, data []byte) error {
+	if layer < 0 || layer >= t.Layers() {
+		return fmt.Errorf("gogpu: layer %d out of range [0, %d)", layer, t.Layers())
+	}
+	if bytesPerRow <= 0 || len(data) < bytesPerRow*t.height {
+		return fmt.Errorf("gogpu: invalid layer data size: expected %d bytes, got %d", bytesPerRow*t.height, len(data))
+	}
+
+	t.renderer.backend.WriteTexture(
+		t.renderer.queue,
+		&types.ImageCopyTexture{
+			Texture: t.texture,
+			Origin:  types.Origin3D{Z: uint32(layer)}, //nolint:gosec // G115: layer validated above
+			Aspect:  types.TextureAspectAll,
+		},
+		data,
+		&types.ImageDataLayout{
+			BytesPerRow:  uint32(bytesPerRow), //nolint:gosec // G115: validated positive above
+			RowsPerImage: uint32(t.height),    //nolint:gosec // G115: texture size is positive
+		},
+		&types.Extent3D{
+			Width:              uint32(t.width),  //nolint:gosec // G115: texture size is positive
+			Height:             uint32(t.height), //nolint:gosec // G115: texture size is positive
+			DepthOrArrayLayers: 1,
+		},
+	)
+	return nil
+}
+
 // Destroy releases all GPU resources associated with this texture.
 // After calling Destroy, the texture should not be used.
 func (t *Texture) Destroy() {
@@ -197,7 +269,7 @@ func (r *Renderer) NewTextureFromRGBAWithOptions(width, height int, data []byte,
 		SampleCount:   1,
 		Dimension:     types.TextureDimension2D,
 		Format:        types.TextureFormatRGBA8Unorm,
-		Usage:         types.TextureUsageTextureBinding | types.TextureUsageCopyDst,
+		Usage:         types.TextureUsageTextureBinding | types.TextureUsageCopyDst | types.TextureUsageCopySrc,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("gogpu: failed to create texture: %w", err)
@@ -260,3 +332,86 @@ func (r *Renderer) NewTextureFromRGBAWithOptions(width, height int, data []byte,
 		renderer: r,
 	}, nil
 }
+
+// NewTexture creates a texture from a descriptor with a view of the given
+// dimension covering the whole texture, or the texture's default view
+// dimension if undefined. It has no sampler; storage textures do not
+// need one, and sampled ones can use any sampler created with the
+// backend.
+func (r *Renderer) NewTexture(desc *types.TextureDescriptor, viewDimension types.TextureViewDimension) (*Texture, error) {
+	if viewDimension == types.TextureViewDimensionUndefined {
+		viewDimension = types.DefaultViewDimension(desc.Dimension, desc.Size.DepthOrArrayLayers)
+	}
+
+	if err := gpu.ValidateViewFormats(desc.Format, desc.ViewFormats); err != nil {
+		return nil, fmt.Errorf("gogpu: %w", err)
+	}
+
+	texture, err := r.backend.CreateTexture(r.device, desc)
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create texture: %w", err)
+	}
+
+	view := r.backend.CreateTextureView(texture, &types.TextureViewDescriptor{
+		Format:    desc.Format,
+		Dimension: viewDimension,
+		Aspect:    types.TextureAspectAll,
+	})
+	if view == 0 {
+		r.backend.ReleaseTexture(texture)
+		return nil, fmt.Errorf("gogpu: failed to create texture view")
+	}
+
+	return &Texture{
+		texture:       texture,
+		view:          view,
+		width:         int(desc.Size.Width),
+		height:        int(desc.Size.Height),
+		layers:        int(desc.Size.DepthOrArrayLayers),
+		format:        desc.Format,
+		viewFormats:   slices.Clone(desc.ViewFormats),
+		viewDimension: viewDimension,
+		renderer:      r,
+	}, nil
+}
+
+// NewTextureArray creates a 2D texture array, e.g. for shadow map
+// cascades or sprite sheets, with a 2D-array view. usage is added to
+// TextureBinding and CopyDst.
+func (r *Renderer) NewTextureArray(width, height, layers int, format types.TextureFormat, usage types.TextureUsage) (*Texture, error) {
+	return r.newTextureOf(types.TextureDimension2D, width, height, layers, format, usage, types.TextureViewDimension2DArray)
+}
+
+// NewCubeTexture creates a cube map of six size x size faces, e.g. for
+// skyboxes and environment maps, with a cube view. Faces are layers in
+// the order +X, -X, +Y, -Y, +Z, -Z. usage is added to TextureBinding and
+// CopyDst.
+func (r *Renderer) NewCubeTexture(size int, format types.TextureFormat, usage types.TextureUsage) (*Texture, error) {
+	return r.newTextureOf(types.TextureDimension2D, size, size, 6, format, usage, types.TextureViewDimensionCube)
+}
+
+// NewTexture3D creates a 3D texture, e.g. for color grading LUTs and
+// volumes, with a 3D view. usage is added to TextureBinding and CopyDst.
+func (r *Renderer) NewTexture3D(width, height, depth int, format types.TextureFormat, usage types.TextureUsage) (*Texture, error) {
+	return r.newTextureOf(types.TextureDimension3D, width, height, depth, format, usage, types.TextureViewDimension3D)
+}
+
+// newTextureOf creates a texture with a single mip level and sample.
+func (r *Renderer) newTextureOf(dimension types.TextureDimension, width, height, layers int, format types.TextureFormat, usage types.TextureUsage, viewDimension types.TextureViewDimension) (*Texture, error) {
+	if width <= 0 || height <= 0 || layers <= 0 {
+		return nil, fmt.Errorf("gogpu: invalid texture size %dx%dx%d", width, height, layers)
+	}
+
+	return r.NewTexture(&types.TextureDescriptor{
+		Size: types.Extent3D{
+			Width:              uint32(width),  //nolint:gosec // G115: validated positive above
+			Height:             uint32(height), //nolint:gosec // G115: validated positive above
+			DepthOrArrayLayers: uint32(layers), //nolint:gosec // G115: validated positive above
+		},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     dimension,
+		Format:        format,
+		Usage:         usage | types.TextureUsageTextureBinding | types.TextureUsageCopyDst,
+	}, viewDimension)
+}
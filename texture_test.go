@@ -264,3 +264,24 @@ func TestCheckerboardPattern(t *testing.T) {
 		t.Errorf("Pixel (1,1) = (%d,%d,%d), want (255,255,255)", pixels[idx], pixels[idx+1], pixels[idx+2])
 	}
 }
+
+func TestTextureLayersAndViewDimension(t *testing.T) {
+	plain := &Texture{width: 16, height: 16}
+	if plain.Layers() != 1 {
+		t.Errorf("Layers() = %d, want 1", plain.Layers())
+	}
+	if plain.ViewDimension() != types.TextureViewDimension2D {
+		t.Errorf("ViewDimension() = %d, want TextureViewDimension2D", plain.ViewDimension())
+	}
+
+	cube := &Texture{width: 16, height: 16, layers: 6, viewDimension: types.TextureViewDimensionCube}
+	if cube.Layers() != 6 {
+		t.Errorf("Layers() = %d, want 6", cube.Layers())
+	}
+	if err := cube.WriteLayer(6, 64, make([]byte, 64*16)); err == nil {
+		t.Error("WriteLayer(6) on a cube texture succeeded, want error")
+	}
+	if err := cube.WriteLayer(0, 64, make([]byte, 64)); err == nil {
+		t.Error("WriteLayer with short data succeeded, want error")
+	}
+}
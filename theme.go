@@ -0,0 +1,22 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// IsDarkMode reports whether the system uses a dark appearance.
+// Returns false before Run and on platforms that do not report the
+// appearance (currently reported on macOS and Windows).
+func (a *App) IsDarkMode() bool {
+	if t, ok := a.platform.(platform.ThemeDetector); ok {
+		return t.DarkMode()
+	}
+	return false
+}
+
+// OnThemeChanged sets the callback for switches between the system light
+// and dark appearance, so the app can restyle its UI to match.
+// The callback receives true for dark. Currently delivered on macOS and
+// Windows, where the title bar follows the system setting as well.
+func (a *App) OnThemeChanged(fn func(dark bool)) *App {
+	a.onTheme = fn
+	return a
+}
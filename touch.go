@@ -0,0 +1,27 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// TouchEvent describes one contact on a touchscreen. Each finger is a
+// separate contact, identified by ID from TouchPhaseBegin until
+// TouchPhaseEnd or TouchPhaseCancel. Coordinates are in window pixels.
+type TouchEvent = platform.TouchEvent
+
+// TouchPhase describes what changed in a TouchEvent.
+type TouchPhase = platform.TouchPhase
+
+// Touch phases.
+const (
+	TouchPhaseBegin  = platform.TouchPhaseBegin
+	TouchPhaseMove   = platform.TouchPhaseMove
+	TouchPhaseEnd    = platform.TouchPhaseEnd
+	TouchPhaseCancel = platform.TouchPhaseCancel
+)
+
+// OnTouch sets the callback for touchscreen contacts. The system may also
+// turn touches into mouse input for applications that only read the mouse.
+// Currently delivered on Windows.
+func (a *App) OnTouch(fn func(TouchEvent)) *App {
+	a.onTouch = fn
+	return a
+}
@@ -0,0 +1,44 @@
+package gogpu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gogpu/gogpu/gpu"
+)
+
+// Trace returns the backend calls recorded so far, or nil unless
+// Config.TraceFile is set.
+func (r *Renderer) Trace() *gpu.Trace {
+	if r.recorder == nil {
+		return nil
+	}
+	return r.recorder.Trace()
+}
+
+// TraceErr returns why Destroy could not write the complete trace to
+// Config.TraceFile, or nil.
+func (r *Renderer) TraceErr() error {
+	return r.traceErr
+}
+
+// writeTrace writes the recorded trace to the trace file. Destroy has no
+// error to return, so failures are kept for TraceErr.
+func (r *Renderer) writeTrace() {
+	var errs []error
+	if err := r.recorder.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("gogpu: trace is incomplete: %w", err))
+	}
+	f, err := os.Create(r.traceFile)
+	if err == nil {
+		err = r.recorder.Trace().Encode(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("gogpu: failed to write trace: %w", err))
+	}
+	r.traceErr = errors.Join(errs...)
+}
@@ -0,0 +1,27 @@
+package gogpu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogpu/gogpu/gpu"
+)
+
+func TestWriteTrace(t *testing.T) {
+	dir := t.TempDir()
+	r := &Renderer{recorder: gpu.NewRecorder(nil), traceFile: filepath.Join(dir, "trace.json")}
+	r.writeTrace()
+	if err := r.TraceErr(); err != nil {
+		t.Fatalf("TraceErr() = %v after writing the trace", err)
+	}
+	if _, err := os.Stat(r.traceFile); err != nil {
+		t.Errorf("trace file not written: %v", err)
+	}
+
+	r.traceFile = filepath.Join(dir, "missing", "trace.json")
+	r.writeTrace()
+	if r.TraceErr() == nil {
+		t.Error("TraceErr() = nil after failing to create the trace file")
+	}
+}
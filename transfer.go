@@ -0,0 +1,278 @@
+package gogpu
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// DefaultTransferBudget is the number of bytes a transfer queue uploads
+// per frame when NewTransferQueue is given a budget of 0.
+const DefaultTransferBudget = 1 << 20
+
+// TransferQueue streams uploads, such as assets loaded in the background,
+// to buffers and textures without stalling frames. Devices have a single
+// queue that also draws the frames, see package gpu, so instead of
+// submitting an upload at once a transfer queue submits at most its
+// budget of bytes per frame, through a staging ring of its own. Buffer
+// writes are split into parts of the budget; texture writes are
+// submitted whole.
+//
+// The queue is flushed by every EndFrame, or by Flush. Each write
+// returns a TransferFence that tells when its data has reached the
+// destination. Writes may be queued from any goroutine; Flush must be
+// called on the goroutine drawing the frames. Like the resources of the
+// device, a transfer queue is lost with it.
+type TransferQueue struct {
+	renderer *Renderer
+	ring     *StagingRing
+	budget   uint64
+
+	// Guards pending; writes may come from any goroutine
+	mu      sync.Mutex
+	pending []transfer
+}
+
+// transfer is a write waiting in a transfer queue.
+type transfer struct {
+	fence *TransferFence
+
+	// Buffer destinations; parts of data already submitted are cut off
+	// its front
+	buffer *Buffer
+	offset uint64
+	data   []byte
+
+	// Texture destinations
+	texture *Texture
+	origin  types.Origin3D
+	layout  types.ImageDataLayout
+	size    types.Extent3D
+}
+
+// NewTransferQueue creates a transfer queue that submits at most budget
+// bytes per frame, a multiple of 4, or DefaultTransferBudget for 0. Its
+// staging ring holds two frames of transfers, so the GPU may copy one
+// while the next is staged.
+func (r *Renderer) NewTransferQueue(budget uint64) (*TransferQueue, error) {
+	budget, err := transferBudget(budget)
+	if err != nil {
+		return nil, err
+	}
+	ring, err := r.NewStagingRing(2 * budget)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &TransferQueue{renderer: r, ring: ring, budget: budget}
+	r.transfers = append(r.transfers, q)
+	return q, nil
+}
+
+// transferBudget returns the budget of a transfer queue asked for
+// budget bytes per frame.
+func transferBudget(budget uint64) (uint64, error) {
+	if budget == 0 {
+		return DefaultTransferBudget, nil
+	}
+	if budget%stagingAlign != 0 {
+		return 0, fmt.Errorf("gogpu: transfer budget %d is not a multiple of %d", budget, stagingAlign)
+	}
+	return budget, nil
+}
+
+// Budget returns the number of bytes the queue submits per frame.
+func (q *TransferQueue) Budget() uint64 {
+	return q.budget
+}
+
+// WriteBuffer queues data to be written to dst at offset. offset and
+// len(data) must be multiples of 4, and dst needs BufferUsageCopyDst.
+// data may be reused once WriteBuffer returns.
+func (q *TransferQueue) WriteBuffer(dst *Buffer, offset uint64, data []byte) (*TransferFence, error) {
+	if err := gpu.ValidateBufferCopy(0, offset, uint64(len(data))); err != nil {
+		return nil, err
+	}
+	return q.push(transfer{buffer: dst, offset: offset, data: slices.Clone(data)}), nil
+}
+
+// WriteTexture queues data laid out as described by layout to be written
+// to size texels of dst at origin, like StagingRing.WriteTexture. The
+// data must not exceed the budget of the queue. data may be reused once
+// WriteTexture returns.
+func (q *TransferQueue) WriteTexture(dst *Texture, origin types.Origin3D, data []byte, layout types.ImageDataLayout, size types.Extent3D) (*TransferFence, error) {
+	layout.Offset = 0
+	if err := gpu.ValidateImageCopyBuffer(&layout, &size); err != nil {
+		return nil, err
+	}
+	if n := alignUp64(uint64(len(data)), stagingAlign); n > q.budget {
+		return nil, fmt.Errorf("gogpu: %d bytes of texture data exceed transfer budget of %d bytes", len(data), q.budget)
+	}
+	return q.push(transfer{
+		texture: dst,
+		origin:  origin,
+		data:    slices.Clone(data),
+		layout:  layout,
+		size:    size,
+	}), nil
+}
+
+// push queues a transfer and returns its fence.
+func (q *TransferQueue) push(t transfer) *TransferFence {
+	t.fence = &TransferFence{renderer: q.renderer}
+	if len(t.data) == 0 {
+		t.fence.submitted(0)
+		return t.fence
+	}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, t)
+	q.mu.Unlock()
+	return t.fence
+}
+
+// Pending returns the number of bytes waiting to be submitted.
+func (q *TransferQueue) Pending() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var n uint64
+	for _, t := range q.pending {
+		n += uint64(len(t.data))
+	}
+	return n
+}
+
+// Flush submits the queued writes, up to the budget of the queue, in the
+// order they were queued. EndFrame calls it after presenting the frame.
+func (q *TransferQueue) Flush() {
+	q.mu.Lock()
+	var done []*TransferFence
+	left := q.budget
+	for len(q.pending) > 0 && left > 0 {
+		t := &q.pending[0]
+		if t.texture != nil {
+			n := alignUp64(uint64(len(t.data)), stagingAlign)
+			if n > left {
+				break // Whole textures only; next frame
+			}
+			if err := q.ring.WriteTexture(t.texture, t.origin, t.data, t.layout, t.size); err != nil {
+				break // The ring is full while the GPU catches up
+			}
+			left -= n
+		} else {
+			n := min(uint64(len(t.data)), left)
+			if err := q.ring.WriteBuffer(t.buffer, t.offset, t.data[:n]); err != nil {
+				break
+			}
+			left -= n
+			t.offset += n
+			t.data = t.data[n:]
+			if len(t.data) > 0 {
+				break // The rest goes with the next frames
+			}
+		}
+		done = append(done, t.fence)
+		q.pending[0] = transfer{}
+		q.pending = q.pending[1:]
+	}
+	q.mu.Unlock()
+
+	if left == q.budget {
+		return
+	}
+	q.ring.Flush()
+
+	// A full ring flushes on its own, so the last submission, not the
+	// flush's, covers everything staged
+	index := q.renderer.LastSubmission()
+	for _, f := range done {
+		f.submitted(index)
+	}
+}
+
+// Destroy releases the queue's staging ring. Writes that were not
+// submitted are dropped, and their fences never complete.
+func (q *TransferQueue) Destroy() {
+	q.mu.Lock()
+	q.pending = nil
+	q.mu.Unlock()
+
+	q.ring.Destroy()
+	r := q.renderer
+	r.transfers = slices.DeleteFunc(r.transfers, func(t *TransferQueue) bool { return t == q })
+}
+
+// flushTransfers flushes the renderer's transfer queues.
+func (r *Renderer) flushTransfers() {
+	for _, q := range r.transfers {
+		q.Flush()
+	}
+}
+
+// TransferFence tells when the data of a write to a TransferQueue has
+// reached its destination. Work submitted after Submitted reports true
+// sees the data, since the device's queue runs in submission order.
+type TransferFence struct {
+	renderer *Renderer
+
+	mu      sync.Mutex
+	done    bool                  // All of the write was submitted
+	index   types.SubmissionIndex // Of the last part of the write
+	waiting []func()              // OnDone callbacks before submission
+}
+
+// Submitted reports whether all of the write has been submitted.
+func (f *TransferFence) Submitted() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.done
+}
+
+// Submission returns the index of the submission that completes the
+// write, or 0 until it is submitted.
+func (f *TransferFence) Submission() types.SubmissionIndex {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.index
+}
+
+// Done reports whether the write has completed on the GPU. It changes
+// during Poll, like Renderer.SubmissionDone.
+func (f *TransferFence) Done() bool {
+	f.mu.Lock()
+	done, index := f.done, f.index
+	f.mu.Unlock()
+	return done && f.renderer.SubmissionDone(index)
+}
+
+// OnDone calls fn once the write has completed on the GPU, like
+// Renderer.OnSubmissionDone.
+func (f *TransferFence) OnDone(fn func()) {
+	f.mu.Lock()
+	if !f.done {
+		f.waiting = append(f.waiting, fn)
+		f.mu.Unlock()
+		return
+	}
+	index := f.index
+	f.mu.Unlock()
+	f.renderer.OnSubmissionDone(index, fn)
+}
+
+// submitted records that the last part of the write went with
+// submission index.
+func (f *TransferFence) submitted(index types.SubmissionIndex) {
+	f.mu.Lock()
+	f.done, f.index = true, index
+	waiting := f.waiting
+	f.waiting = nil
+	f.mu.Unlock()
+
+	for _, fn := range waiting {
+		f.renderer.OnSubmissionDone(index, fn)
+	}
+}
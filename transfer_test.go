@@ -0,0 +1,43 @@
+package gogpu
+
+import (
+	"testing"
+)
+
+func TestTransferFence(t *testing.T) {
+	r := &Renderer{}
+	f := &TransferFence{renderer: r}
+
+	ran := false
+	f.OnDone(func() { ran = true })
+	if f.Submitted() || f.Done() || ran {
+		t.Fatal("fence done before submission")
+	}
+
+	r.submitted = 2
+	f.submitted(2)
+	if !f.Submitted() || f.Submission() != 2 {
+		t.Errorf("Submitted, Submission = %v, %d, want true, 2", f.Submitted(), f.Submission())
+	}
+
+	r.completeSubmission(1)
+	if f.Done() || ran {
+		t.Error("fence done before its submission completed")
+	}
+	r.completeSubmission(2)
+	if !f.Done() || !ran {
+		t.Errorf("Done, ran = %v, %v after completion, want true, true", f.Done(), ran)
+	}
+}
+
+func TestTransferBudget(t *testing.T) {
+	if budget, err := transferBudget(0); err != nil || budget != DefaultTransferBudget {
+		t.Errorf("transferBudget(0) = %d, %v, want %d, nil", budget, err, DefaultTransferBudget)
+	}
+	if budget, err := transferBudget(64); err != nil || budget != 64 {
+		t.Errorf("transferBudget(64) = %d, %v, want 64, nil", budget, err)
+	}
+	if _, err := transferBudget(6); err == nil {
+		t.Error("transferBudget(6) succeeded")
+	}
+}
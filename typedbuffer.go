@@ -0,0 +1,81 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/gpu"
+	"github.com/gogpu/gogpu/gpu/types"
+)
+
+// TypedBuffer is a buffer holding a Go value of type T, packed with
+// gpu.Pack in the memory layout shaders expect, so uniform data needs no
+// manual padding. T is a struct, array or gmath type, or for storage
+// buffers a slice of them.
+type TypedBuffer[T any] struct {
+	*Buffer
+	layout gpu.MemoryLayout
+
+	// Packed value, reused by Set
+	data []byte
+}
+
+// NewUniformBuffer creates a uniform buffer holding value in the std140
+// layout.
+func NewUniformBuffer[T any](r *Renderer, value T) (*TypedBuffer[T], error) {
+	return newTypedBuffer(r, value, gpu.LayoutStd140, types.BufferUsageUniform|types.BufferUsageCopyDst)
+}
+
+// NewStorageBuffer creates a storage buffer holding value in the std430
+// layout. usage is added to BufferUsageStorage and BufferUsageCopyDst,
+// e.g. BufferUsageVertex for vertices written by a compute shader. A
+// slice value sets the capacity; Set accepts slices up to that size.
+func NewStorageBuffer[T any](r *Renderer, value T, usage types.BufferUsage) (*TypedBuffer[T], error) {
+	return newTypedBuffer(r, value, gpu.LayoutStd430, usage|types.BufferUsageStorage|types.BufferUsageCopyDst)
+}
+
+// newTypedBuffer creates a buffer sized for value and uploads it.
+func newTypedBuffer[T any](r *Renderer, value T, layout gpu.MemoryLayout, usage types.BufferUsage) (*TypedBuffer[T], error) {
+	data, err := gpu.Pack(layout, value)
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("gogpu: typed buffer value is empty")
+	}
+
+	buffer, err := r.NewBuffer(uint64(len(data)), usage)
+	if err != nil {
+		return nil, err
+	}
+	buffer.Write(0, data)
+
+	return &TypedBuffer[T]{
+		Buffer: buffer,
+		layout: layout,
+		data:   data,
+	}, nil
+}
+
+// Layout returns the memory layout values are packed in.
+func (b *TypedBuffer[T]) Layout() gpu.MemoryLayout {
+	return b.layout
+}
+
+// Set packs value and uploads it to the start of the buffer through the
+// renderer's staging ring, so the upload lands before the next frame,
+// compute pass or copy is submitted.
+func (b *TypedBuffer[T]) Set(value T) error {
+	data, err := gpu.AppendPacked(b.data[:0], b.layout, value)
+	if err != nil {
+		return fmt.Errorf("gogpu: %w", err)
+	}
+	b.data = data
+	if uint64(len(data)) > b.Size() {
+		return fmt.Errorf("gogpu: value of %d bytes does not fit typed buffer of %d bytes", len(data), b.Size())
+	}
+	if ring, err := b.renderer.StagingRing(); err == nil && ring.WriteBuffer(b.Buffer, 0, data) == nil {
+		return nil
+	}
+	b.Write(0, data)
+	return nil
+}
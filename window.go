@@ -0,0 +1,231 @@
+package gogpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// Window is an additional top-level window opened at runtime with
+// App.NewWindow. The main window is created by Run and owned by the App.
+//
+// Each window has its own surface on the app's renderer. The main loop
+// draws the windows with an OnDraw callback after the main window, one
+// after the other, so resources of the Renderer can be used in all of
+// them.
+type Window struct {
+	app     *App
+	id      platform.WindowID
+	surface *surfaceState
+	closed  bool
+
+	// User callbacks
+	onDraw   func(*Context)
+	onResize func(int, int)
+	onClose  func()
+}
+
+// NewWindow opens an additional window while the app is running.
+// Only Title, Width, Height, Resizable and Fullscreen of config are used.
+// In the browser, windows are canvas elements of the page with the ids
+// "gogpu-canvas-2" and up, which the page may provide itself; the title
+// becomes the canvas's tooltip. Returns ErrNotInitialized before Run, and
+// ErrPlatformNotSupported if the platform cannot open more than one
+// window. While Config.RenderThread is in effect, additional windows are
+// not drawn.
+func (a *App) NewWindow(config Config) (*Window, error) {
+	if a.platform == nil || a.renderer == nil {
+		return nil, ErrNotInitialized
+	}
+
+	manager, ok := a.platform.(platform.WindowManager)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+
+	id, err := manager.CreateWindow(platform.Config{
+		Title:      config.Title,
+		Width:      config.Width,
+		Height:     config.Height,
+		Resizable:  config.Resizable,
+		Fullscreen: config.Fullscreen,
+	})
+	if err != nil {
+		return nil, platformError(err)
+	}
+
+	instance, handle := manager.WindowHandle(id)
+	width, height := manager.WindowSize(id)
+	surface, err := a.renderer.newWindowSurface(instance, handle, width, height)
+	if err != nil {
+		manager.DestroyWindow(id)
+		return nil, err
+	}
+
+	w := &Window{app: a, id: id, surface: surface}
+	if a.windows == nil {
+		a.windows = make(map[platform.WindowID]*Window)
+	}
+	a.windows[id] = w
+	return w, nil
+}
+
+// OnDraw sets the callback for rendering each frame of the window.
+// The Context draws into the window and is only valid during the
+// callback.
+func (w *Window) OnDraw(fn func(*Context)) *Window {
+	w.onDraw = fn
+	return w
+}
+
+// OnResize sets the callback for resizes of the window.
+func (w *Window) OnResize(fn func(width, height int)) *Window {
+	w.onResize = fn
+	return w
+}
+
+// OnClose sets the callback for the user closing the window. The window
+// is closed once it returns; Close does not call it.
+func (w *Window) OnClose(fn func()) *Window {
+	w.onClose = fn
+	return w
+}
+
+// Size returns the window size in pixels, or (0, 0) once closed.
+func (w *Window) Size() (width, height int) {
+	if w.closed {
+		return 0, 0
+	}
+	return w.manager().WindowSize(w.id)
+}
+
+// Closed reports whether the window was closed by Close or by the user.
+func (w *Window) Closed() bool {
+	return w.closed
+}
+
+// Close closes the window. Closing an already closed window is a no-op.
+func (w *Window) Close() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	w.app.renderer.releaseWindowSurface(w.surface)
+	w.surface = nil
+	w.manager().DestroyWindow(w.id)
+	delete(w.app.windows, w.id)
+}
+
+// resize applies a new window size to its surface and calls OnResize.
+func (w *Window) resize(width, height int) {
+	r := w.app.renderer
+	r.withSurface(w.surface, func() { r.Resize(width, height) })
+	if w.onResize != nil {
+		w.onResize(width, height)
+	}
+}
+
+// draw acquires a frame of the window, calls OnDraw and presents it.
+func (w *Window) draw() {
+	if w.closed || w.onDraw == nil {
+		return
+	}
+	r := w.app.renderer
+	r.withSurface(w.surface, func() {
+		if !r.BeginFrame() {
+			return
+		}
+		ctx := newContext(r)
+		ctx.alpha = w.app.fixed.alpha
+		if w.app.guard("Window.OnDraw", func() { w.onDraw(ctx) }) {
+			r.present()
+		}
+	})
+}
+
+// manager returns the platform window manager. NewWindow guarantees it exists.
+func (w *Window) manager() platform.WindowManager {
+	return w.app.platform.(platform.WindowManager)
+}
+
+// handleWindowEvent processes an event for an additional window.
+func (a *App) handleWindowEvent(event platform.Event) {
+	w, ok := a.windows[event.Window]
+	if !ok {
+		return
+	}
+
+	switch event.Type {
+	case platform.EventResize:
+		w.resize(event.Width, event.Height)
+	case platform.EventClose:
+		if w.onClose != nil {
+			w.onClose()
+		}
+		w.Close()
+	}
+}
+
+// drawWindows draws the additional windows. Each frame is presented
+// before the next window's begins, as backends hold one surface frame at
+// a time.
+func (a *App) drawWindows() {
+	if len(a.windows) == 0 || a.renderer.DeviceLost() {
+		return
+	}
+	for _, w := range a.windows {
+		w.draw()
+	}
+}
+
+// restoreWindows recreates the frame state of the additional windows for
+// the device that replaced a lost one.
+func (a *App) restoreWindows() {
+	r := a.renderer
+	for _, w := range a.windows {
+		r.withSurface(w.surface, func() {
+			r.releaseMSAATarget()
+			r.releaseFrame()
+			r.frame.surfaceConfigured = false
+			r.Resize(int(r.frame.width), int(r.frame.height))
+		})
+	}
+}
+
+// closeWindows closes all additional windows before the platform is destroyed.
+func (a *App) closeWindows() {
+	for _, w := range a.windows {
+		w.Close()
+	}
+}
+
+// newWindowSurface creates the surface of a window from its platform
+// handles and configures it like the main surface.
+func (r *Renderer) newWindowSurface(instance, window uintptr, width, height int) (*surfaceState, error) {
+	surface, err := r.backend.CreateSurface(r.instance, r.surfaceHandle(instance, window))
+	if err != nil {
+		return nil, fmt.Errorf("gogpu: failed to create surface: %w", err)
+	}
+	s := &surfaceState{surface: surface}
+	r.withSurface(s, func() { r.Resize(width, height) })
+	return s, nil
+}
+
+// releaseWindowSurface releases the surface of a window and its frame
+// state.
+func (r *Renderer) releaseWindowSurface(s *surfaceState) {
+	r.withSurface(s, func() {
+		r.releaseMSAATarget()
+		r.releaseFrame()
+	})
+	r.backend.ReleaseSurface(s.surface)
+	s.surface = 0
+}
+
+// withSurface calls fn with frames going to s instead of the main
+// surface.
+func (r *Renderer) withSurface(s *surfaceState, fn func()) {
+	r.frame = s
+	defer func() { r.frame = &r.mainFrame }()
+	fn()
+}
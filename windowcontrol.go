@@ -0,0 +1,166 @@
+package gogpu
+
+import (
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// SetTitle changes the title of the main window.
+func (a *App) SetTitle(title string) error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.SetWindowTitle(platform.MainWindow, title))
+}
+
+// SetSize resizes the content area of the main window, in the units of
+// Config.Width and Config.Height. The new size is reported to OnResize.
+func (a *App) SetSize(width, height int) error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.SetWindowSize(platform.MainWindow, width, height))
+}
+
+// SetPosition moves the top-left corner of the main window's frame to
+// (x, y) in the desktop coordinates of Monitor. Returns
+// ErrPlatformNotSupported on Wayland, where applications cannot place
+// their windows, and in the browser.
+func (a *App) SetPosition(x, y int) error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.SetWindowPosition(platform.MainWindow, x, y))
+}
+
+// Minimize minimizes (iconifies) the main window.
+func (a *App) Minimize() error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.MinimizeWindow(platform.MainWindow))
+}
+
+// Maximize maximizes the main window.
+func (a *App) Maximize() error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.MaximizeWindow(platform.MainWindow))
+}
+
+// Restore restores the main window from being minimized or maximized.
+// Wayland compositors only restore maximized windows this way; Focus
+// may bring back a minimized one.
+func (a *App) Restore() error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.RestoreWindow(platform.MainWindow))
+}
+
+// Focus brings the main window to the front and gives it keyboard focus.
+// Systems that prevent focus stealing may only highlight the window, as
+// with RequestAttention, unless the user interacted with the app lately.
+func (a *App) Focus() error {
+	c, err := a.windowController()
+	if err != nil {
+		return err
+	}
+	return platformError(c.FocusWindow(platform.MainWindow))
+}
+
+// windowController returns the platform WindowController implementation.
+func (a *App) windowController() (platform.WindowController, error) {
+	if a.platform == nil {
+		return nil, ErrNotInitialized
+	}
+	c, ok := a.platform.(platform.WindowController)
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	return c, nil
+}
+
+// SetTitle changes the title of the window. It does nothing once the
+// window is closed.
+func (w *Window) SetTitle(title string) error {
+	return w.control(func(c platform.WindowController) error {
+		return c.SetWindowTitle(w.id, title)
+	})
+}
+
+// SetSize resizes the content area of the window, see App.SetSize. It
+// does nothing once the window is closed.
+func (w *Window) SetSize(width, height int) error {
+	return w.control(func(c platform.WindowController) error {
+		return c.SetWindowSize(w.id, width, height)
+	})
+}
+
+// SetPosition moves the window, see App.SetPosition. It does nothing once
+// the window is closed.
+func (w *Window) SetPosition(x, y int) error {
+	return w.control(func(c platform.WindowController) error {
+		return c.SetWindowPosition(w.id, x, y)
+	})
+}
+
+// Minimize minimizes the window. It does nothing once the window is
+// closed.
+func (w *Window) Minimize() error {
+	return w.control(func(c platform.WindowController) error {
+		return c.MinimizeWindow(w.id)
+	})
+}
+
+// Maximize maximizes the window. It does nothing once the window is
+// closed.
+func (w *Window) Maximize() error {
+	return w.control(func(c platform.WindowController) error {
+		return c.MaximizeWindow(w.id)
+	})
+}
+
+// Restore restores the window from being minimized or maximized. It does
+// nothing once the window is closed.
+func (w *Window) Restore() error {
+	return w.control(func(c platform.WindowController) error {
+		return c.RestoreWindow(w.id)
+	})
+}
+
+// Focus brings the window to the front and gives it keyboard focus, see
+// App.Focus. It does nothing once the window is closed.
+func (w *Window) Focus() error {
+	return w.control(func(c platform.WindowController) error {
+		return c.FocusWindow(w.id)
+	})
+}
+
+// RequestAttention highlights the window while it is not active, e.g. by
+// flashing its taskbar button, until it is activated if critical is set.
+// It does nothing once the window is closed.
+func (w *Window) RequestAttention(critical bool) error {
+	return w.control(func(c platform.WindowController) error {
+		return c.RequestWindowAttention(w.id, critical)
+	})
+}
+
+// control calls fn with the platform WindowController unless the window
+// is closed.
+func (w *Window) control(fn func(c platform.WindowController) error) error {
+	c, ok := w.app.platform.(platform.WindowController)
+	if !ok {
+		return ErrPlatformNotSupported
+	}
+	if w.closed {
+		return nil
+	}
+	return platformError(fn(c))
+}
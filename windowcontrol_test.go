@@ -0,0 +1,116 @@
+package gogpu
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gogpu/gogpu/internal/platform"
+)
+
+// windowControlPlatform records the WindowController calls of an app.
+type windowControlPlatform struct {
+	platform.Platform
+	calls []string
+}
+
+func (p *windowControlPlatform) record(format string, args ...any) error {
+	p.calls = append(p.calls, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (p *windowControlPlatform) SetWindowTitle(id platform.WindowID, title string) error {
+	return p.record("title %d %s", id, title)
+}
+
+func (p *windowControlPlatform) SetWindowSize(id platform.WindowID, width, height int) error {
+	return p.record("size %d %dx%d", id, width, height)
+}
+
+func (p *windowControlPlatform) SetWindowPosition(id platform.WindowID, x, y int) error {
+	return platform.ErrUnsupported
+}
+
+func (p *windowControlPlatform) MinimizeWindow(id platform.WindowID) error {
+	return p.record("minimize %d", id)
+}
+
+func (p *windowControlPlatform) MaximizeWindow(id platform.WindowID) error {
+	return p.record("maximize %d", id)
+}
+
+func (p *windowControlPlatform) RestoreWindow(id platform.WindowID) error {
+	return p.record("restore %d", id)
+}
+
+func (p *windowControlPlatform) FocusWindow(id platform.WindowID) error {
+	return p.record("focus %d", id)
+}
+
+func (p *windowControlPlatform) RequestWindowAttention(id platform.WindowID, critical bool) error {
+	return p.record("attention %d %t", id, critical)
+}
+
+func TestWindowControlBeforeRun(t *testing.T) {
+	app := NewApp(DefaultConfig())
+	if err := app.SetTitle("title"); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("SetTitle before Run = %v, want ErrNotInitialized", err)
+	}
+	if err := app.Maximize(); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Maximize before Run = %v, want ErrNotInitialized", err)
+	}
+	if err := app.RequestAttention(false); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("RequestAttention before Run = %v, want ErrNotInitialized", err)
+	}
+}
+
+func TestWindowControl(t *testing.T) {
+	p := &windowControlPlatform{Platform: platform.NewHeadless()}
+	app := &App{platform: p}
+	w := &Window{app: app, id: 2}
+
+	for _, err := range []error{
+		app.SetTitle("main"),
+		app.SetSize(640, 480),
+		app.Minimize(),
+		app.Maximize(),
+		app.Restore(),
+		app.Focus(),
+		app.RequestAttention(true),
+		w.SetTitle("second"),
+		w.RequestAttention(false),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := app.SetPosition(10, 20); !errors.Is(err, ErrPlatformNotSupported) {
+		t.Errorf("SetPosition = %v, want ErrPlatformNotSupported", err)
+	}
+
+	w.closed = true
+	if err := w.Focus(); err != nil {
+		t.Errorf("Focus of a closed window = %v, want nil", err)
+	}
+
+	want := []string{
+		"title 0 main",
+		"size 0 640x480",
+		"minimize 0",
+		"maximize 0",
+		"restore 0",
+		"focus 0",
+		"attention 0 true",
+		"title 2 second",
+		"attention 2 false",
+	}
+	if !reflect.DeepEqual(p.calls, want) {
+		t.Errorf("calls = %q, want %q", p.calls, want)
+	}
+
+	app = &App{platform: platform.NewHeadless()}
+	if err := app.Focus(); !errors.Is(err, ErrPlatformNotSupported) {
+		t.Errorf("Focus without WindowController = %v, want ErrPlatformNotSupported", err)
+	}
+}
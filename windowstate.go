@@ -0,0 +1,70 @@
+package gogpu
+
+import "github.com/gogpu/gogpu/internal/platform"
+
+// WindowState describes a change of the main window's state.
+type WindowState = platform.WindowState
+
+// Window states.
+const (
+	WindowMinimized     = platform.WindowMinimized
+	WindowRestored      = platform.WindowRestored
+	WindowMaximized     = platform.WindowMaximized
+	WindowUnmaximized   = platform.WindowUnmaximized
+	WindowFocused       = platform.WindowFocused
+	WindowUnfocused     = platform.WindowUnfocused
+	WindowOccluded      = platform.WindowOccluded
+	WindowVisible       = platform.WindowVisible
+	WindowScreenChanged = platform.WindowScreenChanged
+
+	WindowEnteredFullscreen = platform.WindowEnteredFullscreen
+	WindowExitedFullscreen  = platform.WindowExitedFullscreen
+)
+
+// OnWindowState sets the callback for main window state changes such as
+// minimize, focus and occlusion. Rendering is paused automatically while
+// the window is minimized or occluded; OnUpdate keeps being called unless
+// Config.Background is BackgroundPause. On macOS and Windows a locked
+// session or a display turned off counts as occluded. OnFocusChanged,
+// OnMinimized, OnRestored and OnVisibilityChanged report the common
+// cases without a switch on the state.
+func (a *App) OnWindowState(fn func(WindowState)) *App {
+	a.onWindowState = fn
+	return a
+}
+
+// handleWindowState tracks the states that pause rendering and
+// calls the user callbacks.
+func (a *App) handleWindowState(state WindowState) {
+	visible := !a.hidden()
+	minimized := a.minimized
+	switch state {
+	case WindowMinimized:
+		a.minimized = true
+	case WindowRestored:
+		a.minimized = false
+	case WindowOccluded:
+		a.occluded = true
+	case WindowVisible:
+		a.occluded = false
+	}
+
+	if a.onWindowState != nil {
+		a.onWindowState(state)
+	}
+	switch {
+	case state == WindowFocused || state == WindowUnfocused:
+		if a.onFocusChanged != nil {
+			a.onFocusChanged(state == WindowFocused)
+		}
+	case a.minimized && !minimized:
+		if a.onMinimized != nil {
+			a.onMinimized()
+		}
+	case !a.minimized && minimized:
+		if a.onRestored != nil {
+			a.onRestored()
+		}
+	}
+	a.visibilityChanged(visible)
+}